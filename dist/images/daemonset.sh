@@ -1011,5 +1011,8 @@ cp ../templates/k8s.ovn.org_egressqoses.yaml.j2 ${output_dir}/k8s.ovn.org_egress
 cp ../templates/k8s.ovn.org_egressservices.yaml.j2 ${output_dir}/k8s.ovn.org_egressservices.yaml
 cp ../templates/k8s.ovn.org_adminpolicybasedexternalroutes.yaml.j2 ${output_dir}/k8s.ovn.org_adminpolicybasedexternalroutes.yaml
 cp ../templates/k8s.ovn.org_userdefinednetworks.yaml.j2 ${output_dir}/k8s.ovn.org_userdefinednetworks.yaml
+cp ../templates/k8s.ovn.org_serviceannouncements.yaml.j2 ${output_dir}/k8s.ovn.org_serviceannouncements.yaml
+cp ../templates/k8s.ovn.org_dpunodepairings.yaml.j2 ${output_dir}/k8s.ovn.org_dpunodepairings.yaml
+cp ../templates/k8s.ovn.org_physicalnetworks.yaml.j2 ${output_dir}/k8s.ovn.org_physicalnetworks.yaml
 
 exit 0
@@ -268,6 +268,10 @@ ovn_egressfirewall_enable=${OVN_EGRESSFIREWALL_ENABLE:-false}
 ovn_egressqos_enable=${OVN_EGRESSQOS_ENABLE:-false}
 #OVN_EGRESSSERVICE_ENABLE - enable egress Service for ovn-kubernetes
 ovn_egressservice_enable=${OVN_EGRESSSERVICE_ENABLE:-false}
+#OVN_SERVICEANNOUNCEMENT_ENABLE - enable Service announcement for ovn-kubernetes
+ovn_serviceannouncement_enable=${OVN_SERVICEANNOUNCEMENT_ENABLE:-false}
+#OVN_PHYSICALNETWORKS_ENABLE - enable the ovn-bridge-mappings reconciliation from PhysicalNetworks
+ovn_physicalnetworks_enable=${OVN_PHYSICALNETWORKS_ENABLE:-false}
 #OVN_DISABLE_OVN_IFACE_ID_VER - disable usage of the OVN iface-id-ver option
 ovn_disable_ovn_iface_id_ver=${OVN_DISABLE_OVN_IFACE_ID_VER:-false}
 #OVN_MULTI_NETWORK_ENABLE - enable multiple network support for ovn-kubernetes
@@ -1804,6 +1808,12 @@ ovnkube-controller-with-node() {
   fi
   echo "egressservice_enabled_flag=${egressservice_enabled_flag}"
 
+  serviceannouncement_enabled_flag=
+  if [[ ${ovn_serviceannouncement_enable} == "true" ]]; then
+	  serviceannouncement_enabled_flag="--enable-service-announcement"
+  fi
+  echo "serviceannouncement_enabled_flag=${serviceannouncement_enabled_flag}"
+
   disable_ovn_iface_id_ver_flag=
   if [[ ${ovn_disable_ovn_iface_id_ver} == "true" ]]; then
       disable_ovn_iface_id_ver_flag="--disable-ovn-iface-id-ver"
@@ -2028,6 +2038,7 @@ ovnkube-controller-with-node() {
     ${egressip_healthcheck_port_flag} \
     ${egressqos_enabled_flag} \
     ${egressservice_enabled_flag} \
+    ${serviceannouncement_enabled_flag} \
     ${empty_lb_events_flag} \
     ${enable_lflow_cache} \
     ${hybrid_overlay_flags} \
@@ -2419,6 +2430,16 @@ ovn-node() {
 	  egressservice_enabled_flag="--enable-egress-service"
   fi
 
+  serviceannouncement_enabled_flag=
+  if [[ ${ovn_serviceannouncement_enable} == "true" ]]; then
+	  serviceannouncement_enabled_flag="--enable-service-announcement"
+  fi
+
+  physicalnetworks_enabled_flag=
+  if [[ ${ovn_physicalnetworks_enable} == "true" ]]; then
+	  physicalnetworks_enabled_flag="--enable-physical-networks"
+  fi
+
   disable_ovn_iface_id_ver_flag=
   if [[ ${ovn_disable_ovn_iface_id_ver} == "true" ]]; then
       disable_ovn_iface_id_ver_flag="--disable-ovn-iface-id-ver"
@@ -2622,6 +2643,8 @@ ovn-node() {
         ${egressip_enabled_flag} \
         ${egressip_healthcheck_port_flag} \
         ${egressservice_enabled_flag} \
+        ${serviceannouncement_enabled_flag} \
+        ${physicalnetworks_enabled_flag} \
         ${enable_lflow_cache} \
         ${hybrid_overlay_flags} \
         ${ipfix_config} \
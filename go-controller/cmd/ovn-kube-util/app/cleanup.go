@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+	"github.com/urfave/cli/v2"
+	kexec "k8s.io/utils/exec"
+)
+
+// CleanupCommand removes the node rules of disabled features while ovnkube-node is not running
+var CleanupCommand = cli.Command{
+	Name:  "cleanup",
+	Usage: "Remove the iptables rules, ip rules, routes and OVS bridges of features from the node",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "feature",
+			Usage:    "Feature to clean up, one of: " + strings.Join(node.CleanupFeatureNames(), ", "),
+			Required: true,
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		if ctx.Args().Len() > 0 {
+			return fmt.Errorf("unexpected arguments %v", ctx.Args().Slice())
+		}
+		if err := util.SetExec(kexec.New()); err != nil {
+			return err
+		}
+
+		var errorList []error
+		for _, feature := range ctx.StringSlice("feature") {
+			if err := node.CleanupFeature(feature); err != nil {
+				errorList = append(errorList, err)
+			}
+		}
+		return utilerrors.Join(errorList...)
+	},
+}
@@ -29,6 +29,7 @@ func main() {
 		&app.BridgesToNicCommand,
 		&app.ReadinessProbeCommand,
 		&app.OvsExporterCommand,
+		&app.CleanupCommand,
 	}
 
 	c.Before = func(ctx *cli.Context) error {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovnnode "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+
+	kexec "k8s.io/utils/exec"
+)
+
+// gatewayInterfaceCommand reports how the gateway interface is autodetected from the default routes of the node
+// with the configuration given by the global options, without changing the node, and prints it in JSON
+var gatewayInterfaceCommand = &cli.Command{
+	Name:  "gateway-interface",
+	Usage: "report the gateway interface ovnkube-node autodetects from the default routes in JSON, without changing the node",
+	Action: func(ctx *cli.Context) error {
+		if _, err := config.InitConfig(ctx, kexec.New(), nil); err != nil {
+			return err
+		}
+		selections, err := ovnnode.ReportGatewayInterfaceSelection()
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(selections)
+	},
+}
@@ -42,7 +42,7 @@ const (
    {{.Name}} - {{.Usage}}
 
 USAGE:
-   {{.HelpName}} [global options]
+   {{.HelpName}} [global options] [command]
 
 VERSION:
    {{.Version}}{{if .Description}}
@@ -105,6 +105,7 @@ func main() {
 	c.Version = config.Version
 	c.CustomAppHelpTemplate = CustomAppHelpTemplate
 	c.Flags = config.GetFlags(nil)
+	c.Commands = []*cli.Command{preflightCommand, gatewayInterfaceCommand}
 
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -183,6 +184,9 @@ type ovnkubeRunMode struct {
 	clusterManager    bool // cluster manager (--init-cluster-manager or --init-master) is enabled
 	node              bool // node (--init-node) is enabled
 	cleanupNode       bool // cleanup (--cleanup-node) is enabled
+	// standalone (--init-standalone) enables the ovnkube controller and the node for the single-node
+	// zone of the node
+	standalone bool
 
 	// Along with the run mode, an identity is provided that uniquely identifies
 	// this instance vs other instances that might be running in the cluster.
@@ -197,6 +201,7 @@ type ovnkubeRunMode struct {
 //   - master (ovnkube controller + cluster manager) + node
 //   - ovnkube controller + cluster manager
 //   - ovnkube controller + node
+//   - standalone (ovnkube controller + node of a single-node zone)
 func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 	mode := &ovnkubeRunMode{}
 
@@ -205,6 +210,7 @@ func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 	ovnkController := ctx.String("init-ovnkube-controller")
 	node := ctx.String("init-node")
 	cleanup := ctx.String("cleanup-node")
+	standalone := ctx.String("init-standalone")
 
 	if master != "" {
 		// If init-master is set, then both ovnkube controller and cluster manager
@@ -229,6 +235,19 @@ func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 		mode.cleanupNode = true
 	}
 
+	if standalone != "" {
+		// the standalone mode already runs the ovnkube controller and the node of the zone of the node, the
+		// other modes contradict it
+		if master != "" || cm != "" || ovnkController != "" || node != "" || cleanup != "" {
+			return nil, fmt.Errorf("cannot run standalone mode along with any other mode")
+		}
+		// the node runs the ovnkube controller of its own zone, removing the need for a separate
+		// ovnkube controller on resource constrained edge nodes
+		mode.standalone = true
+		mode.ovnkubeController = true
+		mode.node = true
+	}
+
 	if mode.cleanupNode && (mode.clusterManager || mode.ovnkubeController || mode.node) {
 		return nil, fmt.Errorf("cannot run cleanup-node mode along with any other mode")
 	}
@@ -241,7 +260,7 @@ func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 		return nil, fmt.Errorf("cannot run in both cluster manager and node mode")
 	}
 
-	identities := sets.NewString(master, cm, ovnkController, node, cleanup)
+	identities := sets.NewString(master, cm, ovnkController, node, cleanup, standalone)
 	identities.Delete("")
 	if identities.Len() != 1 {
 		return nil, fmt.Errorf("provided no identity or different identities for different modes")
@@ -249,9 +268,46 @@ func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 
 	mode.identity, _ = identities.PopAny()
 
+	if mode.standalone {
+		if err := configureStandaloneZone(mode.identity); err != nil {
+			return nil, err
+		}
+	}
+
 	return mode, nil
 }
 
+// tracingServiceName returns the service name the traces of the run mode are exported under
+func tracingServiceName(mode *ovnkubeRunMode) string {
+	var components []string
+	if mode.clusterManager {
+		components = append(components, "cluster-manager")
+	}
+	if mode.ovnkubeController {
+		components = append(components, "controller")
+	}
+	if mode.node {
+		components = append(components, "node")
+	}
+	if len(components) == 0 {
+		return "ovnkube"
+	}
+	return "ovnkube-" + strings.Join(components, "-")
+}
+
+// configureStandaloneZone checks that the configuration runs the node in its own interconnect zone, the
+// zone is named after the node when it is not configured
+func configureStandaloneZone(nodeName string) error {
+	if !config.OVNKubernetesFeature.EnableInterconnect {
+		return fmt.Errorf("standalone mode requires interconnect to be enabled")
+	}
+	if config.Default.Zone == types.OvnDefaultZone {
+		config.Default.Zone = nodeName
+	}
+	klog.Infof("Running ovnkube standalone for node %s in zone %s", nodeName, config.Default.Zone)
+	return nil
+}
+
 func startOvnKube(ctx *cli.Context, cancel context.CancelFunc) error {
 	pidfile := ctx.String("pidfile")
 	if pidfile != "" {
@@ -293,13 +349,26 @@ func startOvnKube(ctx *cli.Context, cancel context.CancelFunc) error {
 		return err
 	}
 
-	eventRecorder := util.EventRecorder(ovnClientset.KubeClient)
+	var writeThrottle *util.APIWriteThrottle
+	if runMode.node {
+		// spread the events of the nodes restarted at once like their other API writes
+		writeThrottle = util.NodeAPIWriteThrottle(runMode.identity)
+	}
+	eventRecorder := util.EventRecorderWithThrottle(ovnClientset.KubeClient, writeThrottle)
 
 	// Start metric server for master and node. Expose the metrics HTTP endpoint if configured.
 	// Non LE master instances also are required to expose the metrics server.
 	if config.Metrics.BindAddress != "" {
 		metrics.StartMetricsServer(config.Metrics.BindAddress, config.Metrics.EnablePprof,
-			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, ctx.Done(), ovnKubeStartWg)
+			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, config.Metrics.LogLevelTokenFile,
+			ctx.Done(), ovnKubeStartWg)
+	}
+
+	if config.Metrics.TracingEndpoint != "" {
+		if err := metrics.StartTracing(config.Metrics.TracingEndpoint, tracingServiceName(runMode), runMode.identity,
+			config.Metrics.TracingSamplingRatePerMillion, ctx.Done(), ovnKubeStartWg); err != nil {
+			return err
+		}
 	}
 
 	// no need for leader election in node mode
@@ -528,6 +597,13 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				return
 			}
 
+			if config.OvnKubeNode.NetNS != "" {
+				if err := util.SetNodeNetNS(config.OvnKubeNode.NetNS); err != nil {
+					nodeErr = fmt.Errorf("failed to configure the node network namespace: %w", err)
+					return
+				}
+			}
+
 			// register ovnkube node specific prometheus metrics exported by the node
 			metrics.RegisterNodeMetrics(ctx.Done())
 
@@ -564,7 +640,10 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 		if config.Metrics.ExportOVSMetrics {
 			metrics.RegisterOvsMetricsWithOvnMetrics(ctx.Done())
 		}
-		metrics.RegisterOvnMetrics(ovnClientset.KubeClient, runMode.identity, ctx.Done())
+		// without local OVN the ovn-controller metrics are exported where ovn-controller runs
+		if !config.OvnKubeNode.NoLocalOVN {
+			metrics.RegisterOvnMetrics(ovnClientset.KubeClient, runMode.identity, ctx.Done())
+		}
 		metrics.StartOVNMetricsServer(config.Metrics.OVNMetricsBindAddress,
 			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, ctx.Done(), wg)
 	}
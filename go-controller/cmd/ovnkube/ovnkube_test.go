@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+func TestDetermineOvnkubeRunModeStandalone(t *testing.T) {
+	testCases := []struct {
+		name         string
+		flags        map[string]string
+		interconnect bool
+		zone         string
+		expectedMode *ovnkubeRunMode
+		expectedZone string
+		expectedErr  string
+	}{
+		{
+			name:         "standalone runs the ovnkube controller and the node in the zone of the node",
+			flags:        map[string]string{"init-standalone": "node1"},
+			interconnect: true,
+			expectedMode: &ovnkubeRunMode{ovnkubeController: true, node: true, standalone: true, identity: "node1"},
+			expectedZone: "node1",
+		},
+		{
+			name:         "standalone keeps the configured zone",
+			flags:        map[string]string{"init-standalone": "node1"},
+			interconnect: true,
+			zone:         "edge",
+			expectedMode: &ovnkubeRunMode{ovnkubeController: true, node: true, standalone: true, identity: "node1"},
+			expectedZone: "edge",
+		},
+		{
+			name:        "standalone requires interconnect",
+			flags:       map[string]string{"init-standalone": "node1"},
+			expectedErr: "standalone mode requires interconnect to be enabled",
+		},
+		{
+			name:         "standalone conflicts with master",
+			flags:        map[string]string{"init-standalone": "node1", "init-master": "node1"},
+			interconnect: true,
+			expectedErr:  "cannot run standalone mode along with any other mode",
+		},
+		{
+			name:         "standalone conflicts with cluster manager",
+			flags:        map[string]string{"init-standalone": "node1", "init-cluster-manager": "node1"},
+			interconnect: true,
+			expectedErr:  "cannot run standalone mode along with any other mode",
+		},
+		{
+			name:         "standalone conflicts with ovnkube controller",
+			flags:        map[string]string{"init-standalone": "node1", "init-ovnkube-controller": "node1"},
+			interconnect: true,
+			expectedErr:  "cannot run standalone mode along with any other mode",
+		},
+		{
+			name:         "standalone conflicts with node",
+			flags:        map[string]string{"init-standalone": "node1", "init-node": "node1"},
+			interconnect: true,
+			expectedErr:  "cannot run standalone mode along with any other mode",
+		},
+		{
+			name:         "standalone conflicts with cleanup node",
+			flags:        map[string]string{"init-standalone": "node1", "cleanup-node": "node1"},
+			interconnect: true,
+			expectedErr:  "cannot run standalone mode along with any other mode",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := config.PrepareTestConfig(); err != nil {
+				t.Fatalf("failed to prepare the test config: %v", err)
+			}
+			config.OVNKubernetesFeature.EnableInterconnect = tc.interconnect
+			if tc.zone != "" {
+				config.Default.Zone = tc.zone
+			}
+
+			set := flag.NewFlagSet("ovnkube", flag.ContinueOnError)
+			for _, name := range []string{"init-master", "init-cluster-manager", "init-ovnkube-controller",
+				"init-node", "cleanup-node", "init-standalone"} {
+				set.String(name, "", "")
+			}
+			for name, value := range tc.flags {
+				if err := set.Set(name, value); err != nil {
+					t.Fatalf("failed to set flag %s: %v", name, err)
+				}
+			}
+
+			mode, err := determineOvnkubeRunMode(cli.NewContext(cli.NewApp(), set, nil))
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(mode, tc.expectedMode) {
+				t.Fatalf("expected mode %+v, got %+v", tc.expectedMode, mode)
+			}
+			if config.Default.Zone != tc.expectedZone {
+				t.Fatalf("expected zone %q, got %q", tc.expectedZone, config.Default.Zone)
+			}
+		})
+	}
+}
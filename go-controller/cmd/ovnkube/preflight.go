@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovnnode "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kexec "k8s.io/utils/exec"
+)
+
+// preflightCommand validates the host prerequisites of ovnkube-node with the configuration given by the
+// global options, and prints the results of the checks in JSON
+var preflightCommand = &cli.Command{
+	Name:  "preflight",
+	Usage: "validate the host prerequisites of ovnkube-node and report them in JSON",
+	Action: func(ctx *cli.Context) error {
+		exec := kexec.New()
+		if _, err := config.InitConfig(ctx, exec, nil); err != nil {
+			return err
+		}
+		if err := util.SetExec(exec); err != nil {
+			return fmt.Errorf("failed to initialize exec helper: %v", err)
+		}
+
+		report := ovnnode.RunPreflightChecks()
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		if !report.Passed {
+			return cli.Exit("", 1)
+		}
+		return nil
+	},
+}
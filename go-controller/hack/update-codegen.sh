@@ -117,3 +117,9 @@ echo "Copying IPAMClaim CRD"
 curl -sSL https://raw.githubusercontent.com/k8snetworkplumbingwg/ipamclaims/v0.4.0-alpha/artifacts/k8s.cni.cncf.io_ipamclaims.yaml -o ../dist/templates/k8s.cni.cncf.io_ipamclaims.yaml
 echo "Copying userdefinednetworks CRD"
 cp _output/crds/k8s.ovn.org_userdefinednetworks.yaml ../dist/templates/k8s.ovn.org_userdefinednetworks.yaml.j2
+echo "Copying serviceAnnouncements CRD"
+cp _output/crds/k8s.ovn.org_serviceannouncements.yaml ../dist/templates/k8s.ovn.org_serviceannouncements.yaml.j2
+echo "Copying dpuNodePairings CRD"
+cp _output/crds/k8s.ovn.org_dpunodepairings.yaml ../dist/templates/k8s.ovn.org_dpunodepairings.yaml.j2
+echo "Copying physicalNetworks CRD"
+cp _output/crds/k8s.ovn.org_physicalnetworks.yaml ../dist/templates/k8s.ovn.org_physicalnetworks.yaml.j2
@@ -44,9 +44,7 @@ func (h *egressIPClusterControllerEventHandler) AddResource(obj interface{}, fro
 		if err := h.eIPC.initEgressIPAllocator(node); err != nil {
 			klog.Warningf("Egress node initialization error: %v", err)
 		}
-		nodeEgressLabel := util.GetNodeEgressLabel()
-		nodeLabels := node.GetLabels()
-		_, hasEgressLabel := nodeLabels[nodeEgressLabel]
+		hasEgressLabel := isEgressAssignableNode(node)
 		if hasEgressLabel {
 			h.eIPC.setNodeEgressAssignable(node.Name, true)
 		}
@@ -99,11 +97,8 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 		if err := h.eIPC.initEgressIPAllocator(newNode); err != nil {
 			klog.Warningf("Egress node initialization error: %v", err)
 		}
-		nodeEgressLabel := util.GetNodeEgressLabel()
-		oldLabels := oldNode.GetLabels()
-		newLabels := newNode.GetLabels()
-		_, oldHadEgressLabel := oldLabels[nodeEgressLabel]
-		_, newHasEgressLabel := newLabels[nodeEgressLabel]
+		oldHadEgressLabel := isEgressAssignableNode(oldNode)
+		newHasEgressLabel := isEgressAssignableNode(newNode)
 		// If the node is not labeled for egress assignment, just return
 		// directly, we don't really need to set the ready / reachable
 		// status on this node if the user doesn't care about using it.
@@ -178,9 +173,7 @@ func (h *egressIPClusterControllerEventHandler) DeleteResource(obj, cachedObj in
 			return nil
 		}
 		h.eIPC.deleteNodeForEgress(node)
-		nodeEgressLabel := util.GetNodeEgressLabel()
-		nodeLabels := node.GetLabels()
-		_, hasEgressLabel := nodeLabels[nodeEgressLabel]
+		hasEgressLabel := isEgressAssignableNode(node)
 		if hasEgressLabel {
 			if err := h.eIPC.deleteEgressNode(node.Name); err != nil {
 				return err
@@ -220,6 +213,19 @@ func (h *egressIPClusterControllerEventHandler) SyncFunc(objs []interface{}) err
 	return syncFunc(objs)
 }
 
+// isEgressAssignableNode returns whether the node is labeled for egress IP assignment and does not refuse it
+// as a non-egress node
+func isEgressAssignableNode(node *v1.Node) bool {
+	if _, hasEgressLabel := node.Labels[util.GetNodeEgressLabel()]; !hasEgressLabel {
+		return false
+	}
+	if util.NodeRefusesEgress(node) {
+		klog.V(5).Infof("Node %s is labeled for egress IP assignment but is a non-egress node", node.Name)
+		return false
+	}
+	return true
+}
+
 // getResourceFromInformerCache returns the latest state of the object from the informers cache
 // given an object key and its type
 func (h *egressIPClusterControllerEventHandler) GetResourceFromInformerCache(key string) (interface{}, error) {
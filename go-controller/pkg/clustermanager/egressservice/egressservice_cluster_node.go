@@ -343,8 +343,11 @@ func (c *Controller) cachedNodesFor(selector labels.Selector) (sets.Set[string],
 	return names, states
 }
 
-// Returns if the given node is in "Ready" state.
+// Returns if the given node is in "Ready" state and is not a non-egress node.
 func nodeIsReady(n *corev1.Node) bool {
+	if util.NodeRefusesEgress(n) {
+		return false
+	}
 	for _, condition := range n.Status.Conditions {
 		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
 			return true
@@ -32,7 +32,14 @@ func (m *apbRouteManager) get(namespace, name string) (*adminpolicybasedrouteapi
 
 //lint:ignore U1000 generic interfaces throw false-positives
 func (m *apbRouteManager) getMessages(route *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute) []string {
-	return route.Status.Messages
+	// the BFD session status messages of the nodes are informational, they are not zone statuses
+	var messages []string
+	for _, message := range route.Status.Messages {
+		if !types.IsNodeBFDStatus(message) {
+			messages = append(messages, message)
+		}
+	}
+	return messages
 }
 
 //lint:ignore U1000 generic interfaces throw false-positives
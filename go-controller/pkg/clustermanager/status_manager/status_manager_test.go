@@ -316,6 +316,23 @@ var _ = Describe("Cluster Manager Status Manager", func() {
 		checkAPBRouteStatusEventually(apbRoute, false, false, fakeClient)
 	})
 
+	It("ignores the BFD statuses of the nodes in APBRoute status", func() {
+		config.OVNKubernetesFeature.EnableMultiExternalGateway = true
+		zones := sets.New[string]("zone1")
+		apbRoute := newAPBRoute(apbrouteName)
+		start(zones, apbRoute)
+
+		updateAPBRouteStatus(apbRoute, &adminpolicybasedrouteapi.AdminPolicyBasedRouteStatus{
+			Messages: []string{
+				types.GetNodeBFDStatus("node1", "BFD sessions down: 172.18.0.5"),
+				types.GetNodeBFDStatus("node2", "BFD sessions up: 172.18.0.5"),
+				types.GetZoneStatus("zone1", "OK"),
+			},
+		}, fakeClient)
+
+		checkAPBRouteStatusEventually(apbRoute, false, false, fakeClient)
+	})
+
 	It("updates APBRoute status with 2 zones", func() {
 		config.OVNKubernetesFeature.EnableMultiExternalGateway = true
 		zones := sets.New[string]("zone1", "zone2")
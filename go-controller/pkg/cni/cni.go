@@ -19,6 +19,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -145,13 +146,23 @@ func (pr *PodRequest) cmdAddWithGetCNIResultFunc(kubeAuth *KubeAPIAuth, clientse
 	if util.IsNetworkSegmentationSupportEnabled() {
 		annotCondFn = primaryUDN.WaitForPrimaryAnnotationFn(namespace, annotCondFn)
 	}
-	pod, annotations, podNADAnnotation, err := GetPodWithAnnotations(pr.ctx, clientset, namespace, podName, pr.nadName, annotCondFn)
+	ctx, span := metrics.StartSpan(pr.ctx, "GetPodWithAnnotations")
+	start := time.Now()
+	pod, annotations, podNADAnnotation, err := GetPodWithAnnotations(ctx, clientset, namespace, podName, pr.nadName, annotCondFn)
+	metrics.EndSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod annotation: %v", err)
 	}
+	metrics.ObserveWithTraceExemplar(pr.ctx, metrics.MetricCNIPodSetupDuration.WithLabelValues(
+		metrics.PodSetupStageAnnotationWait, pr.netName), time.Since(start).Seconds())
 	if err = pr.checkOrUpdatePodUID(pod); err != nil {
 		return nil, err
 	}
+	if pr.netName == types.DefaultNetworkName {
+		if err = clientset.validateDefaultNetworkStaticRequest(pod, podNADAnnotation); err != nil {
+			return nil, err
+		}
+	}
 
 	podInterfaceInfo, err := pr.buildPodInterfaceInfo(annotations, podNADAnnotation, netdevName)
 	if err != nil {
@@ -377,12 +388,12 @@ func (pr *PodRequest) buildPrimaryUDNPodRequest(
 		nadName:    primaryUDN.NADName(),
 		deviceInfo: v1.DeviceInfo{},
 	}
-	req.ctx, req.cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	req.ctx, req.cancel = context.WithTimeout(context.Background(), config.CNI.RequestTimeout)
 	return req
 }
 
 func (pr *PodRequest) buildPodInterfaceInfo(annotations map[string]string, podAnnotation *util.PodAnnotation, netDevice string) (*PodInterfaceInfo, error) {
-	return PodAnnotation2PodInfo(
+	podInterfaceInfo, err := PodAnnotation2PodInfo(
 		annotations,
 		podAnnotation,
 		pr.PodUID,
@@ -391,4 +402,10 @@ func (pr *PodRequest) buildPodInterfaceInfo(annotations map[string]string, podAn
 		pr.netName,
 		pr.CNIConf.MTU,
 	)
+	if err != nil {
+		return nil, err
+	}
+	// the pods on localnet networks are reached through the switches of the physical network
+	podInterfaceInfo.AnnounceIPs = config.OvnKubeNode.AnnounceLocalnetPodIPs && pr.CNIConf.Topology == types.LocalnetTopology
+	return podInterfaceInfo, nil
 }
@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
@@ -18,6 +22,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
@@ -59,8 +64,9 @@ func NewCNIServer(factory factory.NodeWatchFactory, kclient kubernetes.Interface
 			Handler: router,
 		},
 		clientSet: &ClientSet{
-			podLister: corev1listers.NewPodLister(factory.LocalPodInformer().GetIndexer()),
-			kclient:   kclient,
+			podLister:  corev1listers.NewPodLister(factory.LocalPodInformer().GetIndexer()),
+			nodeLister: factory.NodeCoreInformer().Lister(),
+			kclient:    kclient,
 		},
 		kubeAuth: &KubeAPIAuth{
 			Kubeconfig:       config.Kubernetes.Kubeconfig,
@@ -69,6 +75,7 @@ func NewCNIServer(factory factory.NodeWatchFactory, kclient kubernetes.Interface
 			KubeAPITokenFile: config.Kubernetes.TokenFile,
 		},
 		handlePodRequestFunc: HandlePodRequest,
+		podLocks:             syncmap.NewSyncMap[struct{}](),
 	}
 
 	if util.IsNetworkSegmentationSupportEnabled() {
@@ -199,8 +206,9 @@ func cniRequestToPodRequest(cr *Request) (*PodRequest, error) {
 	req.CNIConf = conf
 	req.deviceInfo = cr.DeviceInfo
 	req.timestamp = time.Now()
-	// Match the Kubelet default CRI operation timeout of 2m
-	req.ctx, req.cancel = context.WithTimeout(context.Background(), 2*time.Minute)
+	// Defaults to the Kubelet CRI operation timeout of 2m; a shorter budget fails the
+	// request with an error naming the phase it timed out in before the Kubelet gives up
+	req.ctx, req.cancel = context.WithTimeout(context.Background(), config.CNI.RequestTimeout)
 	return req, nil
 }
 
@@ -216,9 +224,36 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer req.cancel()
+	defer func() {
+		req.cancel()
+	}()
 
-	result, err := s.handlePodRequestFunc(req, s.clientSet, s.kubeAuth)
+	s.quiesceLock.RLock()
+	defer s.quiesceLock.RUnlock()
+	if s.quiesced {
+		return nil, fmt.Errorf("%s rejected, ovnkube-node is quiesced for an OVS upgrade", req)
+	}
+
+	// the runtime may send the DEL of a previous sandbox of the pod while the ADD of
+	// the next one is in progress, for example when a StatefulSet pod is recreated
+	// with the same name, so the requests of a pod are handled one at a time
+	podKey := req.PodNamespace + "/" + req.PodName
+	if err := s.lockPod(req.ctx, podKey); err != nil {
+		return nil, fmt.Errorf("%s %v", req, err)
+	}
+	defer s.podLocks.UnlockKey(podKey)
+
+	var span trace.Span
+	req.ctx, span = metrics.StartSpan(req.ctx, "CNI "+string(req.Command),
+		semconv.K8SNamespaceName(req.PodNamespace),
+		semconv.K8SPodName(req.PodName),
+		semconv.ContainerID(req.SandboxID),
+		attribute.String("ovn.network", req.netName),
+	)
+	result, err := s.handlePodRequestFunc(req, s.clientSet, s.currentKubeAuth())
+	// the UID of the pod is only known once it is retrieved when the runtime doesn't pass it
+	span.SetAttributes(semconv.K8SPodUID(req.PodUID))
+	metrics.EndSpan(span, err)
 	if err != nil {
 		// Prefix error with request information for easier debugging
 		return nil, fmt.Errorf("%s %v", req, err)
@@ -226,6 +261,67 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 	return result, nil
 }
 
+// lockPod takes the lock of the requests of the pod, the wait for the previous requests of the pod counts
+// in the time budget of the request so that it fails naming the wait once the budget ran out
+func (s *Server) lockPod(ctx context.Context, podKey string) error {
+	start := time.Now()
+	locked := make(chan struct{})
+	go func() {
+		s.podLocks.LockKey(podKey)
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		return nil
+	case <-ctx.Done():
+		// the request gave up on the lock, release it once taken
+		go func() {
+			<-locked
+			s.podLocks.UnlockKey(podKey)
+		}()
+		return waitError(ctx, "the previous requests of pod "+podKey, start)
+	}
+}
+
+// Quiesce rejects the pod requests until Resume is called, the runtime retries them. It returns once the
+// pod requests being handled are done.
+func (s *Server) Quiesce() {
+	s.quiesceLock.Lock()
+	defer s.quiesceLock.Unlock()
+	s.quiesced = true
+}
+
+// Resume handles the pod requests again after Quiesce
+func (s *Server) Resume() {
+	s.quiesceLock.Lock()
+	defer s.quiesceLock.Unlock()
+	s.quiesced = false
+}
+
+// currentKubeAuth returns the Kube API authentication to hand to the CNI shim with the current token of
+// the token file. The shim can't read the token file of the ovnkube-node container, so the token is
+// re-read for every request: bound service account tokens are rotated by the kubelet and the token
+// read at startup expires in clusters with short token lifetimes. The last token read is kept for the
+// requests the token file can't be read for.
+func (s *Server) currentKubeAuth() *KubeAPIAuth {
+	s.kubeAuthLock.Lock()
+	defer s.kubeAuthLock.Unlock()
+	if s.kubeAuth.KubeAPITokenFile != "" {
+		token, err := os.ReadFile(s.kubeAuth.KubeAPITokenFile)
+		if err == nil && len(strings.TrimSpace(string(token))) == 0 {
+			err = fmt.Errorf("empty token")
+		}
+		if err != nil {
+			klog.Warningf("Failed to read the Kube API token file %s, using the last token read: %v",
+				s.kubeAuth.KubeAPITokenFile, err)
+		} else {
+			s.kubeAuth.KubeAPIToken = strings.TrimSpace(string(token))
+		}
+	}
+	kubeAuth := *s.kubeAuth
+	return &kubeAuth
+}
+
 func (s *Server) handleCNIMetrics(w http.ResponseWriter, r *http.Request) {
 	var cm CNIRequestMetrics
 
@@ -4,8 +4,10 @@
 package cni
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -73,11 +75,37 @@ func (s *Server) Start(rundir string) error {
 		return fmt.Errorf("failed to set pod info socket mode: %v", err)
 	}
 
+	s.socketPath = socketPath
+	s.stopChan = make(chan struct{})
 	s.SetKeepAlivesEnabled(false)
-	go utilwait.Forever(func() {
-		if err := s.Serve(l); err != nil {
+	go utilwait.Until(func() {
+		if err := s.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			utilruntime.HandleError(fmt.Errorf("CNI server Serve() failed: %v", err))
 		}
-	}, 0)
+	}, 0, s.stopChan)
+	return nil
+}
+
+// Stop closes the socket of a started Server and removes it, the CNI requests being served are
+// interrupted.
+func (s *Server) Stop() error {
+	if s.stopChan == nil {
+		return nil
+	}
+	close(s.stopChan)
+	if err := s.Close(); err != nil {
+		return fmt.Errorf("failed to close pod info socket %s: %v", s.socketPath, err)
+	}
+	if err := os.Remove(s.socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove pod info socket %s: %v", s.socketPath, err)
+	}
+	return nil
+}
+
+// CheckSocket returns an error if the socket of the started Server was removed
+func (s *Server) CheckSocket() error {
+	if _, err := os.Stat(s.socketPath); err != nil {
+		return fmt.Errorf("pod info socket %s is not available: %v", s.socketPath, err)
+	}
 	return nil
 }
@@ -10,11 +10,15 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 	cni020 "github.com/containernetworking/cni/pkg/types/020"
@@ -22,7 +26,9 @@ import (
 	utiltesting "k8s.io/client-go/util/testing"
 
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -273,4 +279,235 @@ func TestCNIServer(t *testing.T) {
 			}
 		}
 	}
+
+	if err := s.CheckSocket(); err != nil {
+		t.Fatalf("expected the socket of the started CNI server to be available: %v", err)
+	}
+	if err := s.Stop(); err != nil {
+		t.Fatalf("error stopping CNI server: %v", err)
+	}
+	if err := s.CheckSocket(); err == nil {
+		t.Fatalf("expected the socket of the stopped CNI server to be removed")
+	}
+	if _, err := net.Dial("unix", socketPath); err == nil {
+		t.Fatalf("expected the stopped CNI server not to accept connections")
+	}
+}
+
+func TestCNIServerKubeAuthTokenReload(t *testing.T) {
+	tmpDir, err := utiltesting.MkTmpdir("cniserver")
+	if err != nil {
+		t.Fatalf("failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tokenFile := filepath.Join(tmpDir, "token")
+
+	s := &Server{kubeAuth: &KubeAPIAuth{
+		KubeAPIServer:    "https://10.0.0.1:6443",
+		KubeAPIToken:     "token-1",
+		KubeAPITokenFile: tokenFile,
+	}}
+
+	// the token read at startup is used while the token file can't be read
+	if token := s.currentKubeAuth().KubeAPIToken; token != "token-1" {
+		t.Fatalf("expected token %q, got %q", "token-1", token)
+	}
+
+	// the rotated token is handed to the shim
+	if err := os.WriteFile(tokenFile, []byte("token-2\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	kubeAuth := s.currentKubeAuth()
+	if kubeAuth.KubeAPIToken != "token-2" || kubeAuth.KubeAPIServer != "https://10.0.0.1:6443" {
+		t.Fatalf("expected the rotated token, got %+v", kubeAuth)
+	}
+
+	// the last token read is used while the token file is being rewritten or is missing
+	if err := os.WriteFile(tokenFile, nil, 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	if token := s.currentKubeAuth().KubeAPIToken; token != "token-2" {
+		t.Fatalf("expected the last token %q, got %q", "token-2", token)
+	}
+	if err := os.Remove(tokenFile); err != nil {
+		t.Fatalf("failed to remove token file: %v", err)
+	}
+	if token := s.currentKubeAuth().KubeAPIToken; token != "token-2" {
+		t.Fatalf("expected the last token %q, got %q", "token-2", token)
+	}
+}
+
+func TestCNIServerSerializesPodRequests(t *testing.T) {
+	var active, maxActive int32
+	s := &Server{
+		kubeAuth: &KubeAPIAuth{},
+		podLocks: syncmap.NewSyncMap[struct{}](),
+		handlePodRequestFunc: func(request *PodRequest, clientset *ClientSet, kubeAuth *KubeAPIAuth) ([]byte, error) {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(50 * time.Millisecond)
+			return nil, nil
+		},
+	}
+
+	// the DEL of the previous sandbox of the pod races with the ADD of the next one
+	var wg sync.WaitGroup
+	for i, command := range []command{CNIDel, CNIAdd, CNIDel, CNIAdd} {
+		data, err := json.Marshal(&Request{
+			Env: map[string]string{
+				"CNI_COMMAND":     string(command),
+				"CNI_CONTAINERID": fmt.Sprintf("%s%d", sandboxID, i),
+				"CNI_NETNS":       "/path/to/something",
+				"CNI_ARGS":        makeCNIArgs(namespace, name),
+			},
+			Config: []byte(cniConfig),
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal CNI request: %v", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+			if _, err := s.handleCNIRequest(r); err != nil {
+				t.Errorf("unexpected error handling CNI request: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Fatalf("expected the requests of the pod to be handled one at a time, %d were handled concurrently", maxActive)
+	}
+}
+
+func TestCNIServerTimesOutWaitingForPodLock(t *testing.T) {
+	requestTimeout := config.CNI.RequestTimeout
+	config.CNI.RequestTimeout = 100 * time.Millisecond
+	defer func() {
+		config.CNI.RequestTimeout = requestTimeout
+	}()
+	var calls int32
+	release := make(chan struct{})
+	s := &Server{
+		kubeAuth: &KubeAPIAuth{},
+		podLocks: syncmap.NewSyncMap[struct{}](),
+		handlePodRequestFunc: func(request *PodRequest, clientset *ClientSet, kubeAuth *KubeAPIAuth) ([]byte, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				// the first request holds the lock of the pod past the budget of the second one
+				<-release
+			}
+			return nil, nil
+		},
+	}
+	newRequest := func(command command, i int) *http.Request {
+		data, err := json.Marshal(&Request{
+			Env: map[string]string{
+				"CNI_COMMAND":     string(command),
+				"CNI_CONTAINERID": fmt.Sprintf("%s%d", sandboxID, i),
+				"CNI_NETNS":       "/path/to/something",
+				"CNI_ARGS":        makeCNIArgs(namespace, name),
+			},
+			Config: []byte(cniConfig),
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal CNI request: %v", err)
+		}
+		return httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	}
+
+	delDone := make(chan error)
+	go func() {
+		_, err := s.handleCNIRequest(newRequest(CNIDel, 0))
+		delDone <- err
+	}()
+	// let the DEL take the lock first
+	time.Sleep(20 * time.Millisecond)
+	start := time.Now()
+	_, err := s.handleCNIRequest(newRequest(CNIAdd, 1))
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for the previous requests of pod "+namespace+"/"+name) {
+		t.Fatalf("expected the ADD to time out waiting for the lock of the pod, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the ADD to fail within its budget, it took %s", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected the ADD not to be handled")
+	}
+
+	// the lock given up on is released, the next request of the pod is handled once the DEL is done
+	close(release)
+	if err := <-delDone; err != nil {
+		t.Fatalf("unexpected error handling the DEL: %v", err)
+	}
+	if _, err := s.handleCNIRequest(newRequest(CNIAdd, 2)); err != nil {
+		t.Fatalf("unexpected error handling the ADD: %v", err)
+	}
+}
+
+func TestCNIServerRejectsPodRequestsWhileQuiesced(t *testing.T) {
+	handled := make(chan struct{})
+	release := make(chan struct{})
+	s := &Server{
+		kubeAuth: &KubeAPIAuth{},
+		podLocks: syncmap.NewSyncMap[struct{}](),
+		handlePodRequestFunc: func(request *PodRequest, clientset *ClientSet, kubeAuth *KubeAPIAuth) ([]byte, error) {
+			handled <- struct{}{}
+			<-release
+			return nil, nil
+		},
+	}
+	data, err := json.Marshal(&Request{
+		Env: map[string]string{
+			"CNI_COMMAND":     string(CNIAdd),
+			"CNI_CONTAINERID": sandboxID,
+			"CNI_NETNS":       "/path/to/something",
+			"CNI_ARGS":        makeCNIArgs(namespace, name),
+		},
+		Config: []byte(cniConfig),
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal CNI request: %v", err)
+	}
+	handleRequest := func() error {
+		_, err := s.handleCNIRequest(httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data)))
+		return err
+	}
+
+	inFlight := make(chan error, 1)
+	go func() { inFlight <- handleRequest() }()
+	<-handled
+
+	// the quiesce waits for the request being handled
+	quiesced := make(chan struct{})
+	go func() {
+		s.Quiesce()
+		close(quiesced)
+	}()
+	select {
+	case <-quiesced:
+		t.Fatal("expected the quiesce to wait for the request being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if err := <-inFlight; err != nil {
+		t.Fatalf("unexpected error handling CNI request: %v", err)
+	}
+	<-quiesced
+
+	if err := handleRequest(); err == nil || !strings.Contains(err.Error(), "quiesced") {
+		t.Fatalf("expected the request to be rejected while quiesced, got %v", err)
+	}
+
+	s.Resume()
+	go func() { <-handled }()
+	if err := handleRequest(); err != nil {
+		t.Fatalf("unexpected error handling CNI request after resuming: %v", err)
+	}
 }
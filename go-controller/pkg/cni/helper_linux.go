@@ -13,8 +13,11 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog/v2"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -209,9 +212,15 @@ func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInter
 	contIface := &current.Interface{}
 	ifnameSuffix := ""
 
+	nodeNS, err := util.GetNodeNetNS()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the node network namespace: %v", err)
+	}
+	defer nodeNS.Close()
+
 	var oldHostVethName string
-	err := netns.Do(func(hostNS ns.NetNS) error {
-		// create the veth pair in the container and move host end into host netns
+	err = netns.Do(func(_ ns.NetNS) error {
+		// create the veth pair in the container and move host end into the node netns
 		// set host interface name now for default network as it is already known; otherwise for secondary network,
 		// host interface will be renamed later.
 		if ifInfo.NetName == types.DefaultNetworkName {
@@ -220,7 +229,7 @@ func setupInterface(netns ns.NetNS, containerID, ifName string, ifInfo *PodInter
 			hostIface.Name = ""
 		}
 		contIface.Mac = ifInfo.MAC.String()
-		hostVeth, containerVeth, err := cniPluginLibOps.SetupVeth(ifName, hostIface.Name, ifInfo.MTU, contIface.Mac, hostNS)
+		hostVeth, containerVeth, err := cniPluginLibOps.SetupVeth(ifName, hostIface.Name, ifInfo.MTU, contIface.Mac, nodeNS)
 		if err != nil {
 			return err
 		}
@@ -415,7 +424,16 @@ func getPfEncapIP(deviceID string) (string, error) {
 		return "", nil
 	}
 
-	encapIP := encapIpMapping[uplinkRepName]
+	encapIP, ok := encapIpMapping[uplinkRepName]
+	if !ok {
+		// with VF-LAG the encap IP is mapped to the bond of the PFs
+		bond, _, err := util.GetVFLAGBond(uplinkRepName)
+		if err != nil {
+			klog.Errorf("Failed to check if uplink representor %s is bonded: %v", uplinkRepName, err)
+			return "", nil
+		}
+		encapIP = encapIpMapping[bond]
+	}
 	return encapIP, nil
 }
 
@@ -536,9 +554,12 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		ovsArgs = append(ovsArgs, []string{"--", "--if-exists", "remove", "interface", hostIfaceName, "external_ids", types.NADExternalID}...)
 	}
 
+	start := time.Now()
 	if out, err := ovsExec(ovsArgs...); err != nil {
 		return fmt.Errorf("failure in plugging pod interface: %v\n  %q", err, out)
 	}
+	metrics.ObserveWithTraceExemplar(ctx, metrics.MetricCNIPodSetupDuration.WithLabelValues(
+		metrics.PodSetupStageOVSPortAdd, ifInfo.NetName), time.Since(start).Seconds())
 
 	if err := clearPodBandwidth(sandboxID); err != nil {
 		return err
@@ -559,13 +580,26 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		}
 	}
 
-	if err := waitForPodInterface(ctx, ifInfo, hostIfaceName, ifaceID, getter,
-		namespace, podName, initialPodUID); err != nil {
+	// the port is bound, and its flows programmed by ovn-controller, once it is ovn-installed; success is only
+	// returned to the runtime then so that the pod doesn't send traffic to a port without flows
+	waitCtx := ctx
+	if config.CNI.OVNInstalledTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, config.CNI.OVNInstalledTimeout)
+		defer cancel()
+	}
+	waitCtx, span := metrics.StartSpan(waitCtx, "waitForPodInterface", attribute.String("ovs.interface", hostIfaceName))
+	start = time.Now()
+	err = waitForPodInterface(waitCtx, ifInfo, hostIfaceName, ifaceID, getter, namespace, podName, initialPodUID)
+	metrics.EndSpan(span, err)
+	if err != nil {
 		// Ensure the error shows up in node logs, rather than just
 		// being reported back to the runtime.
 		klog.Warningf("[%s/%s %s] pod uid %s: %v", namespace, podName, sandboxID, initialPodUID, err)
 		return err
 	}
+	metrics.ObserveWithTraceExemplar(ctx, metrics.MetricCNIPodSetupDuration.WithLabelValues(
+		metrics.PodSetupStageFlowsReady, ifInfo.NetName), time.Since(start).Seconds())
 	return nil
 }
 
@@ -648,6 +682,22 @@ func (*defaultPodRequestInterfaceOps) ConfigureInterface(pr *PodRequest, getter
 		}
 	}
 
+	if ifInfo.AnnounceIPs && !ifInfo.SkipIPConfig && !pr.IsVFIO {
+		err = netns.Do(func(hostNS ns.NetNS) error {
+			for _, ip := range ifInfo.IPs {
+				if err := util.AdvertiseIP(ip.IP, contIface.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// the switches eventually learn the pod MACs from the traffic of the pods
+			klog.Warningf("Failed to announce the IPs of pod %s/%s on %s: %v", pr.PodNamespace, pr.PodName,
+				contIface.Name, err)
+		}
+	}
+
 	return []*current.Interface{hostIface, contIface}, nil
 }
 
@@ -749,7 +799,14 @@ func (*defaultPodRequestInterfaceOps) UnconfigureInterface(pr *PodRequest, ifInf
 		// hostIfName is not empty if using device ID, a secondary network, or segmentation not enabled
 		// delete the port in traditional fashion
 		if hostIfName != "" {
-			pr.deletePort(hostIfName, pr.PodNamespace, pr.PodName)
+			// the port of a representor is reused by the next sandbox that is given the
+			// device, a DEL of the previous sandbox arriving after its ADD must not remove it
+			if owner := pr.portSandbox(hostIfName); owner != "" && owner != pr.SandboxID {
+				klog.Infof("Not deleting OVS port %s %s: it belongs to sandbox %s, not %s",
+					hostIfName, podDesc, owner, pr.SandboxID)
+			} else {
+				pr.deletePort(hostIfName, pr.PodNamespace, pr.PodName)
+			}
 		} else {
 			// this is a primary interface deletion and segmentation is enabled, delete all ports
 			// delete happens in reverse order for attached networks, so this is the final deletion
@@ -813,6 +870,17 @@ func (pr *PodRequest) deletePort(ifaceName, podNamespace, podName string) {
 	}
 }
 
+// portSandbox returns the sandbox the OVS port of the given interface was added
+// for, or an empty string if there is no such port or it can't be read
+func (pr *PodRequest) portSandbox(ifaceName string) string {
+	sandbox, err := ovsGet("Interface", ifaceName, "external_ids", "sandbox")
+	if err != nil {
+		klog.Warningf("Failed to get the sandbox of OVS interface %s: %v", ifaceName, err)
+		return ""
+	}
+	return sandbox
+}
+
 func (pr *PodRequest) deletePorts(ifaces []string, podNamespace, podName string) {
 	for _, iface := range ifaces {
 		pr.deletePort(iface, podNamespace, podName)
@@ -715,6 +715,8 @@ func TestSetupSriovInterface(t *testing.T) {
 			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
 				{OnCallMethodName: "LinkSetNsFd", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil}},
+				// The below mock call is to check if the uplink representor is bonded (VF-LAG)
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "testlinkrepresentor"}}, nil}},
 			},
 			nsMockHelper: []ovntest.TestifyMockHelper{
 				// The below mock call is needed when moveIfToNetns() is called
@@ -1381,7 +1383,39 @@ func TestConfigureOVS(t *testing.T) {
 			sriovnetOpsMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "GetUplinkRepresentor", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{"enp999s0f0", nil}},
 			},
-			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp999s0f0"}}, nil}},
+			},
+		},
+		{
+			desc:    "VF-LAG bond has matching external_ids:ovn-pf-encap-ip-mapping",
+			podNs:   "ns-foo",
+			podName: "pod-bar",
+			vfRep:   "enp1s0f1_1",
+			ifInfo: &PodInterfaceInfo{
+				PodAnnotation: util.PodAnnotation{
+					IPs: []*net.IPNet{ipnet},
+				},
+				IsDPUHostMode: false,
+				NetName:       ovntypes.DefaultNetworkName,
+				NetdevName:    "enp1s0f1v1",
+				PodUID:        "xyz",
+			},
+			ovnPfEncapIpMapping: "bond0:10.0.0.2",
+			errMatch:            nil,
+			pfEncapIp:           "10.0.0.2",
+			execMock:            ovntest.NewFakeExec(),
+			sriovnetOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "GetUplinkRepresentor", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{"enp1s0f1", nil}},
+			},
+			netLinkOpsMockHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp1s0f1", MasterIndex: 10}}, nil}},
+				{OnCallMethodName: "LinkByIndex", OnCallMethodArgType: []string{"int"}, RetArgList: []interface{}{&netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10}}, nil}},
+				{OnCallMethodName: "LinkList", RetArgList: []interface{}{[]netlink.Link{
+					&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp1s0f0", MasterIndex: 10}},
+					&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "enp1s0f1", MasterIndex: 10}},
+				}, nil}},
+			},
 		},
 		{
 			desc:    "empty external_ids:ovn-pf-encap-ip-mapping",
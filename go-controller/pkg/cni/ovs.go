@@ -290,14 +290,11 @@ func waitForPodInterface(ctx context.Context, ifInfo *PodInterfaceInfo,
 
 	mac := ifInfo.MAC.String()
 	ifAddrs := ifInfo.IPs
+	start := time.Now()
 	for {
 		select {
 		case <-ctx.Done():
-			errDetail := "timed out"
-			if ctx.Err() == context.Canceled {
-				errDetail = "canceled while"
-			}
-			return fmt.Errorf("%s waiting for OVS port binding%s for %s %v", errDetail, detail, mac, ifAddrs)
+			return waitError(ctx, fmt.Sprintf("OVS port binding%s for %s %v", detail, mac, ifAddrs), start)
 		default:
 			columns := []string{"external-ids:iface-id"}
 			if checkExternalIDs {
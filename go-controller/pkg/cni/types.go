@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	current "github.com/containernetworking/cni/pkg/types/100"
@@ -12,6 +13,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -54,6 +56,9 @@ type PodInterfaceInfo struct {
 	PodUID               string `json:"pod-uid"`
 	NetdevName           string `json:"vf-netdev-name"`
 	EnableUDPAggregation bool   `json:"enable-udp-aggregation"`
+	// AnnounceIPs sends a gratuitous ARP or an unsolicited neighbor advertisement for the IPs of the pod
+	// interface once it is configured
+	AnnounceIPs bool `json:"announce-ips"`
 
 	// network name, for default network, it is "default", otherwise it is net-attach-def's netconf spec name
 	NetName string `json:"netName"`
@@ -178,9 +183,10 @@ type PodInfoGetter interface {
 
 type ClientSet struct {
 	PodInfoGetter
-	kclient   kubernetes.Interface
-	podLister corev1listers.PodLister
-	nadLister nadlister.NetworkAttachmentDefinitionLister
+	kclient    kubernetes.Interface
+	podLister  corev1listers.PodLister
+	nodeLister corev1listers.NodeLister
+	nadLister  nadlister.NetworkAttachmentDefinitionLister
 }
 
 func NewClientSet(kclient kubernetes.Interface, podLister corev1listers.PodLister) *ClientSet {
@@ -196,5 +202,18 @@ type Server struct {
 	http.Server
 	handlePodRequestFunc podRequestFunc
 	clientSet            *ClientSet
-	kubeAuth             *KubeAPIAuth
+	// kubeAuthLock guards the token of kubeAuth, updated with the last token read from the token file
+	kubeAuthLock sync.Mutex
+	kubeAuth     *KubeAPIAuth
+	// socketPath is the path of the socket the server listens on once started
+	socketPath string
+	// stopChan stops serving the socket once the server is stopped
+	stopChan chan struct{}
+	// podLocks serializes the requests of a pod, keyed by namespace/name, so that the
+	// DEL of a previous sandbox and the ADD of the next one don't interleave
+	podLocks *syncmap.SyncMap[struct{}]
+	// quiesceLock is held for reading by the pod requests being handled, Quiesce takes it to wait for them
+	quiesceLock sync.RWMutex
+	// quiesced is set while the pod requests are rejected, e.g. while OVS is upgraded on the node
+	quiesced bool
 }
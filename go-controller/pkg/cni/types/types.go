@@ -44,6 +44,15 @@ type NetConf struct {
 	JoinSubnet string `json:"joinSubnet,omitempty"`
 	// VLANID, valid in localnet topology network only
 	VLANID int `json:"vlanID,omitempty"`
+	// AllowedVLANs is a comma-seperated list of VLAN IDs and VLAN ID ranges trunked to the pods
+	// valid in localnet topology network only and exclusive with VLANID
+	// eg. "100,200-210"
+	AllowedVLANs string `json:"allowedVLANs,omitempty"`
+	// PhysicalNetworkName is the name of the physical network the localnet network is
+	// mapped to in ovn-bridge-mappings. Several localnet networks may share a physical network
+	// valid in localnet topology network only
+	// default value: the network name if not provided
+	PhysicalNetworkName string `json:"physicalNetworkName,omitempty"`
 	// AllowPersistentIPs is valid on both localnet / layer topologies.
 	// It allows for having IP allocations that outlive the pod for which
 	// they are originally created - e.g. a KubeVirt VM's migration, or
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -39,6 +40,16 @@ func isDPUReady(podAnnotation map[string]string, nadName string) (*util.PodAnnot
 	return nil, false
 }
 
+// waitError returns the error of a CNI request phase waiting on the given condition
+// since start when the time budget of the request ran out or it was canceled
+func waitError(ctx context.Context, condition string, start time.Time) error {
+	detail := "timed out"
+	if ctx.Err() == context.Canceled {
+		detail = "canceled while"
+	}
+	return fmt.Errorf("%s waiting for %s after %s: %w", detail, condition, time.Since(start).Round(time.Millisecond), ctx.Err())
+}
+
 // getPod tries to read a Pod object from the informer cache, or if the pod
 // doesn't exist there, the apiserver. If neither a list or a kube client is
 // given, returns no pod and no error
@@ -59,19 +70,90 @@ func (c *ClientSet) getPod(namespace, name string) (*kapi.Pod, error) {
 	return pod, err
 }
 
+// getNode tries to read a Node object from the informer cache, or if the node
+// doesn't exist there or there is no cache, the apiserver
+func (c *ClientSet) getNode(name string) (*kapi.Node, error) {
+	if c.nodeLister != nil {
+		node, err := c.nodeLister.Get(name)
+		if err == nil {
+			return node, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return c.kclient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// validateDefaultNetworkStaticRequest validates the static IPs and MAC requested for the
+// pod through the default network selection element (the ips and mac fields of the
+// v1.multus-cni.io/default-network annotation) against the host subnets of the node
+// and the addresses allocated to the pod in the default network annotation, which the
+// pod interface is configured with.
+func (c *ClientSet) validateDefaultNetworkStaticRequest(pod *kapi.Pod, podNADAnnotation *util.PodAnnotation) error {
+	network, err := util.GetK8sPodDefaultNetworkSelection(pod)
+	if err != nil {
+		return err
+	}
+	if network == nil || (len(network.IPRequest) == 0 && network.MacRequest == "") {
+		return nil
+	}
+
+	if network.MacRequest != "" {
+		mac, err := net.ParseMAC(network.MacRequest)
+		if err != nil {
+			return fmt.Errorf("failed to parse MAC %q requested for pod %s/%s: %w", network.MacRequest, pod.Namespace, pod.Name, err)
+		}
+		if podNADAnnotation.MAC.String() != mac.String() {
+			return fmt.Errorf("pod %s/%s requested MAC %s but was allocated %s", pod.Namespace, pod.Name, mac, podNADAnnotation.MAC)
+		}
+	}
+
+	if len(network.IPRequest) == 0 {
+		return nil
+	}
+	ips, err := util.ParseIPNets(network.IPRequest)
+	if err != nil {
+		return fmt.Errorf("failed to parse IPs %v requested for pod %s/%s: %w", network.IPRequest, pod.Namespace, pod.Name, err)
+	}
+	node, err := c.getNode(pod.Spec.NodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s of pod %s/%s: %w", pod.Spec.NodeName, pod.Namespace, pod.Name, err)
+	}
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return fmt.Errorf("failed to get the host subnets of node %s: %w", node.Name, err)
+	}
+	for _, ip := range ips {
+		if !util.IsContainedInAnyCIDR(ip, hostSubnets...) {
+			return fmt.Errorf("IP %s requested for pod %s/%s is not within the host subnets %v of node %s",
+				ip.IP, pod.Namespace, pod.Name, util.StringSlice(hostSubnets), node.Name)
+		}
+		allocated := false
+		for _, podIP := range podNADAnnotation.IPs {
+			if podIP.IP.Equal(ip.IP) {
+				allocated = true
+				break
+			}
+		}
+		if !allocated {
+			return fmt.Errorf("pod %s/%s requested IP %s but was allocated %v",
+				pod.Namespace, pod.Name, ip.IP, util.StringSlice(podNADAnnotation.IPs))
+		}
+	}
+	return nil
+}
+
 // GetPodAnnotations obtains the pod UID and annotation from the cache or apiserver
 func GetPodWithAnnotations(ctx context.Context, getter PodInfoGetter,
 	namespace, name, nadName string, annotCond podAnnotWaitCond) (*kapi.Pod, map[string]string, *util.PodAnnotation, error) {
 	var notFoundCount uint
+	start := time.Now()
 
 	for {
 		select {
 		case <-ctx.Done():
-			detail := "timed out"
-			if ctx.Err() == context.Canceled {
-				detail = "canceled while"
-			}
-			return nil, nil, nil, fmt.Errorf("%s waiting for annotations: %w", detail, ctx.Err())
+			return nil, nil, nil, waitError(ctx, "pod annotation from ovnkube-controller", start)
 		default:
 			pod, err := getter.getPod(namespace, name)
 			if err != nil {
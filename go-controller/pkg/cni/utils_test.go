@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
 
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -240,6 +241,22 @@ var _ = Describe("CNI Utils tests", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("timed out waiting for pod after 1s"))
 		})
+
+		It("Returns an error naming the pod annotation if the request runs out of time", func() {
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancelFunc()
+
+			cond := func(podAnnotation map[string]string, netName string) (*util.PodAnnotation, bool) {
+				return nil, false
+			}
+
+			clientset := newFakeClientSet(pod, &podNamespaceLister)
+
+			podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(pod, nil)
+			_, _, _, err := GetPodWithAnnotations(ctx, clientset, namespace, podName, ovntypes.DefaultNetworkName, cond)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(err.Error()).To(ContainSubstring("timed out waiting for pod annotation from ovnkube-controller after"))
+		})
 	})
 
 	Context("PodAnnotation2PodInfo", func() {
@@ -279,5 +296,74 @@ var _ = Describe("CNI Utils tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(pif.EnableUDPAggregation).To(BeFalse())
 		})
+
+		It("Announces the IPs of the pod interfaces on localnet networks when enabled", func() {
+			pr := &PodRequest{
+				PodUID:  podUID,
+				netName: ovntypes.DefaultNetworkName,
+				nadName: ovntypes.DefaultNetworkName,
+				CNIConf: &ovncnitypes.NetConf{Topology: ovntypes.LocalnetTopology},
+			}
+			config.OvnKubeNode.AnnounceLocalnetPodIPs = true
+			defer func() { config.OvnKubeNode.AnnounceLocalnetPodIPs = false }()
+			pif, err := pr.buildPodInterfaceInfo(podAnnot, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pif.AnnounceIPs).To(BeTrue())
+
+			pr.CNIConf.Topology = ovntypes.Layer2Topology
+			pif, err = pr.buildPodInterfaceInfo(podAnnot, nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pif.AnnounceIPs).To(BeFalse())
+		})
+	})
+
+	Context("validateDefaultNetworkStaticRequest", func() {
+		var (
+			clientSet        *ClientSet
+			pod              *v1.Pod
+			podNADAnnotation *util.PodAnnotation
+		)
+
+		BeforeEach(func() {
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: map[string]string{"k8s.ovn.org/node-subnets": `{"default":["192.168.2.0/24"]}`},
+				},
+			}
+			clientSet = &ClientSet{kclient: fake.NewSimpleClientset(node)}
+			pod = newPod("some-ns", "some-pod", map[string]string{util.OvnPodAnnotationName: defaultPodAnnotation})
+			pod.Spec.NodeName = node.Name
+			var err error
+			podNADAnnotation, err = util.UnmarshalPodAnnotation(pod.Annotations, ovntypes.DefaultNetworkName)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a pod without static requests", func() {
+			Expect(clientSet.validateDefaultNetworkStaticRequest(pod, podNADAnnotation)).To(Succeed())
+		})
+
+		It("accepts static IP and MAC requests honored by the pod annotation", func() {
+			pod.Annotations[util.DefNetworkAnnotation] = `[{"name":"ovn-kubernetes","ips":["192.168.2.3/24"],"mac":"0a:58:c0:a8:02:03"}]`
+			Expect(clientSet.validateDefaultNetworkStaticRequest(pod, podNADAnnotation)).To(Succeed())
+		})
+
+		It("rejects a static IP request outside of the host subnets of the node", func() {
+			pod.Annotations[util.DefNetworkAnnotation] = `[{"name":"ovn-kubernetes","ips":["10.0.0.3/24"]}]`
+			err := clientSet.validateDefaultNetworkStaticRequest(pod, podNADAnnotation)
+			Expect(err).To(MatchError(ContainSubstring("is not within the host subnets")))
+		})
+
+		It("rejects a static IP request not honored by the pod annotation", func() {
+			pod.Annotations[util.DefNetworkAnnotation] = `[{"name":"ovn-kubernetes","ips":["192.168.2.4/24"]}]`
+			err := clientSet.validateDefaultNetworkStaticRequest(pod, podNADAnnotation)
+			Expect(err).To(MatchError(ContainSubstring("requested IP 192.168.2.4 but was allocated")))
+		})
+
+		It("rejects a static MAC request not honored by the pod annotation", func() {
+			pod.Annotations[util.DefNetworkAnnotation] = `[{"name":"ovn-kubernetes","mac":"0a:58:c0:a8:02:04"}]`
+			err := clientSet.validateDefaultNetworkStaticRequest(pod, podNADAnnotation)
+			Expect(err).To(MatchError(ContainSubstring("requested MAC 0a:58:c0:a8:02:04 but was allocated")))
+		})
 	})
 })
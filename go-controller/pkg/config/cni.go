@@ -71,6 +71,14 @@ func WriteCNIConfig() error {
 	return os.Rename(f.Name(), confFile)
 }
 
+// RemoveCNIConfig removes the CNI config file written by WriteCNIConfig, if any
+func RemoveCNIConfig() error {
+	if err := os.Remove(filepath.Join(CNI.ConfDir, CNIConfFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
 // ParseNetConf parses config in NAD spec
 func ParseNetConf(bytes []byte) (*ovncnitypes.NetConf, error) {
 	var netconf *ovncnitypes.NetConf
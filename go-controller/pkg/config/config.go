@@ -3,18 +3,21 @@ package config
 import (
 	"flag"
 	"fmt"
+	"io"
+	"math"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	knet "k8s.io/apimachinery/pkg/util/net"
 
 	"github.com/urfave/cli/v2"
 	gcfg "gopkg.in/gcfg.v1"
@@ -25,6 +28,7 @@ import (
 	utilnet "k8s.io/utils/net"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/logging"
 )
 
 // DefaultEncapPort number used if not supplied
@@ -37,6 +41,13 @@ const DefaultVXLANPort = 4789
 
 const DefaultDBTxnTimeout = time.Second * 100
 
+// PodSNATPortRangeMin and PodSNATPortRangeMax bound the source ports the gateway pod SNAT port range size option
+// partitions
+const (
+	PodSNATPortRangeMin = 1024
+	PodSNATPortRangeMax = 65535
+)
+
 // The following are global config parameters that other modules may access directly
 var (
 	// Build information. Populated at build-time.
@@ -104,8 +115,9 @@ var (
 
 	// CNI holds CNI-related parsed config file parameters and command-line overrides
 	CNI = CNIConfig{
-		ConfDir: "/etc/cni/net.d",
-		Plugin:  "ovn-k8s-cni-overlay",
+		ConfDir:        "/etc/cni/net.d",
+		Plugin:         "ovn-k8s-cni-overlay",
+		RequestTimeout: 2 * time.Minute,
 	}
 
 	// Kubernetes holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -123,7 +135,9 @@ var (
 	}
 
 	// Metrics holds Prometheus metrics-related parameters.
-	Metrics MetricsConfig
+	Metrics = MetricsConfig{
+		TracingSamplingRatePerMillion: 1000000,
+	}
 
 	// OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
@@ -142,6 +156,7 @@ var (
 		V6JoinSubnet:       "fd98::/64",
 		V4MasqueradeSubnet: "169.254.169.0/29",
 		V6MasqueradeSubnet: "fd69::/125",
+		NodePortRange:      "30000-32767",
 		MasqueradeIPs: MasqueradeIPsConfig{
 			V4OVNMasqueradeIP:               net.ParseIP("169.254.169.1"),
 			V6OVNMasqueradeIP:               net.ParseIP("fd69::1"),
@@ -215,6 +230,14 @@ type DefaultConfig struct {
 	// an MTU migration procedure where different nodes might be using different
 	// MTU values
 	RoutableMTU int `gcfg:"routable-mtu"`
+	// MgmtPortMTU overrides the MTU of the management port, which uses MTU otherwise
+	MgmtPortMTU int `gcfg:"mgmt-port-mtu"`
+	// GatewayBridgeMTU overrides the MTU of the gateway bridge internal port, which keeps
+	// the MTU it was created with otherwise
+	GatewayBridgeMTU int `gcfg:"gateway-bridge-mtu"`
+	// GatewayPatchMTU overrides the MTU the gateway routers check the packets they send towards the
+	// cluster through their patch port against (options:gateway_mtu), which uses MTU otherwise
+	GatewayPatchMTU int `gcfg:"gateway-patch-mtu"`
 	// ConntrackZone affects only the gateway nodes, This value is used to track connections
 	// that are initiated from the pods so that the reverse connections go back to the pods.
 	// This represents the conntrack zone used for the conntrack flow rules.
@@ -230,9 +253,16 @@ type DefaultConfig struct {
 	// EncapType value defines the encapsulation protocol to use to transmit packets between
 	// hypervisors. By default the value is 'geneve'
 	EncapType string `gcfg:"encap-type"`
-	// The IP address of the encapsulation endpoint. If not specified, the IP address the
-	// NodeName resolves to will be used
+	// The IP address of the encapsulation endpoint, or a comma separated IPv4 and IPv6 address
+	// for an endpoint per IP family. If not specified, the IP address the NodeName resolves to
+	// will be used
 	EncapIP string `gcfg:"encap-ip"`
+	// RawNodeIPExclude is a comma separated list of CIDRs and shell patterns of interface names never selected
+	// for the encap IP or the gateway interface when they are autodetected, e.g. the storage or BMC networks
+	// of the node. The k8s.ovn.org/node-ip-exclude annotation of a node overrides it on the node.
+	RawNodeIPExclude string `gcfg:"node-ip-exclude"`
+	// NodeIPExclude is the parsed RawNodeIPExclude
+	NodeIPExclude NodeIPExclusions
 	// The UDP Port of the encapsulation endpoint. If not specified, the IP default port
 	// of 6081 will be used
 	EncapPort uint `gcfg:"encap-port"`
@@ -266,6 +296,13 @@ type DefaultConfig struct {
 	// may create when the logical flow  cache  is  enabled.  By
 	// default the size of the cache is unlimited.
 	LFlowCacheLimitKb uint `gcfg:"lflow-cache-limit-kb"`
+	// LFlowCacheAdaptive makes ovnkube-node adjust the memory limit of the logical flow cache between
+	// LFlowCacheLimitKbMin and LFlowCacheLimitKbMax according to the node memory pressure
+	LFlowCacheAdaptive bool `gcfg:"lflow-cache-adaptive"`
+	// LFlowCacheLimitKbMin is the lowest memory limit of the logical flow cache in adaptive mode
+	LFlowCacheLimitKbMin uint `gcfg:"lflow-cache-limit-kb-min"`
+	// LFlowCacheLimitKbMax is the highest memory limit of the logical flow cache in adaptive mode
+	LFlowCacheLimitKbMax uint `gcfg:"lflow-cache-limit-kb-max"`
 	// RawClusterSubnets holds the unparsed cluster subnets. Should only be
 	// used inside config module.
 	RawClusterSubnets string `gcfg:"cluster-subnets"`
@@ -282,6 +319,23 @@ type DefaultConfig struct {
 	Zone string `gcfg:"zone"`
 }
 
+// zoneMutex guards Default.Zone once ovnkube-node runs, the node can be migrated to another zone at runtime
+var zoneMutex sync.RWMutex
+
+// GetZone returns the zone of the node, it is safe to call while the node is migrated to another zone
+func GetZone() string {
+	zoneMutex.RLock()
+	defer zoneMutex.RUnlock()
+	return Default.Zone
+}
+
+// SetZone moves the node to zone at runtime
+func SetZone(zone string) {
+	zoneMutex.Lock()
+	defer zoneMutex.Unlock()
+	Default.Zone = zone
+}
+
 // LoggingConfig holds logging-related parsed config file parameters and command-line overrides
 type LoggingConfig struct {
 	// File is the path of the file to log to
@@ -301,6 +355,10 @@ type LoggingConfig struct {
 	LogFileMaxAge int `gcfg:"logfile-maxage"`
 	// Logging rate-limiting meter
 	ACLLoggingRateLimit int `gcfg:"acl-logging-rate-limit"`
+	// Format is the format of the logs, text or json
+	Format string `gcfg:"log-format"`
+	// SubsystemLevels overrides the verbosity of subsystems, as a comma separated list of <subsystem>=<level>
+	SubsystemLevels string `gcfg:"subsystem-loglevels"`
 }
 
 // MonitoringConfig holds monitoring-related parsed config file parameters and command-line overrides
@@ -342,6 +400,13 @@ type CNIConfig struct {
 	ConfDir string `gcfg:"conf-dir"`
 	// Plugin specifies the name of the CNI plugin
 	Plugin string `gcfg:"plugin"`
+	// RequestTimeout is the time budget of a CNI request in the CNI server, after which the
+	// request fails with an error naming the phase it was waiting on
+	RequestTimeout time.Duration `gcfg:"request-timeout"`
+	// OVNInstalledTimeout bounds the wait of a CNI ADD for the OVS interface of the pod to be flagged
+	// ovn-installed by ovn-controller, i.e. for the flows of the pod to be programmed, before success is
+	// returned to the runtime. The wait is only bounded by RequestTimeout when it is 0.
+	OVNInstalledTimeout time.Duration `gcfg:"ovn-installed-timeout"`
 }
 
 // KubernetesConfig holds Kubernetes-related parsed config file parameters and command-line overrides
@@ -390,6 +455,20 @@ type MetricsConfig struct {
 	// configuration duration and optionally, its application to all nodes
 	EnableConfigDuration bool `gcfg:"enable-config-duration"`
 	EnableScaleMetrics   bool `gcfg:"enable-scale-metrics"`
+	// LogLevelTokenFile is the file holding the bearer token of the /debug/loglevel endpoint of the metrics
+	// server, the endpoint is served only when it is set
+	LogLevelTokenFile string `gcfg:"loglevel-token-file"`
+	// ConntrackServiceTopN is the number of services with the most conntrack entries the node reports
+	// in metrics, the conntrack entries are not counted when it is 0
+	ConntrackServiceTopN int `gcfg:"conntrack-service-top-n"`
+	// EnablePodConntrackMetrics enables the metrics of the conntrack entries of the connections of the pods
+	// of the node
+	EnablePodConntrackMetrics bool `gcfg:"enable-pod-conntrack"`
+	// TracingEndpoint is the host:port of the OTLP gRPC collector the traces are exported to, e.g. of the CNI
+	// requests and the node control loops, nothing is traced when it is empty
+	TracingEndpoint string `gcfg:"tracing-endpoint"`
+	// TracingSamplingRatePerMillion is how many of the traces started by ovnkube are sampled per million
+	TracingSamplingRatePerMillion int `gcfg:"tracing-sampling-rate-per-million"`
 }
 
 // OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
@@ -413,6 +492,12 @@ type OVNKubernetesFeatureConfig struct {
 	EnablePersistentIPs             bool `gcfg:"enable-persistent-ips"`
 	EnableDNSNameResolver           bool `gcfg:"enable-dns-name-resolver"`
 	EnableServiceTemplateSupport    bool `gcfg:"enable-svc-template-support"`
+	EnableServiceAnnouncement       bool `gcfg:"enable-service-announcement"`
+	EnablePhysicalNetworks          bool `gcfg:"enable-physical-networks"`
+
+	// EgressIPNDPProxy proxies the neighbor discovery of the IPv6 egress IPs assigned to a node
+	// on its primary interface whose prefix is not on-link, for the upstream routers to resolve them
+	EgressIPNDPProxy bool `gcfg:"egressip-ndp-proxy"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -427,20 +512,57 @@ const (
 	GatewayModeLocal GatewayMode = "local"
 )
 
+// IPv6RAPolicy holds the policy applied to IPv6 router advertisements received on the gateway interface
+type IPv6RAPolicy string
+
+const (
+	// IPv6RAPolicyUnmanaged leaves the router advertisement sysctls of the gateway interface untouched
+	IPv6RAPolicyUnmanaged IPv6RAPolicy = ""
+	// IPv6RAPolicyDisabled ignores router advertisements on the gateway interface
+	IPv6RAPolicyDisabled IPv6RAPolicy = "disabled"
+	// IPv6RAPolicyManaged accepts router advertisements on the gateway interface for address
+	// autoconfiguration, but default routes towards the advertising routers are installed by
+	// ovnkube-node instead of the kernel
+	IPv6RAPolicyManaged IPv6RAPolicy = "managed"
+)
+
+// IPv6AddrGenMode holds how the gateway interface generates the interface identifier of its
+// autoconfigured IPv6 addresses
+type IPv6AddrGenMode string
+
+const (
+	// IPv6AddrGenModeUnmanaged leaves the address generation sysctls of the gateway interface untouched
+	IPv6AddrGenModeUnmanaged IPv6AddrGenMode = ""
+	// IPv6AddrGenModeEUI64 derives the interface identifier from the MAC address of the gateway interface,
+	// or from the IPv6 token when one is configured
+	IPv6AddrGenModeEUI64 IPv6AddrGenMode = "eui64"
+	// IPv6AddrGenModeStablePrivacy derives a stable interface identifier per prefix from the
+	// stable_secret of the host (RFC 7217)
+	IPv6AddrGenModeStablePrivacy IPv6AddrGenMode = "stable-privacy"
+)
+
 // GatewayConfig holds node gateway-related parsed config file parameters and command-line overrides
 type GatewayConfig struct {
 	// Mode is the gateway mode; if may be either empty (disabled), "shared", or "local"
 	Mode GatewayMode `gcfg:"mode"`
 	// Interface is the network interface to use for the gateway in "shared" mode
 	Interface string `gcfg:"interface"`
+	// InterfaceExclude is a comma separated list of shell patterns of the interfaces never selected as the
+	// gateway interface when it is autodetected from the default routes, e.g. "wg*,tailscale0"
+	InterfaceExclude string `gcfg:"interface-exclude"`
 	// Exgress gateway interface is the optional network interface to use for external gw pods traffic.
 	EgressGWInterface string `gcfg:"egw-interface"`
-	// NextHop is the gateway IP address of Interface; will be autodetected if not given
+	// NextHop is the gateway IP address of Interface; will be autodetected if not given. In full node
+	// mode, several comma separated next hops of an IP family are health checked and used as ECMP
+	// default routes of the gateway router.
 	NextHop string `gcfg:"next-hop"`
 	// UplinkPort is the port used as the uplink on the gateway interface bridge.
 	UplinkPort string `gcfg:"uplink-port"`
 	// VLANID is the option VLAN tag to apply to gateway traffic for "shared" mode
 	VLANID uint `gcfg:"vlan-id"`
+	// VLANSubInterface creates a VLAN sub-interface of the gateway interface tagged with VLANID and builds
+	// the gateway bridge on it, so the host traffic is tagged as well and OVN leaves the traffic untagged
+	VLANSubInterface bool `gcfg:"vlan-sub-interface"`
 	// NodeportEnable sets whether to provide Kubernetes NodePort service or not
 	NodeportEnable bool `gcfg:"nodeport"`
 	// DisableSNATMultipleGws sets whether to disable SNAT of egress traffic in namespaces annotated with routing-external-gws
@@ -470,6 +592,100 @@ type GatewayConfig struct {
 	DisableForwarding bool `gcfg:"disable-forwarding"`
 	// AllowNoUplink (disabled by default) controls if the external gateway bridge without an uplink port is allowed in local gateway mode.
 	AllowNoUplink bool `gcfg:"allow-no-uplink"`
+	// NodePortRange is the range of ports used for NodePort services, in the same
+	// "min-max" format as the kube-apiserver --service-node-port-range option.
+	NodePortRange string `gcfg:"nodeport-range"`
+	// ReserveNodePortRange adds NodePortRange to net.ipv4.ip_local_reserved_ports so the
+	// kernel never hands out node ports as ephemeral source ports to host processes.
+	ReserveNodePortRange bool `gcfg:"reserve-nodeport-range"`
+	// IPv6RAPolicy is the policy applied to IPv6 router advertisements on the gateway interface;
+	// it may be either empty (unmanaged), "disabled" or "managed"
+	IPv6RAPolicy IPv6RAPolicy `gcfg:"ipv6-ra-policy"`
+	// IPv6AddrGenMode is how the gateway interface generates its autoconfigured IPv6 addresses; it may be
+	// either empty (unmanaged), "eui64" or "stable-privacy". The temporary addresses of the privacy
+	// extensions are disabled on the interface when it is set.
+	IPv6AddrGenMode IPv6AddrGenMode `gcfg:"ipv6-addr-gen-mode"`
+	// IPv6Token is the interface identifier, for example ::10, of the autoconfigured IPv6 addresses of
+	// the gateway interface. The address with this identifier is preferred as the gateway IPv6 address.
+	IPv6Token string `gcfg:"ipv6-token"`
+	// EnableFlowtable offloads the established pod egress connections to an nftables flowtable
+	// spanning the management port and the gateway bridge. Only supported in local gateway mode.
+	EnableFlowtable bool `gcfg:"enable-flowtable"`
+	// NAT64Prefix is the IPv6 prefix embedding the IPv4 addresses the IPv6 pods reach through the stateful
+	// NAT64 of the node, 64:ff9b::/96 for the well-known prefix; empty disables the NAT64. Only supported in
+	// local gateway mode.
+	NAT64Prefix string `gcfg:"nat64-prefix"`
+	// PodSNATPortRangeSize partitions the ephemeral port range of the node into ranges of this size, ovnkube-node
+	// allocating one of them to each local pod for the gateway router to SNAT the egress traffic of the pod to,
+	// so that a pod can't exhaust the source ports of the node IP for the other pods; 0 disables the partitioning.
+	// Only supported in shared gateway mode with the per pod SNAT of disable-snat-multiple-gws.
+	PodSNATPortRangeSize int `gcfg:"pod-snat-port-range-size"`
+	// EnableDuplicateAddressDetection watches the ARP and NDP packets received on the gateway bridges for
+	// other MACs claiming the pod IPs, egress IPs and masquerade IPs of the node, reported in node events.
+	EnableDuplicateAddressDetection bool `gcfg:"enable-duplicate-address-detection"`
+	// EnableLatencyCriticalBypass steers the traffic of the pods annotated as latency critical between the
+	// physical port and OVN without the conntrack of the gateway bridge. Only supported in shared gateway mode
+	// with disable-snat-multiple-gws, where the pod subnets are already forwarded to OVN without it.
+	EnableLatencyCriticalBypass bool `gcfg:"enable-latency-critical-bypass"`
+	// EnableNamespaceEgressRate limits the egress rate of the pods of the namespaces annotated with
+	// k8s.ovn.org/egress-rate with a meter of the gateway bridge per namespace. Only supported in shared gateway
+	// mode with disable-snat-multiple-gws, where the traffic of the pods leaves OVN with the pod IPs.
+	EnableNamespaceEgressRate bool `gcfg:"enable-namespace-egress-rate"`
+	// TCPMSSClamp clamps the MSS of the TCP connections the node routes out of the gateway bridge and of the
+	// management port to the path MTU, so that the connections to external destinations behind tunnels with a
+	// reduced MTU are not blackholed when the ICMP errors of path MTU discovery are filtered on the way. The pods
+	// only go through the routing of the node in local gateway mode.
+	TCPMSSClamp bool `gcfg:"tcp-mss-clamp"`
+	// ControlPlaneVIPs is a comma separated list of the IPs or CIDRs of the control plane VIPs kube-vip or
+	// keepalived move between the nodes, e.g. "192.168.1.100,fd00::100". They are never selected as the
+	// addresses of the gateway, never removed from the interfaces of the node, and the traffic of the pods to
+	// them is not masqueraded.
+	ControlPlaneVIPs string `gcfg:"control-plane-vips"`
+}
+
+// GetInterfaceExcludePatterns returns the patterns of the interfaces never selected as the autodetected
+// gateway interface
+func (cfg *GatewayConfig) GetInterfaceExcludePatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(cfg.InterfaceExclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// GetControlPlaneVIPs returns the networks of the control plane VIPs, an IP without a prefix length being a
+// single address network
+func (cfg *GatewayConfig) GetControlPlaneVIPs() []*net.IPNet {
+	var vips []*net.IPNet
+	for _, vip := range strings.Split(cfg.ControlPlaneVIPs, ",") {
+		if vip = strings.TrimSpace(vip); vip == "" {
+			continue
+		}
+		if ipNet, err := parseControlPlaneVIP(vip); err == nil {
+			vips = append(vips, ipNet)
+		}
+	}
+	return vips
+}
+
+func parseControlPlaneVIP(vip string) (*net.IPNet, error) {
+	if !strings.Contains(vip, "/") {
+		ip := net.ParseIP(vip)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid gateway control plane VIP %q", vip)
+		}
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(vip)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway control plane VIP %q: %v", vip, err)
+	}
+	return ipNet, nil
 }
 
 // OvnAuthConfig holds client authentication and location details for
@@ -518,6 +734,114 @@ type OvnKubeNodeConfig struct {
 	MgmtPortNetdev         string `gcfg:"mgmt-port-netdev"`
 	MgmtPortDPResourceName string `gcfg:"mgmt-port-dp-resource-name"`
 	LeaseNS                string `gcfg:"lease-namespace"`
+	// DPUSerial is the serial number of the DPU, published in the DPU topology node annotation
+	DPUSerial string `gcfg:"dpu-serial"`
+	// DPUNodeName is the name of the node of the DPU paired with the DPU host
+	DPUNodeName string `gcfg:"dpu-node-name"`
+	// NoLocalOVN is set on nodes whose ovn-controller runs elsewhere and that have no
+	// Southbound database to connect to; the SB dependent steps are left to the remote side
+	NoLocalOVN bool `gcfg:"no-local-ovn"`
+	// DBDiscoveryService is the "namespace/name" of the Service exposing the OVN Southbound database on a port
+	// named "south"; when set the node follows the database endpoints instead of the static address
+	DBDiscoveryService string `gcfg:"db-discovery-service"`
+	// AnnotationServerSideApply makes the node write its annotations with a server-side apply of a field
+	// manager per subsystem instead of a merge patch
+	AnnotationServerSideApply bool `gcfg:"annotation-server-side-apply"`
+	// DisableSubsystems is a comma separated list of the node subsystems not to start
+	DisableSubsystems string `gcfg:"disable-subsystems"`
+	// ConntrackMax is the value of net.netfilter.nf_conntrack_max; 0 leaves it untouched
+	ConntrackMax int `gcfg:"conntrack-max"`
+	// ConntrackBuckets is the size of the conntrack hash table; 0 leaves it untouched
+	ConntrackBuckets int `gcfg:"conntrack-buckets"`
+	// ConntrackTCPTimeoutEstablished is the idle timeout in seconds of established TCP connections; 0 leaves it untouched
+	ConntrackTCPTimeoutEstablished int `gcfg:"conntrack-tcp-timeout-established"`
+	// ConntrackTCPTimeoutCloseWait is the timeout in seconds of TCP connections in CLOSE_WAIT; 0 leaves it untouched
+	ConntrackTCPTimeoutCloseWait int `gcfg:"conntrack-tcp-timeout-close-wait"`
+	// ConntrackUDPTimeout is the timeout in seconds of unreplied UDP flows; 0 leaves it untouched
+	ConntrackUDPTimeout int `gcfg:"conntrack-udp-timeout"`
+	// ConntrackUDPTimeoutStream is the timeout in seconds of UDP flows that saw traffic in both
+	// directions; 0 leaves it untouched
+	ConntrackUDPTimeoutStream int `gcfg:"conntrack-udp-timeout-stream"`
+	// ARPTimeout is the time in seconds the neighbor entries learned on the gateway bridge and the
+	// management port stay reachable without confirmation; 0 leaves it untouched
+	ARPTimeout int `gcfg:"arp-timeout"`
+	// OVSCPUSet is the CPU list ovs-vswitchd and ovsdb-server are pinned to, in the linux CPU list format
+	OVSCPUSet string `gcfg:"ovs-cpuset"`
+	// OVNControllerCPUSet is the CPU list ovn-controller is pinned to, in the linux CPU list format
+	OVNControllerCPUSet string `gcfg:"ovn-controller-cpuset"`
+	// UplinkRxQueueCPUSet is the CPU list the interrupts of the gateway uplink rx queues are steered to
+	UplinkRxQueueCPUSet string `gcfg:"uplink-rx-queue-cpuset"`
+	// TuningNUMALocal restricts the memory of the pinned daemons to the NUMA node of the gateway uplink,
+	// and steers the uplink rx queues to the CPUs of that NUMA node unless UplinkRxQueueCPUSet is set
+	TuningNUMALocal bool `gcfg:"tuning-numa-local"`
+	// TuningDryRun logs the node tuning changes instead of applying them
+	TuningDryRun bool `gcfg:"tuning-dry-run"`
+	// OVSPortGCDryRun logs the OVS ports of pods that no longer exist instead of removing them from br-int
+	OVSPortGCDryRun bool `gcfg:"ovs-port-gc-dry-run"`
+	// ServiceProbeInterval is the interval in seconds between the probes the node sends to a sample of the
+	// services through the gateway to detect blackholed services; 0 disables the probes
+	ServiceProbeInterval int `gcfg:"service-probe-interval"`
+	// EgressRole is the egress role of the node, egress or non-egress, when the node has no egress role
+	// annotation; the node is an egress node when both are unset
+	EgressRole string `gcfg:"egress-role"`
+	// LocalnetBridgeMappings maps the physical networks of the localnet networks to the OVS bridges of the
+	// node in the ovn-bridge-mappings format, physnet1:br1,physnet2:br2
+	LocalnetBridgeMappings string `gcfg:"localnet-bridge-mappings"`
+	// HealthzTLS serves the node proxy healthz listener over TLS with the node server certificate
+	HealthzTLS bool `gcfg:"healthz-tls"`
+	// AdminBindAddress is the address of the listener serving the node admin APIs, disabled when empty. It
+	// shares the server of the healthz listener when both are bound to the same address.
+	AdminBindAddress string `gcfg:"admin-bind-address"`
+	// AdminTLS serves the admin listener over TLS with the node server certificate
+	AdminTLS bool `gcfg:"admin-tls"`
+	// HostnameMismatchFail fails the node startup and makes the node unhealthy when the kernel hostname
+	// does not match the node name, instead of only reporting it
+	HostnameMismatchFail bool `gcfg:"hostname-mismatch-fail"`
+	// AnnounceLocalnetPodIPs sends a gratuitous ARP or an unsolicited neighbor advertisement for the IPs of
+	// the pod interfaces on localnet networks when they are created, so that the switches of the physical
+	// network learn the pod MACs without waiting for the pods to send traffic
+	AnnounceLocalnetPodIPs bool `gcfg:"announce-localnet-pod-ips"`
+	// NetNS is the path of the network namespace the links, addresses, routes, rules and iptables rules of the
+	// node are configured in, e.g. /var/run/netns/node1 for a nested node. The network namespace ovnkube-node
+	// runs in when empty.
+	NetNS string `gcfg:"netns"`
+	// DrainTimeout is the maximum time in seconds a cordoned node waits for the connections through its gateway
+	// to drain before it stops serving its egress IPs; 0 disables the drain of the cordoned nodes
+	DrainTimeout int `gcfg:"drain-timeout"`
+	// LoadKernelModules loads the kernel modules missing on start with modprobe, instead of only failing the
+	// start on the missing required ones and reporting the ones loaded on demand
+	LoadKernelModules bool `gcfg:"load-kernel-modules"`
+	// APIWriteSpread is the window in seconds after the start the API writes of the node, its annotations and
+	// events, are spread over: the node delays them by an offset within the window derived from its name so that
+	// the nodes of a cluster restarted at once don't write at once; 0 disables the spread
+	APIWriteSpread int `gcfg:"api-write-spread"`
+	// APIWriteQPS is the maximum number of API writes per second of the node, the lease renewals included; 0
+	// disables the limit
+	APIWriteQPS int `gcfg:"api-write-qps"`
+	// ZoneLeaderElection elects a single node of the zone with a lease to run the tasks acting on the objects of
+	// the whole zone, e.g. the cleanup of the stale conntrack entries of the external gateway namespaces or the
+	// pruning of the leases of the removed DPU nodes. Every node runs them otherwise.
+	ZoneLeaderElection bool `gcfg:"zone-leader-election"`
+	// HostTrafficCgroups is a comma separated list of the cgroup v2 paths, e.g. /system.slice/kubelet.service,
+	// whose sockets are marked with an eBPF program as host traffic. The traffic of the marked sockets to the pods
+	// is always SNATed to the management port IP, whatever the address the host process is bound to. The host
+	// traffic is only identified by its addresses when empty.
+	HostTrafficCgroups string `gcfg:"host-traffic-cgroups"`
+	// ETPLocalProbePath DNATs the host traffic, or only the traffic of the host traffic cgroups when set, to the
+	// NodePorts, external IPs and load balancer IPs of the services with externalTrafficPolicy=Local straight to
+	// their endpoints on the node, without SNAT, instead of sending it through the gateway.
+	ETPLocalProbePath bool `gcfg:"etp-local-probe-path"`
+}
+
+// GetHostTrafficCgroups returns the cgroup v2 paths whose sockets are marked as host traffic
+func (cfg *OvnKubeNodeConfig) GetHostTrafficCgroups() []string {
+	var cgroups []string
+	for _, cgroup := range strings.Split(cfg.HostTrafficCgroups, ",") {
+		if cgroup = strings.TrimSpace(cgroup); cgroup != "" {
+			cgroups = append(cgroups, cgroup)
+		}
+	}
+	return cgroups
 }
 
 // ClusterManagerConfig holds configuration for ovnkube-cluster-manager
@@ -732,6 +1056,11 @@ var CommonFlags = []cli.Flag{
 		Name:  "init-node",
 		Usage: "initialize node, requires the name that node is registered with in kubernetes cluster",
 	},
+	&cli.StringFlag{
+		Name: "init-standalone",
+		Usage: "initialize node and an ovnkube-controller for the single-node interconnect zone of the node in the same process, " +
+			"requires the name that node is registered with in kubernetes cluster",
+	},
 	&cli.StringFlag{
 		Name:  "cleanup-node",
 		Usage: "cleanup node, requires the name that node is registered with in kubernetes cluster",
@@ -756,6 +1085,21 @@ var CommonFlags = []cli.Flag{
 		Usage:       "Maximum routable MTU between nodes, used to facilitate an MTU migration procedure where different nodes might be using different MTU values",
 		Destination: &cliConfig.Default.RoutableMTU,
 	},
+	&cli.IntFlag{
+		Name:        "mgmt-port-mtu",
+		Usage:       "MTU of the management port, if not set the management port uses the overlay MTU",
+		Destination: &cliConfig.Default.MgmtPortMTU,
+	},
+	&cli.IntFlag{
+		Name:        "gateway-bridge-mtu",
+		Usage:       "MTU of the gateway bridge internal port, if not set the MTU of the gateway bridge is left untouched",
+		Destination: &cliConfig.Default.GatewayBridgeMTU,
+	},
+	&cli.IntFlag{
+		Name:        "gateway-patch-mtu",
+		Usage:       "MTU the gateway routers check the packets they send towards the cluster against, if not set the overlay MTU is used",
+		Destination: &cliConfig.Default.GatewayPatchMTU,
+	},
 	&cli.IntFlag{
 		Name:        "conntrack-zone",
 		Usage:       "For gateway nodes, the conntrack zone used for conntrack flow rules (default: 64000)",
@@ -770,9 +1114,15 @@ var CommonFlags = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:        "encap-ip",
-		Usage:       "The IP address of the encapsulation endpoint (default: Node IP address resolved from Node hostname)",
+		Usage:       "The IP address of the encapsulation endpoint, or a comma separated IPv4 and IPv6 address for an endpoint per IP family (default: Node IP address resolved from Node hostname)",
 		Destination: &cliConfig.Default.EncapIP,
 	},
+	&cli.StringFlag{
+		Name: "node-ip-exclude",
+		Usage: "A comma separated list of CIDRs and shell patterns of interface names never selected for the " +
+			"encap IP or the gateway interface when they are autodetected, e.g. \"192.168.100.0/24,bmc*\"",
+		Destination: &cliConfig.Default.RawNodeIPExclude,
+	},
 	&cli.UintFlag{
 		Name:        "encap-port",
 		Usage:       "The UDP port used by the encapsulation endpoint (default: 6081)",
@@ -840,6 +1190,27 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Default.LFlowCacheLimitKb,
 		Value:       Default.LFlowCacheLimitKb,
 	},
+	&cli.BoolFlag{
+		Name: "lflow-cache-adaptive",
+		Usage: "Adjust the memory limit of the logical flow cache of ovn-controller between " +
+			"lflow-cache-limit-kb-min and lflow-cache-limit-kb-max: the limit is halved when the node " +
+			"is under memory pressure and doubled when the node has memory to spare. lflow-cache-limit-kb " +
+			"is the initial limit, lflow-cache-limit-kb-max when not set.",
+		Destination: &cliConfig.Default.LFlowCacheAdaptive,
+		Value:       Default.LFlowCacheAdaptive,
+	},
+	&cli.UintFlag{
+		Name:        "lflow-cache-limit-kb-min",
+		Usage:       "Lowest memory limit of the logical flow cache in adaptive mode.",
+		Destination: &cliConfig.Default.LFlowCacheLimitKbMin,
+		Value:       Default.LFlowCacheLimitKbMin,
+	},
+	&cli.UintFlag{
+		Name:        "lflow-cache-limit-kb-max",
+		Usage:       "Highest memory limit of the logical flow cache in adaptive mode.",
+		Destination: &cliConfig.Default.LFlowCacheLimitKbMax,
+		Value:       Default.LFlowCacheLimitKbMax,
+	},
 	&cli.StringFlag{
 		Name:        "cluster-subnet",
 		Usage:       "Deprecated alias for cluster-subnets.",
@@ -917,6 +1288,17 @@ var CommonFlags = []cli.Flag{
 		Destination: &cliConfig.Logging.ACLLoggingRateLimit,
 		Value:       20,
 	},
+	&cli.StringFlag{
+		Name:        "log-format",
+		Usage:       "format of the logs, text or json (default: text)",
+		Destination: &cliConfig.Logging.Format,
+	},
+	&cli.StringFlag{
+		Name: "subsystem-loglevels",
+		Usage: "comma separated list of <subsystem>=<level> overriding the log verbosity of subsystems, " +
+			"e.g. node=5,gateway=4. The node subsystems are node, gateway, services, node-ip, dpu and health. It can be changed at runtime through the /debug/flags/subsystems endpoint of the metrics server",
+		Destination: &cliConfig.Logging.SubsystemLevels,
+	},
 	&cli.StringFlag{
 		Name:        "zone",
 		Usage:       "zone name to which ovnkube-node/ovnkube-controller belongs to",
@@ -987,6 +1369,18 @@ var CNIFlags = []cli.Flag{
 		Destination: &cliConfig.CNI.Plugin,
 		Value:       CNI.Plugin,
 	},
+	&cli.DurationFlag{
+		Name:        "cni-request-timeout",
+		Usage:       "the time budget of a CNI request in the CNI server (default: 2m, the kubelet CRI operation timeout)",
+		Destination: &cliConfig.CNI.RequestTimeout,
+		Value:       CNI.RequestTimeout,
+	},
+	&cli.DurationFlag{
+		Name: "cni-ovn-installed-timeout",
+		Usage: "the time a CNI ADD waits for the OVS interface of the pod to be ovn-installed, i.e. for its " +
+			"flows to be programmed, before failing (default: 0, the wait is bounded by the request time budget)",
+		Destination: &cliConfig.CNI.OVNInstalledTimeout,
+	},
 }
 
 // OVNK8sFeatureFlags capture OVN-Kubernetes feature related options
@@ -1026,6 +1420,12 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Usage:       "Configure EgressIP node reachability using gRPC on this TCP port.",
 		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
 	},
+	&cli.BoolFlag{
+		Name: "egressip-ndp-proxy",
+		Usage: "Proxy the neighbor discovery of the IPv6 egress IPs assigned to a node on its primary interface " +
+			"whose prefix is not on-link.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNDPProxy,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-multi-network",
 		Usage:       "Configure to use multiple NetworkAttachmentDefinition CRD feature with ovn-kubernetes.",
@@ -1086,6 +1486,18 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableServiceTemplateSupport,
 		Value:       OVNKubernetesFeature.EnableServiceTemplateSupport,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-service-announcement",
+		Usage:       "Configure to use ServiceAnnouncement CRD feature with ovn-kubernetes.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableServiceAnnouncement,
+		Value:       OVNKubernetesFeature.EnableServiceAnnouncement,
+	},
+	&cli.BoolFlag{
+		Name:        "enable-physical-networks",
+		Usage:       "Configure to use PhysicalNetwork CRD feature with ovn-kubernetes, reconciling the ovn-bridge-mappings of the nodes.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePhysicalNetworks,
+		Value:       OVNKubernetesFeature.EnablePhysicalNetworks,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1249,6 +1661,36 @@ var MetricsFlags = []cli.Flag{
 		Usage:       "Enables metrics related to scaling",
 		Destination: &cliConfig.Metrics.EnableScaleMetrics,
 	},
+	&cli.StringFlag{
+		Name: "metrics-loglevel-token-file",
+		Usage: "File holding the bearer token of the /debug/loglevel endpoint of the metrics server, to get and set " +
+			"the log levels at runtime. The endpoint is served only when it is set.",
+		Destination: &cliConfig.Metrics.LogLevelTokenFile,
+	},
+	&cli.IntFlag{
+		Name: "metrics-conntrack-service-top-n",
+		Usage: "Number of services with the most conntrack entries on the node, by ClusterIP and NodePort, " +
+			"reported in metrics. The conntrack entries are not counted when it is 0 (default: 0)",
+		Destination: &cliConfig.Metrics.ConntrackServiceTopN,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-pod-conntrack",
+		Usage: "Enables the metrics of the conntrack entries of the connections of the pods of the node, " +
+			"by pod, including the entries whose source is translated by the gateway SNAT",
+		Destination: &cliConfig.Metrics.EnablePodConntrackMetrics,
+	},
+	&cli.StringFlag{
+		Name: "metrics-tracing-endpoint",
+		Usage: "The host:port of the OTLP gRPC collector to export the traces of the CNI requests and the control " +
+			"loops to, over plaintext gRPC, e.g. a collector agent on the node. Nothing is traced when it is empty.",
+		Destination: &cliConfig.Metrics.TracingEndpoint,
+	},
+	&cli.IntFlag{
+		Name: "metrics-tracing-sampling-rate-per-million",
+		Usage: "How many of the traces started by ovnkube are sampled per million, the traces propagated from " +
+			"another component are sampled as they are there (default: 1000000)",
+		Destination: &cliConfig.Metrics.TracingSamplingRatePerMillion,
+	},
 }
 
 // OvnNBFlags capture OVN northbound database options
@@ -1350,6 +1792,19 @@ var OVNGatewayFlags = []cli.Flag{
 			"interface. Only useful with \"init-gateways\"",
 		Destination: &cliConfig.Gateway.Interface,
 	},
+	&cli.StringFlag{
+		Name: "gateway-interface-exclude",
+		Usage: "A comma separated list of shell patterns of the interfaces never selected as the gateway " +
+			"interface when it is autodetected from the default routes of the node, e.g. \"wg*,tailscale0\".",
+		Destination: &cliConfig.Gateway.InterfaceExclude,
+	},
+	&cli.StringFlag{
+		Name: "gateway-control-plane-vips",
+		Usage: "A comma separated list of the IPs or CIDRs of the control plane VIPs moved between the nodes " +
+			"by kube-vip or keepalived, never selected as gateway addresses, never removed from the interfaces " +
+			"and not masqueraded as destinations of the pod traffic.",
+		Destination: &cliConfig.Gateway.ControlPlaneVIPs,
+	},
 	&cli.StringFlag{
 		Name: "exgw-interface",
 		Usage: "The interface on nodes that will be used for external gw network traffic. " +
@@ -1361,7 +1816,11 @@ var OVNGatewayFlags = []cli.Flag{
 		Usage: "The external default gateway which is used as a next hop by " +
 			"OVN gateway.  This is many times just the default gateway " +
 			"of the node in question. If not specified, the default gateway" +
-			"configured in the node is used. Only useful with " +
+			"configured in the node is used. In full node mode, several comma " +
+			"separated next hops of an IP family are health checked and used as " +
+			"ECMP default routes of the gateway router, the dead ones being removed " +
+			"until they recover, and the host default route fails over between them. " +
+			"Only useful with " +
 			"\"init-gateways\"",
 		Destination: &cliConfig.Gateway.NextHop,
 	},
@@ -1377,6 +1836,12 @@ var OVNGatewayFlags = []cli.Flag{
 			"Valid only for Shared Gateway interface mode.",
 		Destination: &cliConfig.Gateway.VLANID,
 	},
+	&cli.BoolFlag{
+		Name: "gateway-vlan-sub-interface",
+		Usage: "Create a VLAN sub-interface of the gateway interface tagged with the gateway VLAN ID, " +
+			"move the IP addresses and routes of the gateway interface to it and build the gateway bridge on it.",
+		Destination: &cliConfig.Gateway.VLANSubInterface,
+	},
 	&cli.BoolFlag{
 		Name:        "nodeport",
 		Usage:       "Setup nodeport based ingress on gateways.",
@@ -1440,6 +1905,83 @@ var OVNGatewayFlags = []cli.Flag{
 		Usage:       "Allow the external gateway bridge without an uplink port in local gateway mode",
 		Destination: &cliConfig.Gateway.AllowNoUplink,
 	},
+	&cli.StringFlag{
+		Name:        "nodeport-range",
+		Usage:       "The port range used for NodePort services, it must match the kube-apiserver --service-node-port-range",
+		Destination: &cliConfig.Gateway.NodePortRange,
+		Value:       Gateway.NodePortRange,
+	},
+	&cli.BoolFlag{
+		Name:        "reserve-nodeport-range",
+		Usage:       "Add the node port range to net.ipv4.ip_local_reserved_ports on the node",
+		Destination: &cliConfig.Gateway.ReserveNodePortRange,
+	},
+	&cli.StringFlag{
+		Name: "ipv6-ra-policy",
+		Usage: "Sets the policy for IPv6 router advertisements on the gateway interface. Either \"disabled\" " +
+			"or \"managed\". If not given, the router advertisement settings of the interface are left untouched.",
+	},
+	&cli.StringFlag{
+		Name: "ipv6-addr-gen-mode",
+		Usage: "Sets how the gateway interface generates its autoconfigured IPv6 addresses and disables " +
+			"their temporary addresses. One of \"eui64\" or \"stable-privacy\", the latter needs the " +
+			"stable_secret sysctl of the host. If not given, the address generation settings of the interface " +
+			"are left untouched.",
+	},
+	&cli.StringFlag{
+		Name: "ipv6-token",
+		Usage: "The interface identifier, for example ::10, of the autoconfigured IPv6 addresses of the gateway " +
+			"interface, preferred as the gateway IPv6 address.",
+		Destination: &cliConfig.Gateway.IPv6Token,
+	},
+	&cli.BoolFlag{
+		Name: "gateway-enable-flowtable",
+		Usage: "Offload the established pod egress connections to an nftables flowtable (software fastpath) " +
+			"on the management port and gateway bridge. Only supported in local gateway mode.",
+		Destination: &cliConfig.Gateway.EnableFlowtable,
+	},
+	&cli.StringFlag{
+		Name: "gateway-nat64-prefix",
+		Usage: "IPv6 prefix of the stateful NAT64 (jool) translating the traffic of the IPv6 pods to the IPv4 " +
+			"addresses embedded in it, for example 64:ff9b::/96. The cluster DNS must synthesize the AAAA records " +
+			"(DNS64) with the same prefix. Only supported in local gateway mode.",
+		Destination: &cliConfig.Gateway.NAT64Prefix,
+	},
+	&cli.IntFlag{
+		Name: "gateway-pod-snat-port-range-size",
+		Usage: "Number of source ports of the node IP each pod is SNATed to by the gateway router, the ephemeral " +
+			"port range of the node is partitioned into ranges of this size allocated to the local pods by " +
+			"ovnkube-node. Only supported in shared gateway mode with disable-snat-multiple-gws (default: 0, the " +
+			"pods share all the ports)",
+		Destination: &cliConfig.Gateway.PodSNATPortRangeSize,
+	},
+	&cli.BoolFlag{
+		Name: "gateway-enable-duplicate-address-detection",
+		Usage: "Watch the ARP and NDP packets received on the gateway bridges for other MACs claiming the pod IPs, " +
+			"egress IPs and masquerade IPs of the node, and report the conflicts in node events.",
+		Destination: &cliConfig.Gateway.EnableDuplicateAddressDetection,
+	},
+	&cli.BoolFlag{
+		Name: "gateway-enable-latency-critical-bypass",
+		Usage: "Forward the traffic of the pods annotated with k8s.ovn.org/latency-critical=true between the physical " +
+			"port and OVN without the conntrack of the gateway bridge. Only supported in shared gateway mode with " +
+			"disable-snat-multiple-gws.",
+		Destination: &cliConfig.Gateway.EnableLatencyCriticalBypass,
+	},
+	&cli.BoolFlag{
+		Name: "gateway-enable-namespace-egress-rate",
+		Usage: "Limit the egress rate of the pods of the namespaces annotated with k8s.ovn.org/egress-rate on each " +
+			"node with a meter of the gateway bridge. Only supported in shared gateway mode with " +
+			"disable-snat-multiple-gws.",
+		Destination: &cliConfig.Gateway.EnableNamespaceEgressRate,
+	},
+	&cli.BoolFlag{
+		Name: "gateway-tcp-mss-clamp",
+		Usage: "Clamp the MSS of the TCP connections the node routes out of the gateway bridge and of the " +
+			"management port to the path MTU, for the clusters reaching external destinations through tunnels " +
+			"with a reduced MTU.",
+		Destination: &cliConfig.Gateway.TCPMSSClamp,
+	},
 	// Deprecated CLI options
 	&cli.BoolFlag{
 		Name:        "init-gateways",
@@ -1537,6 +2079,48 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.LeaseNS,
 		Destination: &cliConfig.OvnKubeNode.LeaseNS,
 	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-dpu-serial",
+		Usage:       "serial number of the DPU, published in the DPU topology of the node in dpu and dpu-host modes",
+		Value:       OvnKubeNode.DPUSerial,
+		Destination: &cliConfig.OvnKubeNode.DPUSerial,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-dpu-node-name",
+		Usage:       "name of the node of the DPU paired with the host, published in the DPU topology of the node in dpu and dpu-host modes",
+		Value:       OvnKubeNode.DPUNodeName,
+		Destination: &cliConfig.OvnKubeNode.DPUNodeName,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-no-local-ovn",
+		Usage: "Set on nodes whose ovn-controller runs elsewhere. The node does not connect to the Southbound " +
+			"database and leaves the zone check and the encapsulation settings to the remote ovn-controller side",
+		Value:       OvnKubeNode.NoLocalOVN,
+		Destination: &cliConfig.OvnKubeNode.NoLocalOVN,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-db-discovery-service",
+		Usage: "namespace/name of the Service exposing the OVN Southbound database on a port named south. When set, " +
+			"ovnkube-node updates the Southbound database address and ovn-remote when the database endpoints change",
+		Value:       OvnKubeNode.DBDiscoveryService,
+		Destination: &cliConfig.OvnKubeNode.DBDiscoveryService,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-annotation-server-side-apply",
+		Usage: "Write the node annotations with a server-side apply instead of a merge patch. Each subsystem " +
+			"of ovnkube-node applies its annotations with its own field manager, the annotations a subsystem " +
+			"doesn't manage anymore are pruned and the annotations applied by other field managers are not overwritten",
+		Value:       OvnKubeNode.AnnotationServerSideApply,
+		Destination: &cliConfig.OvnKubeNode.AnnotationServerSideApply,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-disable-subsystems",
+		Usage: "Comma separated list of the optional ovnkube-node subsystems not to start, for example " +
+			"\"nodeport-range-checker,transit-switch-health\". The subsystems depending on a disabled one are not " +
+			"started either",
+		Value:       OvnKubeNode.DisableSubsystems,
+		Destination: &cliConfig.OvnKubeNode.DisableSubsystems,
+	},
 	&cli.StringFlag{
 		Name: "ovnkube-node-mgmt-port-netdev",
 		Usage: "When provided, use this netdev as management port. It will be renamed to ovn-k8s-mp0 " +
@@ -1552,6 +2136,175 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.MgmtPortDPResourceName,
 		Destination: &cliConfig.OvnKubeNode.MgmtPortDPResourceName,
 	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-ovs-cpuset",
+		Usage: "CPU list ovs-vswitchd and ovsdb-server are pinned to, for example \"0-3,8\". When set, OVS is not " +
+			"pinned to the CPUs of ovnkube-node by /etc/openvswitch/enable_dynamic_cpu_affinity",
+		Destination: &cliConfig.OvnKubeNode.OVSCPUSet,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-ovn-controller-cpuset",
+		Usage:       "CPU list ovn-controller is pinned to, for example \"4-5\"",
+		Destination: &cliConfig.OvnKubeNode.OVNControllerCPUSet,
+	},
+	&cli.StringFlag{
+		Name:        "ovnkube-node-uplink-rx-queue-cpuset",
+		Usage:       "CPU list the interrupts of the gateway uplink rx queues are steered to, for example \"0-3\"",
+		Destination: &cliConfig.OvnKubeNode.UplinkRxQueueCPUSet,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-tuning-numa-local",
+		Usage: "Restrict the memory of the pinned OVS and OVN daemons to the NUMA node of the gateway uplink, " +
+			"and steer the uplink rx queues to the CPUs of that NUMA node unless ovnkube-node-uplink-rx-queue-cpuset is set",
+		Destination: &cliConfig.OvnKubeNode.TuningNUMALocal,
+	},
+	&cli.BoolFlag{
+		Name:        "ovnkube-node-tuning-dry-run",
+		Usage:       "Log the CPU pinning, rx queue and NUMA memory changes of the node tuning instead of applying them",
+		Destination: &cliConfig.OvnKubeNode.TuningDryRun,
+	},
+	&cli.BoolFlag{
+		Name:        "ovnkube-node-ovs-port-gc-dry-run",
+		Usage:       "Log the OVS ports of br-int referencing pods that no longer exist instead of removing them",
+		Destination: &cliConfig.OvnKubeNode.OVSPortGCDryRun,
+	},
+	&cli.IntFlag{
+		Name: "ovnkube-node-service-probe-interval",
+		Usage: "Interval in seconds between the probes the node sends to the ClusterIP and NodePort of a sample of the " +
+			"services with ready endpoints, the gateway flows are resynced when a probe fails. 0 disables the probes",
+		Destination: &cliConfig.OvnKubeNode.ServiceProbeInterval,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-egress-role",
+		Usage: "Egress role of the node when it has no k8s.ovn.org/egress-role annotation: egress or non-egress. " +
+			"A non-egress node does not run the egress IP and egress service controllers and is not assigned egress IPs " +
+			"and egress services. Defaults to egress",
+		Destination: &cliConfig.OvnKubeNode.EgressRole,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-localnet-bridge-mappings",
+		Usage: "Comma separated list of physical network to OVS bridge mappings of the localnet networks, " +
+			"for example \"physnet1:br-ex1,physnet2:br-ex2\". The mappings are added to ovn-bridge-mappings " +
+			"when a localnet network using the physical network is created",
+		Destination: &cliConfig.OvnKubeNode.LocalnetBridgeMappings,
+	},
+	&cli.BoolFlag{
+		Name:        "ovnkube-node-healthz-tls",
+		Usage:       "Serve the node proxy healthz listener over TLS with the node-server-cert certificate",
+		Destination: &cliConfig.OvnKubeNode.HealthzTLS,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-admin-bind-address",
+		Usage: "The IP address and port for the node admin APIs to serve on. The listener shares the server of the " +
+			"healthz listener when bound to the same address. Disabled by default",
+		Destination: &cliConfig.OvnKubeNode.AdminBindAddress,
+	},
+	&cli.BoolFlag{
+		Name:        "ovnkube-node-admin-tls",
+		Usage:       "Serve the node admin listener over TLS with the node-server-cert certificate",
+		Destination: &cliConfig.OvnKubeNode.AdminTLS,
+	},
+	&cli.BoolFlag{
+		Name:        "ovnkube-node-hostname-mismatch-fail",
+		Usage:       "Fail the node startup and make the node unhealthy when the kernel hostname does not match the node name, instead of only reporting it",
+		Destination: &cliConfig.OvnKubeNode.HostnameMismatchFail,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-announce-localnet-pod-ips",
+		Usage: "Send a gratuitous ARP or an unsolicited neighbor advertisement for the IPs of the pod interfaces " +
+			"on localnet networks when they are created, for physical networks slow to learn the pod MACs",
+		Destination: &cliConfig.OvnKubeNode.AnnounceLocalnetPodIPs,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-netns",
+		Usage: "Path of the network namespace the node networking is configured in, e.g. /var/run/netns/node1 " +
+			"for a nested node. Defaults to the network namespace ovnkube-node runs in",
+		Destination: &cliConfig.OvnKubeNode.NetNS,
+	},
+	&cli.IntFlag{
+		Name: "ovnkube-node-drain-timeout",
+		Usage: "Maximum time in seconds a cordoned node waits for the connections through its gateway to drain " +
+			"before it stops serving its egress IPs. The node fails its health checks as soon as it is cordoned so " +
+			"that the load balancers stop sending it new connections. 0 disables the drain",
+		Destination: &cliConfig.OvnKubeNode.DrainTimeout,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-load-kernel-modules",
+		Usage: "Load the kernel modules used by the node and missing on start with modprobe. The start fails " +
+			"when openvswitch or nf_conntrack is missing otherwise, and the modules the kernel loads on demand, " +
+			"as the tunnel and vrf ones, are only reported",
+		Destination: &cliConfig.OvnKubeNode.LoadKernelModules,
+	},
+	&cli.IntFlag{
+		Name: "ovnkube-node-api-write-spread",
+		Usage: "Window in seconds after the start the API writes of the node (annotations and events) are " +
+			"spread over, each node delaying them by an offset derived from its name so that the nodes of a cluster " +
+			"restarted at once don't write at once. The writes failing with a transient error are retried with a " +
+			"jittered exponential backoff. 0 disables the spread",
+		Destination: &cliConfig.OvnKubeNode.APIWriteSpread,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-api-write-qps",
+		Usage:       "Maximum number of API writes per second of the node, the lease renewals included. 0 disables the limit",
+		Destination: &cliConfig.OvnKubeNode.APIWriteQPS,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-zone-leader-election",
+		Usage: "Elect a single node of the zone with a lease to run the tasks acting on the objects of the whole " +
+			"zone, like the cleanup of the stale conntrack entries of the external gateway namespaces or the pruning " +
+			"of the leases of the removed DPU nodes. Every node of the zone runs them otherwise",
+		Destination: &cliConfig.OvnKubeNode.ZoneLeaderElection,
+	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-host-traffic-cgroups",
+		Usage: "Comma separated list of the cgroup v2 paths (e.g. /system.slice/kubelet.service) whose sockets " +
+			"are marked with an eBPF program as host traffic, always SNATed to the management port IP when sent " +
+			"to the pods whatever the address the host process is bound to",
+		Destination: &cliConfig.OvnKubeNode.HostTrafficCgroups,
+	},
+	&cli.BoolFlag{
+		Name: "ovnkube-node-etp-local-probe-path",
+		Usage: "DNAT the host traffic, or only the traffic of the host traffic cgroups when set, to the services " +
+			"with externalTrafficPolicy=Local straight to their endpoints on the node without SNAT, so that the " +
+			"kubelet probes of the services don't go through the gateway",
+		Destination: &cliConfig.OvnKubeNode.ETPLocalProbePath,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-max",
+		Usage:       "Maximum number of conntrack entries on the node (net.netfilter.nf_conntrack_max). 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackMax,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-buckets",
+		Usage:       "Size of the conntrack hash table on the node (net.netfilter.nf_conntrack_buckets). 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackBuckets,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-tcp-timeout-established",
+		Usage:       "Idle timeout in seconds of established TCP conntrack entries. 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackTCPTimeoutEstablished,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-tcp-timeout-close-wait",
+		Usage:       "Timeout in seconds of TCP conntrack entries in CLOSE_WAIT state. 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackTCPTimeoutCloseWait,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-udp-timeout",
+		Usage:       "Timeout in seconds of unreplied UDP conntrack entries. 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackUDPTimeout,
+	},
+	&cli.IntFlag{
+		Name:        "ovnkube-node-conntrack-udp-timeout-stream",
+		Usage:       "Timeout in seconds of UDP conntrack entries that saw traffic in both directions. 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ConntrackUDPTimeoutStream,
+	},
+	&cli.IntFlag{
+		Name: "ovnkube-node-arp-timeout",
+		Usage: "Time in seconds the IPv4 and IPv6 neighbor entries learned on the gateway bridge and the management port " +
+			"stay reachable without confirmation (base_reachable_time_ms). 0 leaves the kernel value untouched",
+		Destination: &cliConfig.OvnKubeNode.ARPTimeout,
+	},
 	&cli.BoolFlag{
 		Name:        "disable-ovn-iface-id-ver",
 		Usage:       "Deprecated; iface-id-ver is always enabled",
@@ -1818,6 +2571,13 @@ func buildMetricsConfig(cli, file *config) error {
 		return err
 	}
 
+	if Metrics.ConntrackServiceTopN < 0 {
+		return fmt.Errorf("invalid metrics-conntrack-service-top-n %d, must not be negative", Metrics.ConntrackServiceTopN)
+	}
+	if Metrics.TracingSamplingRatePerMillion < 0 || Metrics.TracingSamplingRatePerMillion > 1000000 {
+		return fmt.Errorf("invalid metrics-tracing-sampling-rate-per-million %d, must be between 0 and 1000000",
+			Metrics.TracingSamplingRatePerMillion)
+	}
 	return nil
 }
 
@@ -1828,6 +2588,8 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 	}
 
 	cli.Gateway.Mode = GatewayMode(ctx.String("gateway-mode"))
+	cli.Gateway.IPv6RAPolicy = IPv6RAPolicy(ctx.String("ipv6-ra-policy"))
+	cli.Gateway.IPv6AddrGenMode = IPv6AddrGenMode(ctx.String("ipv6-addr-gen-mode"))
 	if cli.Gateway.Mode == GatewayModeDisabled {
 		// Handle legacy CLI options
 		if ctx.Bool("init-gateways") {
@@ -1866,10 +2628,120 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 		}
 	}
 
+	for _, pattern := range Gateway.GetInterfaceExcludePatterns() {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid gateway interface exclude pattern %q: %v", pattern, err)
+		}
+	}
+
+	for _, vip := range strings.Split(Gateway.ControlPlaneVIPs, ",") {
+		if vip = strings.TrimSpace(vip); vip == "" {
+			continue
+		}
+		if _, err := parseControlPlaneVIP(vip); err != nil {
+			return err
+		}
+	}
+
 	if Gateway.Mode != GatewayModeShared && Gateway.VLANID != 0 {
 		return fmt.Errorf("gateway VLAN ID option: %d is supported only in shared gateway mode", Gateway.VLANID)
 	}
 
+	if Gateway.VLANSubInterface && (Gateway.VLANID == 0 || Gateway.Interface == "") {
+		return fmt.Errorf("gateway VLAN sub-interface option requires the gateway VLAN ID and interface options")
+	}
+
+	if Gateway.Mode != GatewayModeLocal && Gateway.EnableFlowtable {
+		return fmt.Errorf("gateway flowtable option is supported only in local gateway mode")
+	}
+
+	if Gateway.PodSNATPortRangeSize != 0 {
+		if Gateway.PodSNATPortRangeSize < 0 || Gateway.PodSNATPortRangeSize > PodSNATPortRangeMax-PodSNATPortRangeMin+1 {
+			return fmt.Errorf("invalid gateway pod SNAT port range size %d, must be between 1 and %d",
+				Gateway.PodSNATPortRangeSize, PodSNATPortRangeMax-PodSNATPortRangeMin+1)
+		}
+		if Gateway.Mode != GatewayModeShared || !Gateway.DisableSNATMultipleGWs {
+			return fmt.Errorf("gateway pod SNAT port range size option is supported only in shared gateway mode " +
+				"with disable-snat-multiple-gws")
+		}
+	}
+
+	if Gateway.EnableLatencyCriticalBypass && (Gateway.Mode != GatewayModeShared || !Gateway.DisableSNATMultipleGWs) {
+		return fmt.Errorf("gateway latency critical bypass option is supported only in shared gateway mode " +
+			"with disable-snat-multiple-gws")
+	}
+
+	if Gateway.EnableNamespaceEgressRate && (Gateway.Mode != GatewayModeShared || !Gateway.DisableSNATMultipleGWs) {
+		return fmt.Errorf("gateway namespace egress rate option is supported only in shared gateway mode " +
+			"with disable-snat-multiple-gws")
+	}
+
+	if Gateway.NAT64Prefix != "" {
+		if Gateway.Mode != GatewayModeLocal {
+			return fmt.Errorf("gateway NAT64 prefix option is supported only in local gateway mode")
+		}
+		if err := validateNAT64Prefix(Gateway.NAT64Prefix); err != nil {
+			return err
+		}
+	}
+
+	if _, err := knet.ParsePortRange(Gateway.NodePortRange); err != nil {
+		return fmt.Errorf("invalid nodeport range %q: %v", Gateway.NodePortRange, err)
+	}
+
+	switch Gateway.IPv6RAPolicy {
+	case IPv6RAPolicyUnmanaged, IPv6RAPolicyDisabled, IPv6RAPolicyManaged:
+	default:
+		return fmt.Errorf("invalid IPv6 router advertisement policy %q: expect one of %s,%s",
+			Gateway.IPv6RAPolicy, IPv6RAPolicyDisabled, IPv6RAPolicyManaged)
+	}
+
+	switch Gateway.IPv6AddrGenMode {
+	case IPv6AddrGenModeUnmanaged, IPv6AddrGenModeEUI64, IPv6AddrGenModeStablePrivacy:
+	default:
+		return fmt.Errorf("invalid IPv6 address generation mode %q: expect one of %s,%s",
+			Gateway.IPv6AddrGenMode, IPv6AddrGenModeEUI64, IPv6AddrGenModeStablePrivacy)
+	}
+
+	if Gateway.IPv6Token != "" {
+		if Gateway.IPv6AddrGenMode == IPv6AddrGenModeStablePrivacy {
+			return fmt.Errorf("IPv6 token is not supported with the %s address generation mode", IPv6AddrGenModeStablePrivacy)
+		}
+		if err := validateIPv6Token(Gateway.IPv6Token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIPv6Token checks the token is an IPv6 interface identifier: a non zero address within ::/64
+func validateIPv6Token(token string) error {
+	ip := net.ParseIP(token)
+	if ip == nil || !utilnet.IsIPv6(ip) {
+		return fmt.Errorf("invalid IPv6 token %q: must be an IPv6 address", token)
+	}
+	_, identifiers, _ := net.ParseCIDR("::/64")
+	if !identifiers.Contains(ip) || ip.IsUnspecified() {
+		return fmt.Errorf("invalid IPv6 token %q: must be a non zero interface identifier within ::/64", token)
+	}
+	return nil
+}
+
+// validateNAT64Prefix checks the prefix is an IPv6 prefix of one of the lengths of RFC 6052
+func validateNAT64Prefix(prefix string) error {
+	ip, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil || !utilnet.IsIPv6(ip) {
+		return fmt.Errorf("invalid gateway NAT64 prefix %q: must be an IPv6 prefix", prefix)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return fmt.Errorf("invalid gateway NAT64 prefix %q: host bits are set", prefix)
+	}
+	switch ones, _ := ipNet.Mask.Size(); ones {
+	case 32, 40, 48, 56, 64, 96:
+	default:
+		return fmt.Errorf("invalid gateway NAT64 prefix %q: length must be one of 32, 40, 48, 56, 64 or 96", prefix)
+	}
 	return nil
 }
 
@@ -2104,6 +2976,31 @@ func buildDefaultConfig(cli, file *config) error {
 	if Default.Zone == "" {
 		Default.Zone = types.OvnDefaultZone
 	}
+
+	if Default.MgmtPortMTU < 0 {
+		return fmt.Errorf("invalid mgmt-port-mtu %d", Default.MgmtPortMTU)
+	}
+	if Default.GatewayPatchMTU < 0 {
+		return fmt.Errorf("invalid gateway-patch-mtu %d", Default.GatewayPatchMTU)
+	}
+	if Default.GatewayBridgeMTU != 0 && Default.GatewayBridgeMTU < Default.MTU {
+		return fmt.Errorf("gateway-bridge-mtu %d must not be lower than the overlay MTU %d", Default.GatewayBridgeMTU, Default.MTU)
+	}
+
+	if Default.LFlowCacheAdaptive {
+		if !Default.LFlowCacheEnable {
+			return fmt.Errorf("lflow-cache-adaptive requires the logical flow cache to be enabled")
+		}
+		if Default.LFlowCacheLimitKbMin == 0 || Default.LFlowCacheLimitKbMax < Default.LFlowCacheLimitKbMin {
+			return fmt.Errorf("lflow-cache-adaptive requires 0 < lflow-cache-limit-kb-min (%d) <= lflow-cache-limit-kb-max (%d)",
+				Default.LFlowCacheLimitKbMin, Default.LFlowCacheLimitKbMax)
+		}
+		if Default.LFlowCacheLimitKb != 0 &&
+			(Default.LFlowCacheLimitKb < Default.LFlowCacheLimitKbMin || Default.LFlowCacheLimitKb > Default.LFlowCacheLimitKbMax) {
+			return fmt.Errorf("lflow-cache-limit-kb %d must be between lflow-cache-limit-kb-min %d and lflow-cache-limit-kb-max %d",
+				Default.LFlowCacheLimitKb, Default.LFlowCacheLimitKbMin, Default.LFlowCacheLimitKbMax)
+		}
+	}
 	return nil
 }
 
@@ -2119,6 +3016,11 @@ func completeDefaultConfig(allSubnets *configSubnets) error {
 		allSubnets.append(configSubnetCluster, subnet.CIDR)
 	}
 
+	Default.NodeIPExclude, err = ParseNodeIPExclusions(Default.RawNodeIPExclude)
+	if err != nil {
+		return fmt.Errorf("node-ip-exclude invalid: %v", err)
+	}
+
 	Default.HostMasqConntrackZone = Default.ConntrackZone + 1
 	Default.OVNMasqConntrackZone = Default.ConntrackZone + 2
 	Default.HostNodePortConntrackZone = Default.ConntrackZone + 3
@@ -2126,6 +3028,59 @@ func completeDefaultConfig(allSubnets *configSubnets) error {
 	return nil
 }
 
+// NodeIPExclusions are the networks and the interfaces never selected for the encap IP or the gateway interface
+// when they are autodetected
+type NodeIPExclusions struct {
+	CIDRs []*net.IPNet
+	// Interfaces are shell patterns of interface names
+	Interfaces []string
+}
+
+// ParseNodeIPExclusions parses a comma separated list of CIDRs and shell patterns of interface names, the
+// entries with a "/" are CIDRs since interface names can't have one
+func ParseNodeIPExclusions(raw string) (NodeIPExclusions, error) {
+	var exclusions NodeIPExclusions
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, cidr, err := net.ParseCIDR(entry)
+			if err != nil {
+				return NodeIPExclusions{}, fmt.Errorf("invalid CIDR %q: %v", entry, err)
+			}
+			exclusions.CIDRs = append(exclusions.CIDRs, cidr)
+			continue
+		}
+		if _, err := filepath.Match(entry, ""); err != nil {
+			return NodeIPExclusions{}, fmt.Errorf("invalid interface pattern %q: %v", entry, err)
+		}
+		exclusions.Interfaces = append(exclusions.Interfaces, entry)
+	}
+	return exclusions, nil
+}
+
+// ExcludedNetwork returns the excluded network of the IP, nil if it is not excluded
+func (e NodeIPExclusions) ExcludedNetwork(ip net.IP) *net.IPNet {
+	for _, cidr := range e.CIDRs {
+		if cidr.Contains(ip) {
+			return cidr
+		}
+	}
+	return nil
+}
+
+// ExcludedInterfacePattern returns the pattern excluding the interface, empty if it is not excluded
+func (e NodeIPExclusions) ExcludedInterfacePattern(name string) string {
+	for _, pattern := range e.Interfaces {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
 // getConfigFilePath returns config file path and 'true' if the config file is
 // the fallback path (eg not given by the user), 'false' if given explicitly
 // by the user
@@ -2227,6 +3182,13 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	if err = overrideFields(&CNI, &cliConfig.CNI, &savedCNI); err != nil {
 		return "", err
 	}
+	if CNI.RequestTimeout <= 0 {
+		return "", fmt.Errorf("invalid CNI request timeout %s: must be positive", CNI.RequestTimeout)
+	}
+	if CNI.OVNInstalledTimeout < 0 || CNI.OVNInstalledTimeout > CNI.RequestTimeout {
+		return "", fmt.Errorf("invalid CNI ovn-installed timeout %s: must be between 0 and the request timeout %s",
+			CNI.OVNInstalledTimeout, CNI.RequestTimeout)
+	}
 
 	// Logging setup
 	if err = overrideFields(&Logging, &cfg.Logging, &savedLogging); err != nil {
@@ -2236,10 +3198,17 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
-	var level klog.Level
-	if err := level.Set(strconv.Itoa(Logging.Level)); err != nil {
+	if err := logging.SetVerbosity(klog.Level(Logging.Level)); err != nil {
 		return "", fmt.Errorf("failed to set klog log level %v", err)
 	}
+	subsystemLevels, err := logging.ParseLevels(Logging.SubsystemLevels)
+	if err != nil {
+		return "", fmt.Errorf("invalid subsystem-loglevels: %v", err)
+	}
+	if Logging.Format != "" && Logging.Format != logging.FormatText && Logging.Format != logging.FormatJSON {
+		return "", fmt.Errorf("invalid log-format %q, must be %q or %q", Logging.Format, logging.FormatText, logging.FormatJSON)
+	}
+	var logOutput io.Writer = os.Stderr
 	if Logging.File != "" {
 		klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
 		klog.InitFlags(klogFlags)
@@ -2249,14 +3218,20 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		if err := klogFlags.Set("alsologtostderr", "true"); err != nil {
 			klog.Errorf("Error setting klog alsologtostderr: %v", err)
 		}
-		klog.SetOutput(&lumberjack.Logger{
+		logFile := &lumberjack.Logger{
 			Filename:   Logging.File,
 			MaxSize:    Logging.LogFileMaxSize, // megabytes
 			MaxBackups: Logging.LogFileMaxBackups,
 			MaxAge:     Logging.LogFileMaxAge, // days
 			Compress:   true,
-		})
+		}
+		klog.SetOutput(logFile)
+		logOutput = io.MultiWriter(os.Stderr, logFile)
+	}
+	if Logging.Format == logging.FormatJSON {
+		logging.EnableJSON(logOutput)
 	}
+	logging.SetLevels(subsystemLevels)
 
 	if err = buildDefaultConfig(&cliConfig, &cfg); err != nil {
 		return "", err
@@ -2543,12 +3518,26 @@ func (a *OvnAuthConfig) ensureCACert() error {
 	return nil
 }
 
+// dbAddressMutex guards the address of the OVN database configurations, ovnkube-node points
+// itself to another Southbound database at runtime
+var dbAddressMutex sync.RWMutex
+
 // GetURL returns a URL suitable for passing to ovn-northd which describes the
 // transport mechanism for connection to the database
 func (a *OvnAuthConfig) GetURL() string {
+	dbAddressMutex.RLock()
+	defer dbAddressMutex.RUnlock()
 	return a.Address
 }
 
+// SetAddress updates the address of the database at runtime, SetDBAuth has to be called for
+// the Southbound database clients like ovn-controller to use it
+func (a *OvnAuthConfig) SetAddress(address string) {
+	dbAddressMutex.Lock()
+	defer dbAddressMutex.Unlock()
+	a.Address = address
+}
+
 // SetDBAuth sets the authentication configuration and connection method
 // for the OVN northbound or southbound database server or client
 func (a *OvnAuthConfig) SetDBAuth() error {
@@ -2599,7 +3588,7 @@ func (a *OvnAuthConfig) updateIP(newIPs []string, port string) {
 	for _, ipAddress := range newIPs {
 		newAddresses = append(newAddresses, fmt.Sprintf("%v:%s", a.Scheme, net.JoinHostPort(ipAddress, port)))
 	}
-	a.Address = strings.Join(newAddresses, ",")
+	a.SetAddress(strings.Join(newAddresses, ","))
 }
 
 // UpdateOVNNodeAuth updates the host and URL in ClientAuth
@@ -2626,6 +3615,33 @@ func ovnKubeNodeModeSupported(mode string) error {
 	return nil
 }
 
+// ParseBridgeMappings parses bridge mappings in the ovn-bridge-mappings format, physnet1:br1,physnet2:br2,
+// into a map of the physical network names to the bridge names
+func ParseBridgeMappings(mappings string) (map[string]string, error) {
+	bridgeMappings := map[string]string{}
+	if strings.TrimSpace(mappings) == "" {
+		return bridgeMappings, nil
+	}
+	for _, mapping := range strings.Split(mappings, ",") {
+		physNet, bridge, found := strings.Cut(strings.TrimSpace(mapping), ":")
+		if !found || physNet == "" || bridge == "" {
+			return nil, fmt.Errorf("bridge mapping %q must be in the physnet:bridge format", mapping)
+		}
+		if _, ok := bridgeMappings[physNet]; ok {
+			return nil, fmt.Errorf("physical network %s is mapped more than once", physNet)
+		}
+		bridgeMappings[physNet] = bridge
+	}
+	return bridgeMappings, nil
+}
+
+// LocalnetBridgeMappings returns the configured bridges of the physical networks of the localnet networks
+func LocalnetBridgeMappings() map[string]string {
+	// validated when the configuration was built
+	bridgeMappings, _ := ParseBridgeMappings(OvnKubeNode.LocalnetBridgeMappings)
+	return bridgeMappings
+}
+
 // buildOvnKubeNodeConfig updates OvnKubeNode config from cli and config file
 func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 	// Copy config file values over default values
@@ -2648,6 +3664,42 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("hybrid overlay is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
 	}
 
+	// ovnkube-node-mode dpu runs ovn-controller on behalf of the host
+	if OvnKubeNode.Mode == types.NodeModeDPU && OvnKubeNode.NoLocalOVN {
+		return fmt.Errorf("ovnkube-node-no-local-ovn is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
+	}
+
+	switch OvnKubeNode.EgressRole {
+	case "", types.NodeEgressRoleEgress, types.NodeEgressRoleNonEgress:
+	default:
+		return fmt.Errorf("invalid ovnkube-node-egress-role %q, must be %s or %s", OvnKubeNode.EgressRole,
+			types.NodeEgressRoleEgress, types.NodeEgressRoleNonEgress)
+	}
+
+	if _, err := ParseBridgeMappings(OvnKubeNode.LocalnetBridgeMappings); err != nil {
+		return fmt.Errorf("invalid ovnkube-node-localnet-bridge-mappings: %w", err)
+	}
+	if _, ok := LocalnetBridgeMappings()[types.PhysicalNetworkName]; ok {
+		return fmt.Errorf("invalid ovnkube-node-localnet-bridge-mappings: physical network %s is reserved "+
+			"for the gateway bridge", types.PhysicalNetworkName)
+	}
+
+	if OvnKubeNode.NetNS != "" {
+		if !filepath.IsAbs(OvnKubeNode.NetNS) {
+			return fmt.Errorf("ovnkube-node-netns %q must be an absolute path", OvnKubeNode.NetNS)
+		}
+		// the DPU host networking is configured by the DPU
+		if OvnKubeNode.Mode != types.NodeModeFull {
+			return fmt.Errorf("ovnkube-node-netns is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
+		}
+	}
+
+	if OvnKubeNode.DBDiscoveryService != "" {
+		if parts := strings.Split(OvnKubeNode.DBDiscoveryService, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("ovnkube-node-db-discovery-service %q must be in the namespace/name format", OvnKubeNode.DBDiscoveryService)
+		}
+	}
+
 	// Warn the user if both MgmtPortNetdev and MgmtPortDPResourceName are specified since they
 	// configure the management port.
 	if OvnKubeNode.MgmtPortNetdev != "" && OvnKubeNode.MgmtPortDPResourceName != "" {
@@ -2666,5 +3718,71 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 	if OvnKubeNode.Mode == types.NodeModeDPUHost && OvnKubeNode.MgmtPortNetdev == "" && OvnKubeNode.MgmtPortDPResourceName == "" {
 		return fmt.Errorf("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided")
 	}
+	if OvnKubeNode.ServiceProbeInterval < 0 {
+		return fmt.Errorf("ovnkube-node-service-probe-interval %d must not be negative", OvnKubeNode.ServiceProbeInterval)
+	}
+	if OvnKubeNode.DrainTimeout < 0 {
+		return fmt.Errorf("ovnkube-node-drain-timeout %d must not be negative", OvnKubeNode.DrainTimeout)
+	}
+	if OvnKubeNode.APIWriteSpread < 0 {
+		return fmt.Errorf("ovnkube-node-api-write-spread %d must not be negative", OvnKubeNode.APIWriteSpread)
+	}
+	if OvnKubeNode.APIWriteQPS < 0 {
+		return fmt.Errorf("ovnkube-node-api-write-qps %d must not be negative", OvnKubeNode.APIWriteQPS)
+	}
+	for _, cgroup := range OvnKubeNode.GetHostTrafficCgroups() {
+		if !filepath.IsAbs(cgroup) || filepath.Clean(cgroup) != cgroup {
+			return fmt.Errorf("ovnkube-node-host-traffic-cgroups %q must be a clean absolute cgroup path", cgroup)
+		}
+	}
+	if (OvnKubeNode.HealthzTLS || OvnKubeNode.AdminTLS) && (Metrics.NodeServerCert == "" || Metrics.NodeServerPrivKey == "") {
+		return fmt.Errorf("ovnkube-node-healthz-tls and ovnkube-node-admin-tls require node-server-cert and node-server-privkey")
+	}
+	if OvnKubeNode.AdminBindAddress != "" && OvnKubeNode.AdminBindAddress == Kubernetes.HealthzBindAddress &&
+		OvnKubeNode.AdminTLS != OvnKubeNode.HealthzTLS {
+		return fmt.Errorf("ovnkube-node-admin-tls and ovnkube-node-healthz-tls must be the same when the admin and " +
+			"healthz listeners are bound to the same address")
+	}
+	// the timeout is set in milliseconds
+	if OvnKubeNode.ARPTimeout < 0 || OvnKubeNode.ARPTimeout > math.MaxInt32/1000 {
+		return fmt.Errorf("ovnkube-node-arp-timeout %d must be between 0 and %d", OvnKubeNode.ARPTimeout, math.MaxInt32/1000)
+	}
+	return validateConntrackConfig()
+}
+
+// validateConntrackConfig checks the conntrack settings are within the bounds accepted by the kernel
+func validateConntrackConfig() error {
+	const (
+		minConntrackMax = 1024
+		// maxConntrackTimeout is the largest timeout the kernel accepts, in seconds
+		maxConntrackTimeout = math.MaxInt32 / 1000
+	)
+	if OvnKubeNode.ConntrackMax != 0 && (OvnKubeNode.ConntrackMax < minConntrackMax || OvnKubeNode.ConntrackMax > math.MaxInt32) {
+		return fmt.Errorf("ovnkube-node-conntrack-max %d must be between %d and %d",
+			OvnKubeNode.ConntrackMax, minConntrackMax, math.MaxInt32)
+	}
+	if OvnKubeNode.ConntrackBuckets < 0 || OvnKubeNode.ConntrackBuckets > math.MaxInt32 {
+		return fmt.Errorf("ovnkube-node-conntrack-buckets %d must be between 0 and %d",
+			OvnKubeNode.ConntrackBuckets, math.MaxInt32)
+	}
+	if OvnKubeNode.ConntrackMax != 0 && OvnKubeNode.ConntrackBuckets > OvnKubeNode.ConntrackMax {
+		return fmt.Errorf("ovnkube-node-conntrack-buckets %d must not be larger than ovnkube-node-conntrack-max %d",
+			OvnKubeNode.ConntrackBuckets, OvnKubeNode.ConntrackMax)
+	}
+	for name, timeout := range map[string]int{
+		"ovnkube-node-conntrack-tcp-timeout-established": OvnKubeNode.ConntrackTCPTimeoutEstablished,
+		"ovnkube-node-conntrack-tcp-timeout-close-wait":  OvnKubeNode.ConntrackTCPTimeoutCloseWait,
+		"ovnkube-node-conntrack-udp-timeout":             OvnKubeNode.ConntrackUDPTimeout,
+		"ovnkube-node-conntrack-udp-timeout-stream":      OvnKubeNode.ConntrackUDPTimeoutStream,
+	} {
+		if timeout < 0 || timeout > maxConntrackTimeout {
+			return fmt.Errorf("%s %d must be between 0 and %d", name, timeout, maxConntrackTimeout)
+		}
+	}
+	if OvnKubeNode.ConntrackUDPTimeout != 0 && OvnKubeNode.ConntrackUDPTimeoutStream != 0 &&
+		OvnKubeNode.ConntrackUDPTimeoutStream < OvnKubeNode.ConntrackUDPTimeout {
+		return fmt.Errorf("ovnkube-node-conntrack-udp-timeout-stream %d must not be lower than ovnkube-node-conntrack-udp-timeout %d",
+			OvnKubeNode.ConntrackUDPTimeoutStream, OvnKubeNode.ConntrackUDPTimeout)
+	}
 	return nil
 }
@@ -301,6 +301,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(IPFIX.CacheActiveTimeout).To(gomega.Equal(uint(60)))
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/etc/cni/net.d"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("ovn-k8s-cni-overlay"))
+			gomega.Expect(CNI.RequestTimeout).To(gomega.Equal(2 * time.Minute))
+			gomega.Expect(CNI.OVNInstalledTimeout).To(gomega.BeZero())
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(""))
 			gomega.Expect(Kubernetes.BootstrapKubeconfig).To(gomega.Equal(""))
 			gomega.Expect(Kubernetes.CertDir).To(gomega.Equal(""))
@@ -729,6 +731,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Logging.ACLLoggingRateLimit).To(gomega.Equal(30))
 			gomega.Expect(CNI.ConfDir).To(gomega.Equal("/some/cni/dir"))
 			gomega.Expect(CNI.Plugin).To(gomega.Equal("a-plugin"))
+			gomega.Expect(CNI.RequestTimeout).To(gomega.Equal(45 * time.Second))
+			gomega.Expect(CNI.OVNInstalledTimeout).To(gomega.Equal(30 * time.Second))
 			gomega.Expect(Kubernetes.Kubeconfig).To(gomega.Equal(kubeconfigFile))
 			gomega.Expect(Kubernetes.BootstrapKubeconfig).To(gomega.Equal(bootstrapKubeconfigFile))
 			gomega.Expect(Kubernetes.CertDir).To(gomega.Equal(certDir))
@@ -814,6 +818,8 @@ var _ = Describe("Config Operations", func() {
 			"-acl-logging-rate-limit=30",
 			"-cni-conf-dir=/some/cni/dir",
 			"-cni-plugin=a-plugin",
+			"-cni-request-timeout=45s",
+			"-cni-ovn-installed-timeout=30s",
 			"-cluster-subnets=10.130.0.0/15/24",
 			"-k8s-kubeconfig=" + kubeconfigFile,
 			"-bootstrap-kubeconfig=" + bootstrapKubeconfigFile,
@@ -1095,6 +1101,336 @@ enable-pprof=true
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("overrides the IPv6 router advertisement policy from the command line", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Gateway.IPv6RAPolicy).To(gomega.Equal(IPv6RAPolicyManaged))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-ra-policy=managed",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the IPv6 router advertisement policy is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid IPv6 router advertisement policy \"always\": expect one of disabled,managed"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-ra-policy=always",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("overrides the IPv6 address generation mode and token from the command line", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(Gateway.IPv6AddrGenMode).To(gomega.Equal(IPv6AddrGenModeEUI64))
+			gomega.Expect(Gateway.IPv6Token).To(gomega.Equal("::10"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-addr-gen-mode=eui64",
+			"-ipv6-token=::10",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the IPv6 address generation mode is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid IPv6 address generation mode \"random\": expect one of eui64,stable-privacy"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-addr-gen-mode=random",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the IPv6 token is not an interface identifier", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid IPv6 token \"fd00::10\": must be a non zero interface identifier within ::/64"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-token=fd00::10",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the IPv6 token is set with the stable-privacy address generation mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("IPv6 token is not supported with the stable-privacy address generation mode"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-ipv6-addr-gen-mode=stable-privacy",
+			"-ipv6-token=::10",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the conntrack hash table is larger than the conntrack table", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-conntrack-buckets 262144 must not be larger than ovnkube-node-conntrack-max 131072"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-conntrack-max=131072",
+			"-ovnkube-node-conntrack-buckets=262144",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the gateway bridge MTU is lower than the overlay MTU", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway-bridge-mtu 1300 must not be lower than the overlay MTU 1400"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-mtu=1400",
+			"-gateway-bridge-mtu=1300",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the adaptive logical flow cache limit bounds are invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("lflow-cache-adaptive requires 0 < lflow-cache-limit-kb-min (200000) <= lflow-cache-limit-kb-max (100000)"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-lflow-cache-adaptive",
+			"-lflow-cache-limit-kb-min=200000",
+			"-lflow-cache-limit-kb-max=100000",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the conntrack UDP stream timeout is lower than the UDP timeout", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-conntrack-udp-timeout-stream 30 must not be lower than ovnkube-node-conntrack-udp-timeout 60"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-conntrack-udp-timeout=60",
+			"-ovnkube-node-conntrack-udp-timeout-stream=30",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the ARP timeout is negative", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-arp-timeout -1 must be between 0 and 2147483"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-arp-timeout=-1",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the drain timeout is negative", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-drain-timeout -1 must not be negative"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-drain-timeout=-1",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the API write spread is negative", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-api-write-spread -30 must not be negative"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-api-write-spread=-30",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when a host traffic cgroup is not an absolute path", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(
+				"ovnkube-node-host-traffic-cgroups \"system.slice/kubelet.service\" must be a clean absolute cgroup path"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-host-traffic-cgroups=/kubepods.slice, system.slice/kubelet.service",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the no local OVN profile is used in DPU mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-no-local-ovn is not supported with ovnkube-node mode dpu"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-mode=dpu",
+			"-ovnkube-node-no-local-ovn",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the OVN databases discovery service is not a namespace/name", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-db-discovery-service \"ovnkube-db\" must be in the namespace/name format"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-db-discovery-service=ovnkube-db",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the node egress role is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid ovnkube-node-egress-role \"gateway\", must be egress or non-egress"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-egress-role=gateway",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when a node IP exclusion is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("node-ip-exclude invalid: invalid CIDR \"192.168.100.0/33\": " +
+				"invalid CIDR address: 192.168.100.0/33"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-node-ip-exclude=bmc*,192.168.100.0/33",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the node network namespace is not an absolute path", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-netns \"node1\" must be an absolute path"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-netns=node1",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when a node listener serves TLS without the node server certificate", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("ovnkube-node-healthz-tls and ovnkube-node-admin-tls require " +
+				"node-server-cert and node-server-privkey"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-admin-bind-address=127.0.0.1:10257",
+			"-ovnkube-node-admin-tls",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("parses the localnet bridge mappings", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(LocalnetBridgeMappings()).To(gomega.Equal(map[string]string{"tenantblue": "br-blue", "tenantred": "br-red"}))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-localnet-bridge-mappings=tenantblue:br-blue,tenantred:br-red",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when the localnet bridge mappings are invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid ovnkube-node-localnet-bridge-mappings: physical network tenantblue is mapped more than once"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-ovnkube-node-localnet-bridge-mappings=tenantblue:br-blue,tenantblue:br-red",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("returns an error when the gateway mode is invalid", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1123,6 +1459,176 @@ enable-pprof=true
 		err := app.Run(cliArgs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
+	It("returns an error when the VLAN sub-interface is enabled without a vlan-id", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway VLAN sub-interface option requires the gateway VLAN ID and interface options"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-interface=eth0",
+			"-gateway-vlan-sub-interface",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the flowtable is enabled for mode other than local gateway mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway flowtable option is supported only in local gateway mode"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-enable-flowtable",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the pod SNAT port range size is set without the per pod SNAT of shared gateway mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway pod SNAT port range size option is supported only in " +
+				"shared gateway mode with disable-snat-multiple-gws"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-pod-snat-port-range-size=256",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the pod SNAT port range size is larger than the partitioned ports", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid gateway pod SNAT port range size 65536, must be between 1 and 64512"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-disable-snat-multiple-gws",
+			"-gateway-pod-snat-port-range-size=65536",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the latency critical bypass is enabled without disable-snat-multiple-gws", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway latency critical bypass option is supported only in " +
+				"shared gateway mode with disable-snat-multiple-gws"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-enable-latency-critical-bypass",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the namespace egress rate is enabled without disable-snat-multiple-gws", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway namespace egress rate option is supported only in " +
+				"shared gateway mode with disable-snat-multiple-gws"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-enable-namespace-egress-rate",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the NAT64 prefix is set for mode other than local gateway mode", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("gateway NAT64 prefix option is supported only in local gateway mode"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-nat64-prefix=64:ff9b::/96",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the NAT64 prefix length is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid gateway NAT64 prefix \"64:ff9b::/80\": length must be one of 32, 40, 48, 56, 64 or 96"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=local",
+			"-gateway-nat64-prefix=64:ff9b::/80",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the conntrack service top N is negative", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid metrics-conntrack-service-top-n -1, must not be negative"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-metrics-conntrack-service-top-n=-1",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the tracing sampling rate is out of range", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid metrics-tracing-sampling-rate-per-million 2000000, " +
+				"must be between 0 and 1000000"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-metrics-tracing-sampling-rate-per-million=2000000",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when the log format is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(`invalid log-format "xml", must be "text" or "json"`))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-log-format=xml",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when a subsystem log level is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid subsystem-loglevels: invalid subsystem log level \"node\", expected <subsystem>=<level>"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-subsystem-loglevels=gateway=5,node",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
 	It("returns an error when the v4 join subnet specified is invalid", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1220,6 +1726,45 @@ enable-pprof=true
 		err := app.Run(cliArgs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
+	It("returns an error when the CNI ovn-installed timeout exceeds the request timeout", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid CNI ovn-installed timeout 3m0s: must be between 0 and the request timeout 2m0s"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-cni-ovn-installed-timeout=3m",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when a gateway interface exclude pattern is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(`invalid gateway interface exclude pattern "wg[": syntax error in pattern`))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-interface-exclude=tailscale0, wg[",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+	It("returns an error when a gateway control plane VIP is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError(`invalid gateway control plane VIP "192.168.1.300"`))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-control-plane-vips=192.168.1.100, 192.168.1.300",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
 	It("successfully overrides the default transit switch subnets", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -0,0 +1,86 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DPUNodePairing records which DPU node serves which DPU host node. It is created by the
+// DPU host and both sides of the pair report the health of the pairing in its status.
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=dpunodepairings,scope=Cluster,shortName=dpupairing,singular=dpunodepairing
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Host",type="string",JSONPath=`.spec.hostNodeName`
+// +kubebuilder:printcolumn:name="DPU",type="string",JSONPath=`.spec.dpuNodeName`
+// +kubebuilder:printcolumn:name="DPU Ready",type="string",JSONPath=`.status.conditions[?(@.type=="DPUReady")].status`
+// +kubebuilder:printcolumn:name="Host Ready",type="string",JSONPath=`.status.conditions[?(@.type=="HostReady")].status`
+type DPUNodePairing struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:Required
+	// +required
+	Spec DPUNodePairingSpec `json:"spec"`
+	// +optional
+	Status DPUNodePairingStatus `json:"status,omitempty"`
+}
+
+// DPUNodePairingSpec defines the DPU and DPU host nodes of the pair
+type DPUNodePairingSpec struct {
+	// HostNodeName is the name of the DPU host node.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="hostNodeName is immutable"
+	// +required
+	HostNodeName string `json:"hostNodeName"`
+	// DPUNodeName is the name of the node of the DPU serving the host. The DPU heartbeat
+	// lease is named after it.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	DPUNodeName string `json:"dpuNodeName"`
+}
+
+// DPUNodePairingList contains a list of DPUNodePairings
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DPUNodePairingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DPUNodePairing `json:"items"`
+}
+
+// DPUNodePairingStatus contains the health of the pairing as reported by both sides of the pair.
+type DPUNodePairingStatus struct {
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// DPUReadyCondition is reported by the DPU node, it is true while the DPU runs its heartbeat
+	DPUReadyCondition = "DPUReady"
+	// HostReadyCondition is reported by the DPU host node, it is true while the host sees a valid
+	// heartbeat of the DPU
+	HostReadyCondition = "HostReady"
+)
@@ -0,0 +1,61 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PhysicalNetwork maps a physical network, named after the PhysicalNetwork, to an OVS provider bridge
+// in the ovn-bridge-mappings of the selected nodes. The nodes create the bridge when it is missing.
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=physicalnetworks,scope=Cluster,shortName=physnet,singular=physicalnetwork
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Bridge",type="string",JSONPath=`.spec.bridgeName`
+type PhysicalNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:Required
+	// +required
+	Spec PhysicalNetworkSpec `json:"spec"`
+}
+
+// PhysicalNetworkSpec defines the provider bridge of the physical network and the nodes it is mapped on
+type PhysicalNetworkSpec struct {
+	// NodeSelector selects the nodes the physical network is mapped on, all the nodes when empty.
+	// +optional
+	NodeSelector metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// BridgeName is the name of the OVS bridge providing connectivity to the physical network.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._-]+$`
+	// +required
+	BridgeName string `json:"bridgeName"`
+}
+
+// PhysicalNetworkList contains a list of PhysicalNetworks
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PhysicalNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PhysicalNetwork `json:"items"`
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=serviceannouncements
+// +kubebuilder::singular=serviceannouncement
+// +kubebuilder:object:root=true
+// ServiceAnnouncement is a CRD that allows the user to request that the ingress IPs
+// of the LoadBalancer Services it selects are announced on the L2 network of the nodes
+// it selects. A single node announces each ingress IP at a time, by answering the ARP
+// and NDP requests for it on the gateway bridge and by sending gratuitous ARPs and
+// unsolicited neighbor advertisements when it takes over the IP.
+type ServiceAnnouncement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceAnnouncementSpec `json:"spec,omitempty"`
+}
+
+// ServiceAnnouncementSpec defines the desired state of ServiceAnnouncement
+type ServiceAnnouncementSpec struct {
+	// Selects the LoadBalancer Services of the namespace whose ingress IPs are announced.
+	// An empty selector selects all the LoadBalancer Services of the namespace.
+	ServiceSelector metav1.LabelSelector `json:"serviceSelector"`
+
+	// Allows limiting the nodes that can announce the ingress IPs.
+	// When present only a node whose labels match the specified selectors can be selected
+	// for announcing an ingress IP.
+	// When it is not specified any node in the cluster can be chosen to announce the ingress IPs.
+	// +optional
+	NodeSelector metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=serviceannouncements
+// +kubebuilder::singular=serviceannouncement
+// ServiceAnnouncementList contains a list of ServiceAnnouncements
+type ServiceAnnouncementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceAnnouncement `json:"items"`
+}
@@ -74,6 +74,20 @@ import (
 	userdefinednetworkapiinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/informers/externalversions"
 	userdefinednetworkinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/informers/externalversions/userdefinednetwork/v1"
 
+	serviceannouncementapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1"
+	serviceannouncementscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/clientset/versioned/scheme"
+	serviceannouncementinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/informers/externalversions"
+	serviceannouncementinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/informers/externalversions/serviceannouncement/v1"
+
+	dpunodepairingapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1"
+	dpunodepairingscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned/scheme"
+	dpunodepairinginformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/informers/externalversions"
+	dpunodepairinginformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/informers/externalversions/dpunodepairing/v1"
+	physicalnetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1"
+	physicalnetworkscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/clientset/versioned/scheme"
+	physicalnetworkinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/informers/externalversions"
+	physicalnetworkinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/informers/externalversions/physicalnetwork/v1"
+
 	kapi "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
 	knet "k8s.io/api/networking/v1"
@@ -113,6 +127,9 @@ type WatchFactory struct {
 	ipamClaimsFactory    ipamclaimsfactory.SharedInformerFactory
 	nadFactory           nadinformerfactory.SharedInformerFactory
 	udnFactory           userdefinednetworkapiinformerfactory.SharedInformerFactory
+	saFactory            serviceannouncementinformerfactory.SharedInformerFactory
+	dpuPairingFactory    dpunodepairinginformerfactory.SharedInformerFactory
+	physNetFactory       physicalnetworkinformerfactory.SharedInformerFactory
 	informers            map[reflect.Type]*informer
 
 	stopChan chan struct{}
@@ -507,6 +524,33 @@ func (wf *WatchFactory) Start() error {
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableServiceAnnouncement && wf.saFactory != nil {
+		wf.saFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.saFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
+	if wf.dpuPairingFactory != nil {
+		wf.dpuPairingFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.dpuPairingFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
+	if config.OVNKubernetesFeature.EnablePhysicalNetworks && wf.physNetFactory != nil {
+		wf.physNetFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.physNetFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
 	if wf.ipamClaimsFactory != nil {
 		wf.ipamClaimsFactory.Start(wf.stopChan)
 		for oType, synced := range waitForCacheSyncWithTimeout(wf.ipamClaimsFactory, wf.stopChan) {
@@ -571,6 +615,16 @@ func (wf *WatchFactory) Stop() {
 	if wf.ipamClaimsFactory != nil {
 		wf.ipamClaimsFactory.Shutdown()
 	}
+	if wf.saFactory != nil {
+		wf.saFactory.Shutdown()
+	}
+	if wf.dpuPairingFactory != nil {
+		wf.dpuPairingFactory.Shutdown()
+	}
+
+	if wf.physNetFactory != nil {
+		wf.physNetFactory.Shutdown()
+	}
 
 	if wf.udnFactory != nil {
 		wf.udnFactory.Shutdown()
@@ -698,6 +752,34 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 		wf.apbRouteFactory.K8s().V1().AdminPolicyBasedExternalRoutes().Informer()
 	}
 
+	if config.OVNKubernetesFeature.EnableServiceAnnouncement {
+		if err := serviceannouncementapi.AddToScheme(serviceannouncementscheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.saFactory = serviceannouncementinformerfactory.NewSharedInformerFactory(ovnClientset.ServiceAnnouncementClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.saFactory.Start() it is initialized and caches are synced.
+		wf.saFactory.K8s().V1().ServiceAnnouncements().Informer()
+	}
+
+	// the DPU waits for the pairing created by the DPU host
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		if err := dpunodepairingapi.AddToScheme(dpunodepairingscheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.dpuPairingFactory = dpunodepairinginformerfactory.NewSharedInformerFactory(ovnClientset.DPUNodePairingClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.dpuPairingFactory.Start() it is initialized and caches are synced.
+		wf.dpuPairingFactory.K8s().V1().DPUNodePairings().Informer()
+	}
+
+	if config.OVNKubernetesFeature.EnablePhysicalNetworks {
+		if err := physicalnetworkapi.AddToScheme(physicalnetworkscheme.Scheme); err != nil {
+			return nil, err
+		}
+		wf.physNetFactory = physicalnetworkinformerfactory.NewSharedInformerFactory(ovnClientset.PhysicalNetworkClient, resyncInterval)
+		// make sure shared informer is created for a factory, so on wf.physNetFactory.Start() it is initialized and caches are synced.
+		wf.physNetFactory.K8s().V1().PhysicalNetworks().Informer()
+	}
+
 	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode.
 	// need to know what is the primary network for a namespace on the CNI side, which
 	// needs the NAD factory whenever the UDN feature is used.
@@ -1509,6 +1591,18 @@ func (wf *WatchFactory) EgressServiceInformer() egressserviceinformer.EgressServ
 	return wf.egressServiceFactory.K8s().V1().EgressServices()
 }
 
+func (wf *WatchFactory) ServiceAnnouncementInformer() serviceannouncementinformer.ServiceAnnouncementInformer {
+	return wf.saFactory.K8s().V1().ServiceAnnouncements()
+}
+
+func (wf *WatchFactory) DPUNodePairingInformer() dpunodepairinginformer.DPUNodePairingInformer {
+	return wf.dpuPairingFactory.K8s().V1().DPUNodePairings()
+}
+
+func (wf *WatchFactory) PhysicalNetworkInformer() physicalnetworkinformer.PhysicalNetworkInformer {
+	return wf.physNetFactory.K8s().V1().PhysicalNetworks()
+}
+
 func (wf *WatchFactory) APBRouteInformer() adminpolicybasedrouteinformer.AdminPolicyBasedExternalRouteInformer {
 	return wf.apbRouteFactory.K8s().V1().AdminPolicyBasedExternalRoutes()
 }
@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 )
 
 // Annotator represents the exported methods for handling node annotations
@@ -15,19 +20,51 @@ type Annotator interface {
 	Run() error
 }
 
+// NodeAnnotatorOptions configure how a node annotator writes the annotations
+type NodeAnnotatorOptions struct {
+	// FieldManager, when set, makes the annotator write the annotations with a server-side apply owned
+	// by this field manager instead of a merge patch
+	FieldManager string
+	// OwnedAnnotations, when set with FieldManager, are all the annotations FieldManager manages: the
+	// annotations FieldManager applied before and not in OwnedAnnotations are pruned
+	OwnedAnnotations sets.Set[string]
+	// Backoff is how the writes failing with a transient API error, or with a conflict for the merge
+	// patches, are retried; the writes are not retried when it is not set
+	Backoff *wait.Backoff
+	// OnWrite is called with the number, starting at 1, and the result of every write attempt
+	OnWrite func(attempt int, err error)
+	// BeforeWrite, when set, is called before every write attempt, e.g. to throttle the writes; the write is
+	// not attempted when it returns an error
+	BeforeWrite func() error
+}
+
 type nodeAnnotator struct {
 	kube     Interface
 	nodeName string
+	opts     NodeAnnotatorOptions
 
 	changes map[string]interface{}
 	sync.Mutex
 }
 
+// nodeAnnotationsApplier is implemented by the Interfaces able to set node annotations with a
+// server-side apply
+type nodeAnnotationsApplier interface {
+	ApplyAnnotationsOnNode(nodeName, fieldManager string, owned sets.Set[string], annotations map[string]interface{}) error
+}
+
 // NewNodeAnnotator returns a new annotator for Node objects
 func NewNodeAnnotator(kube Interface, nodeName string) Annotator {
+	return NewNodeAnnotatorWithOptions(kube, nodeName, NodeAnnotatorOptions{})
+}
+
+// NewNodeAnnotatorWithOptions returns a new annotator for Node objects writing the annotations as
+// configured by opts
+func NewNodeAnnotatorWithOptions(kube Interface, nodeName string, opts NodeAnnotatorOptions) Annotator {
 	return &nodeAnnotator{
 		kube:     kube,
 		nodeName: nodeName,
+		opts:     opts,
 		changes:  make(map[string]interface{}),
 	}
 }
@@ -69,7 +106,55 @@ func (na *nodeAnnotator) Run() error {
 		return nil
 	}
 
-	return na.kube.SetAnnotationsOnNode(na.nodeName, na.changes)
+	attempt := 0
+	write := func() error {
+		attempt++
+		return na.write(attempt)
+	}
+	if na.opts.Backoff == nil {
+		return write()
+	}
+	retriable := IsRetriableWriteError
+	if na.opts.FieldManager != "" {
+		// a conflict of a server-side apply is an annotation applied by another field manager, it
+		// doesn't go away by applying again
+		retriable = IsTransientWriteError
+	}
+	return retry.OnError(*na.opts.Backoff, retriable, write)
+}
+
+func (na *nodeAnnotator) write(attempt int) error {
+	if na.opts.BeforeWrite != nil {
+		if err := na.opts.BeforeWrite(); err != nil {
+			return err
+		}
+	}
+	var err error
+	if na.opts.FieldManager != "" {
+		applier, ok := na.kube.(nodeAnnotationsApplier)
+		if !ok {
+			return fmt.Errorf("server-side apply of the annotations of node %s is not supported", na.nodeName)
+		}
+		err = applier.ApplyAnnotationsOnNode(na.nodeName, na.opts.FieldManager, na.opts.OwnedAnnotations, na.changes)
+	} else {
+		err = na.kube.SetAnnotationsOnNode(na.nodeName, na.changes)
+	}
+	if na.opts.OnWrite != nil {
+		na.opts.OnWrite(attempt, err)
+	}
+	return err
+}
+
+// IsRetriableWriteError returns true for the errors of the writes that may succeed when retried: the
+// conflicts and the transient API server errors
+func IsRetriableWriteError(err error) bool {
+	return apierrors.IsConflict(err) || IsTransientWriteError(err)
+}
+
+// IsTransientWriteError returns true for the transient API server errors
+func IsTransientWriteError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err)
 }
 
 // NewPodAnnotator returns a new annotator for Pod objects
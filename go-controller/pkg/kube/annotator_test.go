@@ -2,12 +2,20 @@ package kube
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	kubeMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 
@@ -103,5 +111,200 @@ var _ = Describe("Annotator", func() {
 				}
 			})
 		})
+
+		Context("with options", func() {
+			const nodeName string = "TestNode"
+			var (
+				fakeClient *fake.Clientset
+				kube       *Kube
+				attempts   []error
+				opts       NodeAnnotatorOptions
+			)
+
+			BeforeEach(func() {
+				fakeClient = fake.NewSimpleClientset(&v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        nodeName,
+						Annotations: map[string]string{"initialKey": "initialVal", "deleteKey": "val"},
+					},
+				})
+				kube = &Kube{KClient: fakeClient}
+				attempts = nil
+				opts = NodeAnnotatorOptions{
+					Backoff: &wait.Backoff{Steps: 3, Duration: time.Millisecond},
+					OnWrite: func(attempt int, err error) {
+						Expect(attempt).To(Equal(len(attempts) + 1))
+						attempts = append(attempts, err)
+					},
+				}
+			})
+
+			It("should retry the conflicting writes", func() {
+				conflicts := 0
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					if conflicts < 2 {
+						conflicts++
+						return true, nil, apierrors.NewConflict(v1.Resource("nodes"), nodeName, fmt.Errorf("conflict"))
+					}
+					return false, nil, nil
+				})
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(Succeed())
+				Expect(attempts).To(HaveLen(3))
+				Expect(apierrors.IsConflict(attempts[0])).To(BeTrue())
+				Expect(apierrors.IsConflict(attempts[1])).To(BeTrue())
+				Expect(attempts[2]).ToNot(HaveOccurred())
+
+				node, err := kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(node.Annotations).To(HaveKeyWithValue("key1", "val1"))
+			})
+
+			It("should retry the applies failing with a transient error", func() {
+				failures := 0
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					if failures < 1 {
+						failures++
+						return true, nil, apierrors.NewTooManyRequests("throttled", 1)
+					}
+					return true, &v1.Node{}, nil
+				})
+				opts.FieldManager = "ovnkube-node"
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(Succeed())
+				Expect(attempts).To(HaveLen(2))
+				Expect(apierrors.IsTooManyRequests(attempts[0])).To(BeTrue())
+			})
+
+			It("should not write when the write is not admitted", func() {
+				admitted := 0
+				opts.BeforeWrite = func() error {
+					admitted++
+					return fmt.Errorf("context canceled")
+				}
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(MatchError("context canceled"))
+				Expect(admitted).To(Equal(1))
+				Expect(attempts).To(BeEmpty())
+
+				node, err := kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(node.Annotations).NotTo(HaveKey("key1"))
+			})
+
+			It("should not retry the writes failing with a permanent error", func() {
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(v1.Resource("nodes"), nodeName, fmt.Errorf("forbidden"))
+				})
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(apierrors.IsForbidden(nodeAnnot.Run())).To(BeTrue())
+				Expect(attempts).To(HaveLen(1))
+			})
+
+			It("should apply the annotations with the field manager", func() {
+				var applied map[string]interface{}
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					patch := action.(clienttesting.PatchActionImpl)
+					if patch.GetPatchType() != types.ApplyPatchType {
+						return false, nil, nil
+					}
+					Expect(patch.GetSubresource()).To(Equal("status"))
+					Expect(json.Unmarshal(patch.GetPatch(), &applied)).To(Succeed())
+					return true, &v1.Node{}, nil
+				})
+				opts.FieldManager = "ovnkube-node"
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				nodeAnnot.Delete("deleteKey")
+				Expect(nodeAnnot.Run()).To(Succeed())
+				Expect(attempts).To(Equal([]error{nil}))
+
+				Expect(applied["metadata"]).To(HaveKeyWithValue("annotations", map[string]interface{}{"key1": "val1"}))
+
+				// the deleted annotation set before with a merge patch is removed with a merge patch
+				node, err := kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(node.Annotations).To(Equal(map[string]string{"initialKey": "initialVal"}))
+			})
+
+			It("should prune the applied annotations not managed anymore", func() {
+				node, err := kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				node.Annotations["staleKey"] = "val"
+				node.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:     "ovnkube-node-zone",
+					Operation:   metav1.ManagedFieldsOperationApply,
+					Subresource: "status",
+					FieldsType:  "FieldsV1",
+					FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:staleKey":{},"f:key2":{}}}}`)},
+				}}
+				_, err = fakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				var applied map[string]interface{}
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					patch := action.(clienttesting.PatchActionImpl)
+					if patch.GetPatchType() != types.ApplyPatchType {
+						return false, nil, nil
+					}
+					Expect(json.Unmarshal(patch.GetPatch(), &applied)).To(Succeed())
+					return true, &v1.Node{}, nil
+				})
+
+				opts.FieldManager = "ovnkube-node-zone"
+				opts.OwnedAnnotations = sets.New[string]("key1", "key2")
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(Succeed())
+				Expect(applied["metadata"]).To(HaveKeyWithValue("annotations", map[string]interface{}{"key1": "val1"}))
+
+				node, err = kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(node.Annotations).ToNot(HaveKey("staleKey"))
+				Expect(node.Annotations).To(HaveKeyWithValue("initialKey", "initialVal"))
+			})
+
+			It("should not overwrite the annotations applied by another field manager", func() {
+				node, err := kube.GetNode(nodeName)
+				Expect(err).ToNot(HaveOccurred())
+				node.ManagedFields = []metav1.ManagedFieldsEntry{{
+					Manager:    "other-controller",
+					Operation:  metav1.ManagedFieldsOperationApply,
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:annotations":{"f:initialKey":{}}}}`)},
+				}}
+				_, err = fakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				opts.FieldManager = "ovnkube-node-zone"
+				nodeAnnot := NewNodeAnnotatorWithOptions(kube, nodeName, opts)
+				Expect(nodeAnnot.Set("initialKey", "newVal")).To(Succeed())
+				err = nodeAnnot.Run()
+				Expect(apierrors.IsConflict(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("annotation initialKey is applied by field managers [other-controller]"))
+				// the conflict is returned without applying again
+				Expect(attempts).To(HaveLen(1))
+
+				// applying the same value is not a conflict
+				fakeClient.PrependReactor("patch", "nodes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, &v1.Node{}, nil
+				})
+				attempts = nil
+				Expect(nodeAnnot.Set("initialKey", "initialVal")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(Succeed())
+				Expect(attempts).To(Equal([]error{nil}))
+			})
+
+			It("should fail to apply the annotations without server-side apply support", func() {
+				fakeKubeInterface := kubeMocks.Interface{}
+				opts.FieldManager = "ovnkube-node"
+				nodeAnnot := NewNodeAnnotatorWithOptions(&fakeKubeInterface, nodeName, opts)
+				Expect(nodeAnnot.Set("key1", "val1")).To(Succeed())
+				Expect(nodeAnnot.Run()).To(MatchError("server-side apply of the annotations of node TestNode is not supported"))
+			})
+		})
 	})
 })
@@ -31,9 +31,10 @@ import (
 )
 
 // Server serves HTTP endpoints for each service name, with results
-// based on the endpoints.  If there are 0 endpoints for a service, it returns a
-// 503 "Service Unavailable" error (telling LBs not to use this node).  If there
-// are 1 or more endpoints, it returns a 200 "OK".
+// based on the endpoints.  If there are 0 endpoints for a service, or if the
+// node proxier is not healthy, it returns a 503 "Service Unavailable" error
+// (telling LBs not to use this node).  If there are 1 or more endpoints, it
+// returns a 200 "OK".
 type Server interface {
 	// Make the new set of services be active.  Services that were open before
 	// will be closed.  Services that are new will be opened.  Service that
@@ -46,6 +47,13 @@ type Server interface {
 	SyncEndpoints(newEndpoints map[types.NamespacedName]int) error
 }
 
+// ProxierHealthChecker reports the health of the node proxier. Service health
+// checks fail while it is unhealthy, e.g. while the node is being drained, so that
+// LBs stop sending traffic to the node before its local endpoints go away.
+type ProxierHealthChecker interface {
+	IsHealthy() bool
+}
+
 // Listener allows for testing of Server.  If the Listener argument
 // to NewServer() is nil, the real net.Listen function will be used.
 type Listener interface {
@@ -70,8 +78,10 @@ type HTTPServer interface {
 }
 
 // NewServer allocates a new healthcheck server manager.  If either
-// of the injected arguments are nil, defaults will be used.
-func NewServer(hostname string, recorder record.EventRecorder, listener Listener, httpServerFactory HTTPServerFactory) Server {
+// of the injected arguments are nil, defaults will be used.  If proxierHealth
+// is nil, the proxier is always considered healthy.
+func NewServer(hostname string, recorder record.EventRecorder, listener Listener, httpServerFactory HTTPServerFactory,
+	proxierHealth ProxierHealthChecker) Server {
 	if listener == nil {
 		listener = stdNetListener{}
 	}
@@ -79,11 +89,12 @@ func NewServer(hostname string, recorder record.EventRecorder, listener Listener
 		httpServerFactory = stdHTTPServerFactory{}
 	}
 	return &server{
-		hostname:    hostname,
-		recorder:    recorder,
-		listener:    listener,
-		httpFactory: httpServerFactory,
-		services:    map[types.NamespacedName]*hcInstance{},
+		hostname:      hostname,
+		recorder:      recorder,
+		listener:      listener,
+		httpFactory:   httpServerFactory,
+		proxierHealth: proxierHealth,
+		services:      map[types.NamespacedName]*hcInstance{},
 	}
 }
 
@@ -113,6 +124,8 @@ type server struct {
 	recorder    record.EventRecorder // can be nil
 	listener    Listener
 	httpFactory HTTPServerFactory
+	// proxierHealth can be nil
+	proxierHealth ProxierHealthChecker
 
 	lock     sync.RWMutex
 	services map[types.NamespacedName]*hcInstance
@@ -202,14 +215,16 @@ func (h hcHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	count := svc.endpoints
 	h.hcs.lock.RUnlock()
 
+	proxierHealthy := h.hcs.proxierHealth == nil || h.hcs.proxierHealth.IsHealthy()
 	resp.Header().Set("Content-Type", "application/json")
-	if count == 0 {
-		resp.WriteHeader(http.StatusServiceUnavailable)
-	} else {
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	if count != 0 && proxierHealthy {
 		resp.WriteHeader(http.StatusOK)
+	} else {
+		resp.WriteHeader(http.StatusServiceUnavailable)
 	}
-	fmt.Fprintf(resp, `{ "service": { "namespace": %q, "name": %q }, "localEndpoints": %d }`,
-		h.name.Namespace, h.name.Name, count)
+	fmt.Fprintf(resp, `{ "service": { "namespace": %q, "name": %q }, "localEndpoints": %d, "serviceProxyHealthy": %v }`,
+		h.name.Namespace, h.name.Name, count, proxierHealthy)
 }
 
 func (hcs *server) SyncEndpoints(newEndpoints map[types.NamespacedName]int) error {
@@ -103,7 +103,16 @@ type hcPayload struct {
 		Namespace string
 		Name      string
 	}
-	LocalEndpoints int
+	LocalEndpoints      int
+	ServiceProxyHealthy bool
+}
+
+type fakeProxierHealthChecker struct {
+	healthy bool
+}
+
+func (f *fakeProxierHealthChecker) IsHealthy() bool {
+	return f.healthy
 }
 
 type healthzPayload struct {
@@ -116,7 +125,7 @@ func TestServer(t *testing.T) {
 	listener := newFakeListener()
 	httpFactory := newFakeHTTPServerFactory()
 
-	hcsi := NewServer("hostname", nil, listener, httpFactory)
+	hcsi := NewServer("hostname", nil, listener, httpFactory, nil)
 	hcs := hcsi.(*server)
 	if len(hcs.services) != 0 {
 		t.Errorf("expected 0 services, got %d", len(hcs.services))
@@ -380,7 +389,33 @@ func TestServer(t *testing.T) {
 	testHandler(hcs, nsn4, http.StatusOK, 6, t)
 }
 
-func testHandler(hcs *server, nsn types.NamespacedName, status int, endpoints int, t *testing.T) {
+func TestServerProxierUnhealthy(t *testing.T) {
+	listener := newFakeListener()
+	httpFactory := newFakeHTTPServerFactory()
+	proxierHealth := &fakeProxierHealthChecker{healthy: true}
+
+	hcs := NewServer("hostname", nil, listener, httpFactory, proxierHealth).(*server)
+	nsn := mknsn("a", "b")
+	if err := hcs.SyncServices(map[types.NamespacedName]uint16{nsn: 9376}); err != nil {
+		t.Errorf("unexpected error while syncing services: %v", err)
+	}
+	if err := hcs.SyncEndpoints(map[types.NamespacedName]int{nsn: 3}); err != nil {
+		t.Errorf("unexpected error while syncing endpoints: %v", err)
+	}
+	payload := testHandler(hcs, nsn, http.StatusOK, 3, t)
+	if !payload.ServiceProxyHealthy {
+		t.Errorf("expected service proxy to be reported healthy")
+	}
+
+	// the node is being drained, the health check must fail despite local endpoints
+	proxierHealth.healthy = false
+	payload = testHandler(hcs, nsn, http.StatusServiceUnavailable, 3, t)
+	if payload.ServiceProxyHealthy {
+		t.Errorf("expected service proxy to be reported unhealthy")
+	}
+}
+
+func testHandler(hcs *server, nsn types.NamespacedName, status int, endpoints int, t *testing.T) hcPayload {
 	handler := hcs.services[nsn].server.(*fakeHTTPServer).handler
 	req, err := http.NewRequest("GET", "/healthz", nil)
 	if err != nil {
@@ -403,6 +438,7 @@ func testHandler(hcs *server, nsn types.NamespacedName, status int, endpoints in
 	if payload.LocalEndpoints != endpoints {
 		t.Errorf("expected %d endpoints, got %d", endpoints, payload.LocalEndpoints)
 	}
+	return payload
 }
 
 func testHealthzHandler(server HTTPServer, status int, t *testing.T) {
@@ -3,6 +3,7 @@ package kube
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	ipamclaimsapi "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1"
 	ipamclaimssclientset "github.com/k8snetworkplumbingwg/ipamclaims/pkg/crd/ipamclaims/v1alpha1/apis/clientset/versioned"
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
@@ -15,16 +16,20 @@ import (
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
 	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/kubernetes"
 	kv1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/pager"
 	"k8s.io/klog/v2"
 	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
+	"strings"
 )
 
 // InterfaceOVN represents the exported methods for dealing with getting/setting
@@ -139,6 +144,99 @@ func (k *Kube) SetAnnotationsOnNode(nodeName string, annotations map[string]inte
 	return err
 }
 
+// ApplyAnnotationsOnNode sets the annotations on the node with a server-side apply owned by fieldManager.
+// The annotations applied before by fieldManager are kept, unless owned is set and doesn't have them: owned
+// are then all the annotations fieldManager manages and the others are pruned. The apply fails with a
+// conflict when another field manager applied a different value of one of the annotations.
+// The annotations to delete are removed from the applied configuration, and with a merge patch as well
+// when they were also set by another writer not using server-side apply, for example before server-side
+// apply was used.
+func (k *Kube) ApplyAnnotationsOnNode(nodeName, fieldManager string, owned sets.Set[string], annotations map[string]interface{}) error {
+	node, err := k.KClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	applyConfig, err := corev1apply.ExtractNodeStatus(node, fieldManager)
+	if err != nil {
+		return fmt.Errorf("failed to extract the configuration applied by %s on node %s: %w", fieldManager, nodeName, err)
+	}
+	appliers, err := annotationAppliers(node, fieldManager)
+	if err != nil {
+		return fmt.Errorf("failed to parse the managed fields of node %s: %w", nodeName, err)
+	}
+
+	deleted := map[string]interface{}{}
+	deleteAnnotation := func(key string) {
+		delete(applyConfig.Annotations, key)
+		if _, ok := node.Annotations[key]; ok && len(appliers[key]) == 0 {
+			deleted[key] = nil
+		}
+	}
+	for key := range applyConfig.Annotations {
+		if _, ok := annotations[key]; !ok && owned != nil && !owned.Has(key) {
+			klog.Infof("Pruning annotation %s of node %s not managed by %s anymore", key, nodeName, fieldManager)
+			deleteAnnotation(key)
+		}
+	}
+	for key, value := range annotations {
+		if value == nil {
+			deleteAnnotation(key)
+			continue
+		}
+		strValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("invalid value %v of annotation %s on node %s: not a string", value, key, nodeName)
+		}
+		if len(appliers[key]) > 0 && node.Annotations[key] != strValue {
+			return apierrors.NewConflict(kapi.Resource("nodes"), nodeName,
+				fmt.Errorf("annotation %s is applied by field managers %v", key, sets.List(appliers[key])))
+		}
+		applyConfig.WithAnnotations(map[string]string{key: strValue})
+	}
+
+	klog.Infof("Applying annotations %v on node %s with field manager %s", annotations, nodeName, fieldManager)
+	_, err = k.KClient.CoreV1().Nodes().ApplyStatus(context.TODO(), applyConfig,
+		metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+	if err != nil {
+		klog.Errorf("Error in applying annotations on node %s: %v", nodeName, err)
+		return err
+	}
+	if len(deleted) > 0 {
+		return k.SetAnnotationsOnNode(nodeName, deleted)
+	}
+	return nil
+}
+
+// annotationAppliers returns the field managers other than fieldManager that applied each annotation of
+// the node with a server-side apply
+func annotationAppliers(node *kapi.Node, fieldManager string) (map[string]sets.Set[string], error) {
+	appliers := map[string]sets.Set[string]{}
+	for _, entry := range node.ManagedFields {
+		if entry.Manager == fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Metadata struct {
+				Annotations map[string]interface{} `json:"f:annotations"`
+			} `json:"f:metadata"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, err
+		}
+		for field := range fields.Metadata.Annotations {
+			key, ok := strings.CutPrefix(field, "f:")
+			if !ok {
+				continue
+			}
+			if appliers[key] == nil {
+				appliers[key] = sets.New[string]()
+			}
+			appliers[key].Insert(entry.Manager)
+		}
+	}
+	return appliers, nil
+}
+
 // SetAnnotationsOnNamespace takes the namespace name and map of key/value string pairs to set as annotations
 func (k *Kube) SetAnnotationsOnNamespace(namespaceName string, annotations map[string]interface{}) error {
 	var err error
@@ -96,7 +96,7 @@ func newClient(cfg config.OvnAuthConfig, dbModel model.ClientDBModel, stopCh <-c
 		if err != nil {
 			return nil, err
 		}
-		updateFn, err = newSSLKeyPairWatcherFunc(cfg.Cert, cfg.PrivKey, tlsConfig)
+		updateFn, err = newSSLKeyPairWatcherFunc(cfg.Cert, cfg.PrivKey)
 		if err != nil {
 			return nil, err
 		}
@@ -227,8 +227,7 @@ func NewNBClientWithConfig(cfg config.OvnAuthConfig, promRegistry prometheus.Reg
 }
 
 func createTLSConfig(certFile, privKeyFile, caCertFile, serverName string) (*tls.Config, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, privKeyFile)
-	if err != nil {
+	if _, err := tls.LoadX509KeyPair(certFile, privKeyFile); err != nil {
 		return nil, fmt.Errorf("error generating x509 certs for ovndbapi: %s", err)
 	}
 	caCert, err := os.ReadFile(caCertFile)
@@ -238,17 +237,28 @@ func createTLSConfig(certFile, privKeyFile, caCertFile, serverName string) (*tls
 	caCertPool := x509.NewCertPool()
 	caCertPool.AppendCertsFromPEM(caCert)
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      caCertPool,
-		ServerName:   serverName,
+		RootCAs:    caCertPool,
+		ServerName: serverName,
+		// the key pair is loaded at each handshake so that the client reconnects with the rotated certificate
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, privKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("error loading x509 certs for ovndbapi: %s", err)
+			}
+			return &cert, nil
+		},
 	}
 	return tlsConfig, nil
 }
 
-// Watch TLS key/cert files, and update the ovndb tlsConfig Certificate.
-// Call ovndbclient.Close() will disconnect underlying rpc2client connection.
-// With ovndbclient initalized with reconnect flag, rcp2client will reconnct with new tlsConfig Certificate.
-func newSSLKeyPairWatcherFunc(certFile, privKeyFile string, tlsConfig *tls.Config) (func(client.Client, <-chan struct{}), error) {
+// Watch TLS key/cert files, and disconnect the ovndb client when they change.
+// With ovndbclient initalized with reconnect flag, rcp2client will reconnect with the new certificate
+// the tlsConfig loads at the handshake.
+func newSSLKeyPairWatcherFunc(certFile, privKeyFile string) (func(client.Client, <-chan struct{}), error) {
+	current, err := tls.LoadX509KeyPair(certFile, privKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error generating x509 certs for ovndbapi: %s", err)
+	}
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, err
@@ -285,11 +295,11 @@ func newSSLKeyPairWatcherFunc(certFile, privKeyFile string, tlsConfig *tls.Confi
 						klog.Infof("Cannot load new cert with cert %s key %s err %s", certFile, privKeyFile, err)
 						continue
 					}
-					if reflect.DeepEqual(tlsConfig.Certificates, []tls.Certificate{cert}) {
+					if reflect.DeepEqual(current, cert) {
 						klog.Infof("TLS update already finished")
 						continue
 					}
-					tlsConfig.Certificates = []tls.Certificate{cert}
+					current = cert
 					client.Disconnect()
 					klog.Infof("TLS connection to %s force reconnected with new TLS config", client.Schema().Name)
 					// We do not call client.Connect() as reconnection is handled in the reconnect goroutine
@@ -0,0 +1,103 @@
+package libovsdb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeKeyPair writes a self-signed certificate with the given serial number and its key to certFile and keyFile
+func writeKeyPair(t *testing.T, serial int64, certFile, keyFile string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "ovn"},
+		DNSNames:              []string{"ovn"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(certFile, certPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// clientCertificateSerial returns the serial number of the certificate the client presents to a server
+func clientCertificateSerial(t *testing.T, clientConfig *tls.Config, serverCert tls.Certificate) int64 {
+	t.Helper()
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+	server := tls.Server(serverConn, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tls.Client(clientConn, clientConfig).Handshake()
+	}()
+	if err := server.Handshake(); err != nil {
+		t.Fatalf("server handshake failed: %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Fatalf("client handshake failed: %v", err)
+	}
+	return server.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestCreateTLSConfigServesRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	serverCert := writeKeyPair(t, 100, caFile, filepath.Join(dir, "server.key"))
+	writeKeyPair(t, 1, certFile, keyFile)
+
+	tlsConfig, err := createTLSConfig(certFile, keyFile, caFile, "ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial := clientCertificateSerial(t, tlsConfig, serverCert); serial != 1 {
+		t.Fatalf("expected the client certificate 1, got %d", serial)
+	}
+
+	writeKeyPair(t, 2, certFile, keyFile)
+	if serial := clientCertificateSerial(t, tlsConfig, serverCert); serial != 2 {
+		t.Fatalf("expected the rotated client certificate 2, got %d", serial)
+	}
+}
@@ -250,11 +250,12 @@ func copyIndexes(model model.Model) model.Model {
 			UUID: t.UUID,
 		}
 	case *sbdb.Encap:
+		// the chassis_name client index is not used for the lookup as a chassis may have an encap
+		// per tunnel endpoint
 		return &sbdb.Encap{
-			UUID:        t.UUID,
-			Type:        t.Type,
-			IP:          t.IP,
-			ChassisName: t.ChassisName,
+			UUID: t.UUID,
+			Type: t.Type,
+			IP:   t.IP,
 		}
 	case *sbdb.PortBinding:
 		return &sbdb.PortBinding{
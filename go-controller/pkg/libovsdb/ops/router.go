@@ -1100,6 +1100,25 @@ func CreateOrUpdateNATs(nbClient libovsdbclient.Client, router *nbdb.LogicalRout
 	return err
 }
 
+// UpdateNATsPortRangeOps updates the external port range of the provided
+// existing NATs and returns the corresponding ops
+func UpdateNATsPortRangeOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, nats ...*nbdb.NAT) ([]libovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, len(nats))
+	for i := range nats {
+		nat := nats[i]
+		opModel := operationModel{
+			Model:          nat,
+			OnModelUpdates: []interface{}{&nat.ExternalPortRange},
+			ErrNotFound:    true,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	m := newModelClient(nbClient)
+	return m.CreateOrUpdateOps(ops, opModels...)
+}
+
 // DeleteNATsOps deletes the provided NATs, removes them from the provided
 // logical router and returns the corresponding ops
 func DeleteNATsOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, router *nbdb.LogicalRouter, nats ...*nbdb.NAT) ([]libovsdb.Operation, error) {
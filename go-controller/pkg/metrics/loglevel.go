@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/logging"
+)
+
+// logLevels are the log levels got and set through the /debug/loglevel endpoint
+type logLevels struct {
+	// Verbosity is the global klog verbosity
+	Verbosity *klog.Level `json:"v,omitempty"`
+	// Subsystems are the verbosity of the subsystems, by subsystem name. When set, the subsystems
+	// not listed follow the global klog verbosity again.
+	Subsystems map[string]klog.Level `json:"subsystems,omitempty"`
+}
+
+// logLevelHandler gets the log levels on GET and sets them on PUT or POST, to capture verbose logs
+// of a live process without restarting it. The requests must carry the bearer token of the token file,
+// read on every request so that it can be rotated.
+type logLevelHandler struct {
+	tokenFile string
+}
+
+func newLogLevelHandler(tokenFile string) *logLevelHandler {
+	return &logLevelHandler{tokenFile: tokenFile}
+}
+
+func (h *logLevelHandler) authorized(req *http.Request) (bool, error) {
+	token, err := os.ReadFile(h.tokenFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the log level token file %s: %v", h.tokenFile, err)
+	}
+	expected := strings.TrimSpace(string(token))
+	if expected == "" {
+		return false, fmt.Errorf("log level token file %s is empty", h.tokenFile)
+	}
+	provided, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1, nil
+}
+
+func (h *logLevelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ok, err := h.authorized(req)
+	if err != nil {
+		klog.Errorf("Unable to authorize log level request: %v", err)
+		writePlainText(http.StatusInternalServerError, "unable to authorize request", w)
+		return
+	}
+	if !ok {
+		writePlainText(http.StatusUnauthorized, "unauthorized", w)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		var levels logLevels
+		if err := json.NewDecoder(req.Body).Decode(&levels); err != nil {
+			writePlainText(http.StatusBadRequest, "error decoding request body: "+err.Error(), w)
+			return
+		}
+		defer req.Body.Close()
+		for name, level := range levels.Subsystems {
+			if level < 0 {
+				writePlainText(http.StatusBadRequest, fmt.Sprintf("invalid log level %d of subsystem %s", level, name), w)
+				return
+			}
+		}
+		if levels.Verbosity != nil {
+			if err := logging.SetVerbosity(*levels.Verbosity); err != nil {
+				writePlainText(http.StatusBadRequest, fmt.Sprintf("failed to set klog verbosity: %v", err), w)
+				return
+			}
+		}
+		if levels.Subsystems != nil {
+			logging.SetLevels(levels.Subsystems)
+		}
+		klog.Infof("Log levels set to verbosity %d, subsystems %q", logging.Verbosity(), logging.FormatLevels())
+	default:
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+		return
+	}
+
+	verbosity := logging.Verbosity()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(logLevels{Verbosity: &verbosity, Subsystems: logging.Levels()}); err != nil {
+		klog.Errorf("Failed to write log levels response: %v", err)
+	}
+}
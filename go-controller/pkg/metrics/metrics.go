@@ -17,6 +17,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -450,13 +451,27 @@ type stringFlagSetterFunc func(string) (string, error)
 
 // klogSetter is a setter to set klog level.
 func klogSetter(val string) (string, error) {
-	var level klog.Level
-	if err := level.Set(val); err != nil {
+	level, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("failed set klog.logging.verbosity %s: %v", val, err)
+	}
+	if err := logging.SetVerbosity(klog.Level(level)); err != nil {
 		return "", fmt.Errorf("failed set klog.logging.verbosity %s: %v", val, err)
 	}
 	return fmt.Sprintf("successfully set klog.logging.verbosity to %s", val), nil
 }
 
+// subsystemLevelsSetter is a setter to set the log level of the subsystems, given as a comma separated
+// list of <subsystem>=<level>. The subsystems not listed follow the klog level again.
+func subsystemLevelsSetter(val string) (string, error) {
+	levels, err := logging.ParseLevels(val)
+	if err != nil {
+		return "", fmt.Errorf("failed set subsystem log levels %s: %v", val, err)
+	}
+	logging.SetLevels(levels)
+	return fmt.Sprintf("successfully set subsystem log levels to %q", logging.FormatLevels()), nil
+}
+
 // stringFlagPutHandler wraps an http Handler to set string type flag.
 func stringFlagPutHandler(setter stringFlagSetterFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -482,6 +497,71 @@ func stringFlagPutHandler(setter stringFlagSetterFunc) http.HandlerFunc {
 	})
 }
 
+var (
+	gatewayFlowResyncLock    sync.Mutex
+	gatewayFlowResyncTrigger func()
+)
+
+// SetGatewayFlowResyncTrigger sets the function called to force a full resync of the
+// gateway OpenFlow flows through the /debug/gateway/openflow/resync endpoint
+func SetGatewayFlowResyncTrigger(trigger func()) {
+	gatewayFlowResyncLock.Lock()
+	defer gatewayFlowResyncLock.Unlock()
+	gatewayFlowResyncTrigger = trigger
+}
+
+// gatewayFlowResyncHandler forces a full resync of the gateway OpenFlow flows, to be used
+// when the flows on the gateway bridges are suspected to have drifted from the cache.
+func gatewayFlowResyncHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+		return
+	}
+	gatewayFlowResyncLock.Lock()
+	trigger := gatewayFlowResyncTrigger
+	gatewayFlowResyncLock.Unlock()
+	if trigger == nil {
+		writePlainText(http.StatusServiceUnavailable, "gateway OpenFlow manager is not running", w)
+		return
+	}
+	trigger()
+	writePlainText(http.StatusOK, "gateway OpenFlow resync requested", w)
+}
+
+var (
+	sbReconnectLock    sync.Mutex
+	sbReconnectTrigger func() error
+)
+
+// SetSBReconnectTrigger sets the function called to force ovn-controller to reconnect to the
+// southbound database through the /debug/ovn-controller/sb/reconnect endpoint
+func SetSBReconnectTrigger(trigger func() error) {
+	sbReconnectLock.Lock()
+	defer sbReconnectLock.Unlock()
+	sbReconnectTrigger = trigger
+}
+
+// sbReconnectHandler forces ovn-controller to reconnect to the southbound database, to be used
+// when the southbound database endpoints changed and ovn-controller is stuck on a stale one.
+func sbReconnectHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+		return
+	}
+	sbReconnectLock.Lock()
+	trigger := sbReconnectTrigger
+	sbReconnectLock.Unlock()
+	if trigger == nil {
+		writePlainText(http.StatusServiceUnavailable, "ovn-controller is not managed by this node", w)
+		return
+	}
+	if err := trigger(); err != nil {
+		writePlainText(http.StatusInternalServerError, fmt.Sprintf("failed to reconnect ovn-controller: %v", err), w)
+		return
+	}
+	writePlainText(http.StatusOK, "ovn-controller southbound database reconnect requested", w)
+}
+
 // writePlainText renders a simple string response.
 func writePlainText(statusCode int, text string, w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "text/plain")
@@ -492,10 +572,17 @@ func writePlainText(statusCode int, text string, w http.ResponseWriter) {
 
 // StartMetricsServer runs the prometheus listener so that OVN K8s metrics can be collected
 // It puts the endpoint behind TLS if certFile and keyFile are defined.
-func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, keyFile string,
+func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, keyFile string, logLevelTokenFile string,
 	stopChan <-chan struct{}, wg *sync.WaitGroup) {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	// the exemplars are only exposed in the OpenMetrics format
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
+
+	if logLevelTokenFile != "" {
+		// Allow getting and setting the log levels at runtime, protected by a bearer token
+		mux.Handle("/debug/loglevel", newLogLevelHandler(logLevelTokenFile))
+	}
 
 	if enablePprof {
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
@@ -506,6 +593,13 @@ func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, k
 
 		// Allow changes to log level at runtime
 		mux.HandleFunc("/debug/flags/v", stringFlagPutHandler(klogSetter))
+		mux.HandleFunc("/debug/flags/subsystems", stringFlagPutHandler(subsystemLevelsSetter))
+
+		// Allow forcing a resync of the gateway flows when drift is suspected
+		mux.HandleFunc("/debug/gateway/openflow/resync", gatewayFlowResyncHandler)
+
+		// Allow forcing ovn-controller to reconnect when the southbound database endpoints changed
+		mux.HandleFunc("/debug/ovn-controller/sb/reconnect", sbReconnectHandler)
 	}
 
 	startMetricsServer(bindAddress, certFile, keyFile, mux, stopChan, wg)
@@ -1,8 +1,18 @@
 package metrics
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/logging"
 )
 
 func Test_parseStopwatchShowOutput(t *testing.T) {
@@ -133,3 +143,145 @@ Statistics for 'ovn-northd-loop'
 		})
 	}
 }
+
+func Test_gatewayFlowResyncHandler(t *testing.T) {
+	defer SetGatewayFlowResyncTrigger(nil)
+
+	resync := func(method string) int {
+		req := httptest.NewRequest(method, "/debug/gateway/openflow/resync", nil)
+		resp := httptest.NewRecorder()
+		gatewayFlowResyncHandler(resp, req)
+		return resp.Code
+	}
+
+	if code := resync(http.MethodPut); code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d without a gateway, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	triggered := 0
+	SetGatewayFlowResyncTrigger(func() { triggered++ })
+	if code := resync(http.MethodGet); code != http.StatusNotAcceptable {
+		t.Errorf("expected status %d for GET, got %d", http.StatusNotAcceptable, code)
+	}
+	if code := resync(http.MethodPost); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if triggered != 1 {
+		t.Errorf("expected the resync to be triggered once, got %d", triggered)
+	}
+}
+
+func Test_sbReconnectHandler(t *testing.T) {
+	defer SetSBReconnectTrigger(nil)
+
+	reconnect := func(method string) int {
+		req := httptest.NewRequest(method, "/debug/ovn-controller/sb/reconnect", nil)
+		resp := httptest.NewRecorder()
+		sbReconnectHandler(resp, req)
+		return resp.Code
+	}
+
+	if code := reconnect(http.MethodPost); code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d without ovn-controller, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	var err error
+	triggered := 0
+	SetSBReconnectTrigger(func() error {
+		triggered++
+		return err
+	})
+	if code := reconnect(http.MethodGet); code != http.StatusNotAcceptable {
+		t.Errorf("expected status %d for GET, got %d", http.StatusNotAcceptable, code)
+	}
+	if code := reconnect(http.MethodPost); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+	err = fmt.Errorf("failed")
+	if code := reconnect(http.MethodPut); code != http.StatusInternalServerError {
+		t.Errorf("expected status %d when the reconnect fails, got %d", http.StatusInternalServerError, code)
+	}
+	if triggered != 2 {
+		t.Errorf("expected the reconnect to be triggered twice, got %d", triggered)
+	}
+}
+
+func Test_updateSBDBConnectionMetricReconnects(t *testing.T) {
+	counterValue := func() float64 {
+		m := &dto.Metric{}
+		if err := metricOVNControllerSBDBReconnects.Write(m); err != nil {
+			t.Fatalf("failed to read the reconnects counter: %v", err)
+		}
+		return m.GetCounter().GetValue()
+	}
+	status := "not connected"
+	ovsAppctl := func(args ...string) (string, string, error) {
+		return status, "", nil
+	}
+	start := counterValue()
+
+	// ovn-controller connecting for the first time is not a reconnection
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	status = "connected"
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	if got := counterValue() - start; got != 0 {
+		t.Errorf("expected no reconnection, got %v", got)
+	}
+
+	status = "not connected"
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	status = "connected"
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	updateSBDBConnectionMetric(ovsAppctl, 1, 0)
+	if got := counterValue() - start; got != 1 {
+		t.Errorf("expected one reconnection, got %v", got)
+	}
+}
+
+func Test_logLevelHandler(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	verbosity := logging.Verbosity()
+	defer func() {
+		_ = logging.SetVerbosity(verbosity)
+		logging.SetLevels(nil)
+	}()
+
+	handler := newLogLevelHandler(tokenFile)
+	request := func(method, token, body string) (int, string) {
+		req := httptest.NewRequest(method, "/debug/loglevel", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp.Code, strings.TrimSpace(resp.Body.String())
+	}
+
+	if code, _ := request(http.MethodGet, "", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected status %d without a token, got %d", http.StatusUnauthorized, code)
+	}
+	if code, _ := request(http.MethodGet, "wrong", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected status %d with a wrong token, got %d", http.StatusUnauthorized, code)
+	}
+	if code, _ := request(http.MethodDelete, "secret", ""); code != http.StatusNotAcceptable {
+		t.Errorf("expected status %d for DELETE, got %d", http.StatusNotAcceptable, code)
+	}
+	if code, _ := request(http.MethodPut, "secret", `{"subsystems":{"node":-1}}`); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a negative level, got %d", http.StatusBadRequest, code)
+	}
+	code, body := request(http.MethodPut, "secret", `{"v":6,"subsystems":{"node":5}}`)
+	if code != http.StatusOK || body != `{"v":6,"subsystems":{"node":5}}` {
+		t.Errorf("unexpected response %d %s", code, body)
+	}
+	if !logging.Subsystem("node").V(5).Enabled() || logging.Verbosity() != 6 {
+		t.Errorf("expected the log levels to be set")
+	}
+	code, body = request(http.MethodGet, "secret", "")
+	if code != http.StatusOK || body != `{"v":6,"subsystems":{"node":5}}` {
+		t.Errorf("unexpected response %d %s", code, body)
+	}
+}
@@ -20,6 +20,26 @@ var MetricCNIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOp
 	[]string{"command", "err"},
 )
 
+// MetricCNIPodSetupDuration is a prometheus metric that tracks the duration of the stages of the setup of the
+// pod interfaces by the CNI server, with the trace of the CNI request as exemplar
+var MetricCNIPodSetupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "cni_pod_setup_stage_duration_seconds",
+	Help: "The duration of the successful stages of the setup of a pod interface: waiting for the pod annotation " +
+		"(annotation_wait), adding the OVS port (ovs_port_add) and waiting for the port to be ovn-installed (flows_ready).",
+	Buckets: prometheus.ExponentialBuckets(.01, 2, 15)},
+	//labels
+	[]string{"stage", "network"},
+)
+
+// The stages of the setup of a pod interface of MetricCNIPodSetupDuration
+const (
+	PodSetupStageAnnotationWait = "annotation_wait"
+	PodSetupStageOVSPortAdd     = "ovs_port_add"
+	PodSetupStageFlowsReady     = "flows_ready"
+)
+
 var MetricNodeReadyDuration = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: MetricOvnkubeNamespace,
 	Subsystem: MetricOvnkubeSubsystemNode,
@@ -45,14 +65,378 @@ var metricOvnKubeNodeLogFileSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	},
 )
 
+// MetricGatewayOpenFlowCacheGeneration is bumped on every change of the gateway OpenFlow cache
+var MetricGatewayOpenFlowCacheGeneration = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "gateway_openflow_cache_generation",
+	Help:      "The number of changes made to the gateway OpenFlow cache since ovnkube-node started.",
+})
+
+// MetricGatewayOpenFlowLastSyncTimestamp is the time of the last successful sync of the
+// gateway OpenFlow cache to the gateway bridges
+var MetricGatewayOpenFlowLastSyncTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "gateway_openflow_last_sync_timestamp_seconds",
+	Help:      "The time of the last successful sync of the gateway OpenFlow cache to the OVS bridges.",
+})
+
+// MetricGatewayOpenFlowCacheFlows is the number of flows in the gateway OpenFlow cache of a bridge
+var MetricGatewayOpenFlowCacheFlows = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "gateway_openflow_cache_flows",
+	Help:      "The number of flows in the gateway OpenFlow cache of a bridge."},
+	[]string{
+		"bridge",
+	},
+)
+
+// MetricNodeSysctlDrifts is the number of times a sysctl managed by the node was found changed
+var MetricNodeSysctlDrifts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "sysctl_drifts_total",
+	Help:      "The number of times a sysctl managed by ovnkube-node was found with a value different from the required one."},
+	[]string{
+		"sysctl",
+	},
+)
+
+// MetricNodeSysctlsOutOfSync is the number of managed sysctls that could not be set to their required value
+var MetricNodeSysctlsOutOfSync = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "sysctls_out_of_sync",
+	Help:      "The number of sysctls managed by ovnkube-node that could not be set to their required value on the last reconciliation.",
+})
+
+// MetricNodeAnnotationWrites counts the attempts to write the node annotations by result: "success",
+// "conflict" or "error"
+var MetricNodeAnnotationWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "annotation_writes_total",
+	Help:      "The number of attempts to write the node annotations by result (success, conflict or error)."},
+	[]string{
+		"result",
+	},
+)
+
+// MetricNodeAnnotationWriteRetries is the number of retried writes of the node annotations
+var MetricNodeAnnotationWriteRetries = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "annotation_write_retries_total",
+	Help:      "The number of times a write of the node annotations was retried after a conflict or a transient API error."},
+)
+
+// MetricNodeSubsystemState is 1 for the current state of each node subsystem and 0 for the others
+var MetricNodeSubsystemState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "subsystem_state",
+	Help:      "Specifies the state of a node subsystem: 1 for its current state (running, disabled, blocked or failed) and 0 for the others."},
+	[]string{
+		"subsystem",
+		"state",
+	},
+)
+
+// MetricNodeInterconnectZoneReachable reports whether a remote zone is reachable through the transit switch
+var MetricNodeInterconnectZoneReachable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "interconnect_zone_reachable",
+	Help:      "Specifies if all the nodes of a remote zone are reachable through the transit switch(1) or not(0)."},
+	[]string{
+		"zone",
+	},
+)
+
+// MetricNodeInterconnectUnreachableNodes is the number of nodes of a remote zone that can't be
+// reached through the transit switch
+var MetricNodeInterconnectUnreachableNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "interconnect_zone_unreachable_nodes",
+	Help:      "The number of nodes of a remote zone that can't be reached through the transit switch."},
+	[]string{
+		"zone",
+	},
+)
+
+// MetricNodeHwOffloadFunctioning reports whether OVS hardware offload is enabled and offloads the
+// datapath flows of the representors
+var MetricNodeHwOffloadFunctioning = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "hw_offload_functioning",
+	Help:      "Specifies if OVS hardware offload is enabled and offloads the datapath flows of the representors(1) or not(0)."},
+)
+
+// MetricNodeHwOffloadDatapathFlows is the number of datapath flows of a representor port, offloaded or not
+var MetricNodeHwOffloadDatapathFlows = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "hw_offload_datapath_flows",
+	Help:      "The number of datapath flows received on a representor port that are offloaded to the hardware(offloaded=true) or not(offloaded=false)."},
+	[]string{
+		"representor",
+		"offloaded",
+	},
+)
+
+// MetricNodeTuningChanges is the number of node tuning changes by target, setting and result
+var MetricNodeTuningChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "tuning_changes_total",
+	Help:      "The number of node tuning changes of a target (a daemon or the uplink) by setting (cpu-affinity, memory-nodes or rx-queue-affinity) and result (applied, dry-run or error)."},
+	[]string{
+		"target",
+		"setting",
+		"result",
+	},
+)
+
+// MetricNodeGatewayNextHopHealthy is whether a gateway next hop of the node is healthy and used as a default route
+var MetricNodeGatewayNextHopHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "gateway_next_hop_healthy",
+	Help:      "Whether a gateway next hop answers the health checks and is used as a default route (1) or not (0)."},
+	[]string{
+		"next_hop",
+	},
+)
+
+// MetricNodeStaleOVSPorts is the number of OVS ports of br-int found referencing pods that no longer exist
+var MetricNodeStaleOVSPorts = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "stale_ovs_ports",
+	Help:      "The number of OVS ports of pods that no longer exist found on br-int by the last garbage collection.",
+})
+
+// MetricNodeStaleOVSPortRemovals is the number of stale OVS ports garbage collected by result
+var MetricNodeStaleOVSPortRemovals = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "stale_ovs_port_removals_total",
+	Help:      "The number of OVS ports of pods that no longer exist garbage collected from br-int by result (removed, dry-run or error)."},
+	[]string{
+		"result",
+	},
+)
+
+// MetricNodeServiceProbes is the number of probes of the services with ready endpoints by target and result
+var MetricNodeServiceProbes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "service_probes_total",
+	Help:      "The number of probes sent by the node to the services with ready endpoints by target (cluster-ip or node-port) and result (success or failure)."},
+	[]string{
+		"target",
+		"result",
+	},
+)
+
+// MetricNodeServiceProbeResyncs is the number of gateway flow resyncs requested by failed service probes
+var MetricNodeServiceProbeResyncs = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "service_probe_resyncs_total",
+	Help:      "The number of gateway flow resyncs requested because service probes failed while the services had ready endpoints.",
+})
+
+// MetricNodeProbePathServices is the number of services the probe path sends the host traffic of to the
+// endpoints on the node
+var MetricNodeProbePathServices = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "probe_path_services",
+	Help:      "The number of services with externalTrafficPolicy=Local the host traffic is sent to the endpoints on the node of, without going through the gateway.",
+})
+
+// MetricNodeProbePathDrops is the number of connections of the host to a service dropped from the probe path by
+// service
+var MetricNodeProbePathDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "probe_path_drops_total",
+	Help:      "The number of connections of the host to a service with externalTrafficPolicy=Local dropped from the probe path because the service had no endpoint on the node, left to the gateway."},
+	[]string{
+		"namespace",
+		"service",
+	},
+)
+
+// MetricNodeServiceConntrackEntries is the number of conntrack entries of the services with the most entries
+var MetricNodeServiceConntrackEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "service_conntrack_entries",
+	Help:      "The number of conntrack entries on the node destined to the ClusterIPs (type=cluster_ip) or the NodePorts on the node IPs (type=node_port) of a service, for the services with the most entries."},
+	[]string{
+		"namespace",
+		"name",
+		"type",
+	},
+)
+
+// MetricNodePodConntrackEntries is the number of conntrack entries of the connections originated by a pod of the node
+var MetricNodePodConntrackEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "pod_conntrack_entries",
+	Help:      "The number of conntrack entries of the connections originated by a pod of the node, a connection has an entry in each conntrack zone it goes through."},
+	[]string{
+		"namespace",
+		"pod",
+	},
+)
+
+// MetricNodePodConntrackSNATEntries is the number of conntrack entries of the connections of a pod of the node
+// whose source is translated
+var MetricNodePodConntrackSNATEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "pod_conntrack_snat_entries",
+	Help:      "The number of conntrack entries of the connections originated by a pod of the node whose source is translated, e.g. by the gateway SNAT, each of them holding a source port of the translated address."},
+	[]string{
+		"namespace",
+		"pod",
+	},
+)
+
+// MetricNodePodConntrackBytes is the number of bytes of the current connections originated by a pod of the node
+var MetricNodePodConntrackBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "pod_conntrack_bytes",
+	Help:      "The number of bytes sent (direction=tx) and received (direction=rx) by a pod of the node on the connections it originated that are still tracked, they are only accounted when net.netfilter.nf_conntrack_acct is enabled."},
+	[]string{
+		"namespace",
+		"pod",
+		"direction",
+	},
+)
+
+// MetricNodeOVSVswitchdRestarts is the number of ovs-vswitchd restarts the node reprogrammed the gateway flows after
+var MetricNodeOVSVswitchdRestarts = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "ovs_vswitchd_restarts_total",
+	Help:      "The number of ovs-vswitchd restarts detected by the node, the gateway bridge flows are reprogrammed after each of them.",
+})
+
+// MetricNodeOVSUpgradeQuiesced is whether the node is quiesced for an OVS upgrade
+var MetricNodeOVSUpgradeQuiesced = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "ovs_upgrade_quiesced",
+	Help:      "Whether the node is quiesced for an OVS upgrade: the CNI requests are rejected and the gateway flows are not synced.",
+})
+
+// MetricNodeReconciliationPaused is whether the reconciliation of the host networking of the node is paused
+var MetricNodeReconciliationPaused = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "reconciliation_paused",
+	Help:      "Whether the reconciliation of the host networking of the node is paused: the routes, iptables rules and gateway flows are not modified.",
+})
+
+// MetricNodeExternalGatewayBFDSessionUp is whether the BFD session from the gateway router of the node to an
+// external gateway next hop is up
+var MetricNodeExternalGatewayBFDSessionUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "external_gateway_bfd_session_up",
+	Help:      "Whether the BFD session from the gateway router of the node to an external gateway next hop is up."},
+	[]string{
+		"next_hop",
+	},
+)
+
+// MetricNodeHTTPRequests is the number of requests served by the HTTP listeners of the node
+var MetricNodeHTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "http_requests_total",
+	Help:      "The number of requests served by the HTTP listeners of the node, by listener, path and status code."},
+	[]string{
+		"listener",
+		"path",
+		"code",
+	},
+)
+
+// MetricNodeNamespaceEgressRateDroppedPackets is the number of packets of the pods of a namespace dropped by the
+// egress rate limit of the namespace on the node
+var MetricNodeNamespaceEgressRateDroppedPackets = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "namespace_egress_rate_dropped_packets",
+	Help:      "The number of packets leaving the node from the pods of a namespace dropped by the egress rate limit of the namespace since it applies on the node."},
+	[]string{
+		"namespace",
+	},
+)
+
+// MetricNodeNamespaceEgressRateDroppedBytes is the number of bytes of the pods of a namespace dropped by the
+// egress rate limit of the namespace on the node
+var MetricNodeNamespaceEgressRateDroppedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "namespace_egress_rate_dropped_bytes",
+	Help:      "The number of bytes leaving the node from the pods of a namespace dropped by the egress rate limit of the namespace since it applies on the node."},
+	[]string{
+		"namespace",
+	},
+)
+
 var registerNodeMetricsOnce sync.Once
 
 func RegisterNodeMetrics(stopChan <-chan struct{}) {
 	registerNodeMetricsOnce.Do(func() {
 		// ovnkube-node metrics
 		prometheus.MustRegister(MetricCNIRequestDuration)
+		prometheus.MustRegister(MetricCNIPodSetupDuration)
 		prometheus.MustRegister(MetricNodeReadyDuration)
 		prometheus.MustRegister(metricOvnNodePortEnabled)
+		prometheus.MustRegister(MetricGatewayOpenFlowCacheGeneration)
+		prometheus.MustRegister(MetricGatewayOpenFlowLastSyncTimestamp)
+		prometheus.MustRegister(MetricGatewayOpenFlowCacheFlows)
+		prometheus.MustRegister(MetricNodeSysctlDrifts)
+		prometheus.MustRegister(MetricNodeSysctlsOutOfSync)
+		prometheus.MustRegister(MetricNodeInterconnectZoneReachable)
+		prometheus.MustRegister(MetricNodeInterconnectUnreachableNodes)
+		prometheus.MustRegister(MetricNodeAnnotationWrites)
+		prometheus.MustRegister(MetricNodeAnnotationWriteRetries)
+		prometheus.MustRegister(MetricNodeSubsystemState)
+		prometheus.MustRegister(MetricNodeHwOffloadFunctioning)
+		prometheus.MustRegister(MetricNodeHwOffloadDatapathFlows)
+		prometheus.MustRegister(MetricNodeTuningChanges)
+		prometheus.MustRegister(MetricNodeGatewayNextHopHealthy)
+		prometheus.MustRegister(MetricNodeServiceConntrackEntries)
+		prometheus.MustRegister(MetricNodePodConntrackEntries)
+		prometheus.MustRegister(MetricNodePodConntrackSNATEntries)
+		prometheus.MustRegister(MetricNodePodConntrackBytes)
+		prometheus.MustRegister(MetricNodeStaleOVSPorts)
+		prometheus.MustRegister(MetricNodeStaleOVSPortRemovals)
+		prometheus.MustRegister(MetricNodeServiceProbes)
+		prometheus.MustRegister(MetricNodeServiceProbeResyncs)
+		prometheus.MustRegister(MetricNodeProbePathServices)
+		prometheus.MustRegister(MetricNodeProbePathDrops)
+		prometheus.MustRegister(MetricNodeOVSVswitchdRestarts)
+		prometheus.MustRegister(MetricNodeOVSUpgradeQuiesced)
+		prometheus.MustRegister(MetricNodeReconciliationPaused)
+		prometheus.MustRegister(MetricNodeExternalGatewayBFDSessionUp)
+		prometheus.MustRegister(MetricNodeHTTPRequests)
+		prometheus.MustRegister(MetricNodeNamespaceEgressRateDroppedPackets)
+		prometheus.MustRegister(MetricNodeNamespaceEgressRateDroppedBytes)
 		prometheus.MustRegister(prometheus.NewGaugeFunc(
 			prometheus.GaugeOpts{
 				Namespace: MetricOvnkubeNamespace,
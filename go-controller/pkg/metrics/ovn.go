@@ -93,6 +93,29 @@ var metricOVNControllerSBDBConnection = prometheus.NewGauge(prometheus.GaugeOpts
 	Help:      "Specifies if OVN controller is connected to OVN southbound database (1) or not (0)",
 })
 
+var metricOVNControllerSBDBReconnects = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnNamespace,
+	Subsystem: MetricOvnSubsystemController,
+	Name:      "southbound_database_reconnects_total",
+	Help:      "The number of times OVN controller reconnected to OVN southbound database after losing the connection.",
+})
+
+var metricOVNControllerSBRemoteChanges = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnNamespace,
+	Subsystem: MetricOvnSubsystemController,
+	Name:      "southbound_database_remote_changes_total",
+	Help:      "The number of times the ovn-remote value configured on that node changed.",
+})
+
+var (
+	// ovnRemote is the last ovn-remote value read from the Open_vSwitch table's external_ids
+	ovnRemote string
+	// sbdbConnected and sbdbConnectionLost track the southbound database connection status
+	// to count the reconnections
+	sbdbConnected      bool
+	sbdbConnectionLost bool
+)
+
 var (
 	ovnControllerVersion       string
 	ovnControllerOvsLibVersion string
@@ -293,6 +316,10 @@ func setOvnControllerConfigurationMetrics() (err error) {
 			metricEncapIP.Reset()
 			metricEncapIP.WithLabelValues(fieldValue).Set(1)
 		case "ovn-remote":
+			if ovnRemote != "" && ovnRemote != fieldValue {
+				metricOVNControllerSBRemoteChanges.Inc()
+			}
+			ovnRemote = fieldValue
 			metricSbConnectionMethod.Reset()
 			metricSbConnectionMethod.WithLabelValues(fieldValue).Set(1)
 		case "ovn-encap-type":
@@ -402,8 +429,15 @@ func updateSBDBConnectionMetric(ovsAppctl ovsClient, retry int, retrySleep time.
 
 	if connected {
 		metricOVNControllerSBDBConnection.Set(1)
+		if sbdbConnectionLost {
+			metricOVNControllerSBDBReconnects.Inc()
+			sbdbConnectionLost = false
+		}
+		sbdbConnected = true
 	} else {
 		metricOVNControllerSBDBConnection.Set(0)
+		// only count the reconnections after a connection was established
+		sbdbConnectionLost = sbdbConnected
 	}
 }
 
@@ -426,6 +460,8 @@ func RegisterOvnControllerMetrics(stopChan <-chan struct{}) {
 
 	// ovn-controller metrics
 	ovnRegistry.MustRegister(metricOVNControllerSBDBConnection)
+	ovnRegistry.MustRegister(metricOVNControllerSBDBReconnects)
+	ovnRegistry.MustRegister(metricOVNControllerSBRemoteChanges)
 	ovnRegistry.MustRegister(prometheus.NewCounterFunc(
 		prometheus.CounterOpts{
 			Namespace: MetricOvnNamespace,
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// tracerName is the instrumentation scope of the spans of ovn-kubernetes
+const tracerName = "github.com/ovn-org/ovn-kubernetes/go-controller"
+
+// tracingShutdownTimeout is how long the spans not exported yet are flushed for on shutdown
+const tracingShutdownTimeout = 5 * time.Second
+
+// StartTracing exports the spans of the component to the OTLP gRPC collector at endpoint, sampling
+// samplingRatePerMillion of the traces started by the component; the traces started by another component
+// and propagated to it are sampled as they are there. The spans not exported yet are flushed once stopChan
+// is closed. The spans are not recorded unless it is called.
+func StartTracing(endpoint, component, identity string, samplingRatePerMillion int, stopChan <-chan struct{},
+	wg *sync.WaitGroup) error {
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create the OTLP trace exporter for %s: %w", endpoint, err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(component),
+		semconv.ServiceInstanceID(identity),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create the trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(float64(samplingRatePerMillion)/1000000))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	klog.Infof("Exporting the traces of %s to %s, sampling %d per million", component, endpoint, samplingRatePerMillion)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-stopChan
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			klog.Errorf("Failed to flush the traces: %v", err)
+		}
+	}()
+	return nil
+}
+
+// StartSpan starts a span of ctx, the span must be ended with EndSpan
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording the error of the operation it traces if any
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObserveWithTraceExemplar observes the value, with the ID of the trace of ctx as exemplar when the trace is
+// sampled so that the slow observations can be looked up in the tracing backend
+func ObserveWithTraceExemplar(ctx context.Context, observer prometheus.Observer, value float64) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && spanContext.IsSampled() {
+		exemplarObserver.ObserveWithExemplar(value, prometheus.Labels{"trace_id": spanContext.TraceID().String()})
+		return
+	}
+	observer.Observe(value)
+}
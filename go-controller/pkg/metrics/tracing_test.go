@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var _ = ginkgo.Describe("Trace exemplars", func() {
+	newHistogram := func() prometheus.Histogram {
+		return prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_duration_seconds", Buckets: []float64{1}})
+	}
+	exemplarOf := func(histogram prometheus.Histogram) *dto.Exemplar {
+		metric := &dto.Metric{}
+		gomega.Expect(histogram.Write(metric)).To(gomega.Succeed())
+		gomega.Expect(metric.GetHistogram().GetSampleCount()).To(gomega.BeEquivalentTo(1))
+		return metric.GetHistogram().GetBucket()[0].GetExemplar()
+	}
+
+	ginkgo.It("links the observations to the sampled traces", func() {
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+		defer provider.Shutdown(context.Background())
+		ctx, span := provider.Tracer(tracerName).Start(context.Background(), "test")
+		defer span.End()
+
+		histogram := newHistogram()
+		ObserveWithTraceExemplar(ctx, histogram, 0.5)
+		exemplar := exemplarOf(histogram)
+		gomega.Expect(exemplar).NotTo(gomega.BeNil())
+		gomega.Expect(exemplar.GetLabel()).To(gomega.HaveLen(1))
+		gomega.Expect(exemplar.GetLabel()[0].GetName()).To(gomega.Equal("trace_id"))
+		gomega.Expect(exemplar.GetLabel()[0].GetValue()).To(gomega.Equal(span.SpanContext().TraceID().String()))
+	})
+
+	ginkgo.It("observes without exemplar out of a sampled trace", func() {
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+		defer provider.Shutdown(context.Background())
+		ctx, span := provider.Tracer(tracerName).Start(context.Background(), "test")
+		defer span.End()
+
+		histogram := newHistogram()
+		ObserveWithTraceExemplar(ctx, histogram, 0.5)
+		gomega.Expect(exemplarOf(histogram)).To(gomega.BeNil())
+
+		histogram = newHistogram()
+		ObserveWithTraceExemplar(context.Background(), histogram, 0.5)
+		gomega.Expect(exemplarOf(histogram)).To(gomega.BeNil())
+	})
+})
@@ -0,0 +1,179 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	adminpolicybasedrouteapply "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/applyconfiguration/adminpolicybasedroute/v1"
+	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// apbRouteBFDStatusPeriod is how often the node reports the state of the BFD sessions of the external gateways
+const apbRouteBFDStatusPeriod = 30 * time.Second
+
+// apbRouteBFDStatusReporter reports the state of the BFD sessions from the gateway router of the node to the
+// external gateway next hops with BFD enabled, as node metrics and as a message of the node in the status of
+// the APB external route policies, so that the external gateway path that is down on a node can be found.
+type apbRouteBFDStatusReporter struct {
+	nodeName string
+	client   adminpolicybasedrouteclientset.Interface
+	stopChan <-chan struct{}
+	// getBFDGatewayIPs returns the gateway IPs with BFD enabled of every policy, by policy name
+	getBFDGatewayIPs func() (map[string]sets.Set[string], error)
+
+	// nextHops are the next hops the session state metric is set for
+	nextHops sets.Set[string]
+	// reported is the status message last reported for every policy
+	reported map[string]string
+}
+
+func newAPBRouteBFDStatusReporter(nodeName string, client adminpolicybasedrouteclientset.Interface,
+	getBFDGatewayIPs func() (map[string]sets.Set[string], error), stopChan <-chan struct{}) *apbRouteBFDStatusReporter {
+	return &apbRouteBFDStatusReporter{
+		nodeName:         nodeName,
+		client:           client,
+		stopChan:         stopChan,
+		getBFDGatewayIPs: getBFDGatewayIPs,
+		nextHops:         sets.New[string](),
+		reported:         map[string]string{},
+	}
+}
+
+func (r *apbRouteBFDStatusReporter) Run(doneWg *sync.WaitGroup) {
+	runPeriodicSync(r.stopChan, doneWg, apbRouteBFDStatusPeriod, nil, func() {
+		if err := r.sync(); err != nil {
+			klog.Errorf("Failed to report the BFD sessions of the external gateways of node %s: %v", r.nodeName, err)
+		}
+	})
+}
+
+// sync sets the session state metric of every next hop and reports the sessions of the node in the policies
+func (r *apbRouteBFDStatusReporter) sync() error {
+	sessions, err := r.listBFDSessions()
+	if err != nil {
+		return err
+	}
+	for nextHop, status := range sessions {
+		up := 0.0
+		if status == "up" {
+			up = 1
+		}
+		metrics.MetricNodeExternalGatewayBFDSessionUp.WithLabelValues(nextHop).Set(up)
+		r.nextHops.Insert(nextHop)
+	}
+	for nextHop := range r.nextHops {
+		if _, ok := sessions[nextHop]; !ok {
+			metrics.MetricNodeExternalGatewayBFDSessionUp.DeleteLabelValues(nextHop)
+			r.nextHops.Delete(nextHop)
+		}
+	}
+
+	policyGWIPs, err := r.getBFDGatewayIPs()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for policyName, gwIPs := range policyGWIPs {
+		if err := r.report(policyName, bfdSessionsMessage(gwIPs, sessions)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for policyName, reported := range r.reported {
+		if _, ok := policyGWIPs[policyName]; ok {
+			continue
+		}
+		// the policy no longer has next hops with BFD enabled or was deleted
+		if reported != "" {
+			if err := r.report(policyName, ""); err != nil && !apierrors.IsNotFound(errors.Unwrap(err)) {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		delete(r.reported, policyName)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to report the BFD sessions in the APB external route policies: %v", errs)
+	}
+	return nil
+}
+
+// report sets the message of the node in the status of the policy, or removes it when empty
+func (r *apbRouteBFDStatusReporter) report(policyName, message string) error {
+	if reported, ok := r.reported[policyName]; ok && reported == message {
+		return nil
+	}
+	status := adminpolicybasedrouteapply.AdminPolicyBasedRouteStatus()
+	if message != "" {
+		status.WithMessages(types.GetNodeBFDStatus(r.nodeName, message)).WithLastTransitionTime(metav1.Now())
+	}
+	applyObj := adminpolicybasedrouteapply.AdminPolicyBasedExternalRoute(policyName).WithStatus(status)
+	// the message of every node is owned by its own field manager
+	applyOptions := metav1.ApplyOptions{Force: true, FieldManager: r.nodeName + "-bfd"}
+	if _, err := r.client.K8sV1().AdminPolicyBasedExternalRoutes().ApplyStatus(context.TODO(), applyObj, applyOptions); err != nil {
+		return fmt.Errorf("failed to report the BFD sessions in APB external route policy %s: %w", policyName, err)
+	}
+	r.reported[policyName] = message
+	return nil
+}
+
+// listBFDSessions returns the state of the BFD sessions from the gateway router of the node, by next hop
+func (r *apbRouteBFDStatusReporter) listBFDSessions() (map[string]string, error) {
+	logicalPort := types.GWRouterToExtSwitchPrefix + util.GetGatewayRouterFromNode(r.nodeName)
+	stdout, stderr, err := util.RunOVNSbctl("--no-heading", "--data=bare", "--format=csv",
+		"--columns=dst_ip,status", "find", "BFD", "logical_port="+logicalPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the BFD sessions of %s, stderr: %q, error: %v", logicalPort, stderr, err)
+	}
+	sessions := map[string]string{}
+	if stdout == "" {
+		return sessions, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			continue
+		}
+		sessions[fields[0]] = fields[1]
+	}
+	return sessions, nil
+}
+
+// bfdSessionsMessage returns the status message of the sessions to the gateway IPs, empty when the node has
+// no session to any of them
+func bfdSessionsMessage(gwIPs sets.Set[string], sessions map[string]string) string {
+	var down, up []string
+	for _, gwIP := range sets.List(gwIPs) {
+		status, ok := sessions[gwIP]
+		if !ok {
+			continue
+		}
+		if status == "up" {
+			up = append(up, gwIP)
+		} else {
+			down = append(down, fmt.Sprintf("%s (%s)", gwIP, status))
+		}
+	}
+	if len(down) == 0 && len(up) == 0 {
+		return ""
+	}
+	var parts []string
+	if len(down) > 0 {
+		parts = append(parts, "BFD sessions down: "+strings.Join(down, ", "))
+	}
+	if len(up) > 0 {
+		parts = append(parts, "BFD sessions up: "+strings.Join(up, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
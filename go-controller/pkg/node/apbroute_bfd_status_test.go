@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
+	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("APB external route BFD status", func() {
+	const (
+		nodeName     = "node1"
+		policyName   = "policy1"
+		listSessions = "ovn-sbctl --timeout=15 --no-leader-only --no-heading --data=bare --format=csv --columns=dst_ip,status find BFD logical_port=rtoe-GR_node1"
+	)
+
+	var (
+		fakeExec    *ovntest.FakeExec
+		routeClient *adminpolicybasedrouteclient.Clientset
+		policyGWIPs map[string]sets.Set[string]
+		r           *apbRouteBFDStatusReporter
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fakeExec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fakeExec)).To(Succeed())
+		routeClient = adminpolicybasedrouteclient.NewSimpleClientset(&adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: policyName},
+		})
+		policyGWIPs = map[string]sets.Set[string]{policyName: sets.New("172.18.0.5", "172.18.0.6")}
+		r = newAPBRouteBFDStatusReporter(nodeName, routeClient, func() (map[string]sets.Set[string], error) {
+			return policyGWIPs, nil
+		}, make(chan struct{}))
+	})
+
+	AfterEach(func() {
+		Expect(fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeExec.ErrorDesc())
+	})
+
+	getMessages := func() []string {
+		policy, err := routeClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.TODO(), policyName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return policy.Status.Messages
+	}
+
+	It("reports the next hops with their BFD session down", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listSessions, Output: "172.18.0.5,up\n172.18.0.6,down"})
+		Expect(r.sync()).To(Succeed())
+		Expect(getMessages()).To(ConsistOf(
+			types.GetNodeBFDStatus(nodeName, "BFD sessions down: 172.18.0.6 (down); BFD sessions up: 172.18.0.5")))
+		Expect(r.nextHops).To(Equal(sets.New("172.18.0.5", "172.18.0.6")))
+
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listSessions, Output: "172.18.0.5,up"})
+		Expect(r.sync()).To(Succeed())
+		Expect(getMessages()).To(ConsistOf(types.GetNodeBFDStatus(nodeName, "BFD sessions up: 172.18.0.5")))
+		Expect(r.nextHops).To(Equal(sets.New("172.18.0.5")))
+	})
+
+	It("removes the message once the policy has no next hop with BFD enabled", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listSessions, Output: "172.18.0.5,init"})
+		Expect(r.sync()).To(Succeed())
+		Expect(getMessages()).To(ConsistOf(types.GetNodeBFDStatus(nodeName, "BFD sessions down: 172.18.0.5 (init)")))
+
+		policyGWIPs = map[string]sets.Set[string]{}
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listSessions, Output: "172.18.0.5,init"})
+		routeClient.ClearActions()
+		Expect(r.sync()).To(Succeed())
+		// the fake client doesn't track the field managers, check the empty status of the node was applied
+		actions := routeClient.Actions()
+		Expect(actions).To(HaveLen(1))
+		patch, ok := actions[0].(clienttesting.PatchAction)
+		Expect(ok).To(BeTrue())
+		Expect(patch.GetSubresource()).To(Equal("status"))
+		Expect(patch.GetPatchType()).To(Equal(apimachinerytypes.ApplyPatchType))
+		Expect(string(patch.GetPatch())).NotTo(ContainSubstring("messages"))
+		Expect(r.reported).To(BeEmpty())
+	})
+})
@@ -23,7 +23,7 @@ import (
 // If true, return its dpuConnDetails, otherwise return nil
 func (bnnc *BaseNodeNetworkController) podReadyToAddDPU(pod *kapi.Pod, nadName string) *util.DPUConnectionDetails {
 	if bnnc.name != pod.Spec.NodeName {
-		klog.V(5).Infof("Pod %s/%s is not scheduled on this node %s", pod.Namespace, pod.Name, bnnc.name)
+		dpuLog.V(5).Infof("Pod %s/%s is not scheduled on this node %s", pod.Namespace, pod.Name, bnnc.name)
 		return nil
 	}
 
@@ -33,7 +33,7 @@ func (bnnc *BaseNodeNetworkController) podReadyToAddDPU(pod *kapi.Pod, nadName s
 			klog.Errorf("Failed to get DPU annotation for pod %s/%s NAD %s: %v",
 				pod.Namespace, pod.Name, nadName, err)
 		} else {
-			klog.V(5).Infof("DPU connection details annotation still not found for %s/%s for NAD %s",
+			dpuLog.V(5).Infof("DPU connection details annotation still not found for %s/%s for NAD %s",
 				pod.Namespace, pod.Name, nadName)
 		}
 		return nil
@@ -104,7 +104,7 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error)
 	return bnnc.watchFactory.AddPodHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			pod := obj.(*kapi.Pod)
-			klog.V(5).Infof("Add for Pod: %s/%s for network %s", pod.Namespace, pod.Name, netName)
+			dpuLog.V(5).Infof("Add for Pod: %s/%s for network %s", pod.Namespace, pod.Name, netName)
 			if util.PodWantsHostNetwork(pod) {
 				return
 			}
@@ -120,7 +120,7 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error)
 						klog.Errorf("Error getting network-attachment for pod %s/%s network %s: %v",
 							pod.Namespace, pod.Name, bnnc.GetNetworkName(), err)
 					} else {
-						klog.V(5).Infof("Skipping Pod %s/%s as it is not attached to network: %s",
+						dpuLog.V(5).Infof("Skipping Pod %s/%s as it is not attached to network: %s",
 							pod.Namespace, pod.Name, netName)
 					}
 					return
@@ -148,10 +148,10 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error)
 		UpdateFunc: func(old, newer interface{}) {
 			oldPod := old.(*kapi.Pod)
 			newPod := newer.(*kapi.Pod)
-			klog.V(5).Infof("Update for Pod: %s/%s for network %s", newPod.Namespace, newPod.Name, netName)
+			dpuLog.V(5).Infof("Update for Pod: %s/%s for network %s", newPod.Namespace, newPod.Name, netName)
 			v, ok := bnnc.podNADToDPUCDMap.Load(newPod.UID)
 			if !ok {
-				klog.V(5).Infof("Skipping update for Pod %s/%s as it is not attached to network: %s",
+				dpuLog.V(5).Infof("Skipping update for Pod %s/%s as it is not attached to network: %s",
 					newPod.Namespace, newPod.Name, netName)
 				return
 			}
@@ -191,11 +191,11 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error)
 			pod := obj.(*kapi.Pod)
 			v, ok := bnnc.podNADToDPUCDMap.Load(pod.UID)
 			if !ok {
-				klog.V(5).Infof("Skipping delete for Pod %s/%s as it is not attached to network: %s",
+				dpuLog.V(5).Infof("Skipping delete for Pod %s/%s as it is not attached to network: %s",
 					pod.Namespace, pod.Name, netName)
 				return
 			}
-			klog.V(5).Infof("Delete for Pod: %s/%s for network %s", pod.Namespace, pod.Name, netName)
+			dpuLog.V(5).Infof("Delete for Pod: %s/%s for network %s", pod.Namespace, pod.Name, netName)
 			nadToDPUCDMap := v.(map[string]*util.DPUConnectionDetails)
 			bnnc.podNADToDPUCDMap.Delete(pod.UID)
 			for nadName, dpuCD := range nadToDPUCDMap {
@@ -114,7 +114,7 @@ var _ = Describe("Node DPU tests", func() {
 		kubeMock = kubemocks.Interface{}
 		apbExternalRouteClient := adminpolicybasedrouteclient.NewSimpleClientset()
 		factoryMock = factorymocks.NodeWatchFactory{}
-		cnnci := newCommonNodeNetworkControllerInfo(nil, &kubeMock, apbExternalRouteClient, &factoryMock, nil, "", routeManager)
+		cnnci := newCommonNodeNetworkControllerInfo(nil, &kubeMock, apbExternalRouteClient, nil, &factoryMock, nil, "", routeManager)
 		dnnc = newDefaultNodeNetworkController(cnnci, nil, nil, nil, routeManager)
 
 		podInformer = coreinformermocks.PodInformer{}
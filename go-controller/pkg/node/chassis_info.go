@@ -0,0 +1,147 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// chassisInfoSyncPeriod is how often the chassis of the node is read from the Southbound database
+const chassisInfoSyncPeriod = time.Minute
+
+// encapDstPort matches the destination port in the options of an encap
+var encapDstPort = regexp.MustCompile(`(?:^|\s)dst_port=(\d+)`)
+
+// chassisInfoPublisher mirrors the Southbound database chassis of the node in the node chassis annotation,
+// so that the cluster tooling and the other nodes can read the chassis ID, hostname and tunnel encapsulations
+// of the node without access to the Southbound database. The annotation is reconciled periodically and when
+// it is changed by someone else.
+type chassisInfoPublisher struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	kube         kube.Interface
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+}
+
+func newChassisInfoPublisher(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory,
+	stopChan <-chan struct{}) *chassisInfoPublisher {
+	return &chassisInfoPublisher{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		kube:         k,
+		stopChan:     stopChan,
+		trigger:      make(chan struct{}, 1),
+	}
+}
+
+func (p *chassisInfoPublisher) Run(doneWg *sync.WaitGroup) error {
+	_, err := p.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == p.nodeName && util.NodeChassisInfoAnnotationChanged(oldNode, newNode) {
+				p.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the chassis annotation: %w", err)
+	}
+
+	runPeriodicSync(p.stopChan, doneWg, chassisInfoSyncPeriod, p.trigger, func() {
+		if err := p.sync(); err != nil {
+			klog.Errorf("Failed to publish the chassis of node %s: %v", p.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (p *chassisInfoPublisher) requestSync() {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync reads the chassis of the node from the Southbound database and updates the node annotation when
+// it differs
+func (p *chassisInfoPublisher) sync() error {
+	info, err := getChassisInfo()
+	if err != nil {
+		return err
+	}
+	node, err := p.watchFactory.GetNode(p.nodeName)
+	if err != nil {
+		return err
+	}
+	current, err := util.ParseNodeChassisInfo(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid chassis annotation of node %s: %v", p.nodeName, err)
+	}
+	if reflect.DeepEqual(current, info) {
+		return nil
+	}
+	nodeAnnotator := newNodeAnnotator(p.kube, p.nodeName)
+	if err := util.SetNodeChassisInfo(nodeAnnotator, info); err != nil {
+		return err
+	}
+	return nodeAnnotator.Run()
+}
+
+// getChassisInfo returns the Southbound database chassis of the node, with its encaps sorted by type and IP
+func getChassisInfo() (*util.ChassisInfo, error) {
+	chassisID, err := util.GetNodeChassisID()
+	if err != nil {
+		return nil, err
+	}
+	hostname, stderr, err := util.RunOVNSbctl("--no-heading", "--data=bare", "--columns=hostname",
+		"find", "Chassis", "name="+chassisID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the chassis %s, stderr: %q, error: %v", chassisID, stderr, err)
+	}
+	if hostname == "" {
+		return nil, fmt.Errorf("chassis %s is not registered in the Southbound database", chassisID)
+	}
+	info := &util.ChassisInfo{ChassisID: chassisID, Hostname: hostname}
+
+	stdout, stderr, err := util.RunOVNSbctl("--no-heading", "--data=bare", "--format=csv",
+		"--columns=type,ip,options", "find", "Encap", "chassis_name="+chassisID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the encaps of chassis %s, stderr: %q, error: %v", chassisID, stderr, err)
+	}
+	if stdout == "" {
+		return info, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.SplitN(line, ",", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		encap := util.ChassisEncap{Type: fields[0], IP: fields[1]}
+		if match := encapDstPort.FindStringSubmatch(strings.Trim(fields[2], `"`)); match != nil {
+			encap.Port, _ = strconv.Atoi(match[1])
+		}
+		info.Encaps = append(info.Encaps, encap)
+	}
+	sort.Slice(info.Encaps, func(i, j int) bool {
+		if info.Encaps[i].Type != info.Encaps[j].Type {
+			return info.Encaps[i].Type < info.Encaps[j].Type
+		}
+		return info.Encaps[i].IP < info.Encaps[j].IP
+	})
+	return info, nil
+}
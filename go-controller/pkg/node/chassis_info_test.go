@@ -0,0 +1,83 @@
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node chassis annotation", func() {
+	const (
+		nodeName     = "node1"
+		chassisID    = "8a5f5b1c-7d3e-4f7a-9d2b-0c1e2f3a4b5c"
+		getChassisID = "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:system-id"
+		getHostname  = "ovn-sbctl --timeout=15 --no-leader-only --no-heading --data=bare --columns=hostname find Chassis name=" + chassisID
+		listEncaps   = "ovn-sbctl --timeout=15 --no-leader-only --no-heading --data=bare --format=csv --columns=type,ip,options find Encap chassis_name=" + chassisID
+	)
+
+	var (
+		fakeExec       *ovntest.FakeExec
+		kubeFakeClient *fake.Clientset
+		wf             *factory.WatchFactory
+		p              *chassisInfoPublisher
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fakeExec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fakeExec)).To(Succeed())
+		kubeFakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		p = newChassisInfoPublisher(nodeName, &kube.Kube{KClient: kubeFakeClient}, wf, make(chan struct{}))
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+		Expect(fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeExec.ErrorDesc())
+	})
+
+	getChassisInfo := func() *util.ChassisInfo {
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		info, err := util.ParseNodeChassisInfo(node)
+		Expect(err).NotTo(HaveOccurred())
+		return info
+	}
+
+	It("publishes the chassis of the node", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getChassisID, Output: chassisID})
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getHostname, Output: "node1.example.com"})
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listEncaps,
+			Output: "geneve,10.0.0.1,\"csum=true dst_port=6082\"\nvxlan,10.0.0.1,csum=true\ngeneve,fd00::1,csum=true"})
+		Expect(p.sync()).To(Succeed())
+		Expect(getChassisInfo()).To(Equal(&util.ChassisInfo{
+			ChassisID: chassisID,
+			Hostname:  "node1.example.com",
+			Encaps: []util.ChassisEncap{
+				{Type: "geneve", IP: "10.0.0.1", Port: 6082},
+				{Type: "geneve", IP: "fd00::1"},
+				{Type: "vxlan", IP: "10.0.0.1"},
+			},
+		}))
+	})
+
+	It("fails while the chassis is not registered", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getChassisID, Output: chassisID})
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getHostname})
+		Expect(p.sync()).To(MatchError(ContainSubstring("is not registered")))
+	})
+})
@@ -0,0 +1,83 @@
+package conntrackmanager
+
+import (
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	sysctlConntrackMax                   = "net.netfilter.nf_conntrack_max"
+	sysctlConntrackBuckets               = "net.netfilter.nf_conntrack_buckets"
+	sysctlConntrackTCPTimeoutEstablished = "net.netfilter.nf_conntrack_tcp_timeout_established"
+	sysctlConntrackTCPTimeoutCloseWait   = "net.netfilter.nf_conntrack_tcp_timeout_close_wait"
+	sysctlConntrackUDPTimeout            = "net.netfilter.nf_conntrack_udp_timeout"
+	sysctlConntrackUDPTimeoutStream      = "net.netfilter.nf_conntrack_udp_timeout_stream"
+)
+
+// conntrackSysctl is a conntrack sysctl and the value it must have
+type conntrackSysctl struct {
+	name  string
+	value int
+}
+
+// Controller applies the conntrack sysctls set in the ovnkube-node configuration
+type Controller struct {
+	// sysctls are the desired values in the order they are applied
+	sysctls []conntrackSysctl
+}
+
+// NewController creates a conntrack sysctl manager from the ovnkube-node configuration.
+// Settings left to 0 in the configuration are not managed.
+func NewController() *Controller {
+	var sysctls []conntrackSysctl
+	// the hash table must be resized before the max is raised above its previous limit
+	for _, sysctl := range []conntrackSysctl{
+		{sysctlConntrackBuckets, config.OvnKubeNode.ConntrackBuckets},
+		{sysctlConntrackMax, config.OvnKubeNode.ConntrackMax},
+		{sysctlConntrackTCPTimeoutEstablished, config.OvnKubeNode.ConntrackTCPTimeoutEstablished},
+		{sysctlConntrackTCPTimeoutCloseWait, config.OvnKubeNode.ConntrackTCPTimeoutCloseWait},
+		{sysctlConntrackUDPTimeout, config.OvnKubeNode.ConntrackUDPTimeout},
+		{sysctlConntrackUDPTimeoutStream, config.OvnKubeNode.ConntrackUDPTimeoutStream},
+	} {
+		if sysctl.value != 0 {
+			sysctls = append(sysctls, sysctl)
+		}
+	}
+	return &Controller{sysctls: sysctls}
+}
+
+// Sysctls returns the values of the managed conntrack sysctls keyed by sysctl name
+func (c *Controller) Sysctls() map[string]string {
+	sysctls := make(map[string]string, len(c.sysctls))
+	for _, sysctl := range c.sysctls {
+		sysctls[sysctl.name] = strconv.Itoa(sysctl.value)
+	}
+	return sysctls
+}
+
+// Run applies the conntrack sysctls through the node sysctl manager, which restores them if they are
+// changed by something else. It returns immediately if no conntrack sysctl is configured.
+func (c *Controller) Run() {
+	if len(c.sysctls) == 0 {
+		return
+	}
+	if err := c.reconcile(); err != nil {
+		klog.Errorf("Conntrack manager: failed to apply the conntrack sysctls, the sysctl manager retries: %v", err)
+	}
+}
+
+// reconcile sets every managed sysctl whose current value differs from the desired one, in order
+func (c *Controller) reconcile() error {
+	var errs []error
+	for _, sysctl := range c.sysctls {
+		if err := sysctlmanager.Set(sysctl.name, strconv.Itoa(sysctl.value)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package conntrackmanager
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Conntrack Manager", func() {
+	var fexec *ovntest.FakeExec
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	It("does not manage conntrack sysctls that are not configured", func() {
+		Expect(NewController().sysctls).To(BeEmpty())
+	})
+
+	It("only rewrites the sysctls that drifted from the configuration", func() {
+		config.OvnKubeNode.ConntrackMax = 262144
+		config.OvnKubeNode.ConntrackBuckets = 65536
+		config.OvnKubeNode.ConntrackUDPTimeout = 60
+		c := NewController()
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n " + sysctlConntrackBuckets,
+			Output: "65536",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n " + sysctlConntrackMax,
+			Output: "131072",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w " + sysctlConntrackMax + "=262144",
+			Output: sysctlConntrackMax + " = 262144",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n " + sysctlConntrackUDPTimeout,
+			Output: "30",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w " + sysctlConntrackUDPTimeout + "=60",
+			Output: sysctlConntrackUDPTimeout + " = 60",
+		})
+		Expect(c.reconcile()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("keeps reconciling the other sysctls when one of them fails", func() {
+		config.OvnKubeNode.ConntrackMax = 262144
+		config.OvnKubeNode.ConntrackBuckets = 65536
+		c := NewController()
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "sysctl -n " + sysctlConntrackBuckets,
+			Err: fmt.Errorf("no such file or directory"),
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n " + sysctlConntrackMax,
+			Output: "262144",
+		})
+		Expect(c.reconcile()).To(MatchError(ContainSubstring(sysctlConntrackBuckets)))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
@@ -0,0 +1,92 @@
+package egressip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// Cleanup removes the iptables rules, ip rules and routes the controller configured on the node, for when the
+// egress IP feature is disabled. The egress IPs assigned to the secondary host interfaces are not removed.
+func Cleanup() error {
+	var errs []error
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		if err := cleanupIPTables(proto); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		if err := cleanupIPRulesAndRoutes(family); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func cleanupIPTables(proto iptables.Protocol) error {
+	ipt, err := util.GetIPTablesHelper(proto)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	deleteRule := func(table, chain string, args []string) {
+		if exists, err := ipt.Exists(table, chain, args...); err != nil || !exists {
+			return
+		}
+		if err := ipt.Delete(table, chain, args...); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete rule %q of chain %s/%s: %w",
+				strings.Join(args, " "), table, chain, err))
+		}
+	}
+	deleteRule("nat", "POSTROUTING", iptJumpRule.Args)
+	deleteRule("mangle", "PREROUTING", iptRestoreMarkRule.Args)
+	deleteRule("mangle", "PREROUTING", iptSaveMarkRule.Args)
+	// the chain does not exist unless the controller ran with the protocol
+	_ = ipt.ClearChain("nat", chainName)
+	_ = ipt.DeleteChain("nat", chainName)
+	return utilerrors.Join(errs...)
+}
+
+// cleanupIPRulesAndRoutes deletes the ip rules of the controller priorities and the routes of the routing
+// tables the egress IP rules point to
+func cleanupIPRulesAndRoutes(family int) error {
+	nlOps := util.GetNetLinkOps()
+	var errs []error
+	tables := map[int]bool{}
+	for _, priority := range []int{rulePriority, ruleFwMarkPriority} {
+		filter, mask := filterRuleByPriority(priority)
+		rules, err := nlOps.RuleListFiltered(family, filter, mask)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list ip rules of priority %d: %w", priority, err))
+			continue
+		}
+		for i := range rules {
+			if priority == rulePriority && rules[i].Table >= routingTableIDStart {
+				tables[rules[i].Table] = true
+			}
+			if err := nlOps.RuleDel(&rules[i]); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete ip rule %s: %w", rules[i].String(), err))
+			}
+		}
+	}
+	for table := range tables {
+		routes, err := nlOps.RouteListFiltered(family, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list the routes of table %d: %w", table, err))
+			continue
+		}
+		for i := range routes {
+			if err := nlOps.RouteDel(&routes[i]); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete route %s: %w", routes[i].String(), err))
+			}
+		}
+		klog.Infof("Deleted the %d routes of egress IP routing table %d", len(routes), table)
+	}
+	return utilerrors.Join(errs...)
+}
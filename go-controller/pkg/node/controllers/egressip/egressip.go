@@ -18,10 +18,12 @@ import (
 	egressiplisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/linkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
@@ -45,6 +47,7 @@ import (
 
 	"github.com/gaissmai/cidrtree"
 	"github.com/vishvananda/netlink"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -138,6 +141,9 @@ type Controller struct {
 	nodeName        string
 	v4              bool
 	v6              bool
+
+	// ndpProxy proxies the neighbor discovery of the IPv6 egress IPs not on-link, nil unless enabled
+	ndpProxy *ndpProxy
 }
 
 func NewController(k kube.Interface, eIPInformer egressipinformer.EgressIPInformer, nodeInformer cache.SharedIndexInformer, namespaceInformer coreinformers.NamespaceInformer,
@@ -178,6 +184,17 @@ func NewController(k kube.Interface, eIPInformer egressipinformer.EgressIPInform
 	return c, nil
 }
 
+// PauseReconciliation stops modifying the iptables rules of the egress IPs until ResumeReconciliation is
+// called, the changes meanwhile are applied once resumed
+func (c *Controller) PauseReconciliation() {
+	c.iptablesManager.Pause()
+}
+
+// ResumeReconciliation applies the iptables rules of the egress IPs changed while paused
+func (c *Controller) ResumeReconciliation() error {
+	return c.iptablesManager.Resume()
+}
+
 // Run starts the Egress IP that is hosted in secondary host networks. Changes to this function
 // need to be mirrored in test function setupFakeTestNode
 func (c *Controller) Run(stopCh <-chan struct{}, wg *sync.WaitGroup, threads int) error {
@@ -272,9 +289,8 @@ func (c *Controller) Run(stopCh <-chan struct{}, wg *sync.WaitGroup, threads int
 			}
 			// The fwmark of the packet is included in reverse path route lookup. This permits rp_filter to function when the fwmark is
 			// used for routing traffic in both directions.
-			stdout, _, err := util.RunSysctl("-w", "net.ipv4.conf.all.src_valid_mark=1")
-			if err != nil || stdout != "net.ipv4.conf.all.src_valid_mark = 1" {
-				return fmt.Errorf("failed to set sysctl net.ipv4.conf.all.src_valid_mark to 1")
+			if err = sysctlmanager.Set("net.ipv4.conf.all.src_valid_mark", "1"); err != nil {
+				return fmt.Errorf("failed to set sysctl net.ipv4.conf.all.src_valid_mark to 1: %v", err)
 			}
 		}
 	}
@@ -430,7 +446,10 @@ func (c *Controller) processNextEIPWorkItem(wg *sync.WaitGroup) bool {
 	}
 	defer c.eIPQueue.Done(key)
 	klog.V(4).Infof("Processing Egress IP %s", key)
-	if err := c.syncEIP(key.(string)); err != nil {
+	_, span := metrics.StartSpan(context.Background(), "EgressIP.sync", attribute.String("egressip", key.(string)))
+	err := c.syncEIP(key.(string))
+	metrics.EndSpan(span, err)
+	if err != nil {
 		if c.eIPQueue.NumRequeues(key) < maxRetries {
 			klog.V(4).Infof("Error found while processing Egress IP %s: %v", key, err)
 			c.eIPQueue.AddRateLimited(key)
@@ -467,6 +486,11 @@ func (c *Controller) syncEIP(eIPName string) error {
 				return fmt.Errorf("failed to get config and update references for Egress IP %s: %w", eIPName, err)
 			}
 		}
+		if c.ndpProxy != nil {
+			if err = c.syncNDPProxy(eIPName, informerEIP); err != nil {
+				return fmt.Errorf("failed to sync the neighbor discovery proxy of Egress IP %s: %w", eIPName, err)
+			}
+		}
 		existing, found := c.cache.Load(eIPName)
 		if !found {
 			if update == nil {
@@ -1060,6 +1084,11 @@ func (c *Controller) repairNode() error {
 		// IPv6 NAT table may not be available by default on some distributions.
 		klog.Warningf("Failed to remove stale IPTable V6 rule(s) (%+v): %v", staleIPTableV6Rules, err)
 	}
+	if c.ndpProxy != nil {
+		if err := c.repairNDPProxy(); err != nil {
+			return fmt.Errorf("failed to remove stale neighbor discovery proxy entries: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -1501,11 +1530,11 @@ func generateIPTablesSNATRuleArg(srcIP net.IP, isIPv6 bool, infName, snatIP stri
 }
 
 func isEgressIPOnLink(linkIndex, ipFamily int, assignedEIPs sets.Set[string]) (bool, error) {
-	link, err := netlink.LinkByIndex(linkIndex)
+	link, err := util.GetNetLinkOps().LinkByIndex(linkIndex)
 	if err != nil {
 		return false, err
 	}
-	addresses, err := netlink.AddrList(link, ipFamily)
+	addresses, err := util.GetNetLinkOps().AddrList(link, ipFamily)
 	if err != nil {
 		return false, err
 	}
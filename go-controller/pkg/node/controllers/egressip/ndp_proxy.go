@@ -0,0 +1,217 @@
+package egressip
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	eipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// ndpProxy answers the neighbor solicitations of the IPv6 egress IPs assigned to the node whose prefix is not on-link
+// on any of its interfaces, on behalf of the primary interface. The upstream routers can't resolve them otherwise.
+type ndpProxy struct {
+	// linkName is the primary interface the egress IPs are proxied on
+	linkName string
+	// updateFlows is called with all the proxied IPs when they change, for the neighbor solicitations to reach the host
+	updateFlows func(ips []string)
+
+	lock sync.Mutex
+	// ips are the proxied IPs, by EgressIP name
+	ips map[string]sets.Set[string]
+}
+
+func newNDPProxy(linkName string, updateFlows func(ips []string)) *ndpProxy {
+	return &ndpProxy{
+		linkName:    linkName,
+		updateFlows: updateFlows,
+		ips:         map[string]sets.Set[string]{},
+	}
+}
+
+// EnableNDPProxy proxies the neighbor discovery of the IPv6 egress IPs assigned to the node that are not on-link on
+// linkName. It must be called before Run.
+func (c *Controller) EnableNDPProxy(linkName string, updateFlows func(ips []string)) {
+	c.ndpProxy = newNDPProxy(linkName, updateFlows)
+}
+
+// getNDPProxyIPs returns the IPv6 egress IPs of the EgressIP assigned to the node that are neither on the OVN network
+// nor on a secondary host network
+func (c *Controller) getNDPProxyIPs(eip *eipv1.EgressIP, parsedNodeEIPConfig *util.ParsedNodeEgressIPConfiguration) (sets.Set[string], error) {
+	ips := sets.New[string]()
+	if eip == nil || !eip.DeletionTimestamp.IsZero() {
+		return ips, nil
+	}
+	for _, status := range eip.Status.Items {
+		if isValid := isEIPStatusItemValid(status, c.nodeName); !isValid {
+			continue
+		}
+		eIPNet, err := util.GetIPNetFullMask(status.EgressIP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mask for EgressIP %s IP %s: %v", eip.Name, status.EgressIP, err)
+		}
+		if !utilnet.IsIPv6(eIPNet.IP) || util.IsOVNNetwork(parsedNodeEIPConfig, eIPNet.IP) {
+			continue
+		}
+		found, _, err := findLinkOnSameNetworkAsIP(eIPNet.IP, c.v4, c.v6)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find a network to host EgressIP %s IP %s: %v", eip.Name, status.EgressIP, err)
+		}
+		if found {
+			continue
+		}
+		ips.Insert(eIPNet.IP.String())
+	}
+	return ips, nil
+}
+
+// syncNDPProxy proxies the IPs of the EgressIP, the IPs it no longer has are not proxied anymore
+func (c *Controller) syncNDPProxy(eIPName string, eip *eipv1.EgressIP) error {
+	parsedNodeEIPConfig, err := c.getNodeEgressIPConfig()
+	if err != nil {
+		return fmt.Errorf("failed to determine egress IP config for node %s: %w", c.nodeName, err)
+	}
+	ips, err := c.getNDPProxyIPs(eip, parsedNodeEIPConfig)
+	if err != nil {
+		return err
+	}
+	return c.ndpProxy.sync(eIPName, ips)
+}
+
+// repairNDPProxy removes the proxy entries of the primary interface that no EgressIP needs anymore
+func (c *Controller) repairNDPProxy() error {
+	parsedNodeEIPConfig, err := c.getNodeEgressIPConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get node egress IP config: %v", err)
+	}
+	egressIPs, err := c.getAllEIPs()
+	if err != nil {
+		return err
+	}
+	expected := sets.New[string]()
+	for _, egressIP := range egressIPs {
+		ips, err := c.getNDPProxyIPs(egressIP, parsedNodeEIPConfig)
+		if err != nil {
+			return err
+		}
+		expected = expected.Union(ips)
+	}
+	return c.ndpProxy.removeStale(expected)
+}
+
+func (p *ndpProxy) sync(eIPName string, ips sets.Set[string]) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	existing := p.ips[eIPName]
+	if existing == nil {
+		existing = sets.New[string]()
+	}
+	if existing.Equal(ips) {
+		return nil
+	}
+	var errs []error
+	proxied := existing.Clone()
+	for _, ip := range sets.List(ips.Difference(existing)) {
+		klog.Infof("Proxying the neighbor discovery of Egress IP %s %s on %s", eIPName, ip, p.linkName)
+		if err := addNDPProxyEntry(ip, p.linkName); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		proxied.Insert(ip)
+	}
+	for _, ip := range sets.List(existing.Difference(ips)) {
+		// another EgressIP may still be assigned the IP while its status is being updated
+		if p.isProxiedByOther(eIPName, ip) {
+			proxied.Delete(ip)
+			continue
+		}
+		klog.Infof("Stopping the neighbor discovery proxy of Egress IP %s %s on %s", eIPName, ip, p.linkName)
+		if err := delNDPProxyEntry(ip, p.linkName); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		proxied.Delete(ip)
+	}
+	if proxied.Len() == 0 {
+		delete(p.ips, eIPName)
+	} else {
+		p.ips[eIPName] = proxied
+	}
+	p.updateFlows(p.allIPs())
+	return utilerrors.Join(errs...)
+}
+
+// removeStale removes the proxy entries of the interface that aren't expected
+func (p *ndpProxy) removeStale(expected sets.Set[string]) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	existing, err := listNDPProxyEntries(p.linkName)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ip := range sets.List(existing.Difference(expected)) {
+		klog.Infof("Removing stale neighbor discovery proxy entry %s on %s", ip, p.linkName)
+		if err := delNDPProxyEntry(ip, p.linkName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func (p *ndpProxy) isProxiedByOther(eIPName, ip string) bool {
+	for name, ips := range p.ips {
+		if name != eIPName && ips.Has(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ndpProxy) allIPs() []string {
+	all := sets.New[string]()
+	for _, ips := range p.ips {
+		all = all.Union(ips)
+	}
+	return sets.List(all)
+}
+
+func addNDPProxyEntry(ip, linkName string) error {
+	_, stderr, err := util.RunIP("-6", "neigh", "add", "proxy", ip, "dev", linkName)
+	if err != nil && !strings.Contains(stderr, "File exists") {
+		return fmt.Errorf("failed to add the neighbor proxy entry %s on %s, stderr: %q, error: %v", ip, linkName, stderr, err)
+	}
+	return nil
+}
+
+func delNDPProxyEntry(ip, linkName string) error {
+	_, stderr, err := util.RunIP("-6", "neigh", "del", "proxy", ip, "dev", linkName)
+	if err != nil && !strings.Contains(stderr, "No such file or directory") {
+		return fmt.Errorf("failed to delete the neighbor proxy entry %s on %s, stderr: %q, error: %v", ip, linkName, stderr, err)
+	}
+	return nil
+}
+
+func listNDPProxyEntries(linkName string) (sets.Set[string], error) {
+	stdout, stderr, err := util.RunIP("-6", "neigh", "show", "proxy", "dev", linkName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the neighbor proxy entries on %s, stderr: %q, error: %v", linkName, stderr, err)
+	}
+	ips := sets.New[string]()
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !utilnet.IsIPv6String(fields[0]) {
+			continue
+		}
+		ips.Insert(fields[0])
+	}
+	return ips, nil
+}
@@ -0,0 +1,91 @@
+package egressip
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = ginkgo.Describe("NDP proxy", func() {
+	var (
+		p        *ndpProxy
+		fakeExec *ovntest.FakeExec
+		flowIPs  []string
+	)
+
+	addCmd := func(ip string) *ovntest.ExpectedCmd {
+		return &ovntest.ExpectedCmd{Cmd: "ip -6 neigh add proxy " + ip + " dev breth0"}
+	}
+	delCmd := func(ip string) *ovntest.ExpectedCmd {
+		return &ovntest.ExpectedCmd{Cmd: "ip -6 neigh del proxy " + ip + " dev breth0"}
+	}
+
+	ginkgo.BeforeEach(func() {
+		fakeExec = ovntest.NewFakeExec()
+		gomega.Expect(util.SetExec(fakeExec)).To(gomega.Succeed())
+		flowIPs = nil
+		p = newNDPProxy("breth0", func(ips []string) { flowIPs = ips })
+	})
+
+	ginkgo.AfterEach(func() {
+		gomega.Expect(fakeExec.CalledMatchesExpected()).To(gomega.BeTrue(), fakeExec.ErrorDesc())
+	})
+
+	ginkgo.It("proxies the IPs of the egress IPs and their flows", func() {
+		fakeExec.AddFakeCmdsNoOutputNoError([]string{
+			addCmd("2001:db8:1::5").Cmd,
+			addCmd("2001:db8:1::6").Cmd,
+			addCmd("2001:db8:2::5").Cmd,
+			delCmd("2001:db8:1::5").Cmd,
+			delCmd("2001:db8:2::5").Cmd,
+		})
+		gomega.Expect(p.sync("eip1", sets.New("2001:db8:1::5", "2001:db8:1::6"))).To(gomega.Succeed())
+		gomega.Expect(p.sync("eip2", sets.New("2001:db8:2::5"))).To(gomega.Succeed())
+		gomega.Expect(flowIPs).To(gomega.Equal([]string{"2001:db8:1::5", "2001:db8:1::6", "2001:db8:2::5"}))
+
+		gomega.Expect(p.sync("eip1", sets.New("2001:db8:1::6"))).To(gomega.Succeed())
+		gomega.Expect(p.sync("eip2", sets.New[string]())).To(gomega.Succeed())
+		gomega.Expect(flowIPs).To(gomega.Equal([]string{"2001:db8:1::6"}))
+		gomega.Expect(p.ips).NotTo(gomega.HaveKey("eip2"))
+	})
+
+	ginkgo.It("keeps the entry of an IP moving to another egress IP", func() {
+		// the entry already exists when the second egress IP is assigned the IP
+		fakeExec.AddFakeCmd(addCmd("2001:db8:1::5"))
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ip -6 neigh add proxy 2001:db8:1::5 dev breth0",
+			Stderr: "RTNETLINK answers: File exists",
+			Err:    fmt.Errorf("exit status 2"),
+		})
+		gomega.Expect(p.sync("eip1", sets.New("2001:db8:1::5"))).To(gomega.Succeed())
+		gomega.Expect(p.sync("eip2", sets.New("2001:db8:1::5"))).To(gomega.Succeed())
+		gomega.Expect(p.sync("eip1", sets.New[string]())).To(gomega.Succeed())
+		gomega.Expect(flowIPs).To(gomega.Equal([]string{"2001:db8:1::5"}))
+	})
+
+	ginkgo.It("retries the IPs it failed to proxy", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ip -6 neigh add proxy 2001:db8:1::5 dev breth0",
+			Stderr: "RTNETLINK answers: Operation not permitted",
+			Err:    fmt.Errorf("exit status 2"),
+		})
+		gomega.Expect(p.sync("eip1", sets.New("2001:db8:1::5"))).NotTo(gomega.Succeed())
+		gomega.Expect(p.ips).NotTo(gomega.HaveKey("eip1"))
+		gomega.Expect(flowIPs).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("removes the stale entries", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ip -6 neigh show proxy dev breth0",
+			Output: "2001:db8:1::5 dev breth0 proxy\n2001:db8:1::6 dev breth0 proxy",
+		})
+		fakeExec.AddFakeCmd(delCmd("2001:db8:1::5"))
+		gomega.Expect(p.removeStale(sets.New("2001:db8:1::6"))).To(gomega.Succeed())
+	})
+})
@@ -0,0 +1,60 @@
+package egressservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// Cleanup removes the SNAT rules and ip rules the controller configured on the node, for when the egress service
+// feature is disabled. The chain and its return rule are left to the gateway that creates them.
+func Cleanup() error {
+	var errs []error
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		if err := cleanupSNATRules(proto); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	nlOps := util.GetNetLinkOps()
+	for _, family := range []int{netlink.FAMILY_V4, netlink.FAMILY_V6} {
+		rules, err := nlOps.RuleListFiltered(family, &netlink.Rule{Priority: IPRulePriority}, netlink.RT_FILTER_PRIORITY)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list ip rules of priority %d: %w", IPRulePriority, err))
+			continue
+		}
+		for i := range rules {
+			if err := nlOps.RuleDel(&rules[i]); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete ip rule %s: %w", rules[i].String(), err))
+			}
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func cleanupSNATRules(proto iptables.Protocol) error {
+	ipt, err := util.GetIPTablesHelper(proto)
+	if err != nil {
+		return err
+	}
+	rules, err := ipt.List("nat", Chain)
+	if err != nil {
+		// the chain does not exist unless the gateway ran with the protocol
+		return nil
+	}
+	var errs []error
+	for _, rule := range rules {
+		if !strings.Contains(rule, "-j SNAT") {
+			continue
+		}
+		args := strings.Fields(strings.TrimPrefix(rule, fmt.Sprintf("-A %s ", Chain)))
+		if err := ipt.Delete("nat", Chain, args...); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete rule %q of chain nat/%s: %w", rule, Chain, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
@@ -0,0 +1,319 @@
+package physicalnetwork
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	physicalnetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1"
+	physicalnetworkinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/informers/externalversions/physicalnetwork/v1"
+	physicalnetworklisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/listers/physicalnetwork/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// syncKey is the only key of the queue: every change triggers a recomputation of
+	// the bridge mappings of this node
+	syncKey = "physicalnetworks"
+
+	// managedPhysicalNetworksKey is the Open_vSwitch external_ids key recording the physical networks
+	// whose ovn-bridge-mappings are managed by the controller, the mappings set by other means are
+	// never removed
+	managedPhysicalNetworksKey = "ovn-k8s-physical-networks"
+
+	// physicalNetworkConflictEventReason is the reason of the node events reporting a PhysicalNetwork
+	// that can't be mapped on the node
+	physicalNetworkConflictEventReason = "PhysicalNetworkConflict"
+)
+
+// Controller reconciles the ovn-bridge-mappings of the node from the PhysicalNetworks selecting it:
+// it creates the missing provider bridges, maps the physical networks to them and removes the
+// mappings of the PhysicalNetworks that were deleted or don't select the node anymore.
+type Controller struct {
+	stopCh   <-chan struct{}
+	thisNode string // name of the node we're running on
+	recorder record.EventRecorder
+
+	physicalNetworkLister physicalnetworklisters.PhysicalNetworkLister
+	physicalNetworkSynced cache.InformerSynced
+
+	nodeLister  corelisters.NodeLister
+	nodesSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(stopCh <-chan struct{}, thisNode string, recorder record.EventRecorder,
+	physicalNetworkInformer physicalnetworkinformer.PhysicalNetworkInformer,
+	nodeInformer cache.SharedIndexInformer) (*Controller, error) {
+	klog.Info("Setting up event handlers for Physical Networks")
+
+	c := &Controller{
+		stopCh:   stopCh,
+		thisNode: thisNode,
+		recorder: recorder,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemFastSlowRateLimiter(1*time.Second, 5*time.Second, 5),
+			"physicalnetworks",
+		),
+	}
+
+	c.physicalNetworkLister = physicalNetworkInformer.Lister()
+	c.physicalNetworkSynced = physicalNetworkInformer.Informer().HasSynced
+	_, err := physicalNetworkInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdd,
+		UpdateFunc: c.onUpdate,
+		DeleteFunc: c.onAdd,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	c.nodeLister = corelisters.NewNodeLister(nodeInformer.GetIndexer())
+	c.nodesSynced = nodeInformer.HasSynced
+	_, err = nodeInformer.AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onNodeAdd,
+		UpdateFunc: c.onNodeUpdate,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) onAdd(_ interface{}) {
+	c.queue.Add(syncKey)
+}
+
+func (c *Controller) onUpdate(oldObj, newObj interface{}) {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get object meta from %+v: %v", oldObj, err))
+		return
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get object meta from %+v: %v", newObj, err))
+		return
+	}
+	if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return
+	}
+	c.queue.Add(syncKey)
+}
+
+func (c *Controller) onNodeAdd(obj interface{}) {
+	if node, ok := obj.(*corev1.Node); ok && node.Name == c.thisNode {
+		c.queue.Add(syncKey)
+	}
+}
+
+// onNodeUpdate queues a sync only when the labels of this node change, they select the
+// PhysicalNetworks mapped on the node
+func (c *Controller) onNodeUpdate(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*corev1.Node)
+	newNode := newObj.(*corev1.Node)
+	if newNode.Name != c.thisNode || reflect.DeepEqual(oldNode.Labels, newNode.Labels) {
+		return
+	}
+	c.queue.Add(syncKey)
+}
+
+func (c *Controller) Run(wg *sync.WaitGroup) error {
+	defer utilruntime.HandleCrash()
+
+	klog.Infof("Starting Physical Networks Controller")
+
+	if !util.WaitForInformerCacheSyncWithTimeout("physicalnetworks", c.stopCh, c.physicalNetworkSynced, c.nodesSynced) {
+		return fmt.Errorf("timed out waiting for physical network caches to sync")
+	}
+
+	c.queue.Add(syncKey)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			for c.processNextItem() {
+			}
+		}, time.Second, c.stopCh)
+	}()
+
+	// add shutdown goroutine waiting for c.stopCh
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// wait until we're told to stop
+		<-c.stopCh
+
+		klog.Infof("Shutting down Physical Networks controller")
+		c.queue.ShutDown()
+	}()
+
+	return nil
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.sync()
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("failed to sync the bridge mappings of the physical networks: %v", err))
+	c.queue.AddRateLimited(key)
+	return true
+}
+
+func (c *Controller) sync() error {
+	desired, err := c.desiredBridgeMappings()
+	if err != nil {
+		return err
+	}
+	// ovn-controller only maps the physical networks to existing bridges
+	for _, physNet := range sets.List(sets.KeySet(desired)) {
+		if _, stderr, err := util.RunOVSVsctl("--may-exist", "add-br", desired[physNet]); err != nil {
+			return fmt.Errorf("failed to create bridge %s of physical network %s, stderr: %s (%v)",
+				desired[physNet], physNet, stderr, err)
+		}
+	}
+
+	current, err := util.GetOVNBridgeMappings()
+	if err != nil {
+		return err
+	}
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:"+managedPhysicalNetworksKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the managed physical networks, stderr: %s (%v)", stderr, err)
+	}
+	managed := sets.New[string]()
+	for _, physNet := range strings.Split(stdout, ",") {
+		if physNet != "" {
+			managed.Insert(physNet)
+		}
+	}
+
+	mappings := reconcileBridgeMappings(current, managed, desired)
+	if reflect.DeepEqual(mappings, current) && managed.Equal(sets.KeySet(desired)) {
+		return nil
+	}
+	klog.Infof("Node %s maps the physical networks %v, ovn-bridge-mappings %s", c.thisNode,
+		sets.List(sets.KeySet(desired)), formatBridgeMappings(mappings))
+	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		fmt.Sprintf("external_ids:ovn-bridge-mappings=%q", formatBridgeMappings(mappings)),
+		fmt.Sprintf("external_ids:%s=%q", managedPhysicalNetworksKey, strings.Join(sets.List(sets.KeySet(desired)), ",")))
+	if err != nil {
+		return fmt.Errorf("failed to set ovn-bridge-mappings, stderr: %s (%v)", stderr, err)
+	}
+	return nil
+}
+
+// desiredBridgeMappings returns the bridges of the physical networks of the PhysicalNetworks selecting this
+// node. The gateway physical network and the physical networks with a localnet bridge mapping configured on
+// the node can't be mapped by a PhysicalNetwork.
+func (c *Controller) desiredBridgeMappings() (map[string]string, error) {
+	node, err := c.nodeLister.Get(c.thisNode)
+	if err != nil {
+		return nil, err
+	}
+	physicalNetworks, err := c.physicalNetworkLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	localMappings := config.LocalnetBridgeMappings()
+	desired := map[string]string{}
+	for _, physicalNetwork := range physicalNetworks {
+		nodeSelector, err := metav1.LabelSelectorAsSelector(&physicalNetwork.Spec.NodeSelector)
+		if err != nil {
+			klog.Errorf("Invalid node selector of PhysicalNetwork %s: %v", physicalNetwork.Name, err)
+			continue
+		}
+		if !nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		if physicalNetwork.Name == types.PhysicalNetworkName {
+			c.reportConflict(physicalNetwork, "physical network %s is reserved for the gateway bridge", physicalNetwork.Name)
+			continue
+		}
+		if bridge, ok := localMappings[physicalNetwork.Name]; ok {
+			if bridge != physicalNetwork.Spec.BridgeName {
+				c.reportConflict(physicalNetwork, "physical network %s is mapped to bridge %s by the node configuration",
+					physicalNetwork.Name, bridge)
+			}
+			continue
+		}
+		desired[physicalNetwork.Name] = physicalNetwork.Spec.BridgeName
+	}
+	return desired, nil
+}
+
+func (c *Controller) reportConflict(physicalNetwork *physicalnetworkv1.PhysicalNetwork, messageFmt string, args ...interface{}) {
+	klog.Warningf("Ignoring PhysicalNetwork %s on node %s: "+messageFmt,
+		append([]interface{}{physicalNetwork.Name, c.thisNode}, args...)...)
+	nodeRef := &corev1.ObjectReference{
+		Kind: "Node",
+		Name: c.thisNode,
+		UID:  ktypes.UID(c.thisNode),
+	}
+	c.recorder.Eventf(nodeRef, corev1.EventTypeWarning, physicalNetworkConflictEventReason, messageFmt, args...)
+}
+
+// reconcileBridgeMappings returns the current bridge mappings without the managed physical networks that are
+// not desired anymore, and with the desired ones
+func reconcileBridgeMappings(current map[string]string, managed sets.Set[string], desired map[string]string) map[string]string {
+	mappings := map[string]string{}
+	for physNet, bridge := range current {
+		if managed.Has(physNet) {
+			if _, ok := desired[physNet]; !ok {
+				klog.Infof("Removing the stale mapping of physical network %s to bridge %s", physNet, bridge)
+				continue
+			}
+		}
+		mappings[physNet] = bridge
+	}
+	for physNet, bridge := range desired {
+		mappings[physNet] = bridge
+	}
+	return mappings
+}
+
+// formatBridgeMappings formats the bridge mappings in the ovn-bridge-mappings format, sorted by physical network
+func formatBridgeMappings(mappings map[string]string) string {
+	physNets := make([]string, 0, len(mappings))
+	for physNet := range mappings {
+		physNets = append(physNets, physNet)
+	}
+	sort.Strings(physNets)
+	for i, physNet := range physNets {
+		physNets[i] = physNet + ":" + mappings[physNet]
+	}
+	return strings.Join(physNets, ",")
+}
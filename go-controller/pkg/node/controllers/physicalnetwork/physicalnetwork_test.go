@@ -0,0 +1,148 @@
+package physicalnetwork
+
+import (
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	physicalnetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1"
+	physicalnetworklisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/listers/physicalnetwork/v1"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+)
+
+func newPhysicalNetwork(name, bridge string, nodeSelector map[string]string) *physicalnetworkv1.PhysicalNetwork {
+	return &physicalnetworkv1.PhysicalNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: physicalnetworkv1.PhysicalNetworkSpec{
+			NodeSelector: metav1.LabelSelector{MatchLabels: nodeSelector},
+			BridgeName:   bridge,
+		},
+	}
+}
+
+// newTestController returns a controller for node1 whose listers serve the given objects
+func newTestController(recorder record.EventRecorder, objects ...interface{}) *Controller {
+	physicalNetworkIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, obj := range objects {
+		var err error
+		switch obj.(type) {
+		case *physicalnetworkv1.PhysicalNetwork:
+			err = physicalNetworkIndexer.Add(obj)
+		case *corev1.Node:
+			err = nodeIndexer.Add(obj)
+		}
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+	return &Controller{
+		thisNode:              "node1",
+		recorder:              recorder,
+		physicalNetworkLister: physicalnetworklisters.NewPhysicalNetworkLister(physicalNetworkIndexer),
+		nodeLister:            corelisters.NewNodeLister(nodeIndexer),
+	}
+}
+
+var _ = ginkgo.Describe("Physical network bridge mappings", func() {
+	var (
+		fexec    *ovntest.FakeExec
+		recorder *record.FakeRecorder
+		node     *corev1.Node
+	)
+
+	ginkgo.BeforeEach(func() {
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+		fexec = ovntest.NewFakeExec()
+		gomega.Expect(util.SetExec(fexec)).To(gomega.Succeed())
+		recorder = record.NewFakeRecorder(10)
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"rack": "r1"}}}
+	})
+
+	ginkgo.It("creates the bridges and maps the physical networks selecting the node", func() {
+		c := newTestController(recorder, node,
+			newPhysicalNetwork("physnet1", "br-phys1", map[string]string{"rack": "r1"}),
+			newPhysicalNetwork("physnet2", "br-phys2", nil),
+			newPhysicalNetwork("physnet3", "br-phys3", map[string]string{"rack": "r2"}),
+		)
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --may-exist add-br br-phys1",
+			"ovs-vsctl --timeout=15 --may-exist add-br br-phys2",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:" + managedPhysicalNetworksKey,
+			"ovs-vsctl --timeout=15 set Open_vSwitch . " +
+				"external_ids:ovn-bridge-mappings=\"" + types.PhysicalNetworkName + ":breth0,physnet1:br-phys1,physnet2:br-phys2\" " +
+				"external_ids:" + managedPhysicalNetworksKey + "=\"physnet1,physnet2\"",
+		})
+
+		gomega.Expect(c.sync()).To(gomega.Succeed())
+		gomega.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
+		gomega.Expect(recorder.Events).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("removes the stale managed mappings and keeps the others", func() {
+		c := newTestController(recorder, node, newPhysicalNetwork("physnet1", "br-phys1", nil))
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --may-exist add-br br-phys1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0,physnet1:br-phys1,physnet2:br-phys2,external:br-ext",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:" + managedPhysicalNetworksKey,
+			Output: "physnet1,physnet2",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 set Open_vSwitch . " +
+				"external_ids:ovn-bridge-mappings=\"external:br-ext," + types.PhysicalNetworkName + ":breth0,physnet1:br-phys1\" " +
+				"external_ids:" + managedPhysicalNetworksKey + "=\"physnet1\"",
+		})
+
+		gomega.Expect(c.sync()).To(gomega.Succeed())
+		gomega.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
+	})
+
+	ginkgo.It("does not update the mappings already in sync", func() {
+		c := newTestController(recorder, node, newPhysicalNetwork("physnet1", "br-phys1", nil))
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --may-exist add-br br-phys1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0,physnet1:br-phys1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:" + managedPhysicalNetworksKey,
+			Output: "physnet1",
+		})
+
+		gomega.Expect(c.sync()).To(gomega.Succeed())
+		gomega.Expect(fexec.CalledMatchesExpected()).To(gomega.BeTrue(), fexec.ErrorDesc)
+	})
+
+	ginkgo.It("reports the physical networks conflicting with the node configuration", func() {
+		config.OvnKubeNode.LocalnetBridgeMappings = "physnet1:br-local"
+		c := newTestController(recorder, node,
+			newPhysicalNetwork(types.PhysicalNetworkName, "br-phys0", nil),
+			newPhysicalNetwork("physnet1", "br-phys1", nil),
+		)
+
+		desired, err := c.desiredBridgeMappings()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(desired).To(gomega.BeEmpty())
+		gomega.Expect(recorder.Events).To(gomega.HaveLen(2))
+		gomega.Expect(<-recorder.Events).To(gomega.ContainSubstring(physicalNetworkConflictEventReason))
+	})
+})
@@ -0,0 +1,360 @@
+package serviceannouncement
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+	"time"
+
+	serviceannouncementinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/informers/externalversions/serviceannouncement/v1"
+	serviceannouncementlisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/listers/serviceannouncement/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// syncKey is the only key of the queue: every change triggers a recomputation of
+// the ingress IPs announced by this node
+const syncKey = "serviceannouncements"
+
+// Announcer claims IPs on the L2 network of the node
+type Announcer interface {
+	// SetAnnouncedIPs makes the node announce exactly the given IPs
+	SetAnnouncedIPs(ips sets.Set[string]) error
+}
+
+// Controller selects, for every LoadBalancer ingress IP of the Services selected by a
+// ServiceAnnouncement, the node that announces it. Every node computes the same selection
+// from the informer caches and only announces the IPs it was selected for.
+type Controller struct {
+	stopCh    <-chan struct{}
+	thisNode  string // name of the node we're running on
+	announcer Announcer
+
+	serviceAnnouncementLister serviceannouncementlisters.ServiceAnnouncementLister
+	serviceAnnouncementSynced cache.InformerSynced
+
+	serviceLister  corelisters.ServiceLister
+	servicesSynced cache.InformerSynced
+
+	endpointSliceLister  discoverylisters.EndpointSliceLister
+	endpointSlicesSynced cache.InformerSynced
+
+	nodeLister  corelisters.NodeLister
+	nodesSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewController(stopCh <-chan struct{}, thisNode string, announcer Announcer,
+	saInformer serviceannouncementinformer.ServiceAnnouncementInformer,
+	serviceInformer cache.SharedIndexInformer,
+	endpointSliceInformer cache.SharedIndexInformer,
+	nodeInformer cache.SharedIndexInformer) (*Controller, error) {
+	klog.Info("Setting up event handlers for Service Announcements")
+
+	c := &Controller{
+		stopCh:    stopCh,
+		thisNode:  thisNode,
+		announcer: announcer,
+		queue: workqueue.NewNamedRateLimitingQueue(
+			workqueue.NewItemFastSlowRateLimiter(1*time.Second, 5*time.Second, 5),
+			"serviceannouncements",
+		),
+	}
+
+	c.serviceAnnouncementLister = saInformer.Lister()
+	c.serviceAnnouncementSynced = saInformer.Informer().HasSynced
+	_, err := saInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdd,
+		UpdateFunc: c.onUpdate,
+		DeleteFunc: c.onAdd,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	c.serviceLister = corelisters.NewServiceLister(serviceInformer.GetIndexer())
+	c.servicesSynced = serviceInformer.HasSynced
+	_, err = serviceInformer.AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdd,
+		UpdateFunc: c.onUpdate,
+		DeleteFunc: c.onAdd,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	c.endpointSliceLister = discoverylisters.NewEndpointSliceLister(endpointSliceInformer.GetIndexer())
+	c.endpointSlicesSynced = endpointSliceInformer.HasSynced
+	_, err = endpointSliceInformer.AddEventHandler(factory.WithUpdateHandlingForObjReplace(
+		util.GetDefaultEndpointSlicesEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.onAdd,
+			UpdateFunc: c.onUpdate,
+			DeleteFunc: c.onAdd,
+		})))
+	if err != nil {
+		return nil, err
+	}
+
+	c.nodeLister = corelisters.NewNodeLister(nodeInformer.GetIndexer())
+	c.nodesSynced = nodeInformer.HasSynced
+	_, err = nodeInformer.AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdd,
+		UpdateFunc: c.onNodeUpdate,
+		DeleteFunc: c.onAdd,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Controller) onAdd(_ interface{}) {
+	c.queue.Add(syncKey)
+}
+
+func (c *Controller) onUpdate(oldObj, newObj interface{}) {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get object meta from %+v: %v", oldObj, err))
+		return
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get object meta from %+v: %v", newObj, err))
+		return
+	}
+	if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return
+	}
+	c.queue.Add(syncKey)
+}
+
+// onNodeUpdate queues a sync only when a change of the node can change its eligibility,
+// node status updates are too frequent otherwise
+func (c *Controller) onNodeUpdate(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*corev1.Node)
+	newNode := newObj.(*corev1.Node)
+	if reflect.DeepEqual(oldNode.Labels, newNode.Labels) && nodeIsReady(oldNode) == nodeIsReady(newNode) {
+		return
+	}
+	c.queue.Add(syncKey)
+}
+
+func (c *Controller) Run(wg *sync.WaitGroup) error {
+	defer utilruntime.HandleCrash()
+
+	klog.Infof("Starting Service Announcements Controller")
+
+	if !util.WaitForInformerCacheSyncWithTimeout("serviceannouncements", c.stopCh, c.serviceAnnouncementSynced,
+		c.servicesSynced, c.endpointSlicesSynced, c.nodesSynced) {
+		return fmt.Errorf("timed out waiting for service announcement caches to sync")
+	}
+
+	c.queue.Add(syncKey)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			for c.processNextItem() {
+			}
+		}, time.Second, c.stopCh)
+	}()
+
+	// add shutdown goroutine waiting for c.stopCh
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// wait until we're told to stop
+		<-c.stopCh
+
+		klog.Infof("Shutting down Service Announcements controller")
+		c.queue.ShutDown()
+	}()
+
+	return nil
+}
+
+func (c *Controller) processNextItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.sync()
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	utilruntime.HandleError(fmt.Errorf("failed to sync the announced service ingress IPs: %v", err))
+	c.queue.AddRateLimited(key)
+	return true
+}
+
+func (c *Controller) sync() error {
+	ips, err := c.announcedIPs()
+	if err != nil {
+		return err
+	}
+	klog.V(5).Infof("Node %s announces the service ingress IPs %v", c.thisNode, sets.List(ips))
+	return c.announcer.SetAnnouncedIPs(ips)
+}
+
+// announcedIPs returns the LoadBalancer ingress IPs this node was selected to announce
+func (c *Controller) announcedIPs() (sets.Set[string], error) {
+	announcements, err := c.serviceAnnouncementLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	// the eligible nodes of each service, a service selected by several announcements can be
+	// announced by the nodes selected by any of them
+	serviceNodes := map[string]sets.Set[string]{}
+	services := map[string]*corev1.Service{}
+	for _, announcement := range announcements {
+		serviceSelector, err := metav1.LabelSelectorAsSelector(&announcement.Spec.ServiceSelector)
+		if err != nil {
+			klog.Errorf("Invalid service selector of ServiceAnnouncement %s/%s: %v", announcement.Namespace, announcement.Name, err)
+			continue
+		}
+		nodeSelector, err := metav1.LabelSelectorAsSelector(&announcement.Spec.NodeSelector)
+		if err != nil {
+			klog.Errorf("Invalid node selector of ServiceAnnouncement %s/%s: %v", announcement.Namespace, announcement.Name, err)
+			continue
+		}
+		svcs, err := c.serviceLister.Services(announcement.Namespace).List(serviceSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range svcs {
+			if !util.ServiceTypeHasLoadBalancer(svc) || len(svc.Status.LoadBalancer.Ingress) == 0 {
+				continue
+			}
+			key := svc.Namespace + "/" + svc.Name
+			if serviceNodes[key] == nil {
+				serviceNodes[key] = sets.New[string]()
+			}
+			services[key] = svc
+			for _, node := range nodes {
+				if nodeSelector.Matches(labels.Set(node.Labels)) && nodeIsEligible(node) {
+					serviceNodes[key].Insert(node.Name)
+				}
+			}
+		}
+	}
+
+	// the eligible nodes of each IP, an IP shared by several services can be announced by the nodes
+	// eligible for any of them, every node has to select the announcing node among the same ones
+	ipNodes := map[string]sets.Set[string]{}
+	for key, eligible := range serviceNodes {
+		svc := services[key]
+		if util.ServiceExternalTrafficPolicyLocal(svc) {
+			// only the nodes whose health check passes, i.e. with local endpoints, can receive the traffic
+			withEndpoints, err := c.nodesWithEndpoints(svc)
+			if err != nil {
+				return nil, err
+			}
+			eligible = eligible.Intersection(withEndpoints)
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP == "" {
+				continue
+			}
+			ip := utilnet.ParseIPSloppy(ingress.IP).String()
+			if ipNodes[ip] == nil {
+				ipNodes[ip] = sets.New[string]()
+			}
+			ipNodes[ip].Insert(eligible.UnsortedList()...)
+		}
+	}
+
+	ips := sets.New[string]()
+	for ip, eligible := range ipNodes {
+		if eligible.Has(c.thisNode) && selectNode(ip, eligible) == c.thisNode {
+			ips.Insert(ip)
+		}
+	}
+	return ips, nil
+}
+
+// nodesWithEndpoints returns the nodes hosting an eligible endpoint of the service
+func (c *Controller) nodesWithEndpoints(svc *corev1.Service) (sets.Set[string], error) {
+	esLabelSelector := labels.Set(map[string]string{
+		discoveryv1.LabelServiceName: svc.Name,
+	}).AsSelectorPreValidated()
+	endpointSlices, err := c.endpointSliceLister.EndpointSlices(svc.Namespace).List(esLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	nodes := sets.New[string]()
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.NodeName == nil {
+				continue
+			}
+			if util.IsEndpointReady(endpoint) || (util.IsEndpointServing(endpoint) && util.IsEndpointTerminating(endpoint)) {
+				nodes.Insert(*endpoint.NodeName)
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// selectNode returns the node announcing the IP among the eligible ones, using rendezvous
+// hashing so that only the IPs of a node that goes away move to another node
+func selectNode(ip string, nodes sets.Set[string]) string {
+	var selected string
+	var maxScore uint64
+	for node := range nodes {
+		h := fnv.New64a()
+		h.Write([]byte(node))
+		h.Write([]byte(ip))
+		score := h.Sum64()
+		if selected == "" || score > maxScore || (score == maxScore && node < selected) {
+			selected = node
+			maxScore = score
+		}
+	}
+	return selected
+}
+
+// nodeIsEligible returns true when the node can announce ingress IPs
+func nodeIsEligible(node *corev1.Node) bool {
+	if _, excluded := node.Labels[corev1.LabelNodeExcludeBalancers]; excluded {
+		return false
+	}
+	return node.DeletionTimestamp.IsZero() && nodeIsReady(node)
+}
+
+func nodeIsReady(n *corev1.Node) bool {
+	for _, condition := range n.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
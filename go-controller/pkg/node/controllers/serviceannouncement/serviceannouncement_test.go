@@ -0,0 +1,237 @@
+package serviceannouncement
+
+import (
+	"fmt"
+
+	serviceannouncementv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1"
+	serviceannouncementlisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/listers/serviceannouncement/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+)
+
+func newNode(name string, ready bool, labels map[string]string) *corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func newLoadBalancerService(name string, etp corev1.ServiceExternalTrafficPolicyType, ips ...string) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"announce": "true"}},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeLoadBalancer,
+			ExternalTrafficPolicy: etp,
+		},
+	}
+	for _, ip := range ips {
+		svc.Status.LoadBalancer.Ingress = append(svc.Status.LoadBalancer.Ingress, corev1.LoadBalancerIngress{IP: ip})
+	}
+	return svc
+}
+
+func newAnnouncement(nodeSelector map[string]string) *serviceannouncementv1.ServiceAnnouncement {
+	return &serviceannouncementv1.ServiceAnnouncement{
+		ObjectMeta: metav1.ObjectMeta{Name: "announcement", Namespace: "default"},
+		Spec: serviceannouncementv1.ServiceAnnouncementSpec{
+			ServiceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"announce": "true"}},
+			NodeSelector:    metav1.LabelSelector{MatchLabels: nodeSelector},
+		},
+	}
+}
+
+// newTestController returns a controller for the node thisNode whose listers serve the given objects
+func newTestController(thisNode string, objects ...interface{}) *Controller {
+	saIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	serviceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	endpointSliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, obj := range objects {
+		var err error
+		switch obj.(type) {
+		case *serviceannouncementv1.ServiceAnnouncement:
+			err = saIndexer.Add(obj)
+		case *corev1.Service:
+			err = serviceIndexer.Add(obj)
+		case *discoveryv1.EndpointSlice:
+			err = endpointSliceIndexer.Add(obj)
+		case *corev1.Node:
+			err = nodeIndexer.Add(obj)
+		}
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+	return &Controller{
+		thisNode:                  thisNode,
+		serviceAnnouncementLister: serviceannouncementlisters.NewServiceAnnouncementLister(saIndexer),
+		serviceLister:             corelisters.NewServiceLister(serviceIndexer),
+		endpointSliceLister:       discoverylisters.NewEndpointSliceLister(endpointSliceIndexer),
+		nodeLister:                corelisters.NewNodeLister(nodeIndexer),
+	}
+}
+
+// announcers returns, for each ingress IP, the nodes announcing it
+func announcers(nodes []string, objects ...interface{}) map[string][]string {
+	result := map[string][]string{}
+	for _, node := range nodes {
+		ips, err := newTestController(node, objects...).announcedIPs()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		for ip := range ips {
+			result[ip] = append(result[ip], node)
+		}
+	}
+	return result
+}
+
+var _ = ginkgo.Describe("Service announcement node selection", func() {
+	nodes := []string{"node1", "node2", "node3"}
+
+	ginkgo.It("announces every ingress IP from exactly one eligible node", func() {
+		objects := []interface{}{
+			newAnnouncement(map[string]string{"edge": "true"}),
+			newLoadBalancerService("svc", corev1.ServiceExternalTrafficPolicyTypeCluster, "192.168.10.1", "192.168.10.2", "fd00::1"),
+			newNode("node1", true, map[string]string{"edge": "true"}),
+			newNode("node2", true, map[string]string{"edge": "true"}),
+			// not selected by the announcement
+			newNode("node3", true, nil),
+		}
+		result := announcers(nodes, objects...)
+		gomega.Expect(result).To(gomega.HaveLen(3))
+		for ip, announcers := range result {
+			gomega.Expect(announcers).To(gomega.HaveLen(1), "IP %s announced by %v", ip, announcers)
+			gomega.Expect(announcers[0]).NotTo(gomega.Equal("node3"))
+		}
+	})
+
+	ginkgo.It("moves the IPs of a node that is not ready anymore", func() {
+		readyNodes := []interface{}{
+			newAnnouncement(nil),
+			newLoadBalancerService("svc", corev1.ServiceExternalTrafficPolicyTypeCluster, "192.168.10.1"),
+			newNode("node1", true, nil),
+			newNode("node2", true, nil),
+			newNode("node3", true, nil),
+		}
+		owner := announcers(nodes, readyNodes...)["192.168.10.1"]
+		gomega.Expect(owner).To(gomega.HaveLen(1))
+
+		objects := []interface{}{
+			newAnnouncement(nil),
+			newLoadBalancerService("svc", corev1.ServiceExternalTrafficPolicyTypeCluster, "192.168.10.1"),
+		}
+		for _, node := range nodes {
+			objects = append(objects, newNode(node, node != owner[0], nil))
+		}
+		newOwner := announcers(nodes, objects...)["192.168.10.1"]
+		gomega.Expect(newOwner).To(gomega.HaveLen(1))
+		gomega.Expect(newOwner[0]).NotTo(gomega.Equal(owner[0]))
+	})
+
+	ginkgo.It("only announces the IPs of services with local traffic policy from nodes with endpoints", func() {
+		objects := []interface{}{
+			newAnnouncement(nil),
+			newLoadBalancerService("svc", corev1.ServiceExternalTrafficPolicyTypeLocal, "192.168.10.1", "192.168.10.2", "192.168.10.3"),
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svc-ab23",
+					Namespace: "default",
+					Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses:  []string{"10.128.0.5"},
+						Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+						NodeName:   ptr.To("node2"),
+					},
+				},
+			},
+			newNode("node1", true, nil),
+			newNode("node2", true, nil),
+			newNode("node3", true, nil),
+		}
+		result := announcers(nodes, objects...)
+		gomega.Expect(result).To(gomega.Equal(map[string][]string{
+			"192.168.10.1": {"node2"},
+			"192.168.10.2": {"node2"},
+			"192.168.10.3": {"node2"},
+		}))
+	})
+
+	ginkgo.It("announces an IP shared by several services from exactly one node eligible for any of them", func() {
+		sharedIPs := []string{"192.168.10.1", "192.168.10.10"}
+		objects := []interface{}{
+			newAnnouncement(nil),
+			newLoadBalancerService("local", corev1.ServiceExternalTrafficPolicyTypeLocal, sharedIPs...),
+			newLoadBalancerService("cluster", corev1.ServiceExternalTrafficPolicyTypeCluster, sharedIPs...),
+			&discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "local-ab23",
+					Namespace: "default",
+					Labels:    map[string]string{discoveryv1.LabelServiceName: "local"},
+				},
+				AddressType: discoveryv1.AddressTypeIPv4,
+				Endpoints: []discoveryv1.Endpoint{
+					{
+						Addresses:  []string{"10.128.0.5"},
+						Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+						NodeName:   ptr.To("node1"),
+					},
+				},
+			},
+			newNode("node1", true, nil),
+			newNode("node2", true, nil),
+			newNode("node3", true, nil),
+		}
+		result := announcers(nodes, objects...)
+		gomega.Expect(result).To(gomega.HaveLen(len(sharedIPs)))
+		for ip, announcers := range result {
+			gomega.Expect(announcers).To(gomega.HaveLen(1), "IP %s announced by %v", ip, announcers)
+		}
+	})
+
+	ginkgo.It("does not announce services not selected or without ingress IPs", func() {
+		notSelected := newLoadBalancerService("other", corev1.ServiceExternalTrafficPolicyTypeCluster, "192.168.10.1")
+		notSelected.Labels = nil
+		objects := []interface{}{
+			newAnnouncement(nil),
+			notSelected,
+			newLoadBalancerService("pending", corev1.ServiceExternalTrafficPolicyTypeCluster),
+			newNode("node1", true, nil),
+		}
+		gomega.Expect(announcers([]string{"node1"}, objects...)).To(gomega.BeEmpty())
+	})
+})
+
+var _ = ginkgo.Describe("Service announcement rendezvous hashing", func() {
+	ginkgo.It("only moves the IPs of a removed node", func() {
+		nodes := sets.New("node1", "node2", "node3", "node4")
+		before := map[string]string{}
+		for i := 1; i < 50; i++ {
+			ip := fmt.Sprintf("192.168.10.%d", i)
+			before[ip] = selectNode(ip, nodes)
+		}
+		nodes.Delete("node2")
+		for ip, node := range before {
+			if node != "node2" {
+				gomega.Expect(selectNode(ip, nodes)).To(gomega.Equal(node))
+			} else {
+				gomega.Expect(selectNode(ip, nodes)).NotTo(gomega.Equal("node2"))
+			}
+		}
+	})
+})
@@ -0,0 +1,136 @@
+package node
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// dbAuthChangeDelay is how long the watcher waits for a rotation to settle before re-applying the SSL
+// configuration, as the certificate, key and CA files are usually rotated together
+const dbAuthChangeDelay = time.Second
+
+// dbAuthFiles are the SSL files of an OVN database connection and the function re-applying them
+type dbAuthFiles struct {
+	name  string
+	files []string
+	// digest is the digest of the content of the files the SSL configuration was applied with
+	digest [sha256.Size]byte
+	apply  func() error
+}
+
+// dbAuthCertWatcher watches the client certificate, private key and CA certificate of the SSL connection
+// of ovn-controller to the Southbound database and re-applies the SSL configuration to OVS when they are
+// rotated, ovn-controller is reconnected with it. The libovsdb clients of the OVN databases load the
+// rotated certificates themselves when they reconnect.
+// The directories of the files are watched, so that the atomic symlink swaps of Kubernetes secret
+// volumes are seen, and the content of the files is compared to ignore unrelated events.
+type dbAuthCertWatcher struct {
+	auths []*dbAuthFiles
+}
+
+func newDBAuthCertWatcher(sbEndpoint *southboundEndpoint) *dbAuthCertWatcher {
+	w := &dbAuthCertWatcher{}
+	if config.OvnSouth.Scheme == config.OvnDBSchemeSSL {
+		w.auths = append(w.auths, &dbAuthFiles{
+			name:  "Southbound",
+			files: []string{config.OvnSouth.PrivKey, config.OvnSouth.Cert, config.OvnSouth.CACert},
+			apply: sbEndpoint.reconnect,
+		})
+	}
+	return w
+}
+
+func (w *dbAuthCertWatcher) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	if len(w.auths) == 0 {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create filesystem watcher: %w", err)
+	}
+	dirs := sets.New[string]()
+	for _, auth := range w.auths {
+		auth.digest = filesDigest(auth.files)
+		for _, file := range auth.files {
+			dirs.Insert(filepath.Dir(file))
+		}
+	}
+	for _, dir := range sets.List(dirs) {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("unable to watch [%s] directory: %w", dir, err)
+		}
+	}
+
+	doneWg.Add(1)
+	go func() {
+		defer func() {
+			watcher.Close()
+			doneWg.Done()
+		}()
+		timer := time.NewTimer(dbAuthChangeDelay)
+		timer.Stop()
+		for {
+			select {
+			case _, ok := <-watcher.Events:
+				if ok {
+					timer.Reset(dbAuthChangeDelay)
+				}
+			case err, ok := <-watcher.Errors:
+				if ok {
+					klog.Errorf("Error watching for OVN database SSL files changes: %v", err)
+				}
+			case <-timer.C:
+				w.sync()
+			case <-stopChan:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// sync re-applies the SSL configuration of the connections whose files changed
+func (w *dbAuthCertWatcher) sync() {
+	for _, auth := range w.auths {
+		digest := filesDigest(auth.files)
+		if digest == auth.digest {
+			continue
+		}
+		klog.Infof("OVN %s database SSL files changed, re-applying the SSL configuration", auth.name)
+		if err := auth.apply(); err != nil {
+			// the digest is not updated so that the next change retries
+			klog.Errorf("Failed to re-apply the OVN %s database SSL configuration: %v", auth.name, err)
+			continue
+		}
+		auth.digest = digest
+	}
+}
+
+// filesDigest returns the digest of the content of the files, missing files are digested as empty
+func filesDigest(files []string) [sha256.Size]byte {
+	h := sha256.New()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+			klog.Warningf("Failed to read %s: %v", file, err)
+		}
+		h.Write(content)
+		// separate the files so that content moving from one file to another is a change
+		h.Write([]byte{0})
+	}
+	var digest [sha256.Size]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
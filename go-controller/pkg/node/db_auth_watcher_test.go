@@ -0,0 +1,91 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+var _ = Describe("OVN database SSL files watcher", func() {
+	var (
+		dir     string
+		lock    sync.Mutex
+		applied int
+	)
+	getApplied := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return applied
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		var err error
+		dir, err = os.MkdirTemp("", "ovn-db-auth")
+		Expect(err).NotTo(HaveOccurred())
+		applied = 0
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	// writeSecretFiles writes the files the way a Kubernetes secret volume does: in a data directory
+	// that the ..data symlink is atomically swapped to, the files being symlinks through ..data
+	writeSecretFiles := func(version string) {
+		dataDir := filepath.Join(dir, "..data_"+version)
+		Expect(os.Mkdir(dataDir, 0755)).To(Succeed())
+		for _, name := range []string{"tls.key", "tls.crt", "ca.crt"} {
+			Expect(os.WriteFile(filepath.Join(dataDir, name), []byte(name+" "+version), 0644)).To(Succeed())
+			if _, err := os.Lstat(filepath.Join(dir, name)); os.IsNotExist(err) {
+				Expect(os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name))).To(Succeed())
+			}
+		}
+		Expect(os.Symlink(filepath.Base(dataDir), filepath.Join(dir, "..data_tmp"))).To(Succeed())
+		Expect(os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data"))).To(Succeed())
+	}
+
+	It("re-applies the SSL configuration when the files are rotated", func() {
+		writeSecretFiles("1")
+		config.OvnSouth.Scheme = config.OvnDBSchemeSSL
+		config.OvnSouth.PrivKey = filepath.Join(dir, "tls.key")
+		config.OvnSouth.Cert = filepath.Join(dir, "tls.crt")
+		config.OvnSouth.CACert = filepath.Join(dir, "ca.crt")
+
+		w := newDBAuthCertWatcher(newSouthboundEndpoint())
+		Expect(w.auths).To(HaveLen(1))
+		w.auths[0].apply = func() error {
+			lock.Lock()
+			defer lock.Unlock()
+			applied++
+			return nil
+		}
+		stop := make(chan struct{})
+		wg := &sync.WaitGroup{}
+		defer func() {
+			close(stop)
+			wg.Wait()
+		}()
+		Expect(w.Run(stop, wg)).To(Succeed())
+
+		// an unrelated file does not change the SSL files
+		Expect(os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0644)).To(Succeed())
+		Consistently(getApplied, 2*dbAuthChangeDelay).Should(Equal(0))
+
+		writeSecretFiles("2")
+		Eventually(getApplied, 3*dbAuthChangeDelay).Should(Equal(1))
+		Consistently(getApplied, 2*dbAuthChangeDelay).Should(Equal(1))
+	})
+
+	It("only watches the Southbound SSL connection of ovn-controller", func() {
+		Expect(newDBAuthCertWatcher(newSouthboundEndpoint()).auths).To(BeEmpty())
+		// the libovsdb clients of the Northbound database reload their certificate themselves
+		config.OvnNorth.Scheme = config.OvnDBSchemeSSL
+		Expect(newDBAuthCertWatcher(newSouthboundEndpoint()).auths).To(BeEmpty())
+	})
+})
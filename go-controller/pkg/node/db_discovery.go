@@ -0,0 +1,154 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// dbDiscoverySouthPort is the name of the port of the Southbound database in the discovery service
+const dbDiscoverySouthPort = "south"
+
+// dbEndpointDiscovery watches the EndpointSlices of the Service exposing the OVN databases and points
+// the node to the ready Southbound database endpoints, so that ovn-controller follows the database pods
+// when they move without the node being restarted. The Northbound database is not followed: the
+// libovsdb clients connected to it are not reconnected at runtime.
+// The OVN databases Service is usually headless, which the node watch factory ignores, so the
+// EndpointSlices are watched with a dedicated informer.
+type dbEndpointDiscovery struct {
+	namespace  string
+	name       string
+	client     kubernetes.Interface
+	sbEndpoint *southboundEndpoint
+}
+
+func newDBEndpointDiscovery(client kubernetes.Interface, service string, sbEndpoint *southboundEndpoint) (*dbEndpointDiscovery, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(service)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OVN databases discovery service %q: %v", service, err)
+	}
+	return &dbEndpointDiscovery{
+		namespace:  namespace,
+		name:       name,
+		client:     client,
+		sbEndpoint: sbEndpoint,
+	}, nil
+}
+
+// Run connects the node to the ready Southbound database endpoints before returning, then follows them
+// until stopChan is closed
+func (d *dbEndpointDiscovery) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	if config.OvnSouth.Scheme == config.OvnDBSchemeUnix {
+		return fmt.Errorf("OVN databases discovery requires a tcp or ssl Southbound database scheme")
+	}
+	endpointSliceFactory := informers.NewSharedInformerFactoryWithOptions(d.client, 0,
+		informers.WithNamespace(d.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = labels.Set{discovery.LabelServiceName: d.name}.String()
+		}))
+	endpointSliceInformer := endpointSliceFactory.Discovery().V1().EndpointSlices()
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	_, err := endpointSliceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add the OVN databases EndpointSlice event handler: %v", err)
+	}
+	endpointSliceFactory.Start(stopChan)
+	if !cache.WaitForCacheSync(stopChan, endpointSliceInformer.Informer().HasSynced) {
+		endpointSliceFactory.Shutdown()
+		return fmt.Errorf("timed out waiting for the OVN databases EndpointSlice informer to sync")
+	}
+	sync := func() error {
+		slices, err := endpointSliceInformer.Lister().EndpointSlices(d.namespace).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		return d.sync(slices)
+	}
+	if err := sync(); err != nil {
+		endpointSliceFactory.Shutdown()
+		return fmt.Errorf("failed to connect to the OVN Southbound database endpoints of service %s/%s: %w",
+			d.namespace, d.name, err)
+	}
+
+	doneWg.Add(1)
+	go func() {
+		defer func() {
+			endpointSliceFactory.Shutdown()
+			doneWg.Done()
+		}()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-changed:
+				if err := sync(); err != nil {
+					klog.Errorf("Failed to update the OVN databases endpoints from service %s/%s: %v", d.namespace, d.name, err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// sync points the node to the ready endpoints of the Southbound database, the current address is kept
+// when the service has no ready endpoint
+func (d *dbEndpointDiscovery) sync(slices []*discovery.EndpointSlice) error {
+	south := dbEndpointsAddress(config.OvnSouth.Scheme, dbDiscoverySouthPort, slices)
+	if south == "" {
+		klog.Warningf("No ready OVN Southbound database endpoint in service %s/%s, keeping %s",
+			d.namespace, d.name, config.OvnSouth.GetURL())
+		return nil
+	}
+	return d.sbEndpoint.follow(south)
+}
+
+// dbEndpointsAddress returns the OVN database address of the ready endpoints of the given port,
+// in the "scheme:ip:port,scheme:ip:port" format
+func dbEndpointsAddress(scheme config.OvnDBScheme, portName string, slices []*discovery.EndpointSlice) string {
+	addresses := sets.New[string]()
+	for _, slice := range slices {
+		var port *int32
+		for _, p := range slice.Ports {
+			if p.Name != nil && *p.Name == portName {
+				port = p.Port
+				break
+			}
+		}
+		if port == nil {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			for _, ip := range endpoint.Addresses {
+				addresses.Insert(fmt.Sprintf("%s:%s", scheme, net.JoinHostPort(ip, strconv.Itoa(int(*port)))))
+			}
+		}
+	}
+	return strings.Join(sets.List(addresses), ",")
+}
@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+func newDBEndpointSlice(name string, addressType discovery.AddressType, endpoints ...discovery.Endpoint) *discovery.EndpointSlice {
+	return &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ovn-kubernetes",
+			Labels:    map[string]string{discovery.LabelServiceName: "ovnkube-db"},
+		},
+		AddressType: addressType,
+		Ports: []discovery.EndpointPort{
+			{Name: ptr.To("north"), Port: ptr.To[int32](6641)},
+			{Name: ptr.To(dbDiscoverySouthPort), Port: ptr.To[int32](6642)},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+var _ = Describe("OVN databases endpoint discovery", func() {
+	var (
+		lock      sync.Mutex
+		connected []string
+	)
+	getConnected := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, connected...)
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OvnNorth.Scheme = config.OvnDBSchemeSSL
+		config.OvnNorth.Address = "ssl:10.0.0.1:6641"
+		config.OvnSouth.Scheme = config.OvnDBSchemeSSL
+		config.OvnSouth.Address = "ssl:10.0.0.1:6642"
+		connected = nil
+	})
+
+	newDiscovery := func(client *fake.Clientset) *dbEndpointDiscovery {
+		d, err := newDBEndpointDiscovery(client, "ovn-kubernetes/ovnkube-db", &southboundEndpoint{
+			apply: func() error {
+				lock.Lock()
+				defer lock.Unlock()
+				connected = append(connected, config.OvnSouth.GetURL())
+				return nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return d
+	}
+
+	It("builds the database addresses from the ready endpoints", func() {
+		slices := []*discovery.EndpointSlice{
+			newDBEndpointSlice("ovnkube-db-v4", discovery.AddressTypeIPv4,
+				discovery.Endpoint{Addresses: []string{"10.0.0.3"}, Conditions: discovery.EndpointConditions{Ready: ptr.To(true)}},
+				discovery.Endpoint{Addresses: []string{"10.0.0.2"}},
+				discovery.Endpoint{Addresses: []string{"10.0.0.4"}, Conditions: discovery.EndpointConditions{Ready: ptr.To(false)}}),
+			newDBEndpointSlice("ovnkube-db-v6", discovery.AddressTypeIPv6,
+				discovery.Endpoint{Addresses: []string{"fd00::2"}, Conditions: discovery.EndpointConditions{Ready: ptr.To(true)}}),
+		}
+		Expect(dbEndpointsAddress(config.OvnDBSchemeSSL, dbDiscoverySouthPort, slices)).To(
+			Equal("ssl:10.0.0.2:6642,ssl:10.0.0.3:6642,ssl:[fd00::2]:6642"))
+		Expect(dbEndpointsAddress(config.OvnDBSchemeTCP, "north", slices)).To(
+			Equal("tcp:10.0.0.2:6641,tcp:10.0.0.3:6641,tcp:[fd00::2]:6641"))
+		Expect(dbEndpointsAddress(config.OvnDBSchemeSSL, "other", slices)).To(BeEmpty())
+	})
+
+	It("keeps the current addresses without ready endpoints", func() {
+		d := newDiscovery(fake.NewSimpleClientset())
+		Expect(d.sync([]*discovery.EndpointSlice{
+			newDBEndpointSlice("ovnkube-db", discovery.AddressTypeIPv4,
+				discovery.Endpoint{Addresses: []string{"10.0.0.2"}, Conditions: discovery.EndpointConditions{Ready: ptr.To(false)}}),
+		})).To(Succeed())
+		Expect(connected).To(BeEmpty())
+		Expect(config.OvnNorth.Address).To(Equal("ssl:10.0.0.1:6641"))
+		Expect(config.OvnSouth.Address).To(Equal("ssl:10.0.0.1:6642"))
+	})
+
+	It("reconnects ovn-controller when the database endpoints change", func() {
+		client := fake.NewSimpleClientset(newDBEndpointSlice("ovnkube-db", discovery.AddressTypeIPv4,
+			discovery.Endpoint{Addresses: []string{"10.0.0.2"}}))
+		d := newDiscovery(client)
+		stop := make(chan struct{})
+		wg := &sync.WaitGroup{}
+		defer func() {
+			close(stop)
+			wg.Wait()
+		}()
+		// the node is connected to the discovered endpoints once Run returns
+		Expect(d.Run(stop, wg)).To(Succeed())
+		Expect(getConnected()).To(Equal([]string{"ssl:10.0.0.2:6642"}))
+
+		slice := newDBEndpointSlice("ovnkube-db", discovery.AddressTypeIPv4, discovery.Endpoint{Addresses: []string{"10.0.0.5"}})
+		_, err := client.DiscoveryV1().EndpointSlices("ovn-kubernetes").Update(context.TODO(), slice, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(getConnected).Should(Equal([]string{"ssl:10.0.0.2:6642", "ssl:10.0.0.5:6642"}))
+		// the Northbound database is not followed
+		Expect(config.OvnNorth.Address).To(Equal("ssl:10.0.0.1:6641"))
+	})
+})
@@ -16,9 +16,9 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
-	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -29,20 +29,27 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
 	config "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	dpunodepairingclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/informer"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/conntrackmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/physicalnetwork"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/serviceannouncement"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/linkmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/ovspinning"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/apbroute"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 
 	"github.com/vishvananda/netlink"
 )
@@ -54,6 +61,7 @@ type CommonNodeNetworkControllerInfo struct {
 	recorder               record.EventRecorder
 	name                   string
 	apbExternalRouteClient adminpolicybasedrouteclientset.Interface
+	dpuNodePairingClient   dpunodepairingclientset.Interface
 	// route manager that creates and manages routes
 	routeManager *routemanager.Controller
 }
@@ -78,24 +86,31 @@ type BaseNodeNetworkController struct {
 	wg       *sync.WaitGroup
 }
 
+// nodeEventInterval is the minimum interval between two events of the same reason about the same
+// object recorded by the node subsystems, flapping conditions would flood the API server otherwise
+const nodeEventInterval = 5 * time.Minute
+
 func newCommonNodeNetworkControllerInfo(kubeClient clientset.Interface, kube kube.Interface, apbExternalRouteClient adminpolicybasedrouteclientset.Interface,
-	wf factory.NodeWatchFactory, eventRecorder record.EventRecorder, name string, routeManager *routemanager.Controller) *CommonNodeNetworkControllerInfo {
+	dpuNodePairingClient dpunodepairingclientset.Interface, wf factory.NodeWatchFactory, eventRecorder record.EventRecorder, name string, routeManager *routemanager.Controller) *CommonNodeNetworkControllerInfo {
 
 	return &CommonNodeNetworkControllerInfo{
 		client:                 kubeClient,
 		Kube:                   kube,
 		apbExternalRouteClient: apbExternalRouteClient,
+		dpuNodePairingClient:   dpuNodePairingClient,
 		watchFactory:           wf,
 		name:                   name,
-		recorder:               eventRecorder,
+		recorder:               util.NewRateLimitedEventRecorder(eventRecorder, nodeEventInterval),
 		routeManager:           routeManager,
 	}
 }
 
 // NewCommonNodeNetworkControllerInfo creates and returns the base node network controller info
-func NewCommonNodeNetworkControllerInfo(kubeClient clientset.Interface, apbExternalRouteClient adminpolicybasedrouteclientset.Interface, wf factory.NodeWatchFactory,
+func NewCommonNodeNetworkControllerInfo(kubeClient clientset.Interface, apbExternalRouteClient adminpolicybasedrouteclientset.Interface,
+	dpuNodePairingClient dpunodepairingclientset.Interface, wf factory.NodeWatchFactory,
 	eventRecorder record.EventRecorder, name string, routeManager *routemanager.Controller) *CommonNodeNetworkControllerInfo {
-	return newCommonNodeNetworkControllerInfo(kubeClient, &kube.Kube{KClient: kubeClient}, apbExternalRouteClient, wf, eventRecorder, name, routeManager)
+	return newCommonNodeNetworkControllerInfo(kubeClient, &kube.Kube{KClient: kubeClient}, apbExternalRouteClient, dpuNodePairingClient, wf,
+		eventRecorder, name, routeManager)
 }
 
 // DefaultNodeNetworkController is the object holder for utilities meant for node management of default network
@@ -105,7 +120,11 @@ type DefaultNodeNetworkController struct {
 	Gateway Gateway
 	// Node healthcheck server for cloud load balancers
 	healthzServer *proxierHealthUpdater
-	routeManager  *routemanager.Controller
+	// egressIPHealthServers are the egress IP health check servers of the management ports
+	egressIPHealthServers []healthcheck.EgressIPHealthServer
+	// httpServers runs the HTTP listeners of the node
+	httpServers  *httpServerManager
+	routeManager *routemanager.Controller
 
 	// retry framework for namespaces, used for the removal of stale conntrack entries for external gateways
 	retryNamespaces *retry.RetryFramework
@@ -113,6 +132,25 @@ type DefaultNodeNetworkController struct {
 	retryEndpointSlices *retry.RetryFramework
 
 	apbExternalRouteNodeController *apbroute.ExternalGatewayNodeController
+
+	// affinityConntrack flushes the conntrack entries of endpoints removed from services with
+	// ClientIP session affinity once the affinity timeout has elapsed
+	affinityConntrack *affinityConntrackCleaner
+
+	// subsystems are the subsystems started by Start, stopped in the reverse order by Stop
+	subsystems *subsystemRegistry
+
+	// sbEndpoint is the Southbound database the node is connected to at runtime
+	sbEndpoint *southboundEndpoint
+
+	// hostnameChecker detects the hostnames of the node drifting from the node name
+	hostnameChecker *hostnameChecker
+
+	// reconciliationPause pauses the reconciliation of the host networking on request
+	reconciliationPause *reconciliationPauseController
+
+	// kernelModules finds the kernel modules the node requires
+	kernelModules *kernelModules
 }
 
 func newDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, stopChan chan struct{}, errChan chan error,
@@ -126,7 +164,13 @@ func newDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, sto
 			errChan:                         errChan,
 			wg:                              wg,
 		},
-		routeManager: routeManager,
+		routeManager:      routeManager,
+		affinityConntrack: newAffinityConntrackCleaner(),
+		sbEndpoint:        newSouthboundEndpoint(),
+		hostnameChecker:   newHostnameChecker(cnnci.name, cnnci.recorder),
+		reconciliationPause: newReconciliationPauseController(cnnci.name, cnnci.watchFactory, cnnci.recorder,
+			stopChan),
+		kernelModules: newKernelModules(),
 	}
 }
 
@@ -137,10 +181,16 @@ func NewDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, err
 	wg := &sync.WaitGroup{}
 	nc := newDefaultNodeNetworkController(cnnci, stopChan, errChan, wg, cnnci.routeManager)
 
-	if len(config.Kubernetes.HealthzBindAddress) != 0 {
+	nc.httpServers = newHTTPServerManager(nc.name, config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, nc.recorder)
+	if err = nc.httpServers.addListener(healthzListener, config.Kubernetes.HealthzBindAddress, config.OvnKubeNode.HealthzTLS); err != nil {
+		return nil, err
+	}
+	if err = nc.httpServers.addListener(adminListener, config.OvnKubeNode.AdminBindAddress, config.OvnKubeNode.AdminTLS); err != nil {
+		return nil, err
+	}
+	if nc.httpServers.enabled(healthzListener) {
 		klog.Infof("Enable node proxy healthz server on %s", config.Kubernetes.HealthzBindAddress)
-		nc.healthzServer, err = newNodeProxyHealthzServer(
-			nc.name, config.Kubernetes.HealthzBindAddress, nc.recorder, nc.watchFactory)
+		nc.healthzServer, err = newNodeProxyHealthzServer(nc.watchFactory)
 		if err != nil {
 			return nil, fmt.Errorf("could not create node proxy healthz server: %w", err)
 		}
@@ -283,17 +333,21 @@ func setupOVNNode(node *kapi.Node) error {
 
 	encapIP := config.Default.EncapIP
 	if encapIP == "" {
-		encapIP, err = util.GetNodePrimaryIP(node)
+		encapIP, err = util.GetNodePrimaryIPExcluding(node, nodeIPExcluded)
 		if err != nil {
 			return fmt.Errorf("failed to obtain local IP from node %q: %v", node.Name, err)
 		}
 		config.Default.EncapIP = encapIP
 	} else {
-		// OVN allows `external_ids:ovn-encap-ip` to be a list of IPs separated by comma.
-		ovnEncapIps := strings.Split(encapIP, ",")
-		for _, ovnEncapIp := range ovnEncapIps {
-			if ip := net.ParseIP(strings.TrimSpace(ovnEncapIp)); ip == nil {
-				return fmt.Errorf("invalid IP address %q in provided encap-ip setting %q", ovnEncapIp, encapIP)
+		// OVN allows `external_ids:ovn-encap-ip` to be a list of IPs separated by comma, e.g. an IPv4 and
+		// an IPv6 tunnel endpoint in dual-stack clusters.
+		encapIPs, err := util.ParseEncapIPs(encapIP)
+		if err != nil {
+			return fmt.Errorf("invalid encap-ip setting: %w", err)
+		}
+		for _, ip := range encapIPs {
+			if cidr := config.Default.NodeIPExclude.ExcludedNetwork(ip); cidr != nil {
+				klog.Warningf("Configured encap IP %s is in excluded network %s", ip, cidr)
 			}
 		}
 	}
@@ -304,15 +358,10 @@ func setupOVNNode(node *kapi.Node) error {
 		".",
 		fmt.Sprintf("external_ids:ovn-encap-type=%s", config.Default.EncapType),
 		fmt.Sprintf("external_ids:ovn-encap-ip=%s", encapIP),
-		fmt.Sprintf("external_ids:ovn-remote-probe-interval=%d",
-			config.Default.InactivityProbe),
-		fmt.Sprintf("external_ids:ovn-openflow-probe-interval=%d",
-			config.Default.OpenFlowProbe),
-		// bundle-idle-timeout default value is 10s, it should be set
-		// as high as the ovn-openflow-probe-interval to allow ovn-controller
-		// to finish computation specially with complex acl configuration with port range.
-		fmt.Sprintf("other_config:bundle-idle-timeout=%d",
-			config.Default.OpenFlowProbe),
+	}
+	// the probe intervals can be overridden per node
+	setExternalIdsCmd = append(setExternalIdsCmd, nodeProbeIntervals(node).ovsSettings()...)
+	setExternalIdsCmd = append(setExternalIdsCmd,
 		fmt.Sprintf("external_ids:hostname=\"%s\"", node.Name),
 		// If Interconnect feature is enabled, we want to tell ovn-controller to
 		// make this node/chassis as an interconnect gateway.
@@ -322,7 +371,7 @@ func setupOVNNode(node *kapi.Node) error {
 		fmt.Sprintf("external_ids:ovn-enable-lflow-cache=%t", config.Default.LFlowCacheEnable),
 		// when creating tunnel ports set local_ip, helps ensures multiple interfaces and ipv6 will work
 		"external_ids:ovn-set-local-ip=\"true\"",
-	}
+	)
 
 	if config.Default.LFlowCacheLimit > 0 {
 		setExternalIdsCmd = append(setExternalIdsCmd,
@@ -330,9 +379,14 @@ func setupOVNNode(node *kapi.Node) error {
 		)
 	}
 
-	if config.Default.LFlowCacheLimitKb > 0 {
+	// in adaptive mode the limit starts at the highest limit unless configured
+	lflowCacheLimitKb := config.Default.LFlowCacheLimitKb
+	if config.Default.LFlowCacheAdaptive && lflowCacheLimitKb == 0 {
+		lflowCacheLimitKb = config.Default.LFlowCacheLimitKbMax
+	}
+	if lflowCacheLimitKb > 0 {
 		setExternalIdsCmd = append(setExternalIdsCmd,
-			fmt.Sprintf("external_ids:ovn-memlimit-lflow-cache-kb=%d", config.Default.LFlowCacheLimitKb),
+			fmt.Sprintf("external_ids:ovn-memlimit-lflow-cache-kb=%d", lflowCacheLimitKb),
 		)
 	}
 
@@ -408,12 +462,12 @@ func isOVNControllerReady() (bool, error) {
 	// check by dumping br-int flow entries
 	stdout, _, err := util.RunOVSOfctl("dump-aggregate", "br-int")
 	if err != nil {
-		klog.V(5).Infof("Error dumping aggregate flows: %v", err)
+		nodeLog.V(5).Infof("Error dumping aggregate flows: %v", err)
 		return false, nil
 	}
 	hasFlowCountZero := strings.Contains(stdout, "flow_count=0")
 	if hasFlowCountZero {
-		klog.V(5).Info("Got a flow count of 0 when dumping flows for node")
+		nodeLog.V(5).Info("Got a flow count of 0 when dumping flows for node")
 		return false, nil
 	}
 
@@ -454,7 +508,7 @@ func handleDevicePluginResources() error {
 	// features share the same device pool.
 	config.OvnKubeNode.DPResourceDeviceIdsMap = make(map[string][]string)
 	config.OvnKubeNode.DPResourceDeviceIdsMap[config.OvnKubeNode.MgmtPortDPResourceName] = deviceIds
-	klog.V(5).Infof("Setting DPResourceDeviceIdsMap for %s using env %s with device IDs %v",
+	nodeLog.V(5).Infof("Setting DPResourceDeviceIdsMap for %s using env %s with device IDs %v",
 		config.OvnKubeNode.MgmtPortDPResourceName, mgmtPortEnvName, deviceIds)
 	return nil
 }
@@ -588,7 +642,7 @@ func getMgmtPortAndRepName(node *kapi.Node) (string, string, error) {
 	}
 }
 
-func createNodeManagementPorts(node *kapi.Node, nodeLister listers.NodeLister, nodeAnnotator kube.Annotator, kubeInterface kube.Interface, waiter *startupWaiter,
+func createNodeManagementPorts(node *kapi.Node, nodeAnnotator kube.Annotator, waiter *startupWaiter,
 	subnets []*net.IPNet, routeManager *routemanager.Controller) ([]managementPortEntry, *managementPortConfig, error) {
 	netdevName, rep, err := getMgmtPortAndRepName(node)
 	if err != nil {
@@ -603,24 +657,88 @@ func createNodeManagementPorts(node *kapi.Node, nodeLister listers.NodeLister, n
 	}
 	ports := NewManagementPorts(node.Name, subnets, netdevName, rep)
 
+	mgmtPorts, err := createManagementPorts(ports, managementPortCreateTimeout, func(port ManagementPort) (*managementPortConfig, error) {
+		return port.Create(routeManager, node, nodeAnnotator, waiter)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var mgmtPortConfig *managementPortConfig
-	mgmtPorts := make([]managementPortEntry, 0)
-	for _, port := range ports {
-		config, err := port.Create(routeManager, node, nodeLister, kubeInterface, waiter)
-		if err != nil {
-			return nil, nil, err
-		}
-		mgmtPorts = append(mgmtPorts, managementPortEntry{port: port, config: config})
+	for _, mgmtPort := range mgmtPorts {
 		// Save this management port config for later usage.
 		// Since only one OVS internal port / Representor config may exist it is fine just to overwrite it
-		if _, ok := port.(*managementPortNetdev); !ok {
-			mgmtPortConfig = config
+		if _, ok := mgmtPort.port.(*managementPortNetdev); !ok {
+			mgmtPortConfig = mgmtPort.config
 		}
 	}
 
 	return mgmtPorts, mgmtPortConfig, nil
 }
 
+// managementPortCreateTimeout is how long the node bring up waits for the management ports to be created
+var managementPortCreateTimeout = 5 * time.Minute
+
+// createManagementPorts creates the management ports in parallel, as the netdevice and the representor of
+// a hardware backed management port are independent. The entries of the ports are returned in the order of
+// the ports; if a port fails or is not created before the timeout, the errors of all the failed ports are
+// returned. The ports still being created after the timeout are waited for before failing, so that their
+// creation doesn't go on concurrently with the rest of the node bring up; the steps of the creation are
+// OVS and netlink operations that time out on their own.
+func createManagementPorts(ports []ManagementPort, timeout time.Duration,
+	create func(ManagementPort) (*managementPortConfig, error)) ([]managementPortEntry, error) {
+	type result struct {
+		config *managementPortConfig
+		err    error
+	}
+	results := make([]result, len(ports))
+	done := make(chan int, len(ports))
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	for i, port := range ports {
+		wg.Add(1)
+		go func(i int, port ManagementPort) {
+			defer wg.Done()
+			config, err := create(port)
+			results[i] = result{config: config, err: err}
+			done <- i
+		}(i, port)
+	}
+
+	// finished are the ports whose creation returned, only their results can be read
+	finished := make([]bool, len(ports))
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var errs []error
+wait:
+	for pending := len(ports); pending > 0; pending-- {
+		select {
+		case i := <-done:
+			finished[i] = true
+			if results[i].err != nil {
+				errs = append(errs, fmt.Errorf("failed to create management port %T: %w", ports[i], results[i].err))
+			}
+		case <-timer.C:
+			for i, port := range ports {
+				if !finished[i] {
+					klog.Warningf("Timed out after %v creating management port %T, waiting for it to return", timeout, port)
+					errs = append(errs, fmt.Errorf("timed out after %v creating management port %T", timeout, port))
+				}
+			}
+			break wait
+		}
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.Join(errs...)
+	}
+
+	mgmtPorts := make([]managementPortEntry, 0, len(ports))
+	for i, port := range ports {
+		mgmtPorts = append(mgmtPorts, managementPortEntry{port: port, config: results[i].config})
+	}
+	return mgmtPorts, nil
+}
+
 // getOVNSBZone returns the zone name stored in the Southbound db.
 // It returns the default zone name if "options:name" is not set in the SB_Global row
 func getOVNSBZone() (string, error) {
@@ -693,6 +811,14 @@ func portExists(namespace, name string) bool {
 // Start learns the subnets assigned to it by the master controller
 // and calls the SetupNode script which establishes the logical switch
 func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
+	ctx, span := metrics.StartSpan(ctx, "DefaultNodeNetworkController.Start", semconv.K8SNodeName(nc.name))
+	err := nc.start(ctx)
+	metrics.EndSpan(span, err)
+	return err
+}
+
+// start does the work of Start in its trace span
+func (nc *DefaultNodeNetworkController) start(ctx context.Context) error {
 	klog.Infof("Starting the default node network controller")
 
 	var err error
@@ -702,18 +828,23 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 
 	// Setting debug log level during node bring up to expose bring up process.
 	// Log level is returned to configured value when bring up is complete.
+	// The subsystems with a configured log level keep it.
 	var level klog.Level
 	if err := level.Set("5"); err != nil {
 		klog.Errorf("Setting klog \"loglevel\" to 5 failed, err: %v", err)
 	}
 
+	if err := nc.ensureKernelModules(); err != nil {
+		return err
+	}
+
 	if config.OvnKubeNode.Mode != types.NodeModeDPU {
 		if err = configureGlobalForwarding(); err != nil {
 			return err
 		}
 	}
 
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+	if util.IsLocalOVNAvailable() {
 		// Bootstrap flows in OVS if just normal flow is present
 		if err := bootstrapOVSFlows(nc.name); err != nil {
 			return fmt.Errorf("failed to bootstrap OVS flows: %w", err)
@@ -724,6 +855,17 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("error retrieving node %s: %v", nc.name, err)
 	}
 
+	// the egress IP and egress service controllers only run on the egress nodes, they follow the changes of the
+	// egress role of the node
+	egressRole := newEgressRoleWatcher(nc.name, nc.Kube, nc.watchFactory, node)
+	if !egressRole.isEgressNode() && egressEnabled() {
+		klog.Infof("Node %s is a non-egress node, the egress IP and egress service controllers are not started", nc.name)
+	}
+
+	// the encap IP and the gateway interface autodetected below are never selected in the excluded networks
+	// and interfaces of the node
+	config.Default.NodeIPExclude = nodeIPExclusions(node)
+
 	nodeAddrStr, err := util.GetNodePrimaryIP(node)
 	if err != nil {
 		return err
@@ -733,13 +875,26 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to parse kubernetes node IP address. %v", nodeAddrStr)
 	}
 
+	// Follow the OVN databases endpoints before connecting to the Southbound database so that a
+	// database that moved since the node was configured is found
+	if config.OvnKubeNode.DBDiscoveryService != "" && util.IsLocalOVNAvailable() {
+		dbDiscovery, err := newDBEndpointDiscovery(nc.client, config.OvnKubeNode.DBDiscoveryService, nc.sbEndpoint)
+		if err != nil {
+			return err
+		}
+		if err := dbDiscovery.Run(nc.stopChan, nc.wg); err != nil {
+			return err
+		}
+	}
+
 	// Make sure that the node zone matches with the Southbound db zone.
 	// Wait for 300s before giving up
 	var sbZone string
 	var err1 error
 
-	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
-		// There is no SBDB to connect to in DPU Host mode, so we will just take the default input config zone
+	if !util.IsLocalOVNAvailable() {
+		// There is no SBDB to connect to in DPU Host mode or without local OVN, so we will just take the
+		// default input config zone
 		sbZone = config.Default.Zone
 	} else {
 		err = wait.PollUntilContextTimeout(ctx, 500*time.Millisecond, 300*time.Second, true, func(ctx context.Context) (bool, error) {
@@ -761,13 +916,19 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 
 		// if its nonIC OR IC=true and if its phase1 OR if its IC to IC upgrades
 		if !config.OVNKubernetesFeature.EnableInterconnect || sbZone == types.OvnDefaultZone || util.HasNodeMigratedZone(node) { // if its nonIC or if its phase1
-			for _, auth := range []config.OvnAuthConfig{config.OvnNorth, config.OvnSouth} {
-				if err := auth.SetDBAuth(); err != nil {
-					return err
-				}
+			if err := config.OvnNorth.SetDBAuth(); err != nil {
+				return err
+			}
+			if err := nc.sbEndpoint.setDBAuth(); err != nil {
+				return err
 			}
 		}
 
+		// ovn-controller registers the chassis with the node name, report a kernel hostname that doesn't
+		// match it before the port bindings get lost
+		if err := nc.hostnameChecker.checkKernelHostname(); err != nil {
+			return err
+		}
 		err = setupOVNNode(node)
 		if err != nil {
 			return err
@@ -804,7 +965,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		}
 	}
 
-	nodeAnnotator := kube.NewNodeAnnotator(nc.Kube, node.Name)
+	nodeAnnotator := newNodeAnnotator(nc.Kube, node.Name)
 	waiter := newStartupWaiter()
 
 	// Use the device from environment when the DP resource name is specified.
@@ -823,13 +984,12 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 				config.OvnKubeNode.MgmtPortNetdev)
 		}
 		config.OvnKubeNode.MgmtPortNetdev = netdevice
-		klog.V(5).Infof("Using MgmtPortNetdev (Netdev %s) passed via resource %s",
+		nodeLog.V(5).Infof("Using MgmtPortNetdev (Netdev %s) passed via resource %s",
 			config.OvnKubeNode.MgmtPortNetdev, config.OvnKubeNode.MgmtPortDPResourceName)
 	}
 
 	// Setup management ports
-	mgmtPorts, mgmtPortConfig, err := createNodeManagementPorts(node, nc.watchFactory.NodeCoreInformer().Lister(), nodeAnnotator,
-		nc.Kube, waiter, subnets, nc.routeManager)
+	mgmtPorts, mgmtPortConfig, err := createNodeManagementPorts(node, nodeAnnotator, waiter, subnets, nc.routeManager)
 	if err != nil {
 		return err
 	}
@@ -851,13 +1011,15 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to set node zone annotation for node %s: %w", nc.name, err)
 	}
 
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+	// Without local OVN the encap IP is set by the side running ovn-controller
+	if util.IsLocalOVNAvailable() {
 		klog.Infof("Setting EncapIp %s in node annotation", config.Default.EncapIP)
 		if err = util.SetNodeEncapIp(nodeAnnotator, config.Default.EncapIP); err != nil {
 			return err
 		}
 	}
 
+	// write all the annotations set while bringing up the node in a single batch
 	if err := nodeAnnotator.Run(); err != nil {
 		return fmt.Errorf("failed to set node %s annotations: %w", nc.name, err)
 	}
@@ -867,8 +1029,9 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	// and configured the chassis in SBDB (ovnkube-controller waits for ovnkube-node to set annotation
 	// for at least one node in the given zone)
 	// NOTE: ovnkube-node in DPU-host mode has no SBDB to connect to. The encap port will be handled by the
-	// ovnkube-node running in DPU mode on behalf of the host.
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost && config.Default.EncapPort != config.DefaultEncapPort {
+	// ovnkube-node running in DPU mode on behalf of the host, likewise for the remote ovn-controller of a
+	// node without local OVN.
+	if util.IsLocalOVNAvailable() && config.Default.EncapPort != config.DefaultEncapPort {
 		if err := setEncapPort(ctx); err != nil {
 			return err
 		}
@@ -893,9 +1056,10 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	// STEP5: Legacy ovnkube-master sees "k8s.ovn.org/remote-zone-migrated" annotation on this node and now knows that
 	//        this node has remote-zone-migrated successfully and tears down old setup and creates new IC resource
 	//        plumbing (takes 80ms based on what we saw in CI runs so we might still have that small window of disruption).
-	// NOTE: ovnkube-node in DPU host mode doesn't go through upgrades for OVN-IC and has no SBDB to connect to. Thus this part shall be skipped.
+	// NOTE: ovnkube-node in DPU host mode or without local OVN doesn't go through upgrades for OVN-IC and has no SBDB
+	// to connect to. Thus this part shall be skipped.
 	var syncNodes, syncServices, syncPods bool
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost && config.OVNKubernetesFeature.EnableInterconnect && sbZone != types.OvnDefaultZone && !util.HasNodeMigratedZone(node) { // so this should be done only once in phase2 (not in phase1)
+	if util.IsLocalOVNAvailable() && config.OVNKubernetesFeature.EnableInterconnect && sbZone != types.OvnDefaultZone && !util.HasNodeMigratedZone(node) { // so this should be done only once in phase2 (not in phase1)
 		klog.Info("Upgrade Hack: Interconnect is enabled")
 		var err1 error
 		start := time.Now()
@@ -909,7 +1073,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 				}
 				for _, node := range nodes {
 					node := *node
-					if nc.name != node.Name && util.GetNodeZone(&node) != config.Default.Zone && !util.NoHostSubnet(&node) {
+					if nc.name != node.Name && util.GetNodeZone(&node) != config.GetZone() && !util.NoHostSubnet(&node) {
 						nodeSubnets, err := util.ParseNodeHostSubnetAnnotation(&node, types.DefaultNetworkName)
 						if err != nil {
 							if util.IsAnnotationNotSetError(err) {
@@ -977,77 +1141,66 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("upgrade hack: failed while waiting for the remote ovnkube-controller to be ready: %v, %v", err, err1)
 		}
-		if err := util.SetNodeZoneMigrated(nodeAnnotator, sbZone); err != nil {
+		// the annotations of the node were written above in a single batch, only the remote-zone-migrated
+		// annotation is written now: it can't be batched with the zone annotation ovnkube-controller waits for
+		migratedAnnotator := newNodeAnnotator(nc.Kube, node.Name)
+		if err := util.SetNodeZoneMigrated(migratedAnnotator, sbZone); err != nil {
 			return fmt.Errorf("upgrade hack: failed to set node zone annotation for node %s: %w", nc.name, err)
 		}
-		if err := nodeAnnotator.Run(); err != nil {
+		if err := migratedAnnotator.Run(); err != nil {
 			return fmt.Errorf("upgrade hack: failed to set node %s annotations: %w", nc.name, err)
 		}
 		klog.Infof("ovnkube-node %s finished annotating node with remote-zone-migrated; took: %v", nc.name, time.Since(start))
-		for _, auth := range []config.OvnAuthConfig{config.OvnNorth, config.OvnSouth} {
-			if err := auth.SetDBAuth(); err != nil {
-				return fmt.Errorf("upgrade hack: Unable to set the authentication towards OVN local dbs")
-			}
+		if err := config.OvnNorth.SetDBAuth(); err != nil {
+			return fmt.Errorf("upgrade hack: Unable to set the authentication towards OVN local dbs")
+		}
+		if err := nc.sbEndpoint.setDBAuth(); err != nil {
+			return fmt.Errorf("upgrade hack: Unable to set the authentication towards OVN local dbs")
 		}
 		klog.Infof("Upgrade hack: ovnkube-node %s finished setting DB Auth; took: %v", nc.name, time.Since(start))
 	}
 	/** HACK END **/
 
-	// Wait for management port and gateway resources to be created by the master
-	klog.Infof("Waiting for gateway and management port readiness...")
-	start := time.Now()
-	if err := waiter.Wait(); err != nil {
-		return err
-	}
-	nc.Gateway.Start()
-	klog.Infof("Gateway and management port readiness took %v", time.Since(start))
-
-	// Note(adrianc): DPU deployments are expected to support the new shared gateway changes, upgrade flow
-	// is not needed. Future upgrade flows will need to take DPUs into account.
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
-		bridgeName := ""
-		if config.OvnKubeNode.Mode == types.NodeModeFull {
-			bridgeName = nc.Gateway.GetGatewayBridgeIface()
-			// Configure route for svc towards shared gw bridge
-			// Have to have the route to bridge for multi-NIC mode, where the default gateway may go to a non-OVS interface
-			if err := configureSvcRouteViaBridge(nc.routeManager, bridgeName); err != nil {
+	// the subsystems are started through the registry, in their registration order, each batch of
+	// registered subsystems is started at once and all of them are stopped in the reverse order by Stop
+	subsystems := newSubsystemRegistry()
+	nc.subsystems = subsystems
+	if err := subsystems.register(&nodeSubsystem{
+		name:     "gateway",
+		required: true,
+		start: func() error {
+			// Wait for management port and gateway resources to be created by the master
+			klog.Infof("Waiting for gateway and management port readiness...")
+			start := time.Now()
+			if err := waiter.Wait(); err != nil {
 				return err
 			}
-		}
-
+			if err := nc.Gateway.Start(); err != nil {
+				return err
+			}
+			klog.Infof("Gateway and management port readiness took %v", time.Since(start))
+
+			// Note(adrianc): DPU deployments are expected to support the new shared gateway changes, upgrade flow
+			// is not needed. Future upgrade flows will need to take DPUs into account.
+			if config.OvnKubeNode.Mode == types.NodeModeFull {
+				// Configure route for svc towards shared gw bridge
+				// Have to have the route to bridge for multi-NIC mode, where the default gateway may go to a non-OVS interface
+				if err := configureSvcRouteViaBridge(nc.routeManager, nc.Gateway.GetGatewayBridgeIface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}); err != nil {
+		return err
 	}
-
-	if config.HybridOverlay.Enabled {
-		// Not supported with DPUs, enforced in config
-		// TODO(adrianc): Revisit above comment
-		nodeController, err := honode.NewNode(
-			nc.Kube,
-			nc.name,
-			nc.watchFactory.NodeInformer(),
-			nc.watchFactory.LocalPodInformer(),
-			informer.NewDefaultEventHandler,
-			false,
-		)
-		if err != nil {
-			return err
-		}
-		nc.wg.Add(1)
-		go func() {
-			defer nc.wg.Done()
-			nodeController.Run(nc.stopChan)
-		}()
-	} else {
-		// attempt to cleanup the possibly stale bridge
-		_, stderr, err := util.RunOVSVsctl("--if-exists", "del-br", "br-ext")
-		if err != nil {
-			klog.Errorf("Deletion of bridge br-ext failed: %v (%v)", err, stderr)
-		}
-		_, stderr, err = util.RunOVSVsctl("--if-exists", "del-port", "br-int", "int")
-		if err != nil {
-			klog.Errorf("Deletion of port int on  br-int failed: %v (%v)", err, stderr)
-		}
+	if err := subsystems.startPending(ctx); err != nil {
+		return err
 	}
 
+	// attempt to cleanup the possibly stale rules of the disabled features
+	cleanupDisabledFeatures()
+
 	if err := level.Set(strconv.Itoa(config.Logging.Level)); err != nil {
 		klog.Errorf("Reset of initial klog \"loglevel\" failed, err: %v", err)
 	}
@@ -1055,85 +1208,416 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	// start management ports health check
 	for _, mgmtPort := range mgmtPorts {
 		mgmtPort.port.CheckManagementPortHealth(nc.routeManager, mgmtPort.config, nc.stopChan)
-		if config.OVNKubernetesFeature.EnableEgressIP {
-			// Start the health checking server used by egressip, if EgressIPNodeHealthCheckPort is specified
-			if err := nc.startEgressIPHealthCheckingServer(mgmtPort); err != nil {
-				return err
-			}
-		}
 	}
 
-	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
-		// If interconnect is disabled OR interconnect is running in single-zone-mode,
-		// the ovnkube-master is responsible for patching ICNI managed namespaces with
-		// "k8s.ovn.org/external-gw-pod-ips". In that case, we need ovnkube-node to flush
-		// conntrack on every node. In multi-zone-interconnect case, we will handle the flushing
-		// directly on the ovnkube-controller code to avoid an extra namespace annotation
-		if !config.OVNKubernetesFeature.EnableInterconnect || sbZone == types.OvnDefaultZone {
-			err := nc.WatchNamespaces()
-			if err != nil {
-				return fmt.Errorf("failed to watch namespaces: %w", err)
-			}
-			// every minute cleanup stale conntrack entries if any
-			go wait.Until(func() {
-				nc.checkAndDeleteStaleConntrackEntries()
-			}, time.Minute*1, nc.stopChan)
-		}
-		err = nc.WatchEndpointSlices()
-		if err != nil {
-			return fmt.Errorf("failed to watch endpointSlices: %w", err)
+	hybridOverlayTeardown := newSubsystemTeardown()
+	var nat64 *gatewayNAT64
+	externalGatewayConntrackEnabled := func() bool {
+		return util.IsLocalOVNAvailable() &&
+			(!config.OVNKubernetesFeature.EnableInterconnect || sbZone == types.OvnDefaultZone)
+	}
+	for _, subsystem := range []*nodeSubsystem{
+		{
+			name: "hybrid-overlay",
+			// Not supported with DPUs, enforced in config
+			// TODO(adrianc): Revisit above comment
+			enabled: func() bool { return config.HybridOverlay.Enabled },
+			start: func() error {
+				nodeController, err := honode.NewNode(
+					nc.Kube,
+					nc.name,
+					nc.watchFactory.NodeInformer(),
+					nc.watchFactory.LocalPodInformer(),
+					informer.NewDefaultEventHandler,
+					false,
+				)
+				if err != nil {
+					return err
+				}
+				hybridOverlayTeardown.wg.Add(1)
+				go func() {
+					defer hybridOverlayTeardown.wg.Done()
+					nodeController.Run(hybridOverlayTeardown.stopChan)
+				}()
+				return nil
+			},
+			stop: hybridOverlayTeardown.stop,
+		},
+		{
+			// the health checks are served on the non-egress nodes too, the egress ready label keeps the egress
+			// IPs off them
+			name:    "egress-ip-health-check",
+			enabled: func() bool { return config.OVNKubernetesFeature.EnableEgressIP },
+			start: func() error {
+				// Start the health checking server used by egressip, if EgressIPNodeHealthCheckPort is specified
+				for _, mgmtPort := range mgmtPorts {
+					if err := nc.startEgressIPHealthCheckingServer(mgmtPort); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			// If interconnect is disabled OR interconnect is running in single-zone-mode,
+			// the ovnkube-master is responsible for patching ICNI managed namespaces with
+			// "k8s.ovn.org/external-gw-pod-ips". In that case, we need ovnkube-node to flush
+			// conntrack on every node. In multi-zone-interconnect case, we will handle the flushing
+			// directly on the ovnkube-controller code to avoid an extra namespace annotation. The stale conntrack
+			// entries of the external gateway namespaces are cleaned up every minute by the zone tasks.
+			name:    "external-gateway-conntrack",
+			enabled: externalGatewayConntrackEnabled,
+			start: func() error {
+				if err := nc.WatchNamespaces(); err != nil {
+					return fmt.Errorf("failed to watch namespaces: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			name:    "service-conntrack",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				if err := nc.WatchEndpointSlices(); err != nil {
+					return fmt.Errorf("failed to watch endpointSlices: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			name:    "healthz-server",
+			enabled: func() bool { return nc.healthzServer != nil },
+			start: func() error {
+				nc.healthzServer.register(nc.httpServers)
+				return nil
+			},
+		},
+		{
+			// serve the HTTP listeners once the handlers of the subsystems started before are registered
+			name:    "http-servers",
+			enabled: func() bool { return len(nc.httpServers.servers) > 0 },
+			start: func() error {
+				nc.httpServers.Start(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			name: "nodeport-range-checker",
+			enabled: func() bool {
+				return config.Gateway.NodeportEnable && config.OvnKubeNode.Mode == types.NodeModeFull
+			},
+			start: func() error {
+				nodePortRangeChecker, err := newNodePortRangeChecker(nc.name, nc.recorder, nc.watchFactory)
+				if err != nil {
+					return err
+				}
+				nodePortRangeChecker.Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// allow admins to force ovn-controller to reconnect when the Southbound database endpoints changed
+			name:    "ovn-controller-reconnect",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				metrics.SetSBReconnectTrigger(nc.sbEndpoint.reconnect)
+				return nil
+			},
+			stop: func() error {
+				metrics.SetSBReconnectTrigger(nil)
+				return nil
+			},
+		},
+		{
+			// re-apply the SSL configuration of the OVN databases when the certificates are rotated
+			name:      "ovn-db-auth-watcher",
+			dependsOn: []string{"ovn-controller-reconnect"},
+			start: func() error {
+				return newDBAuthCertWatcher(nc.sbEndpoint).Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// report the reachability of the remote zones through the transit switch, this requires the
+			// local Southbound database and OVS
+			name: "transit-switch-health",
+			enabled: func() bool {
+				return config.OVNKubernetesFeature.EnableInterconnect && util.IsLocalOVNAvailable()
+			},
+			start: func() error {
+				newTransitSwitchHealthChecker(nc.name, sbZone, nc.Kube, nc.watchFactory).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// keep the OVS hostname set to the node name and report a kernel hostname not matching it
+			name:    "hostname-check",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				nc.hostnameChecker.Run(nc.stopChan, nc.wg)
+				return nil
+			},
+			healthy: nc.hostnameChecker.healthy,
+		},
+		{
+			// mirror the Southbound database chassis of the node in the node chassis annotation
+			name:    "chassis-info",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				return newChassisInfoPublisher(nc.name, nc.Kube, nc.watchFactory, nc.stopChan).Run(nc.wg)
+			},
+		},
+		{
+			// report whether OVS hardware offload offloads the flows of the representors, when it is enabled
+			name: "hw-offload-status",
+			enabled: func() bool {
+				return util.IsLocalOVNAvailable() && isHwOffloadEnabled()
+			},
+			start: func() error {
+				newHwOffloadStatusReporter(nc.name, nc.Kube, nc.watchFactory).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// configure the management port with the address family added to the node subnets when the
+			// cluster is converted to dual-stack
+			name: "management-port-families",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull && mgmtPortConfig != nil
+			},
+			start: func() error {
+				return newManagementPortFamiliesController(nc.name, nc.watchFactory, nc.routeManager, mgmtPortConfig,
+					nc.stopChan).Run(nc.wg)
+			},
+		},
+		{
+			// apply the probe intervals overridden in the node probe intervals annotation when it changes
+			name:    "probe-intervals",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				return newProbeIntervalsController(nc.name, nc.watchFactory, nc.stopChan).Run(nc.wg)
+			},
+		},
+		{
+			// adjust the memory limit of the ovn-controller logical flow cache to the node memory pressure
+			name: "lflow-cache-limiter",
+			enabled: func() bool {
+				return config.Default.LFlowCacheAdaptive && util.IsLocalOVNAvailable()
+			},
+			start: func() error {
+				newLFlowCacheLimiter(nc.name, nc.watchFactory).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// fail over the default routes between the gateway next hops configured for an IP family
+			name: "gateway-nexthop-health",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull && len(healthCheckedGatewayNextHops()) > 0
+			},
+			start: func() error {
+				newGatewayNextHopHealthChecker(nc.name, nc.Gateway.GetGatewayBridgeIface(), healthCheckedGatewayNextHops(),
+					nc.Kube, nc.watchFactory, nc.routeManager, newNeighborProber()).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// allocate a range of SNAT source ports of the node IP to each local pod
+			name: "pod-snat-port-ranges",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull && config.Gateway.Mode == config.GatewayModeShared
+			},
+			start: func() error {
+				if config.Gateway.PodSNATPortRangeSize == 0 {
+					return deletePodSNATPortRanges(nc.name, nc.Kube, nc.watchFactory)
+				}
+				return newPodSNATPortRangeAllocator(nc.name, nc.Kube, nc.watchFactory,
+					config.Gateway.PodSNATPortRangeSize).Start(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// offload the established pod egress connections of the local gateway mode to an nftables flowtable
+			name: "gateway-flowtable",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull && config.Gateway.Mode == config.GatewayModeLocal
+			},
+			start: func() error {
+				if !config.Gateway.EnableFlowtable {
+					return deleteGatewayFlowtable()
+				}
+				mgmtPortNames := make([]string, 0, len(mgmtPorts))
+				for _, mgmtPort := range mgmtPorts {
+					mgmtPortNames = append(mgmtPortNames, mgmtPort.config.ifName)
+				}
+				newGatewayFlowtable(mgmtPortNames, nc.Gateway.GetGatewayBridgeIface()).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// translate the traffic of the IPv6 pods to the NAT64 prefix to IPv4 on the host
+			name: "gateway-nat64",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull && config.Gateway.Mode == config.GatewayModeLocal
+			},
+			start: func() error {
+				if config.Gateway.NAT64Prefix == "" {
+					return deleteGatewayNAT64()
+				}
+				nat64 = newGatewayNAT64(config.Gateway.NAT64Prefix)
+				nat64.Run(nc.stopChan, nc.wg)
+				return nil
+			},
+			// the node is not ready until the NAT64 translates the pod traffic
+			healthy: func() error {
+				if nat64 == nil {
+					return nil
+				}
+				return nat64.healthy()
+			},
+		},
+		{
+			// restore the overridden MTU of the management port and of the gateway bridge
+			name: "interface-mtu",
+			enabled: func() bool {
+				return config.OvnKubeNode.Mode == types.NodeModeFull &&
+					(config.Default.MgmtPortMTU != 0 || config.Default.GatewayBridgeMTU != 0)
+			},
+			start: func() error {
+				newInterfaceMTUReconciler(types.K8sMgmtIntfName, nc.Gateway.GetGatewayBridgeIface()).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// clamp the MSS of the TCP connections leaving the node through the gateway bridge and the management
+			// port to the path MTU
+			name: "tcp-mss-clamp",
+			enabled: func() bool {
+				return config.Gateway.TCPMSSClamp && config.OvnKubeNode.Mode == types.NodeModeFull &&
+					config.Gateway.Mode != config.GatewayModeDisabled
+			},
+			start: func() error {
+				return syncMSSClampRules([]string{nc.Gateway.GetGatewayBridgeIface(), types.K8sMgmtIntfName})
+			},
+		},
+		{
+			// report the services with the most conntrack entries on the node
+			name:    "service-conntrack-metrics",
+			enabled: func() bool { return config.Metrics.ConntrackServiceTopN > 0 },
+			start: func() error {
+				var nodeIPs func() []net.IP
+				if gw, ok := nc.Gateway.(*gateway); ok && gw.nodeIPManager != nil {
+					nodeIPs = gw.nodeIPManager.ListAddresses
+				}
+				newServiceConntrackCollector(config.Metrics.ConntrackServiceTopN, nc.watchFactory.GetServices, nodeIPs).
+					Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// report the conntrack entries of the connections of the pods of the node
+			name:    "pod-conntrack-metrics",
+			enabled: func() bool { return config.Metrics.EnablePodConntrackMetrics },
+			start: func() error {
+				newPodConntrackCollector(nc.watchFactory.GetAllPods).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// report the IPs of the node claimed by other MACs on the gateway bridges
+			name: "duplicate-address-detection",
+			enabled: func() bool {
+				return config.Gateway.EnableDuplicateAddressDetection && config.OvnKubeNode.Mode == types.NodeModeFull &&
+					config.Gateway.Mode != config.GatewayModeDisabled
+			},
+			start: func() error {
+				bridges := []string{nc.Gateway.GetGatewayBridgeIface()}
+				if gw, ok := nc.Gateway.(*gateway); ok && gw.openflowManager != nil && gw.openflowManager.externalGatewayBridge != nil {
+					bridges = append(bridges, gw.openflowManager.externalGatewayBridge.bridgeName)
+				}
+				return newDuplicateAddressDetector(nc.name, bridges, nc.watchFactory, nc.recorder, neighborClaimListener{}).
+					Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// remove the OVS ports of the pods whose CNI DEL was missed; there is no OVS without local OVN
+			name:    "ovs-port-gc",
+			enabled: util.IsLocalOVNAvailable,
+			start: func() error {
+				newOVSPortGC(config.OvnKubeNode.OVSPortGCDryRun, nc.watchFactory.GetAllPods).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// move the node to the zone requested by the zone migration annotation
+			name:      "zone-migration",
+			enabled:   func() bool { return config.OVNKubernetesFeature.EnableInterconnect },
+			dependsOn: []string{"ovn-controller-reconnect"},
+			start: func() error {
+				return newZoneMigrationController(nc.name, nc.Kube, nc.watchFactory, nc.Gateway, nc.sbEndpoint, nc.stopChan).Run(nc.wg)
+			},
+		},
+	} {
+		if err := subsystems.register(subsystem); err != nil {
+			return err
 		}
 	}
+	if err := subsystems.startPending(ctx); err != nil {
+		return err
+	}
 
-	if nc.healthzServer != nil {
-		nc.healthzServer.Start(nc.stopChan, nc.wg)
+	if config.OvnKubeNode.Mode == types.NodeModeDPU || config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		if err := nc.updateDPUTopology(); err != nil {
+			return err
+		}
 	}
 
 	if config.OvnKubeNode.Mode == types.NodeModeDPU {
 		if _, err := nc.watchPodsDPU(); err != nil {
 			return err
 		}
-	} else {
-		if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
-			// There is no SBDB to connect to in DPU Host mode, so we will just take the default input config zone
-			sbZone := config.Default.Zone
-			ns := config.OvnKubeNode.LeaseNS
-			if ns == "" {
-				ns = defaultLeaseNS
-			}
-			// We should wait for the dpu node to be ready before starting the cni server
-			// this impacts the readiness probe of the ovn-kube-node pod
-			// as it uses `command: ["/usr/bin/ovn-kube-util", "readiness-probe", "-t", "ovnkube-node"]`
-			// which in turn check if the file /etc/cni/net.d/10-ovn-kubernetes.conf exists
-			err = nc.checkDPUNodeHeartbeat(ctx, sbZone, ns, 60*time.Second, 300*time.Second)
-			if err != nil {
-				return err
+	}
+	if err := subsystems.register(&nodeSubsystem{
+		name:     "cni-server",
+		required: true,
+		enabled:  func() bool { return config.OvnKubeNode.Mode != types.NodeModeDPU },
+		start: func() error {
+			if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+				// The DPU heartbeat lease is named after the DPU node paired with this host, which
+				// runs with the host node name unless configured or published otherwise
+				dpuNodeName := nc.pairedDPUNodeName()
+				ns := config.OvnKubeNode.LeaseNS
+				if ns == "" {
+					ns = defaultLeaseNS
+				}
+				// We should wait for the dpu node to be ready before starting the cni server
+				// this impacts the readiness probe of the ovn-kube-node pod
+				// as it uses `command: ["/usr/bin/ovn-kube-util", "readiness-probe", "-t", "ovnkube-node"]`
+				// which in turn check if the file /etc/cni/net.d/10-ovn-kubernetes.conf exists
+				if err := nc.checkDPUNodeHeartbeat(ctx, dpuNodeName, ns, 60*time.Second, 300*time.Second); err != nil {
+					if errors.Is(err, errNoDPUNodeInZone) {
+						// run with the host networking only rather than failing until a DPU node joins the zone
+						nc.restartOnDPUNodeJoin(ctx, dpuNodeName, ns)
+						return &subsystemDegradedError{err}
+					}
+					return err
+				}
 			}
-		}
-		// start the cni server
-		if err := cniServer.Start(cni.ServerRunDir); err != nil {
-			return err
-		}
+			return cniServer.Start(cni.ServerRunDir)
+		},
+		// the CNI server is stopped before the subsystems started ahead of it, so that no pod is set up
+		// without them
+		stop:    func() error { return cniServer.Stop() },
+		healthy: func() error { return cniServer.CheckSocket() },
+	}); err != nil {
+		return err
 	}
-
-	// Write CNI config file if it doesn't already exist
-	if err := config.WriteCNIConfig(); err != nil {
+	if err := subsystems.startPending(ctx); err != nil {
 		return err
 	}
 
-	if config.OVNKubernetesFeature.EnableEgressService {
-		wf := nc.watchFactory.(*factory.WatchFactory)
-		c, err := egressservice.NewController(nc.stopChan, ovnKubeNodeSNATMark, nc.name,
-			wf.EgressServiceInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer())
-		if err != nil {
-			return err
-		}
-		if err = c.Run(nc.wg, 1); err != nil {
-			return err
-		}
-	}
-	if config.OVNKubernetesFeature.EnableMultiExternalGateway {
-		if err = nc.apbExternalRouteNodeController.Run(nc.wg, 1); err != nil {
+	// Write CNI config file if it doesn't already exist, the pods are not scheduled on a node running degraded
+	// without the CNI server
+	if subsystems.getStatus("cni-server").State != subsystemDegraded {
+		if err := config.WriteCNIConfig(); err != nil {
 			return err
 		}
 	}
@@ -1141,42 +1625,423 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	// create link manager, will work for egress IP as well as monitoring MAC changes to default gw bridge
 	linkManager := linkmanager.NewController(nc.name, config.IPv4Mode, config.IPv6Mode, nc.updateGatewayMAC)
 
-	if config.OVNKubernetesFeature.EnableEgressIP && !util.PlatformTypeIsEgressIPCloudProvider() {
-		c, err := egressip.NewController(nc.Kube, nc.watchFactory.EgressIPInformer(), nc.watchFactory.NodeInformer(),
-			nc.watchFactory.NamespaceInformer(), nc.watchFactory.PodCoreInformer(), nc.routeManager, config.IPv4Mode,
-			config.IPv6Mode, nc.name, linkManager)
-		if err != nil {
-			return fmt.Errorf("failed to create egress IP controller: %v", err)
-		}
-		if err = c.Run(nc.stopChan, nc.wg, 1); err != nil {
-			return fmt.Errorf("failed to run egress IP controller: %v", err)
+	gatewayBridge := ""
+	if config.OvnKubeNode.Mode == types.NodeModeFull {
+		gatewayBridge = nc.Gateway.GetGatewayBridgeIface()
+	}
+	egressServiceTeardown, egressIPTeardown := newSubsystemTeardown(), newSubsystemTeardown()
+	dpuPairing := newDPUNodePairing(nc.dpuNodePairingClient, nc.name)
+	for _, subsystem := range []*nodeSubsystem{
+		{
+			name:    "egress-service",
+			enabled: func() bool { return config.OVNKubernetesFeature.EnableEgressService && egressRole.isEgressNode() },
+			start: func() error {
+				wf := nc.watchFactory.(*factory.WatchFactory)
+				c, err := egressservice.NewController(egressServiceTeardown.stopChan, ovnKubeNodeSNATMark, nc.name,
+					wf.EgressServiceInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer())
+				if err != nil {
+					return err
+				}
+				return c.Run(egressServiceTeardown.wg, 1)
+			},
+			stop: egressServiceTeardown.stop,
+		},
+		{
+			name: "service-announcement",
+			enabled: func() bool {
+				return config.OVNKubernetesFeature.EnableServiceAnnouncement && config.OvnKubeNode.Mode == types.NodeModeFull
+			},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.openflowManager == nil {
+					return fmt.Errorf("unable to announce services without the gateway openflow manager")
+				}
+				wf := nc.watchFactory.(*factory.WatchFactory)
+				gw.announcer = newGatewayAnnouncer(gw.openflowManager, neighborAdvertiser{})
+				c, err := serviceannouncement.NewController(nc.stopChan, nc.name, gw.announcer,
+					wf.ServiceAnnouncementInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer(), wf.NodeInformer())
+				if err != nil {
+					return err
+				}
+				return c.Run(nc.wg)
+			},
+		},
+		{
+			// enforce the load balancer source ranges of the services on the gateway bridge
+			name: "service-source-ranges",
+			enabled: func() bool {
+				return util.IsLocalOVNAvailable() && config.Gateway.Mode != config.GatewayModeDisabled
+			},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.openflowManager == nil {
+					return fmt.Errorf("unable to filter the service sources without the gateway openflow manager")
+				}
+				return newSourceRangeFilter(gw.openflowManager, nc.watchFactory).Start(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// forward the traffic of the latency critical pods without the conntrack of the gateway bridge
+			name: "latency-critical-bypass",
+			enabled: func() bool {
+				return config.Gateway.EnableLatencyCriticalBypass && util.IsLocalOVNAvailable()
+			},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.openflowManager == nil {
+					return fmt.Errorf("unable to bypass the conntrack of the latency critical pods without the gateway openflow manager")
+				}
+				return newLatencyCriticalBypass(gw.openflowManager, nc.watchFactory).Start(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// limit the egress rate of the annotated namespaces with meters of the gateway bridge
+			name: "namespace-egress-rate",
+			enabled: func() bool {
+				return config.Gateway.EnableNamespaceEgressRate && util.IsLocalOVNAvailable()
+			},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.openflowManager == nil {
+					return fmt.Errorf("unable to limit the egress rate of the namespaces without the gateway openflow manager")
+				}
+				return newNamespaceEgressRateLimiter(gw.openflowManager, nc.watchFactory).Start(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// probe a sample of the services through the gateway and resync the gateway flows on failures
+			name: "service-probe",
+			enabled: func() bool {
+				return config.OvnKubeNode.ServiceProbeInterval > 0 && util.IsLocalOVNAvailable() &&
+					config.Gateway.Mode != config.GatewayModeDisabled
+			},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.openflowManager == nil {
+					return fmt.Errorf("unable to probe the services without the gateway openflow manager")
+				}
+				bridge := gw.openflowManager.defaultBridge
+				nodeIPs := func() []net.IP {
+					bridge.Lock()
+					defer bridge.Unlock()
+					ips := make([]net.IP, 0, len(bridge.ips))
+					for _, ip := range bridge.ips {
+						ips = append(ips, ip.IP)
+					}
+					return ips
+				}
+				getEndpointSlices := func(namespace, name string) ([]*discovery.EndpointSlice, error) {
+					return nc.watchFactory.GetServiceEndpointSlices(namespace, name, types.DefaultNetworkName)
+				}
+				newServiceProber(time.Duration(config.OvnKubeNode.ServiceProbeInterval)*time.Second, nc.name, nc.watchFactory.GetServices,
+					getEndpointSlices, nodeIPs, gw.openflowManager.requestFlowSync).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// drain the connections through the gateway of the node while it is cordoned, once the egress IP
+			// health check servers are started
+			name:    "drain-coordination",
+			enabled: func() bool { return config.OvnKubeNode.DrainTimeout > 0 },
+			start: func() error {
+				return newNodeDrainCoordinator(nc.name, nc.watchFactory, nc.healthzServer, nc.egressIPHealthServers,
+					time.Duration(config.OvnKubeNode.DrainTimeout)*time.Second).Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// neither masquerade nor route through OVN the subnets claimed by the agents of other networks
+			name:    "external-subnet-claims",
+			enabled: func() bool { return config.OvnKubeNode.Mode != types.NodeModeDPUHost },
+			start: func() error {
+				return newSubnetClaimController(nc.name, nc.recorder, nc.watchFactory, nc.routeManager).Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// mark the sockets of the host traffic cgroups, and stop marking those of the cgroups not configured
+			// anymore
+			name:    "host-traffic-marking",
+			enabled: func() bool { return config.OvnKubeNode.Mode != types.NodeModeDPU },
+			start: func() error {
+				return syncHostTrafficMarking(config.OvnKubeNode.GetHostTrafficCgroups())
+			},
+		},
+		{
+			// send the host traffic to the services with externalTrafficPolicy=Local straight to their endpoints
+			// on the node, once the sockets of the host traffic cgroups are marked
+			name: "etp-local-probe-path",
+			enabled: func() bool {
+				return config.OvnKubeNode.ETPLocalProbePath && config.OvnKubeNode.Mode == types.NodeModeFull &&
+					config.Gateway.Mode != config.GatewayModeDisabled
+			},
+			dependsOn: []string{"host-traffic-marking"},
+			start: func() error {
+				gw, ok := nc.Gateway.(*gateway)
+				if !ok || gw.nodeIPManager == nil {
+					return fmt.Errorf("unable to find the node IPs of the probe path without the gateway")
+				}
+				return newProbePathController(nc.name, nc.watchFactory, gw.nodeIPManager.ListAddresses).Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			// prune the annotations of the former versions and of the disabled features once the subsystems
+			// wrote theirs
+			name: "annotation-pruning",
+			start: func() error {
+				newNodeAnnotationPruner(nc.name, nc.Kube, nc.watchFactory).Run(nc.stopChan, nc.wg)
+				return nil
+			},
+		},
+		{
+			// run the tasks acting on the objects of the whole zone, on a single node of the zone with the zone
+			// leader election: the cleanup of the stale conntrack entries of the external gateway namespaces
+			// and the pruning of the leases of the removed DPU nodes
+			name: "zone-tasks",
+			start: func() error {
+				kclient, ok := nc.Kube.(*kube.Kube)
+				if !ok {
+					return fmt.Errorf("cannot get kubeclient for the zone leader election")
+				}
+				elector := newZoneLeaderElector(nc.name, config.Default.Zone, kclient.KClient, nc.recorder)
+				ns := config.OvnKubeNode.LeaseNS
+				if ns == "" {
+					ns = defaultLeaseNS
+				}
+				if externalGatewayConntrackEnabled() {
+					elector.addTask("external-gateway-conntrack", func(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+						runPeriodicSync(stopChan, doneWg, time.Minute, nil, nc.checkAndDeleteStaleConntrackEntries)
+					})
+				}
+				elector.addTask("dpu-lease-pruning", func(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+					runPeriodicSync(stopChan, doneWg, dpuLeasePruningPeriod, nil, func() {
+						if err := pruneStaleDPULeases(context.TODO(), kclient.KClient, config.Default.Zone, ns); err != nil {
+							klog.Errorf("Failed to prune the leases of the removed DPU nodes of zone %s: %v", config.Default.Zone, err)
+						}
+					})
+				})
+				return elector.Run(nc.stopChan, nc.wg)
+			},
+		},
+		{
+			name: "physical-networks",
+			enabled: func() bool {
+				return config.OVNKubernetesFeature.EnablePhysicalNetworks && util.IsLocalOVNAvailable()
+			},
+			start: func() error {
+				wf := nc.watchFactory.(*factory.WatchFactory)
+				c, err := physicalnetwork.NewController(nc.stopChan, nc.name, nc.recorder,
+					wf.PhysicalNetworkInformer(), wf.NodeInformer())
+				if err != nil {
+					return err
+				}
+				return c.Run(nc.wg)
+			},
+		},
+		{
+			name:    "multi-external-gateway",
+			enabled: func() bool { return config.OVNKubernetesFeature.EnableMultiExternalGateway },
+			start: func() error {
+				return nc.apbExternalRouteNodeController.Run(nc.wg, 1)
+			},
+		},
+		{
+			// report the BFD sessions to the external gateways, the node reads them from the Southbound database
+			name: "apbroute-bfd-status",
+			enabled: func() bool {
+				return config.OVNKubernetesFeature.EnableMultiExternalGateway && util.IsLocalOVNAvailable()
+			},
+			dependsOn: []string{"multi-external-gateway"},
+			start: func() error {
+				newAPBRouteBFDStatusReporter(nc.name, nc.apbExternalRouteClient,
+					nc.apbExternalRouteNodeController.GetBFDGatewayIPs, nc.stopChan).Run(nc.wg)
+				return nil
+			},
+		},
+		{
+			// quiesce the node while an external agent upgrades OVS and ovn-controller
+			name:      "ovs-upgrade-quiesce",
+			enabled:   func() bool { return config.OvnKubeNode.Mode == types.NodeModeFull },
+			dependsOn: []string{"cni-server"},
+			start: func() error {
+				var ofm *openflowManager
+				if gw, ok := nc.Gateway.(*gateway); ok {
+					ofm = gw.openflowManager
+				}
+				quiesce := func() {
+					cniServer.Quiesce()
+					if ofm != nil {
+						ofm.pauseFlowSyncs("ovs-upgrade-quiesce")
+					}
+				}
+				resume := func() {
+					if ofm != nil {
+						ofm.resumeFlowSyncs("ovs-upgrade-quiesce")
+					}
+					cniServer.Resume()
+				}
+				return newOVSUpgradeQuiesceController(nc.name, nc.Kube, nc.watchFactory, quiesce, resume, nc.stopChan).Run(nc.wg)
+			},
+		},
+		{
+			// pause the reconciliation of the routes, iptables rules and gateway flows while operators debug
+			// the host networking, the CNI server keeps serving the pods
+			name: "reconciliation-pause",
+			start: func() error {
+				nc.reconciliationPause.register("routes", nc.routeManager.Pause, func() error {
+					nc.routeManager.Resume()
+					return nil
+				})
+				if gw, ok := nc.Gateway.(*gateway); ok && gw.openflowManager != nil {
+					ofm := gw.openflowManager
+					nc.reconciliationPause.register("gateway-flows", func() {
+						ofm.pauseFlowSyncs("reconciliation-pause")
+					}, func() error {
+						ofm.resumeFlowSyncs("reconciliation-pause")
+						return nil
+					})
+				}
+				nc.reconciliationPause.register("gateway-iptables", gatewayIPTables.pause, gatewayIPTables.resume)
+				for _, mgmtPort := range mgmtPorts {
+					cfg := mgmtPort.config
+					nc.reconciliationPause.register("management-port-"+cfg.ifName, func() {
+						cfg.reconcilePaused.Store(true)
+					}, func() error {
+						cfg.reconcilePaused.Store(false)
+						return nil
+					})
+				}
+				return nc.reconciliationPause.Run(nc.wg)
+			},
+		},
+		{
+			// Egress IP for secondary host network
+			name: "egress-ip",
+			enabled: func() bool {
+				return config.OVNKubernetesFeature.EnableEgressIP && !util.PlatformTypeIsEgressIPCloudProvider() &&
+					egressRole.isEgressNode()
+			},
+			start: func() error {
+				c, err := egressip.NewController(nc.Kube, nc.watchFactory.EgressIPInformer(), nc.watchFactory.NodeInformer(),
+					nc.watchFactory.NamespaceInformer(), nc.watchFactory.PodCoreInformer(), nc.routeManager, config.IPv4Mode,
+					config.IPv6Mode, nc.name, linkManager)
+				if err != nil {
+					return fmt.Errorf("failed to create egress IP controller: %v", err)
+				}
+				if config.IPv6Mode && config.OVNKubernetesFeature.EgressIPNDPProxy && gatewayBridge != "" {
+					gw, ok := nc.Gateway.(*gateway)
+					if !ok || gw.openflowManager == nil {
+						return fmt.Errorf("unable to proxy the egress IP neighbor discovery without the gateway openflow manager")
+					}
+					c.EnableNDPProxy(gatewayBridge, gw.openflowManager.setNDPProxyFlows)
+				}
+				if err = c.Run(egressIPTeardown.stopChan, egressIPTeardown.wg, 1); err != nil {
+					return fmt.Errorf("failed to run egress IP controller: %v", err)
+				}
+				nc.reconciliationPause.register("egress-ip", c.PauseReconciliation, c.ResumeReconciliation)
+				return nil
+			},
+			stop: egressIPTeardown.stop,
+		},
+		{
+			// OVS is pinned to the CPUs of ovnkube-node unless the node tuning pins it to its own CPU set
+			name:    "ovs-pinning",
+			enabled: func() bool { return config.OvnKubeNode.OVSCPUSet == "" },
+			start: func() error {
+				nc.wg.Add(1)
+				go func() {
+					defer nc.wg.Done()
+					ovspinning.Run(nc.stopChan)
+				}()
+				return nil
+			},
+		},
+		{
+			name:      "node-tuning",
+			enabled:   ovspinning.NodeTuningEnabled,
+			dependsOn: []string{"gateway"},
+			start: func() error {
+				uplink := ""
+				if gw, ok := nc.Gateway.(*gateway); ok && gw.openflowManager != nil {
+					uplink = gw.openflowManager.defaultBridge.uplinkName
+				}
+				nodeTuning, err := ovspinning.NewNodeTuningController(uplink)
+				if err != nil {
+					return err
+				}
+				nc.wg.Add(1)
+				go func() {
+					defer nc.wg.Done()
+					nodeTuning.Run(nc.stopChan)
+				}()
+				return nil
+			},
+		},
+		{
+			name: "sysctl-manager",
+			start: func() error {
+				sysctlmanager.Add(nodeSysctls(gatewayBridge))
+				conntrackmanager.NewController().Run()
+				nc.wg.Add(1)
+				go func() {
+					defer nc.wg.Done()
+					sysctlmanager.Run(nc.stopChan, 0)
+				}()
+				return nil
+			},
+		},
+		{
+			name:    "dpu-heartbeat",
+			enabled: func() bool { return config.OvnKubeNode.Mode == types.NodeModeDPU },
+			start: func() error {
+				// TODO @souleb: This breaks ovn-central deployment, need to fix it
+				zone, err := getOVNSBZone()
+				if err != nil {
+					return fmt.Errorf("failed to get the zone name from the OVN Southbound db server, err : %w", err)
+				}
+				ns := config.OvnKubeNode.LeaseNS
+				if ns == "" {
+					ns = defaultLeaseNS
+				}
+				return nc.startDPUNodeheartbeat(ctx, zone, ns, defaultLeaseDurationSeconds, 10*time.Second)
+			},
+		},
+		{
+			name:      "dpu-node-pairing",
+			enabled:   func() bool { return config.OvnKubeNode.Mode == types.NodeModeDPU && nc.dpuNodePairingClient != nil },
+			dependsOn: []string{"dpu-heartbeat"},
+			start: func() error {
+				return dpuPairing.runDPU(ctx, nc.watchFactory.(*factory.WatchFactory).DPUNodePairingInformer())
+			},
+			// the heartbeat is stopped with the controller, the host must not see the DPU ready anymore
+			stop: func() error {
+				stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				return dpuPairing.stopDPU(stopCtx)
+			},
+		},
+	} {
+		if err := subsystems.register(subsystem); err != nil {
+			return err
 		}
-	} else {
-		klog.Infof("Egress IP for secondary host network is disabled")
 	}
-
-	linkManager.Run(nc.stopChan, nc.wg)
-
-	nc.wg.Add(1)
-	go func() {
-		defer nc.wg.Done()
-		ovspinning.Run(nc.stopChan)
-	}()
-
-	if config.OvnKubeNode.Mode == types.NodeModeDPU {
-		// TODO @souleb: This breaks ovn-central deployment, need to fix it
-		zone, err := getOVNSBZone()
-		if err != nil {
-			return fmt.Errorf("failed to get the zone name from the OVN Southbound db server, err : %w", err)
-		}
-		ns := config.OvnKubeNode.LeaseNS
-		if ns == "" {
-			ns = defaultLeaseNS
-		}
-		if err := nc.startDPUNodeheartbeat(ctx, zone, ns, defaultLeaseDurationSeconds, 10*time.Second); err != nil {
+	if err := subsystems.startPending(ctx); err != nil {
+		return err
+	}
+	if egressEnabled() {
+		// the egress ready label is set by the first sync of the egress role and retried until it succeeds
+		if err := egressRole.Run(nc.stopChan, nc.wg, func() error {
+			return subsystems.reconcile("egress-service", "egress-ip")
+		}); err != nil {
 			return err
 		}
 	}
+	subsystems.warnUnknownDisabled()
+	if nc.healthzServer != nil {
+		nc.healthzServer.AddReadinessCheck("node-subsystems", subsystems.checkHealth)
+	}
+	nc.httpServers.handle(adminListener, "/subsystems", subsystems)
+
+	// the link manager is run once the subsystems using it are started
+	linkManager.Run(nc.stopChan, nc.wg)
 
 	klog.Infof("Default node network controller initialized and ready.")
 	return nil
@@ -1185,8 +2050,18 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 // Stop gracefully stops the controller
 // deleteLogicalEntities will never be true for default network
 func (nc *DefaultNodeNetworkController) Stop() {
+	// the subsystems with their own teardown are stopped first, in the reverse order of their start,
+	// the others are stopped with the controller stop channel
+	if nc.subsystems != nil {
+		if err := nc.subsystems.stopAll(); err != nil {
+			klog.Errorf("Failed to stop the default node network controller subsystems: %v", err)
+		}
+	}
 	close(nc.stopChan)
 	nc.wg.Wait()
+	if nc.affinityConntrack != nil {
+		nc.affinityConntrack.stop()
+	}
 }
 
 func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPortEntry managementPortEntry) error {
@@ -1221,6 +2096,7 @@ func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPo
 		return fmt.Errorf("unable to allocate health checking server: %v", err)
 	}
 
+	nc.egressIPHealthServers = append(nc.egressIPHealthServers, healthServer)
 	nc.wg.Add(1)
 	go func() {
 		defer nc.wg.Done()
@@ -1229,10 +2105,22 @@ func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPo
 	return nil
 }
 
+// protocolNeedsConntrackFlush returns true for the protocols whose conntrack entries towards
+// a removed endpoint must be flushed. TCP connections are reset by the endpoint going away, whereas
+// UDP flows and SCTP associations would otherwise keep being sent to the stale endpoint.
+func protocolNeedsConntrackFlush(protocol kapi.Protocol) bool {
+	return protocol == kapi.ProtocolUDP || protocol == kapi.ProtocolSCTP
+}
+
 func (nc *DefaultNodeNetworkController) reconcileConntrackUponEndpointSliceEvents(oldEndpointSlice, newEndpointSlice *discovery.EndpointSlice) error {
 	var errors []error
+	if newEndpointSlice != nil && nc.affinityConntrack != nil {
+		// endpoints that are part of the service again must not be flushed when their
+		// session affinity timeout elapses
+		nc.affinityConntrack.cancelEndpointSlice(newEndpointSlice)
+	}
 	if oldEndpointSlice == nil {
-		// nothing to do upon an add event
+		// nothing else to do upon an add event
 		return nil
 	}
 	namespacedName, err := util.ServiceNamespacedNameFromEndpointSlice(oldEndpointSlice)
@@ -1244,23 +2132,34 @@ func (nc *DefaultNodeNetworkController) reconcileConntrackUponEndpointSliceEvent
 		return fmt.Errorf("error while retrieving service for endpointslice %s/%s when reconciling conntrack: %v",
 			newEndpointSlice.Namespace, newEndpointSlice.Name, err)
 	}
+	// with ClientIP session affinity, clients keep being steered to a removed endpoint until their
+	// affinity expires, so conntrack is flushed for all protocols and once more after the timeout
+	affinityTimeout, hasAffinity := getSessionAffinityTimeout(svc)
+	var newTargetPorts map[string]map[util.EndpointPortKey]int32
+	if newEndpointSlice != nil {
+		newTargetPorts = util.GetEligibleEndpointTargetPorts(newEndpointSlice, svc)
+	}
 	for _, oldPort := range oldEndpointSlice.Ports {
-		if *oldPort.Protocol != kapi.ProtocolUDP { // flush conntrack only for UDP
+		if !hasAffinity && !protocolNeedsConntrackFlush(*oldPort.Protocol) {
 			continue
 		}
 		for _, oldEndpoint := range oldEndpointSlice.Endpoints {
 			for _, oldIP := range oldEndpoint.Addresses {
 				oldIPStr := utilnet.ParseIPSloppy(oldIP).String()
 				// upon an update event, remove conntrack entries for IP addresses that are no longer
-				// in the endpointslice, skip otherwise
-				if newEndpointSlice != nil && util.DoesEndpointSliceContainEligibleEndpoint(newEndpointSlice, oldIPStr, *oldPort.Port, *oldPort.Protocol, svc) {
+				// in the endpointslice or whose (named) target port now resolves to another port, skip otherwise
+				if targetPort, ok := newTargetPorts[oldIPStr][util.NewEndpointPortKey(oldPort)]; ok && targetPort == *oldPort.Port {
 					continue
 				}
-				// upon update and delete events, flush conntrack only for UDP
+				// upon update and delete events, flush conntrack only for UDP and SCTP unless the
+				// service uses session affinity
 				if err := util.DeleteConntrackServicePort(oldIPStr, *oldPort.Port, *oldPort.Protocol,
 					netlink.ConntrackReplyAnyIP, nil); err != nil {
 					klog.Errorf("Failed to delete conntrack entry for %s: %v", oldIPStr, err)
 				}
+				if hasAffinity && nc.affinityConntrack != nil {
+					nc.affinityConntrack.schedule(oldIPStr, *oldPort.Port, *oldPort.Protocol, affinityTimeout)
+				}
 			}
 		}
 	}
@@ -1290,15 +2189,38 @@ func exGatewayPodsAnnotationsChanged(oldNs, newNs *kapi.Namespace) bool {
 		(oldNs.Annotations[util.RoutingExternalGWsAnnotation] != newNs.Annotations[util.RoutingExternalGWsAnnotation])
 }
 
+// exGatewayNamespaceChanged returns true if the external gateways of the namespace may have changed: either its
+// exgw annotations changed or its labels did, which may select it as target of an Admin Policy Based External Route
+// or no longer
+func exGatewayNamespaceChanged(oldNs, newNs *kapi.Namespace) bool {
+	return exGatewayPodsAnnotationsChanged(oldNs, newNs) || !labels.Equals(oldNs.Labels, newNs.Labels)
+}
+
+// isExternalGatewayNamespace returns true if the pods of the namespace are served by external gateways, set either
+// by the exgw annotations of the namespace or by the Admin Policy Based External Routes targeting it
+func (nc *DefaultNodeNetworkController) isExternalGatewayNamespace(namespace *kapi.Namespace) bool {
+	_, foundRoutingExternalGWsAnnotation := namespace.Annotations[util.RoutingExternalGWsAnnotation]
+	_, foundExternalGatewayPodIPsAnnotation := namespace.Annotations[util.ExternalGatewayPodIPsAnnotation]
+	if foundRoutingExternalGWsAnnotation || foundExternalGatewayPodIPsAnnotation {
+		return true
+	}
+	targeted, err := nc.apbExternalRouteNodeController.IsTargetNamespace(namespace)
+	if err != nil {
+		// assume it is targeted, the conntrack entries are synced with the gateways found in that case
+		klog.Warningf("Unable to check if namespace %s is targeted by Admin Policy Based External Routes: %v",
+			namespace.Name, err)
+		return true
+	}
+	return targeted
+}
+
 func (nc *DefaultNodeNetworkController) checkAndDeleteStaleConntrackEntries() {
 	namespaces, err := nc.watchFactory.GetNamespaces()
 	if err != nil {
 		klog.Errorf("Unable to get pods from informer: %v", err)
 	}
 	for _, namespace := range namespaces {
-		_, foundRoutingExternalGWsAnnotation := namespace.Annotations[util.RoutingExternalGWsAnnotation]
-		_, foundExternalGatewayPodIPsAnnotation := namespace.Annotations[util.ExternalGatewayPodIPsAnnotation]
-		if foundRoutingExternalGWsAnnotation || foundExternalGatewayPodIPsAnnotation {
+		if nc.isExternalGatewayNamespace(namespace) {
 			pods, err := nc.watchFactory.GetPods(namespace.Name)
 			if err != nil {
 				klog.Warningf("Unable to get pods from informer for namespace %s: %v", namespace.Name, err)
@@ -1332,41 +2254,35 @@ func (nc *DefaultNodeNetworkController) WatchNamespaces() error {
 }
 
 // validateVTEPInterfaceMTU checks if the MTU of the interface that has ovn-encap-ip is big
-// enough to carry the `config.Default.MTU` and the Geneve header. If the MTU is not big
+// enough to carry the `config.Default.MTU` (or the larger management port or gateway patch MTU) and the Geneve header. If the MTU is not big
 // enough, it will return an error
 func (nc *DefaultNodeNetworkController) validateVTEPInterfaceMTU() error {
 	// OVN allows `external_ids:ovn-encap-ip` to be a list of IPs separated by comma
-	ovnEncapIps := strings.Split(config.Default.EncapIP, ",")
-	for _, ip := range ovnEncapIps {
-		ovnEncapIP := net.ParseIP(strings.TrimSpace(ip))
-		if ovnEncapIP == nil {
-			return fmt.Errorf("invalid IP address %q in provided encap-ip setting %q", ovnEncapIP, config.Default.EncapIP)
-		}
-		interfaceName, mtu, err := util.GetIFNameAndMTUForAddress(ovnEncapIP)
+	ovnEncapIps, err := util.ParseEncapIPs(config.Default.EncapIP)
+	if err != nil {
+		return fmt.Errorf("invalid encap-ip setting: %w", err)
+	}
+	// the management port may send larger packets than the pods over the overlay
+	mtu := overlayMTU()
+	// with a tunnel endpoint per IP family, each interface only carries the tunnels of its family
+	perFamilyEndpoints, _ := utilnet.IsDualStackIPs(ovnEncapIps)
+	for _, ovnEncapIP := range ovnEncapIps {
+		interfaceName, ifMTU, err := util.GetIFNameAndMTUForAddress(ovnEncapIP)
 		if err != nil {
 			return fmt.Errorf("could not get MTU for the interface with address %s: %w", ovnEncapIP, err)
 		}
-
-		// calc required MTU
-		var requiredMTU int
-		if config.Gateway.SingleNode {
-			requiredMTU = config.Default.MTU
-		} else {
-			if config.IPv4Mode && !config.IPv6Mode {
-				// we run in single-stack IPv4 only
-				requiredMTU = config.Default.MTU + types.GeneveHeaderLengthIPv4
-			} else {
-				// we run in single-stack IPv6 or dual-stack mode
-				requiredMTU = config.Default.MTU + types.GeneveHeaderLengthIPv6
-			}
+		if config.Default.GatewayBridgeMTU != 0 && nc.Gateway != nil && interfaceName == nc.Gateway.GetGatewayBridgeIface() {
+			// the gateway bridge is set to its MTU override
+			ifMTU = config.Default.GatewayBridgeMTU
 		}
 
-		if mtu < requiredMTU {
+		requiredMTU := requiredVTEPInterfaceMTU(mtu, ovnEncapIP, perFamilyEndpoints)
+		if ifMTU < requiredMTU {
 			return fmt.Errorf("MTU (%d) of network interface %s is too small for specified overlay MTU (%d)",
-				mtu, interfaceName, requiredMTU)
+				ifMTU, interfaceName, requiredMTU)
 		}
-		klog.V(2).Infof("MTU (%d) of network interface %s is big enough to deal with Geneve header overhead (sum %d). ",
-			mtu, interfaceName, requiredMTU)
+		nodeLog.V(2).Infof("MTU (%d) of network interface %s is big enough to deal with Geneve header overhead (sum %d). ",
+			ifMTU, interfaceName, requiredMTU)
 	}
 	return nil
 }
@@ -1377,40 +2293,71 @@ func (nc *DefaultNodeNetworkController) startDPUNodeheartbeat(ctx context.Contex
 		LeaseDurationSecondsOption(duration),
 		LeaseNSOption(ns),
 		ModeOption(types.NodeModeDPU),
-		IntervalOption(interval))
+		IntervalOption(interval),
+		ThrottleOption{util.NodeAPIWriteThrottle(nc.name)})
 	if err := h.run(ctx); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (nc *DefaultNodeNetworkController) checkDPUNodeHeartbeat(ctx context.Context, zone, ns string, interval, timeout time.Duration) error {
-	err := wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
-		ready, err := isHeartBeatValid(ctx, nc.Kube.(*kube.Kube).KClient, zone, ns)
-		if err != nil {
-			klog.Infof("Waiting for the dpu node to be ready: %v", err)
-			return false, nil
+func (nc *DefaultNodeNetworkController) checkDPUNodeHeartbeat(ctx context.Context, dpuNodeName, ns string, interval, timeout time.Duration) error {
+	readiness := newDPUNodeReadiness(dpuNodeName).withEvents(nc.recorder, nc.name)
+	if nc.healthzServer != nil {
+		nc.healthzServer.AddReadinessCheck("dpu-node", readiness.check)
+	}
+	reportHostReady := func(error) {}
+	if nc.dpuNodePairingClient != nil {
+		pairing := newDPUNodePairing(nc.dpuNodePairingClient, nc.name)
+		if err := pairing.ensureHostPairing(ctx, dpuNodeName); err != nil {
+			return err
 		}
-		if ready {
-			return true, nil
+		reportHostReady = func(err error) {
+			pairing.reportHostReady(ctx, err)
 		}
-		return false, nil
-	})
+	}
+	err := waitForDPUNodeHeartbeat(ctx, nc.Kube.(*kube.Kube).KClient, dpuNodeName, config.Default.Zone, ns, timeout,
+		dpuNodeAbsentGracePeriod, readiness)
+	reportHostReady(err)
 	if err != nil {
-		return fmt.Errorf("timed out waiting for the dpu node to be ready: %v", err)
+		return err
 	}
 
 	// Start the heartbeat for the DPU Host node
-	h := newHeartbeat(nc.Kube.(*kube.Kube).KClient, nc.name, zone, nc.errChan,
+	h := newHeartbeat(nc.Kube.(*kube.Kube).KClient, nc.name, config.Default.Zone, nc.errChan,
 		LeaseNSOption(ns),
 		ModeOption(types.NodeModeDPUHost),
-		IntervalOption(interval))
+		IntervalOption(interval),
+		PeerLeaseOption(dpuNodeName),
+		StatusHandlerOption(reportHostReady))
 	if err = h.run(ctx); err != nil {
 		return err
 	}
 	return nil
 }
 
+// restartOnDPUNodeJoin waits in the background for a DPU node to join the zone of the DPU host running degraded,
+// and then stops ovnkube-node for it to restart with the CNI server
+func (nc *DefaultNodeNetworkController) restartOnDPUNodeJoin(ctx context.Context, dpuNodeName, ns string) {
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		err := waitForDPUNodeHeartbeat(ctx, nc.Kube.(*kube.Kube).KClient, dpuNodeName, config.Default.Zone, ns, 0, 0,
+			newDPUNodeReadiness(dpuNodeName))
+		if err != nil || ctx.Err() != nil {
+			return
+		}
+		klog.Infof("A DPU node joined zone %s, restarting to start the CNI server", config.Default.Zone)
+		nc.recorder.Event(&kapi.ObjectReference{Kind: "Node", Name: nc.name, UID: ktypes.UID(nc.name)},
+			kapi.EventTypeNormal, "DPUNodeJoined", "A DPU node joined the zone, restarting to start the CNI server")
+		// nothing reads the error channel anymore once the node controller is stopped
+		select {
+		case nc.errChan <- fmt.Errorf("dpu node joined zone %s, restarting to leave the degraded mode", config.Default.Zone):
+		case <-nc.stopChan:
+		}
+	}()
+}
+
 func configureSvcRouteViaBridge(routeManager *routemanager.Controller, bridge string) error {
 	return configureSvcRouteViaInterface(routeManager, bridge, DummyNextHopIPs())
 }
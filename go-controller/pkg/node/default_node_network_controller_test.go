@@ -237,6 +237,47 @@ var _ = Describe("Node", func() {
 			})
 		})
 
+		Context("with an ovn encap IP per IP family", func() {
+			var netlinkLinkMockV6 *netlink_mocks.Link
+
+			BeforeEach(func() {
+				config.IPv4Mode = true
+				config.IPv6Mode = true
+				config.Gateway.SingleNode = false
+				config.Default.EncapIP = "10.1.0.40,fd00::40"
+				netlinkLinkMockV6 = new(netlink_mocks.Link)
+				netlinkOpsMock.On("AddrList", nil, netlink.FAMILY_V6).
+					Return([]netlink.Addr{{LinkIndex: 6, IPNet: ovntest.MustParseIPNet("fd00::40/128")}}, nil)
+				netlinkOpsMock.On("LinkByIndex", 6).Return(netlinkLinkMockV6, nil)
+				// the IPv4 interface only carries the IPv4 tunnels
+				netlinkLinkMock.On("Attrs").Return(&netlink.LinkAttrs{
+					MTU:  mtuOkForIPv4ButTooSmallForIPv6,
+					Name: linkName,
+				})
+			})
+
+			It("validates the MTU of each interface with the header of its IP family", func() {
+				netlinkLinkMockV6.On("Attrs").Return(&netlink.LinkAttrs{
+					MTU:  mtuOkForIPv4AndIPv6,
+					Name: "breth1",
+				})
+
+				err := nc.validateVTEPInterfaceMTU()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails if the IPv6 interface MTU is too small for the IPv6 tunnels", func() {
+				netlinkLinkMockV6.On("Attrs").Return(&netlink.LinkAttrs{
+					MTU:  mtuOkForIPv4ButTooSmallForIPv6,
+					Name: "breth1",
+				})
+
+				err := nc.validateVTEPInterfaceMTU()
+				Expect(err).To(MatchError(fmt.Sprintf("MTU (%d) of network interface breth1 is too small for specified overlay MTU (%d)",
+					mtuOkForIPv4ButTooSmallForIPv6, mtuOkForIPv4AndIPv6)))
+			})
+		})
+
 	})
 
 	Describe("Node Operations", func() {
@@ -709,23 +750,23 @@ func heartbeatDPUHostTest(app *cli.App, uplinkName, hostIP string) {
 
 		stop := make(chan struct{})
 		errChan := make(chan error)
-		cnnci := NewCommonNodeNetworkControllerInfo(fakeClient.KubeClient, fakeClient.AdminPolicyRouteClient, nil, nil, nodeName, nil)
+		cnnci := NewCommonNodeNetworkControllerInfo(fakeClient.KubeClient, fakeClient.AdminPolicyRouteClient, nil, nil, nil, nodeName, nil)
 		nc := newDefaultNodeNetworkController(cnnci, stop, errChan, nil, nil)
 
 		contx, cancel := context.WithCancel(context.Background())
 
 		// check that the heartbeat fails when the lease is not created
-		err = nc.checkDPUNodeHeartbeat(contx, config.Default.Zone, defaultLeaseNS, 10*time.Millisecond, 500*time.Millisecond)
+		err = nc.checkDPUNodeHeartbeat(contx, nodeName, defaultLeaseNS, 10*time.Millisecond, 500*time.Millisecond)
 		Expect(err).To(HaveOccurred())
 
 		// simulate dpu node heartbeat
 		nodeErrChan := make(chan error)
-		nodeNC := newDefaultNodeNetworkController(NewCommonNodeNetworkControllerInfo(kubeFakeClient, nil, nil, nil, nodeName, nil), nil, nodeErrChan, nil, nil)
+		nodeNC := newDefaultNodeNetworkController(NewCommonNodeNetworkControllerInfo(kubeFakeClient, nil, nil, nil, nil, nodeName, nil), nil, nodeErrChan, nil, nil)
 		err = nodeNC.startDPUNodeheartbeat(contx, config.Default.Zone, defaultLeaseNS, 1, 5*time.Millisecond)
 		Expect(err).NotTo(HaveOccurred())
 
 		// check that the heartbeat succeeds when the lease is created
-		err = nc.checkDPUNodeHeartbeat(contx, config.Default.Zone, defaultLeaseNS, 10*time.Millisecond, 500*time.Millisecond)
+		err = nc.checkDPUNodeHeartbeat(contx, nodeName, defaultLeaseNS, 10*time.Millisecond, 500*time.Millisecond)
 		Expect(err).NotTo(HaveOccurred())
 
 		// wait 1 second to ensure the lease is renewed
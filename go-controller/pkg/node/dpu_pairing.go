@@ -0,0 +1,170 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	dpunodepairingv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1"
+	dpunodepairingclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned"
+	dpunodepairinginformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/informers/externalversions/dpunodepairing/v1"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// dpuNodePairing maintains the DPUNodePairing of a DPU / DPU host pair. The DPU host creates
+// the pairing, named after the host node, and each side reports the health of the pairing
+// in its status.
+type dpuNodePairing struct {
+	client   dpunodepairingclientset.Interface
+	nodeName string
+	// informer and handle are the pairing informer of the DPU and its event handler, set by runDPU
+	informer dpunodepairinginformer.DPUNodePairingInformer
+	handle   cache.ResourceEventHandlerRegistration
+}
+
+func newDPUNodePairing(client dpunodepairingclientset.Interface, nodeName string) *dpuNodePairing {
+	return &dpuNodePairing{
+		client:   client,
+		nodeName: nodeName,
+	}
+}
+
+// ensureHostPairing creates or updates the pairing of this DPU host with the given DPU node
+func (p *dpuNodePairing) ensureHostPairing(ctx context.Context, dpuNodeName string) error {
+	pairings := p.client.K8sV1().DPUNodePairings()
+	pairing, err := pairings.Get(ctx, p.nodeName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the DPU node pairing %s: %v", p.nodeName, err)
+		}
+		klog.Infof("Creating the DPU node pairing of host %s with DPU node %s", p.nodeName, dpuNodeName)
+		_, err = pairings.Create(ctx, &dpunodepairingv1.DPUNodePairing{
+			ObjectMeta: metav1.ObjectMeta{Name: p.nodeName},
+			Spec: dpunodepairingv1.DPUNodePairingSpec{
+				HostNodeName: p.nodeName,
+				DPUNodeName:  dpuNodeName,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create the DPU node pairing %s: %v", p.nodeName, err)
+		}
+		return nil
+	}
+	if pairing.Spec.DPUNodeName == dpuNodeName {
+		return nil
+	}
+	klog.Infof("Updating the DPU node pairing of host %s from DPU node %s to %s", p.nodeName,
+		pairing.Spec.DPUNodeName, dpuNodeName)
+	pairing = pairing.DeepCopy()
+	pairing.Spec.DPUNodeName = dpuNodeName
+	// the conditions reported for the previous DPU do not apply anymore
+	pairing.Status.Conditions = nil
+	pairing, err = pairings.Update(ctx, pairing, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update the DPU node pairing %s: %v", p.nodeName, err)
+	}
+	_, err = pairings.UpdateStatus(ctx, pairing, metav1.UpdateOptions{})
+	return err
+}
+
+// setCondition sets the given condition in the status of the pairing, retrying on conflict as
+// both sides of the pair update the status
+func (p *dpuNodePairing) setCondition(ctx context.Context, name, conditionType string, status metav1.ConditionStatus,
+	reason, message string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		pairing, err := p.client.K8sV1().DPUNodePairings().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pairing = pairing.DeepCopy()
+		if !meta.SetStatusCondition(&pairing.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: pairing.Generation,
+		}) {
+			return nil
+		}
+		_, err = p.client.K8sV1().DPUNodePairings().UpdateStatus(ctx, pairing, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// reportHostReady sets the HostReady condition of the pairing of this DPU host from the result
+// of the check of the DPU heartbeat
+func (p *dpuNodePairing) reportHostReady(ctx context.Context, heartbeatErr error) {
+	status, reason, message := metav1.ConditionTrue, "DPUHeartbeatValid", "The DPU heartbeat is valid"
+	if heartbeatErr != nil {
+		status, reason, message = metav1.ConditionFalse, "DPUHeartbeatInvalid", heartbeatErr.Error()
+	}
+	if err := p.setCondition(ctx, p.nodeName, dpunodepairingv1.HostReadyCondition, status, reason, message); err != nil {
+		klog.Errorf("Failed to report the host readiness on the DPU node pairing %s: %v", p.nodeName, err)
+	}
+}
+
+// runDPU reports this DPU node ready on the pairings that have it as DPU, as the host may
+// create its pairing after the DPU started its heartbeat
+func (p *dpuNodePairing) runDPU(ctx context.Context, informer dpunodepairinginformer.DPUNodePairingInformer) error {
+	reportDPUReady := func(obj interface{}) {
+		pairing, ok := obj.(*dpunodepairingv1.DPUNodePairing)
+		if !ok || pairing.Spec.DPUNodeName != p.nodeName ||
+			meta.IsStatusConditionTrue(pairing.Status.Conditions, dpunodepairingv1.DPUReadyCondition) {
+			return
+		}
+		err := p.setCondition(ctx, pairing.Name, dpunodepairingv1.DPUReadyCondition, metav1.ConditionTrue,
+			"HeartbeatRunning", "The DPU heartbeat is running")
+		if err != nil {
+			klog.Errorf("Failed to report the DPU readiness on the DPU node pairing %s: %v", pairing.Name, err)
+		}
+	}
+	handle, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: reportDPUReady,
+		UpdateFunc: func(_, newObj interface{}) {
+			reportDPUReady(newObj)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	p.informer = informer
+	p.handle = handle
+	return nil
+}
+
+// stopDPU reports this DPU node not ready on the pairings that have it as DPU once its heartbeat
+// stopped
+func (p *dpuNodePairing) stopDPU(ctx context.Context) error {
+	if p.handle == nil {
+		return nil
+	}
+	// the pairings must not be reported ready again
+	if err := p.informer.Informer().RemoveEventHandler(p.handle); err != nil {
+		return fmt.Errorf("failed to remove the DPU node pairing event handler: %v", err)
+	}
+	p.handle = nil
+	pairings, err := p.informer.Lister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pairing := range pairings {
+		if pairing.Spec.DPUNodeName != p.nodeName {
+			continue
+		}
+		err := p.setCondition(ctx, pairing.Name, dpunodepairingv1.DPUReadyCondition, metav1.ConditionFalse,
+			"HeartbeatStopped", "The DPU heartbeat is stopped")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to report the DPU not ready on the DPU node pairing %s: %v",
+				pairing.Name, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
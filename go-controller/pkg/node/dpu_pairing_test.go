@@ -0,0 +1,93 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dpunodepairingv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1"
+	dpunodepairingfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned/fake"
+	dpunodepairinginformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/informers/externalversions"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DPU node pairing", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		client *dpunodepairingfake.Clientset
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		client = dpunodepairingfake.NewSimpleClientset()
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	getPairing := func() *dpunodepairingv1.DPUNodePairing {
+		pairing, err := client.K8sV1().DPUNodePairings().Get(ctx, "host1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return pairing
+	}
+
+	It("creates the pairing of the host and reports the DPU heartbeat health", func() {
+		host := newDPUNodePairing(client, "host1")
+		Expect(host.ensureHostPairing(ctx, "dpu1")).To(Succeed())
+		Expect(getPairing().Spec).To(Equal(dpunodepairingv1.DPUNodePairingSpec{HostNodeName: "host1", DPUNodeName: "dpu1"}))
+
+		host.reportHostReady(ctx, errors.New("lease dpu1 is expired"))
+		condition := meta.FindStatusCondition(getPairing().Status.Conditions, dpunodepairingv1.HostReadyCondition)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Message).To(Equal("lease dpu1 is expired"))
+
+		host.reportHostReady(ctx, nil)
+		Expect(meta.IsStatusConditionTrue(getPairing().Status.Conditions, dpunodepairingv1.HostReadyCondition)).To(BeTrue())
+
+		// pairing the host with another DPU resets the conditions
+		Expect(host.ensureHostPairing(ctx, "dpu2")).To(Succeed())
+		pairing := getPairing()
+		Expect(pairing.Spec.DPUNodeName).To(Equal("dpu2"))
+		Expect(pairing.Status.Conditions).To(BeEmpty())
+	})
+
+	It("reports the DPU ready once the host created the pairing", func() {
+		factory := dpunodepairinginformerfactory.NewSharedInformerFactory(client, 0)
+		dpu := newDPUNodePairing(client, "dpu1")
+		Expect(dpu.runDPU(ctx, factory.K8s().V1().DPUNodePairings())).To(Succeed())
+		factory.Start(ctx.Done())
+		factory.WaitForCacheSync(ctx.Done())
+
+		Expect(newDPUNodePairing(client, "host1").ensureHostPairing(ctx, "dpu1")).To(Succeed())
+		Eventually(func() bool {
+			return meta.IsStatusConditionTrue(getPairing().Status.Conditions, dpunodepairingv1.DPUReadyCondition)
+		}).Should(BeTrue())
+	})
+
+	It("reports the DPU not ready once its heartbeat stopped", func() {
+		factory := dpunodepairinginformerfactory.NewSharedInformerFactory(client, 0)
+		dpu := newDPUNodePairing(client, "dpu1")
+		Expect(dpu.runDPU(ctx, factory.K8s().V1().DPUNodePairings())).To(Succeed())
+		factory.Start(ctx.Done())
+		factory.WaitForCacheSync(ctx.Done())
+
+		Expect(newDPUNodePairing(client, "host1").ensureHostPairing(ctx, "dpu1")).To(Succeed())
+		Eventually(func() bool {
+			return meta.IsStatusConditionTrue(getPairing().Status.Conditions, dpunodepairingv1.DPUReadyCondition)
+		}).Should(BeTrue())
+
+		Expect(dpu.stopDPU(ctx)).To(Succeed())
+		Expect(meta.IsStatusConditionFalse(getPairing().Status.Conditions, dpunodepairingv1.DPUReadyCondition)).To(BeTrue())
+		// the DPU is not reported ready again on the next update of the pairing
+		Consistently(func() bool {
+			return meta.IsStatusConditionFalse(getPairing().Status.Conditions, dpunodepairingv1.DPUReadyCondition)
+		}, "200ms").Should(BeTrue())
+	})
+})
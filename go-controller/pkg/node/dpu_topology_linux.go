@@ -0,0 +1,94 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/klog/v2"
+)
+
+// updateDPUTopology publishes the DPU topology of this side of the DPU / DPU host pair
+// in the DPU topology annotation of the node
+func (nc *DefaultNodeNetworkController) updateDPUTopology() error {
+	topology := &util.DPUTopology{
+		Serial:      config.OvnKubeNode.DPUSerial,
+		DPUNodeName: config.OvnKubeNode.DPUNodeName,
+	}
+	if config.OvnKubeNode.Mode == types.NodeModeDPU && topology.DPUNodeName == "" {
+		// the DPU heartbeat lease is named after the node the DPU runs as
+		topology.DPUNodeName = nc.name
+	}
+	pfs, err := nc.dpuTopologyPFs()
+	if err != nil {
+		// the PFs are informational, the topology is still published without them
+		klog.Warningf("Unable to find the PFs of the DPU topology of node %s: %v", nc.name, err)
+	}
+	topology.PFs = pfs
+	klog.Infof("Publishing %s DPU topology of node %s: %+v", config.OvnKubeNode.Mode, nc.name, *topology)
+	return util.UpdateNodeDPUTopologyWithRetry(nc.name, nc.watchFactory.NodeCoreInformer().Lister(), nc.Kube,
+		config.OvnKubeNode.Mode, topology)
+}
+
+// dpuTopologyPFs returns on the DPU host the PF netdevs of the management port VF and on
+// the DPU the uplink of the gateway bridge
+func (nc *DefaultNodeNetworkController) dpuTopologyPFs() ([]string, error) {
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		gw, ok := nc.Gateway.(*gateway)
+		if !ok || gw.openflowManager == nil || gw.openflowManager.defaultBridge.uplinkName == "" {
+			return nil, fmt.Errorf("no gateway bridge uplink")
+		}
+		return []string{gw.openflowManager.defaultBridge.uplinkName}, nil
+	}
+
+	// the management port netdev is renamed once configured
+	var vfPci string
+	var err error
+	for _, netdev := range []string{config.OvnKubeNode.MgmtPortNetdev, types.K8sMgmtIntfName} {
+		if netdev == "" {
+			continue
+		}
+		if vfPci, err = util.GetSriovnetOps().GetPciFromNetDevice(netdev); err == nil {
+			break
+		}
+	}
+	if vfPci == "" {
+		return nil, fmt.Errorf("failed to get the PCI address of the management port: %v", err)
+	}
+	pfPci, err := util.GetSriovnetOps().GetPfPciFromVfPci(vfPci)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the PF PCI address of VF %s: %v", vfPci, err)
+	}
+	return util.GetSriovnetOps().GetNetDevicesFromPci(pfPci)
+}
+
+// pairedDPUNodeName returns on the DPU host the name of the node of the paired DPU: the configured
+// one, the one the DPU published in the DPU topology of the node or, by default, the host node name
+func (nc *DefaultNodeNetworkController) pairedDPUNodeName() string {
+	if config.OvnKubeNode.DPUNodeName != "" {
+		return config.OvnKubeNode.DPUNodeName
+	}
+	node, err := nc.watchFactory.GetNode(nc.name)
+	if err != nil {
+		klog.Warningf("Unable to get node %s to find its paired DPU node: %v", nc.name, err)
+		return nc.name
+	}
+	if !util.IsDPUHostNode(node) {
+		klog.Warningf("Node %s has no DPU host topology, using its name for the paired DPU node", nc.name)
+		return nc.name
+	}
+	if !util.IsNodeDPUPaired(node) {
+		// the DPU did not publish its topology yet or declares a different serial
+		return nc.name
+	}
+	dpuNodeName, err := util.GetNodePairedDPUNodeName(node)
+	if err != nil {
+		return nc.name
+	}
+	return dpuNodeName
+}
@@ -0,0 +1,397 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ndp"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// duplicateAddressSyncInterval is how often the addresses watched for conflicts are listed
+	duplicateAddressSyncInterval = 30 * time.Second
+	// duplicateAddressReportInterval is how often a conflict still seen is reported again
+	duplicateAddressReportInterval = 10 * time.Minute
+	// duplicateAddressReadTimeout bounds the reads of the listeners so that they notice when they are stopped
+	duplicateAddressReadTimeout = time.Second
+	// duplicateAddressEventReason is the reason of the node events reporting the conflicts
+	duplicateAddressEventReason = "DuplicateAddress"
+)
+
+// addressClaim is an IP claimed by a MAC in an ARP packet or a neighbor discovery message received on an
+// interface
+type addressClaim struct {
+	ip        net.IP
+	mac       net.HardwareAddr
+	ifaceName string
+}
+
+// addressClaimListener listens for the IPs claimed on an interface
+type addressClaimListener interface {
+	// listen sends the IPs claimed in the packets received on the interface to claims until stopChan is closed,
+	// addresses returns the IPs watched for conflicts
+	listen(ifaceName string, claims chan<- addressClaim, addresses func() []net.IP, stopChan <-chan struct{},
+		doneWg *sync.WaitGroup) error
+}
+
+// neighborClaimListener listens for the ARP packets and the neighbor discovery messages on the wire
+type neighborClaimListener struct{}
+
+func (neighborClaimListener) listen(ifaceName string, claims chan<- addressClaim, addresses func() []net.IP,
+	stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	return listenAddressClaims(ifaceName, claims, addresses, stopChan, doneWg)
+}
+
+// duplicateAddressDetector reports in node events the IPs of the node claimed by other MACs on the L2
+// networks of the gateway bridges: the pod IPs, the egress IPs assigned to the node and the masquerade IPs,
+// which the node answers for with its own MACs if at all. The senders of the ARP packets, and the targets
+// of the neighbor advertisements and the sources of the neighbor solicitations, are checked against them.
+// Duplicate IPs otherwise silently break the connections of the pods that happen to resolve the wrong MAC.
+type duplicateAddressDetector struct {
+	nodeName string
+	bridges  []string
+	listPods func() ([]*kapi.Pod, error)
+	// listEgressIPs is nil when egress IP is disabled
+	listEgressIPs func() ([]*egressipv1.EgressIP, error)
+	// listLocalMACs lists the MACs of the interfaces of the node
+	listLocalMACs func() (sets.Set[string], error)
+	listener      addressClaimListener
+	recorder      record.EventRecorder
+	nodeRef       *kapi.ObjectReference
+
+	lock sync.Mutex
+	// owners are the owners of the watched addresses by IP, e.g. pod ns/name
+	owners map[string]string
+	// localMACs are the MACs the node claims its addresses with
+	localMACs sets.Set[string]
+	// reported is when each conflict, by IP and MAC, was last reported
+	reported map[string]time.Time
+}
+
+func newDuplicateAddressDetector(nodeName string, bridges []string, watchFactory factory.NodeWatchFactory,
+	recorder record.EventRecorder, listener addressClaimListener) *duplicateAddressDetector {
+	d := &duplicateAddressDetector{
+		nodeName:      nodeName,
+		bridges:       bridges,
+		listPods:      watchFactory.GetAllPods,
+		listLocalMACs: listLocalMACs,
+		listener:      listener,
+		recorder:      recorder,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		owners:    map[string]string{},
+		localMACs: sets.New[string](),
+		reported:  map[string]time.Time{},
+	}
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		d.listEgressIPs = func() ([]*egressipv1.EgressIP, error) {
+			return watchFactory.EgressIPInformer().Lister().List(labels.Everything())
+		}
+	}
+	return d
+}
+
+func (d *duplicateAddressDetector) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	// the addresses are listed before the claims are checked against them
+	if err := d.sync(); err != nil {
+		klog.Errorf("Failed to list the addresses of node %s watched for conflicts: %v", d.nodeName, err)
+	}
+	claims := make(chan addressClaim, 100)
+	for _, bridge := range d.bridges {
+		if err := d.listener.listen(bridge, claims, d.watchedIPs, stopChan, doneWg); err != nil {
+			return fmt.Errorf("failed to listen for the addresses claimed on %s: %w", bridge, err)
+		}
+	}
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case claim := <-claims:
+				d.checkClaim(claim, time.Now())
+			}
+		}
+	}()
+	runPeriodicSync(stopChan, doneWg, duplicateAddressSyncInterval, nil, func() {
+		if err := d.sync(); err != nil {
+			klog.Errorf("Failed to list the addresses of node %s watched for conflicts: %v", d.nodeName, err)
+		}
+	})
+	return nil
+}
+
+// sync lists the addresses watched for conflicts and the MACs of the node, and forgets the conflicts of the
+// addresses no longer watched
+func (d *duplicateAddressDetector) sync() error {
+	owners := map[string]string{}
+	for _, ip := range masqueradeIPs() {
+		owners[ip.String()] = "masquerade IP"
+	}
+	if d.listEgressIPs != nil {
+		eips, err := d.listEgressIPs()
+		if err != nil {
+			return fmt.Errorf("failed to list the egress IPs: %w", err)
+		}
+		for _, eip := range eips {
+			for _, status := range eip.Status.Items {
+				if status.Node == d.nodeName {
+					owners[status.EgressIP] = "egress IP " + eip.Name
+				}
+			}
+		}
+	}
+	pods, err := d.listPods()
+	if err != nil {
+		return fmt.Errorf("failed to list the pods: %w", err)
+	}
+	for _, pod := range pods {
+		if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		ips, err := util.DefaultNetworkPodIPs(pod)
+		if err != nil {
+			klog.V(5).Infof("Not watching the IPs of pod %s/%s for conflicts: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		for _, ip := range ips {
+			owners[ip.String()] = fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+	localMACs, err := d.listLocalMACs()
+	if err != nil {
+		return fmt.Errorf("failed to list the MACs of the node: %w", err)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.owners = owners
+	d.localMACs = localMACs
+	for conflict := range d.reported {
+		ip, _, _ := strings.Cut(conflict, "/")
+		if _, ok := owners[ip]; !ok {
+			delete(d.reported, conflict)
+		}
+	}
+	return nil
+}
+
+// watchedIPs returns the addresses watched for conflicts
+func (d *duplicateAddressDetector) watchedIPs() []net.IP {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	ips := make([]net.IP, 0, len(d.owners))
+	for ip := range d.owners {
+		ips = append(ips, net.ParseIP(ip))
+	}
+	return ips
+}
+
+// checkClaim reports the claim of a watched address by a MAC of another host, a conflict is reported again
+// every duplicateAddressReportInterval while it is seen
+func (d *duplicateAddressDetector) checkClaim(claim addressClaim, now time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	ip := claim.ip.String()
+	owner, ok := d.owners[ip]
+	if !ok || d.localMACs.Has(claim.mac.String()) {
+		return
+	}
+	conflict := ip + "/" + claim.mac.String()
+	if last, ok := d.reported[conflict]; ok && now.Sub(last) < duplicateAddressReportInterval {
+		return
+	}
+	d.reported[conflict] = now
+	klog.Warningf("IP %s of %s on node %s is also claimed by MAC %s on %s", ip, owner, d.nodeName, claim.mac,
+		claim.ifaceName)
+	d.recorder.Eventf(d.nodeRef, kapi.EventTypeWarning, duplicateAddressEventReason,
+		"IP %s of %s is also claimed by MAC %s on %s, the node uses MACs %s", ip, owner, claim.mac,
+		claim.ifaceName, strings.Join(sets.List(d.localMACs), ", "))
+}
+
+// masqueradeIPs returns the masquerade IPs of the IP families of the node
+func masqueradeIPs() []net.IP {
+	masqIPs := config.Gateway.MasqueradeIPs
+	var ips []net.IP
+	if config.IPv4Mode {
+		ips = append(ips, masqIPs.V4OVNMasqueradeIP, masqIPs.V4HostMasqueradeIP, masqIPs.V4HostETPLocalMasqueradeIP,
+			masqIPs.V4DummyNextHopMasqueradeIP, masqIPs.V4OVNServiceHairpinMasqueradeIP)
+	}
+	if config.IPv6Mode {
+		ips = append(ips, masqIPs.V6OVNMasqueradeIP, masqIPs.V6HostMasqueradeIP, masqIPs.V6HostETPLocalMasqueradeIP,
+			masqIPs.V6DummyNextHopMasqueradeIP, masqIPs.V6OVNServiceHairpinMasqueradeIP)
+	}
+	return ips
+}
+
+// listLocalMACs returns the MACs of the interfaces of the node
+func listLocalMACs() (sets.Set[string], error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	macs := sets.New[string]()
+	for _, iface := range ifaces {
+		if len(iface.HardwareAddr) > 0 {
+			macs.Insert(iface.HardwareAddr.String())
+		}
+	}
+	return macs, nil
+}
+
+// listenAddressClaims listens for the ARP packets, when the node has IPv4, and for the neighbor discovery
+// messages, when it has IPv6, received on the interface
+func listenAddressClaims(ifaceName string, claims chan<- addressClaim, addresses func() []net.IP,
+	stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("failed finding interface %s: %w", ifaceName, err)
+	}
+	send := func(ip net.IP, mac net.HardwareAddr) {
+		if ip.IsUnspecified() || len(mac) == 0 {
+			return
+		}
+		select {
+		case claims <- addressClaim{ip: ip, mac: mac, ifaceName: ifaceName}:
+		case <-stopChan:
+		}
+	}
+
+	if config.IPv4Mode {
+		c, err := arp.Dial(iface)
+		if err != nil {
+			return fmt.Errorf("failed dialing interface %s: %w", ifaceName, err)
+		}
+		readClaims(ifaceName, stopChan, doneWg, c.Close, c.SetReadDeadline, func() error {
+			p, _, err := c.Read()
+			if err != nil {
+				return err
+			}
+			send(net.IP(p.SenderIP.AsSlice()), p.SenderHardwareAddr)
+			return nil
+		})
+	}
+
+	if config.IPv6Mode {
+		c, _, err := ndp.Listen(iface, ndp.LinkLocal)
+		if err != nil {
+			return fmt.Errorf("failed to dial NDP connection on interface %s: %w", ifaceName, err)
+		}
+		// the groups are joined with the addresses listed by the detector, as often as it lists them
+		joined := sets.New[netip.Addr]()
+		var lastJoin time.Time
+		readClaims(ifaceName, stopChan, doneWg, c.Close, c.SetReadDeadline, func() error {
+			if time.Since(lastJoin) >= duplicateAddressSyncInterval {
+				joinSolicitedNodeGroups(ifaceName, joined, addresses(), c.JoinGroup, c.LeaveGroup)
+				lastJoin = time.Now()
+			}
+			msg, _, src, err := c.ReadFrom()
+			if err != nil {
+				return err
+			}
+			switch m := msg.(type) {
+			case *ndp.NeighborAdvertisement:
+				send(net.IP(m.TargetAddress.AsSlice()), linkLayerAddress(m.Options, ndp.Target))
+			case *ndp.NeighborSolicitation:
+				send(net.IP(src.AsSlice()), linkLayerAddress(m.Options, ndp.Source))
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// joinSolicitedNodeGroups joins the solicited-node multicast groups of the IPv6 addresses and leaves the groups
+// of joined no longer needed: the neighbor solicitations of an address are sent to its group rather than to all
+// the nodes, and the node only receives the groups of its own addresses otherwise. joined is updated with the
+// groups the interface is a member of, the groups failing to be joined or left are retried on the next call.
+func joinSolicitedNodeGroups(ifaceName string, joined sets.Set[netip.Addr], addresses []net.IP,
+	join, leave func(netip.Addr) error) {
+	groups := sets.New[netip.Addr]()
+	for _, ip := range addresses {
+		if ip.To4() != nil {
+			continue
+		}
+		addr, ok := netip.AddrFromSlice(ip)
+		if !ok {
+			continue
+		}
+		group, err := ndp.SolicitedNodeMulticast(addr)
+		if err != nil {
+			klog.V(5).Infof("Not joining the solicited-node multicast group of %s on %s: %v", ip, ifaceName, err)
+			continue
+		}
+		groups.Insert(group)
+	}
+	for group := range groups.Difference(joined) {
+		if err := join(group); err != nil {
+			klog.Errorf("Failed to join the solicited-node multicast group %s on %s: %v", group, ifaceName, err)
+			continue
+		}
+		joined.Insert(group)
+	}
+	for group := range joined.Difference(groups) {
+		if err := leave(group); err != nil {
+			klog.Errorf("Failed to leave the solicited-node multicast group %s on %s: %v", group, ifaceName, err)
+			continue
+		}
+		joined.Delete(group)
+	}
+}
+
+// readClaims calls read until stopChan is closed, then closes the connection
+func readClaims(ifaceName string, stopChan <-chan struct{}, doneWg *sync.WaitGroup, close func() error,
+	setReadDeadline func(time.Time) error, read func() error) {
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		defer close()
+		for {
+			select {
+			case <-stopChan:
+				return
+			default:
+			}
+			if err := setReadDeadline(time.Now().Add(duplicateAddressReadTimeout)); err != nil {
+				klog.Errorf("Failed to set the read deadline of the address claims on %s: %v", ifaceName, err)
+				return
+			}
+			if err := read(); err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+				klog.V(5).Infof("Failed to read the address claims on %s: %v", ifaceName, err)
+			}
+		}
+	}()
+}
+
+// linkLayerAddress returns the link layer address option of the direction of a neighbor discovery message
+func linkLayerAddress(options []ndp.Option, direction ndp.Direction) net.HardwareAddr {
+	for _, option := range options {
+		if lla, ok := option.(*ndp.LinkLayerAddress); ok && lla.Direction == direction {
+			return lla.Addr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package node
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+)
+
+// fakeClaimListener hands the claims channel of the detector to the test
+type fakeClaimListener struct {
+	claims chan chan<- addressClaim
+}
+
+func (l *fakeClaimListener) listen(_ string, claims chan<- addressClaim, _ func() []net.IP, _ <-chan struct{},
+	_ *sync.WaitGroup) error {
+	l.claims <- claims
+	return nil
+}
+
+var _ = Describe("Duplicate address detector", func() {
+	const (
+		nodeName = "node1"
+		localMAC = "0a:58:0a:f4:00:01"
+		otherMAC = "0a:58:0a:f4:00:99"
+	)
+
+	var (
+		d        *duplicateAddressDetector
+		recorder *record.FakeRecorder
+		pods     []*kapi.Pod
+		eips     []*egressipv1.EgressIP
+	)
+
+	claim := func(ip, mac string) addressClaim {
+		hwAddr, err := net.ParseMAC(mac)
+		Expect(err).NotTo(HaveOccurred())
+		return addressClaim{ip: net.ParseIP(ip), mac: hwAddr, ifaceName: "breth0"}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		pods = []*kapi.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client"},
+			Status:     kapi.PodStatus{Phase: kapi.PodRunning, PodIPs: []kapi.PodIP{{IP: "10.244.0.5"}}},
+		}}
+		eips = []*egressipv1.EgressIP{{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip"},
+			Status: egressipv1.EgressIPStatus{Items: []egressipv1.EgressIPStatusItem{
+				{Node: nodeName, EgressIP: "172.18.0.100"},
+				{Node: "node2", EgressIP: "172.18.0.101"},
+			}},
+		}}
+		recorder = record.NewFakeRecorder(10)
+		d = &duplicateAddressDetector{
+			nodeName:      nodeName,
+			bridges:       []string{"breth0"},
+			listPods:      func() ([]*kapi.Pod, error) { return pods, nil },
+			listEgressIPs: func() ([]*egressipv1.EgressIP, error) { return eips, nil },
+			listLocalMACs: func() (sets.Set[string], error) { return sets.New(localMAC), nil },
+			recorder:      recorder,
+			nodeRef:       &kapi.ObjectReference{Kind: "Node", Name: nodeName},
+			owners:        map[string]string{},
+			localMACs:     sets.New[string](),
+			reported:      map[string]time.Time{},
+		}
+		Expect(d.sync()).To(Succeed())
+	})
+
+	It("reports the addresses of the node claimed by other MACs", func() {
+		now := time.Now()
+		d.checkClaim(claim("10.244.0.5", otherMAC), now)
+		Expect(<-recorder.Events).To(And(ContainSubstring(duplicateAddressEventReason),
+			ContainSubstring("IP 10.244.0.5 of pod ns/client is also claimed by MAC "+otherMAC+" on breth0")))
+		d.checkClaim(claim("172.18.0.100", otherMAC), now)
+		Expect(<-recorder.Events).To(ContainSubstring("IP 172.18.0.100 of egress IP eip is also claimed by MAC " + otherMAC))
+		d.checkClaim(claim(config.Gateway.MasqueradeIPs.V4HostMasqueradeIP.String(), otherMAC), now)
+		Expect(<-recorder.Events).To(ContainSubstring("of masquerade IP is also claimed by MAC " + otherMAC))
+
+		// claimed by the node or not an address of the node
+		d.checkClaim(claim("10.244.0.5", localMAC), now)
+		d.checkClaim(claim("172.18.0.101", otherMAC), now)
+		d.checkClaim(claim("10.244.0.6", otherMAC), now)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("reports a conflict again after the report interval", func() {
+		now := time.Now()
+		d.checkClaim(claim("10.244.0.5", otherMAC), now)
+		Expect(recorder.Events).To(HaveLen(1))
+		d.checkClaim(claim("10.244.0.5", otherMAC), now.Add(time.Minute))
+		Expect(recorder.Events).To(HaveLen(1))
+		d.checkClaim(claim("10.244.0.5", otherMAC), now.Add(duplicateAddressReportInterval))
+		Expect(recorder.Events).To(HaveLen(2))
+	})
+
+	It("forgets the conflicts of the addresses no longer on the node", func() {
+		now := time.Now()
+		d.checkClaim(claim("10.244.0.5", otherMAC), now)
+		Expect(recorder.Events).To(HaveLen(1))
+		pods = nil
+		Expect(d.sync()).To(Succeed())
+		Expect(d.reported).To(BeEmpty())
+		d.checkClaim(claim("10.244.0.5", otherMAC), now)
+		Expect(recorder.Events).To(HaveLen(1))
+	})
+
+	It("checks the claims received on the bridges", func() {
+		listener := &fakeClaimListener{claims: make(chan chan<- addressClaim, 1)}
+		d.listener = listener
+		stopChan := make(chan struct{})
+		wg := &sync.WaitGroup{}
+		defer func() {
+			close(stopChan)
+			wg.Wait()
+		}()
+		Expect(d.Run(stopChan, wg)).To(Succeed())
+		claims := <-listener.claims
+		claims <- claim("172.18.0.100", otherMAC)
+		Eventually(recorder.Events).Should(Receive(ContainSubstring("IP 172.18.0.100 of egress IP eip")))
+	})
+
+	It("joins the solicited-node multicast groups of the IPv6 addresses", func() {
+		joined := sets.New[netip.Addr]()
+		var joins, leaves []string
+		failJoin := true
+		join := func(group netip.Addr) error {
+			if failJoin && group == netip.MustParseAddr("ff02::1:ff01:6") {
+				return errors.New("join failed")
+			}
+			joins = append(joins, group.String())
+			return nil
+		}
+		leave := func(group netip.Addr) error {
+			leaves = append(leaves, group.String())
+			return nil
+		}
+		ips := func(ips ...string) []net.IP {
+			parsed := make([]net.IP, 0, len(ips))
+			for _, ip := range ips {
+				parsed = append(parsed, net.ParseIP(ip))
+			}
+			return parsed
+		}
+
+		// the IPv4 addresses have no group and the addresses with the same low 24 bits share one
+		joinSolicitedNodeGroups("breth0", joined, ips("10.244.0.5", "fd00:10:244::5", "fd00:10:245::5",
+			"fd00:10:244::1:6"), join, leave)
+		Expect(joins).To(ConsistOf("ff02::1:ff00:5"))
+		Expect(joined.UnsortedList()).To(ConsistOf(netip.MustParseAddr("ff02::1:ff00:5")))
+
+		// the group failing to be joined is retried
+		failJoin = false
+		joins = nil
+		joinSolicitedNodeGroups("breth0", joined, ips("10.244.0.5", "fd00:10:244::5", "fd00:10:245::5",
+			"fd00:10:244::1:6"), join, leave)
+		Expect(joins).To(ConsistOf("ff02::1:ff01:6"))
+		Expect(leaves).To(BeEmpty())
+
+		// the groups of the addresses no longer watched are left
+		joins = nil
+		joinSolicitedNodeGroups("breth0", joined, ips("fd00:10:244::1:6"), join, leave)
+		Expect(joins).To(BeEmpty())
+		Expect(leaves).To(ConsistOf("ff02::1:ff00:5"))
+		Expect(joined.UnsortedList()).To(ConsistOf(netip.MustParseAddr("ff02::1:ff01:6")))
+	})
+})
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// nodeEgressRole returns the egress role of the node: the egress role annotation of the node overrides the
+// configured egress role, an invalid annotation is ignored and the node is an egress node when neither is set
+func nodeEgressRole(node *kapi.Node) string {
+	role, err := util.ParseNodeEgressRole(node)
+	if err == nil {
+		return role
+	}
+	if !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Ignoring the egress role of node %s: %v", node.Name, err)
+	}
+	if config.OvnKubeNode.EgressRole != "" {
+		return config.OvnKubeNode.EgressRole
+	}
+	return types.NodeEgressRoleEgress
+}
+
+// egressEnabled returns whether an egress feature run by ovnkube-node is enabled
+func egressEnabled() bool {
+	return config.OVNKubernetesFeature.EnableEgressIP || config.OVNKubernetesFeature.EnableEgressService
+}
+
+// setNodeEgressReadyLabel reflects to the cluster controllers whether the egress controllers run on the node
+func setNodeEgressReadyLabel(k kube.Interface, nodeName string, ready bool) error {
+	return k.SetLabelsOnNode(nodeName, map[string]interface{}{util.OvnNodeEgressReadyLabel: strconv.FormatBool(ready)})
+}
+
+// egressRoleSyncPeriod is how often the egress role of the node is synced, which retries the failed changes
+const egressRoleSyncPeriod = time.Minute
+
+// egressRoleWatcher applies the changes of the egress role of the node: the egress subsystems are stopped when
+// the node becomes a non-egress node and started again when it becomes an egress node, and the egress ready
+// label of the node follows them.
+type egressRoleWatcher struct {
+	nodeName     string
+	kube         kube.Interface
+	watchFactory factory.NodeWatchFactory
+	// reconcile applies the egress role to the egress subsystems
+	reconcile  func() error
+	egressNode atomic.Bool
+	trigger    chan struct{}
+}
+
+func newEgressRoleWatcher(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory,
+	node *kapi.Node) *egressRoleWatcher {
+	w := &egressRoleWatcher{
+		nodeName:     nodeName,
+		kube:         k,
+		watchFactory: watchFactory,
+		trigger:      make(chan struct{}, 1),
+	}
+	w.egressNode.Store(nodeEgressRole(node) == types.NodeEgressRoleEgress)
+	return w
+}
+
+// isEgressNode returns whether the node is an egress node
+func (w *egressRoleWatcher) isEgressNode() bool {
+	return w.egressNode.Load()
+}
+
+// Run watches the egress role of the node, reconcile applies its changes to the egress subsystems. The egress
+// ready label is set on the first sync, a failure to set it is retried by the next syncs.
+func (w *egressRoleWatcher) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup, reconcile func() error) error {
+	w.reconcile = reconcile
+	_, err := w.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == w.nodeName &&
+				(oldNode.Annotations[util.OvnNodeEgressRole] != newNode.Annotations[util.OvnNodeEgressRole] ||
+					oldNode.Labels[util.OvnNodeEgressReadyLabel] != newNode.Labels[util.OvnNodeEgressReadyLabel]) {
+				w.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the egress role: %w", err)
+	}
+	runPeriodicSync(stopChan, doneWg, egressRoleSyncPeriod, w.trigger, func() {
+		if err := w.sync(); err != nil {
+			klog.Errorf("Failed to apply the egress role of node %s: %v", w.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (w *egressRoleWatcher) requestSync() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync applies the egress role of the node to the egress subsystems and to the egress ready label
+func (w *egressRoleWatcher) sync() error {
+	node, err := w.watchFactory.GetNode(w.nodeName)
+	if err != nil {
+		return err
+	}
+	egressNode := nodeEgressRole(node) == types.NodeEgressRoleEgress
+	if w.egressNode.Swap(egressNode) != egressNode {
+		klog.Infof("Node %s egress role changed, egress node: %v", w.nodeName, egressNode)
+	}
+	if err := w.reconcile(); err != nil {
+		return err
+	}
+	if node.Labels[util.OvnNodeEgressReadyLabel] == strconv.FormatBool(egressNode) {
+		return nil
+	}
+	return setNodeEgressReadyLabel(w.kube, w.nodeName, egressNode)
+}
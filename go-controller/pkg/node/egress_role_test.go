@@ -0,0 +1,120 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node egress role", func() {
+	newEgressRoleNode := func(role string) *kapi.Node {
+		node := &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: map[string]string{}}}
+		if role != "" {
+			node.Annotations[util.OvnNodeEgressRole] = role
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	It("is egress unless configured or annotated otherwise", func() {
+		Expect(nodeEgressRole(newEgressRoleNode(""))).To(Equal(types.NodeEgressRoleEgress))
+		Expect(nodeEgressRole(newEgressRoleNode(types.NodeEgressRoleNonEgress))).To(Equal(types.NodeEgressRoleNonEgress))
+	})
+
+	It("overrides the configured egress role with the one of the node annotation", func() {
+		config.OvnKubeNode.EgressRole = types.NodeEgressRoleNonEgress
+		Expect(nodeEgressRole(newEgressRoleNode(""))).To(Equal(types.NodeEgressRoleNonEgress))
+		Expect(nodeEgressRole(newEgressRoleNode(types.NodeEgressRoleEgress))).To(Equal(types.NodeEgressRoleEgress))
+		// an invalid annotation is ignored
+		Expect(nodeEgressRole(newEgressRoleNode("gateway"))).To(Equal(types.NodeEgressRoleNonEgress))
+	})
+	It("applies the changes of the egress role of the node", func() {
+		node := newEgressRoleNode("")
+		kubeFakeClient := fake.NewSimpleClientset(node)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, node.Name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		w := newEgressRoleWatcher(node.Name, &kube.Kube{KClient: kubeFakeClient}, wf, node)
+		Expect(w.isEgressNode()).To(BeTrue())
+		var reconciled []bool
+		w.reconcile = func() error {
+			reconciled = append(reconciled, w.isEgressNode())
+			return nil
+		}
+		getEgressReadyLabel := func() string {
+			node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return node.Labels[util.OvnNodeEgressReadyLabel]
+		}
+		// setRole annotates the node with the egress role and waits for the informer to catch up with it
+		setRole := func(role string) {
+			node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			node.Annotations = map[string]string{util.OvnNodeEgressRole: role}
+			_, err = kubeFakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() string {
+				cached, err := wf.GetNode(node.Name)
+				Expect(err).NotTo(HaveOccurred())
+				return cached.Annotations[util.OvnNodeEgressRole]
+			}).Should(Equal(role))
+		}
+
+		setRole(types.NodeEgressRoleNonEgress)
+		Expect(w.sync()).To(Succeed())
+		Expect(w.isEgressNode()).To(BeFalse())
+		Expect(reconciled).To(Equal([]bool{false}))
+		Expect(getEgressReadyLabel()).To(Equal("false"))
+
+		setRole(types.NodeEgressRoleEgress)
+		Expect(w.sync()).To(Succeed())
+		Expect(w.isEgressNode()).To(BeTrue())
+		Expect(reconciled).To(Equal([]bool{false, true}))
+		Expect(getEgressReadyLabel()).To(Equal("true"))
+	})
+
+	It("retries setting the egress ready label when it fails", func() {
+		node := newEgressRoleNode(types.NodeEgressRoleNonEgress)
+		kubeFakeClient := fake.NewSimpleClientset(node)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, node.Name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		failPatch := true
+		kubeFakeClient.PrependReactor("patch", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+			if failPatch {
+				return true, nil, errors.New("patch rejected")
+			}
+			return false, nil, nil
+		})
+		w := newEgressRoleWatcher(node.Name, &kube.Kube{KClient: kubeFakeClient}, wf, node)
+		w.reconcile = func() error { return nil }
+		Expect(w.sync()).To(MatchError("patch rejected"))
+
+		failPatch = false
+		Expect(w.sync()).To(Succeed())
+		updated, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Labels).To(HaveKeyWithValue(util.OvnNodeEgressReadyLabel, "false"))
+	})
+})
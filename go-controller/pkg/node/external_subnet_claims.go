@@ -0,0 +1,218 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// subnetClaimSyncPeriod is how often the rules of the claimed subnets are checked
+	subnetClaimSyncPeriod = time.Minute
+	// subnetClaimConflictEventReason is the reason of the node events reporting the rejected subnet claims
+	subnetClaimConflictEventReason = "ExternalSubnetClaimConflict"
+)
+
+// subnetClaimController lets the agents of other networks sharing the node, e.g. the tunnels of a multi-cluster
+// network, claim subnets with the k8s.ovn.org/external-subnet-claims node annotation. The traffic of the pods to
+// the claimed subnets leaves the node with the pod IPs for the agent to route it, and the routes ovnkube-node
+// manages to destinations within the claimed subnets are removed. The traffic of the pods to the control plane
+// VIPs of the gateway configuration leaves the node with the pod IPs as well.
+//
+// A claim overlapping the cluster, service, join or masquerade subnets, or the claim of another agent, is
+// rejected and reported as a node event: ovnkube-node keeps handling the subnets it owns, and the subnets two
+// agents claim are left to neither.
+type subnetClaimController struct {
+	nodeName     string
+	nodeRef      *kapi.ObjectReference
+	recorder     record.EventRecorder
+	watchFactory factory.NodeWatchFactory
+	routeManager *routemanager.Controller
+	trigger      chan struct{}
+
+	// conflicts are the rejected claims last reported
+	conflicts []string
+	// excluded are the claimed subnets the route manager was last given
+	excluded []*net.IPNet
+}
+
+func newSubnetClaimController(nodeName string, recorder record.EventRecorder, watchFactory factory.NodeWatchFactory,
+	routeManager *routemanager.Controller) *subnetClaimController {
+	return &subnetClaimController{
+		nodeName: nodeName,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		recorder:     recorder,
+		watchFactory: watchFactory,
+		routeManager: routeManager,
+		trigger:      make(chan struct{}, 1),
+	}
+}
+
+func (c *subnetClaimController) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && (util.NodeExternalSubnetClaimsAnnotationChanged(oldNode, newNode) ||
+				util.NodeSubnetAnnotationChanged(oldNode, newNode)) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the external subnet claims: %w", err)
+	}
+
+	runPeriodicSync(stopChan, doneWg, subnetClaimSyncPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to sync the external subnet claims of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *subnetClaimController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *subnetClaimController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	claims, err := util.ParseNodeExternalSubnetClaims(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		return err
+	}
+	podSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		return fmt.Errorf("failed to parse the node subnets: %w", err)
+	}
+
+	accepted, conflicts := validateSubnetClaims(claims, ovnOwnedSubnets())
+	c.reportConflicts(conflicts)
+	// the traffic of the pods to the control plane VIPs, wherever they fail over, is not masqueraded either
+	destinations := append(append([]*net.IPNet{}, accepted...), config.Gateway.GetControlPlaneVIPs()...)
+	if err := syncSubnetClaimRules(podSubnets, destinations); err != nil {
+		return err
+	}
+	if c.routeManager != nil && !slices.Equal(util.StringSlice(c.excluded), util.StringSlice(accepted)) {
+		c.routeManager.SetExcludedSubnets(accepted)
+		c.excluded = accepted
+	}
+	return nil
+}
+
+// reportConflicts reports the rejected claims as a node event when they change
+func (c *subnetClaimController) reportConflicts(conflicts []string) {
+	if strings.Join(conflicts, "; ") == strings.Join(c.conflicts, "; ") {
+		return
+	}
+	c.conflicts = conflicts
+	if len(conflicts) == 0 {
+		klog.Infof("The external subnet claims of node %s have no conflict anymore", c.nodeName)
+		return
+	}
+	message := fmt.Sprintf("Rejected external subnet claims: %s", strings.Join(conflicts, "; "))
+	klog.Warning(message)
+	c.recorder.Event(c.nodeRef, kapi.EventTypeWarning, subnetClaimConflictEventReason, message)
+}
+
+// ownedSubnet is a subnet ovnkube-node handles, claims overlapping it are rejected
+type ownedSubnet struct {
+	name   string
+	subnet *net.IPNet
+}
+
+// ovnOwnedSubnets returns the cluster, service, join and masquerade subnets
+func ovnOwnedSubnets() []ownedSubnet {
+	var owned []ownedSubnet
+	for _, subnet := range config.Default.ClusterSubnets {
+		owned = append(owned, ownedSubnet{name: "cluster subnet", subnet: subnet.CIDR})
+	}
+	for _, subnet := range config.Kubernetes.ServiceCIDRs {
+		owned = append(owned, ownedSubnet{name: "service subnet", subnet: subnet})
+	}
+	for name, cidr := range map[string]string{
+		"join subnet":            config.Gateway.V4JoinSubnet,
+		"IPv6 join subnet":       config.Gateway.V6JoinSubnet,
+		"masquerade subnet":      config.Gateway.V4MasqueradeSubnet,
+		"IPv6 masquerade subnet": config.Gateway.V6MasqueradeSubnet,
+	} {
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+			owned = append(owned, ownedSubnet{name: name, subnet: subnet})
+		}
+	}
+	return owned
+}
+
+func subnetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// validateSubnetClaims returns the claimed subnets overlapping neither the owned subnets nor the subnets claimed
+// by the other agents, and the description of the rejected claims, both sorted
+func validateSubnetClaims(claims map[string][]*net.IPNet, owned []ownedSubnet) ([]*net.IPNet, []string) {
+	agents := make([]string, 0, len(claims))
+	for agent := range claims {
+		agents = append(agents, agent)
+	}
+	sort.Strings(agents)
+	var accepted []*net.IPNet
+	var conflicts []string
+	for _, agent := range agents {
+		for _, claim := range claims[agent] {
+			conflict := ""
+			for _, o := range owned {
+				if subnetsOverlap(claim, o.subnet) {
+					conflict = fmt.Sprintf("%s of agent %s overlaps %s %s", claim, agent, o.name, o.subnet)
+					break
+				}
+			}
+			for _, other := range agents {
+				if conflict != "" {
+					break
+				}
+				if other == agent {
+					continue
+				}
+				for _, otherClaim := range claims[other] {
+					if subnetsOverlap(claim, otherClaim) {
+						conflict = fmt.Sprintf("%s of agent %s overlaps %s of agent %s", claim, agent, otherClaim, other)
+						break
+					}
+				}
+			}
+			if conflict != "" {
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			accepted = append(accepted, claim)
+		}
+	}
+	sort.Slice(accepted, func(i, j int) bool { return accepted[i].String() < accepted[j].String() })
+	return accepted, conflicts
+}
@@ -0,0 +1,103 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("External subnet claims", func() {
+	var (
+		wf       *factory.WatchFactory
+		recorder *record.FakeRecorder
+		iptV4    util.IPTablesHelper
+	)
+
+	newController := func(claims string) *subnetClaimController {
+		client := fake.NewSimpleClientset(&kapi.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Annotations: map[string]string{
+				"k8s.ovn.org/node-subnets":       `{"default":["10.244.1.0/24"]}`,
+				util.OvnNodeExternalSubnetClaims: claims,
+			},
+		}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: client}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		return newSubnetClaimController("node1", recorder, wf, nil)
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.244.0.0/16"), HostSubnetLength: 24}}
+		recorder = record.NewFakeRecorder(10)
+		iptV4, _ = util.SetFakeIPTablesHelpers()
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	It("accepts the traffic of the pods to the claimed subnets without NAT", func() {
+		c := newController(`{"submariner":["10.2.0.0/16"]}`)
+		Expect(c.sync()).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+
+		rules, err := iptV4.List("nat", "POSTROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{"-A POSTROUTING -j " + iptableSubnetClaimChain}))
+		rules, err = iptV4.List("nat", iptableSubnetClaimChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(ConsistOf(
+			"-A "+iptableSubnetClaimChain+" -s 10.244.1.0/24 -d 10.2.0.0/16 -j ACCEPT",
+			"-A "+iptableSubnetClaimChain+" -s "+config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP.String()+" -d 10.2.0.0/16 -j ACCEPT",
+		))
+	})
+
+	It("accepts the traffic of the pods to the control plane VIPs without NAT", func() {
+		config.Gateway.ControlPlaneVIPs = "192.168.1.100"
+		c := newController(`{}`)
+		Expect(c.sync()).To(Succeed())
+
+		rules, err := iptV4.List("nat", iptableSubnetClaimChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(ConsistOf(
+			"-A "+iptableSubnetClaimChain+" -s 10.244.1.0/24 -d 192.168.1.100/32 -j ACCEPT",
+			"-A "+iptableSubnetClaimChain+" -s "+config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP.String()+" -d 192.168.1.100/32 -j ACCEPT",
+		))
+	})
+
+	It("rejects the claims overlapping the cluster subnets or the claims of other agents", func() {
+		c := newController(`{"a":["10.244.8.0/24","10.3.0.0/16"],"b":["10.3.1.0/24"],"c":["10.4.0.0/16"]}`)
+		Expect(c.sync()).To(Succeed())
+		Expect(c.conflicts).To(Equal([]string{
+			"10.244.8.0/24 of agent a overlaps cluster subnet 10.244.0.0/16",
+			"10.3.0.0/16 of agent a overlaps 10.3.1.0/24 of agent b",
+			"10.3.1.0/24 of agent b overlaps 10.3.0.0/16 of agent a",
+		}))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(subnetClaimConflictEventReason))
+
+		rules, err := iptV4.List("nat", iptableSubnetClaimChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(ConsistOf(
+			"-A "+iptableSubnetClaimChain+" -s 10.244.1.0/24 -d 10.4.0.0/16 -j ACCEPT",
+			"-A "+iptableSubnetClaimChain+" -s "+config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP.String()+" -d 10.4.0.0/16 -j ACCEPT",
+		))
+
+		// the same conflicts are reported once
+		Expect(c.sync()).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,92 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressip"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// featureCleanup removes the node rules of a feature once it is disabled
+type featureCleanup struct {
+	enabled func() bool
+	cleanup func() error
+}
+
+// featureCleanups are the cleanups of the features by the name they are cleaned up with
+var featureCleanups = map[string]featureCleanup{
+	"egress-ip": {
+		enabled: func() bool { return config.OVNKubernetesFeature.EnableEgressIP },
+		cleanup: egressip.Cleanup,
+	},
+	"egress-service": {
+		enabled: func() bool { return config.OVNKubernetesFeature.EnableEgressService },
+		cleanup: egressservice.Cleanup,
+	},
+	"etp-local-probe-path": {
+		enabled: func() bool { return config.OvnKubeNode.ETPLocalProbePath },
+		cleanup: cleanupProbePathRules,
+	},
+	"tcp-mss-clamp": {
+		enabled: func() bool { return config.Gateway.TCPMSSClamp },
+		cleanup: cleanupMSSClampRules,
+	},
+	"hybrid-overlay": {
+		enabled: func() bool { return config.HybridOverlay.Enabled },
+		cleanup: cleanupHybridOverlay,
+	},
+}
+
+// CleanupFeatureNames returns the names of the features CleanupFeature cleans up
+func CleanupFeatureNames() []string {
+	names := make([]string, 0, len(featureCleanups))
+	for name := range featureCleanups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CleanupFeature removes the iptables rules, ip rules, routes and OVS bridges and flows the feature configured
+// on the node, for when it is disabled or ovnkube-node is not running
+func CleanupFeature(feature string) error {
+	c, ok := featureCleanups[feature]
+	if !ok {
+		return fmt.Errorf("unknown feature %q, valid features are %v", feature, CleanupFeatureNames())
+	}
+	if err := c.cleanup(); err != nil {
+		return fmt.Errorf("failed to clean up feature %s: %w", feature, err)
+	}
+	return nil
+}
+
+// cleanupDisabledFeatures removes the node rules left by the features disabled since they were last enabled, the
+// cleanups do nothing for the features that were already disabled
+func cleanupDisabledFeatures() {
+	for _, name := range CleanupFeatureNames() {
+		if featureCleanups[name].enabled() {
+			continue
+		}
+		if err := CleanupFeature(name); err != nil {
+			klog.Errorf("Failed to remove the stale node rules of disabled feature %s: %v", name, err)
+		}
+	}
+}
+
+// cleanupHybridOverlay deletes the hybrid overlay bridge and its patch port on the integration bridge
+func cleanupHybridOverlay() error {
+	_, stderr, err := util.RunOVSVsctl("--if-exists", "del-br", "br-ext")
+	if err != nil {
+		return fmt.Errorf("deletion of bridge br-ext failed: %v (%v)", err, stderr)
+	}
+	_, stderr, err = util.RunOVSVsctl("--if-exists", "del-port", "br-int", "int")
+	if err != nil {
+		return fmt.Errorf("deletion of port int on br-int failed: %v (%v)", err, stderr)
+	}
+	return nil
+}
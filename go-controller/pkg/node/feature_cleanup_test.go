@@ -0,0 +1,74 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+var _ = Describe("Feature cleanup", func() {
+	var fexec *ovntest.FakeExec
+	origNetlinkInst := util.GetNetLinkOps()
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		util.SetNetLinkOpMockInst(origNetlinkInst)
+	})
+
+	It("rejects the unknown features", func() {
+		Expect(CleanupFeature("unknown")).To(MatchError(
+			`unknown feature "unknown", valid features are [egress-ip egress-service etp-local-probe-path hybrid-overlay tcp-mss-clamp]`))
+	})
+
+	It("only cleans up the disabled features", func() {
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		config.OVNKubernetesFeature.EnableEgressService = true
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists del-br br-ext",
+			"ovs-vsctl --timeout=15 --if-exists del-port br-int int",
+		})
+		cleanupDisabledFeatures()
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+	})
+
+	It("removes the egress IP iptables rules, ip rules and routes", func() {
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+		Expect(iptV4.NewChain("nat", "POSTROUTING")).To(Succeed())
+		Expect(iptV4.Append("nat", "POSTROUTING", "-j", "OVN-KUBE-EGRESS-IP-MULTI-NIC")).To(Succeed())
+		Expect(iptV4.NewChain("nat", "OVN-KUBE-EGRESS-IP-MULTI-NIC")).To(Succeed())
+		Expect(iptV4.Append("nat", "OVN-KUBE-EGRESS-IP-MULTI-NIC", "-s", "10.244.0.5/32", "-o", "eth1", "-j", "SNAT",
+			"--to-source", "192.168.1.5")).To(Succeed())
+
+		netlinkMock := &mocks.NetLinkOps{}
+		util.SetNetLinkOpMockInst(netlinkMock)
+		rule := netlink.NewRule()
+		rule.Priority = 6000
+		rule.Table = 1003
+		route := netlink.Route{Table: 1003, LinkIndex: 3}
+		netlinkMock.On("RuleListFiltered", netlink.FAMILY_V4, &netlink.Rule{Priority: 6000}, netlink.RT_FILTER_PRIORITY).
+			Return([]netlink.Rule{*rule}, nil)
+		netlinkMock.On("RuleListFiltered", mock.Anything, mock.Anything, netlink.RT_FILTER_PRIORITY).Return(nil, nil)
+		netlinkMock.On("RuleDel", rule).Return(nil)
+		netlinkMock.On("RouteListFiltered", netlink.FAMILY_V4, &netlink.Route{Table: 1003}, netlink.RT_FILTER_TABLE).
+			Return([]netlink.Route{route}, nil)
+		netlinkMock.On("RouteDel", &route).Return(nil)
+
+		Expect(CleanupFeature("egress-ip")).To(Succeed())
+		netlinkMock.AssertExpectations(GinkgoT())
+		exists, err := iptV4.Exists("nat", "POSTROUTING", "-j", "OVN-KUBE-EGRESS-IP-MULTI-NIC")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+		Expect(iptV4.ListChains("nat")).NotTo(ContainElement("OVN-KUBE-EGRESS-IP-MULTI-NIC"))
+	})
+})
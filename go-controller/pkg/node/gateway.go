@@ -10,6 +10,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/informer"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -30,7 +31,7 @@ import (
 type Gateway interface {
 	informer.ServiceAndEndpointsEventHandler
 	Init(<-chan struct{}, *sync.WaitGroup) error
-	Start()
+	Start() error
 	GetGatewayBridgeIface() string
 	SetDefaultGatewayBridgeMAC(addr net.HardwareAddr)
 	Reconcile() error
@@ -47,8 +48,16 @@ type gateway struct {
 	nodePortWatcher informer.ServiceAndEndpointsEventHandler
 	openflowManager *openflowManager
 	nodeIPManager   *addressManager
-	initFunc        func() error
-	readyFunc       func() (bool, error)
+	// ipv6RAManager applies the IPv6 router advertisement policy to the gateway bridge
+	ipv6RAManager *ipv6RAManager
+	// gatewayIntentPublisher publishes the gateway intent of the DPU host, DPU host mode only
+	gatewayIntentPublisher *gatewayIntentPublisher
+	// gatewayIntentController applies the gateway intent of the DPU host, DPU mode only
+	gatewayIntentController *gatewayIntentController
+	// announcer announces the service ingress IPs the node was selected for, when service announcement is enabled
+	announcer *gatewayAnnouncer
+	initFunc  func() error
+	readyFunc func() (bool, error)
 
 	servicesRetryFramework *retry.RetryFramework
 
@@ -248,15 +257,39 @@ func (g *gateway) Init(stopChan <-chan struct{}, wg *sync.WaitGroup) error {
 	return nil
 }
 
-func (g *gateway) Start() {
+func (g *gateway) Start() error {
 	if g.nodeIPManager != nil {
 		g.nodeIPManager.Run(g.stopChan, g.wg)
 	}
 
+	if g.ipv6RAManager != nil {
+		g.ipv6RAManager.Run(g.stopChan, g.wg)
+	}
+
+	if g.gatewayIntentPublisher != nil {
+		g.gatewayIntentPublisher.Run(g.stopChan, g.wg)
+	}
+
+	if g.gatewayIntentController != nil {
+		if err := g.gatewayIntentController.Run(g.stopChan, g.wg); err != nil {
+			return err
+		}
+	}
+
 	if g.openflowManager != nil {
 		klog.Info("Spawning Conntrack Rule Check Thread")
 		g.openflowManager.Run(g.stopChan, g.wg)
+		// allow admins to force a resync of the flows if they are suspected to have drifted
+		metrics.SetGatewayFlowResyncTrigger(g.openflowManager.requestFlowSync)
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			<-g.stopChan
+			// the stopped gateway must not be resynced anymore
+			metrics.SetGatewayFlowResyncTrigger(nil)
+		}()
 	}
+	return nil
 }
 
 // sets up an uplink interface for UDP Generic Receive Offload forwarding as part of
@@ -360,6 +393,10 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 		NodePortEnable: config.Gateway.NodeportEnable,
 		VLANID:         &config.Gateway.VLANID,
 	}
+	if config.Gateway.VLANSubInterface {
+		// the VLAN sub-interface the gateway bridge is built on tags the traffic
+		l3GwConfig.VLANID = nil
+	}
 	if egressGWBridge != nil {
 		l3GwConfig.EgressGWInterfaceID = egressGWBridge.interfaceID
 		l3GwConfig.EgressGWMACAddress = egressGWBridge.macAddress
@@ -381,6 +418,9 @@ func gatewayReady(patchPort string) (bool, error) {
 }
 
 func (g *gateway) GetGatewayBridgeIface() string {
+	if g.openflowManager == nil {
+		return ""
+	}
 	return g.openflowManager.getDefaultBridgeName()
 }
 
@@ -393,6 +433,11 @@ func getMaxFrameLength() int {
 func (g *gateway) SetDefaultGatewayBridgeMAC(macAddr net.HardwareAddr) {
 	g.openflowManager.setDefaultBridgeMAC(macAddr)
 	klog.Infof("Default gateway bridge MAC address updated to %s", macAddr)
+	if g.announcer != nil {
+		if err := g.announcer.refresh(); err != nil {
+			klog.Errorf("Failed to announce the service ingress IPs with the new gateway bridge MAC: %v", err)
+		}
+	}
 }
 
 // Reconcile handles triggering updates to different components of a gateway, like OFM, Services
@@ -427,7 +472,7 @@ func (g *gateway) addAllServices() []error {
 	} else {
 		for _, svc := range svcs {
 			svc := *svc
-			klog.V(5).Infof("Adding service %s/%s to retryServices", svc.Namespace, svc.Name)
+			gatewayLog.V(5).Infof("Adding service %s/%s to retryServices", svc.Namespace, svc.Name)
 			err = g.servicesRetryFramework.AddRetryObjWithAddNoBackoff(&svc)
 			if err != nil {
 				err = fmt.Errorf("failed to add service %s/%s to retry framework: %w", svc.Namespace, svc.Name, err)
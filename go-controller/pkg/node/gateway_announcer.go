@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// gatewayAnnouncer claims the service ingress IPs the node was selected to announce on the
+// L2 network of the gateway bridge. The ARP and NDP requests received on the physical port
+// for them are answered with the bridge MAC by OpenFlow, and a gratuitous ARP or an
+// unsolicited neighbor advertisement is sent when the node takes over an IP, so that the
+// neighbors update their caches without waiting for them to expire. The IPs are announced again
+// when the bridge MAC changes.
+type gatewayAnnouncer struct {
+	sync.Mutex
+	ofm *openflowManager
+	// claimedIPs are the IPs the ARP and NDP requests are answered for, whether they were advertised or not
+	claimedIPs sets.Set[string]
+	// ips are the claimed IPs advertised to the neighbors
+	ips sets.Set[string]
+	// announcedMAC is the bridge MAC the IPs are announced with
+	announcedMAC string
+	advertiser   ipAdvertiser
+}
+
+// ipAdvertiser advertises the IPs the node takes over to its neighbors
+type ipAdvertiser interface {
+	// advertise sends a gratuitous ARP or an unsolicited neighbor advertisement for ip out of the interface
+	advertise(ip net.IP, ifaceName string) error
+}
+
+// neighborAdvertiser advertises the IPs on the wire
+type neighborAdvertiser struct{}
+
+func (neighborAdvertiser) advertise(ip net.IP, ifaceName string) error {
+	return util.AdvertiseIP(ip, ifaceName)
+}
+
+func newGatewayAnnouncer(ofm *openflowManager, advertiser ipAdvertiser) *gatewayAnnouncer {
+	return &gatewayAnnouncer{
+		ofm:        ofm,
+		claimedIPs: sets.New[string](),
+		ips:        sets.New[string](),
+		advertiser: advertiser,
+	}
+}
+
+func announcerFlowsKey(ip string) string {
+	return "announce_" + ip
+}
+
+// SetAnnouncedIPs implements serviceannouncement.Announcer
+func (a *gatewayAnnouncer) SetAnnouncedIPs(ips sets.Set[string]) error {
+	a.Lock()
+	defer a.Unlock()
+	return a.setAnnouncedIPs(ips)
+}
+
+// refresh announces the claimed IPs again if the bridge MAC changed since they were announced, and retries
+// the failed advertisements
+func (a *gatewayAnnouncer) refresh() error {
+	a.Lock()
+	defer a.Unlock()
+	return a.setAnnouncedIPs(a.claimedIPs.Clone())
+}
+
+func (a *gatewayAnnouncer) setAnnouncedIPs(ips sets.Set[string]) error {
+	for _, ip := range sets.List(a.claimedIPs.Difference(ips)) {
+		klog.Infof("Stopping the announcement of service ingress IP %s", ip)
+		a.ofm.deleteFlowsByKey(announcerFlowsKey(ip))
+		a.claimedIPs.Delete(ip)
+		a.ips.Delete(ip)
+	}
+
+	a.ofm.defaultBridge.Lock()
+	ofPortPhys := a.ofm.defaultBridge.ofPortPhys
+	bridgeMAC := a.ofm.defaultBridge.macAddress.String()
+	bridgeName := a.ofm.defaultBridge.bridgeName
+	a.ofm.defaultBridge.Unlock()
+	if bridgeMAC != a.announcedMAC {
+		if a.claimedIPs.Len() > 0 {
+			klog.Infof("The MAC of %s changed to %s, announcing the service ingress IPs again", bridgeName, bridgeMAC)
+		}
+		a.claimedIPs = sets.New[string]()
+		a.ips = sets.New[string]()
+		a.announcedMAC = bridgeMAC
+	}
+
+	for _, ip := range sets.List(ips.Difference(a.claimedIPs)) {
+		a.ofm.updateFlowCacheEntry(announcerFlowsKey(ip), generateAnnouncerFlows(ip, ofPortPhys, bridgeMAC))
+		a.claimedIPs.Insert(ip)
+	}
+	a.ofm.requestFlowSync()
+
+	var errs []error
+	for _, ip := range sets.List(ips.Difference(a.ips)) {
+		klog.Infof("Announcing service ingress IP %s on %s", ip, bridgeName)
+		// keep the IP out of the announced ones on failure so that it is advertised again on the next sync
+		if err := a.advertiser.advertise(net.ParseIP(ip), bridgeName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to advertise service ingress IP %s on %s: %w", ip, bridgeName, err))
+			continue
+		}
+		a.ips.Insert(ip)
+	}
+	return utilerrors.Join(errs...)
+}
+
+// generateAnnouncerFlows returns the flows answering the ARP or NDP requests received on the
+// physical port for the IP with the bridge MAC. They take precedence over the ARP bypass flow
+// of the service.
+func generateAnnouncerFlows(ip, ofPortPhys, bridgeMAC string) []string {
+	if utilnet.IsIPv6String(ip) {
+		return []string{
+			fmt.Sprintf("cookie=%s, priority=111, in_port=%s, icmp6, icmp_type=135, icmp_code=0, nd_target=%s, "+
+				"actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[], mod_dl_src:%s, "+
+				"move:NXM_NX_IPV6_SRC[]->NXM_NX_IPV6_DST[], set_field:%s->ipv6_src, set_field:255->nw_ttl, "+
+				"set_field:136->icmp_type, set_field:0x60000000->nd_reserved, set_field:2->nd_options_type, "+
+				"set_field:%s->nd_tll, IN_PORT",
+				defaultOpenFlowCookie, ofPortPhys, ip, bridgeMAC, ip, bridgeMAC),
+		}
+	}
+	return []string{
+		fmt.Sprintf("cookie=%s, priority=111, in_port=%s, arp, arp_op=1, arp_tpa=%s, "+
+			"actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[], mod_dl_src:%s, load:0x2->NXM_OF_ARP_OP[], "+
+			"move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[], move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[], "+
+			"set_field:%s->arp_sha, set_field:%s->arp_spa, IN_PORT",
+			defaultOpenFlowCookie, ofPortPhys, ip, bridgeMAC, bridgeMAC, ip),
+	}
+}
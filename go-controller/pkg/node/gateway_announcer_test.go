@@ -0,0 +1,145 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fakeAdvertiser records the advertised IPs and fails to advertise the failing ones
+type fakeAdvertiser struct {
+	t          *testing.T
+	failing    sets.Set[string]
+	advertised []string
+}
+
+func (f *fakeAdvertiser) advertise(ip net.IP, ifaceName string) error {
+	if ifaceName != "breth0" {
+		f.t.Fatalf("Advertised %s on %s, expected breth0", ip, ifaceName)
+	}
+	if f.failing.Has(ip.String()) {
+		return fmt.Errorf("failed")
+	}
+	f.advertised = append(f.advertised, ip.String())
+	return nil
+}
+
+func TestGatewayAnnouncerSetAnnouncedIPs(t *testing.T) {
+	mac, _ := net.ParseMAC("0a:58:0a:f4:00:01")
+	ofm := &openflowManager{
+		defaultBridge: &bridgeConfiguration{
+			bridgeName: "breth0",
+			macAddress: mac,
+			ofPortPhys: "1",
+		},
+		flowCache: map[string][]string{},
+		flowChan:  make(chan struct{}, 1),
+	}
+	advertiser := &fakeAdvertiser{t: t, failing: sets.New[string]()}
+	a := newGatewayAnnouncer(ofm, advertiser)
+
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1", "fd00::1")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !sets.New(advertiser.advertised...).Equal(sets.New("192.168.10.1", "fd00::1")) {
+		t.Fatalf("Expected both IPs to be advertised, got %v", advertiser.advertised)
+	}
+	if len(ofm.flowCache[announcerFlowsKey("192.168.10.1")]) != 1 || len(ofm.flowCache[announcerFlowsKey("fd00::1")]) != 1 {
+		t.Fatalf("Expected responder flows for both IPs, got %v", ofm.flowCache)
+	}
+
+	// announced IPs are not advertised again, a failed advertisement is retried on the next call
+	advertiser.advertised = nil
+	advertiser.failing.Insert("192.168.10.2")
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1", "192.168.10.2")); err == nil {
+		t.Fatalf("Expected an error for the failed advertisement")
+	}
+	if _, ok := ofm.flowCache[announcerFlowsKey("fd00::1")]; ok {
+		t.Fatalf("Expected the responder flows of fd00::1 to be removed")
+	}
+	advertiser.failing.Delete("192.168.10.2")
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1", "192.168.10.2")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(advertiser.advertised) != 1 || advertiser.advertised[0] != "192.168.10.2" {
+		t.Fatalf("Expected only 192.168.10.2 to be advertised again, got %v", advertiser.advertised)
+	}
+	if !a.ips.Equal(sets.New("192.168.10.1", "192.168.10.2")) {
+		t.Fatalf("Unexpected announced IPs %v", sets.List(a.ips))
+	}
+}
+
+func TestGatewayAnnouncerBridgeMACChange(t *testing.T) {
+	mac, _ := net.ParseMAC("0a:58:0a:f4:00:01")
+	ofm := &openflowManager{
+		defaultBridge: &bridgeConfiguration{
+			bridgeName: "breth0",
+			macAddress: mac,
+			ofPortPhys: "1",
+		},
+		flowCache: map[string][]string{},
+		flowChan:  make(chan struct{}, 1),
+	}
+	advertiser := &fakeAdvertiser{t: t, failing: sets.New[string]()}
+	a := newGatewayAnnouncer(ofm, advertiser)
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// nothing is announced again while the MAC does not change
+	advertiser.advertised = nil
+	if err := a.refresh(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(advertiser.advertised) != 0 {
+		t.Fatalf("Expected no advertisement, got %v", advertiser.advertised)
+	}
+
+	newMAC, _ := net.ParseMAC("0a:58:0a:f4:00:02")
+	ofm.setDefaultBridgeMAC(newMAC)
+	if err := a.refresh(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(advertiser.advertised) != 1 || advertiser.advertised[0] != "192.168.10.1" {
+		t.Fatalf("Expected 192.168.10.1 to be advertised again, got %v", advertiser.advertised)
+	}
+	flows := ofm.flowCache[announcerFlowsKey("192.168.10.1")]
+	if len(flows) != 1 || !strings.Contains(flows[0], "mod_dl_src:0a:58:0a:f4:00:02") {
+		t.Fatalf("Expected the responder flows to use the new MAC, got %v", flows)
+	}
+}
+
+func TestGatewayAnnouncerWithdrawFailedIP(t *testing.T) {
+	mac, _ := net.ParseMAC("0a:58:0a:f4:00:01")
+	ofm := &openflowManager{
+		defaultBridge: &bridgeConfiguration{
+			bridgeName: "breth0",
+			macAddress: mac,
+			ofPortPhys: "1",
+		},
+		flowCache: map[string][]string{},
+		flowChan:  make(chan struct{}, 1),
+	}
+	advertiser := &fakeAdvertiser{t: t, failing: sets.New("192.168.10.2")}
+	a := newGatewayAnnouncer(ofm, advertiser)
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1", "192.168.10.2")); err == nil {
+		t.Fatalf("Expected an error for the failed advertisement")
+	}
+	if len(ofm.flowCache[announcerFlowsKey("192.168.10.2")]) != 1 {
+		t.Fatalf("Expected responder flows for the IP that failed to be advertised, got %v", ofm.flowCache)
+	}
+
+	// the responder flows of an IP withdrawn before it was ever advertised are removed too
+	if err := a.SetAnnouncedIPs(sets.New("192.168.10.1")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := ofm.flowCache[announcerFlowsKey("192.168.10.2")]; ok {
+		t.Fatalf("Expected the responder flows of 192.168.10.2 to be removed, got %v", ofm.flowCache)
+	}
+	if !a.claimedIPs.Equal(sets.New("192.168.10.1")) || !a.ips.Equal(sets.New("192.168.10.1")) {
+		t.Fatalf("Unexpected claimed IPs %v and announced IPs %v", sets.List(a.claimedIPs), sets.List(a.ips))
+	}
+}
@@ -0,0 +1,274 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// namespaceEgressRateLimiter limits the egress rate of the local pods of the namespaces annotated with
+// k8s.ovn.org/egress-rate, with one drop meter of the gateway bridge per namespace applied in table 0 to the
+// traffic of their pod IPs coming from the patch port. The rate is aggregated over all the pods of the namespace
+// on the node, unlike the kubernetes.io/egress-bandwidth annotation that shapes each pod on its own.
+//
+// It is only effective with disable-snat-multiple-gws, where the traffic of the pods leaves OVN with the pod IPs.
+// The metered traffic is marked in reg2 and resubmitted to table 0 for the other flows of the bridge.
+type namespaceEgressRateLimiter struct {
+	ofm *openflowManager
+	wf  factory.NodeWatchFactory
+	// meterStats returns the stats of the meters of the bridge by meter ID
+	meterStats func(bridgeName string) (map[uint32]meterStats, error)
+	// trigger requests a sync of the limits
+	trigger chan struct{}
+	// meters are the meter IDs of the rate limited namespaces, only accessed by sync
+	meters map[string]uint32
+}
+
+// meterStats are the stats of the drop band of a meter
+type meterStats struct {
+	droppedPackets uint64
+	droppedBytes   uint64
+}
+
+// namespaceEgressRateSyncInterval is how often the limits are synced, which also refreshes the drop metrics and
+// picks up the changes of the gateway bridge ports
+const namespaceEgressRateSyncInterval = 30 * time.Second
+
+func newNamespaceEgressRateLimiter(ofm *openflowManager, wf factory.NodeWatchFactory) *namespaceEgressRateLimiter {
+	return &namespaceEgressRateLimiter{
+		ofm:        ofm,
+		wf:         wf,
+		meterStats: getMeterStats,
+		trigger:    make(chan struct{}, 1),
+		meters:     map[string]uint32{},
+	}
+}
+
+// Start installs the egress rate limits of the namespaces and keeps them in sync
+func (l *namespaceEgressRateLimiter) Start(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) {
+			l.requestSync()
+		},
+		UpdateFunc: func(interface{}, interface{}) {
+			l.requestSync()
+		},
+		DeleteFunc: func(interface{}) {
+			l.requestSync()
+		},
+	}
+	if _, err := l.wf.AddNamespaceHandler(handler, nil); err != nil {
+		return err
+	}
+	if _, err := l.wf.AddPodHandler(handler, nil); err != nil {
+		return err
+	}
+	runPeriodicSync(stopChan, doneWg, namespaceEgressRateSyncInterval, l.trigger, func() {
+		if err := l.sync(); err != nil {
+			klog.Errorf("Failed to sync the namespace egress rate limits: %v", err)
+		}
+	})
+	return nil
+}
+
+func (l *namespaceEgressRateLimiter) requestSync() {
+	select {
+	case l.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func namespaceEgressRateFlowsKey(namespace string) string {
+	return "EgressRate_" + namespace
+}
+
+// sync updates the meters and flows of the rate limited namespaces with local pods and reports their drops
+func (l *namespaceEgressRateLimiter) sync() error {
+	namespaces, err := l.wf.GetNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	pods, err := l.wf.GetAllPods()
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	podsByNamespace := map[string][]*kapi.Pod{}
+	for _, pod := range pods {
+		if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	l.ofm.defaultBridge.Lock()
+	bridgeName := l.ofm.defaultBridge.bridgeName
+	var ofPortPatch string
+	if netConfig, ok := l.ofm.defaultBridge.netConfig[types.DefaultNetworkName]; ok {
+		ofPortPatch = netConfig.ofPortPatch
+	}
+	l.ofm.defaultBridge.Unlock()
+
+	rates := map[string]uint64{}
+	if ofPortPatch != "" {
+		for _, ns := range namespaces {
+			rate, err := util.ParseNamespaceEgressRate(ns.Annotations)
+			if err != nil {
+				klog.Warningf("Not limiting the egress rate of namespace %s: %v", ns.Name, err)
+				continue
+			}
+			if rate > 0 && len(podsByNamespace[ns.Name]) > 0 {
+				rates[ns.Name] = rate
+			}
+		}
+	}
+
+	for namespace, id := range l.meters {
+		if _, ok := rates[namespace]; ok {
+			continue
+		}
+		l.ofm.deleteFlowsByKey(namespaceEgressRateFlowsKey(namespace))
+		l.ofm.deleteMeter(id)
+		delete(l.meters, namespace)
+		metrics.MetricNodeNamespaceEgressRateDroppedPackets.DeleteLabelValues(namespace)
+		metrics.MetricNodeNamespaceEgressRateDroppedBytes.DeleteLabelValues(namespace)
+	}
+	for namespace, rate := range rates {
+		id, ok := l.meters[namespace]
+		if !ok {
+			id = l.freeMeterID()
+			l.meters[namespace] = id
+		}
+		var flows []string
+		for _, pod := range podsByNamespace[namespace] {
+			ips, err := util.DefaultNetworkPodIPs(pod)
+			if err != nil {
+				klog.V(5).Infof("Not limiting the egress rate of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+			for _, ip := range ips {
+				flows = append(flows, generateEgressRateFlows(ip.String(), id, ofPortPatch)...)
+			}
+		}
+		// the meter is set first so that it is installed with the flows referring to it
+		l.ofm.setMeter(id, fmt.Sprintf("kbps,band=type=drop,rate=%d", rate))
+		l.ofm.updateFlowCacheEntry(namespaceEgressRateFlowsKey(namespace), flows)
+	}
+	l.ofm.requestFlowSync()
+
+	if len(l.meters) == 0 {
+		return nil
+	}
+	stats, err := l.meterStats(bridgeName)
+	if err != nil {
+		return fmt.Errorf("failed to get the meter stats of bridge %s: %w", bridgeName, err)
+	}
+	for namespace, id := range l.meters {
+		// the meters of the namespaces limited since the last flow sync are not installed yet
+		if s, ok := stats[id]; ok {
+			metrics.MetricNodeNamespaceEgressRateDroppedPackets.WithLabelValues(namespace).Set(float64(s.droppedPackets))
+			metrics.MetricNodeNamespaceEgressRateDroppedBytes.WithLabelValues(namespace).Set(float64(s.droppedBytes))
+		}
+	}
+	return nil
+}
+
+// freeMeterID returns the lowest meter ID not used by a namespace
+func (l *namespaceEgressRateLimiter) freeMeterID() uint32 {
+	used := make([]uint32, 0, len(l.meters))
+	for _, id := range l.meters {
+		used = append(used, id)
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i] < used[j] })
+	id := uint32(1)
+	for _, u := range used {
+		if u != id {
+			break
+		}
+		id++
+	}
+	return id
+}
+
+// generateEgressRateFlows returns the flows applying the meter to the traffic of the pod IP leaving OVN
+func generateEgressRateFlows(podIP string, meterID uint32, ofPortPatch string) []string {
+	isIPv6 := utilnet.IsIPv6String(podIP)
+	if (isIPv6 && !config.IPv6Mode) || (!isIPv6 && !config.IPv4Mode) {
+		return nil
+	}
+	ipPrefix := "ip"
+	if isIPv6 {
+		ipPrefix = "ipv6"
+	}
+	return []string{
+		// table 0, the traffic of the pod is metered once and goes through the other flows of the table
+		fmt.Sprintf("cookie=%s, priority=700, in_port=%s, reg2=0, %s, %s_src=%s, "+
+			"actions=meter:%d,load:0x1->NXM_NX_REG2[0],resubmit(,0)",
+			defaultOpenFlowCookie, ofPortPatch, ipPrefix, ipPrefix, podIP, meterID),
+	}
+}
+
+// getMeterStats returns the stats of the meters of the bridge by meter ID
+func getMeterStats(bridgeName string) (map[uint32]meterStats, error) {
+	stdout, stderr, err := util.RunOVSOfctl("-O", "OpenFlow13", "meter-stats", bridgeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the meter stats, stderr: %q: %w", stderr, err)
+	}
+	return parseMeterStats(stdout), nil
+}
+
+// parseMeterStats parses the output of ovs-ofctl meter-stats, the stats of a meter are those of its first band:
+//
+//	meter:1 flow_count:2 packet_in_count:100 byte_in_count:9800 duration:10.0s bands:
+//	0: packet_count:5 byte_count:490
+func parseMeterStats(output string) map[uint32]meterStats {
+	stats := map[uint32]meterStats{}
+	var id uint32
+	var inMeter bool
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "meter:") {
+			meterID, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "meter:"), 10, 32)
+			id, inMeter = uint32(meterID), err == nil
+			continue
+		}
+		if !inMeter || fields[0] != "0:" {
+			continue
+		}
+		var s meterStats
+		for _, field := range fields[1:] {
+			name, value, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			count, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch name {
+			case "packet_count":
+				s.droppedPackets = count
+			case "byte_count":
+				s.droppedBytes = count
+			}
+		}
+		stats[id] = s
+		inMeter = false
+	}
+	return stats
+}
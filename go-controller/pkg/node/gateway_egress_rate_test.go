@@ -0,0 +1,108 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Namespace egress rate limiter", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+	})
+
+	It("meters the traffic of the pod IPs leaving OVN", func() {
+		Expect(generateEgressRateFlows("10.244.0.5", 3, "2")).To(Equal([]string{
+			"cookie=" + defaultOpenFlowCookie + ", priority=700, in_port=2, reg2=0, ip, ip_src=10.244.0.5, " +
+				"actions=meter:3,load:0x1->NXM_NX_REG2[0],resubmit(,0)",
+		}))
+		Expect(generateEgressRateFlows("fd00:10:244::5", 3, "2")).To(Equal([]string{
+			"cookie=" + defaultOpenFlowCookie + ", priority=700, in_port=2, reg2=0, ipv6, ipv6_src=fd00:10:244::5, " +
+				"actions=meter:3,load:0x1->NXM_NX_REG2[0],resubmit(,0)",
+		}))
+		config.IPv6Mode = false
+		Expect(generateEgressRateFlows("fd00:10:244::5", 3, "2")).To(BeEmpty())
+	})
+
+	It("parses the drop band stats of the meters", func() {
+		output := `OFPST_METER reply (OF1.3) (xid=0x2):
+meter:1 flow_count:2 packet_in_count:100 byte_in_count:9800 duration:10.021s bands:
+0: packet_count:5 byte_count:490
+
+meter:2 flow_count:1 packet_in_count:0 byte_in_count:0 duration:1.002s bands:
+0: packet_count:0 byte_count:0
+`
+		Expect(parseMeterStats(output)).To(Equal(map[uint32]meterStats{
+			1: {droppedPackets: 5, droppedBytes: 490},
+			2: {},
+		}))
+	})
+
+	It("keeps a meter and the flows of the rate limited namespaces with local pods", func() {
+		namespace := &kapi.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "ns",
+			Annotations: map[string]string{util.NamespaceEgressRateAnnotation: "10M"},
+		}}
+		pod := &kapi.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client"},
+			Spec:       kapi.PodSpec{NodeName: "node1"},
+			Status: kapi.PodStatus{
+				Phase:  kapi.PodRunning,
+				PodIPs: []kapi.PodIP{{IP: "10.244.0.5"}, {IP: "fd00:10:244::5"}},
+			},
+		}
+		client := fake.NewSimpleClientset(namespace, pod)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: client}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		bridge := &bridgeConfiguration{
+			bridgeName: "breth0",
+			netConfig:  map[string]*bridgeUDNConfiguration{types.DefaultNetworkName: {ofPortPatch: "2"}},
+		}
+		ofm := &openflowManager{defaultBridge: bridge, flowCache: map[string][]string{}, flowChan: make(chan struct{}, 1)}
+		l := newNamespaceEgressRateLimiter(ofm, wf)
+		l.meterStats = func(string) (map[uint32]meterStats, error) {
+			return map[uint32]meterStats{1: {droppedPackets: 5, droppedBytes: 490}}, nil
+		}
+		Expect(l.sync()).To(Succeed())
+		Expect(l.meters).To(Equal(map[string]uint32{"ns": 1}))
+		Expect(ofm.meters).To(Equal(map[uint32]string{1: "kbps,band=type=drop,rate=10000"}))
+		Expect(ofm.flowCache[namespaceEgressRateFlowsKey("ns")]).To(HaveLen(2))
+
+		// the meter is installed before the flows referring to it, and deleted after them
+		fakeExec := ovntest.NewFakeExec()
+		Expect(util.SetExec(fakeExec)).To(Succeed())
+		fakeExec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-ofctl -O OpenFlow13 del-meters breth0",
+			"ovs-ofctl -O OpenFlow13 add-meter breth0 meter=1,kbps,band=type=drop,rate=10000",
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -",
+			"ovs-ofctl -O OpenFlow13 del-meter breth0 meter=1",
+		})
+		Expect(ofm.syncFlows()).To(BeTrue())
+		Expect(ofm.syncFlows()).To(BeTrue())
+
+		namespace.Annotations = nil
+		Expect(wf.NamespaceInformer().Informer().GetStore().Update(namespace)).To(Succeed())
+		Expect(l.sync()).To(Succeed())
+		Expect(l.meters).To(BeEmpty())
+		Expect(ofm.meters).To(BeEmpty())
+		Expect(ofm.flowCache).NotTo(HaveKey(namespaceEgressRateFlowsKey("ns")))
+		Expect(ofm.syncFlows()).To(BeTrue())
+		Expect(fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeExec.ErrorDesc())
+	})
+})
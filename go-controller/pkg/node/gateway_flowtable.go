@@ -0,0 +1,126 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// gatewayFlowtableInterval is how often the gateway flowtable is reconciled
+	gatewayFlowtableInterval = 30 * time.Second
+	// gatewayFlowtableTable is the nftables inet table holding the gateway flowtable and its forward chain
+	gatewayFlowtableTable = "ovn-kubernetes-fastpath"
+	// gatewayFlowtableName is the name of the flowtable offloading the established pod egress connections
+	gatewayFlowtableName = "fastpath"
+)
+
+// gatewayFlowtable offloads the established pod egress connections of the local gateway mode to an nftables
+// flowtable: once a connection from a management port out of the gateway bridge is established its packets
+// skip the host forwarding path in both directions.
+type gatewayFlowtable struct {
+	mgmtPortNames []string
+	bridgeName    string
+}
+
+func newGatewayFlowtable(mgmtPortNames []string, bridgeName string) *gatewayFlowtable {
+	return &gatewayFlowtable{
+		mgmtPortNames: mgmtPortNames,
+		bridgeName:    bridgeName,
+	}
+}
+
+func (f *gatewayFlowtable) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, gatewayFlowtableInterval, nil, func() {
+		if err := f.sync(); err != nil {
+			klog.Errorf("Failed to reconcile the gateway flowtable: %v", err)
+		}
+	})
+}
+
+// sync replaces the gateway flowtable table when it is missing or differs from the expected one
+func (f *gatewayFlowtable) sync() error {
+	current, _, err := util.RunNFT("list", "table", "inet", gatewayFlowtableTable)
+	if err == nil && f.inSync(current) {
+		return nil
+	}
+	klog.Infof("Setting up the gateway flowtable on %s", strings.Join(f.devices(), ", "))
+	// the table is flushed and recreated in a single transaction so the forwarding is never left without it
+	if _, stderr, err := util.RunNFT(f.script()); err != nil {
+		return fmt.Errorf("failed to set up the gateway flowtable, stderr: %q, error: %v", stderr, err)
+	}
+	return nil
+}
+
+// devices returns the sorted devices the flowtable is attached to
+func (f *gatewayFlowtable) devices() []string {
+	return sets.List(sets.New(f.mgmtPortNames...).Insert(f.bridgeName))
+}
+
+// rules returns the forward chain rules adding the established pod egress connections to the flowtable,
+// formatted the way nft lists them
+func (f *gatewayFlowtable) rules() []string {
+	rules := make([]string, 0, len(f.mgmtPortNames))
+	for _, mgmtPortName := range f.mgmtPortNames {
+		rules = append(rules, fmt.Sprintf("iifname %q oifname %q meta l4proto { tcp, udp } ct state established flow add @%s",
+			mgmtPortName, f.bridgeName, gatewayFlowtableName))
+	}
+	return rules
+}
+
+func (f *gatewayFlowtable) script() string {
+	commands := []string{
+		fmt.Sprintf("add table inet %s", gatewayFlowtableTable),
+		fmt.Sprintf("delete table inet %s", gatewayFlowtableTable),
+		fmt.Sprintf("add table inet %s", gatewayFlowtableTable),
+		fmt.Sprintf("add flowtable inet %s %s { hook ingress priority filter ; devices = { %s } ; }",
+			gatewayFlowtableTable, gatewayFlowtableName, strings.Join(f.devices(), ", ")),
+		fmt.Sprintf("add chain inet %s forward { type filter hook forward priority filter ; policy accept ; }",
+			gatewayFlowtableTable),
+	}
+	for _, rule := range f.rules() {
+		commands = append(commands, fmt.Sprintf("add rule inet %s forward %s", gatewayFlowtableTable, rule))
+	}
+	return strings.Join(commands, " ; ")
+}
+
+// inSync returns whether the listed gateway flowtable table has the expected devices and rules
+func (f *gatewayFlowtable) inSync(listed string) bool {
+	var devices []string
+	rules := sets.New[string]()
+	for _, line := range strings.Split(listed, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "devices = {") {
+			devices = strings.Split(strings.Trim(strings.TrimPrefix(line, "devices ="), " {}"), ",")
+			for i := range devices {
+				devices[i] = strings.TrimSpace(devices[i])
+			}
+			sort.Strings(devices)
+			continue
+		}
+		if strings.Contains(line, "flow add @") {
+			rules.Insert(line)
+		}
+	}
+	return strings.Join(devices, ",") == strings.Join(f.devices(), ",") && rules.Equal(sets.New(f.rules()...))
+}
+
+// deleteGatewayFlowtable removes the gateway flowtable left over by a previous run with the flowtable enabled
+func deleteGatewayFlowtable() error {
+	if _, _, err := util.RunNFT("list", "table", "inet", gatewayFlowtableTable); err != nil {
+		// there is no table to delete, or no nft to have ever created it
+		return nil
+	}
+	klog.Infof("Deleting the gateway flowtable")
+	if _, stderr, err := util.RunNFT("delete", "table", "inet", gatewayFlowtableTable); err != nil {
+		return fmt.Errorf("failed to delete the gateway flowtable, stderr: %q, error: %v", stderr, err)
+	}
+	return nil
+}
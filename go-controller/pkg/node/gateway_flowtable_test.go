@@ -0,0 +1,83 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Gateway flowtable", func() {
+	const (
+		listCmd = "nft list table inet ovn-kubernetes-fastpath"
+		listed  = `table inet ovn-kubernetes-fastpath {
+	flowtable fastpath {
+		hook ingress priority filter
+		devices = { ovn-k8s-mp0, breth0 }
+	}
+
+	chain forward {
+		type filter hook forward priority filter; policy accept;
+		iifname "ovn-k8s-mp0" oifname "breth0" meta l4proto { tcp, udp } ct state established flow add @fastpath
+	}
+}`
+	)
+
+	var (
+		f     *gatewayFlowtable
+		fexec *ovntest.FakeExec
+	)
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		f = newGatewayFlowtable([]string{"ovn-k8s-mp0"}, "breth0")
+	})
+
+	It("creates the flowtable on the management port and gateway bridge when it is missing", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    listCmd,
+			Stderr: "Error: No such file or directory",
+			Err:    fmt.Errorf("exit status 1"),
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "nft add table inet ovn-kubernetes-fastpath ; delete table inet ovn-kubernetes-fastpath ; " +
+				"add table inet ovn-kubernetes-fastpath ; " +
+				"add flowtable inet ovn-kubernetes-fastpath fastpath { hook ingress priority filter ; devices = { breth0, ovn-k8s-mp0 } ; } ; " +
+				"add chain inet ovn-kubernetes-fastpath forward { type filter hook forward priority filter ; policy accept ; } ; " +
+				`add rule inet ovn-kubernetes-fastpath forward iifname "ovn-k8s-mp0" oifname "breth0" ` +
+				"meta l4proto { tcp, udp } ct state established flow add @fastpath",
+		})
+		Expect(f.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("leaves the flowtable alone when it is in sync and replaces it when it is not", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listCmd, Output: listed})
+		Expect(f.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    listCmd,
+			Output: strings.Replace(listed, "ovn-k8s-mp0, breth0", "ovn-k8s-mp0", 1),
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: "nft " + f.script()})
+		Expect(f.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("deletes a leftover flowtable only when it exists", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listCmd, Err: fmt.Errorf("exit status 1")})
+		Expect(deleteGatewayFlowtable()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listCmd, Output: "table inet ovn-kubernetes-fastpath {\n}"})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: "nft delete table inet ovn-kubernetes-fastpath"})
+		Expect(deleteGatewayFlowtable()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"strings"
+	"slices"
 
 	"github.com/vishvananda/netlink"
 	"k8s.io/klog/v2"
@@ -14,6 +14,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	kapi "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -25,44 +26,13 @@ import (
 func bridgedGatewayNodeSetup(nodeName, bridgeName, physicalNetworkName string) (string, error) {
 	// IPv6 forwarding is enabled globally
 	if config.IPv4Mode {
-		stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("net.ipv4.conf.%s.forwarding=1", bridgeName))
-		if err != nil || stdout != fmt.Sprintf("net.ipv4.conf.%s.forwarding = 1", bridgeName) {
-			return "", fmt.Errorf("could not set the correct forwarding value for interface %s: stdout: %v, stderr: %v, err: %v",
-				bridgeName, stdout, stderr, err)
+		if err := sysctlmanager.Set(fmt.Sprintf("net.ipv4.conf.%s.forwarding", bridgeName), "1"); err != nil {
+			return "", fmt.Errorf("could not set the correct forwarding value for interface %s: %v", bridgeName, err)
 		}
 	}
 
-	// ovn-bridge-mappings maps a physical network name to a local ovs bridge
-	// that provides connectivity to that network. It is in the form of physnet1:br1,physnet2:br2.
-	// Note that there may be multiple ovs bridge mappings, be sure not to override
-	// the mappings for the other physical network
-	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
-		"external_ids:ovn-bridge-mappings")
-	if err != nil {
-		return "", fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
-	}
-	// skip the existing mapping setting for the specified physicalNetworkName
-	mapString := ""
-	bridgeMappings := strings.Split(stdout, ",")
-	for _, bridgeMapping := range bridgeMappings {
-		m := strings.Split(bridgeMapping, ":")
-		if network := m[0]; network != physicalNetworkName {
-			if len(mapString) != 0 {
-				mapString += ","
-			}
-			mapString += bridgeMapping
-		}
-	}
-	if len(mapString) != 0 {
-		mapString += ","
-	}
-	mapString += physicalNetworkName + ":" + bridgeName
-
-	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
-		fmt.Sprintf("external_ids:ovn-bridge-mappings=%s", mapString))
-	if err != nil {
-		return "", fmt.Errorf("failed to set ovn-bridge-mappings for ovs bridge %s"+
-			", stderr:%s (%v)", bridgeName, stderr, err)
+	if err := setBridgeMapping(physicalNetworkName, bridgeName); err != nil {
+		return "", err
 	}
 
 	ifaceID := bridgeName + "_" + nodeName
@@ -104,6 +74,15 @@ func getNetworkInterfaceIPAddresses(iface string) ([]*net.IPNet, error) {
 	return ips, nil
 }
 
+// gatewayInterface returns the configured gateway interface, or its VLAN sub-interface when the gateway
+// bridge is built on it
+func gatewayInterface() string {
+	if config.Gateway.VLANSubInterface {
+		return util.GetVLANSubInterfaceName(config.Gateway.Interface, config.Gateway.VLANID)
+	}
+	return config.Gateway.Interface
+}
+
 func getGatewayNextHops() ([]net.IP, string, error) {
 	var gatewayNextHops []net.IP
 	var needIPv4NextHop bool
@@ -117,44 +96,36 @@ func getGatewayNextHops() ([]net.IP, string, error) {
 	}
 
 	if config.Gateway.NextHop != "" {
-		nextHopsRaw := strings.Split(config.Gateway.NextHop, ",")
-		if len(nextHopsRaw) > 2 {
+		configuredNextHops, err := parseGatewayNextHops(config.Gateway.NextHop)
+		if err != nil {
+			return nil, "", err
+		}
+		// several next hops of an IP family are only failed over by gatewayNextHopHealthChecker in full mode
+		multipleNextHops := config.OvnKubeNode.Mode == types.NodeModeFull
+		if len(configuredNextHops) > 2 && !multipleNextHops {
 			return nil, "", fmt.Errorf("unexpected next-hops are provided, more than 2 next-hops is not allowed: %s", config.Gateway.NextHop)
 		}
-		for _, nh := range nextHopsRaw {
-			// Parse NextHop to make sure it is valid before using. Return error if not valid.
-			nextHop := net.ParseIP(nh)
-			if nextHop == nil {
-				return nil, "", fmt.Errorf("failed to parse configured next-hop: %s", config.Gateway.NextHop)
+		for _, nextHop := range configuredNextHops {
+			if slices.ContainsFunc(gatewayNextHops, nextHop.Equal) {
+				return nil, "", fmt.Errorf("duplicate next-hop %s is provided: %s", nextHop, config.Gateway.NextHop)
 			}
-			if config.IPv4Mode {
-				if needIPv4NextHop {
-					if !utilnet.IsIPv6(nextHop) {
-						gatewayNextHops = append(gatewayNextHops, nextHop)
-						needIPv4NextHop = false
-					}
-				} else {
-					if !utilnet.IsIPv6(nextHop) {
-						return nil, "", fmt.Errorf("only one IPv4 next-hop is allowed: %s", config.Gateway.NextHop)
-					}
+			if config.IPv4Mode && !utilnet.IsIPv6(nextHop) {
+				if !needIPv4NextHop && !multipleNextHops {
+					return nil, "", fmt.Errorf("only one IPv4 next-hop is allowed: %s", config.Gateway.NextHop)
 				}
+				gatewayNextHops = append(gatewayNextHops, nextHop)
+				needIPv4NextHop = false
 			}
-
-			if config.IPv6Mode {
-				if needIPv6NextHop {
-					if utilnet.IsIPv6(nextHop) {
-						gatewayNextHops = append(gatewayNextHops, nextHop)
-						needIPv6NextHop = false
-					}
-				} else {
-					if utilnet.IsIPv6(nextHop) {
-						return nil, "", fmt.Errorf("only one IPv6 next-hop is allowed: %s", config.Gateway.NextHop)
-					}
+			if config.IPv6Mode && utilnet.IsIPv6(nextHop) {
+				if !needIPv6NextHop && !multipleNextHops {
+					return nil, "", fmt.Errorf("only one IPv6 next-hop is allowed: %s", config.Gateway.NextHop)
 				}
+				gatewayNextHops = append(gatewayNextHops, nextHop)
+				needIPv6NextHop = false
 			}
 		}
 	}
-	gatewayIntf := config.Gateway.Interface
+	gatewayIntf := gatewayInterface()
 	if gatewayIntf != "" {
 		if bridgeName, _, err := util.RunOVSVsctl("port-to-br", gatewayIntf); err == nil {
 			// This is an OVS bridge's internal port
@@ -288,11 +259,25 @@ func configureSvcRouteViaInterface(routeManager *routemanager.Controller, iface
 		return fmt.Errorf("unable to get link for %s, error: %v", iface, err)
 	}
 
+	routes, err := getSvcRoutes(gwIPs)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		route.LinkIndex = link.Attrs().Index
+		routeManager.Add(route)
+	}
+	return nil
+}
+
+// getSvcRoutes returns the routes of the service CIDRs through the gateway IPs, without their link
+func getSvcRoutes(gwIPs []net.IP) ([]netlink.Route, error) {
+	var routes []netlink.Route
 	for _, subnet := range config.Kubernetes.ServiceCIDRs {
 		isV6 := utilnet.IsIPv6CIDR(subnet)
 		gwIP, err := util.MatchIPFamily(isV6, gwIPs)
 		if err != nil {
-			return fmt.Errorf("unable to find gateway IP for subnet: %v, found IPs: %v", subnet, gwIPs)
+			return nil, fmt.Errorf("unable to find gateway IP for subnet: %v, found IPs: %v", subnet, gwIPs)
 		}
 		srcIP := config.Gateway.MasqueradeIPs.V4HostMasqueradeIP
 		if isV6 {
@@ -305,9 +290,9 @@ func configureSvcRouteViaInterface(routeManager *routemanager.Controller, iface
 		}
 		subnetCopy := *subnet
 		gwIPCopy := gwIP[0]
-		routeManager.Add(netlink.Route{LinkIndex: link.Attrs().Index, Gw: gwIPCopy, Dst: &subnetCopy, Src: srcIP, MTU: mtu})
+		routes = append(routes, netlink.Route{Gw: gwIPCopy, Dst: &subnetCopy, Src: srcIP, MTU: mtu})
 	}
-	return nil
+	return routes, nil
 }
 
 func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAnnotator kube.Annotator,
@@ -320,13 +305,23 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 	var portClaimWatcher *portClaimWatcher
 
 	if config.Gateway.NodeportEnable && config.OvnKubeNode.Mode == types.NodeModeFull {
-		loadBalancerHealthChecker = newLoadBalancerHealthChecker(nc.name, nc.watchFactory)
+		loadBalancerHealthChecker = newLoadBalancerHealthChecker(nc.name, nc.watchFactory, nc.recorder, nc.healthzServer)
 		portClaimWatcher, err = newPortClaimWatcher(nc.recorder)
 		if err != nil {
 			return err
 		}
 	}
 
+	if config.Gateway.VLANSubInterface {
+		// the gateway bridge is built on the VLAN sub-interface of the gateway interface, see gatewayInterface
+		vlanIntf, err := util.EnsureVLANSubInterface(config.Gateway.Interface, config.Gateway.VLANID)
+		if err != nil {
+			return fmt.Errorf("failed to set up the VLAN %d sub-interface of %s: %w",
+				config.Gateway.VLANID, config.Gateway.Interface, err)
+		}
+		klog.Infof("Building the gateway on VLAN sub-interface %s", vlanIntf)
+	}
+
 	gatewayNextHops, gatewayIntf, err := getGatewayNextHops()
 	if err != nil {
 		return err
@@ -405,6 +400,15 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 	if portClaimWatcher != nil {
 		gw.portClaimWatcher = portClaimWatcher
 	}
+	if config.IPv6Mode && (config.Gateway.IPv6RAPolicy != config.IPv6RAPolicyUnmanaged ||
+		config.Gateway.IPv6AddrGenMode != config.IPv6AddrGenModeUnmanaged || config.Gateway.IPv6Token != "") &&
+		gw.openflowManager != nil {
+		gw.ipv6RAManager = newIPv6RAManager(gw.openflowManager.getDefaultBridgeName(), nc.routeManager)
+	}
+	if nc.healthzServer != nil && gw.openflowManager != nil {
+		// the node is not live on /livez when the gateway flows stop being synced
+		gw.openflowManager.syncObserver = nc.healthzServer
+	}
 
 	initGwFunc := func() error {
 		return gw.Init(nc.stopChan, nc.wg)
@@ -422,6 +426,11 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 	waiter.AddWait(readyGwFunc, initGwFunc)
 	nc.Gateway = gw
 
+	if gw.openflowManager != nil {
+		if err := validateGatewayBridgeMTU(gw.openflowManager.defaultBridge.uplinkName); err != nil {
+			return err
+		}
+	}
 	return nc.validateVTEPInterfaceMTU()
 }
 
@@ -522,6 +531,8 @@ func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP, no
 		initFunc:     func() error { return nil },
 		readyFunc:    func() (bool, error) { return true, nil },
 		watchFactory: nc.watchFactory.(*factory.WatchFactory),
+		// the DPU gateway cannot see the host interface, publish what it has to handle
+		gatewayIntentPublisher: newGatewayIntentPublisher(nc.name, gatewayIntf, ifAddrs, nc.Kube, nc.watchFactory),
 	}
 
 	// TODO(adrianc): revisit if support for nodeIPManager is needed.
@@ -531,7 +542,7 @@ func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP, no
 			return err
 		}
 		gw.nodePortWatcherIptables = newNodePortWatcherIptables()
-		gw.loadBalancerHealthChecker = newLoadBalancerHealthChecker(nc.name, nc.watchFactory)
+		gw.loadBalancerHealthChecker = newLoadBalancerHealthChecker(nc.name, nc.watchFactory, nc.recorder, nc.healthzServer)
 		portClaimWatcher, err := newPortClaimWatcher(nc.recorder)
 		if err != nil {
 			return err
@@ -548,13 +559,21 @@ func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP, no
 	return err
 }
 
-// CleanupClusterNode cleans up OVS resources on the k8s node on ovnkube-node daemonset deletion.
+// CleanupClusterNode cleans up OVS resources on the k8s node on ovnkube-node daemonset deletion, and the
+// gateway bridge, the management port, the iptables chains, the OVS external_ids and the CNI config so
+// that the node can move to another CNI.
 // This is going to be a best effort cleanup.
 func CleanupClusterNode(name string) error {
 	var err error
+	var gatewayBridge string
 
-	klog.V(5).Infof("Cleaning up gateway resources on node: %q", name)
+	gatewayLog.V(5).Infof("Cleaning up gateway resources on node: %q", name)
 	if config.Gateway.Mode == config.GatewayModeLocal || config.Gateway.Mode == config.GatewayModeShared {
+		// the gateway bridge is looked up before its bridge mapping is removed
+		gatewayBridge, err = getPhysicalNetworkBridge()
+		if err != nil {
+			klog.Errorf("Failed to get the gateway bridge, error: %v", err)
+		}
 		err = cleanupLocalnetGateway(types.LocalNetworkName)
 		if err != nil {
 			klog.Errorf("Failed to cleanup Localnet Gateway, error: %v", err)
@@ -574,6 +593,26 @@ func CleanupClusterNode(name string) error {
 	// Delete iptable rules for management port
 	DelMgtPortIptRules()
 
+	for _, feature := range CleanupFeatureNames() {
+		if err := CleanupFeature(feature); err != nil {
+			klog.Errorf("Failed to cleanup feature %s, error: %v", feature, err)
+		}
+	}
+	cleanupGatewayIPTables()
+
+	if err := deleteManagementPort(); err != nil {
+		klog.Errorf("Failed to cleanup management port, error: %v", err)
+	}
+	if err := cleanupOVNNodeExternalIDs(); err != nil {
+		klog.Errorf("Failed to cleanup OVS external_ids, error: %v", err)
+	}
+	if err := restoreGatewayUplink(gatewayBridge); err != nil {
+		klog.Errorf("Failed to cleanup gateway bridge, error: %v", err)
+	}
+	if err := config.RemoveCNIConfig(); err != nil {
+		klog.Errorf("Failed to remove CNI config, error: %v", err)
+	}
+
 	return nil
 }
 
@@ -606,7 +645,7 @@ func (nc *DefaultNodeNetworkController) updateGatewayMAC(link netlink.Link) erro
 	if err := nc.Gateway.Reconcile(); err != nil {
 		return fmt.Errorf("failed to reconcile gateway for MAC address update: %w", err)
 	}
-	nodeAnnotator := kube.NewNodeAnnotator(nc.Kube, node.Name)
+	nodeAnnotator := newNodeAnnotator(nc.Kube, node.Name)
 	l3gwConf.MACAddress = link.Attrs().HardwareAddr
 	if err := util.SetL3GatewayConfig(nodeAnnotator, l3gwConf); err != nil {
 		return fmt.Errorf("failed to update L3 gateway config annotation for node: %s, error: %w", node.Name, err)
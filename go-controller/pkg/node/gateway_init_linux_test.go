@@ -97,6 +97,10 @@ func shareGatewayInterfaceTest(app *cli.App, testNS ns.NetNS,
 			},
 		})
 		if config.IPv4Mode {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "sysctl -n net.ipv4.conf.breth0.forwarding",
+				Output: "0",
+			})
 			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd:    "sysctl -w net.ipv4.conf.breth0.forwarding=1",
 				Output: "net.ipv4.conf.breth0.forwarding = 1",
@@ -158,6 +162,10 @@ func shareGatewayInterfaceTest(app *cli.App, testNS ns.NetNS,
 			Cmd:    "ip -4 rule add fwmark 0x1745ec lookup 7 prio 30",
 			Output: "0",
 		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.conf.ovn-k8s-mp0.rp_filter",
+			Output: "0",
+		})
 		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 			Cmd:    "sysctl -w net.ipv4.conf.ovn-k8s-mp0.rp_filter=2",
 			Output: "net.ipv4.conf.ovn-k8s-mp0.rp_filter = 2",
@@ -510,12 +518,20 @@ func shareGatewayInterfaceDPUTest(app *cli.App, testNS ns.NetNS,
 			Cmd: "ovs-vsctl --timeout=15 get interface p0 ofport",
 		})
 		if config.IPv4Mode {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "sysctl -n net.ipv4.conf.brp0.forwarding",
+				Output: "0",
+			})
 			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd:    "sysctl -w net.ipv4.conf.brp0.forwarding=1",
 				Output: "net.ipv4.conf.brp0.forwarding = 1",
 			})
 		}
 		if config.IPv6Mode {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "sysctl -n net.ipv6.conf.brp0.forwarding",
+				Output: "0",
+			})
 			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd:    "sysctl -w net.ipv6.conf.brp0.forwarding=1",
 				Output: "net.ipv6.conf.brp0.forwarding = 1",
@@ -798,7 +814,7 @@ func shareGatewayInterfaceDPUHostTest(app *cli.App, testNS ns.NetNS, uplinkName,
 		Expect(err).NotTo(HaveOccurred())
 		ipnet.IP = ip
 		routeManager := routemanager.NewController()
-		cnnci := NewCommonNodeNetworkControllerInfo(kubeFakeClient, fakeClient.AdminPolicyRouteClient, wf, nil, nodeName, routeManager)
+		cnnci := NewCommonNodeNetworkControllerInfo(kubeFakeClient, fakeClient.AdminPolicyRouteClient, nil, wf, nil, nodeName, routeManager)
 		nc := newDefaultNodeNetworkController(cnnci, stop, errChan, wg, routeManager)
 		nodeAnnotator := kube.NewNodeAnnotator(nc.Kube, nc.name)
 		// must run route manager manually which is usually started with nc.Start()
@@ -958,6 +974,10 @@ OFPT_GET_CONFIG_REPLY (xid=0x4): frags=normal miss_send_len=0`
 			},
 		})
 		if config.IPv4Mode {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "sysctl -n net.ipv4.conf.breth0.forwarding",
+				Output: "0",
+			})
 			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd:    "sysctl -w net.ipv4.conf.breth0.forwarding=1",
 				Output: "net.ipv4.conf.breth0.forwarding = 1",
@@ -1013,6 +1033,10 @@ OFPT_GET_CONFIG_REPLY (xid=0x4): frags=normal miss_send_len=0`
 			Cmd:    "ip -4 rule add fwmark 0x1745ec lookup 7 prio 30",
 			Output: "0",
 		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.conf.ovn-k8s-mp0.rp_filter",
+			Output: "0",
+		})
 		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 			Cmd:    "sysctl -w net.ipv4.conf.ovn-k8s-mp0.rp_filter=2",
 			Output: "net.ipv4.conf.ovn-k8s-mp0.rp_filter = 2",
@@ -1760,6 +1784,7 @@ var _ = Describe("Gateway unit tests", func() {
 				MTU:       config.Default.MTU,
 			}
 			lnk.On("Attrs").Return(lnkAttr)
+			lnk.On("Type").Return("device")
 			netlinkMock.On("LinkByName", mock.Anything).Return(lnk, nil)
 			netlinkMock.On("LinkByIndex", mock.Anything).Return(lnk, nil)
 			netlinkMock.On("RouteListFiltered", mock.Anything, mock.Anything, mock.Anything).Return([]netlink.Route{*defaultRoute}, nil)
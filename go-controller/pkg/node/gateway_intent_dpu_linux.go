@@ -0,0 +1,262 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/vishvananda/netlink"
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const defaultGatewayIntentSyncPeriod = 10 * time.Second
+
+// gatewayIntentPublisher runs on the DPU host. The DPU host cannot reach OVS, so it publishes
+// in the node gateway intent annotation the addresses and routes of its gateway interface
+// that the gateway running on the DPU has to handle.
+type gatewayIntentPublisher struct {
+	nodeName    string
+	gatewayIntf string
+	// primaryIPs are the host addresses the DPU gateway already handles as node IPs
+	primaryIPs    sets.Set[string]
+	watchFactory  factory.NodeWatchFactory
+	nodeAnnotator kube.Annotator
+	syncPeriod    time.Duration
+}
+
+func newGatewayIntentPublisher(nodeName, gatewayIntf string, primaryIfAddrs []*net.IPNet, k kube.Interface,
+	watchFactory factory.NodeWatchFactory) *gatewayIntentPublisher {
+	primaryIPs := sets.New[string]()
+	for _, ifAddr := range primaryIfAddrs {
+		primaryIPs.Insert(ifAddr.IP.String())
+	}
+	return &gatewayIntentPublisher{
+		nodeName:      nodeName,
+		gatewayIntf:   gatewayIntf,
+		primaryIPs:    primaryIPs,
+		watchFactory:  watchFactory,
+		nodeAnnotator: newNodeAnnotator(k, nodeName),
+		syncPeriod:    defaultGatewayIntentSyncPeriod,
+	}
+}
+
+func (p *gatewayIntentPublisher) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, p.syncPeriod, nil, func() {
+		if err := p.sync(); err != nil {
+			klog.Errorf("Failed to publish the DPU gateway intent of node %s: %v", p.nodeName, err)
+		}
+	})
+}
+
+// sync updates the gateway intent annotation of the node when the host configuration changed
+func (p *gatewayIntentPublisher) sync() error {
+	intent, err := p.intent()
+	if err != nil {
+		return err
+	}
+	node, err := p.watchFactory.GetNode(p.nodeName)
+	if err != nil {
+		return err
+	}
+	current, err := util.ParseNodeDPUGatewayIntent(node)
+	if err != nil {
+		if !util.IsAnnotationNotSetError(err) {
+			klog.Warningf("Overwriting the invalid DPU gateway intent of node %s: %v", p.nodeName, err)
+		}
+		current = &util.DPUGatewayIntent{}
+	}
+	if reflect.DeepEqual(normalizeGatewayIntent(current), intent) {
+		return nil
+	}
+	klog.Infof("Publishing DPU gateway intent of node %s: VIPs %v, routes %v", p.nodeName, intent.VIPs, intent.Routes)
+	if err := util.SetNodeDPUGatewayIntent(p.nodeAnnotator, intent); err != nil {
+		return err
+	}
+	return p.nodeAnnotator.Run()
+}
+
+// intent returns the additional addresses and the routes through a next hop of the gateway interface
+func (p *gatewayIntentPublisher) intent() (*util.DPUGatewayIntent, error) {
+	link, err := util.GetNetLinkOps().LinkByName(p.gatewayIntf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup link %s: %v", p.gatewayIntf, err)
+	}
+	intent := &util.DPUGatewayIntent{}
+
+	addrs, err := util.GetNetLinkOps().AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses of %s: %v", p.gatewayIntf, err)
+	}
+	for _, addr := range addrs {
+		ip := addr.IP
+		if !gatewayIntentIPFamilyEnabled(ip) || ip.IsLinkLocalUnicast() || ip.IsLoopback() ||
+			util.IsAddressReservedForInternalUse(ip) || p.primaryIPs.Has(ip.String()) {
+			continue
+		}
+		intent.VIPs = append(intent.VIPs, ip.String())
+	}
+
+	routes, err := util.GetNetLinkOps().RouteList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes of %s: %v", p.gatewayIntf, err)
+	}
+	for _, route := range routes {
+		// the default route is handled by the gateway next hop, on-link routes need no next hop and
+		// the routes through the masquerade next hops (e.g. to the service CIDRs) are ovnkube's own
+		if route.Dst == nil || route.Gw == nil || !gatewayIntentIPFamilyEnabled(route.Gw) ||
+			util.IsAddressReservedForInternalUse(route.Gw) {
+			continue
+		}
+		if ones, _ := route.Dst.Mask.Size(); ones == 0 {
+			continue
+		}
+		intent.Routes = append(intent.Routes, util.DPUGatewayIntentRoute{
+			Destination: route.Dst.String(),
+			NextHop:     route.Gw.String(),
+		})
+	}
+	return normalizeGatewayIntent(intent), nil
+}
+
+func gatewayIntentIPFamilyEnabled(ip net.IP) bool {
+	if utilnet.IsIPv6(ip) {
+		return config.IPv6Mode
+	}
+	return config.IPv4Mode
+}
+
+// normalizeGatewayIntent sorts the intent so that it can be compared
+func normalizeGatewayIntent(intent *util.DPUGatewayIntent) *util.DPUGatewayIntent {
+	sort.Strings(intent.VIPs)
+	sort.Slice(intent.Routes, func(i, j int) bool {
+		return intent.Routes[i].Destination < intent.Routes[j].Destination
+	})
+	if len(intent.VIPs) == 0 {
+		intent.VIPs = nil
+	}
+	if len(intent.Routes) == 0 {
+		intent.Routes = nil
+	}
+	return intent
+}
+
+// gatewayIntentController runs on the DPU and applies the gateway intent published by the DPU
+// host: the host addresses are handled by the gateway bridge flows like the node IPs and the
+// host routes are installed through the gateway bridge.
+type gatewayIntentController struct {
+	sync.Mutex
+	nodeName      string
+	bridgeName    string
+	watchFactory  factory.NodeWatchFactory
+	routeManager  *routemanager.Controller
+	nodeIPManager *addressManager
+	// routes are the applied routes keyed by destination
+	routes map[string]netlink.Route
+}
+
+func newGatewayIntentController(nodeName, bridgeName string, watchFactory factory.NodeWatchFactory,
+	routeManager *routemanager.Controller, nodeIPManager *addressManager) *gatewayIntentController {
+	return &gatewayIntentController{
+		nodeName:      nodeName,
+		bridgeName:    bridgeName,
+		watchFactory:  watchFactory,
+		routeManager:  routeManager,
+		nodeIPManager: nodeIPManager,
+		routes:        map[string]netlink.Route{},
+	}
+}
+
+// Run applies the gateway intent of the node on every change of it until stopChan is closed
+func (c *gatewayIntentController) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	informer := c.watchFactory.NodeInformer()
+	handle, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			node := obj.(*kapi.Node)
+			if node.Name == c.nodeName {
+				c.sync(node)
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && util.NodeDPUGatewayIntentAnnotationChanged(oldNode, newNode) {
+				c.sync(newNode)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the DPU gateway intent: %w", err)
+	}
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		<-stopChan
+		if err := informer.RemoveEventHandler(handle); err != nil {
+			klog.Errorf("Failed to remove the node event handler for the DPU gateway intent: %v", err)
+		}
+	}()
+	return nil
+}
+
+// sync applies the gateway intent of the node, an intent that is not set or invalid is applied
+// as an empty one
+func (c *gatewayIntentController) sync(node *kapi.Node) {
+	c.Lock()
+	defer c.Unlock()
+	intent, err := util.ParseNodeDPUGatewayIntent(node)
+	if err != nil {
+		if !util.IsAnnotationNotSetError(err) {
+			klog.Errorf("Ignoring the DPU gateway intent of node %s: %v", c.nodeName, err)
+		}
+		intent = &util.DPUGatewayIntent{}
+	}
+
+	vips := make([]net.IP, 0, len(intent.VIPs))
+	for _, vip := range intent.VIPs {
+		vips = append(vips, net.ParseIP(vip))
+	}
+	if c.nodeIPManager.setDPUHostIPs(vips) {
+		klog.Infof("DPU host addresses of node %s changed to %v, re-syncing bridge flows", c.nodeName, intent.VIPs)
+		c.nodeIPManager.OnChanged()
+	}
+
+	link, err := util.GetNetLinkOps().LinkByName(c.bridgeName)
+	if err != nil {
+		klog.Errorf("Failed to apply the DPU gateway intent routes of node %s, unable to lookup link %s: %v",
+			c.nodeName, c.bridgeName, err)
+		return
+	}
+	routes := map[string]netlink.Route{}
+	for _, intentRoute := range intent.Routes {
+		_, dst, _ := net.ParseCIDR(intentRoute.Destination)
+		routes[dst.String()] = netlink.Route{LinkIndex: link.Attrs().Index, Dst: dst, Gw: net.ParseIP(intentRoute.NextHop)}
+	}
+	for dst, route := range c.routes {
+		if newRoute, ok := routes[dst]; !ok || !newRoute.Gw.Equal(route.Gw) {
+			c.routeManager.Del(route)
+			delete(c.routes, dst)
+		}
+	}
+	for dst, route := range routes {
+		if _, ok := c.routes[dst]; !ok {
+			c.routeManager.Add(route)
+			c.routes[dst] = route
+		}
+	}
+}
@@ -0,0 +1,78 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+	"github.com/vishvananda/netlink"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var _ = Describe("DPU gateway intent", func() {
+	origNetlinkOps := util.GetNetLinkOps()
+	var netlinkOpsMock *utilMocks.NetLinkOps
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		netlinkOpsMock = &utilMocks.NetLinkOps{}
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+	})
+
+	AfterEach(func() {
+		util.SetNetLinkOpMockInst(origNetlinkOps)
+	})
+
+	It("publishes the additional addresses and the routes through a next hop of the gateway interface", func() {
+		linkMock := &mocks.Link{}
+		netlinkOpsMock.On("LinkByName", "eth0").Return(linkMock, nil)
+		netlinkOpsMock.On("AddrList", linkMock, netlink.FAMILY_ALL).Return([]netlink.Addr{
+			// primary address, already handled as the node IP
+			{IPNet: ovntest.MustParseIPNet("192.168.1.5/24")},
+			{IPNet: ovntest.MustParseIPNet("192.168.1.10/32")},
+			{IPNet: ovntest.MustParseIPNet("192.168.1.9/24")},
+			// masquerade and link local addresses
+			{IPNet: ovntest.MustParseIPNet(config.Gateway.MasqueradeIPs.V4HostMasqueradeIP.String() + "/29")},
+			{IPNet: ovntest.MustParseIPNet("fe80::1/64")},
+		}, nil)
+		netlinkOpsMock.On("RouteList", linkMock, netlink.FAMILY_ALL).Return([]netlink.Route{
+			// default route
+			{Dst: ovntest.MustParseIPNet("0.0.0.0/0"), Gw: net.ParseIP("192.168.1.1")},
+			// on-link route
+			{Dst: ovntest.MustParseIPNet("192.168.1.0/24")},
+			{Dst: ovntest.MustParseIPNet("10.20.0.0/16"), Gw: net.ParseIP("192.168.1.254")},
+			// service route through the masquerade next hop
+			{Dst: ovntest.MustParseIPNet("172.30.0.0/16"), Gw: config.Gateway.MasqueradeIPs.V4DummyNextHopMasqueradeIP},
+		}, nil)
+
+		p := &gatewayIntentPublisher{
+			nodeName:    "node1",
+			gatewayIntf: "eth0",
+			primaryIPs:  sets.New("192.168.1.5"),
+		}
+		intent, err := p.intent()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(intent).To(Equal(&util.DPUGatewayIntent{
+			VIPs:   []string{"192.168.1.10", "192.168.1.9"},
+			Routes: []util.DPUGatewayIntentRoute{{Destination: "10.20.0.0/16", NextHop: "192.168.1.254"}},
+		}))
+	})
+
+	It("replaces the node IPs of the DPU with the host addresses of the intent", func() {
+		mgr := &addressManager{cidrs: sets.New[string]()}
+		Expect(mgr.setDPUHostIPs([]net.IP{net.ParseIP("192.168.1.10")})).To(BeTrue())
+		Expect(mgr.ListAddresses()).To(Equal([]net.IP{net.ParseIP("192.168.1.10")}))
+		Expect(mgr.setDPUHostIPs([]net.IP{net.ParseIP("192.168.1.10")})).To(BeFalse())
+		Expect(mgr.setDPUHostIPs(nil)).To(BeTrue())
+		Expect(mgr.ListAddresses()).To(BeEmpty())
+	})
+})
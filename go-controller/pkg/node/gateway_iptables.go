@@ -6,8 +6,13 @@ package node
 import (
 	"fmt"
 	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 
 	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
@@ -15,17 +20,30 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
 	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
 const (
-	iptableNodePortChain   = "OVN-KUBE-NODEPORT"   // called from nat-PREROUTING and nat-OUTPUT
-	iptableExternalIPChain = "OVN-KUBE-EXTERNALIP" // called from nat-PREROUTING and nat-OUTPUT
-	iptableETPChain        = "OVN-KUBE-ETP"        // called from nat-PREROUTING only
-	iptableITPChain        = "OVN-KUBE-ITP"        // called from mangle-OUTPUT and nat-OUTPUT
+	iptableNodePortChain   = "OVN-KUBE-NODEPORT"       // called from nat-PREROUTING and nat-OUTPUT
+	iptableExternalIPChain = "OVN-KUBE-EXTERNALIP"     // called from nat-PREROUTING and nat-OUTPUT
+	iptableETPChain        = "OVN-KUBE-ETP"            // called from nat-PREROUTING only
+	iptableITPChain        = "OVN-KUBE-ITP"            // called from mangle-OUTPUT and nat-OUTPUT
+	iptableUDNMasqChain    = "OVN-KUBE-UDN-MASQUERADE" // called from nat-POSTROUTING only
 )
 
+// iptableSubnetClaimChain is the chain of the subnets claimed by other agents, called from nat-POSTROUTING only
+const iptableSubnetClaimChain = "OVN-KUBE-EXTERNAL-CLAIMS"
+
+const (
+	iptableProbeChain     = "OVN-KUBE-PROBE"      // called from nat-OUTPUT only
+	iptableProbeSNATChain = "OVN-KUBE-PROBE-SNAT" // called from nat-POSTROUTING only
+)
+
+// iptableMSSClampChain is the chain clamping the MSS of the TCP connections, called from mangle-POSTROUTING only
+const iptableMSSClampChain = "OVN-KUBE-MSS-CLAMP"
+
 func clusterIPTablesProtocols() []iptables.Protocol {
 	var protocols []iptables.Protocol
 	if config.IPv4Mode {
@@ -53,10 +71,55 @@ func getMasqueradeVIP(ip string) string {
 	return config.Gateway.MasqueradeIPs.V4HostETPLocalMasqueradeIP.String()
 }
 
+// gatewayIPTables holds back the changes of the gateway iptables rules while the reconciliation of the node is
+// paused, they are applied in order once it is resumed
+var gatewayIPTables = &iptablesPause{}
+
+type iptablesPause struct {
+	sync.Mutex
+	paused bool
+	// pending are the changes of the rules held back by the pause
+	pending []func() error
+}
+
+// apply applies the change of the rules, or holds it back until the pause is over
+func (p *iptablesPause) apply(change func() error) error {
+	p.Lock()
+	defer p.Unlock()
+	if p.paused {
+		p.pending = append(p.pending, change)
+		return nil
+	}
+	return change()
+}
+
+func (p *iptablesPause) pause() {
+	p.Lock()
+	defer p.Unlock()
+	p.paused = true
+}
+
+// resume applies the changes held back by the pause
+func (p *iptablesPause) resume() error {
+	p.Lock()
+	defer p.Unlock()
+	p.paused = false
+	var errs []error
+	for _, change := range p.pending {
+		if err := change(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.pending = nil
+	return utilerrors.Join(errs...)
+}
+
 // insertIptRules adds the provided rules in an insert fashion
 // i.e each rule gets added at the first position in the chain
 func insertIptRules(rules []nodeipt.Rule) error {
-	return nodeipt.AddRules(rules, false)
+	return gatewayIPTables.apply(func() error {
+		return nodeipt.AddRules(rules, false)
+	})
 }
 
 // restoreIptRulesFiltered restores the provided rules in an insert fashion with a filter for table/chain
@@ -64,18 +127,24 @@ func insertIptRules(rules []nodeipt.Rule) error {
 // filter is defined as a map of table/chains. Only rules matching this filter will be restored.
 // If no rules match the filter, the chain will still be restored as empty as specified in the filter.
 func restoreIptRulesFiltered(rules []nodeipt.Rule, filter map[string]map[string]struct{}) error {
-	return nodeipt.RestoreRulesFiltered(rules, filter)
+	return gatewayIPTables.apply(func() error {
+		return nodeipt.RestoreRulesFiltered(rules, filter)
+	})
 }
 
 // appendIptRules adds the provided rules in an append fashion
 // i.e each rule gets added at the last position in the chain
 func appendIptRules(rules []nodeipt.Rule) error {
-	return nodeipt.AddRules(rules, true)
+	return gatewayIPTables.apply(func() error {
+		return nodeipt.AddRules(rules, true)
+	})
 }
 
 // deleteIptRules removes provided rules from the chain
 func deleteIptRules(rules []nodeipt.Rule) error {
-	return nodeipt.DelRules(rules)
+	return gatewayIPTables.apply(func() error {
+		return nodeipt.DelRules(rules)
+	})
 }
 
 func getGatewayInitRules(chain string, proto iptables.Protocol) []nodeipt.Rule {
@@ -202,14 +271,17 @@ func getNodePortETPLocalIPTRule(svcPort kapi.ServicePort, targetIP string) nodei
 	return getSkipMgmtSNATRule(string(svcPort.Protocol), fmt.Sprintf("%d", svcPort.NodePort), "", getIPTablesProtocol(targetIP))
 }
 
-// getSkipMgmtSNATRule generates the return iptables rule for avoiding SNAT to mgmt port
+// getSkipMgmtSNATRule generates the return iptables rule for avoiding SNAT to mgmt port. The traffic of the
+// sockets marked as host traffic, like the kubelet probes to the local endpoints, is SNATed whatever its port.
 func getSkipMgmtSNATRule(protocol, port, destIP string, ipFamily iptables.Protocol) nodeipt.Rule {
-	args := make([]string, 0, 8)
+	args := make([]string, 0, 13)
 	args = append(args, "-p", protocol)
 	if len(destIP) > 0 {
 		args = append(args, "-d", destIP)
 	}
-	args = append(args, "--dport", port, "-j", "RETURN")
+	args = append(args, "--dport", port)
+	args = append(args, hostTrafficMarkArgs()...)
+	args = append(args, "-j", "RETURN")
 	n := nodeipt.Rule{
 		Table:    "nat",
 		Chain:    iptableMgmPortChain,
@@ -335,8 +407,11 @@ func getGatewayForwardRules(cidrs []*net.IPNet) []nodeipt.Rule {
 		}...)
 	}
 
-	// Add rules for MasqueraIPs.
-	for protocol := range protocols {
+	// Add rules for MasqueraIPs, in a stable order of the protocols.
+	for _, protocol := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		if _, ok := protocols[protocol]; !ok {
+			continue
+		}
 		masqueradeIP := config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP
 		if protocol == iptables.ProtocolIPv6 {
 			masqueradeIP = config.Gateway.MasqueradeIPs.V6OVNMasqueradeIP
@@ -390,6 +465,16 @@ func getMasqueradeIpTablesNATRules(masqueradeIP net.IP, protocol iptables.Protoc
 	}
 }
 
+// getGatewayForwardCIDRs returns the cluster and service CIDRs whose traffic is forwarded through the gateway
+// bridge when forwarding is disabled
+func getGatewayForwardCIDRs() []*net.IPNet {
+	var cidrs []*net.IPNet
+	for _, subnet := range config.Default.ClusterSubnets {
+		cidrs = append(cidrs, subnet.CIDR)
+	}
+	return append(cidrs, config.Kubernetes.ServiceCIDRs...)
+}
+
 // initExternalBridgeForwardingRules sets up iptables rules for br-* interface svc traffic forwarding
 // -A FORWARD -s 10.96.0.0/16 -j ACCEPT
 // -A FORWARD -d 10.96.0.0/16 -j ACCEPT
@@ -469,6 +554,384 @@ func getLocalGatewayNATRules(ifname string, cidr *net.IPNet) []nodeipt.Rule {
 	}
 }
 
+// getUDNMasqueradeChainRules returns the rules jumping to the masquerade chain of the user defined networks, used in
+// local gateway mode. The traffic of the networks destined to the services is not masqueraded.
+// -A POSTROUTING -j OVN-KUBE-UDN-MASQUERADE
+// -A OVN-KUBE-UDN-MASQUERADE -d 10.96.0.0/16 -j RETURN
+func getUDNMasqueradeChainRules(protocol iptables.Protocol) []nodeipt.Rule {
+	rules := []nodeipt.Rule{
+		{
+			Table:    "nat",
+			Chain:    "POSTROUTING",
+			Args:     []string{"-j", iptableUDNMasqChain},
+			Protocol: protocol,
+		},
+	}
+	for _, svcCIDR := range config.Kubernetes.ServiceCIDRs {
+		if getIPTablesProtocol(svcCIDR.IP.String()) != protocol {
+			continue
+		}
+		rules = append(rules, nodeipt.Rule{
+			Table:    "nat",
+			Chain:    iptableUDNMasqChain,
+			Args:     []string{"-d", svcCIDR.String(), "-j", "RETURN"},
+			Protocol: protocol,
+		})
+	}
+	return rules
+}
+
+// getUDNMasqueradeRule returns the rule masquerading to the node IP the traffic of a user defined network leaving
+// the node in local gateway mode, sourced from the management port masquerade IP of the network:
+// -A OVN-KUBE-UDN-MASQUERADE -s 169.254.0.12/32 -j MASQUERADE
+func getUDNMasqueradeRule(mgmtPortMasqIP *net.IPNet) nodeipt.Rule {
+	return nodeipt.Rule{
+		Table:    "nat",
+		Chain:    iptableUDNMasqChain,
+		Args:     []string{"-s", mgmtPortMasqIP.String(), "-j", "MASQUERADE"},
+		Protocol: getIPTablesProtocol(mgmtPortMasqIP.IP.String()),
+	}
+}
+
+// getUDNConntrackZoneRules returns the rules tracking in the conntrack zone of a user defined network the
+// connections entering the host from the management port of the network or sent by the host into its VRF, so
+// that the connections of the networks with overlapping subnets don't collide in the host conntrack table. Only
+// the original direction is tracked in the zone, the replies to the traffic of the network masqueraded to the
+// node IP are found in the default zone:
+// -A PREROUTING -i ovn-k8s-mp3 -j CT --zone-orig 63997
+// -A OUTPUT -o mp3-udn-vrf -j CT --zone-orig 63997
+func getUDNConntrackZoneRules(mgmtPortName, vrfName string, zone int) []nodeipt.Rule {
+	var rules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		rules = append(rules,
+			nodeipt.Rule{
+				Table:    "raw",
+				Chain:    "PREROUTING",
+				Args:     []string{"-i", mgmtPortName, "-j", "CT", "--zone-orig", strconv.Itoa(zone)},
+				Protocol: proto,
+			},
+			nodeipt.Rule{
+				Table:    "raw",
+				Chain:    "OUTPUT",
+				Args:     []string{"-o", vrfName, "-j", "CT", "--zone-orig", strconv.Itoa(zone)},
+				Protocol: proto,
+			},
+		)
+	}
+	return rules
+}
+
+// getSubnetClaimJumpRule returns the rule jumping to the chain of the subnets claimed by other agents, it is
+// inserted first so that the traffic of the pods to the claimed subnets is not masqueraded by the rules of
+// ovnkube-node appended after it
+// -I POSTROUTING -j OVN-KUBE-EXTERNAL-CLAIMS
+func getSubnetClaimJumpRule(protocol iptables.Protocol) nodeipt.Rule {
+	return nodeipt.Rule{
+		Table:    "nat",
+		Chain:    "POSTROUTING",
+		Args:     []string{"-j", iptableSubnetClaimChain},
+		Protocol: protocol,
+	}
+}
+
+// getSubnetClaimRules returns the rules accepting without NAT the traffic of the pod subnets and of the OVN
+// masquerade IPs to the subnets claimed by other agents and to the control plane VIPs:
+// -A OVN-KUBE-EXTERNAL-CLAIMS -s 10.244.1.0/24 -d 10.2.0.0/16 -j ACCEPT
+// -A OVN-KUBE-EXTERNAL-CLAIMS -s 169.254.169.1 -d 10.2.0.0/16 -j ACCEPT
+func getSubnetClaimRules(podSubnets, claims []*net.IPNet) []nodeipt.Rule {
+	sources := make(map[iptables.Protocol][]string)
+	for _, subnet := range podSubnets {
+		protocol := getIPTablesProtocol(subnet.IP.String())
+		sources[protocol] = append(sources[protocol], subnet.String())
+	}
+	if config.IPv4Mode {
+		sources[iptables.ProtocolIPv4] = append(sources[iptables.ProtocolIPv4],
+			config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP.String())
+	}
+	if config.IPv6Mode {
+		sources[iptables.ProtocolIPv6] = append(sources[iptables.ProtocolIPv6],
+			config.Gateway.MasqueradeIPs.V6OVNMasqueradeIP.String())
+	}
+	var rules []nodeipt.Rule
+	for _, claim := range claims {
+		protocol := getIPTablesProtocol(claim.IP.String())
+		for _, source := range sources[protocol] {
+			rules = append(rules, nodeipt.Rule{
+				Table:    "nat",
+				Chain:    iptableSubnetClaimChain,
+				Args:     []string{"-s", source, "-d", claim.String(), "-j", "ACCEPT"},
+				Protocol: protocol,
+			})
+		}
+	}
+	return rules
+}
+
+// syncSubnetClaimRules makes the chain of the subnets claimed by other agents hold the rules of the claims
+func syncSubnetClaimRules(podSubnets, claims []*net.IPNet) error {
+	var jumpRules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		jumpRules = append(jumpRules, getSubnetClaimJumpRule(proto))
+	}
+	if err := insertIptRules(jumpRules); err != nil {
+		return fmt.Errorf("failed to insert the jump to chain %s: %w", iptableSubnetClaimChain, err)
+	}
+	return recreateIPTRules("nat", iptableSubnetClaimChain, getSubnetClaimRules(podSubnets, claims))
+}
+
+// getMSSClampJumpRule returns the rule jumping to the chain clamping the MSS of the TCP connections
+// -I POSTROUTING -j OVN-KUBE-MSS-CLAMP
+func getMSSClampJumpRule(protocol iptables.Protocol) nodeipt.Rule {
+	return nodeipt.Rule{
+		Table:    "mangle",
+		Chain:    "POSTROUTING",
+		Args:     []string{"-j", iptableMSSClampChain},
+		Protocol: protocol,
+	}
+}
+
+// getMSSClampRules returns the rules clamping the MSS of the SYN packets of the TCP connections leaving through
+// the interfaces to the path MTU of their route, e.g. for the gateway bridge:
+// -A OVN-KUBE-MSS-CLAMP -o breth0 -p tcp --tcp-flags SYN,RST SYN -j TCPMSS --clamp-mss-to-pmtu
+func getMSSClampRules(ifnames []string) []nodeipt.Rule {
+	var rules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		for _, ifname := range ifnames {
+			rules = append(rules, nodeipt.Rule{
+				Table:    "mangle",
+				Chain:    iptableMSSClampChain,
+				Args:     []string{"-o", ifname, "-p", "tcp", "--tcp-flags", "SYN,RST", "SYN", "-j", "TCPMSS", "--clamp-mss-to-pmtu"},
+				Protocol: proto,
+			})
+		}
+	}
+	return rules
+}
+
+// syncMSSClampRules makes the chain clamping the MSS of the TCP connections hold the rules of the interfaces
+func syncMSSClampRules(ifnames []string) error {
+	var jumpRules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		jumpRules = append(jumpRules, getMSSClampJumpRule(proto))
+	}
+	if err := insertIptRules(jumpRules); err != nil {
+		return fmt.Errorf("failed to insert the jump to chain %s: %w", iptableMSSClampChain, err)
+	}
+	return recreateIPTRules("mangle", iptableMSSClampChain, getMSSClampRules(ifnames))
+}
+
+// cleanupMSSClampRules deletes the chain clamping the MSS of the TCP connections with the jump to it
+func cleanupMSSClampRules() error {
+	var rules []nodeipt.Rule
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		rules = append(rules, getMSSClampJumpRule(proto))
+	}
+	if err := deleteIptRules(rules); err != nil {
+		return fmt.Errorf("failed to delete the jump to chain %s: %w", iptableMSSClampChain, err)
+	}
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		ipt, err := util.GetIPTablesHelper(proto)
+		if err != nil {
+			return err
+		}
+		_ = ipt.ClearChain("mangle", iptableMSSClampChain)
+		_ = ipt.DeleteChain("mangle", iptableMSSClampChain)
+	}
+	return nil
+}
+
+// getProbePathJumpRules returns the jumps to the chains of the probe path, the traffic to the management port
+// only goes through the SNAT chain
+func getProbePathJumpRules(protocol iptables.Protocol) []nodeipt.Rule {
+	return []nodeipt.Rule{
+		{
+			Table:    "nat",
+			Chain:    "OUTPUT",
+			Args:     []string{"-j", iptableProbeChain},
+			Protocol: protocol,
+		},
+		{
+			Table:    "nat",
+			Chain:    "POSTROUTING",
+			Args:     []string{"-o", types.K8sMgmtIntfName, "-j", iptableProbeSNATChain},
+			Protocol: protocol,
+		},
+	}
+}
+
+// probePathEndpoint is an endpoint on the node of a service port
+type probePathEndpoint struct {
+	ip   string
+	port int32
+}
+
+// getProbePathRules returns the rules of the probe path of a service with externalTrafficPolicy=Local. The host
+// traffic, or only the traffic of the host traffic cgroups when set, to its NodePorts on the node addresses, to
+// its external IPs and to its load balancer IPs is DNATed straight to its endpoints on the node, spread with the
+// statistic module, and the DNATed traffic is not SNATed to the management port:
+// -A OVN-KUBE-PROBE -p TCP -m addrtype --dst-type LOCAL --dport 30080 -m comment --comment ns/svc -j DNAT --to-destination 10.244.1.3:8080 -m statistic --mode random --probability 1.0000000000
+// -A OVN-KUBE-PROBE-SNAT -p TCP -d 10.244.1.3 --dport 8080 -m conntrack --ctstate DNAT --ctorigdstport 30080 -j ACCEPT
+// The host traffic to a port without endpoint on the node is counted by a RETURN rule as dropped from the probe
+// path, and goes through the gateway:
+// -A OVN-KUBE-PROBE -p TCP -m addrtype --dst-type LOCAL --dport 30080 -m comment --comment ns/svc -j RETURN
+func getProbePathRules(service *kapi.Service, endpoints map[util.EndpointPortKey][]probePathEndpoint) []nodeipt.Rule {
+	var rules []nodeipt.Rule
+	comment := service.Namespace + "/" + service.Name
+	var hostTrafficArgs []string
+	if len(config.OvnKubeNode.GetHostTrafficCgroups()) > 0 {
+		hostTrafficArgs = []string{"-m", "mark", "--mark", ovnkubeHostTrafficMark + "/" + ovnkubeHostTrafficMark}
+	}
+	for _, svcPort := range service.Spec.Ports {
+		if util.ValidatePort(svcPort.Protocol, svcPort.Port) != nil {
+			continue
+		}
+		// the destinations of the port by IP family, with the service port they are reached on
+		type destination struct {
+			args []string
+			port int32
+		}
+		destinations := map[iptables.Protocol][]destination{}
+		if util.ServiceTypeHasNodePort(service) && util.ValidatePort(svcPort.Protocol, svcPort.NodePort) == nil {
+			for _, clusterIP := range util.GetClusterIPs(service) {
+				protocol := getIPTablesProtocol(clusterIP)
+				destinations[protocol] = append(destinations[protocol], destination{
+					args: []string{"-m", "addrtype", "--dst-type", "LOCAL", "--dport", fmt.Sprintf("%d", svcPort.NodePort)},
+					port: svcPort.NodePort,
+				})
+			}
+		}
+		for _, externalIP := range util.GetExternalAndLBIPs(service) {
+			protocol := getIPTablesProtocol(externalIP)
+			destinations[protocol] = append(destinations[protocol], destination{
+				args: []string{"-d", externalIP, "--dport", fmt.Sprintf("%d", svcPort.Port)},
+				port: svcPort.Port,
+			})
+		}
+
+		for _, protocol := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+			var portEndpoints []probePathEndpoint
+			for _, endpoint := range endpoints[util.EndpointPortKey{Name: svcPort.Name, Protocol: svcPort.Protocol}] {
+				if getIPTablesProtocol(endpoint.ip) == protocol {
+					portEndpoints = append(portEndpoints, endpoint)
+				}
+			}
+			origPorts := sets.New[int32]()
+			for _, dst := range destinations[protocol] {
+				args := append([]string{"-p", string(svcPort.Protocol)}, dst.args...)
+				args = append(args, hostTrafficArgs...)
+				args = append(args, "-m", "comment", "--comment", comment)
+				if len(portEndpoints) == 0 {
+					rules = append(rules, nodeipt.Rule{
+						Table:    "nat",
+						Chain:    iptableProbeChain,
+						Args:     append(args, "-j", "RETURN"),
+						Protocol: protocol,
+					})
+					continue
+				}
+				origPorts.Insert(dst.port)
+				for i, endpoint := range portEndpoints {
+					rules = append(rules, nodeipt.Rule{
+						Table: "nat",
+						Chain: iptableProbeChain,
+						Args: append(append([]string{}, args...),
+							"-j", "DNAT",
+							"--to-destination", util.JoinHostPortInt32(endpoint.ip, endpoint.port),
+							"-m", "statistic",
+							"--mode", "random",
+							"--probability", computeProbability(len(portEndpoints), i+1),
+						),
+						Protocol: protocol,
+					})
+				}
+			}
+			for _, origPort := range sets.List(origPorts) {
+				for _, endpoint := range portEndpoints {
+					rules = append(rules, nodeipt.Rule{
+						Table: "nat",
+						Chain: iptableProbeSNATChain,
+						Args: []string{
+							"-p", string(svcPort.Protocol),
+							"-d", endpoint.ip,
+							"--dport", fmt.Sprintf("%d", endpoint.port),
+							"-m", "conntrack",
+							"--ctstate", "DNAT",
+							"--ctorigdstport", fmt.Sprintf("%d", origPort),
+							"-j", "ACCEPT",
+						},
+						Protocol: protocol,
+					})
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// syncProbePathRules makes the chains of the probe path hold the rules of the services, before the jumps to
+// them are inserted first in the built-in chains
+func syncProbePathRules(rules []nodeipt.Rule) error {
+	var errs []error
+	// the rules are restored in an insert fashion, the first rule of a chain is restored last
+	restored := slices.Clone(rules)
+	slices.Reverse(restored)
+	for _, chain := range []string{iptableProbeChain, iptableProbeSNATChain} {
+		if err := recreateIPTRules("nat", chain, restored); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	var jumpRules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		jumpRules = append(jumpRules, getProbePathJumpRules(proto)...)
+	}
+	if err := insertIptRules(jumpRules); err != nil {
+		errs = append(errs, fmt.Errorf("failed to insert the jumps to the probe path chains: %w", err))
+	}
+	return utilerrors.Join(errs...)
+}
+
+// cleanupProbePathRules deletes the chains of the probe path with the jumps to them
+func cleanupProbePathRules() error {
+	var rules []nodeipt.Rule
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		rules = append(rules, getProbePathJumpRules(proto)...)
+	}
+	if err := deleteIptRules(rules); err != nil {
+		return fmt.Errorf("failed to delete the jumps to the probe path chains: %w", err)
+	}
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		ipt, err := util.GetIPTablesHelper(proto)
+		if err != nil {
+			return err
+		}
+		for _, chain := range []string{iptableProbeChain, iptableProbeSNATChain} {
+			_ = ipt.ClearChain("nat", chain)
+			_ = ipt.DeleteChain("nat", chain)
+		}
+	}
+	return nil
+}
+
+// parseProbePathDrop returns the service in the comment of a RETURN rule of the probe path listed with its
+// counters and the connections it counted, only the first packet of a connection goes through the nat table
+func parseProbePathDrop(rule string) (string, uint64, bool) {
+	fields := strings.Fields(rule)
+	var service string
+	var packets uint64
+	isReturn := false
+	for i := 0; i < len(fields)-1; i++ {
+		switch fields[i] {
+		case "--comment":
+			service = strings.Trim(fields[i+1], `"`)
+		case "-c":
+			packets, _ = strconv.ParseUint(fields[i+1], 10, 64)
+		case "-j":
+			isReturn = fields[i+1] == "RETURN"
+		}
+	}
+	return service, packets, isReturn && service != ""
+}
+
 // initLocalGatewayNATRules sets up iptables rules for interfaces
 func initLocalGatewayNATRules(ifname string, cidr *net.IPNet) error {
 	// Insert the filter table rules because they need to be evaluated BEFORE the DROP rules
@@ -485,14 +948,19 @@ func initLocalGatewayNATRules(ifname string, cidr *net.IPNet) error {
 
 func addChaintoTable(ipt util.IPTablesHelper, tableName, chain string) {
 	if err := ipt.NewChain(tableName, chain); err != nil {
-		klog.V(5).Infof("Chain: \"%s\" in table: \"%s\" already exists, skipping creation: %v", chain, tableName, err)
+		gatewayLog.V(5).Infof("Chain: \"%s\" in table: \"%s\" already exists, skipping creation: %v", chain, tableName, err)
 	}
 }
 
+// getGatewayIPTablesChains returns the chains the gateway jumps to from the built-in chains
+func getGatewayIPTablesChains() []string {
+	// (NOTE: Order is important, add jump to iptableETPChain before jump to NP/EIP chains)
+	return []string{iptableITPChain, egressservice.Chain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain}
+}
+
 func handleGatewayIPTables(iptCallback func(rules []nodeipt.Rule) error, genGatewayChainRules func(chain string, proto iptables.Protocol) []nodeipt.Rule) error {
 	rules := make([]nodeipt.Rule, 0)
-	// (NOTE: Order is important, add jump to iptableETPChain before jump to NP/EIP chains)
-	for _, chain := range []string{iptableITPChain, egressservice.Chain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain} {
+	for _, chain := range getGatewayIPTablesChains() {
 		for _, proto := range clusterIPTablesProtocols() {
 			ipt, err := util.GetIPTablesHelper(proto)
 			if err != nil {
@@ -539,6 +1007,38 @@ func cleanupSharedGatewayIPTChains() {
 	}
 }
 
+// cleanupGatewayIPTables deletes the chains the gateway jumps to from the built-in chains, with the jumps
+func cleanupGatewayIPTables() {
+	var rules []nodeipt.Rule
+	for _, chain := range getGatewayIPTablesChains() {
+		for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+			rules = append(rules, getGatewayInitRules(chain, proto)...)
+		}
+	}
+	for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+		rules = append(rules, getSubnetClaimJumpRule(proto))
+		rules = append(rules, getProbePathJumpRules(proto)...)
+		rules = append(rules, getMSSClampJumpRule(proto))
+	}
+	if err := deleteIptRules(rules); err != nil {
+		klog.Errorf("Failed to delete the gateway iptables jump rules: %v", err)
+	}
+	for _, chain := range append(getGatewayIPTablesChains(), iptableSubnetClaimChain, iptableProbeChain, iptableProbeSNATChain,
+		iptableMSSClampChain) {
+		// We clean up both IPv4 and IPv6, regardless of what is currently in use
+		for _, proto := range []iptables.Protocol{iptables.ProtocolIPv4, iptables.ProtocolIPv6} {
+			ipt, err := util.GetIPTablesHelper(proto)
+			if err != nil {
+				return
+			}
+			for _, table := range []string{"nat", "mangle"} {
+				_ = ipt.ClearChain(table, chain)
+				_ = ipt.DeleteChain(table, chain)
+			}
+		}
+	}
+}
+
 func recreateIPTRules(table, chain string, keepIPTRules []nodeipt.Rule) error {
 	var errors []error
 	var err error
@@ -0,0 +1,47 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"github.com/coreos/go-iptables/iptables"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Gateway iptables reconciliation pause", func() {
+	rule := nodeipt.Rule{
+		Table:    "nat",
+		Chain:    "OVN-KUBE-NODEPORT",
+		Args:     []string{"-p", "tcp", "--dport", "30080", "-j", "DNAT", "--to-destination", "10.96.0.10:80"},
+		Protocol: iptables.ProtocolIPv4,
+	}
+
+	It("applies the changes held back by the pause in order once resumed", func() {
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+		pause := gatewayIPTables
+		defer func() { gatewayIPTables = pause }()
+		gatewayIPTables = &iptablesPause{}
+
+		gatewayIPTables.pause()
+		Expect(insertIptRules([]nodeipt.Rule{rule})).To(Succeed())
+		exists, err := iptV4.Exists(rule.Table, rule.Chain, rule.Args...)
+		Expect(err).To(HaveOccurred(), "the chain is not created while paused")
+		Expect(exists).To(BeFalse())
+
+		Expect(deleteIptRules([]nodeipt.Rule{rule})).To(Succeed())
+		Expect(appendIptRules([]nodeipt.Rule{rule})).To(Succeed())
+		Expect(gatewayIPTables.resume()).To(Succeed())
+		exists, err = iptV4.Exists(rule.Table, rule.Chain, rule.Args...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeTrue())
+
+		Expect(deleteIptRules([]nodeipt.Rule{rule})).To(Succeed())
+		exists, err = iptV4.Exists(rule.Table, rule.Chain, rule.Args...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+})
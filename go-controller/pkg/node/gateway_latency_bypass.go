@@ -0,0 +1,205 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// latencyCriticalBypass forwards the traffic of the local pods annotated as latency critical between the physical
+// port of the gateway bridge and OVN in table 0, without the conntrack zone of the bridge and the table 1
+// lookup of the reply direction. The traffic of the pods is otherwise committed to the conntrack of the bridge
+// on its way out and looked up on its way in, which adds latency and jitter to PTP and other time sensitive
+// traffic.
+//
+// It is only safe with disable-snat-multiple-gws, where the traffic to the pod subnets is forwarded to OVN
+// regardless of its conntrack state: the conntrack of the bridge only tells the replies to the node and to
+// OVN apart, and the pod IPs are OVN's. The traffic the gateway router SNATs to the node IP, or marked for the
+// SNAT of egress services, does not match the pod IPs and keeps going through the conntrack of the bridge.
+// The flows of br-int are OVN's, the fast path only covers the gateway bridge.
+type latencyCriticalBypass struct {
+	sync.Mutex
+	ofm *openflowManager
+	wf  factory.NodeWatchFactory
+	// pods are the pods with bypass flows
+	pods sets.Set[ktypes.NamespacedName]
+	// bridge is the state of the gateway bridge the flows of the pods were generated for
+	bridge latencyBypassBridge
+}
+
+// latencyBypassBridge is the state of the gateway bridge the bypass flows depend on
+type latencyBypassBridge struct {
+	ofPortPhys  string
+	ofPortPatch string
+	macAddress  string
+}
+
+// latencyBypassResyncInterval is how often the gateway bridge is checked for a change of its ports or MAC,
+// which requires the flows of all the pods to be regenerated
+const latencyBypassResyncInterval = 10 * time.Second
+
+func newLatencyCriticalBypass(ofm *openflowManager, wf factory.NodeWatchFactory) *latencyCriticalBypass {
+	return &latencyCriticalBypass{
+		ofm:  ofm,
+		wf:   wf,
+		pods: sets.New[ktypes.NamespacedName](),
+	}
+}
+
+// Start installs the bypass flows of the latency critical pods and keeps them in sync
+func (b *latencyCriticalBypass) Start(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	b.bridge = b.currentBridge()
+	_, err := b.wf.AddPodHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			b.AddPod(obj.(*kapi.Pod))
+		},
+		UpdateFunc: func(old, new interface{}) {
+			b.AddPod(new.(*kapi.Pod))
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod, ok := obj.(*kapi.Pod)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					klog.Errorf("Couldn't get object from tombstone %#v", obj)
+					return
+				}
+				if pod, ok = tombstone.Obj.(*kapi.Pod); !ok {
+					klog.Errorf("Tombstone contained object that is not a Pod %#v", tombstone.Obj)
+					return
+				}
+			}
+			b.DeletePod(pod)
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	runPeriodicSync(stopChan, doneWg, latencyBypassResyncInterval, nil, func() {
+		if err := b.resync(); err != nil {
+			klog.Errorf("Failed to resync the latency critical bypass flows: %v", err)
+		}
+	})
+	return nil
+}
+
+// currentBridge returns the state of the gateway bridge the bypass flows depend on
+func (b *latencyCriticalBypass) currentBridge() latencyBypassBridge {
+	b.ofm.defaultBridge.Lock()
+	defer b.ofm.defaultBridge.Unlock()
+	bridge := latencyBypassBridge{
+		ofPortPhys: b.ofm.defaultBridge.ofPortPhys,
+		macAddress: b.ofm.defaultBridge.macAddress.String(),
+	}
+	if netConfig, ok := b.ofm.defaultBridge.netConfig[types.DefaultNetworkName]; ok {
+		bridge.ofPortPatch = netConfig.ofPortPatch
+	}
+	return bridge
+}
+
+// resync regenerates the bypass flows of all the pods when the ports or the MAC of the gateway bridge change
+func (b *latencyCriticalBypass) resync() error {
+	b.Lock()
+	defer b.Unlock()
+	bridge := b.currentBridge()
+	if bridge == b.bridge {
+		return nil
+	}
+	pods, err := b.wf.GetAllPods()
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	klog.Infof("Gateway bridge changed from %+v to %+v, regenerating the latency critical bypass flows", b.bridge, bridge)
+	b.bridge = bridge
+	for _, pod := range pods {
+		b.syncPod(pod)
+	}
+	b.ofm.requestFlowSync()
+	return nil
+}
+
+func latencyBypassFlowsKey(pod ktypes.NamespacedName) string {
+	return strings.Join([]string{"LatencyCritical", pod.Namespace, pod.Name}, "_")
+}
+
+// AddPod installs or removes the bypass flows of the pod as it is annotated
+func (b *latencyCriticalBypass) AddPod(pod *kapi.Pod) {
+	b.Lock()
+	defer b.Unlock()
+	b.syncPod(pod)
+	b.ofm.requestFlowSync()
+}
+
+// DeletePod removes the bypass flows of the pod
+func (b *latencyCriticalBypass) DeletePod(pod *kapi.Pod) {
+	b.Lock()
+	defer b.Unlock()
+	name := ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	if b.pods.Has(name) {
+		b.ofm.deleteFlowsByKey(latencyBypassFlowsKey(name))
+		b.pods.Delete(name)
+		b.ofm.requestFlowSync()
+	}
+}
+
+// syncPod updates the bypass flows of the pod for the last known state of the gateway bridge, b must be locked
+func (b *latencyCriticalBypass) syncPod(pod *kapi.Pod) {
+	name := ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	var flows []string
+	if b.bridge.ofPortPhys != "" && b.bridge.ofPortPatch != "" && util.PodLatencyCritical(pod) &&
+		!util.PodWantsHostNetwork(pod) && !util.PodCompleted(pod) {
+		ips, err := util.DefaultNetworkPodIPs(pod)
+		if err != nil {
+			klog.V(5).Infof("Not bypassing the conntrack of the gateway bridge for pod %s: %v", name, err)
+		}
+		for _, ip := range ips {
+			flows = append(flows, generateLatencyBypassFlows(ip.String(), b.bridge)...)
+		}
+	}
+	if len(flows) == 0 {
+		if b.pods.Has(name) {
+			b.ofm.deleteFlowsByKey(latencyBypassFlowsKey(name))
+			b.pods.Delete(name)
+		}
+		return
+	}
+	gatewayLog.V(5).Infof("Bypassing the conntrack of the gateway bridge for latency critical pod %s", name)
+	b.ofm.updateFlowCacheEntry(latencyBypassFlowsKey(name), flows)
+	b.pods.Insert(name)
+}
+
+// generateLatencyBypassFlows returns the flows forwarding the traffic of the pod IP between the physical port and
+// OVN without conntrack
+func generateLatencyBypassFlows(podIP string, bridge latencyBypassBridge) []string {
+	isIPv6 := utilnet.IsIPv6String(podIP)
+	if (isIPv6 && !config.IPv6Mode) || (!isIPv6 && !config.IPv4Mode) {
+		return nil
+	}
+	ipPrefix := "ip"
+	if isIPv6 {
+		ipPrefix = "ipv6"
+	}
+	return []string{
+		// table 0, the traffic of the pod leaving OVN unSNATed goes straight out, the egress service traffic
+		// marked for the SNAT to the node IP is left to the priority 105 flow
+		fmt.Sprintf("cookie=%s, priority=110, in_port=%s, dl_src=%s, %s, %s_src=%s, pkt_mark=0, actions=output:%s",
+			defaultOpenFlowCookie, bridge.ofPortPatch, bridge.macAddress, ipPrefix, ipPrefix, podIP, bridge.ofPortPhys),
+		// table 0, the traffic to the pod goes straight to OVN instead of through the conntrack lookup of the
+		// priority 50 flow and the pod subnet flow of table 1
+		fmt.Sprintf("cookie=%s, priority=55, in_port=%s, %s, %s_dst=%s, actions=output:%s",
+			defaultOpenFlowCookie, bridge.ofPortPhys, ipPrefix, ipPrefix, podIP, bridge.ofPortPatch),
+	}
+}
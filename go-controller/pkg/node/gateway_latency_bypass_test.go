@@ -0,0 +1,90 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Latency critical pods bypass", func() {
+	const bridgeMAC = "0a:58:ac:12:00:02"
+
+	podName := ktypes.NamespacedName{Namespace: "ns", Name: "ptp"}
+
+	newPod := func(annotations map[string]string) *kapi.Pod {
+		return &kapi.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: podName.Namespace, Name: podName.Name, Annotations: annotations},
+			Spec:       kapi.PodSpec{NodeName: "node1"},
+			Status: kapi.PodStatus{
+				Phase:  kapi.PodRunning,
+				PodIPs: []kapi.PodIP{{IP: "10.244.0.5"}, {IP: "fd00:10:244::5"}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+	})
+
+	It("forwards the traffic of the pod IPs between the physical port and OVN without conntrack", func() {
+		bridge := latencyBypassBridge{ofPortPhys: "1", ofPortPatch: "2", macAddress: bridgeMAC}
+		Expect(generateLatencyBypassFlows("10.244.0.5", bridge)).To(Equal([]string{
+			"cookie=" + defaultOpenFlowCookie + ", priority=110, in_port=2, dl_src=" + bridgeMAC + ", ip, ip_src=10.244.0.5, " +
+				"pkt_mark=0, actions=output:1",
+			"cookie=" + defaultOpenFlowCookie + ", priority=55, in_port=1, ip, ip_dst=10.244.0.5, actions=output:2",
+		}))
+		Expect(generateLatencyBypassFlows("fd00:10:244::5", bridge)).To(Equal([]string{
+			"cookie=" + defaultOpenFlowCookie + ", priority=110, in_port=2, dl_src=" + bridgeMAC + ", ipv6, " +
+				"ipv6_src=fd00:10:244::5, pkt_mark=0, actions=output:1",
+			"cookie=" + defaultOpenFlowCookie + ", priority=55, in_port=1, ipv6, ipv6_dst=fd00:10:244::5, actions=output:2",
+		}))
+		config.IPv6Mode = false
+		Expect(generateLatencyBypassFlows("fd00:10:244::5", bridge)).To(BeEmpty())
+	})
+
+	It("keeps the flows of the pods in sync with their annotation and the gateway bridge", func() {
+		pod := newPod(map[string]string{util.PodLatencyCriticalAnnotation: "true"})
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fake.NewSimpleClientset(pod)}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		mac, err := net.ParseMAC(bridgeMAC)
+		Expect(err).NotTo(HaveOccurred())
+		bridge := &bridgeConfiguration{
+			macAddress: mac,
+			netConfig:  map[string]*bridgeUDNConfiguration{types.DefaultNetworkName: {ofPortPatch: "2"}},
+		}
+		ofm := &openflowManager{defaultBridge: bridge, flowCache: map[string][]string{}, flowChan: make(chan struct{}, 1)}
+		b := newLatencyCriticalBypass(ofm, wf)
+		b.bridge = b.currentBridge()
+		b.AddPod(pod)
+		Expect(ofm.flowCache).NotTo(HaveKey(latencyBypassFlowsKey(podName)))
+
+		// the flows are generated once the physical port is known
+		bridge.ofPortPhys = "1"
+		Expect(b.resync()).To(Succeed())
+		Expect(ofm.flowCache[latencyBypassFlowsKey(podName)]).To(HaveLen(4))
+
+		b.AddPod(newPod(nil))
+		Expect(ofm.flowCache).NotTo(HaveKey(latencyBypassFlowsKey(podName)))
+
+		b.AddPod(pod)
+		Expect(ofm.flowCache).To(HaveKey(latencyBypassFlowsKey(podName)))
+		b.DeletePod(pod)
+		Expect(ofm.flowCache).NotTo(HaveKey(latencyBypassFlowsKey(podName)))
+	})
+})
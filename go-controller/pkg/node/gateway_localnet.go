@@ -129,7 +129,7 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 		}
 		// resync flows on IP change
 		gw.nodeIPManager.OnChanged = func() {
-			klog.V(5).Info("Node addresses changed, re-syncing bridge flows")
+			gatewayLog.V(5).Info("Node addresses changed, re-syncing bridge flows")
 			if err := gw.openflowManager.updateBridgeFlowCache(hostSubnets, gw.nodeIPManager.ListAddresses()); err != nil {
 				// very unlikely - somehow node has lost its IP address
 				klog.Errorf("Failed to re-generate gateway flows after address change: %v", err)
@@ -199,7 +199,7 @@ func getLocalAddrs() (map[string]net.IPNet, error) {
 		}
 		localAddrSet[ip.String()] = *ipNet
 	}
-	klog.V(5).Infof("Node local addresses initialized to: %v", localAddrSet)
+	gatewayLog.V(5).Infof("Node local addresses initialized to: %v", localAddrSet)
 	return localAddrSet, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
@@ -2903,3 +2904,41 @@ var _ = Describe("Node Operations", func() {
 	})
 
 })
+
+var _ = table.DescribeTable("Node gateway service rules per protocol",
+	func(protocol kapi.Protocol, flowProtocolV4, flowProtocolV6 string, flushConntrack bool) {
+		svcPort := kapi.ServicePort{
+			Protocol:   protocol,
+			Port:       8080,
+			NodePort:   31111,
+			TargetPort: intstr.FromInt(8080),
+		}
+		Expect(getNodePortIPTRules(svcPort, "10.96.0.10", svcPort.Port, false, false)).To(Equal([]nodeipt.Rule{
+			{
+				Table: "nat",
+				Chain: iptableNodePortChain,
+				Args: []string{
+					"-p", string(protocol),
+					"-m", "addrtype",
+					"--dst-type", "LOCAL",
+					"--dport", "31111",
+					"-j", "DNAT",
+					"--to-destination", "10.96.0.10:8080",
+				},
+				Protocol: iptables.ProtocolIPv4,
+			},
+		}))
+		Expect(getNodePortETPLocalIPTRule(svcPort, "fd00:10:96::10")).To(Equal(nodeipt.Rule{
+			Table:    "nat",
+			Chain:    iptableMgmPortChain,
+			Args:     []string{"-p", string(protocol), "--dport", "31111", "-j", "RETURN"},
+			Protocol: iptables.ProtocolIPv6,
+		}))
+		Expect(getFlowProtocol(protocol, false)).To(Equal(flowProtocolV4))
+		Expect(getFlowProtocol(protocol, true)).To(Equal(flowProtocolV6))
+		Expect(protocolNeedsConntrackFlush(protocol)).To(Equal(flushConntrack))
+	},
+	table.Entry("TCP", kapi.ProtocolTCP, "tcp", "tcp6", false),
+	table.Entry("UDP", kapi.ProtocolUDP, "udp", "udp6", true),
+	table.Entry("SCTP", kapi.ProtocolSCTP, "sctp", "sctp6", true),
+)
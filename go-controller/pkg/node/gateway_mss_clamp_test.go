@@ -0,0 +1,57 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Gateway TCP MSS clamping", func() {
+	var iptV4, iptV6 util.IPTablesHelper
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		iptV4, iptV6 = util.SetFakeIPTablesHelpers()
+	})
+
+	It("clamps the MSS of the connections leaving the gateway bridge and the management port for both families", func() {
+		Expect(syncMSSClampRules([]string{"breth0", types.K8sMgmtIntfName})).To(Succeed())
+
+		for _, ipt := range []util.IPTablesHelper{iptV4, iptV6} {
+			rules, err := ipt.List("mangle", "POSTROUTING")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules).To(Equal([]string{"-A POSTROUTING -j " + iptableMSSClampChain}))
+			rules, err = ipt.List("mangle", iptableMSSClampChain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules).To(ConsistOf(
+				"-A "+iptableMSSClampChain+" -o breth0 -p tcp --tcp-flags SYN,RST SYN -j TCPMSS --clamp-mss-to-pmtu",
+				"-A "+iptableMSSClampChain+" -o "+types.K8sMgmtIntfName+" -p tcp --tcp-flags SYN,RST SYN -j TCPMSS --clamp-mss-to-pmtu",
+			))
+		}
+
+		// a restarted ovnkube-node keeps the rules of the current interfaces only
+		Expect(syncMSSClampRules([]string{"breth1"})).To(Succeed())
+		rules, err := iptV4.List("mangle", iptableMSSClampChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			"-A " + iptableMSSClampChain + " -o breth1 -p tcp --tcp-flags SYN,RST SYN -j TCPMSS --clamp-mss-to-pmtu",
+		}))
+	})
+
+	It("deletes the chain with the jump to it once disabled", func() {
+		Expect(syncMSSClampRules([]string{"breth0"})).To(Succeed())
+		Expect(cleanupMSSClampRules()).To(Succeed())
+
+		rules, err := iptV4.List("mangle", "POSTROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+		chains, err := iptV4.ListChains("mangle")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(chains).NotTo(ContainElement(iptableMSSClampChain))
+	})
+})
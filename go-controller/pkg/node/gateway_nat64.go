@@ -0,0 +1,103 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// gatewayNAT64Interval is how often the gateway NAT64 instance is reconciled
+	gatewayNAT64Interval = 30 * time.Second
+	// gatewayNAT64Instance is the name of the jool instance translating the pod traffic to the NAT64 prefix
+	gatewayNAT64Instance = "ovn-kubernetes"
+)
+
+// gatewayNAT64 runs a stateful NAT64 on the node for the IPv6 pods of the local gateway mode with a jool
+// netfilter instance: the pod traffic to an IPv4 address embedded in the NAT64 prefix is routed to the host
+// through the management port, where jool translates it to IPv4 from the addresses of the node, and
+// translates the replies back. Resolving the IPv4 only names to the prefix is up to the DNS64 of the cluster.
+type gatewayNAT64 struct {
+	prefix string
+
+	sync.Mutex
+	// syncErr is the error of the last reconciliation, the instance is not ready until it is nil
+	syncErr error
+}
+
+func newGatewayNAT64(prefix string) *gatewayNAT64 {
+	return &gatewayNAT64{
+		prefix:  prefix,
+		syncErr: fmt.Errorf("the NAT64 instance was not reconciled yet"),
+	}
+}
+
+func (n *gatewayNAT64) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, gatewayNAT64Interval, nil, func() {
+		err := n.sync()
+		if err != nil {
+			klog.Errorf("Failed to reconcile the gateway NAT64: %v", err)
+		}
+		n.Lock()
+		n.syncErr = err
+		n.Unlock()
+	})
+}
+
+// healthy returns why the NAT64 instance is not translating the pod traffic
+func (n *gatewayNAT64) healthy() error {
+	n.Lock()
+	defer n.Unlock()
+	return n.syncErr
+}
+
+// sync creates the NAT64 instance when it is missing and replaces it when its prefix changed
+func (n *gatewayNAT64) sync() error {
+	pool6, err := getJoolPool6()
+	if err == nil && pool6 == n.prefix {
+		return nil
+	}
+	if err == nil {
+		klog.Infof("Replacing the gateway NAT64 prefix %s with %s", pool6, n.prefix)
+		if _, stderr, err := util.RunJool("instance", "remove", gatewayNAT64Instance); err != nil {
+			return fmt.Errorf("failed to remove the NAT64 instance, stderr: %q, error: %v", stderr, err)
+		}
+	}
+	klog.Infof("Setting up the gateway NAT64 with prefix %s", n.prefix)
+	if _, stderr, err := util.RunJool("instance", "add", gatewayNAT64Instance, "--netfilter", "--pool6", n.prefix); err != nil {
+		return fmt.Errorf("failed to add the NAT64 instance with prefix %s, stderr: %q, error: %v", n.prefix, stderr, err)
+	}
+	return nil
+}
+
+// getJoolPool6 returns the NAT64 prefix of the instance, an error when there is no instance
+func getJoolPool6() (string, error) {
+	stdout, stderr, err := util.RunJool("--instance", gatewayNAT64Instance, "global", "display", "--csv")
+	if err != nil {
+		return "", fmt.Errorf("failed to display the NAT64 instance, stderr: %q, error: %v", stderr, err)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		if field, value, ok := strings.Cut(strings.TrimSpace(line), ","); ok && field == "pool6" {
+			return value, nil
+		}
+	}
+	return "", nil
+}
+
+// deleteGatewayNAT64 removes the NAT64 instance left over by a previous run with a NAT64 prefix
+func deleteGatewayNAT64() error {
+	if _, err := getJoolPool6(); err != nil {
+		// there is no instance to delete, or no jool to have ever created it
+		return nil
+	}
+	klog.Infof("Deleting the gateway NAT64")
+	if _, stderr, err := util.RunJool("instance", "remove", gatewayNAT64Instance); err != nil {
+		return fmt.Errorf("failed to remove the NAT64 instance, stderr: %q, error: %v", stderr, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Gateway NAT64", func() {
+	const (
+		displayCmd = "jool --instance ovn-kubernetes global display --csv"
+		removeCmd  = "jool instance remove ovn-kubernetes"
+		addCmd     = "jool instance add ovn-kubernetes --netfilter --pool6 64:ff9b::/96"
+	)
+
+	var (
+		n     *gatewayNAT64
+		fexec *ovntest.FakeExec
+	)
+
+	// addDisplayCmd expects the instance to be displayed with the given prefix, or to be missing when it is empty
+	addDisplayCmd := func(pool6 string) {
+		if pool6 == "" {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    displayCmd,
+				Stderr: "The requested instance does not exist.",
+				Err:    fmt.Errorf("exit status 1"),
+			})
+			return
+		}
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    displayCmd,
+			Output: "manually-enabled,false\npool6," + pool6 + "\nlowest-ipv6-mtu,1280",
+		})
+	}
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		n = newGatewayNAT64("64:ff9b::/96")
+	})
+
+	It("adds the NAT64 instance when it is missing", func() {
+		addDisplayCmd("")
+		fexec.AddFakeCmdsNoOutputNoError([]string{addCmd})
+		Expect(n.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("leaves the NAT64 instance alone when it is in sync and replaces it when the prefix changed", func() {
+		addDisplayCmd("64:ff9b::/96")
+		Expect(n.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		addDisplayCmd("64:ff9b:1::/48")
+		fexec.AddFakeCmdsNoOutputNoError([]string{removeCmd, addCmd})
+		Expect(n.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("is not healthy until the NAT64 instance is reconciled", func() {
+		Expect(n.healthy()).NotTo(Succeed())
+	})
+
+	It("deletes the NAT64 instance left over when the NAT64 is disabled", func() {
+		addDisplayCmd("")
+		Expect(deleteGatewayNAT64()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		addDisplayCmd("64:ff9b::/96")
+		fexec.AddFakeCmdsNoOutputNoError([]string{removeCmd})
+		Expect(deleteGatewayNAT64()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
@@ -0,0 +1,446 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ndp"
+	"github.com/vishvananda/netlink"
+
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	// gatewayNextHopHealthCheckInterval is how often the gateway next hops are probed
+	gatewayNextHopHealthCheckInterval = 5 * time.Second
+	// gatewayNextHopProbeTimeout is how long a next hop has to answer a probe
+	gatewayNextHopProbeTimeout = time.Second
+	// gatewayNextHopFailureThreshold is the number of consecutive failed probes after which a next hop is dead
+	gatewayNextHopFailureThreshold = 3
+	// gatewayNextHopRecoveryThreshold is the number of consecutive answered probes after which a dead next hop
+	// is restored
+	gatewayNextHopRecoveryThreshold = 2
+)
+
+// parseGatewayNextHops parses the comma separated gateway next hops
+func parseGatewayNextHops(nextHops string) ([]net.IP, error) {
+	var ips []net.IP
+	for _, nh := range strings.Split(nextHops, ",") {
+		// Parse NextHop to make sure it is valid before using. Return error if not valid.
+		nextHop := net.ParseIP(strings.TrimSpace(nh))
+		if nextHop == nil {
+			return nil, fmt.Errorf("failed to parse configured next-hop: %s", nextHops)
+		}
+		ips = append(ips, nextHop)
+	}
+	return ips, nil
+}
+
+// healthCheckedGatewayNextHops returns the configured gateway next hops of the IP families with several
+// of them, the ones that are health checked
+func healthCheckedGatewayNextHops() []net.IP {
+	if config.Gateway.NextHop == "" {
+		return nil
+	}
+	nextHops, err := parseGatewayNextHops(config.Gateway.NextHop)
+	if err != nil {
+		return nil
+	}
+	var v4NextHops, v6NextHops []net.IP
+	for _, nextHop := range nextHops {
+		if utilnet.IsIPv6(nextHop) {
+			if config.IPv6Mode {
+				v6NextHops = append(v6NextHops, nextHop)
+			}
+		} else if config.IPv4Mode {
+			v4NextHops = append(v4NextHops, nextHop)
+		}
+	}
+	var checked []net.IP
+	if len(v4NextHops) > 1 {
+		checked = append(checked, v4NextHops...)
+	}
+	if len(v6NextHops) > 1 {
+		checked = append(checked, v6NextHops...)
+	}
+	return checked
+}
+
+// nextHopHealth is the health of a gateway next hop
+type nextHopHealth struct {
+	healthy bool
+	// failures and successes are the numbers of consecutive failed and answered probes
+	failures  int
+	successes int
+}
+
+// nextHopProber checks that a gateway next hop answers
+type nextHopProber interface {
+	// probe resolves the next hop out of the interface
+	probe(nextHop net.IP, ifaceName string) error
+	// close releases the resources of the prober, the probes fail afterwards
+	close()
+}
+
+// hostRouteManager manages the host default routes the next hops are failed over with
+type hostRouteManager interface {
+	Add(r netlink.Route)
+	Replace(oldRoute, newRoute netlink.Route) error
+}
+
+// gatewayNextHopHealthChecker probes the gateway next hops of the IP families configured with several of
+// them, with ARP for IPv4 and NDP for IPv6, out of the gateway bridge. A next hop failing
+// gatewayNextHopFailureThreshold consecutive probes is removed from the next hops of the node L3 gateway
+// config, and so from the ECMP default routes of the gateway router; it is restored after
+// gatewayNextHopRecoveryThreshold consecutive answered probes. When all the next hops of an IP family are
+// dead, all of them are kept since there is nothing to fail over to.
+// The host default route of an IP family goes through the first active next hop, it is managed by the
+// route manager, which does not manage multipath routes.
+type gatewayNextHopHealthChecker struct {
+	nodeName      string
+	bridgeName    string
+	nextHops      []net.IP
+	watchFactory  factory.NodeWatchFactory
+	nodeAnnotator kube.Annotator
+	routeManager  hostRouteManager
+	prober        nextHopProber
+	health        map[string]*nextHopHealth
+	// hostRoutes are the host default routes of each IP family, as last added to the route manager
+	hostRoutes map[bool]*netlink.Route
+}
+
+func newGatewayNextHopHealthChecker(nodeName, bridgeName string, nextHops []net.IP, k kube.Interface,
+	watchFactory factory.NodeWatchFactory, routeManager hostRouteManager, prober nextHopProber) *gatewayNextHopHealthChecker {
+	c := &gatewayNextHopHealthChecker{
+		nodeName:      nodeName,
+		bridgeName:    bridgeName,
+		nextHops:      nextHops,
+		watchFactory:  watchFactory,
+		nodeAnnotator: newNodeAnnotator(k, nodeName),
+		routeManager:  routeManager,
+		prober:        prober,
+		health:        map[string]*nextHopHealth{},
+		hostRoutes:    map[bool]*netlink.Route{},
+	}
+	for _, nextHop := range nextHops {
+		c.health[nextHop.String()] = &nextHopHealth{healthy: true}
+	}
+	return c
+}
+
+func (c *gatewayNextHopHealthChecker) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, gatewayNextHopHealthCheckInterval, nil, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to fail over the gateway next hops of node %s: %v", c.nodeName, err)
+		}
+	})
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		<-stopChan
+		c.prober.close()
+	}()
+}
+
+// sync probes the next hops and updates the default routes with the healthy ones
+func (c *gatewayNextHopHealthChecker) sync() error {
+	c.probeNextHops()
+	active := c.activeNextHops()
+
+	var errs []error
+	for _, isIPv6 := range []bool{false, true} {
+		nextHops := filterIPsByFamily(active, isIPv6)
+		if len(nextHops) == 0 {
+			continue
+		}
+		if err := c.setHostDefaultRoute(nextHops[0]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set the host default route via %s: %w", nextHops[0], err))
+		}
+	}
+	if err := c.updateGatewayConfig(active); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.Join(errs...)
+}
+
+// probeNextHops probes every next hop and updates its health and metric
+func (c *gatewayNextHopHealthChecker) probeNextHops() {
+	for _, nextHop := range c.nextHops {
+		health := c.health[nextHop.String()]
+		if err := c.prober.probe(nextHop, c.bridgeName); err != nil {
+			gatewayLog.V(5).Infof("Gateway next hop %s did not answer: %v", nextHop, err)
+			health.successes = 0
+			health.failures++
+			if health.healthy && health.failures >= gatewayNextHopFailureThreshold {
+				klog.Warningf("Gateway next hop %s of node %s is dead after %d failed probes: %v",
+					nextHop, c.nodeName, health.failures, err)
+				health.healthy = false
+			}
+		} else {
+			health.failures = 0
+			health.successes++
+			if !health.healthy && health.successes >= gatewayNextHopRecoveryThreshold {
+				klog.Infof("Gateway next hop %s of node %s recovered", nextHop, c.nodeName)
+				health.healthy = true
+			}
+		}
+		healthy := 0.0
+		if health.healthy {
+			healthy = 1
+		}
+		metrics.MetricNodeGatewayNextHopHealthy.WithLabelValues(nextHop.String()).Set(healthy)
+	}
+}
+
+// activeNextHops returns the healthy next hops, or all the next hops of an IP family when none of them is
+// healthy
+func (c *gatewayNextHopHealthChecker) activeNextHops() []net.IP {
+	var active []net.IP
+	for _, isIPv6 := range []bool{false, true} {
+		nextHops := filterIPsByFamily(c.nextHops, isIPv6)
+		var healthy []net.IP
+		for _, nextHop := range nextHops {
+			if c.health[nextHop.String()].healthy {
+				healthy = append(healthy, nextHop)
+			}
+		}
+		if len(healthy) == 0 && len(nextHops) > 0 {
+			klog.Warningf("All the gateway next hops %v of node %s are dead, keeping them", nextHops, c.nodeName)
+			healthy = nextHops
+		}
+		active = append(active, healthy...)
+	}
+	return active
+}
+
+// updateGatewayConfig sets the health checked next hops of the node L3 gateway config to the active ones,
+// the next hops of the other IP families are kept
+func (c *gatewayNextHopHealthChecker) updateGatewayConfig(active []net.IP) error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	l3GwConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return err
+	}
+	var nextHops []net.IP
+	for _, isIPv6 := range []bool{false, true} {
+		if len(filterIPsByFamily(c.nextHops, isIPv6)) > 0 {
+			nextHops = append(nextHops, filterIPsByFamily(active, isIPv6)...)
+		} else {
+			nextHops = append(nextHops, filterIPsByFamily(l3GwConfig.NextHops, isIPv6)...)
+		}
+	}
+	if joinIPs(nextHops) == joinIPs(l3GwConfig.NextHops) {
+		return nil
+	}
+	klog.Infof("Updating the gateway next hops of node %s from %v to %v", c.nodeName, l3GwConfig.NextHops, nextHops)
+	l3GwConfig.NextHops = nextHops
+	if err := util.SetL3GatewayConfig(c.nodeAnnotator, l3GwConfig); err != nil {
+		return err
+	}
+	return c.nodeAnnotator.Run()
+}
+
+func filterIPsByFamily(ips []net.IP, isIPv6 bool) []net.IP {
+	var filtered []net.IP
+	for _, ip := range ips {
+		if utilnet.IsIPv6(ip) == isIPv6 {
+			filtered = append(filtered, ip)
+		}
+	}
+	return filtered
+}
+
+func joinIPs(ips []net.IP) string {
+	s := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		s = append(s, ip.String())
+	}
+	return strings.Join(s, ",")
+}
+
+// neighborProber probes the next hops with ARP for the IPv4 addresses and with neighbor solicitations for the
+// IPv6 addresses. It keeps a socket per IP family open on the interface across the probes, the sockets are
+// reopened when the interface is recreated or after an error other than a probe timeout.
+type neighborProber struct {
+	sync.Mutex
+	iface     *net.Interface
+	arpClient *arp.Client
+	ndpConn   *ndp.Conn
+	closed    bool
+}
+
+func newNeighborProber() *neighborProber {
+	return &neighborProber{}
+}
+
+func (p *neighborProber) probe(nextHop net.IP, ifaceName string) error {
+	p.Lock()
+	defer p.Unlock()
+	if p.closed {
+		return fmt.Errorf("the prober is closed")
+	}
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("failed finding interface %s: %w", ifaceName, err)
+	}
+	if p.iface != nil && (p.iface.Name != iface.Name || p.iface.Index != iface.Index) {
+		p.closeSockets()
+	}
+	p.iface = iface
+	addr, err := netip.ParseAddr(nextHop.String())
+	if err != nil {
+		return fmt.Errorf("failed converting net.IP to netip.Addr: %w", err)
+	}
+	if addr.Is4() {
+		err = p.probeARP(addr)
+	} else {
+		err = p.probeNDP(addr)
+	}
+	var netErr net.Error
+	if err != nil && !(errors.As(err, &netErr) && netErr.Timeout()) {
+		p.closeSockets()
+	}
+	return err
+}
+
+func (p *neighborProber) probeARP(addr netip.Addr) error {
+	if p.arpClient == nil {
+		c, err := arp.Dial(p.iface)
+		if err != nil {
+			return fmt.Errorf("failed dialing interface %s: %w", p.iface.Name, err)
+		}
+		p.arpClient = c
+	}
+	if err := p.arpClient.SetDeadline(time.Now().Add(gatewayNextHopProbeTimeout)); err != nil {
+		return err
+	}
+	_, err := p.arpClient.Resolve(addr)
+	return err
+}
+
+func (p *neighborProber) probeNDP(addr netip.Addr) error {
+	if p.ndpConn == nil {
+		c, _, err := ndp.Listen(p.iface, ndp.LinkLocal)
+		if err != nil {
+			return fmt.Errorf("failed to dial NDP connection on interface %s: %w", p.iface.Name, err)
+		}
+		p.ndpConn = c
+	}
+	if err := p.ndpConn.SetDeadline(time.Now().Add(gatewayNextHopProbeTimeout)); err != nil {
+		return err
+	}
+	snm, err := ndp.SolicitedNodeMulticast(addr)
+	if err != nil {
+		return err
+	}
+	m := &ndp.NeighborSolicitation{
+		TargetAddress: addr,
+		Options: []ndp.Option{
+			&ndp.LinkLayerAddress{
+				Direction: ndp.Source,
+				Addr:      p.iface.HardwareAddr,
+			},
+		},
+	}
+	if err := p.ndpConn.WriteTo(m, nil, snm); err != nil {
+		return fmt.Errorf("failed sending neighbor solicitation: %w", err)
+	}
+	for {
+		msg, _, _, err := p.ndpConn.ReadFrom()
+		if err != nil {
+			return err
+		}
+		if na, ok := msg.(*ndp.NeighborAdvertisement); ok && na.TargetAddress == addr {
+			return nil
+		}
+	}
+}
+
+func (p *neighborProber) close() {
+	p.Lock()
+	defer p.Unlock()
+	p.closed = true
+	p.closeSockets()
+}
+
+func (p *neighborProber) closeSockets() {
+	if p.arpClient != nil {
+		p.arpClient.Close()
+		p.arpClient = nil
+	}
+	if p.ndpConn != nil {
+		p.ndpConn.Close()
+		p.ndpConn = nil
+	}
+}
+
+// probeGatewayNextHop resolves the next hop with ARP for an IPv4 address or with a neighbor solicitation for
+// an IPv6 address out of the interface, with sockets opened for this probe only
+func probeGatewayNextHop(nextHop net.IP, ifaceName string) error {
+	p := newNeighborProber()
+	defer p.close()
+	return p.probe(nextHop, ifaceName)
+}
+
+// setHostDefaultRoute routes the host default route of the IP family of the next hop through it, out of the
+// bridge. Only the next hop of the route changes, the source, MTU and priority of the host default route
+// are kept.
+func (c *gatewayNextHopHealthChecker) setHostDefaultRoute(nextHop net.IP) error {
+	isIPv6 := utilnet.IsIPv6(nextHop)
+	current := c.hostRoutes[isIPv6]
+	if current != nil && current.Gw.Equal(nextHop) {
+		return nil
+	}
+	link, err := util.GetNetLinkOps().LinkByName(c.bridgeName)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", c.bridgeName, err)
+	}
+	defaultSubnet := &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)}
+	family := netlink.FAMILY_V4
+	if isIPv6 {
+		defaultSubnet = &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+		family = netlink.FAMILY_V6
+	}
+	route := netlink.Route{LinkIndex: link.Attrs().Index, Dst: defaultSubnet, Gw: nextHop, Table: routemanager.MainTableID}
+	if current == nil {
+		existing, err := util.GetNetLinkOps().RouteListFiltered(family, &route,
+			netlink.RT_FILTER_DST|netlink.RT_FILTER_OIF|netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return fmt.Errorf("failed to list the default routes of %s: %w", c.bridgeName, err)
+		}
+		if len(existing) > 0 {
+			route.Src, route.MTU, route.Priority = existing[0].Src, existing[0].MTU, existing[0].Priority
+		}
+		klog.Infof("Routing the host default route of node %s through gateway next hop %s", c.nodeName, nextHop)
+		c.routeManager.Add(route)
+	} else {
+		// the route through the next hop replaces the current one, the host is never left without a default
+		// route and keeps the current one when the new one fails to apply
+		route.Src, route.MTU, route.Priority = current.Src, current.MTU, current.Priority
+		klog.Infof("Routing the host default route of node %s through gateway next hop %s instead of %s",
+			c.nodeName, nextHop, current.Gw)
+		if err := c.routeManager.Replace(*current, route); err != nil {
+			return err
+		}
+	}
+	c.hostRoutes[isIPv6] = &route
+	return nil
+}
@@ -0,0 +1,211 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	linkMock "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+// fakeNextHopProber fails the probes of the dead next hops
+type fakeNextHopProber struct {
+	dead sets.Set[string]
+}
+
+func (f *fakeNextHopProber) probe(nextHop net.IP, ifaceName string) error {
+	Expect(ifaceName).To(Equal("breth0"))
+	if f.dead.Has(nextHop.String()) {
+		return fmt.Errorf("no answer")
+	}
+	return nil
+}
+
+func (f *fakeNextHopProber) close() {}
+
+// fakeHostRouteManager records the host default routes and fails their replacements on demand
+type fakeHostRouteManager struct {
+	routes     []netlink.Route
+	replaceErr error
+}
+
+func (f *fakeHostRouteManager) Add(r netlink.Route) {
+	f.routes = append(f.routes, r)
+}
+
+func (f *fakeHostRouteManager) Replace(oldRoute, newRoute netlink.Route) error {
+	if f.replaceErr != nil {
+		return f.replaceErr
+	}
+	for i := range f.routes {
+		if f.routes[i].Gw.Equal(oldRoute.Gw) {
+			f.routes[i] = newRoute
+			return nil
+		}
+	}
+	return fmt.Errorf("route %s not found", oldRoute)
+}
+
+var _ = Describe("Gateway next hop health checker", func() {
+	const nodeName = "node1"
+	var (
+		kubeFakeClient *fake.Clientset
+		wf             *factory.WatchFactory
+		c              *gatewayNextHopHealthChecker
+		prober         *fakeNextHopProber
+		routeManager   *fakeHostRouteManager
+		netlinkOpsMock *utilMocks.NetLinkOps
+		hostRoutes     []string
+	)
+	origNetlinkOps := util.GetNetLinkOps()
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		config.Gateway.NextHop = "10.0.0.1,fd00::1,10.0.0.2"
+
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+				Annotations: map[string]string{
+					util.OvnNodeChassisID: "1a3dfc82-2749-4931-9190-c30e7c0ecea3",
+					util.OvnNodeL3GatewayConfig: `{"default":{"mode":"shared","mac-address":"0a:58:0a:00:00:0a",` +
+						`"ip-addresses":["10.0.0.10/24","fd00::10/64"],"next-hops":["10.0.0.1","10.0.0.2","fd00::1"]}}`,
+				},
+			},
+		}
+		kubeFakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{node}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+
+		netlinkOpsMock = &utilMocks.NetLinkOps{}
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+		bridgeLink := &linkMock.Link{}
+		bridgeLink.On("Attrs").Return(&netlink.LinkAttrs{Name: "breth0", Index: 5})
+		netlinkOpsMock.On("LinkByName", "breth0").Return(bridgeLink, nil)
+		netlinkOpsMock.On("RouteListFiltered", netlink.FAMILY_V4, mock.Anything, mock.Anything).Return([]netlink.Route{{
+			LinkIndex: 5,
+			Dst:       &net.IPNet{IP: net.IPv4zero, Mask: net.CIDRMask(0, 32)},
+			Gw:        net.ParseIP("10.0.0.1"),
+			Src:       net.ParseIP("10.0.0.10"),
+			Priority:  100,
+		}}, nil)
+
+		prober = &fakeNextHopProber{dead: sets.New[string]()}
+		routeManager = &fakeHostRouteManager{}
+		hostRoutes = nil
+		c = newGatewayNextHopHealthChecker(nodeName, "breth0", healthCheckedGatewayNextHops(),
+			&kube.Kube{KClient: kubeFakeClient}, wf, routeManager, prober)
+	})
+
+	// syncHostRoutes syncs the checker and records the next hop of the host IPv4 default route
+	syncHostRoutes := func() {
+		Expect(c.sync()).To(Succeed())
+		route := c.hostRoutes[false]
+		Expect(route).NotTo(BeNil())
+		// the attributes of the host default route are kept
+		Expect(route.LinkIndex).To(Equal(5))
+		Expect(route.Src.String()).To(Equal("10.0.0.10"))
+		Expect(route.Priority).To(Equal(100))
+		Expect(routeManager.routes).To(HaveLen(1))
+		Expect(routeManager.routes[0]).To(Equal(*route))
+		if len(hostRoutes) == 0 || hostRoutes[len(hostRoutes)-1] != route.Gw.String() {
+			hostRoutes = append(hostRoutes, route.Gw.String())
+		}
+	}
+
+	AfterEach(func() {
+		wf.Shutdown()
+		util.SetNetLinkOpMockInst(origNetlinkOps)
+	})
+
+	// getNextHops returns the next hops of the node L3 gateway config once the informer caught up with it
+	getNextHops := func() string {
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() string {
+			cached, err := wf.GetNode(nodeName)
+			Expect(err).NotTo(HaveOccurred())
+			return cached.Annotations[util.OvnNodeL3GatewayConfig]
+		}).Should(Equal(node.Annotations[util.OvnNodeL3GatewayConfig]))
+		l3GwConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+		Expect(err).NotTo(HaveOccurred())
+		return joinIPs(l3GwConfig.NextHops)
+	}
+
+	It("health checks the next hops of the IP families with several of them", func() {
+		Expect(c.nextHops).To(Equal([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")}))
+	})
+
+	It("removes a dead next hop from the default routes and restores it when it recovers", func() {
+		syncHostRoutes()
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1"}))
+		Expect(getNextHops()).To(Equal("10.0.0.1,10.0.0.2,fd00::1"))
+
+		prober.dead.Insert("10.0.0.1")
+		for i := 1; i < gatewayNextHopFailureThreshold; i++ {
+			syncHostRoutes()
+		}
+		Expect(getNextHops()).To(Equal("10.0.0.1,10.0.0.2,fd00::1"))
+		syncHostRoutes()
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		Expect(getNextHops()).To(Equal("10.0.0.2,fd00::1"))
+
+		prober.dead.Delete("10.0.0.1")
+		for i := 1; i < gatewayNextHopRecoveryThreshold; i++ {
+			syncHostRoutes()
+		}
+		Expect(getNextHops()).To(Equal("10.0.0.2,fd00::1"))
+		syncHostRoutes()
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1", "10.0.0.2", "10.0.0.1"}))
+		Expect(getNextHops()).To(Equal("10.0.0.1,10.0.0.2,fd00::1"))
+	})
+
+	It("keeps all the next hops of an IP family when all of them are dead", func() {
+		prober.dead.Insert("10.0.0.1", "10.0.0.2")
+		for i := 0; i < gatewayNextHopFailureThreshold; i++ {
+			syncHostRoutes()
+		}
+		Expect(c.health["10.0.0.1"].healthy).To(BeFalse())
+		Expect(c.health["10.0.0.2"].healthy).To(BeFalse())
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1"}))
+		Expect(getNextHops()).To(Equal("10.0.0.1,10.0.0.2,fd00::1"))
+	})
+	It("keeps the host default route on the dead next hop when the route through another one fails", func() {
+		syncHostRoutes()
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1"}))
+
+		routeManager.replaceErr = fmt.Errorf("failed to replace the route")
+		prober.dead.Insert("10.0.0.1")
+		for i := 1; i < gatewayNextHopFailureThreshold; i++ {
+			syncHostRoutes()
+		}
+		Expect(c.sync()).To(MatchError(ContainSubstring("failed to replace the route")))
+		Expect(c.hostRoutes[false].Gw.String()).To(Equal("10.0.0.1"))
+		Expect(routeManager.routes[0].Gw.String()).To(Equal("10.0.0.1"))
+
+		routeManager.replaceErr = nil
+		syncHostRoutes()
+		Expect(hostRoutes).To(Equal([]string{"10.0.0.1", "10.0.0.2"}))
+		Expect(routeManager.routes[0].Gw.String()).To(Equal("10.0.0.2"))
+	})
+})
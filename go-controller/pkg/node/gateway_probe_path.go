@@ -0,0 +1,243 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// probePathSyncPeriod is how often the rules of the probe path are checked and the drops counted
+const probePathSyncPeriod = time.Minute
+
+// probePathController sends the host traffic to the NodePorts, external IPs and load balancer IPs of the services
+// with externalTrafficPolicy=Local straight to their endpoints on the node, instead of through the gateway where
+// the kubelet probes of the services can be SNATed or sent to the endpoints of other nodes. The traffic keeps the
+// node IP as source: the endpoints reply to it through the management port, which the OVN routing policies send
+// the traffic of the pods to the node IPs to. With host traffic cgroups, only the traffic of their sockets takes
+// the probe path.
+//
+// The services with a host network endpoint on the node are left to the gateway, which already sends the host
+// traffic to it. The host traffic to a service without endpoint on the node is counted as dropped from the probe
+// path and goes through the gateway.
+type probePathController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	// nodeIPs returns the addresses of the node the host network endpoints are found with
+	nodeIPs func() []net.IP
+	trigger chan struct{}
+
+	// counted are the drops already counted by RETURN rule, until the rules are recreated with no drop
+	counted map[string]uint64
+	// dropServices are the services with drop metrics
+	dropServices sets.Set[string]
+}
+
+func newProbePathController(nodeName string, watchFactory factory.NodeWatchFactory, nodeIPs func() []net.IP) *probePathController {
+	return &probePathController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		nodeIPs:      nodeIPs,
+		trigger:      make(chan struct{}, 1),
+		counted:      map[string]uint64{},
+		dropServices: sets.New[string](),
+	}
+}
+
+func (c *probePathController) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.AddServiceHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if util.ServiceExternalTrafficPolicyLocal(obj.(*kapi.Service)) {
+				c.requestSync()
+			}
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if util.ServiceExternalTrafficPolicyLocal(old.(*kapi.Service)) ||
+				util.ServiceExternalTrafficPolicyLocal(new.(*kapi.Service)) {
+				c.requestSync()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.requestSync()
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("could not add service event handler for the probe path: %w", err)
+	}
+	endpointSliceChanged := func(obj interface{}) {
+		epSlice, ok := obj.(*discovery.EndpointSlice)
+		if !ok {
+			c.requestSync()
+			return
+		}
+		service, err := c.watchFactory.GetService(epSlice.Namespace, epSlice.Labels[discovery.LabelServiceName])
+		if err != nil || util.ServiceExternalTrafficPolicyLocal(service) {
+			c.requestSync()
+		}
+	}
+	_, err = c.watchFactory.AddFilteredEndpointSliceHandler("", labels.Everything(), cache.ResourceEventHandlerFuncs{
+		AddFunc: endpointSliceChanged,
+		UpdateFunc: func(_, new interface{}) {
+			endpointSliceChanged(new)
+		},
+		DeleteFunc: endpointSliceChanged,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("could not add endpointslice event handler for the probe path: %w", err)
+	}
+
+	runPeriodicSync(stopChan, doneWg, probePathSyncPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to sync the probe path of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *probePathController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *probePathController) sync() error {
+	services, err := c.watchFactory.GetServices()
+	if err != nil {
+		return fmt.Errorf("failed to list the services: %w", err)
+	}
+	var errs []error
+	var rules []nodeipt.Rule
+	probed := sets.New[string]()
+	nodeIPs := c.nodeIPs()
+	for _, service := range services {
+		if !util.ServiceExternalTrafficPolicyLocal(service) || !util.ServiceTypeHasClusterIP(service) ||
+			!util.IsClusterIPSet(service) {
+			continue
+		}
+		epSlices, err := c.watchFactory.GetServiceEndpointSlices(service.Namespace, service.Name, types.DefaultNetworkName)
+		if err != nil && !kerrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to get the endpointslices of service %s/%s: %w",
+				service.Namespace, service.Name, err))
+			continue
+		}
+		localEndpoints := util.GetLocalEligibleEndpointAddressesFromSlices(epSlices, service, c.nodeName)
+		if util.HasLocalHostNetworkEndpoints(localEndpoints, nodeIPs) {
+			continue
+		}
+		probed.Insert(service.Namespace + "/" + service.Name)
+		rules = append(rules, getProbePathRules(service, getProbePathEndpoints(epSlices, service, localEndpoints))...)
+	}
+
+	// the drops are counted before the rules are recreated with no drop
+	if err := c.countDrops(probed); err != nil {
+		errs = append(errs, err)
+	}
+	if err := syncProbePathRules(rules); err != nil {
+		errs = append(errs, err)
+	} else {
+		c.counted = map[string]uint64{}
+	}
+	metrics.MetricNodeProbePathServices.Set(float64(probed.Len()))
+	return utilerrors.Join(errs...)
+}
+
+// getProbePathEndpoints returns the local endpoints of the service by the port of the endpointslices they are
+// reached on, a named target port can resolve to a different number on each endpoint
+func getProbePathEndpoints(epSlices []*discovery.EndpointSlice, service *kapi.Service,
+	localEndpoints sets.Set[string]) map[util.EndpointPortKey][]probePathEndpoint {
+	unique := map[util.EndpointPortKey]sets.Set[probePathEndpoint]{}
+	for _, epSlice := range epSlices {
+		for ip, ports := range util.GetEligibleEndpointTargetPorts(epSlice, service) {
+			if !localEndpoints.Has(ip) {
+				continue
+			}
+			for key, port := range ports {
+				if unique[key] == nil {
+					unique[key] = sets.New[probePathEndpoint]()
+				}
+				unique[key].Insert(probePathEndpoint{ip: ip, port: port})
+			}
+		}
+	}
+	endpoints := make(map[util.EndpointPortKey][]probePathEndpoint, len(unique))
+	for key, set := range unique {
+		list := set.UnsortedList()
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].ip != list[j].ip {
+				return list[i].ip < list[j].ip
+			}
+			return list[i].port < list[j].port
+		})
+		endpoints[key] = list
+	}
+	return endpoints
+}
+
+// countDrops adds the connections the RETURN rules of the probe path counted since they were last counted to the
+// drop metrics, and deletes the metrics of the services the probe path does not handle anymore
+func (c *probePathController) countDrops(probed sets.Set[string]) error {
+	var errs []error
+	for _, proto := range clusterIPTablesProtocols() {
+		ipt, err := util.GetIPTablesHelper(proto)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules, err := ipt.ListWithCounters("nat", iptableProbeChain)
+		if err != nil {
+			// the chain does not exist before the first sync
+			continue
+		}
+		for _, rule := range rules {
+			service, drops, ok := parseProbePathDrop(rule)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%v %s", proto, probePathRuleKey(rule))
+			if drops > c.counted[key] {
+				namespace, name, _ := strings.Cut(service, "/")
+				metrics.MetricNodeProbePathDrops.WithLabelValues(namespace, name).Add(float64(drops - c.counted[key]))
+				c.dropServices.Insert(service)
+			}
+			c.counted[key] = drops
+		}
+	}
+	for _, service := range sets.List(c.dropServices.Difference(probed)) {
+		namespace, name, _ := strings.Cut(service, "/")
+		metrics.MetricNodeProbePathDrops.DeleteLabelValues(namespace, name)
+		c.dropServices.Delete(service)
+	}
+	return utilerrors.Join(errs...)
+}
+
+// probePathRuleKey returns the rule listed with its counters without them
+func probePathRuleKey(rule string) string {
+	fields := strings.Fields(rule)
+	key := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "-c" && i+2 < len(fields) {
+			i += 2
+			continue
+		}
+		key = append(key, fields[i])
+	}
+	return strings.Join(key, " ")
+}
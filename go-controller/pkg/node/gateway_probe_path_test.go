@@ -0,0 +1,147 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("ETP=Local probe path", func() {
+	var (
+		wf    *factory.WatchFactory
+		iptV4 util.IPTablesHelper
+	)
+
+	newController := func(objects ...runtime.Object) *probePathController {
+		client := fake.NewSimpleClientset(objects...)
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: client}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		return newProbePathController("node1", wf, func() []net.IP { return []net.IP{net.ParseIP("172.18.0.2")} })
+	}
+
+	localEndpoint := func(ip, node string, ready bool) discovery.Endpoint {
+		return discovery.Endpoint{
+			Addresses:  []string{ip},
+			Conditions: discovery.EndpointConditions{Ready: ptr.To(ready)},
+			NodeName:   ptr.To(node),
+		}
+	}
+
+	servicePort := kapi.ServicePort{Name: "http", Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 30080}
+	endpointPort := discovery.EndpointPort{Name: ptr.To("http"), Protocol: ptr.To(kapi.ProtocolTCP), Port: ptr.To(int32(8080))}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		iptV4, _ = util.SetFakeIPTablesHelpers()
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	It("sends the host traffic to the endpoints on the node without SNAT", func() {
+		c := newController(
+			newService("web", "ns", "10.96.0.10", []kapi.ServicePort{servicePort}, kapi.ServiceTypeNodePort,
+				[]string{"192.168.10.10"}, kapi.ServiceStatus{}, true, false),
+			newEndpointSlice("web", "ns", []discovery.Endpoint{
+				localEndpoint("10.244.1.3", "node1", true),
+				localEndpoint("10.244.1.4", "node1", true),
+				localEndpoint("10.244.2.3", "node2", true),
+			}, []discovery.EndpointPort{endpointPort}),
+		)
+		Expect(c.sync()).To(Succeed())
+
+		rules, err := iptV4.List("nat", "OUTPUT")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{"-A OUTPUT -j " + iptableProbeChain}))
+		rules, err = iptV4.List("nat", "POSTROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{"-A POSTROUTING -o " + types.K8sMgmtIntfName + " -j " + iptableProbeSNATChain}))
+
+		nodePort := "-A " + iptableProbeChain + " -p TCP -m addrtype --dst-type LOCAL --dport 30080 -m comment --comment ns/web"
+		externalIP := "-A " + iptableProbeChain + " -p TCP -d 192.168.10.10 --dport 80 -m comment --comment ns/web"
+		rules, err = iptV4.List("nat", iptableProbeChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			nodePort + " -j DNAT --to-destination 10.244.1.3:8080 -m statistic --mode random --probability 0.5000000000",
+			nodePort + " -j DNAT --to-destination 10.244.1.4:8080 -m statistic --mode random --probability 1.0000000000",
+			externalIP + " -j DNAT --to-destination 10.244.1.3:8080 -m statistic --mode random --probability 0.5000000000",
+			externalIP + " -j DNAT --to-destination 10.244.1.4:8080 -m statistic --mode random --probability 1.0000000000",
+		}))
+		rules, err = iptV4.List("nat", iptableProbeSNATChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(ConsistOf(
+			"-A "+iptableProbeSNATChain+" -p TCP -d 10.244.1.3 --dport 8080 -m conntrack --ctstate DNAT --ctorigdstport 80 -j ACCEPT",
+			"-A "+iptableProbeSNATChain+" -p TCP -d 10.244.1.4 --dport 8080 -m conntrack --ctstate DNAT --ctorigdstport 80 -j ACCEPT",
+			"-A "+iptableProbeSNATChain+" -p TCP -d 10.244.1.3 --dport 8080 -m conntrack --ctstate DNAT --ctorigdstport 30080 -j ACCEPT",
+			"-A "+iptableProbeSNATChain+" -p TCP -d 10.244.1.4 --dport 8080 -m conntrack --ctstate DNAT --ctorigdstport 30080 -j ACCEPT",
+		))
+	})
+
+	It("only sends the traffic of the host traffic cgroups with host traffic cgroups", func() {
+		config.OvnKubeNode.HostTrafficCgroups = "/system.slice/kubelet.service"
+		c := newController(
+			newService("web", "ns", "10.96.0.10", []kapi.ServicePort{servicePort}, kapi.ServiceTypeNodePort,
+				nil, kapi.ServiceStatus{}, true, false),
+			newEndpointSlice("web", "ns", []discovery.Endpoint{localEndpoint("10.244.1.3", "node1", true)},
+				[]discovery.EndpointPort{endpointPort}),
+		)
+		Expect(c.sync()).To(Succeed())
+
+		rules, err := iptV4.List("nat", iptableProbeChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			"-A " + iptableProbeChain + " -p TCP -m addrtype --dst-type LOCAL --dport 30080 -m mark --mark " +
+				ovnkubeHostTrafficMark + "/" + ovnkubeHostTrafficMark + " -m comment --comment ns/web -j DNAT " +
+				"--to-destination 10.244.1.3:8080 -m statistic --mode random --probability 1.0000000000",
+		}))
+	})
+
+	It("counts the traffic to the services without endpoint on the node as dropped", func() {
+		c := newController(
+			newService("remote", "ns", "10.96.0.11", []kapi.ServicePort{servicePort}, kapi.ServiceTypeNodePort,
+				nil, kapi.ServiceStatus{}, true, false),
+			newEndpointSlice("remote", "ns", []discovery.Endpoint{
+				localEndpoint("10.244.1.3", "node1", false),
+				localEndpoint("10.244.2.3", "node2", true),
+			}, []discovery.EndpointPort{endpointPort}),
+			newService("cluster", "ns", "10.96.0.12", []kapi.ServicePort{servicePort}, kapi.ServiceTypeNodePort,
+				nil, kapi.ServiceStatus{}, false, false),
+			newService("host", "ns", "10.96.0.13", []kapi.ServicePort{servicePort}, kapi.ServiceTypeNodePort,
+				nil, kapi.ServiceStatus{}, true, false),
+			newEndpointSlice("host", "ns", []discovery.Endpoint{localEndpoint("172.18.0.2", "node1", true)},
+				[]discovery.EndpointPort{endpointPort}),
+		)
+		Expect(c.sync()).To(Succeed())
+
+		rules, err := iptV4.List("nat", iptableProbeChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(Equal([]string{
+			"-A " + iptableProbeChain + " -p TCP -m addrtype --dst-type LOCAL --dport 30080 -m comment --comment ns/remote -j RETURN",
+		}))
+		rules, err = iptV4.List("nat", iptableProbeSNATChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+
+		service, drops, ok := parseProbePathDrop("-A " + iptableProbeChain + " -p tcp -m addrtype --dst-type LOCAL " +
+			"-m tcp --dport 30080 -m comment --comment \"ns/remote\" -c 3 180 -j RETURN")
+		Expect(ok).To(BeTrue())
+		Expect(service).To(Equal("ns/remote"))
+		Expect(drops).To(BeEquivalentTo(3))
+	})
+})
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+// gatewayRenderInput is the node the gateway state is rendered for, as discovered on the host by the gateway
+type gatewayRenderInput struct {
+	// bridge is the gateway bridge, with its IPs, MAC address and OpenFlow ports
+	bridge *bridgeConfiguration
+	// hostSubnets are the pod subnets of the node
+	hostSubnets []*net.IPNet
+	// extraIPs are the other IPs of the node
+	extraIPs []net.IP
+	// encapIP is the ovn-encap-ip of the node
+	encapIP string
+	// mgmtPortName and mgmtPortIPs are the name and the IPs of the management port
+	mgmtPortName string
+	mgmtPortIPs  []*net.IPNet
+}
+
+// renderGateway renders the bridge flows, the iptables rules and the service routes the gateway programs for
+// the node with the current configuration, without touching the host. The output is deterministic so that it
+// can be compared against golden files or diffed between versions.
+func renderGateway(in *gatewayRenderInput) (string, error) {
+	var out strings.Builder
+
+	flows, err := renderGatewayFlows(in)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&out, "# flows %s\n", in.bridge.bridgeName)
+	for _, flow := range flows {
+		fmt.Fprintln(&out, flow)
+	}
+
+	fmt.Fprintln(&out, "\n# iptables")
+	for _, rule := range renderGatewayIPTables(in) {
+		fmt.Fprintln(&out, rule)
+	}
+
+	routes, err := getSvcRoutes(DummyNextHopIPs())
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(&out, "\n# routes")
+	for _, route := range routes {
+		fmt.Fprintf(&out, "%s via %s dev %s src %s mtu %d\n", route.Dst, route.Gw, in.bridge.bridgeName, route.Src, route.MTU)
+	}
+	return out.String(), nil
+}
+
+// renderGatewayFlows returns the static flows of the gateway bridge, sorted as their order is not relevant to OVS
+func renderGatewayFlows(in *gatewayRenderInput) ([]string, error) {
+	flows, err := flowsForDefaultBridge(in.bridge, in.extraIPs)
+	if err != nil {
+		return nil, err
+	}
+	common, err := commonFlows(in.hostSubnets, in.bridge)
+	if err != nil {
+		return nil, err
+	}
+	flows = append(flows, common...)
+	flows = append(flows, normalFlows(in.encapIP)...)
+	for i := range flows {
+		flows[i] = strings.TrimSpace(flows[i])
+	}
+	sort.Strings(flows)
+	return flows, nil
+}
+
+// renderGatewayIPTables returns the iptables rules the gateway adds at startup, in the order they are added
+func renderGatewayIPTables(in *gatewayRenderInput) []string {
+	var rules []string
+	add := func(op string, iptRules []nodeipt.Rule) {
+		for _, r := range iptRules {
+			cmd := "iptables"
+			if r.Protocol == iptables.ProtocolIPv6 {
+				cmd = "ip6tables"
+			}
+			rules = append(rules, fmt.Sprintf("%s -t %s %s %s %s", cmd, r.Table, op, r.Chain, strings.Join(r.Args, " ")))
+		}
+	}
+
+	if config.Gateway.Mode == config.GatewayModeLocal {
+		for _, ip := range in.mgmtPortIPs {
+			cidr := &net.IPNet{IP: ip.IP.Mask(ip.Mask), Mask: ip.Mask}
+			add("-I", getLocalGatewayFilterRules(in.mgmtPortName, cidr))
+			add("-A", getLocalGatewayNATRules(in.mgmtPortName, cidr))
+		}
+	}
+	if config.OvnKubeNode.Mode == types.NodeModeFull {
+		for _, chain := range getGatewayIPTablesChains() {
+			for _, proto := range clusterIPTablesProtocols() {
+				add("-I", getGatewayInitRules(chain, proto))
+			}
+		}
+	}
+	if config.Gateway.DisableForwarding {
+		add("-I", getGatewayForwardRules(getGatewayForwardCIDRs()))
+	}
+	return rules
+}
@@ -0,0 +1,186 @@
+package node
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "update the golden files of the gateway render tests")
+
+func TestRenderGateway(t *testing.T) {
+	testCases := []struct {
+		name   string
+		golden string
+		setup  func()
+		input  func() *gatewayRenderInput
+	}{
+		{
+			name:   "shared gateway IPv4",
+			golden: "gateway_render_shared_v4.golden",
+			setup: func() {
+				config.IPv4Mode = true
+				config.Gateway.Mode = config.GatewayModeShared
+				config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.128.0.0/14"), HostSubnetLength: 23}}
+				config.Kubernetes.ServiceCIDRs = []*net.IPNet{ovntest.MustParseIPNet("172.30.0.0/16")}
+			},
+			input: func() *gatewayRenderInput {
+				return &gatewayRenderInput{
+					bridge:      renderTestBridge("192.168.1.10/24"),
+					hostSubnets: []*net.IPNet{ovntest.MustParseIPNet("10.128.0.0/23")},
+					encapIP:     "192.168.1.10",
+				}
+			},
+		},
+		{
+			name:   "local gateway dual-stack with forwarding disabled",
+			golden: "gateway_render_local_dualstack.golden",
+			setup: func() {
+				config.IPv4Mode = true
+				config.IPv6Mode = true
+				config.Gateway.Mode = config.GatewayModeLocal
+				config.Gateway.DisableForwarding = true
+				config.Default.ClusterSubnets = []config.CIDRNetworkEntry{
+					{CIDR: ovntest.MustParseIPNet("10.128.0.0/14"), HostSubnetLength: 23},
+					{CIDR: ovntest.MustParseIPNet("fd01::/48"), HostSubnetLength: 64},
+				}
+				config.Kubernetes.ServiceCIDRs = []*net.IPNet{
+					ovntest.MustParseIPNet("172.30.0.0/16"),
+					ovntest.MustParseIPNet("fd02::/112"),
+				}
+			},
+			input: func() *gatewayRenderInput {
+				return &gatewayRenderInput{
+					bridge: renderTestBridge("192.168.1.10/24", "fc00:f853:ccd:e793::3/64"),
+					hostSubnets: []*net.IPNet{
+						ovntest.MustParseIPNet("10.128.0.0/23"),
+						ovntest.MustParseIPNet("fd01:0:0:1::/64"),
+					},
+					extraIPs:     []net.IP{net.ParseIP("192.168.2.10")},
+					encapIP:      "192.168.1.10",
+					mgmtPortName: types.K8sMgmtIntfName,
+					mgmtPortIPs: []*net.IPNet{
+						ovntest.MustParseIPNet("10.128.0.2/23"),
+						ovntest.MustParseIPNet("fd01:0:0:1::2/64"),
+					},
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := config.PrepareTestConfig(); err != nil {
+				t.Fatalf("failed to prepare the test config: %v", err)
+			}
+			tc.setup()
+			out, err := renderGateway(tc.input())
+			if err != nil {
+				t.Fatalf("failed to render the gateway: %v", err)
+			}
+			// the render must be deterministic
+			for i := 0; i < 5; i++ {
+				again, err := renderGateway(tc.input())
+				if err != nil {
+					t.Fatalf("failed to render the gateway: %v", err)
+				}
+				if again != out {
+					t.Fatalf("the gateway render is not deterministic:\n%s\n---\n%s", out, again)
+				}
+			}
+
+			golden := filepath.Join("testdata", tc.golden)
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(out), 0644); err != nil {
+					t.Fatalf("failed to update %s: %v", golden, err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read %s, run the test with -update-golden to create it: %v", golden, err)
+			}
+			if string(expected) != out {
+				t.Fatalf("the gateway render does not match %s, run the test with -update-golden to update it:\n%s", golden, out)
+			}
+		})
+	}
+}
+
+func TestCommonFlowsSteerICMPErrors(t *testing.T) {
+	if err := config.PrepareTestConfig(); err != nil {
+		t.Fatalf("failed to prepare the test config: %v", err)
+	}
+	config.IPv4Mode = true
+	config.IPv6Mode = true
+	config.Gateway.Mode = config.GatewayModeShared
+	flows, err := commonFlows(nil, renderTestBridge("192.168.1.10/24", "fc00:f853:ccd:e793::3/64"))
+	if err != nil {
+		t.Fatalf("failed to generate the common flows: %v", err)
+	}
+
+	// the ICMP errors not related to a connection of zone 64000 are looked up in the node port zone, then go
+	// either to the host or to OVN, never to both
+	for _, icmpMatch := range []string{
+		"icmp, icmp_type=3, icmp_code=4",  // IPv4 fragmentation needed
+		"icmp6, icmp_type=2, icmp_code=0", // IPv6 packet too big
+	} {
+		expected := fmt.Sprintf("cookie=%s, priority=12, table=1, in_port=1, dl_dst=0a:58:c0:a8:01:0a, %s, "+
+			"ct_state=+trk-rel, actions=ct(zone=%d,nat,table=8)",
+			defaultOpenFlowCookie, icmpMatch, config.Default.HostNodePortConntrackZone)
+		if !slices.Contains(flows, expected) {
+			t.Errorf("expected the flow %q in:\n%s", expected, strings.Join(flows, "\n"))
+		}
+	}
+	var table8 []string
+	for _, flow := range flows {
+		if strings.Contains(flow, "table=8,") {
+			table8 = append(table8, flow)
+		}
+	}
+	expected := []string{
+		fmt.Sprintf("cookie=%s, priority=10, table=8, ct_state=+trk+rel, actions=output:LOCAL", defaultOpenFlowCookie),
+		fmt.Sprintf("cookie=%s, priority=0, table=8, actions=output:2", defaultOpenFlowCookie),
+	}
+	if !slices.Equal(table8, expected) {
+		t.Errorf("expected the table 8 flows %q, got %q", expected, table8)
+	}
+}
+
+// renderTestBridge returns the gateway bridge of the render tests with the default network and a user
+// defined network
+func renderTestBridge(ips ...string) *bridgeConfiguration {
+	bridge := &bridgeConfiguration{
+		nodeName:   "node1",
+		bridgeName: "breth0",
+		uplinkName: "eth0",
+		macAddress: ovntest.MustParseMAC("0a:58:c0:a8:01:0a"),
+		ofPortPhys: "1",
+		ofPortHost: "LOCAL",
+		netConfig: map[string]*bridgeUDNConfiguration{
+			types.DefaultNetworkName: {
+				patchPort:   "patch-breth0_node1-to-br-int",
+				ofPortPatch: "2",
+				masqCTMark:  ctMarkOVN,
+			},
+			"tenant-blue": {
+				patchPort:   "patch-breth0_tenant-blue_node1-to-br-int",
+				ofPortPatch: "3",
+				masqCTMark:  "0x3",
+				v4MasqIP:    ovntest.MustParseIPNet("169.254.169.13/29"),
+				v6MasqIP:    ovntest.MustParseIPNet("fd69::13/125"),
+			},
+		},
+	}
+	for _, ip := range ips {
+		bridge.ips = append(bridge.ips, ovntest.MustParseIPNet(ip))
+	}
+	return bridge
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
 	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
@@ -56,6 +57,19 @@ const (
 	ovnKubeNodeSNATMark = "0x3f0"
 )
 
+// serviceProtocols are the service port protocols for which the gateway installs flows and rules
+var serviceProtocols = []kapi.Protocol{kapi.ProtocolTCP, kapi.ProtocolUDP, kapi.ProtocolSCTP}
+
+// getFlowProtocol returns the OpenFlow match keyword for the service port protocol
+// and IP family, e.g. "tcp" or "sctp6"
+func getFlowProtocol(protocol kapi.Protocol, isIPv6 bool) string {
+	flowProtocol := strings.ToLower(string(protocol))
+	if isIPv6 {
+		return flowProtocol + "6"
+	}
+	return flowProtocol
+}
+
 // nodePortWatcherIptables manages iptables rules for shared gateway
 // to ensure that services using NodePorts are accessible.
 type nodePortWatcherIptables struct {
@@ -147,14 +161,13 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 
 	// cookie is only used for debugging purpose. so it is not fatal error if cookie is failed to be generated.
 	for _, svcPort := range service.Spec.Ports {
-		protocol := strings.ToLower(string(svcPort.Protocol))
 		if svcPort.NodePort > 0 {
 			flowProtocols := []string{}
 			if config.IPv4Mode {
-				flowProtocols = append(flowProtocols, protocol)
+				flowProtocols = append(flowProtocols, getFlowProtocol(svcPort.Protocol, false))
 			}
 			if config.IPv6Mode {
-				flowProtocols = append(flowProtocols, protocol+"6")
+				flowProtocols = append(flowProtocols, getFlowProtocol(svcPort.Protocol, true))
 			}
 			for _, flowProtocol := range flowProtocols {
 				cookie, err = svcToCookie(service.Namespace, service.Name, flowProtocol, svcPort.NodePort)
@@ -176,7 +189,7 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 				if isServiceTypeETPLocal && hasLocalHostNetworkEp {
 					// case1 (see function description for details)
 					var nodeportFlows []string
-					klog.V(5).Infof("Adding flows on breth0 for Nodeport Service %s in Namespace: %s since ExternalTrafficPolicy=local", service.Name, service.Namespace)
+					gatewayLog.V(5).Infof("Adding flows on breth0 for Nodeport Service %s in Namespace: %s since ExternalTrafficPolicy=local", service.Name, service.Namespace)
 					// table 0, This rule matches on all traffic with dst port == NodePort, DNAT's the nodePort to the svc targetPort
 					// If ipv6 make sure to choose the ipv6 node address for rule
 					if strings.Contains(flowProtocol, "6") {
@@ -252,12 +265,12 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 					err)
 			}
 		}
-		if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions,
+		if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, actions,
 			ingParsedIPs, "Ingress", ofPorts); err != nil {
 			errors = append(errors, err)
 		}
 
-		if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, protocol, actions,
+		if err = npw.createLbAndExternalSvcFlows(service, &svcPort, add, hasLocalHostNetworkEp, actions,
 			extParsedIPs, "External", ofPorts); err != nil {
 			errors = append(errors, err)
 		}
@@ -280,12 +293,11 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 //
 // `add` parameter indicates if the flows should exist or be removed from the cache
 // `hasLocalHostNetworkEp` indicates if at least one host networked endpoint exists for this service which is local to this node.
-// `protocol` is TCP/UDP/SCTP as set in the svc.Port
 // `actions`: "send to patchport"
 // `externalIPOrLBIngressIP` is either externalIP.IP or LB.status.ingress.IP
 // `ipType` is either "External" or "Ingress"
 func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, svcPort *kapi.ServicePort, add bool,
-	hasLocalHostNetworkEp bool, protocol string, actions string, externalIPOrLBIngressIPs []string, ipType string, ofPorts []string) error {
+	hasLocalHostNetworkEp bool, actions string, externalIPOrLBIngressIPs []string, ipType string, ofPorts []string) error {
 
 	for _, externalIPOrLBIngressIP := range externalIPOrLBIngressIPs {
 		// each path has per IP generates about 4-5 flows. So we preallocate a slice with capacity.
@@ -294,11 +306,11 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 		// CAUTION: when adding new flows where the in_port is ofPortPatch and the out_port is ofPortPhys, ensure
 		// that dl_src is included in match criteria!
 
-		flowProtocol := protocol
+		isIPv6 := utilnet.IsIPv6String(externalIPOrLBIngressIP)
+		flowProtocol := getFlowProtocol(svcPort.Protocol, isIPv6)
 		nwDst := "nw_dst"
 		nwSrc := "nw_src"
-		if utilnet.IsIPv6String(externalIPOrLBIngressIP) {
-			flowProtocol = protocol + "6"
+		if isIPv6 {
 			nwDst = "ipv6_dst"
 			nwSrc = "ipv6_src"
 		}
@@ -326,7 +338,7 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 		isServiceTypeETPLocal := util.ServiceExternalTrafficPolicyLocal(service)
 		if isServiceTypeETPLocal && hasLocalHostNetworkEp {
 			// case1 (see function description for details)
-			klog.V(5).Infof("Adding flows on breth0 for %s Service %s in Namespace: %s since ExternalTrafficPolicy=local", ipType, service.Name, service.Namespace)
+			gatewayLog.V(5).Infof("Adding flows on breth0 for %s Service %s in Namespace: %s since ExternalTrafficPolicy=local", ipType, service.Name, service.Namespace)
 			// table 0, This rule matches on all traffic with dst ip == LoadbalancerIP / externalIP, DNAT's the nodePort to the svc targetPort
 			// If ipv6 make sure to choose the ipv6 node address for rule
 			if strings.Contains(flowProtocol, "6") {
@@ -426,6 +438,16 @@ func (npw *nodePortWatcher) generateICMPFragmentationFlow(nwDst, ipAddr string,
 	return icmpFragmentationFlow
 }
 
+// icmpErrorMatches returns the matches of the ICMP errors path MTU discovery and the unreachable hosts rely on:
+// destination unreachable with fragmentation needed or host unreachable for IPv4, packet too big or address
+// unreachable for IPv6.
+func icmpErrorMatches(ipv6 bool) []string {
+	if ipv6 {
+		return []string{"icmp6, icmp_type=2, icmp_code=0", "icmp6, icmp_type=1, icmp_code=3"}
+	}
+	return []string{"icmp, icmp_type=3, icmp_code=4", "icmp, icmp_type=3, icmp_code=1"}
+}
+
 // getAndDeleteServiceInfo returns the serviceConfig for a service and if it exists and then deletes the entry
 func (npw *nodePortWatcher) getAndDeleteServiceInfo(index ktypes.NamespacedName) (out *serviceConfig, exists bool) {
 	npw.serviceInfoLock.Lock()
@@ -479,7 +501,7 @@ func (npw *nodePortWatcher) updateServiceInfo(index ktypes.NamespacedName, servi
 	defer npw.serviceInfoLock.Unlock()
 
 	if old, exists = npw.serviceInfo[index]; !exists {
-		klog.V(5).Infof("No serviceConfig found for service %s in namespace %s", index.Name, index.Namespace)
+		gatewayLog.V(5).Infof("No serviceConfig found for service %s in namespace %s", index.Name, index.Namespace)
 		return nil, exists
 	}
 	ptrCopy := *old
@@ -563,19 +585,19 @@ func delServiceRules(service *kapi.Service, localEndpoints []string, npw *nodePo
 			// |                          |                       |                       |   + default dnat towards CIP   |
 			// +--------------------------+-----------------------+-----------------------+--------------------------------+
 
-			if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
+			if err = deleteIptRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
 				errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
 			}
-			if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
+			if err = deleteIptRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
 				errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
 			}
 		}
 	} else {
 
-		if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
+		if err = deleteIptRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
 			errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
 		}
-		if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
+		if err = deleteIptRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
 			errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
 		}
 	}
@@ -604,7 +626,7 @@ func (npw *nodePortWatcher) AddService(service *kapi.Service) error {
 		return nil
 	}
 
-	klog.V(5).Infof("Adding service %s in namespace %s", service.Name, service.Namespace)
+	gatewayLog.V(5).Infof("Adding service %s in namespace %s", service.Name, service.Namespace)
 	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
 	epSlices, err := npw.watchFactory.GetServiceEndpointSlices(service.Namespace, service.Name, types.DefaultNetworkName)
 	if err != nil {
@@ -612,7 +634,7 @@ func (npw *nodePortWatcher) AddService(service *kapi.Service) error {
 			return fmt.Errorf("error retrieving all endpointslices for service %s/%s during service add: %w",
 				service.Namespace, service.Name, err)
 		}
-		klog.V(5).Infof("No endpointslice found for service %s in namespace %s during service Add",
+		gatewayLog.V(5).Infof("No endpointslice found for service %s in namespace %s during service Add",
 			service.Name, service.Namespace)
 		// No endpoint object exists yet so default to false
 		hasLocalHostNetworkEp = false
@@ -623,14 +645,14 @@ func (npw *nodePortWatcher) AddService(service *kapi.Service) error {
 	}
 	// If something didn't already do it add correct Service rules
 	if exists := npw.addOrSetServiceInfo(name, service, hasLocalHostNetworkEp, localEndpoints); !exists {
-		klog.V(5).Infof("Service Add %s event in namespace %s came before endpoint event setting svcConfig",
+		gatewayLog.V(5).Infof("Service Add %s event in namespace %s came before endpoint event setting svcConfig",
 			service.Name, service.Namespace)
 		if err := addServiceRules(service, sets.List(localEndpoints), hasLocalHostNetworkEp, npw); err != nil {
 			return fmt.Errorf("AddService failed for nodePortWatcher: %v", err)
 		}
 	} else {
 		// Need to update flows here in case an attribute of the gateway has changed, such as MAC address
-		klog.V(5).Infof("Updating already programmed rules for %s in namespace %s", service.Name, service.Namespace)
+		gatewayLog.V(5).Infof("Updating already programmed rules for %s in namespace %s", service.Name, service.Namespace)
 		if err = npw.updateServiceFlowCache(service, true, hasLocalHostNetworkEp); err != nil {
 			return fmt.Errorf("failed to update flows for service %s/%s: %w", service.Namespace, service.Name, err)
 		}
@@ -645,7 +667,7 @@ func (npw *nodePortWatcher) UpdateService(old, new *kapi.Service) error {
 	name := ktypes.NamespacedName{Namespace: old.Namespace, Name: old.Name}
 
 	if serviceUpdateNotNeeded(old, new) {
-		klog.V(5).Infof("Skipping service update for: %s as change does not apply to any of .Spec.Ports, "+
+		gatewayLog.V(5).Infof("Skipping service update for: %s as change does not apply to any of .Spec.Ports, "+
 			".Spec.ExternalIP, .Spec.ClusterIP, .Spec.ClusterIPs, .Spec.Type, .Status.LoadBalancer.Ingress, "+
 			".Spec.ExternalTrafficPolicy, .Spec.InternalTrafficPolicy", new.Name)
 		return nil
@@ -654,21 +676,21 @@ func (npw *nodePortWatcher) UpdateService(old, new *kapi.Service) error {
 	// threads do the correct thing, leave hasLocalHostNetworkEp and localEndpoints alone in the cache
 	svcConfig, exists := npw.updateServiceInfo(name, new, nil, nil)
 	if !exists {
-		klog.V(5).Infof("Service %s in namespace %s was deleted during service Update", old.Name, old.Namespace)
+		gatewayLog.V(5).Infof("Service %s in namespace %s was deleted during service Update", old.Name, old.Namespace)
 		return nil
 	}
 
 	if util.ServiceTypeHasClusterIP(old) && util.IsClusterIPSet(old) {
 		// Delete old rules if needed, but don't delete svcConfig
 		// so that we don't miss any endpoint update events here
-		klog.V(5).Infof("Deleting old service rules for: %v", old)
+		gatewayLog.V(5).Infof("Deleting old service rules for: %v", old)
 		if err = delServiceRules(old, sets.List(svcConfig.localEndpoints), npw); err != nil {
 			errors = append(errors, err)
 		}
 	}
 
 	if util.ServiceTypeHasClusterIP(new) && util.IsClusterIPSet(new) {
-		klog.V(5).Infof("Adding new service rules for: %v", new)
+		gatewayLog.V(5).Infof("Adding new service rules for: %v", new)
 		if err = addServiceRules(new, sets.List(svcConfig.localEndpoints), svcConfig.hasLocalHostNetworkEp, npw); err != nil {
 			errors = append(errors, err)
 		}
@@ -729,7 +751,7 @@ func (npw *nodePortWatcher) DeleteService(service *kapi.Service) error {
 		return nil
 	}
 
-	klog.V(5).Infof("Deleting service %s in namespace %s", service.Name, service.Namespace)
+	gatewayLog.V(5).Infof("Deleting service %s in namespace %s", service.Name, service.Namespace)
 	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
 	if svcConfig, exists := npw.getAndDeleteServiceInfo(name); exists {
 		if err = delServiceRules(svcConfig.service, sets.List(svcConfig.localEndpoints), npw); err != nil {
@@ -776,7 +798,7 @@ func (npw *nodePortWatcher) SyncServices(services []interface{}) error {
 				return fmt.Errorf("error retrieving all endpointslices for service %s/%s during SyncServices: %w",
 					service.Namespace, service.Name, err)
 			}
-			klog.V(5).Infof("No endpointslice found for service %s in namespace %s during sync", service.Name, service.Namespace)
+			gatewayLog.V(5).Infof("No endpointslice found for service %s in namespace %s during sync", service.Name, service.Namespace)
 			continue
 		}
 		nodeIPs := npw.nodeIPManager.ListAddresses()
@@ -828,7 +850,7 @@ func (npw *nodePortWatcher) AddEndpointSlice(epSlice *discovery.EndpointSlice) e
 		}
 		// This is not necessarily an error. For e.g when there are endpoints
 		// without a corresponding service.
-		klog.V(5).Infof("No service found for endpointslice %s in namespace %s during endpointslice add",
+		gatewayLog.V(5).Infof("No service found for endpointslice %s in namespace %s during endpointslice add",
 			epSlice.Name, epSlice.Namespace)
 		return nil
 	}
@@ -837,7 +859,7 @@ func (npw *nodePortWatcher) AddEndpointSlice(epSlice *discovery.EndpointSlice) e
 		return nil
 	}
 
-	klog.V(5).Infof("Adding endpointslice %s in namespace %s", epSlice.Name, epSlice.Namespace)
+	gatewayLog.V(5).Infof("Adding endpointslice %s in namespace %s", epSlice.Name, epSlice.Namespace)
 	nodeIPs := npw.nodeIPManager.ListAddresses()
 	epSlices, err := npw.watchFactory.GetServiceEndpointSlices(svc.Namespace, svc.Name, types.DefaultNetworkName)
 	if err != nil {
@@ -856,13 +878,13 @@ func (npw *nodePortWatcher) AddEndpointSlice(epSlice *discovery.EndpointSlice) e
 	}
 	out, exists := npw.getAndSetServiceInfo(namespacedName, svc, hasLocalHostNetworkEp, localEndpoints)
 	if !exists {
-		klog.V(5).Infof("Endpointslice %s ADD event in namespace %s is creating rules", epSlice.Name, epSlice.Namespace)
+		gatewayLog.V(5).Infof("Endpointslice %s ADD event in namespace %s is creating rules", epSlice.Name, epSlice.Namespace)
 		return addServiceRules(svc, sets.List(localEndpoints), hasLocalHostNetworkEp, npw)
 	}
 
 	if out.hasLocalHostNetworkEp != hasLocalHostNetworkEp ||
 		(!util.LoadBalancerServiceHasNodePortAllocation(svc) && !reflect.DeepEqual(out.localEndpoints, localEndpoints)) {
-		klog.V(5).Infof("Endpointslice %s ADD event in namespace %s is updating rules", epSlice.Name, epSlice.Namespace)
+		gatewayLog.V(5).Infof("Endpointslice %s ADD event in namespace %s is updating rules", epSlice.Name, epSlice.Namespace)
 		if err = delServiceRules(svc, sets.List(out.localEndpoints), npw); err != nil {
 			errors = append(errors, err)
 		}
@@ -880,7 +902,7 @@ func (npw *nodePortWatcher) DeleteEndpointSlice(epSlice *discovery.EndpointSlice
 	var errors []error
 	var hasLocalHostNetworkEp = false
 
-	klog.V(5).Infof("Deleting endpointslice %s in namespace %s", epSlice.Name, epSlice.Namespace)
+	gatewayLog.V(5).Infof("Deleting endpointslice %s in namespace %s", epSlice.Name, epSlice.Namespace)
 	// remove rules for endpoints and add back normal ones
 	namespacedName, err := util.ServiceNamespacedNameFromEndpointSlice(epSlice)
 	if err != nil {
@@ -893,7 +915,7 @@ func (npw *nodePortWatcher) DeleteEndpointSlice(epSlice *discovery.EndpointSlice
 				namespacedName.Namespace, namespacedName.Name, epSlice.Name, err)
 		}
 		// an endpoint slice that we retry to delete will be gone from the api server, so don't return here
-		klog.V(5).Infof("No endpointslices found for service %s/%s during endpointslice delete on %s (did we previously fail to delete it?)",
+		gatewayLog.V(5).Infof("No endpointslices found for service %s/%s during endpointslice delete on %s (did we previously fail to delete it?)",
 			namespacedName.Namespace, namespacedName.Name, epSlice.Name)
 		epSlices = []*discovery.EndpointSlice{epSlice}
 	}
@@ -949,7 +971,7 @@ func (npw *nodePortWatcher) UpdateEndpointSlice(oldEpSlice, newEpSlice *discover
 		return nil
 	}
 
-	klog.V(5).Infof("Updating endpointslice %s in namespace %s", oldEpSlice.Name, oldEpSlice.Namespace)
+	gatewayLog.V(5).Infof("Updating endpointslice %s in namespace %s", oldEpSlice.Name, oldEpSlice.Namespace)
 
 	var serviceInfo *serviceConfig
 	var exists bool
@@ -976,7 +998,7 @@ func (npw *nodePortWatcher) UpdateEndpointSlice(oldEpSlice, newEpSlice *discover
 			return fmt.Errorf("error retrieving all endpointslices for service %s/%s during endpointslice update on %s: %w",
 				namespacedName.Namespace, namespacedName.Name, newEpSlice.Name, err)
 		}
-		klog.V(5).Infof("No endpointslices found for service %s/%s during endpointslice update on %s: %v",
+		gatewayLog.V(5).Infof("No endpointslices found for service %s/%s during endpointslice update on %s: %v",
 			namespacedName.Namespace, namespacedName.Name, newEpSlice.Name, err)
 	}
 
@@ -1021,7 +1043,7 @@ func (npwipt *nodePortWatcherIptables) UpdateService(old, new *kapi.Service) err
 	var err error
 	var errors []error
 	if serviceUpdateNotNeeded(old, new) {
-		klog.V(5).Infof("Skipping service update for: %s as change does not apply to "+
+		gatewayLog.V(5).Infof("Skipping service update for: %s as change does not apply to "+
 			"any of .Spec.Ports, .Spec.ExternalIP, .Spec.ClusterIP, .Spec.ClusterIPs,"+
 			" .Spec.Type, .Status.LoadBalancer.Ingress", new.Name)
 		return nil
@@ -1248,6 +1270,15 @@ func flowsForDefaultBridge(bridge *bridgeConfiguration, extraIPs []net.IP) ([]st
 					"actions=ct(zone=%d,nat,table=3)",
 					defaultOpenFlowCookie, netConfig.ofPortPatch, protoPrefix, protoPrefix, svcCIDR,
 					protoPrefix, masqIP, config.Default.HostMasqConntrackZone))
+			// table 0, ICMP errors to host towards SVC, coming from OVN whatever their source, e.g. the gateway
+			// router unable to forward the packets of a too large MTU, unSNAT so that the host gets them
+			for _, icmpMatch := range icmpErrorMatches(protoPrefix == "ipv6") {
+				dftFlows = append(dftFlows,
+					fmt.Sprintf("cookie=%s, priority=501, in_port=%s, %s, %s_dst=%s, "+
+						"actions=ct(zone=%d,nat,table=3)",
+						defaultOpenFlowCookie, netConfig.ofPortPatch, icmpMatch, protoPrefix, masqIP,
+						config.Default.HostMasqConntrackZone))
+			}
 			// table 0, Reply traffic coming from OVN to outside, drop it if the DNAT wasn't done either
 			// at the GR load balancer or switch load balancer. It means the correct port wasn't provided.
 			// nodeCIDR->serviceCIDR traffic flow is internal and it shouldn't be carried to outside the cluster
@@ -1450,18 +1481,13 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 			for _, netConfig := range bridge.patchedNetConfigs() {
 				// table 0, any packet coming from OVN send to host in LGW mode, host will take care of sending it outside if needed.
 				// exceptions are traffic for egressIP and egressGW features and ICMP related traffic which will hit the priority 100 flow instead of this.
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, tcp, nw_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, udp, nw_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, sctp, nw_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
+				for _, protocol := range serviceProtocols {
+					dftFlows = append(dftFlows,
+						fmt.Sprintf("cookie=%s, priority=175, in_port=%s, %s, nw_src=%s, "+
+							"actions=ct(table=4,zone=%d)",
+							defaultOpenFlowCookie, netConfig.ofPortPatch, getFlowProtocol(protocol, false), physicalIP.IP,
+							config.Default.HostMasqConntrackZone))
+				}
 				// We send BFD traffic coming from OVN to outside directly using a higher priority flow
 				if ofPortPhys != "" {
 					dftFlows = append(dftFlows,
@@ -1525,18 +1551,13 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 			for _, netConfig := range bridge.patchedNetConfigs() {
 				// table 0, any packet coming from OVN send to host in LGW mode, host will take care of sending it outside if needed.
 				// exceptions are traffic for egressIP and egressGW features and ICMP related traffic which will hit the priority 100 flow instead of this.
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, tcp6, ipv6_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, udp6, ipv6_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
-				dftFlows = append(dftFlows,
-					fmt.Sprintf("cookie=%s, priority=175, in_port=%s, sctp6, ipv6_src=%s, "+
-						"actions=ct(table=4,zone=%d)",
-						defaultOpenFlowCookie, netConfig.ofPortPatch, physicalIP.IP, config.Default.HostMasqConntrackZone))
+				for _, protocol := range serviceProtocols {
+					dftFlows = append(dftFlows,
+						fmt.Sprintf("cookie=%s, priority=175, in_port=%s, %s, ipv6_src=%s, "+
+							"actions=ct(table=4,zone=%d)",
+							defaultOpenFlowCookie, netConfig.ofPortPatch, getFlowProtocol(protocol, true), physicalIP.IP,
+							config.Default.HostMasqConntrackZone))
+				}
 				if ofPortPhys != "" {
 					// We send BFD traffic coming from OVN to outside directly using a higher priority flow
 					dftFlows = append(dftFlows,
@@ -1637,6 +1658,30 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 			}
 		}
 
+		// table 1, ICMP errors to the shared mac that are not related to a connection of zone 64000 are looked up
+		// in the zone of the node port connections of the host networked endpoints, and steered in table 8.
+		// Masquerading would drop them otherwise and break path MTU discovery.
+		for _, ipv6 := range []bool{false, true} {
+			if (ipv6 && !config.IPv6Mode) || (!ipv6 && !config.IPv4Mode) {
+				continue
+			}
+			for _, icmpMatch := range icmpErrorMatches(ipv6) {
+				dftFlows = append(dftFlows,
+					fmt.Sprintf("cookie=%s, priority=12, table=1, in_port=%s, dl_dst=%s, %s, ct_state=+trk-rel, "+
+						"actions=ct(zone=%d,nat,table=8)",
+						defaultOpenFlowCookie, ofPortPhys, bridgeMacAddress, icmpMatch, config.Default.HostNodePortConntrackZone))
+			}
+		}
+		// table 8, the ICMP errors related to a node port connection go to the host. The others belong to the
+		// service traffic OVN sends out without committing it on the bridge and go to OVN, whose gateway router
+		// tracks that traffic.
+		dftFlows = append(dftFlows,
+			fmt.Sprintf("cookie=%s, priority=10, table=8, ct_state=+trk+rel, actions=output:%s",
+				defaultOpenFlowCookie, ofPortHost))
+		dftFlows = append(dftFlows,
+			fmt.Sprintf("cookie=%s, priority=0, table=8, actions=output:%s",
+				defaultOpenFlowCookie, defaultNetConfig.ofPortPatch))
+
 		// packets larger than known acceptable MTU need to go to kernel for
 		// potential fragmentation
 		// introduced specifically for replies to egress traffic not routed
@@ -1710,7 +1755,7 @@ func initSvcViaMgmPortRoutingRules(hostSubnets []*net.IPNet) error {
 				if stdout, stderr, err := util.RunIP("route", "replace", "table", ovnkubeSvcViaMgmPortRT, svcCIDR.String(), "via", gatewayIP, "dev", types.K8sMgmtIntfName); err != nil {
 					return fmt.Errorf("error adding routing table entry into custom routing table: %s: stdout: %s, stderr: %s, err: %v", ovnkubeSvcViaMgmPortRT, stdout, stderr, err)
 				}
-				klog.V(5).Infof("Successfully added route into custom routing table: %s", ovnkubeSvcViaMgmPortRT)
+				gatewayLog.V(5).Infof("Successfully added route into custom routing table: %s", ovnkubeSvcViaMgmPortRT)
 			}
 		}
 	}
@@ -1743,12 +1788,8 @@ func initSvcViaMgmPortRoutingRules(hostSubnets []*net.IPNet) error {
 	// lastly update the reverse path filtering options for ovn-k8s-mp0 interface to avoid dropping return packets
 	// NOTE: v6 doesn't have rp_filter strict mode block
 	rpFilterLooseMode := "2"
-	// TODO: Convert testing framework to mock golang module utilities. Example:
-	// result, err := sysctl.Sysctl(fmt.Sprintf("net/ipv4/conf/%s/rp_filter", types.K8sMgmtIntfName), rpFilterLooseMode)
-	stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("net.ipv4.conf.%s.rp_filter=%s", types.K8sMgmtIntfName, rpFilterLooseMode))
-	if err != nil || stdout != fmt.Sprintf("net.ipv4.conf.%s.rp_filter = %s", types.K8sMgmtIntfName, rpFilterLooseMode) {
-		return fmt.Errorf("could not set the correct rp_filter value for interface %s: stdout: %v, stderr: %v, err: %v",
-			types.K8sMgmtIntfName, stdout, stderr, err)
+	if err := sysctlmanager.Set(fmt.Sprintf("net.ipv4.conf.%s.rp_filter", types.K8sMgmtIntfName), rpFilterLooseMode); err != nil {
+		return fmt.Errorf("could not set the correct rp_filter value for interface %s: %v", types.K8sMgmtIntfName, err)
 	}
 
 	return nil
@@ -1849,7 +1890,7 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 
 		// resync flows on IP change
 		gw.nodeIPManager.OnChanged = func() {
-			klog.V(5).Info("Node addresses changed, re-syncing bridge flows")
+			gatewayLog.V(5).Info("Node addresses changed, re-syncing bridge flows")
 			if err := gw.openflowManager.updateBridgeFlowCache(subnets, gw.nodeIPManager.ListAddresses()); err != nil {
 				// very unlikely - somehow node has lost its IP address
 				klog.Errorf("Failed to re-generate gateway flows after address change: %v", err)
@@ -1866,6 +1907,12 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 			gw.openflowManager.requestFlowSync()
 		}
 
+		if config.OvnKubeNode.Mode == types.NodeModeDPU {
+			// the addresses and routes of the DPU host are declared by the host in its gateway intent
+			gw.gatewayIntentController = newGatewayIntentController(nodeName, gwBridge.bridgeName, watchFactory,
+				routeManager, gw.nodeIPManager)
+		}
+
 		if config.Gateway.NodeportEnable {
 			if config.OvnKubeNode.Mode == types.NodeModeFull {
 				// (TODO): Internal Traffic Policy is not supported in DPU mode
@@ -1932,11 +1979,7 @@ func newNodePortWatcher(gwBridge *bridgeConfiguration, ofm *openflowManager,
 		}
 	}
 
-	var subnets []*net.IPNet
-	for _, subnet := range config.Default.ClusterSubnets {
-		subnets = append(subnets, subnet.CIDR)
-	}
-	subnets = append(subnets, config.Kubernetes.ServiceCIDRs...)
+	subnets := getGatewayForwardCIDRs()
 	if config.Gateway.DisableForwarding {
 		if err := initExternalBridgeServiceForwardingRules(subnets); err != nil {
 			return nil, fmt.Errorf("failed to add accept rules in forwarding table for bridge %s: err %v", gwBridge.bridgeName, err)
@@ -1986,22 +2029,9 @@ func cleanupSharedGateway() error {
 		}
 	}
 
-	// Get the OVS bridge name from ovn-bridge-mappings
-	stdout, stderr, err = util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
-		"external_ids:ovn-bridge-mappings")
+	bridgeName, err := getPhysicalNetworkBridge()
 	if err != nil {
-		return fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
-	}
-
-	// skip the existing mapping setting for the specified physicalNetworkName
-	bridgeName := ""
-	bridgeMappings := strings.Split(stdout, ",")
-	for _, bridgeMapping := range bridgeMappings {
-		m := strings.Split(bridgeMapping, ":")
-		if network := m[0]; network == types.PhysicalNetworkName {
-			bridgeName = m[1]
-			break
-		}
+		return err
 	}
 	if len(bridgeName) == 0 {
 		return nil
@@ -2016,6 +2046,23 @@ func cleanupSharedGateway() error {
 	return nil
 }
 
+// getPhysicalNetworkBridge returns the OVS bridge of the physical network from ovn-bridge-mappings, empty when
+// it is not mapped
+func getPhysicalNetworkBridge() (string, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:ovn-bridge-mappings")
+	if err != nil {
+		return "", fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+	}
+	for _, bridgeMapping := range strings.Split(stdout, ",") {
+		m := strings.Split(bridgeMapping, ":")
+		if network := m[0]; network == types.PhysicalNetworkName && len(m) > 1 {
+			return m[1], nil
+		}
+	}
+	return "", nil
+}
+
 func svcToCookie(namespace string, name string, token string, port int32) (string, error) {
 	id := fmt.Sprintf("%s%s%s%d", namespace, name, token, port)
 	h := fnv.New64a()
@@ -0,0 +1,276 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+)
+
+// sourceRangeFilter restricts the sources allowed to reach the NodePorts, external IPs and load balancer ingress
+// IPs of the services with load balancer source ranges, from the spec or from the
+// service.beta.kubernetes.io/load-balancer-source-ranges annotation, on the gateway bridge. The service flows
+// send the traffic received on the physical port to OVN, whose load balancers accept it from anywhere.
+//
+// The traffic of a service from an allowed source is marked in reg1 and resubmitted to table 0, where the service
+// flows handle it; the traffic of a service from any other source is dropped. The NodePorts are only filtered on
+// the addresses of the gateway bridge.
+//
+// The sources are filtered on the node the traffic is received on, before OVN forwards it: with
+// externalTrafficPolicy=Cluster the clients are SNATed to the node when the endpoint is on another node, the
+// endpoint node receives the traffic over the overlay and does not filter it again. A load balancer in front of
+// the nodes that SNATs the clients to its own addresses hides the clients though, its addresses must then be
+// part of the source ranges.
+type sourceRangeFilter struct {
+	sync.Mutex
+	ofm *openflowManager
+	wf  factory.NodeWatchFactory
+	// services are the services with source range flows
+	services sets.Set[ktypes.NamespacedName]
+	// bridge is the state of the gateway bridge the flows of the services were generated for
+	bridge sourceRangeBridge
+}
+
+// sourceRangeBridge is the state of the gateway bridge the source range flows depend on
+type sourceRangeBridge struct {
+	ofPortPhys string
+	// nodeIPs are the addresses of the gateway bridge, comma separated
+	nodeIPs string
+}
+
+// sourceRangeResyncInterval is how often the gateway bridge is checked for a change of its physical port or
+// addresses, which requires the flows of all the services to be regenerated
+const sourceRangeResyncInterval = 10 * time.Second
+
+func newSourceRangeFilter(ofm *openflowManager, wf factory.NodeWatchFactory) *sourceRangeFilter {
+	return &sourceRangeFilter{
+		ofm:      ofm,
+		wf:       wf,
+		services: sets.New[ktypes.NamespacedName](),
+	}
+}
+
+// Start installs the source range flows of the services and keeps them in sync
+func (f *sourceRangeFilter) Start(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	f.bridge = f.currentBridge()
+	_, err := f.wf.AddServiceHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			f.AddService(obj.(*kapi.Service))
+		},
+		UpdateFunc: func(old, new interface{}) {
+			f.UpdateService(old.(*kapi.Service), new.(*kapi.Service))
+		},
+		DeleteFunc: func(obj interface{}) {
+			svc, ok := obj.(*kapi.Service)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					klog.Errorf("Couldn't get object from tombstone %#v", obj)
+					return
+				}
+				if svc, ok = tombstone.Obj.(*kapi.Service); !ok {
+					klog.Errorf("Tombstone contained object that is not a Service %#v", tombstone.Obj)
+					return
+				}
+			}
+			f.DeleteService(svc)
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	runPeriodicSync(stopChan, doneWg, sourceRangeResyncInterval, nil, func() {
+		if err := f.resync(); err != nil {
+			klog.Errorf("Failed to resync the load balancer source range flows: %v", err)
+		}
+	})
+	return nil
+}
+
+// currentBridge returns the state of the gateway bridge the source range flows depend on
+func (f *sourceRangeFilter) currentBridge() sourceRangeBridge {
+	f.ofm.defaultBridge.Lock()
+	defer f.ofm.defaultBridge.Unlock()
+	nodeIPs := make([]string, 0, len(f.ofm.defaultBridge.ips))
+	for _, ip := range f.ofm.defaultBridge.ips {
+		nodeIPs = append(nodeIPs, ip.IP.String())
+	}
+	return sourceRangeBridge{ofPortPhys: f.ofm.defaultBridge.ofPortPhys, nodeIPs: strings.Join(nodeIPs, ",")}
+}
+
+// resync regenerates the source range flows of all the services when the physical port of the gateway bridge
+// becomes known or changes, or when the addresses of the bridge change
+func (f *sourceRangeFilter) resync() error {
+	f.Lock()
+	defer f.Unlock()
+	bridge := f.currentBridge()
+	if bridge == f.bridge {
+		return nil
+	}
+	services, err := f.wf.GetServices()
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	klog.Infof("Gateway bridge changed from %+v to %+v, regenerating the load balancer source range flows", f.bridge, bridge)
+	f.bridge = bridge
+	for _, service := range services {
+		f.syncService(service)
+	}
+	f.ofm.requestFlowSync()
+	return nil
+}
+
+func sourceRangeFlowsKey(service ktypes.NamespacedName) string {
+	return strings.Join([]string{"SourceRanges", service.Namespace, service.Name}, "_")
+}
+
+// getServiceSourceRanges returns the valid load balancer source ranges of the service, from the spec or
+// when unset from the annotation
+func getServiceSourceRanges(service *kapi.Service) []*net.IPNet {
+	ranges := service.Spec.LoadBalancerSourceRanges
+	if len(ranges) == 0 {
+		if annotation := strings.TrimSpace(service.Annotations[kapi.AnnotationLoadBalancerSourceRangesKey]); annotation != "" {
+			ranges = strings.Split(annotation, ",")
+		}
+	}
+	var cidrs []*net.IPNet
+	for _, r := range ranges {
+		_, cidr, err := utilnet.ParseCIDRSloppy(strings.TrimSpace(r))
+		if err != nil {
+			klog.Warningf("Ignoring invalid load balancer source range %q of service %s/%s: %v", r, service.Namespace, service.Name, err)
+			continue
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs
+}
+
+// AddService installs the source range flows of the service
+func (f *sourceRangeFilter) AddService(service *kapi.Service) {
+	f.Lock()
+	defer f.Unlock()
+	f.syncService(service)
+	f.ofm.requestFlowSync()
+}
+
+// UpdateService updates the source range flows of the service
+func (f *sourceRangeFilter) UpdateService(_, service *kapi.Service) {
+	f.AddService(service)
+}
+
+// DeleteService removes the source range flows of the service
+func (f *sourceRangeFilter) DeleteService(service *kapi.Service) {
+	f.Lock()
+	defer f.Unlock()
+	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+	if f.services.Has(name) {
+		f.ofm.deleteFlowsByKey(sourceRangeFlowsKey(name))
+		f.services.Delete(name)
+		f.ofm.requestFlowSync()
+	}
+}
+
+// syncService updates the source range flows of the service for the last known state of the gateway bridge, f must
+// be locked
+func (f *sourceRangeFilter) syncService(service *kapi.Service) {
+	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+	var flows []string
+	if f.bridge.ofPortPhys != "" {
+		var nodeIPs []string
+		if f.bridge.nodeIPs != "" {
+			nodeIPs = strings.Split(f.bridge.nodeIPs, ",")
+		}
+		flows = generateSourceRangeFlows(service, f.bridge.ofPortPhys, nodeIPs)
+	}
+	if len(flows) == 0 {
+		if f.services.Has(name) {
+			f.ofm.deleteFlowsByKey(sourceRangeFlowsKey(name))
+			f.services.Delete(name)
+		}
+		return
+	}
+	gatewayLog.V(5).Infof("Restricting the sources of service %s to its load balancer source ranges", name)
+	f.ofm.updateFlowCacheEntry(sourceRangeFlowsKey(name), flows)
+	f.services.Insert(name)
+}
+
+// generateSourceRangeFlows returns the flows allowing the traffic of the service received on the physical port
+// from its load balancer source ranges only, for its NodePorts on the node IPs and its external and ingress IPs.
+// A service without source ranges of an IP family is not reachable over that family.
+func generateSourceRangeFlows(service *kapi.Service, ofPortPhys string, nodeIPs []string) []string {
+	ranges := getServiceSourceRanges(service)
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	var vips []string
+	for _, ip := range service.Spec.ExternalIPs {
+		if parsed := utilnet.ParseIPSloppy(ip); parsed != nil {
+			vips = append(vips, parsed.String())
+		}
+	}
+	for _, ing := range service.Status.LoadBalancer.Ingress {
+		if parsed := utilnet.ParseIPSloppy(ing.IP); parsed != nil {
+			vips = append(vips, parsed.String())
+		}
+	}
+
+	var flows []string
+	addFlows := func(isIPv6 bool, svcPort *kapi.ServicePort, nwDstMatch string, port int32) {
+		flowProtocol := getFlowProtocol(svcPort.Protocol, isIPv6)
+		nwSrc := "nw_src"
+		if isIPv6 {
+			nwSrc = "ipv6_src"
+		}
+		cookie, err := svcToCookie(service.Namespace, service.Name, "SourceRanges"+flowProtocol+nwDstMatch, port)
+		if err != nil {
+			cookie = "0"
+		}
+		match := fmt.Sprintf("in_port=%s, reg1=0, %s, %stp_dst=%d", ofPortPhys, flowProtocol, nwDstMatch, port)
+		for _, cidr := range ranges {
+			if utilnet.IsIPv6CIDR(cidr) != isIPv6 {
+				continue
+			}
+			// table=0, marks the service traffic from an allowed source and hands it to the service flows
+			flows = append(flows, fmt.Sprintf("cookie=%s, priority=112, %s, %s=%s, "+
+				"actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)", cookie, match, nwSrc, cidr))
+		}
+		// table=0, drops the service traffic from any other source
+		flows = append(flows, fmt.Sprintf("cookie=%s, priority=111, %s, actions=drop", cookie, match))
+	}
+
+	for i := range service.Spec.Ports {
+		svcPort := &service.Spec.Ports[i]
+		addDstFlows := func(ip string, port int32) {
+			isIPv6 := utilnet.IsIPv6String(ip)
+			if (isIPv6 && !config.IPv6Mode) || (!isIPv6 && !config.IPv4Mode) {
+				return
+			}
+			nwDst := "nw_dst"
+			if isIPv6 {
+				nwDst = "ipv6_dst"
+			}
+			addFlows(isIPv6, svcPort, fmt.Sprintf("%s=%s, ", nwDst, ip), port)
+		}
+		if svcPort.NodePort > 0 {
+			for _, nodeIP := range nodeIPs {
+				addDstFlows(nodeIP, svcPort.NodePort)
+			}
+		}
+		for _, vip := range vips {
+			addDstFlows(vip, svcPort.Port)
+		}
+	}
+	return flows
+}
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Service load balancer source ranges", func() {
+	const ofPortPhys = "1"
+
+	newService := func(sourceRanges []string, annotations map[string]string) *kapi.Service {
+		return &kapi.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc", Annotations: annotations},
+			Spec: kapi.ServiceSpec{
+				Type:                     kapi.ServiceTypeLoadBalancer,
+				ExternalIPs:              []string{"192.168.10.5"},
+				LoadBalancerSourceRanges: sourceRanges,
+				Ports: []kapi.ServicePort{{
+					Protocol: kapi.ProtocolTCP,
+					Port:     80,
+					NodePort: 30080,
+				}},
+			},
+		}
+	}
+
+	cookie := func(token string, port int32) string {
+		c, err := svcToCookie("ns", "svc", token, port)
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = false
+	})
+
+	It("allows the NodePort and external IP traffic from the source ranges only", func() {
+		flows := generateSourceRangeFlows(newService([]string{"10.0.0.0/8", "172.16.1.0/24", "fd00::/64"}, nil), ofPortPhys,
+			[]string{"172.18.0.2", "fd00::2"})
+		nodePortCookie := cookie("SourceRangestcpnw_dst=172.18.0.2, ", 30080)
+		externalIPCookie := cookie("SourceRangestcpnw_dst=192.168.10.5, ", 80)
+		Expect(flows).To(Equal([]string{
+			fmt.Sprintf("cookie=%s, priority=112, in_port=1, reg1=0, tcp, nw_dst=172.18.0.2, tp_dst=30080, nw_src=10.0.0.0/8, "+
+				"actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)", nodePortCookie),
+			fmt.Sprintf("cookie=%s, priority=112, in_port=1, reg1=0, tcp, nw_dst=172.18.0.2, tp_dst=30080, nw_src=172.16.1.0/24, "+
+				"actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)", nodePortCookie),
+			fmt.Sprintf("cookie=%s, priority=111, in_port=1, reg1=0, tcp, nw_dst=172.18.0.2, tp_dst=30080, actions=drop", nodePortCookie),
+			fmt.Sprintf("cookie=%s, priority=112, in_port=1, reg1=0, tcp, nw_dst=192.168.10.5, tp_dst=80, nw_src=10.0.0.0/8, "+
+				"actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)", externalIPCookie),
+			fmt.Sprintf("cookie=%s, priority=112, in_port=1, reg1=0, tcp, nw_dst=192.168.10.5, tp_dst=80, nw_src=172.16.1.0/24, "+
+				"actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)", externalIPCookie),
+			fmt.Sprintf("cookie=%s, priority=111, in_port=1, reg1=0, tcp, nw_dst=192.168.10.5, tp_dst=80, actions=drop", externalIPCookie),
+		}))
+	})
+
+	It("falls back to the source ranges annotation and ignores invalid ranges", func() {
+		svc := newService(nil, map[string]string{
+			kapi.AnnotationLoadBalancerSourceRangesKey: "10.0.0.0/8, not-a-cidr",
+		})
+		flows := generateSourceRangeFlows(svc, ofPortPhys, []string{"172.18.0.2"})
+		Expect(flows).To(HaveLen(4))
+		Expect(flows[0]).To(ContainSubstring("nw_dst=172.18.0.2, tp_dst=30080, nw_src=10.0.0.0/8, actions=load:0x1->NXM_NX_REG1[0],resubmit(,0)"))
+		Expect(flows[2]).To(ContainSubstring("nw_dst=192.168.10.5, tp_dst=80, nw_src=10.0.0.0/8, actions="))
+	})
+
+	It("does not restrict services without source ranges", func() {
+		Expect(generateSourceRangeFlows(newService(nil, nil), ofPortPhys, []string{"172.18.0.2"})).To(BeEmpty())
+	})
+
+	It("regenerates the flows of the services once the physical port is known and when the node IPs change", func() {
+		svc := newService([]string{"10.0.0.0/8"}, nil)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fake.NewSimpleClientset(svc)}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		bridge := &bridgeConfiguration{ips: []*net.IPNet{ovntest.MustParseIPNet("172.18.0.2/24")}}
+		ofm := &openflowManager{defaultBridge: bridge, flowCache: map[string][]string{}, flowChan: make(chan struct{}, 1)}
+		f := newSourceRangeFilter(ofm, wf)
+		f.bridge = f.currentBridge()
+		f.AddService(svc)
+		Expect(ofm.flowCache).NotTo(HaveKey(sourceRangeFlowsKey(ktypes.NamespacedName{Namespace: "ns", Name: "svc"})))
+
+		bridge.ofPortPhys = ofPortPhys
+		Expect(f.resync()).To(Succeed())
+		flows := ofm.flowCache[sourceRangeFlowsKey(ktypes.NamespacedName{Namespace: "ns", Name: "svc"})]
+		Expect(flows).To(HaveLen(4))
+		Expect(flows[0]).To(ContainSubstring("nw_dst=172.18.0.2, tp_dst=30080"))
+
+		bridge.ips = []*net.IPNet{ovntest.MustParseIPNet("172.18.0.3/24")}
+		Expect(f.resync()).To(Succeed())
+		flows = ofm.flowCache[sourceRangeFlowsKey(ktypes.NamespacedName{Namespace: "ns", Name: "svc"})]
+		Expect(flows[0]).To(ContainSubstring("nw_dst=172.18.0.3, tp_dst=30080"))
+	})
+})
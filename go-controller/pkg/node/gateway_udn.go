@@ -3,6 +3,8 @@ package node
 import (
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/generator/udn"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/vrfmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -28,6 +32,10 @@ const (
 	// waitForPatchPortTimeout is the maximum time we wait for a UDN's patch
 	// port to be created by OVN.
 	waitForPatchPortTimeout = 30 * time.Second
+
+	// UDNMasqueradeIPRulePriority is the priority of the IP rules steering the traffic towards the management
+	// port masquerade IP of a UDN into the VRF of the network
+	UDNMasqueradeIPRulePriority = 2000
 )
 
 // UserDefinedNetworkGateway contains information
@@ -46,6 +54,9 @@ type UserDefinedNetworkGateway struct {
 	// vrf manager that creates and manages vrfs for all UDNs
 	// used with a lock since its shared between all network controllers
 	vrfManager *vrfmanager.Controller
+	// IP rule manager that steers the reply traffic of all UDNs into their VRF
+	// shared between all network controllers
+	ruleManager *iprulemanager.Controller
 	// masqCTMark holds the mark value for this network
 	// which is used for egress traffic in shared gateway mode
 	masqCTMark uint
@@ -108,6 +119,8 @@ func (b *bridgeConfiguration) delNetworkBridgeConfig(nInfo util.NetInfo) {
 	delete(b.netConfig, nInfo.GetNetworkName())
 }
 
+// patchedNetConfigs returns the configurations of the networks whose patch port is plugged, ordered by patch
+// port so that the flows generated from them are stable
 func (b *bridgeConfiguration) patchedNetConfigs() []*bridgeUDNConfiguration {
 	result := make([]*bridgeUDNConfiguration, 0, len(b.netConfig))
 	for _, netConfig := range b.netConfig {
@@ -116,6 +129,9 @@ func (b *bridgeConfiguration) patchedNetConfigs() []*bridgeUDNConfiguration {
 		}
 		result = append(result, netConfig)
 	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].patchPort < result[j].patchPort
+	})
 	return result
 }
 
@@ -153,7 +169,7 @@ func setBridgeNetworkOfPorts(bridge *bridgeConfiguration, netName string) error
 }
 
 func NewUserDefinedNetworkGateway(netInfo util.NetInfo, networkID int, node *v1.Node, nodeLister listers.NodeLister,
-	kubeInterface kube.Interface, vrfManager *vrfmanager.Controller,
+	kubeInterface kube.Interface, vrfManager *vrfmanager.Controller, ruleManager *iprulemanager.Controller,
 	defaultNetworkGateway Gateway) (*UserDefinedNetworkGateway, error) {
 	// Generate a per network conntrack mark and masquerade IPs to be used for egress traffic.
 	var (
@@ -188,6 +204,7 @@ func NewUserDefinedNetworkGateway(netInfo util.NetInfo, networkID int, node *v1.
 		nodeLister:    nodeLister,
 		kubeInterface: kubeInterface,
 		vrfManager:    vrfManager,
+		ruleManager:   ruleManager,
 		masqCTMark:    masqCTMark,
 		v4MasqIP:      v4MasqIP,
 		v6MasqIP:      v6MasqIP,
@@ -212,6 +229,26 @@ func (udng *UserDefinedNetworkGateway) AddNetwork() error {
 	if err != nil {
 		return fmt.Errorf("could not add VRF %d for network %s, err: %v", vrfTableId, udng.GetNetworkName(), err)
 	}
+	if err = udng.addUDNManagementPortIPs(mplink); err != nil {
+		return fmt.Errorf("could not add management port IPs for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	rules, err := udng.constructUDNVRFIPRules(vrfTableId)
+	if err != nil {
+		return fmt.Errorf("failed to compute IP rules for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	for _, rule := range rules {
+		if err = udng.ruleManager.Add(rule); err != nil {
+			return fmt.Errorf("failed to add IP rule %s for network %s, err: %v", rule.String(), udng.GetNetworkName(), err)
+		}
+	}
+	if err = udng.addUDNConntrackZoneRules(); err != nil {
+		return fmt.Errorf("failed to add conntrack zone rules for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if config.Gateway.Mode == config.GatewayModeLocal {
+		if err = udng.addUDNMasqueradeRules(); err != nil {
+			return fmt.Errorf("failed to add masquerade rules for network %s, err: %v", udng.GetNetworkName(), err)
+		}
+	}
 	if udng.openflowManager != nil {
 		udng.openflowManager.addNetwork(udng.NetInfo, udng.masqCTMark, udng.v4MasqIP, udng.v6MasqIP)
 
@@ -247,8 +284,31 @@ func (udng *UserDefinedNetworkGateway) AddNetwork() error {
 // DelNetwork will be responsible to remove all plumbings
 // used by this UDN on the gateway side
 func (udng *UserDefinedNetworkGateway) DelNetwork() error {
+	if err := udng.delUDNMasqueradeRules(); err != nil {
+		return fmt.Errorf("failed to delete masquerade rules for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if err := deleteIptRules(udng.getUDNConntrackZoneRules()); err != nil {
+		return fmt.Errorf("failed to delete conntrack zone rules for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	// the VRF table is computed from the management port link, when it is already gone the IP rules
+	// are removed by the rule manager as stale rules of its priority
+	mpLink, err := util.GetNetLinkOps().LinkByName(util.GetNetworkScopedK8sMgmtHostIntfName(uint(udng.networkID)))
+	if err != nil && !util.GetNetLinkOps().IsLinkNotFoundError(err) {
+		return fmt.Errorf("failed to get management port link for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if err == nil {
+		rules, err := udng.constructUDNVRFIPRules(util.CalculateRouteTableID(mpLink.Attrs().Index))
+		if err != nil {
+			return fmt.Errorf("failed to compute IP rules for network %s, err: %v", udng.GetNetworkName(), err)
+		}
+		for _, rule := range rules {
+			if err = udng.ruleManager.Delete(rule); err != nil {
+				return fmt.Errorf("failed to delete IP rule %s for network %s, err: %v", rule.String(), udng.GetNetworkName(), err)
+			}
+		}
+	}
 	vrfDeviceName := util.GetVRFDeviceNameForUDN(udng.networkID)
-	err := udng.vrfManager.DeleteVRF(vrfDeviceName)
+	err = udng.vrfManager.DeleteVRF(vrfDeviceName)
 	if err != nil {
 		return err
 	}
@@ -266,25 +326,11 @@ func (udng *UserDefinedNetworkGateway) DelNetwork() error {
 // STEP2: It saves the MAC address generated on the 1st go as an option on the OVS interface
 // so that it persists on reboots
 // STEP3: sets up the management port link on the host
-// STEP4: adds the management port IP .2 to the mplink
-// STEP5: adds the mac address to the node management port annotation
+// STEP4: adds the mac address to the node management port annotation
+// The management port IPs are added by addUDNManagementPortIPs once the link is enslaved to the VRF of the network.
 func (udng *UserDefinedNetworkGateway) addUDNManagementPort() (netlink.Link, error) {
 	var err error
 	interfaceName := util.GetNetworkScopedK8sMgmtHostIntfName(uint(udng.networkID))
-	var networkLocalSubnets []*net.IPNet
-	if udng.TopologyType() == types.Layer3Topology {
-		networkLocalSubnets, err = util.ParseNodeHostSubnetAnnotation(udng.node, udng.GetNetworkName())
-		if err != nil {
-			return nil, fmt.Errorf("waiting for node %s to start, no annotation found on node for network %s: %w",
-				udng.node.Name, udng.GetNetworkName(), err)
-		}
-	} else if udng.TopologyType() == types.Layer2Topology {
-		// NOTE: We don't support L2 networks without subnets as primary UDNs
-		globalFlatL2Networks := udng.Subnets()
-		for _, globalFlatL2Network := range globalFlatL2Networks {
-			networkLocalSubnets = append(networkLocalSubnets, globalFlatL2Network.CIDR)
-		}
-	}
 
 	// STEP1
 	stdout, stderr, err := util.RunOVSVsctl(
@@ -297,7 +343,7 @@ func (udng *UserDefinedNetworkGateway) addUDNManagementPort() (netlink.Link, err
 		return nil, fmt.Errorf("failed to add port to br-int for network %s, stdout: %q, stderr: %q, error: %w",
 			udng.GetNetworkName(), stdout, stderr, err)
 	}
-	klog.V(3).Infof("Added OVS management port interface %s for network %s", interfaceName, udng.GetNetworkName())
+	gatewayLog.V(3).Infof("Added OVS management port interface %s for network %s", interfaceName, udng.GetNetworkName())
 
 	// STEP2
 	macAddress, err := util.GetOVSPortMACAddress(interfaceName)
@@ -318,9 +364,48 @@ func (udng *UserDefinedNetworkGateway) addUDNManagementPort() (netlink.Link, err
 		return nil, fmt.Errorf("failed to set the link up for interface %s while plumbing network %s, err: %v",
 			interfaceName, udng.GetNetworkName(), err)
 	}
-	klog.V(3).Infof("Setup management port link %s for network %s succeeded", interfaceName, udng.GetNetworkName())
+	gatewayLog.V(3).Infof("Setup management port link %s for network %s succeeded", interfaceName, udng.GetNetworkName())
 
 	// STEP4
+	if err := util.UpdateNodeManagementPortMACAddressesWithRetry(udng.node, udng.nodeLister, udng.kubeInterface, macAddress, udng.GetNetworkName()); err != nil {
+		return nil, fmt.Errorf("unable to update mac address annotation for node %s, for network %s, err: %v", udng.node.Name, udng.GetNetworkName(), err)
+	}
+	gatewayLog.V(3).Infof("Added management port mac address information of %s for network %s", interfaceName, udng.GetNetworkName())
+	return mplink, nil
+}
+
+// addUDNManagementPortIPs adds the management port IP .2 of the subnets of the network to the management port
+// link. The link must be enslaved to the VRF of the network first: the connected routes of the subnets are then
+// added to the VRF table instead of the main table, where they would collide with the routes of the other
+// networks with the same subnets, and the IPv6 addresses are not flushed by the enslavement.
+func (udng *UserDefinedNetworkGateway) addUDNManagementPortIPs(mplink netlink.Link) error {
+	var networkLocalSubnets []*net.IPNet
+	if udng.TopologyType() == types.Layer3Topology {
+		var err error
+		networkLocalSubnets, err = util.ParseNodeHostSubnetAnnotation(udng.node, udng.GetNetworkName())
+		if err != nil {
+			return fmt.Errorf("waiting for node %s to start, no annotation found on node for network %s: %w",
+				udng.node.Name, udng.GetNetworkName(), err)
+		}
+	} else if udng.TopologyType() == types.Layer2Topology {
+		// NOTE: We don't support L2 networks without subnets as primary UDNs
+		globalFlatL2Networks := udng.Subnets()
+		for _, globalFlatL2Network := range globalFlatL2Networks {
+			networkLocalSubnets = append(networkLocalSubnets, globalFlatL2Network.CIDR)
+		}
+	}
+	vrfLink, err := util.GetNetLinkOps().LinkByName(util.GetVRFDeviceNameForUDN(udng.networkID))
+	if err != nil {
+		return fmt.Errorf("failed to get VRF device of network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	mplink, err = util.GetNetLinkOps().LinkByName(mplink.Attrs().Name)
+	if err != nil {
+		return fmt.Errorf("failed to get management port link for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if mplink.Attrs().MasterIndex != vrfLink.Attrs().Index {
+		return fmt.Errorf("management port %s of network %s is not enslaved to VRF device %s",
+			mplink.Attrs().Name, udng.GetNetworkName(), vrfLink.Attrs().Name)
+	}
 	for _, subnet := range networkLocalSubnets {
 		if config.IPv6Mode && utilnet.IsIPv6CIDR(subnet) || config.IPv4Mode && utilnet.IsIPv4CIDR(subnet) {
 			ip := util.GetNodeManagementIfAddr(subnet)
@@ -330,18 +415,12 @@ func (udng *UserDefinedNetworkGateway) addUDNManagementPort() (netlink.Link, err
 				err = util.LinkAddrAdd(mplink, ip, 0, 0, 0)
 			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to add management port IP from subnet %s to netdevice %s for network %s, err: %v",
-					subnet, interfaceName, udng.GetNetworkName(), err)
+				return fmt.Errorf("failed to add management port IP from subnet %s to netdevice %s for network %s, err: %v",
+					subnet, mplink.Attrs().Name, udng.GetNetworkName(), err)
 			}
 		}
 	}
-
-	// STEP5
-	if err := util.UpdateNodeManagementPortMACAddressesWithRetry(udng.node, udng.nodeLister, udng.kubeInterface, macAddress, udng.GetNetworkName()); err != nil {
-		return nil, fmt.Errorf("unable to update mac address annotation for node %s, for network %s, err: %v", udng.node.Name, udng.GetNetworkName(), err)
-	}
-	klog.V(3).Infof("Added management port mac address information of %s for network %s", interfaceName, udng.GetNetworkName())
-	return mplink, nil
+	return nil
 }
 
 // deleteUDNManagementPort does the following:
@@ -358,12 +437,12 @@ func (udng *UserDefinedNetworkGateway) deleteUDNManagementPort() error {
 		return fmt.Errorf("failed to delete port from br-int for network %s, stdout: %q, stderr: %q, error: %v",
 			udng.GetNetworkName(), stdout, stderr, err)
 	}
-	klog.V(3).Infof("Removed OVS management port interface %s for network %s", interfaceName, udng.GetNetworkName())
+	gatewayLog.V(3).Infof("Removed OVS management port interface %s for network %s", interfaceName, udng.GetNetworkName())
 	// sending nil mac address will delete the network's annotation value
 	if err := util.UpdateNodeManagementPortMACAddressesWithRetry(udng.node, udng.nodeLister, udng.kubeInterface, nil, udng.GetNetworkName()); err != nil {
 		return fmt.Errorf("unable to remove mac address annotation for node %s, for network %s, err: %v", udng.node.Name, udng.GetNetworkName(), err)
 	}
-	klog.V(3).Infof("Removed management port mac address information of %s for network %s", interfaceName, udng.GetNetworkName())
+	gatewayLog.V(3).Infof("Removed management port mac address information of %s for network %s", interfaceName, udng.GetNetworkName())
 	return nil
 }
 
@@ -450,6 +529,130 @@ func (udng *UserDefinedNetworkGateway) computeRoutesForUDN(vrfTableId int, mpLin
 	return retVal, nil
 }
 
+// constructUDNVRFIPRules returns the IP rules steering the reply traffic towards the management port masquerade
+// IPs of this network into its VRF, where it is routed to the management port
+// when adding new rules please leave a sample comment on how that rule looks like
+func (udng *UserDefinedNetworkGateway) constructUDNVRFIPRules(vrfTableId int) ([]netlink.Rule, error) {
+	var rules []netlink.Rule
+	masqIPv4, err := udng.getV4MasqueradeIP()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch masqueradeV4 IP for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	// Rule1: 2000: from all to 169.254.0.12 lookup 1007
+	if masqIPv4 != nil {
+		rules = append(rules, newUDNVRFIPRule(netlink.FAMILY_V4, masqIPv4, vrfTableId))
+	}
+	masqIPv6, err := udng.getV6MasqueradeIP()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch masqueradeV6 IP for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	// Rule2: 2000: from all to fd69::c lookup 1007
+	if masqIPv6 != nil {
+		rules = append(rules, newUDNVRFIPRule(netlink.FAMILY_V6, masqIPv6, vrfTableId))
+	}
+	return rules, nil
+}
+
+func newUDNVRFIPRule(family int, dst *net.IPNet, vrfTableId int) netlink.Rule {
+	rule := netlink.NewRule()
+	rule.Priority = UDNMasqueradeIPRulePriority
+	rule.Family = family
+	rule.Dst = dst
+	rule.Table = vrfTableId
+	return *rule
+}
+
+// getUDNMasqueradeRules returns the iptables rules masquerading the traffic of this network leaving the node
+// in local gateway mode, after it was SNATed to the management port masquerade IPs of the network by OVN
+func (udng *UserDefinedNetworkGateway) getUDNMasqueradeRules() ([]nodeipt.Rule, error) {
+	var rules []nodeipt.Rule
+	masqIPv4, err := udng.getV4MasqueradeIP()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch masqueradeV4 IP for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if masqIPv4 != nil {
+		rules = append(rules, getUDNMasqueradeRule(masqIPv4))
+	}
+	masqIPv6, err := udng.getV6MasqueradeIP()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch masqueradeV6 IP for network %s, err: %v", udng.GetNetworkName(), err)
+	}
+	if masqIPv6 != nil {
+		rules = append(rules, getUDNMasqueradeRule(masqIPv6))
+	}
+	return rules, nil
+}
+
+// addUDNMasqueradeRules masquerades the traffic of this network leaving the node to the node IP, the traffic of
+// each network is only masqueraded from its own masquerade IPs
+func (udng *UserDefinedNetworkGateway) addUDNMasqueradeRules() error {
+	var rules []nodeipt.Rule
+	for _, proto := range clusterIPTablesProtocols() {
+		rules = append(rules, getUDNMasqueradeChainRules(proto)...)
+	}
+	networkRules, err := udng.getUDNMasqueradeRules()
+	if err != nil {
+		return err
+	}
+	return appendIptRules(append(rules, networkRules...))
+}
+
+// delUDNMasqueradeRules removes the rules masquerading the traffic of this network, the chain is kept for the
+// other networks
+func (udng *UserDefinedNetworkGateway) delUDNMasqueradeRules() error {
+	rules, err := udng.getUDNMasqueradeRules()
+	if err != nil {
+		return err
+	}
+	return deleteIptRules(rules)
+}
+
+// getConntrackZone returns the host conntrack zone of this network. The zones of the networks are allocated
+// downwards from the zone of the gateway flows, far from the zones ovn-controller allocates upwards from 1.
+func (udng *UserDefinedNetworkGateway) getConntrackZone() int {
+	return config.Default.ConntrackZone - udng.networkID
+}
+
+// getUDNConntrackZoneRules returns the iptables rules tracking the host connections of this network in its
+// conntrack zone
+func (udng *UserDefinedNetworkGateway) getUDNConntrackZoneRules() []nodeipt.Rule {
+	return getUDNConntrackZoneRules(util.GetNetworkScopedK8sMgmtHostIntfName(uint(udng.networkID)),
+		util.GetVRFDeviceNameForUDN(udng.networkID), udng.getConntrackZone())
+}
+
+// addUDNConntrackZoneRules tracks the host connections of this network in its conntrack zone. The OVS datapath
+// shares the conntrack zones with the host, the zone must not be one ovn-controller assigned.
+func (udng *UserDefinedNetworkGateway) addUDNConntrackZoneRules() error {
+	zone := udng.getConntrackZone()
+	if zone <= 0 {
+		return fmt.Errorf("no conntrack zone left below zone %d for network ID %d", config.Default.ConntrackZone, udng.networkID)
+	}
+	if err := checkOVNConntrackZone(zone); err != nil {
+		return err
+	}
+	return appendIptRules(udng.getUDNConntrackZoneRules())
+}
+
+// checkOVNConntrackZone returns an error when ovn-controller assigned the conntrack zone, the zones it assigned
+// are recorded in the ct-zone-<name> external IDs of the integration bridge
+func checkOVNConntrackZone(zone int) error {
+	stdout, stderr, err := util.RunOVSVsctl("--no-heading", "--data=bare", "--columns=external_ids",
+		"list", "Bridge", "br-int")
+	if err != nil {
+		return fmt.Errorf("failed to get the conntrack zones of OVN, stderr: %q, error: %v", stderr, err)
+	}
+	for _, keyVal := range strings.Fields(stdout) {
+		key, val, found := strings.Cut(keyVal, "=")
+		if !found || !strings.HasPrefix(key, "ct-zone-") {
+			continue
+		}
+		if val == strconv.Itoa(zone) {
+			return fmt.Errorf("conntrack zone %d is used by OVN for %s", zone, strings.TrimPrefix(key, "ct-zone-"))
+		}
+	}
+	return nil
+}
+
 // getV4MasqueradeIP returns the V4 management port masqueradeIP for this network
 func (udng *UserDefinedNetworkGateway) getV4MasqueradeIP() (*net.IPNet, error) {
 	if !config.IPv4Mode {
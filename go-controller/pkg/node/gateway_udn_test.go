@@ -9,6 +9,7 @@ import (
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/coreos/go-iptables/iptables"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,8 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	factoryMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory/mocks"
 	kubemocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/vrfmanager"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -55,6 +58,13 @@ func getVRFCreationFakeOVSCommands(fexec *ovntest.FakeExec) {
 	})
 }
 
+func getConntrackZoneFakeOVSCommands(fexec *ovntest.FakeExec) {
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=external_ids list Bridge br-int",
+		Output: "ct-zone-GR_worker1_dnat=2 ct-zone-GR_worker1_snat=1 ovn-nb-cfg=4",
+	})
+}
+
 func getDeletionFakeOVSCommands(fexec *ovntest.FakeExec, mgtPort string) {
 	fexec.AddFakeCmdsNoOutputNoError([]string{
 		"ovs-vsctl --timeout=15 -- --if-exists del-port br-int " + mgtPort,
@@ -89,6 +99,10 @@ func setUpGatewayFakeOVSCommands(fexec *ovntest.FakeExec) {
 		Output: "00:00:00:55:66:99",
 	})
 	if config.IPv4Mode {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.conf.breth0.forwarding",
+			Output: "0",
+		})
 		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 			Cmd:    "sysctl -w net.ipv4.conf.breth0.forwarding=1",
 			Output: "net.ipv4.conf.breth0.forwarding = 1",
@@ -141,6 +155,10 @@ func setUpGatewayFakeOVSCommands(fexec *ovntest.FakeExec) {
 		Cmd:    "ip -6 rule add fwmark 0x1745ec lookup 7 prio 30",
 		Output: "0",
 	})
+	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "sysctl -n net.ipv4.conf.ovn-k8s-mp0.rp_filter",
+		Output: "0",
+	})
 	fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 		Cmd:    "sysctl -w net.ipv4.conf.ovn-k8s-mp0.rp_filter=2",
 		Output: "net.ipv4.conf.ovn-k8s-mp0.rp_filter = 2",
@@ -247,7 +265,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer3Topology, "100.128.0.0/16/24,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getCreationFakeOVSCommands(fexec, mgtPort, mgtPortMAC, netName, nodeName, netInfo.MTU())
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -260,8 +278,15 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			mpLink, err := udnGateway.addUDNManagementPort()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(mpLink).NotTo(BeNil())
+			// the IPs are added once the management port is enslaved to the VRF of the network
 			exists, err := util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("100.128.0.2/24"))
 			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			Expect(udnGateway.addUDNManagementPortIPs(mpLink)).NotTo(Succeed())
+			Expect(vrf.AddVRF(util.GetVRFDeviceNameForUDN(3), mpLink.Attrs().Name, 1003, nil)).To(Succeed())
+			Expect(udnGateway.addUDNManagementPortIPs(mpLink)).To(Succeed())
+			exists, err = util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("100.128.0.2/24"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(exists).To(BeTrue())
 			exists, err = util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("ae70::2/112"))
 			Expect(err).NotTo(HaveOccurred())
@@ -285,7 +310,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer3Topology, "100.128.0.0/16/24,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getDeletionFakeOVSCommands(fexec, mgtPort)
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -314,7 +339,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer2Topology, "100.128.0.0/16,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getCreationFakeOVSCommands(fexec, mgtPort, mgtPortMAC, netName, nodeName, netInfo.MTU())
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -327,8 +352,15 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			mpLink, err := udnGateway.addUDNManagementPort()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(mpLink).NotTo(BeNil())
+			// the IPs are added once the management port is enslaved to the VRF of the network
 			exists, err := util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("100.128.0.2/16"))
 			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			Expect(udnGateway.addUDNManagementPortIPs(mpLink)).NotTo(Succeed())
+			Expect(vrf.AddVRF(util.GetVRFDeviceNameForUDN(3), mpLink.Attrs().Name, 1003, nil)).To(Succeed())
+			Expect(udnGateway.addUDNManagementPortIPs(mpLink)).To(Succeed())
+			exists, err = util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("100.128.0.2/16"))
+			Expect(err).NotTo(HaveOccurred())
 			Expect(exists).To(BeTrue())
 			exists, err = util.LinkAddrExist(mpLink, ovntest.MustParseIPNet("ae70::2/60"))
 			Expect(err).NotTo(HaveOccurred())
@@ -351,7 +383,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer2Topology, "100.128.0.0/16,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, factoryMock.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getDeletionFakeOVSCommands(fexec, mgtPort)
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -387,6 +419,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 		setUpGatewayFakeOVSCommands(fexec)
 		getCreationFakeOVSCommands(fexec, mgtPort, mgtPortMAC, netName, nodeName, netInfo.MTU())
 		getVRFCreationFakeOVSCommands(fexec)
+		getConntrackZoneFakeOVSCommands(fexec)
 		setUpUDNOpenflowManagerFakeOVSCommands(fexec)
 		getDeletionFakeOVSCommands(fexec, mgtPort)
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -464,7 +497,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			stop := make(chan struct{})
 			wg := &sync.WaitGroup{}
 			Expect(localGw.Init(stop, wg)).To(Succeed())
-			udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, wf.NodeCoreInformer().Lister(), &kubeMock, vrf, localGw)
+			udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, wf.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), localGw)
 			Expect(err).NotTo(HaveOccurred())
 			// we cannot start the shared gw directly because it will spawn a goroutine that may not be bound to the test netns
 			// Start does two things, starts nodeIPManager which spawns a go routine and also starts openflow manager by spawning a go routine
@@ -547,6 +580,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 		setUpGatewayFakeOVSCommands(fexec)
 		getCreationFakeOVSCommands(fexec, mgtPort, mgtPortMAC, netName, nodeName, netInfo.MTU())
 		getVRFCreationFakeOVSCommands(fexec)
+		getConntrackZoneFakeOVSCommands(fexec)
 		setUpUDNOpenflowManagerFakeOVSCommands(fexec)
 		getDeletionFakeOVSCommands(fexec, mgtPort)
 		nodeLister.On("Get", mock.AnythingOfType("string")).Return(node, nil)
@@ -626,7 +660,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			stop := make(chan struct{})
 			wg := &sync.WaitGroup{}
 			Expect(localGw.Init(stop, wg)).To(Succeed())
-			udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, wf.NodeCoreInformer().Lister(), &kubeMock, vrf, localGw)
+			udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, wf.NodeCoreInformer().Lister(), &kubeMock, vrf, iprulemanager.NewController(true, true), localGw)
 			Expect(err).NotTo(HaveOccurred())
 			// we cannot start the shared gw directly because it will spawn a goroutine that may not be bound to the test netns
 			// Start does two things, starts nodeIPManager which spawns a go routine and also starts openflow manager by spawning a go routine
@@ -701,7 +735,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer3Topology, "100.128.0.0/16/24,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, nil, nil, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, nil, nil, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getVRFCreationFakeOVSCommands(fexec)
 		err = testNS.Do(func(ns.NetNS) error {
@@ -732,6 +766,48 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
 	})
+	ovntest.OnSupportedPlatformsIt("should compute correct masquerade IP rules and iptables rules for a user defined network", func() {
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+			},
+		}
+		nad := ovntest.GenerateNAD(netName, "rednad", "greenamespace",
+			types.Layer3Topology, "100.128.0.0/16/24,ae70::66/60", types.NetworkRolePrimary)
+		netInfo, err := util.ParseNADInfo(nad)
+		Expect(err).NotTo(HaveOccurred())
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, nil, nil, vrf, iprulemanager.NewController(true, true), &gateway{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rules, err := udnGateway.constructUDNVRFIPRules(1007)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(HaveLen(2))
+		for i, dst := range []string{"169.254.0.16", "fd69::10"} {
+			cidr, err := util.GetIPNetFullMask(dst)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules[i].Priority).To(Equal(UDNMasqueradeIPRulePriority))
+			Expect(rules[i].Table).To(Equal(1007))
+			Expect(*rules[i].Dst).To(Equal(*cidr))
+		}
+		Expect(rules[0].Family).To(Equal(netlink.FAMILY_V4))
+		Expect(rules[1].Family).To(Equal(netlink.FAMILY_V6))
+
+		iptRules, err := udnGateway.getUDNMasqueradeRules()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(iptRules).To(Equal([]nodeipt.Rule{
+			{Table: "nat", Chain: iptableUDNMasqChain, Args: []string{"-s", "169.254.0.16/32", "-j", "MASQUERADE"}, Protocol: iptables.ProtocolIPv4},
+			{Table: "nat", Chain: iptableUDNMasqChain, Args: []string{"-s", "fd69::10/128", "-j", "MASQUERADE"}, Protocol: iptables.ProtocolIPv6},
+		}))
+
+		Expect(udnGateway.getUDNConntrackZoneRules()).To(Equal([]nodeipt.Rule{
+			{Table: "raw", Chain: "PREROUTING", Args: []string{"-i", "ovn-k8s-mp3", "-j", "CT", "--zone-orig", "63997"}, Protocol: iptables.ProtocolIPv4},
+			{Table: "raw", Chain: "OUTPUT", Args: []string{"-o", "mp3-udn-vrf", "-j", "CT", "--zone-orig", "63997"}, Protocol: iptables.ProtocolIPv4},
+			{Table: "raw", Chain: "PREROUTING", Args: []string{"-i", "ovn-k8s-mp3", "-j", "CT", "--zone-orig", "63997"}, Protocol: iptables.ProtocolIPv6},
+			{Table: "raw", Chain: "OUTPUT", Args: []string{"-o", "mp3-udn-vrf", "-j", "CT", "--zone-orig", "63997"}, Protocol: iptables.ProtocolIPv6},
+		}))
+	})
 	ovntest.OnSupportedPlatformsIt("should compute correct service routes for a user defined network", func() {
 		config.Gateway.Interface = "eth0"
 		config.IPv4Mode = true
@@ -746,7 +822,7 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 			types.Layer3Topology, "100.128.0.0/16/24,ae70::66/60", types.NetworkRolePrimary)
 		netInfo, err := util.ParseNADInfo(nad)
 		Expect(err).NotTo(HaveOccurred())
-		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, nil, nil, vrf, &gateway{})
+		udnGateway, err := NewUserDefinedNetworkGateway(netInfo, 3, node, nil, nil, vrf, iprulemanager.NewController(true, true), &gateway{})
 		Expect(err).NotTo(HaveOccurred())
 		getVRFCreationFakeOVSCommands(fexec)
 		err = testNS.Do(func(ns.NetNS) error {
@@ -781,3 +857,30 @@ var _ = Describe("UserDefinedNetworkGateway", func() {
 		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
 	})
 })
+
+var _ = Describe("UDN conntrack zone", func() {
+	var fexec *ovntest.FakeExec
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("accepts a conntrack zone not assigned by OVN", func() {
+		getConntrackZoneFakeOVSCommands(fexec)
+		Expect(checkOVNConntrackZone(63997)).To(Succeed())
+	})
+
+	It("rejects a conntrack zone assigned by OVN", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=external_ids list Bridge br-int",
+			Output: "ct-zone-GR_worker1_dnat=2 ct-zone-0e5e2ffc-5f3c-4b5e-a1b0-7a0c3c2c0f2d=63997",
+		})
+		Expect(checkOVNConntrackZone(63997)).To(MatchError(
+			"conntrack zone 63997 is used by OVN for 0e5e2ffc-5f3c-4b5e-a1b0-7a0c3c2c0f2d"))
+	})
+})
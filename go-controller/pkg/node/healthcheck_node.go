@@ -1,6 +1,7 @@
 package node
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,55 +12,115 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 
-	kapi "k8s.io/api/core/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 )
 
 var updateInterval time.Duration = 500 * time.Millisecond
 
+// proxierHealthTimeout is how long a requested sync of the gateway flows can stay pending before the node
+// is reported not live on /livez, like the kube-proxy healthz timeout
+const proxierHealthTimeout = 2 * time.Minute
+
 type proxierHealthUpdater struct {
-	address      string
-	nodeRef      *kapi.ObjectReference
-	recorder     record.EventRecorder
+	// lock protects the cached health state, which is read by both the node
+	// healthz server and the service health check servers
+	lock         sync.Mutex
 	c            clock.Clock
 	healthy      bool
 	lastCalled   time.Time
 	lastUpdated  time.Time
 	watchFactory factory.NodeWatchFactory
 	nsn          ktypes.NamespacedName
+	// readinessChecks are the named checks served on /readyz, protected by lock
+	readinessChecks map[string]func() error
+	// lastSynced is the time the gateway flows were last synced and oldestPendingSync the time of the
+	// oldest sync requested since then, zero when none is pending, protected by lock
+	lastSynced        time.Time
+	oldestPendingSync time.Time
+	// draining fails the health checks while the connections through the cordoned node drain, protected by lock
+	draining bool
 }
 
 // newNodeProxyHealthzServer creates and returns a new proxier health server
 // if the HealthzBindAddress configuration is set. Cloud load balancers use this
 // health check to determine if the node is available for services with ClusterIP
 // traffic policy.
-func newNodeProxyHealthzServer(nodeName, address string, eventRecorder record.EventRecorder, wf factory.NodeWatchFactory) (*proxierHealthUpdater, error) {
+func newNodeProxyHealthzServer(wf factory.NodeWatchFactory) (*proxierHealthUpdater, error) {
 	podName := os.Getenv("POD_NAME")
 	if len(podName) == 0 {
 		return nil, fmt.Errorf("found empty env variable POD_NAME")
 	}
 	return &proxierHealthUpdater{
-		address:     address,
-		recorder:    eventRecorder,
 		c:           clock.RealClock{},
 		healthy:     true,
 		lastUpdated: time.Time{},
-		nodeRef: &kapi.ObjectReference{
-			Kind:      "Node",
-			Name:      nodeName,
-			UID:       ktypes.UID(nodeName),
-			Namespace: "",
-		},
 		nsn: ktypes.NamespacedName{
 			Namespace: config.Kubernetes.OVNConfigNamespace,
 			Name:      podName},
-		watchFactory: wf,
+		watchFactory:    wf,
+		readinessChecks: map[string]func() error{},
 	}, nil
 }
 
+// readinessCondition is the error of a readiness check waiting on other objects, served on /readyz
+// with the reason of the wait and the names of the objects blocking the node
+type readinessCondition struct {
+	Reason   string   `json:"reason"`
+	Message  string   `json:"message"`
+	Blocking []string `json:"blocking,omitempty"`
+}
+
+func (c *readinessCondition) Error() string {
+	return c.Message
+}
+
+// AddReadinessCheck registers a named check served on /readyz. The node is reported
+// ready when all the checks return no error.
+func (phu *proxierHealthUpdater) AddReadinessCheck(name string, check func() error) {
+	phu.lock.Lock()
+	defer phu.lock.Unlock()
+	phu.readinessChecks[name] = check
+}
+
+// ServeReadiness serves the result of the readiness checks on /readyz: the node is ready once its
+// data plane is programmed, whether or not the ovnkube node pod is terminating. The checks failing
+// with a readinessCondition are detailed under conditions.
+func (phu *proxierHealthUpdater) ServeReadiness(resp http.ResponseWriter, req *http.Request) {
+	phu.lock.Lock()
+	checks := make(map[string]func() error, len(phu.readinessChecks))
+	for name, check := range phu.readinessChecks {
+		checks[name] = check
+	}
+	phu.lock.Unlock()
+
+	failures := map[string]string{}
+	conditions := map[string]*readinessCondition{}
+	for name, check := range checks {
+		if err := check(); err != nil {
+			failures[name] = err.Error()
+			var condition *readinessCondition
+			if errors.As(err, &condition) {
+				conditions[name] = condition
+			}
+		}
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	if len(failures) == 0 {
+		resp.WriteHeader(http.StatusOK)
+	} else {
+		resp.WriteHeader(http.StatusServiceUnavailable)
+	}
+	payload := map[string]interface{}{"failedChecks": failures}
+	if len(conditions) > 0 {
+		payload["conditions"] = conditions
+	}
+	body, _ := json.Marshal(payload)
+	resp.Write(body)
+}
+
 func (phu *proxierHealthUpdater) isOvnkNodePodTerminating() bool {
 	pod, err := phu.watchFactory.GetPod(phu.nsn.Namespace, phu.nsn.Name)
 	if err != nil {
@@ -70,10 +131,15 @@ func (phu *proxierHealthUpdater) isOvnkNodePodTerminating() bool {
 }
 
 // isOvnkNodePodHealthy runs isOvnkNodePodTerminating at most every 500 ms and returns true
-// if the ovnkube node pod is not set for deletion.
+// if the ovnkube node pod is not set for deletion and the node is not draining.
 func (phu *proxierHealthUpdater) isOvnkNodePodHealthy() bool {
+	phu.lock.Lock()
+	defer phu.lock.Unlock()
 	now := phu.c.Now()
 	phu.lastCalled = now
+	if phu.draining {
+		return false
+	}
 	if phu.lastUpdated != (time.Time{}) && now.Sub(phu.lastUpdated) < updateInterval {
 		return phu.healthy
 	}
@@ -82,57 +148,82 @@ func (phu *proxierHealthUpdater) isOvnkNodePodHealthy() bool {
 	return phu.healthy
 }
 
+// SetDraining sets whether the node is draining, the node and service health checks fail while it is so that
+// the load balancers stop sending new connections to the node
+func (phu *proxierHealthUpdater) SetDraining(draining bool) {
+	phu.lock.Lock()
+	defer phu.lock.Unlock()
+	phu.draining = draining
+}
+
+// IsHealthy implements healthcheck.ProxierHealthChecker so that service health checks
+// fail as well when the ovnkube node pod is terminating
+func (phu *proxierHealthUpdater) IsHealthy() bool {
+	return phu.isOvnkNodePodHealthy()
+}
+
+// QueuedUpdate records that a sync of the gateway flows, the data plane of the services, was requested
+func (phu *proxierHealthUpdater) QueuedUpdate() {
+	phu.lock.Lock()
+	defer phu.lock.Unlock()
+	if phu.oldestPendingSync.IsZero() {
+		phu.oldestPendingSync = phu.c.Now()
+	}
+}
+
+// Updated records that the gateway flows were synced
+func (phu *proxierHealthUpdater) Updated() {
+	phu.lock.Lock()
+	defer phu.lock.Unlock()
+	phu.lastSynced = phu.c.Now()
+	phu.oldestPendingSync = time.Time{}
+}
+
+// isProxierStale returns whether a sync of the gateway flows has been pending for more than
+// proxierHealthTimeout, phu must be locked
+func (phu *proxierHealthUpdater) isProxierStale(now time.Time) bool {
+	return !phu.oldestPendingSync.IsZero() && now.Sub(phu.oldestPendingSync) > proxierHealthTimeout
+}
+
+// ServeLiveness serves the liveness of the process on /livez, like kube-proxy does: it fails when a sync of
+// the gateway flows has been pending for more than proxierHealthTimeout but, unlike /healthz, it keeps
+// reporting healthy while the ovnkube node pod is terminating, since the process is still running and keeps
+// the data plane programmed until it exits
+func (phu *proxierHealthUpdater) ServeLiveness(resp http.ResponseWriter, req *http.Request) {
+	now := phu.c.Now()
+	phu.lock.Lock()
+	lastSynced, stale := phu.lastSynced, phu.isProxierStale(now)
+	phu.lock.Unlock()
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	if stale {
+		resp.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		resp.WriteHeader(http.StatusOK)
+	}
+	fmt.Fprintf(resp, `{"lastUpdated": %q,"currentTime": %q}`, lastSynced, now)
+}
+
 func (phu *proxierHealthUpdater) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	resp.Header().Set("Content-Type", "application/json")
 	resp.Header().Set("X-Content-Type-Options", "nosniff")
-	if phu.isOvnkNodePodHealthy() {
+	healthy := phu.isOvnkNodePodHealthy()
+	if healthy {
 		resp.WriteHeader(http.StatusOK)
 	} else {
 		resp.WriteHeader(http.StatusServiceUnavailable)
 	}
 
-	fmt.Fprintf(resp, `{"lastUpdated": %q,"currentTime": %q}`, phu.lastUpdated, phu.lastCalled)
+	phu.lock.Lock()
+	lastUpdated, lastCalled := phu.lastUpdated, phu.lastCalled
+	phu.lock.Unlock()
+	fmt.Fprintf(resp, `{"lastUpdated": %q,"currentTime": %q}`, lastUpdated, lastCalled)
 }
 
-// serveNodeProxyHealthz initializes and runs the healthz server. It will always
-// report healthy while the node process is running.
-func (phu *proxierHealthUpdater) Start(stopChan chan struct{}, wg *sync.WaitGroup) {
-	serveMux := http.NewServeMux()
-	serveMux.Handle("/healthz", phu)
-	server := &http.Server{
-		Addr:    phu.address,
-		Handler: serveMux,
-	}
-
-	startedWg := &sync.WaitGroup{}
-
-	wg.Add(1)
-	startedWg.Add(1)
-	go func() {
-		defer wg.Done()
-		startedWg.Done()
-		<-stopChan
-		server.Close()
-	}()
-
-	wg.Add(1)
-	startedWg.Add(1)
-	go func() {
-		defer wg.Done()
-		startedWg.Done()
-
-		klog.V(3).InfoS("Starting node proxy healthz server", "address", phu.address)
-		for {
-			err := server.ListenAndServe()
-			if errors.Is(err, http.ErrServerClosed) {
-				return
-			}
-			msg := fmt.Sprintf("serving healthz on %s failed: %v", phu.address, err)
-			phu.recorder.Eventf(phu.nodeRef, kapi.EventTypeWarning, "FailedToStartProxierHealthcheck", "StartOVNKubernetesNode", msg)
-			klog.Errorf(msg)
-			time.Sleep(5 * time.Second)
-		}
-	}()
-
-	startedWg.Wait()
+// register serves the node proxy health on the healthz listener
+func (phu *proxierHealthUpdater) register(servers *httpServerManager) {
+	servers.handle(healthzListener, "/healthz", phu)
+	servers.handleFunc(healthzListener, "/livez", phu.ServeLiveness)
+	servers.handleFunc(healthzListener, "/readyz", phu.ServeReadiness)
 }
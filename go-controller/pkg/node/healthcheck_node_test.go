@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sync"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 )
 
 const healthzAddress string = "127.0.0.1:10256"
@@ -107,10 +109,13 @@ var _ = Describe("Node healthcheck tests", func() {
 					},
 				})
 
-			hzs, err := newNodeProxyHealthzServer(nodeName, healthzAddress, recorder, watchFactory)
+			hzs, err := newNodeProxyHealthzServer(watchFactory)
 			Expect(err).NotTo(HaveOccurred())
+			servers := newHTTPServerManager(nodeName, "", "", recorder)
+			Expect(servers.addListener(healthzListener, healthzAddress, false)).To(Succeed())
+			hzs.register(servers)
 
-			hzs.Start(stopCh, wg)
+			servers.Start(stopCh, wg)
 
 			checkResponse(healthzAddress, http.StatusOK)
 		})
@@ -126,12 +131,105 @@ var _ = Describe("Node healthcheck tests", func() {
 					},
 				})
 
-			hzs, err := newNodeProxyHealthzServer(nodeName, healthzAddress, recorder, watchFactory)
+			hzs, err := newNodeProxyHealthzServer(watchFactory)
 			Expect(err).NotTo(HaveOccurred())
+			servers := newHTTPServerManager(nodeName, "", "", recorder)
+			Expect(servers.addListener(healthzListener, healthzAddress, false)).To(Succeed())
+			hzs.register(servers)
 
-			hzs.Start(stopCh, wg)
+			servers.Start(stopCh, wg)
 
 			checkResponse(healthzAddress, http.StatusServiceUnavailable)
 		})
+
+		It("it reports live while the ovnkube node pod is terminating", func() {
+			now := metav1.Now()
+			watchFactory = initWatchFactoryWithObjects(
+				&v1.PodList{
+					Items: []v1.Pod{
+						*newFakeOvnkNodePod(&now),
+					},
+				})
+
+			hzs, err := newNodeProxyHealthzServer(watchFactory)
+			Expect(err).NotTo(HaveOccurred())
+
+			resp := httptest.NewRecorder()
+			hzs.ServeLiveness(resp, httptest.NewRequest(http.MethodGet, "/livez", nil))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			resp = httptest.NewRecorder()
+			hzs.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+		})
+
+		It("it reports not live when the gateway flow syncs stall", func() {
+			watchFactory = initWatchFactoryWithObjects(
+				&v1.PodList{
+					Items: []v1.Pod{
+						*newFakeOvnkNodePod(nil),
+					},
+				})
+
+			hzs, err := newNodeProxyHealthzServer(watchFactory)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			hzs.c = fakeClock
+			serveLiveness := func() int {
+				resp := httptest.NewRecorder()
+				hzs.ServeLiveness(resp, httptest.NewRequest(http.MethodGet, "/livez", nil))
+				return resp.Code
+			}
+
+			hzs.QueuedUpdate()
+			fakeClock.Step(proxierHealthTimeout)
+			Expect(serveLiveness()).To(Equal(http.StatusOK))
+			// the sync requested later does not reset the pending sync
+			hzs.QueuedUpdate()
+			fakeClock.Step(time.Second)
+			Expect(serveLiveness()).To(Equal(http.StatusServiceUnavailable))
+
+			hzs.Updated()
+			Expect(serveLiveness()).To(Equal(http.StatusOK))
+		})
+
+		It("it reports not ready until the readiness checks pass", func() {
+			watchFactory = initWatchFactoryWithObjects(
+				&v1.PodList{
+					Items: []v1.Pod{
+						*newFakeOvnkNodePod(nil),
+					},
+				})
+
+			hzs, err := newNodeProxyHealthzServer(watchFactory)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := record.NewFakeRecorder(10)
+			readiness := newDPUNodeReadiness("dpu1").withEvents(recorder, "host1")
+			hzs.AddReadinessCheck("dpu-node", readiness.check)
+			resp := httptest.NewRecorder()
+			hzs.ServeReadiness(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(ContainSubstring("dpu-node"))
+
+			readiness.waiting([]string{"dpu2", "dpu1"}, fmt.Errorf("lease dpu1 expired"), false)
+			resp = httptest.NewRecorder()
+			hzs.ServeReadiness(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(MatchJSON(`{
+				"failedChecks": {"dpu-node": "lease dpu1 expired"},
+				"conditions": {"dpu-node": {"reason": "WaitingForDPUReady", "message": "lease dpu1 expired", "blocking": ["dpu1", "dpu2"]}}
+			}`))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(ContainSubstring(dpuNodeReadyWaitReason))
+			// the same blocking nodes are reported once
+			readiness.waiting([]string{"dpu1", "dpu2"}, fmt.Errorf("lease dpu1 expired"), false)
+			Expect(recorder.Events).To(BeEmpty())
+
+			readiness.ready()
+			Expect(<-recorder.Events).To(ContainSubstring("DPUReady"))
+			resp = httptest.NewRecorder()
+			hzs.ServeReadiness(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
 	})
 })
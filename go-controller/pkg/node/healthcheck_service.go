@@ -14,6 +14,7 @@ import (
 	discovery "k8s.io/api/discovery/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 )
 
 // initLoadBalancerHealthChecker initializes the health check server for
@@ -28,10 +29,18 @@ type loadBalancerHealthChecker struct {
 	watchFactory factory.NodeWatchFactory
 }
 
-func newLoadBalancerHealthChecker(nodeName string, watchFactory factory.NodeWatchFactory) *loadBalancerHealthChecker {
+// newLoadBalancerHealthChecker creates the health check server for services with
+// HealthCheckNodePort set, i.e. ETP=local LoadBalancer services. If healthzServer is
+// not nil, the service health checks also fail when the node proxier is unhealthy.
+func newLoadBalancerHealthChecker(nodeName string, watchFactory factory.NodeWatchFactory, recorder record.EventRecorder,
+	healthzServer *proxierHealthUpdater) *loadBalancerHealthChecker {
+	var proxierHealth healthcheck.ProxierHealthChecker
+	if healthzServer != nil {
+		proxierHealth = healthzServer
+	}
 	return &loadBalancerHealthChecker{
 		nodeName:     nodeName,
-		server:       healthcheck.NewServer(nodeName, nil, nil, nil),
+		server:       healthcheck.NewServer(nodeName, recorder, nil, nil, proxierHealth),
 		services:     make(map[ktypes.NamespacedName]uint16),
 		endpoints:    make(map[ktypes.NamespacedName]int),
 		watchFactory: watchFactory,
@@ -4,15 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 	coordinationv1 "k8s.io/api/coordination/v1"
+	kapi "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	coordinationlisters "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
@@ -30,12 +43,31 @@ const (
 	retryNumber = 3
 )
 
+const (
+	// staleDPULeaseAge is how long the lease of a removed DPU node is left expired before it is pruned
+	staleDPULeaseAge = time.Hour
+	// dpuLeasePruningPeriod is how often the leases of the removed DPU nodes of the zone are pruned
+	dpuLeasePruningPeriod = 10 * time.Minute
+)
+
+// dpuNodeAbsentGracePeriod is how long the DPU host waits for a DPU node in its zone before it runs degraded
+var dpuNodeAbsentGracePeriod = 2 * time.Minute
+
+// errNoDPUNodeInZone is returned when the zone of the DPU host has no DPU node to wait for
+var errNoDPUNodeInZone = errors.New("no dpu node in the zone")
+
 type heartbeatOptions struct {
 	holderIdentity       string
 	leaseDurationSeconds int32
 	leaseNS              string
 	mode                 string
 	interval             time.Duration
+	// peerLease is the name of the lease of the paired DPU checked by the DPU host
+	peerLease string
+	// statusHandler is called by the DPU host with the result of each check of the DPU lease
+	statusHandler func(error)
+	// throttle, when set, rate limits the writes of the lease
+	throttle *util.APIWriteThrottle
 }
 
 type HeartbeatOption interface {
@@ -72,6 +104,26 @@ func (o IntervalOption) Apply(options *heartbeatOptions) {
 	options.interval = time.Duration(o)
 }
 
+type PeerLeaseOption string
+
+func (o PeerLeaseOption) Apply(options *heartbeatOptions) {
+	options.peerLease = string(o)
+}
+
+type StatusHandlerOption func(error)
+
+func (o StatusHandlerOption) Apply(options *heartbeatOptions) {
+	options.statusHandler = o
+}
+
+type ThrottleOption struct {
+	*util.APIWriteThrottle
+}
+
+func (o ThrottleOption) Apply(options *heartbeatOptions) {
+	options.throttle = o.APIWriteThrottle
+}
+
 type heartbeat struct {
 	nodeName string
 	zone     string
@@ -199,7 +251,11 @@ func (h *heartbeat) runDPUHost(ctx context.Context) error {
 						Steps:    retryNumber,
 						Jitter:   0.4,
 					}, func(context.Context) (done bool, err error) {
-						if valid, err := isHeartBeatValid(ctx, h.client, h.zone, h.leaseNS); err != nil || !valid {
+						valid, err := isDPULeaseValid(ctx, h.client, h.peerLease, h.zone, h.leaseNS)
+						if h.statusHandler != nil && ctx.Err() == nil {
+							h.statusHandler(err)
+						}
+						if err != nil || !valid {
 							klog.Errorf("Heartbeat lease is not valid: %v", err)
 							return false, nil
 						}
@@ -234,6 +290,9 @@ func (h *heartbeat) update(ctx context.Context, leaseSpec coordinationv1.LeaseSp
 
 	h.lease.Spec = leaseSpec
 
+	if err := h.waitToWrite(ctx); err != nil {
+		return err
+	}
 	lease, err := h.client.CoordinationV1().Leases(h.leaseNS).Update(ctx, h.lease, metav1.UpdateOptions{})
 	if err != nil {
 		return err
@@ -244,7 +303,10 @@ func (h *heartbeat) update(ctx context.Context, leaseSpec coordinationv1.LeaseSp
 }
 
 func (h *heartbeat) create(ctx context.Context, leaseSpec coordinationv1.LeaseSpec) error {
-	var err error
+	err := h.waitToWrite(ctx)
+	if err != nil {
+		return err
+	}
 	h.lease, err = h.client.CoordinationV1().Leases(h.leaseNS).Create(ctx, &coordinationv1.Lease{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      h.nodeName,
@@ -259,6 +321,15 @@ func (h *heartbeat) create(ctx context.Context, leaseSpec coordinationv1.LeaseSp
 	return err
 }
 
+// waitToWrite waits for the rate limit of the throttle, the lease expiring when its renewals are delayed by the
+// spread of the writes
+func (h *heartbeat) waitToWrite(ctx context.Context) error {
+	if h.throttle == nil {
+		return nil
+	}
+	return h.throttle.WaitRate(ctx)
+}
+
 func (h *heartbeat) createLeaseSpec(acquireTime, renewTime time.Time) coordinationv1.LeaseSpec {
 	return coordinationv1.LeaseSpec{
 		HolderIdentity:       &h.holderIdentity,
@@ -268,31 +339,330 @@ func (h *heartbeat) createLeaseSpec(acquireTime, renewTime time.Time) coordinati
 	}
 }
 
-// isHeartBeatValid checks if there are any leases in the given namespace with the given zone label.
-// If there are no leases, or if any lease is expired, it returns false.
-// If all leases are valid, it returns true.
-func isHeartBeatValid(ctx context.Context, client kubernetes.Interface, zone, ns string) (bool, error) {
-	labelSelector := labels.Set{defaultLeaseZoneLabel: zone}.AsSelector()
+// isDPULeaseValid checks that the lease of the given DPU node exists in the given namespace
+// and is not expired. Without such a lease, as when the DPU node name differs from the one
+// expected, the leases of the given zone are checked instead.
+func isDPULeaseValid(ctx context.Context, client kubernetes.Interface, dpuNodeName, zone, ns string) (bool, error) {
+	lease, err := client.CoordinationV1().Leases(ns).Get(ctx, dpuNodeName, metav1.GetOptions{})
+	if err == nil {
+		if err := validateLease(lease); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
 	leases, err := client.CoordinationV1().Leases(ns).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector.String(),
+		LabelSelector: labels.Set{defaultLeaseZoneLabel: zone}.AsSelector().String(),
 	})
 	if err != nil {
 		return false, err
 	}
+	if err := validateZoneLeases(leasePointers(leases.Items), dpuNodeName, zone, ns); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	if len(leases.Items) == 0 {
-		return false, fmt.Errorf("no lease found in namespace %s", ns)
+func leasePointers(leases []coordinationv1.Lease) []*coordinationv1.Lease {
+	pointers := make([]*coordinationv1.Lease, 0, len(leases))
+	for i := range leases {
+		pointers = append(pointers, &leases[i])
 	}
+	return pointers
+}
 
-	for _, lease := range leases.Items {
-		if lease.Spec.RenewTime.Time.Add(time.Second * time.Duration(*lease.Spec.LeaseDurationSeconds)).Before(time.Now()) {
-			return false, fmt.Errorf("lease %s is expired", lease.Name)
+// validateZoneLeases returns an error if there is no lease in the zone or if any of them is expired
+func validateZoneLeases(leases []*coordinationv1.Lease, dpuNodeName, zone, ns string) error {
+	if len(leases) == 0 {
+		return fmt.Errorf("no lease %s or lease of zone %s found in namespace %s", dpuNodeName, zone, ns)
+	}
+	for _, lease := range leases {
+		if err := validateLease(lease); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return true, nil
+// validateLease returns an error if the lease is expired
+func validateLease(lease *coordinationv1.Lease) error {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return fmt.Errorf("lease %s was never renewed", lease.Name)
+	}
+	if lease.Spec.RenewTime.Time.Add(time.Second * time.Duration(*lease.Spec.LeaseDurationSeconds)).Before(time.Now()) {
+		return fmt.Errorf("lease %s is expired", lease.Name)
+	}
+	return nil
+}
+
+const (
+	// dpuNodeReadyWaitReason is the reason of the readiness condition and of the node events of the DPU host
+	// waiting for its DPU nodes to be ready before it starts the CNI server
+	dpuNodeReadyWaitReason = "WaitingForDPUReady"
+	// noDPUNodeInZoneReason is the reason of the readiness condition and of the node event of the DPU host
+	// running degraded as its zone has no DPU node
+	noDPUNodeInZoneReason = "NoDPUNodeInZone"
+)
+
+// dpuNodeReadiness tracks the wait of the DPU host for the DPU node. It is exposed
+// as a readiness check of the node healthz server, failing with the DPU nodes blocking
+// the start of the CNI server, and the changes of those are reported as node events.
+type dpuNodeReadiness struct {
+	sync.Mutex
+	err error
+	// blocking are the DPU nodes the host waits for
+	blocking []string
+	// recorder, when set, records the events of the wait about nodeRef
+	recorder record.EventRecorder
+	nodeRef  *kapi.ObjectReference
+}
+
+func newDPUNodeReadiness(dpuNodeName string) *dpuNodeReadiness {
+	return &dpuNodeReadiness{err: &readinessCondition{
+		Reason:   dpuNodeReadyWaitReason,
+		Message:  "waiting for the dpu node heartbeat",
+		Blocking: []string{dpuNodeName},
+	}}
+}
+
+// withEvents makes the readiness record the events of the wait about the given node
+func (r *dpuNodeReadiness) withEvents(recorder record.EventRecorder, nodeName string) *dpuNodeReadiness {
+	r.recorder = recorder
+	r.nodeRef = &kapi.ObjectReference{
+		Kind: "Node",
+		Name: nodeName,
+		UID:  ktypes.UID(nodeName),
+	}
+	return r
+}
+
+func (r *dpuNodeReadiness) event(eventType, reason, message string) {
+	if r.recorder != nil {
+		r.recorder.Event(r.nodeRef, eventType, reason, message)
+	}
+}
+
+// waiting records that the host waits for the blocking DPU nodes, a change of those is reported as an event,
+// as a warning once the wait timed out
+func (r *dpuNodeReadiness) waiting(blocking []string, err error, timedOut bool) {
+	r.Lock()
+	defer r.Unlock()
+	sort.Strings(blocking)
+	r.err = &readinessCondition{Reason: dpuNodeReadyWaitReason, Message: err.Error(), Blocking: blocking}
+	if timedOut {
+		r.event(kapi.EventTypeWarning, dpuNodeReadyWaitReason, fmt.Sprintf("Stopped waiting for the DPU nodes %s "+
+			"to be ready, the CNI server is not started: %v", strings.Join(blocking, ", "), err))
+	} else if !slices.Equal(blocking, r.blocking) {
+		r.event(kapi.EventTypeNormal, dpuNodeReadyWaitReason, fmt.Sprintf("Waiting for the DPU nodes %s to be "+
+			"ready before starting the CNI server: %v", strings.Join(blocking, ", "), err))
+	}
+	r.blocking = blocking
+}
+
+// degraded records that the host runs degraded, with the host networking only, as its zone has no DPU node
+func (r *dpuNodeReadiness) degraded(err error) {
+	r.Lock()
+	defer r.Unlock()
+	r.err = &readinessCondition{Reason: noDPUNodeInZoneReason, Message: err.Error()}
+	r.event(kapi.EventTypeWarning, noDPUNodeInZoneReason, fmt.Sprintf("Running with the host networking only, "+
+		"the CNI server is not started until a DPU node joins the zone: %v", err))
+	r.blocking = nil
+}
+
+// ready records that the DPU nodes are ready
+func (r *dpuNodeReadiness) ready() {
+	r.Lock()
+	defer r.Unlock()
+	r.err = nil
+	if len(r.blocking) > 0 {
+		r.event(kapi.EventTypeNormal, "DPUReady", fmt.Sprintf("The DPU nodes %s are ready, starting the CNI server",
+			strings.Join(r.blocking, ", ")))
+	}
+	r.blocking = nil
+}
+
+// check returns nil once the DPU node is ready, the condition of the wait otherwise
+func (r *dpuNodeReadiness) check() error {
+	r.Lock()
+	defer r.Unlock()
+	return r.err
+}
+
+// invalidLeaseNames returns the names of the leases that are expired, the names of the DPU nodes holding them
+func invalidLeaseNames(leases []*coordinationv1.Lease) []string {
+	var names []string
+	for _, lease := range leases {
+		if validateLease(lease) != nil {
+			names = append(names, lease.Name)
+		}
+	}
+	return names
+}
+
+// zoneHasDPUNode returns whether a DPU node holding the lease of the given DPU node or a lease of the zone
+// exists. The leases of the removed DPU nodes are left behind expired, they don't count.
+func zoneHasDPUNode(ctx context.Context, client kubernetes.Interface, lister coordinationlisters.LeaseNamespaceLister,
+	dpuNodeName, zone string) (bool, error) {
+	holders := sets.New[string]()
+	if _, err := lister.Get(dpuNodeName); err == nil {
+		holders.Insert(dpuNodeName)
+	} else if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	leases, err := lister.List(labels.Set{defaultLeaseZoneLabel: zone}.AsSelector())
+	if err != nil {
+		return false, err
+	}
+	for _, lease := range leases {
+		holders.Insert(lease.Name)
+	}
+	for _, name := range sets.List(holders) {
+		_, err := client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return true, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// waitForDPUNodeHeartbeat watches the lease of the given DPU node, or the leases of the given zone
+// without it, in the given namespace until it is valid or the timeout expires. The wait state is
+// reported to readiness. When the zone has had no DPU node for absentGracePeriod, it returns
+// errNoDPUNodeInZone; 0 waits for a DPU node to join the zone instead, as 0 timeout waits until ctx is done.
+func waitForDPUNodeHeartbeat(ctx context.Context, client kubernetes.Interface, dpuNodeName, zone, ns string,
+	timeout, absentGracePeriod time.Duration, readiness *dpuNodeReadiness) error {
+	leaseFactory := informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithNamespace(ns))
+	leaseInformer := leaseFactory.Coordination().V1().Leases()
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	_, err := leaseInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(interface{}, interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add the dpu node lease event handler: %v", err)
+	}
+
+	cancel := func() {}
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer func() {
+		cancel()
+		leaseFactory.Shutdown()
+	}()
+	leaseFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), leaseInformer.Informer().HasSynced) {
+		err = fmt.Errorf("timed out waiting for the dpu node lease informer to sync")
+		readiness.waiting([]string{dpuNodeName}, err, true)
+		return err
+	}
+
+	err = fmt.Errorf("no lease %s found in namespace %s", dpuNodeName, ns)
+	blocking := []string{dpuNodeName}
+	// absent fires once the zone has had no DPU node for the grace period
+	var absent <-chan time.Time
+	notify()
+	for {
+		select {
+		case <-ctx.Done():
+			err = fmt.Errorf("timed out waiting for the dpu node to be ready: %v", err)
+			readiness.waiting(blocking, err, true)
+			return err
+		case <-absent:
+			// the removal of the DPU nodes changes no lease, check again that none joined the zone
+			present, presentErr := zoneHasDPUNode(ctx, client, leaseInformer.Lister().Leases(ns), dpuNodeName, zone)
+			if presentErr != nil || present {
+				absent = nil
+				notify()
+				continue
+			}
+			err = fmt.Errorf("%w %s, none of the nodes holding the lease %s or a lease of the zone in namespace "+
+				"%s exists", errNoDPUNodeInZone, zone, dpuNodeName, ns)
+			readiness.degraded(err)
+			return err
+		case <-changed:
+			var lease *coordinationv1.Lease
+			blocking = []string{dpuNodeName}
+			lease, err = leaseInformer.Lister().Leases(ns).Get(dpuNodeName)
+			if err == nil {
+				err = validateLease(lease)
+			} else if apierrors.IsNotFound(err) {
+				var leases []*coordinationv1.Lease
+				leases, err = leaseInformer.Lister().Leases(ns).List(labels.Set{defaultLeaseZoneLabel: zone}.AsSelector())
+				if err == nil {
+					err = validateZoneLeases(leases, dpuNodeName, zone, ns)
+					if expired := invalidLeaseNames(leases); len(expired) > 0 {
+						blocking = expired
+					}
+				}
+			}
+			if err == nil {
+				readiness.ready()
+				return nil
+			}
+			if absentGracePeriod > 0 {
+				present, presentErr := zoneHasDPUNode(ctx, client, leaseInformer.Lister().Leases(ns), dpuNodeName, zone)
+				if presentErr != nil {
+					klog.Warningf("Failed to check that zone %s has a dpu node: %v", zone, presentErr)
+				} else if present {
+					absent = nil
+				} else if absent == nil {
+					absent = time.After(absentGracePeriod)
+				}
+			}
+			klog.Infof("Waiting for the dpu nodes %v to be ready: %v", blocking, err)
+			readiness.waiting(blocking, fmt.Errorf("waiting for the dpu node to be ready: %v", err), false)
+		}
+	}
 }
 
 func newTicker(d time.Duration) *time.Ticker {
 	return time.NewTicker(d)
 }
+
+// pruneStaleDPULeases deletes the leases of the zone in the given namespace that have been expired for
+// staleDPULeaseAge and whose node was removed, nothing deletes the leases of the removed DPU nodes otherwise. It
+// is a zone task, run by a single node of the zone with the zone leader election.
+func pruneStaleDPULeases(ctx context.Context, client kubernetes.Interface, zone, ns string) error {
+	leases, err := client.CoordinationV1().Leases(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{defaultLeaseZoneLabel: zone}.AsSelector().String(),
+	})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, lease := range leases.Items {
+		if lease.Spec.RenewTime != nil && time.Since(lease.Spec.RenewTime.Time) < staleDPULeaseAge {
+			continue
+		}
+		if _, err := client.CoreV1().Nodes().Get(ctx, lease.Name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		// the lease renewed since it was listed is left to its node
+		err := client.CoordinationV1().Leases(ns).Delete(ctx, lease.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+		})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			errs = append(errs, fmt.Errorf("failed to delete the lease %s of removed node: %w", lease.Name, err))
+			continue
+		}
+		klog.Infof("Pruned the lease %s of removed DPU node of zone %s", lease.Name, zone)
+	}
+	return utilerrors.Join(errs...)
+}
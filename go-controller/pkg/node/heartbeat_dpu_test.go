@@ -0,0 +1,98 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("DPU host wait for the DPU node", func() {
+	var expiredLease *coordinationv1.Lease
+
+	BeforeEach(func() {
+		duration := int32(defaultLeaseDurationSeconds)
+		expiredLease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dpu1",
+				Namespace: defaultLeaseNS,
+				Labels:    map[string]string{defaultLeaseZoneLabel: "zone1"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: &duration,
+				RenewTime:            &metav1.MicroTime{Time: time.Now().Add(-time.Hour)},
+			},
+		}
+	})
+
+	It("runs degraded once the zone has no DPU node", func() {
+		client := fake.NewSimpleClientset(expiredLease)
+		recorder := record.NewFakeRecorder(10)
+		readiness := newDPUNodeReadiness("dpu1").withEvents(recorder, "host1")
+
+		err := waitForDPUNodeHeartbeat(context.Background(), client, "dpu1", "zone1", defaultLeaseNS,
+			10*time.Second, 100*time.Millisecond, readiness)
+		Expect(err).To(MatchError(errNoDPUNodeInZone))
+		condition, ok := readiness.check().(*readinessCondition)
+		Expect(ok).To(BeTrue())
+		Expect(condition.Reason).To(Equal(noDPUNodeInZoneReason))
+		Eventually(recorder.Events).Should(Receive(ContainSubstring(noDPUNodeInZoneReason)))
+	})
+
+	It("keeps waiting for the DPU node of the zone that exists", func() {
+		client := fake.NewSimpleClientset(expiredLease, &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "dpu1"}})
+		readiness := newDPUNodeReadiness("dpu1")
+
+		err := waitForDPUNodeHeartbeat(context.Background(), client, "dpu1", "zone1", defaultLeaseNS,
+			time.Second, 100*time.Millisecond, readiness)
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for the dpu node to be ready")))
+		condition, ok := readiness.check().(*readinessCondition)
+		Expect(ok).To(BeTrue())
+		Expect(condition.Reason).To(Equal(dpuNodeReadyWaitReason))
+		Expect(condition.Blocking).To(Equal([]string{"dpu1"}))
+	})
+
+	It("does not record the events of the wait without a recorder", func() {
+		cnnci := NewCommonNodeNetworkControllerInfo(fake.NewSimpleClientset(), nil, nil, nil, nil, "host1", nil)
+		readiness := newDPUNodeReadiness("dpu1").withEvents(cnnci.recorder, cnnci.name)
+
+		Expect(func() {
+			readiness.waiting([]string{"dpu1"}, errors.New("lease dpu1 is expired"), false)
+			readiness.waiting([]string{"dpu1"}, errors.New("lease dpu1 is expired"), true)
+			readiness.degraded(errNoDPUNodeInZone)
+		}).NotTo(Panic())
+		condition, ok := readiness.check().(*readinessCondition)
+		Expect(ok).To(BeTrue())
+		Expect(condition.Reason).To(Equal(noDPUNodeInZoneReason))
+	})
+
+	It("prunes the expired leases of the removed DPU nodes of the zone", func() {
+		freshLease := expiredLease.DeepCopy()
+		freshLease.Name = "dpu2"
+		freshLease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+		nodeLease := expiredLease.DeepCopy()
+		nodeLease.Name = "dpu3"
+		otherZoneLease := expiredLease.DeepCopy()
+		otherZoneLease.Name = "dpu4"
+		otherZoneLease.Labels = map[string]string{defaultLeaseZoneLabel: "zone2"}
+		client := fake.NewSimpleClientset(expiredLease, freshLease, nodeLease, otherZoneLease,
+			&kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "dpu3"}})
+
+		Expect(pruneStaleDPULeases(context.Background(), client, "zone1", defaultLeaseNS)).To(Succeed())
+		leases, err := client.CoordinationV1().Leases(defaultLeaseNS).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, lease := range leases.Items {
+			names = append(names, lease.Name)
+		}
+		Expect(names).To(ConsistOf("dpu2", "dpu3", "dpu4"))
+	})
+})
@@ -6,9 +6,12 @@ package node
 import (
 	"fmt"
 	"net"
+	"path/filepath"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/vishvananda/netlink"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
@@ -70,10 +73,161 @@ func getDefaultGatewayInterfaceDetails(gwIface string, ipV4Mode, ipV6Mode bool)
 	return intfName, gatewayIPs, nil
 }
 
+// virtualGatewayInterfaceTypes are the link types of the virtual devices never selected as the autodetected
+// gateway interface, e.g. the tunnels of the VPNs installing a default route
+var virtualGatewayInterfaceTypes = sets.New[string]("dummy", "geneve", "gre", "gretap", "ip6gre", "ip6tnl", "ipip",
+	"sit", "tuntap", "veth", "vti", "vxlan", "wireguard")
+
+// GatewayInterfaceCandidate is a next hop of a default route considered for the autodetected gateway interface
+type GatewayInterfaceCandidate struct {
+	Interface string `json:"interface"`
+	NextHop   net.IP `json:"nextHop,omitempty"`
+	Metric    int    `json:"metric"`
+	// Skipped is the reason the candidate can't be selected, empty for the eligible candidates
+	Skipped  string `json:"skipped,omitempty"`
+	Selected bool   `json:"selected,omitempty"`
+}
+
+// GatewayInterfaceSelection is the selection of the gateway interface among the default routes of an IP family
+type GatewayInterfaceSelection struct {
+	Family     string                      `json:"family"`
+	Candidates []GatewayInterfaceCandidate `json:"candidates"`
+}
+
+// selected returns the selected candidate, nil if no candidate is eligible
+func (s *GatewayInterfaceSelection) selected() *GatewayInterfaceCandidate {
+	for i := range s.Candidates {
+		if s.Candidates[i].Selected {
+			return &s.Candidates[i]
+		}
+	}
+	return nil
+}
+
+// ReportGatewayInterfaceSelection returns how the gateway interface is autodetected on the node with the current
+// configuration, for each IP family, without changing the node
+func ReportGatewayInterfaceSelection() ([]*GatewayInterfaceSelection, error) {
+	var selections []*GatewayInterfaceSelection
+	if config.IPv4Mode {
+		selection, err := selectGatewayInterface(netlink.FAMILY_V4)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	if config.IPv6Mode {
+		selection, err := selectGatewayInterface(netlink.FAMILY_V6)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+	return selections, nil
+}
+
+// selectGatewayInterface selects the gateway interface among the next hops of the default routes of the IP family.
+// The next hops without gateway, through a virtual device, through a member of a bond, through an interface
+// matching the gateway interface-exclude patterns or excluded by node-ip-exclude are skipped; of the others, the next hop of the route with
+// the lowest metric is selected, the first of the routing table among the routes with the same metric, i.e.
+// the one the kernel uses. The next hops of a multipath route are considered in order with the metric of
+// the route.
+func selectGatewayInterface(family int) (*GatewayInterfaceSelection, error) {
+	selection := &GatewayInterfaceSelection{Family: "IPv4"}
+	if family == netlink.FAMILY_V6 {
+		selection.Family = "IPv6"
+	}
+	routes, err := util.GetNetLinkOps().RouteListFiltered(family, &netlink.Route{Dst: nil}, netlink.RT_FILTER_DST)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing table in node: %w", err)
+	}
+	excludePatterns := config.Gateway.GetInterfaceExcludePatterns()
+	for _, r := range routes {
+		nextHops := []*netlink.NexthopInfo{{LinkIndex: r.LinkIndex, Gw: r.Gw}}
+		if len(r.MultiPath) > 0 {
+			nextHops = r.MultiPath
+		}
+		for _, nh := range nextHops {
+			candidate := GatewayInterfaceCandidate{NextHop: nh.Gw, Metric: r.Priority}
+			link, err := util.GetNetLinkOps().LinkByIndex(nh.LinkIndex)
+			if err != nil {
+				candidate.Interface = fmt.Sprintf("index %d", nh.LinkIndex)
+				candidate.Skipped = fmt.Sprintf("failed to get the link: %v", err)
+				selection.Candidates = append(selection.Candidates, candidate)
+				continue
+			}
+			attrs := link.Attrs()
+			if attrs == nil {
+				return nil, fmt.Errorf("no attributes found for link: %#v", link)
+			}
+			candidate.Interface = attrs.Name
+			candidate.Skipped = gatewayInterfaceSkipReason(attrs, link.Type(), nh.Gw, excludePatterns)
+			selection.Candidates = append(selection.Candidates, candidate)
+		}
+	}
+
+	var selected *GatewayInterfaceCandidate
+	for i := range selection.Candidates {
+		candidate := &selection.Candidates[i]
+		if candidate.Skipped == "" && (selected == nil || candidate.Metric < selected.Metric) {
+			selected = candidate
+		}
+	}
+	for _, candidate := range selection.Candidates {
+		if candidate.Skipped != "" {
+			klog.Infof("Skipped %s default gateway interface %s %s: %s", selection.Family, candidate.Interface,
+				candidate.NextHop, candidate.Skipped)
+		}
+	}
+	if selected != nil {
+		selected.Selected = true
+		klog.Infof("Found default gateway interface %s %s with metric %d", selected.Interface, selected.NextHop,
+			selected.Metric)
+	}
+	return selection, nil
+}
+
+// gatewayInterfaceSkipReason returns why the next hop through the link can't be the autodetected gateway, empty
+// if it can
+func gatewayInterfaceSkipReason(attrs *netlink.LinkAttrs, linkType string, gw net.IP, excludePatterns []string) string {
+	if gw == nil {
+		return "no gateway"
+	}
+	for _, pattern := range excludePatterns {
+		if matched, _ := filepath.Match(pattern, attrs.Name); matched {
+			return fmt.Sprintf("excluded by pattern %q", pattern)
+		}
+	}
+	if pattern := config.Default.NodeIPExclude.ExcludedInterfacePattern(attrs.Name); pattern != "" {
+		return fmt.Sprintf("excluded by node IP exclusion pattern %q", pattern)
+	}
+	if cidr := config.Default.NodeIPExclude.ExcludedNetwork(gw); cidr != nil {
+		return fmt.Sprintf("gateway in excluded network %s", cidr)
+	}
+	if attrs.Slave != nil && attrs.Slave.SlaveType() == "bond" {
+		return "member of a bond"
+	}
+	if virtualGatewayInterfaceTypes.Has(linkType) {
+		return fmt.Sprintf("virtual device of type %s", linkType)
+	}
+	return ""
+}
+
 // uses netlink to do a route lookup for default gateway
 // takes IP address family and optional name of a pre-determined gateway interface
 // returns name of default gateway interface, the default gateway ip, and any error
+// The gateway interface is selected with selectGatewayInterface when none is provided.
 func getDefaultGatewayInterfaceByFamily(family int, gwIface string) (string, net.IP, error) {
+	if len(gwIface) == 0 {
+		selection, err := selectGatewayInterface(family)
+		if err != nil {
+			return "", nil, err
+		}
+		if selected := selection.selected(); selected != nil {
+			return selected.Interface, selected.NextHop, nil
+		}
+		return "", net.IP{}, nil
+	}
+
 	// filter the default route to obtain the gateway
 	filter := &netlink.Route{Dst: nil}
 	mask := netlink.RT_FILTER_DST
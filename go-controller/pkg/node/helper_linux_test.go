@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -230,11 +231,11 @@ func TestGetDefaultGatewayInterfaceByFamily(t *testing.T) {
 						Gw:        ovntest.MustParseIP("3.3.3.3"),
 					},
 				}, nil}},
-				{OnCallMethodName: "LinkByIndex", OnCallMethodArgType: []string{"int"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkByIndex", OnCallMethodArgType: []string{"int"}, RetArgList: []interface{}{mockLink, nil}, CallTimes: 2},
 			},
 			linkMockHelper: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}, CallTimes: 2},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}, CallTimes: 2},
 			},
 		},
 		{
@@ -291,6 +292,132 @@ func TestGetDefaultGatewayInterfaceByFamily(t *testing.T) {
 	}
 }
 
+func TestSelectGatewayInterface(t *testing.T) {
+	mockNetLinkOps := new(util_mocks.NetLinkOps)
+	// below sets the `netLinkOps` in util/net_linux.go to a mock instance for purpose of unit tests execution
+	util.SetNetLinkOpMockInst(mockNetLinkOps)
+	defer util.ResetNetLinkOpMockInst()
+
+	gw1 := ovntest.MustParseIP("1.1.1.1")
+	gw2 := ovntest.MustParseIP("2.2.2.2")
+	gw3 := ovntest.MustParseIP("3.3.3.3")
+	eth0 := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 1, Name: "eth0"}}
+	eth1 := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 2, Name: "eth1", Slave: &netlink.BondSlave{}}}
+	bond0 := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Index: 3, Name: "bond0"}}
+	wg0 := &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Index: 4, Name: "wg0"}}
+	tailscale0 := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Index: 5, Name: "tailscale0"}}
+	links := []netlink.Link{eth0, eth1, bond0, wg0, tailscale0}
+
+	tests := []struct {
+		desc          string
+		exclude       string
+		nodeIPExclude string
+		routes        []netlink.Route
+		expCandidates []GatewayInterfaceCandidate
+	}{
+		{
+			desc: "the default route with the lowest metric is selected",
+			routes: []netlink.Route{
+				{LinkIndex: 1, Gw: gw1, Priority: 200},
+				{LinkIndex: 3, Gw: gw3, Priority: 100},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "eth0", NextHop: gw1, Metric: 200},
+				{Interface: "bond0", NextHop: gw3, Metric: 100, Selected: true},
+			},
+		},
+		{
+			desc: "the first default route of the routing table is selected among the routes with the same metric",
+			routes: []netlink.Route{
+				{LinkIndex: 3, Gw: gw3, Priority: 100},
+				{LinkIndex: 1, Gw: gw1, Priority: 100},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "bond0", NextHop: gw3, Metric: 100, Selected: true},
+				{Interface: "eth0", NextHop: gw1, Metric: 100},
+			},
+		},
+		{
+			desc: "the virtual devices and the bond members are skipped",
+			routes: []netlink.Route{
+				{LinkIndex: 4, Gw: gw1, Priority: 50},
+				{LinkIndex: 2, Gw: gw2, Priority: 60},
+				{LinkIndex: 3, Gw: gw3, Priority: 100},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "wg0", NextHop: gw1, Metric: 50, Skipped: "virtual device of type wireguard"},
+				{Interface: "eth1", NextHop: gw2, Metric: 60, Skipped: "member of a bond"},
+				{Interface: "bond0", NextHop: gw3, Metric: 100, Selected: true},
+			},
+		},
+		{
+			desc:    "the excluded interfaces are skipped",
+			exclude: "wg*, tailscale*",
+			routes: []netlink.Route{
+				{LinkIndex: 5, Gw: gw1},
+				{LinkIndex: 1, Gw: gw2, Priority: 100},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "tailscale0", NextHop: gw1, Skipped: `excluded by pattern "tailscale*"`},
+				{Interface: "eth0", NextHop: gw2, Metric: 100, Selected: true},
+			},
+		},
+		{
+			desc:          "the interfaces and next hops excluded from the node IPs are skipped",
+			nodeIPExclude: "1.1.1.0/24,bond*",
+			routes: []netlink.Route{
+				{LinkIndex: 1, Gw: gw1},
+				{LinkIndex: 3, Gw: gw3},
+				{LinkIndex: 1, Gw: gw2, Priority: 100},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "eth0", NextHop: gw1, Skipped: "gateway in excluded network 1.1.1.0/24"},
+				{Interface: "bond0", NextHop: gw3, Skipped: `excluded by node IP exclusion pattern "bond*"`},
+				{Interface: "eth0", NextHop: gw2, Metric: 100, Selected: true},
+			},
+		},
+		{
+			desc: "the next hops of a multipath route are considered in order",
+			routes: []netlink.Route{
+				{Priority: 10, MultiPath: []*netlink.NexthopInfo{{LinkIndex: 4, Gw: gw1}, {LinkIndex: 1}, {LinkIndex: 3, Gw: gw3}}},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "wg0", NextHop: gw1, Metric: 10, Skipped: "virtual device of type wireguard"},
+				{Interface: "eth0", Metric: 10, Skipped: "no gateway"},
+				{Interface: "bond0", NextHop: gw3, Metric: 10, Selected: true},
+			},
+		},
+		{
+			desc: "no interface is selected without eligible default route",
+			routes: []netlink.Route{
+				{LinkIndex: 4, Gw: gw1},
+			},
+			expCandidates: []GatewayInterfaceCandidate{
+				{Interface: "wg0", NextHop: gw1, Skipped: "virtual device of type wireguard"},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			assert.NoError(t, config.PrepareTestConfig())
+			config.Gateway.InterfaceExclude = tc.exclude
+			nodeIPExclusions, err := config.ParseNodeIPExclusions(tc.nodeIPExclude)
+			assert.NoError(t, err)
+			config.Default.NodeIPExclude = nodeIPExclusions
+			mockNetLinkOps.ExpectedCalls = nil
+			mockNetLinkOps.On("RouteListFiltered", netlink.FAMILY_V4, &netlink.Route{}, netlink.RT_FILTER_DST).Return(tc.routes, nil)
+			for _, link := range links {
+				mockNetLinkOps.On("LinkByIndex", link.Attrs().Index).Return(link, nil).Maybe()
+			}
+			selection, err := selectGatewayInterface(netlink.FAMILY_V4)
+			assert.NoError(t, err)
+			assert.Equal(t, "IPv4", selection.Family)
+			assert.Equal(t, tc.expCandidates, selection.Candidates)
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
+
 func TestGetDefaultGatewayInterfaceDetails(t *testing.T) {
 	mockNetLinkOps := new(util_mocks.NetLinkOps)
 	mockLink := new(netlink_mocks.Link)
@@ -338,7 +465,7 @@ func TestGetDefaultGatewayInterfaceDetails(t *testing.T) {
 			},
 			linkMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 			},
 		},
 		{
@@ -359,7 +486,7 @@ func TestGetDefaultGatewayInterfaceDetails(t *testing.T) {
 			},
 			linkMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 			},
 		},
 		{
@@ -385,9 +512,9 @@ func TestGetDefaultGatewayInterfaceDetails(t *testing.T) {
 			},
 			linkMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "invalidInterface"}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "invalidInterface"}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 			},
 		},
 		{
@@ -414,9 +541,9 @@ func TestGetDefaultGatewayInterfaceDetails(t *testing.T) {
 			},
 			linkMockHelper: []ovntest.TestifyMockHelper{
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: defaultIf}}},
+				{OnCallMethodName: "Type", OnCallMethodArgType: []string{}, RetArgList: []interface{}{"device"}},
 			},
 		},
 	}
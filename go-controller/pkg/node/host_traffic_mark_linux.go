@@ -0,0 +1,277 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const (
+	// ovnkubeHostTrafficMark is the mark of the sockets of the host traffic cgroups. The bits are added to the
+	// mark of every socket of the cgroups, they don't overlap the masquerade and drop bits of kube-proxy.
+	ovnkubeHostTrafficMark = "0x17450000"
+	// hostTrafficMarkValue is ovnkubeHostTrafficMark set by the socket mark program
+	hostTrafficMarkValue = 0x17450000
+	// bpfSockMarkOffset is the offset of the mark in struct bpf_sock, the context of the cgroup sock programs
+	bpfSockMarkOffset = 16
+)
+
+var (
+	// cgroup2Root is the mount point of the cgroup v2 hierarchy the host traffic cgroups are found in
+	cgroup2Root = "/sys/fs/cgroup"
+	// hostTrafficPinDir is the bpffs directory the socket mark programs are pinned in by cgroup, so that a
+	// restarted ovnkube-node replaces them
+	hostTrafficPinDir = "/sys/fs/bpf/ovnkube-node-host-traffic"
+)
+
+// hostTrafficMarkArgs returns the iptables match of the packets not sent by the sockets of the host traffic
+// cgroups, nothing when the host traffic is only identified by its addresses
+func hostTrafficMarkArgs() []string {
+	if len(config.OvnKubeNode.GetHostTrafficCgroups()) == 0 {
+		return nil
+	}
+	return []string{"-m", "mark", "!", "--mark", ovnkubeHostTrafficMark + "/" + ovnkubeHostTrafficMark}
+}
+
+// syncHostTrafficMarking attaches the socket mark program to the given cgroups, replacing the programs
+// attached by a former ovnkube-node, and detaches it from the cgroups not given anymore
+func syncHostTrafficMarking(cgroups []string) error {
+	var errs []error
+	pinned, err := os.ReadDir(hostTrafficPinDir)
+	if err != nil && !os.IsNotExist(err) {
+		errs = append(errs, fmt.Errorf("failed to list the pinned socket mark programs: %w", err))
+	}
+	wanted := map[string]bool{}
+	for _, cgroup := range cgroups {
+		wanted[cgroup] = true
+		if err := markCgroupSockets(cgroup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, entry := range pinned {
+		cgroup, err := url.PathUnescape(entry.Name())
+		if err != nil || wanted[cgroup] {
+			continue
+		}
+		if err := unmarkCgroupSockets(cgroup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func hostTrafficPinPath(cgroup string) string {
+	return filepath.Join(hostTrafficPinDir, url.PathEscape(cgroup))
+}
+
+// markCgroupSockets attaches the socket mark program to the cgroup, the sockets created in the cgroup and in
+// its descendants get the ovnkubeHostTrafficMark bits. The program a former ovnkube-node attached is
+// detached once the new one is attached, so that no socket is created unmarked.
+func markCgroupSockets(cgroup string) error {
+	cgroupDir := filepath.Join(cgroup2Root, cgroup)
+	progFD, err := loadSocketMarkProgram(hostTrafficMarkValue)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(progFD)
+	if err := bpfProgAttach(unix.BPF_PROG_ATTACH, cgroupDir, progFD); err != nil {
+		return fmt.Errorf("failed to attach the socket mark program to cgroup %s: %w", cgroup, err)
+	}
+
+	pinPath := hostTrafficPinPath(cgroup)
+	if oldFD, err := bpfObjGet(pinPath); err == nil {
+		if err := bpfProgAttach(unix.BPF_PROG_DETACH, cgroupDir, oldFD); err != nil && !errors.Is(err, unix.ENOENT) {
+			klog.Warningf("Failed to detach the former socket mark program from cgroup %s: %v", cgroup, err)
+		}
+		unix.Close(oldFD)
+		if err := os.Remove(pinPath); err != nil {
+			return fmt.Errorf("failed to unpin the former socket mark program of cgroup %s: %w", cgroup, err)
+		}
+	}
+	if err := os.MkdirAll(hostTrafficPinDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create the directory of the socket mark programs: %w", err)
+	}
+	if err := bpfObjPin(pinPath, progFD); err != nil {
+		return fmt.Errorf("failed to pin the socket mark program of cgroup %s: %w", cgroup, err)
+	}
+	klog.Infof("Marking the sockets of cgroup %s as host traffic", cgroup)
+	return nil
+}
+
+// unmarkCgroupSockets detaches the socket mark program from the cgroup
+func unmarkCgroupSockets(cgroup string) error {
+	pinPath := hostTrafficPinPath(cgroup)
+	progFD, err := bpfObjGet(pinPath)
+	if err != nil {
+		return fmt.Errorf("failed to get the socket mark program of cgroup %s: %w", cgroup, err)
+	}
+	defer unix.Close(progFD)
+	err = bpfProgAttach(unix.BPF_PROG_DETACH, filepath.Join(cgroup2Root, cgroup), progFD)
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("failed to detach the socket mark program from cgroup %s: %w", cgroup, err)
+	}
+	if err := os.Remove(pinPath); err != nil {
+		return fmt.Errorf("failed to unpin the socket mark program of cgroup %s: %w", cgroup, err)
+	}
+	klog.Infof("Stopped marking the sockets of cgroup %s as host traffic", cgroup)
+	return nil
+}
+
+// bpfInstruction encodes an eBPF instruction
+func bpfInstruction(code, dst, src uint8, off int16, imm int32) uint64 {
+	return uint64(code) | uint64(src<<4|dst&0x0f)<<8 | uint64(uint16(off))<<16 | uint64(uint32(imm))<<32
+}
+
+// socketMarkInstructions returns the cgroup sock program adding the mark bits to the mark of the created
+// sockets, the bits set by the programs of other agents are kept
+func socketMarkInstructions(mark uint32) []uint64 {
+	const (
+		// BPF_ALU64 | BPF_MOV | BPF_K
+		movImm = 0xb7
+		// BPF_LDX | BPF_MEM | BPF_W
+		loadWord = 0x61
+		// BPF_ALU | BPF_OR | BPF_K
+		orImm = 0x44
+		// BPF_STX | BPF_MEM | BPF_W
+		storeWord = 0x63
+		// BPF_JMP | BPF_EXIT
+		exit = 0x95
+	)
+	return []uint64{
+		// r2 = ((struct bpf_sock *)r1)->mark
+		bpfInstruction(loadWord, 2, 1, bpfSockMarkOffset, 0),
+		// r2 |= mark
+		bpfInstruction(orImm, 2, 0, 0, int32(mark)),
+		// ((struct bpf_sock *)r1)->mark = r2
+		bpfInstruction(storeWord, 1, 2, bpfSockMarkOffset, 0),
+		// return 1, the socket is created
+		bpfInstruction(movImm, 0, 0, 0, 1),
+		bpfInstruction(exit, 0, 0, 0, 0),
+	}
+}
+
+// bpfProgLoadAttr is the BPF_PROG_LOAD part of union bpf_attr
+type bpfProgLoadAttr struct {
+	progType           uint32
+	insnCnt            uint32
+	insns              uint64
+	license            uint64
+	logLevel           uint32
+	logSize            uint32
+	logBuf             uint64
+	kernVersion        uint32
+	progFlags          uint32
+	progName           [unix.BPF_OBJ_NAME_LEN]byte
+	progIfindex        uint32
+	expectedAttachType uint32
+}
+
+// bpfProgAttachAttr is the BPF_PROG_ATTACH and BPF_PROG_DETACH part of union bpf_attr
+type bpfProgAttachAttr struct {
+	targetFD    uint32
+	attachBPFFD uint32
+	attachType  uint32
+	attachFlags uint32
+}
+
+// bpfObjAttr is the BPF_OBJ_PIN and BPF_OBJ_GET part of union bpf_attr
+type bpfObjAttr struct {
+	pathname  uint64
+	bpfFD     uint32
+	fileFlags uint32
+}
+
+func bpf(cmd int, attr unsafe.Pointer, size uintptr) (int, error) {
+	fd, _, errno := unix.Syscall(unix.SYS_BPF, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+// loadSocketMarkProgram loads the cgroup sock program marking the created sockets with mark
+func loadSocketMarkProgram(mark uint32) (int, error) {
+	insns := socketMarkInstructions(mark)
+	license := []byte("Apache-2.0\x00")
+	log := make([]byte, 4096)
+	attr := bpfProgLoadAttr{
+		progType:           unix.BPF_PROG_TYPE_CGROUP_SOCK,
+		insnCnt:            uint32(len(insns)),
+		insns:              uint64(uintptr(unsafe.Pointer(&insns[0]))),
+		license:            uint64(uintptr(unsafe.Pointer(&license[0]))),
+		logLevel:           1,
+		logSize:            uint32(len(log)),
+		logBuf:             uint64(uintptr(unsafe.Pointer(&log[0]))),
+		expectedAttachType: unix.BPF_CGROUP_INET_SOCK_CREATE,
+	}
+	copy(attr.progName[:], "ovnk_host_mark")
+	fd, err := bpf(unix.BPF_PROG_LOAD, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(insns)
+	runtime.KeepAlive(license)
+	if err != nil {
+		return -1, fmt.Errorf("failed to load the socket mark program: %w: %s", err,
+			strings.TrimRight(string(log), "\x00"))
+	}
+	return fd, nil
+}
+
+// bpfProgAttach attaches the program to or detaches it from the cgroup directory, with cmd BPF_PROG_ATTACH or
+// BPF_PROG_DETACH. The program is attached along with the programs of the other agents.
+func bpfProgAttach(cmd int, cgroupDir string, progFD int) error {
+	cgroupFD, err := unix.Open(cgroupDir, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open cgroup %s: %w", cgroupDir, err)
+	}
+	defer unix.Close(cgroupFD)
+	attr := bpfProgAttachAttr{
+		targetFD:    uint32(cgroupFD),
+		attachBPFFD: uint32(progFD),
+		attachType:  unix.BPF_CGROUP_INET_SOCK_CREATE,
+	}
+	if cmd == unix.BPF_PROG_ATTACH {
+		attr.attachFlags = unix.BPF_F_ALLOW_MULTI
+	}
+	_, err = bpf(cmd, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	return err
+}
+
+func bpfObjPin(path string, fd int) error {
+	pathname, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	attr := bpfObjAttr{
+		pathname: uint64(uintptr(unsafe.Pointer(pathname))),
+		bpfFD:    uint32(fd),
+	}
+	_, err = bpf(unix.BPF_OBJ_PIN, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(pathname)
+	return err
+}
+
+func bpfObjGet(path string) (int, error) {
+	pathname, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return -1, err
+	}
+	attr := bpfObjAttr{
+		pathname: uint64(uintptr(unsafe.Pointer(pathname))),
+	}
+	fd, err := bpf(unix.BPF_OBJ_GET, unsafe.Pointer(&attr), unsafe.Sizeof(attr))
+	runtime.KeepAlive(pathname)
+	return fd, err
+}
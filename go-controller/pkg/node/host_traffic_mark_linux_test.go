@@ -0,0 +1,92 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-iptables/iptables"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+var _ = Describe("Host traffic marking", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	It("encodes the program adding the mark bits to the mark of the sockets", func() {
+		Expect(socketMarkInstructions(hostTrafficMarkValue)).To(Equal([]uint64{
+			0x0000000000101261, // r2 = *(u32 *)(r1 + 16)
+			0x1745000000000244, // w2 |= 0x17450000
+			0x0000000000102163, // *(u32 *)(r1 + 16) = r2
+			0x00000001000000b7, // r0 = 1
+			0x0000000000000095, // exit
+		}))
+	})
+
+	It("keeps the mark bits set by other agents", func() {
+		for _, mark := range []uint32{0, 0x4000, 0x17450000, 0x80004000} {
+			result, sockMark := runSocketMarkProgram(socketMarkInstructions(hostTrafficMarkValue), mark)
+			Expect(result).To(Equal(uint64(1)))
+			Expect(sockMark).To(Equal(mark | hostTrafficMarkValue))
+		}
+	})
+
+	It("loads the socket mark program", func() {
+		fd, err := loadSocketMarkProgram(hostTrafficMarkValue)
+		if errors.Is(err, unix.EPERM) || errors.Is(err, unix.ENOSYS) {
+			Skip("loading eBPF programs is not permitted: " + err.Error())
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unix.Close(fd)).To(Succeed())
+	})
+
+	It("SNATs the marked host traffic to the local endpoints to the management port", func() {
+		rule := getSkipMgmtSNATRule("TCP", "8080", "10.244.0.5", iptables.ProtocolIPv4)
+		Expect(rule.Args).To(Equal([]string{"-p", "TCP", "-d", "10.244.0.5", "--dport", "8080", "-j", "RETURN"}))
+
+		config.OvnKubeNode.HostTrafficCgroups = "/system.slice/kubelet.service"
+		rule = getSkipMgmtSNATRule("TCP", "8080", "10.244.0.5", iptables.ProtocolIPv4)
+		Expect(rule.Args).To(Equal([]string{"-p", "TCP", "-d", "10.244.0.5", "--dport", "8080",
+			"-m", "mark", "!", "--mark", "0x17450000/0x17450000", "-j", "RETURN"}))
+	})
+})
+
+// runSocketMarkProgram runs the instructions of the socket mark program on a socket with mark, it returns the
+// result of the program and the mark of the socket after it ran
+func runSocketMarkProgram(insns []uint64, mark uint32) (uint64, uint32) {
+	var regs [11]uint64
+	for _, insn := range insns {
+		code := uint8(insn)
+		dst := uint8(insn>>8) & 0x0f
+		src := uint8(insn>>12) & 0x0f
+		off := int16(insn >> 16)
+		imm := int32(insn >> 32)
+		switch code {
+		case 0xb7:
+			regs[dst] = uint64(int64(imm))
+		case 0x44:
+			regs[dst] = uint64(uint32(regs[dst]) | uint32(imm))
+		case 0x61:
+			Expect(src).To(Equal(uint8(1)))
+			Expect(off).To(Equal(int16(bpfSockMarkOffset)))
+			regs[dst] = uint64(mark)
+		case 0x63:
+			Expect(dst).To(Equal(uint8(1)))
+			Expect(off).To(Equal(int16(bpfSockMarkOffset)))
+			mark = uint32(regs[src])
+		case 0x95:
+			return regs[0], mark
+		default:
+			Fail(fmt.Sprintf("unexpected instruction %#016x", insn))
+		}
+	}
+	Fail("the program does not exit")
+	return 0, 0
+}
@@ -0,0 +1,142 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// hostnameCheckPeriod is how often the hostnames of the node are checked
+	hostnameCheckPeriod = time.Minute
+	// hostnameMismatchEventReason is the reason of the node events reporting a hostname mismatch
+	hostnameMismatchEventReason = "HostnameMismatch"
+)
+
+// getKernelHostname returns the kernel hostname, it is overridden by the tests
+var getKernelHostname = os.Hostname
+
+// hostnameChecker detects the hostnames of the node drifting from the node name. ovn-controller registers
+// the chassis with the OVS external_ids:hostname and the port bindings of the node follow it, so the
+// external ID is set back to the node name when it is changed, e.g. by the OVS startup scripts after the
+// kernel hostname changed. A kernel hostname not matching the node name is reported as a node event, and
+// makes the node unhealthy when the hostname-mismatch-fail option is set.
+type hostnameChecker struct {
+	nodeName string
+	nodeRef  *kapi.ObjectReference
+	recorder record.EventRecorder
+
+	lock sync.Mutex
+	// mismatch is the last kernel hostname mismatch, nil when the kernel hostname matches
+	mismatch error
+}
+
+func newHostnameChecker(nodeName string, recorder record.EventRecorder) *hostnameChecker {
+	return &hostnameChecker{
+		nodeName: nodeName,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		recorder: recorder,
+	}
+}
+
+func (c *hostnameChecker) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, hostnameCheckPeriod, nil, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to check the hostnames of node %s: %v", c.nodeName, err)
+		}
+	})
+}
+
+// sync checks the kernel hostname and sets the OVS hostname back to the node name when it drifted
+func (c *hostnameChecker) sync() error {
+	if err := c.checkKernelHostname(); err != nil {
+		return err
+	}
+	return c.syncOVSHostname()
+}
+
+// checkKernelHostname reports the kernel hostname when it starts or stops matching the node name, it
+// returns the mismatch when the hostname-mismatch-fail option is set
+func (c *hostnameChecker) checkKernelHostname() error {
+	hostname, err := getKernelHostname()
+	if err != nil {
+		return fmt.Errorf("failed to get the kernel hostname: %w", err)
+	}
+	var mismatch error
+	if !hostnameMatchesNodeName(hostname, c.nodeName) {
+		mismatch = fmt.Errorf("kernel hostname %s does not match node name %s", hostname, c.nodeName)
+	}
+
+	c.lock.Lock()
+	changed := (mismatch == nil) != (c.mismatch == nil) ||
+		(mismatch != nil && mismatch.Error() != c.mismatch.Error())
+	c.mismatch = mismatch
+	c.lock.Unlock()
+
+	if changed {
+		if mismatch != nil {
+			klog.Warningf("Node %s: %v, the node name is kept as the OVS hostname", c.nodeName, mismatch)
+			c.recorder.Eventf(c.nodeRef, kapi.EventTypeWarning, hostnameMismatchEventReason,
+				"%v, the node name is kept as the OVS hostname", mismatch)
+		} else {
+			klog.Infof("Kernel hostname %s matches node name %s again", hostname, c.nodeName)
+		}
+	}
+	if config.OvnKubeNode.HostnameMismatchFail {
+		return mismatch
+	}
+	return nil
+}
+
+// syncOVSHostname sets the OVS external_ids:hostname back to the node name when it differs
+func (c *hostnameChecker) syncOVSHostname() error {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "external_ids:hostname")
+	if err != nil {
+		return fmt.Errorf("failed to get the OVS hostname, stderr: %q, error: %v", stderr, err)
+	}
+	hostname := strings.Trim(stdout, `"`)
+	if hostname == c.nodeName {
+		return nil
+	}
+	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".", fmt.Sprintf("external_ids:hostname=\"%s\"", c.nodeName))
+	if err != nil {
+		return fmt.Errorf("failed to set the OVS hostname, stderr: %q, error: %v", stderr, err)
+	}
+	klog.Warningf("OVS hostname %q of node %s drifted from the node name, it was set back", hostname, c.nodeName)
+	c.recorder.Eventf(c.nodeRef, kapi.EventTypeWarning, hostnameMismatchEventReason,
+		"OVS hostname %q drifted from the node name, it was set back", hostname)
+	return nil
+}
+
+// healthy returns the kernel hostname mismatch when the hostname-mismatch-fail option is set
+func (c *hostnameChecker) healthy() error {
+	if !config.OvnKubeNode.HostnameMismatchFail {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.mismatch
+}
+
+// hostnameMatchesNodeName returns whether the hostname is the node name, ignoring the case and the domain
+// of either, as the kubelet may register the node with the short or the fully qualified hostname
+func hostnameMatchesNodeName(hostname, nodeName string) bool {
+	shortName := func(name string) string {
+		return strings.SplitN(strings.ToLower(name), ".", 2)[0]
+	}
+	return strings.EqualFold(hostname, nodeName) || shortName(hostname) == shortName(nodeName)
+}
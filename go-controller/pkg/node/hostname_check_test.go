@@ -0,0 +1,88 @@
+package node
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node hostname checker", func() {
+	const (
+		nodeName       = "node1"
+		getOVSHostname = "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:hostname"
+	)
+
+	var (
+		fakeExec *ovntest.FakeExec
+		recorder *record.FakeRecorder
+		checker  *hostnameChecker
+		hostname string
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fakeExec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fakeExec)).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+		checker = newHostnameChecker(nodeName, recorder)
+		hostname = nodeName
+		getKernelHostname = func() (string, error) { return hostname, nil }
+	})
+
+	AfterEach(func() {
+		getKernelHostname = os.Hostname
+		Expect(fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeExec.ErrorDesc())
+	})
+
+	table.DescribeTable("matches the hostname with the node name", func(hostname, nodeName string, matches bool) {
+		Expect(hostnameMatchesNodeName(hostname, nodeName)).To(Equal(matches))
+	},
+		table.Entry("same name", "node1", "node1", true),
+		table.Entry("different case", "Node1", "node1", true),
+		table.Entry("fully qualified hostname", "node1.example.com", "node1", true),
+		table.Entry("fully qualified node name", "node1", "node1.example.com", true),
+		table.Entry("different name", "node2", "node1", false),
+		table.Entry("different short name", "node2.example.com", "node1.example.com", false),
+	)
+
+	It("sets the OVS hostname back to the node name", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getOVSHostname, Output: `"node1.example.com"`})
+		fakeExec.AddFakeCmdsNoOutputNoError([]string{
+			`ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:hostname="node1"`,
+		})
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: getOVSHostname, Output: `"node1"`})
+		Expect(checker.sync()).To(Succeed())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring(hostnameMismatchEventReason))
+		Expect(checker.sync()).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("reports the kernel hostname mismatch once", func() {
+		hostname = "node2"
+		Expect(checker.checkKernelHostname()).To(Succeed())
+		Expect(checker.checkKernelHostname()).To(Succeed())
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(ContainSubstring("kernel hostname node2 does not match node name node1"))
+		Expect(checker.healthy()).To(Succeed())
+	})
+
+	It("fails on a kernel hostname mismatch when configured", func() {
+		config.OvnKubeNode.HostnameMismatchFail = true
+		hostname = "node2"
+		Expect(checker.checkKernelHostname()).NotTo(Succeed())
+		Expect(checker.healthy()).NotTo(Succeed())
+
+		hostname = "node1"
+		Expect(checker.checkKernelHostname()).To(Succeed())
+		Expect(checker.healthy()).To(Succeed())
+	})
+})
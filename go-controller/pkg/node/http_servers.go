@@ -0,0 +1,182 @@
+package node
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+const (
+	// healthzListener serves the node proxy health on /healthz, /livez and /readyz
+	healthzListener = "healthz"
+	// adminListener serves the node admin APIs
+	adminListener = "admin"
+)
+
+// httpServerManager runs the HTTP listeners of the node. The listeners bound to the same address share a
+// server and are routed by path, the servers serving TLS share the node server certificate, which is
+// reloaded on every handshake to pick up its rotations.
+type httpServerManager struct {
+	certFile string
+	keyFile  string
+	nodeRef  *kapi.ObjectReference
+	recorder record.EventRecorder
+
+	// servers are the servers by address
+	servers map[string]*httpServer
+	// listeners are the servers of the listeners by listener name
+	listeners map[string]*httpServer
+}
+
+// httpServer is a server of the listeners bound to an address
+type httpServer struct {
+	address string
+	tls     bool
+	mux     *http.ServeMux
+}
+
+func newHTTPServerManager(nodeName, certFile, keyFile string, recorder record.EventRecorder) *httpServerManager {
+	return &httpServerManager{
+		certFile: certFile,
+		keyFile:  keyFile,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		recorder:  recorder,
+		servers:   map[string]*httpServer{},
+		listeners: map[string]*httpServer{},
+	}
+}
+
+// addListener adds a listener bound to the address, a listener without an address is disabled. The
+// listeners bound to the same address must agree on serving TLS.
+func (m *httpServerManager) addListener(name, address string, serveTLS bool) error {
+	if address == "" {
+		return nil
+	}
+	if _, ok := m.listeners[name]; ok {
+		return fmt.Errorf("HTTP listener %s is already added", name)
+	}
+	if serveTLS && (m.certFile == "" || m.keyFile == "") {
+		return fmt.Errorf("HTTP listener %s serves TLS without a node server certificate", name)
+	}
+	server, ok := m.servers[address]
+	if !ok {
+		server = &httpServer{address: address, tls: serveTLS, mux: http.NewServeMux()}
+		m.servers[address] = server
+	} else if server.tls != serveTLS {
+		return fmt.Errorf("HTTP listener %s and the other listeners on %s don't agree on serving TLS", name, address)
+	}
+	m.listeners[name] = server
+	return nil
+}
+
+// enabled returns whether the listener is added
+func (m *httpServerManager) enabled(name string) bool {
+	_, ok := m.listeners[name]
+	return ok
+}
+
+// handle routes the path of the listener to the handler, it is ignored when the listener is disabled. The
+// requests served are counted by listener, path and status code.
+func (m *httpServerManager) handle(name, path string, handler http.Handler) {
+	server, ok := m.listeners[name]
+	if !ok {
+		return
+	}
+	counter := metrics.MetricNodeHTTPRequests.MustCurryWith(prometheus.Labels{"listener": name, "path": path})
+	server.mux.Handle(path, promhttp.InstrumentHandlerCounter(counter, handler))
+}
+
+// handleFunc routes the path of the listener to the handler function
+func (m *httpServerManager) handleFunc(name, path string, handler func(http.ResponseWriter, *http.Request)) {
+	m.handle(name, path, http.HandlerFunc(handler))
+}
+
+// Start runs the servers until the stop channel is closed, a server failing to serve is restarted
+func (m *httpServerManager) Start(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	addresses := make([]string, 0, len(m.servers))
+	for address := range m.servers {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	for _, address := range addresses {
+		m.run(m.servers[address], stopChan, wg)
+	}
+}
+
+func (m *httpServerManager) run(s *httpServer, stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	server := &http.Server{
+		Addr:    s.address,
+		Handler: s.mux,
+	}
+	listenAndServe := server.ListenAndServe
+	if s.tls {
+		server.TLSConfig = &tls.Config{
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				cert, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load the node server certificate: %w", err)
+				}
+				return &cert, nil
+			},
+		}
+		listenAndServe = func() error { return server.ListenAndServeTLS("", "") }
+	}
+
+	startedWg := &sync.WaitGroup{}
+
+	wg.Add(1)
+	startedWg.Add(1)
+	go func() {
+		defer wg.Done()
+		startedWg.Done()
+		<-stopChan
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("Error stopping the node HTTP server on %s: %v", s.address, err)
+		}
+	}()
+
+	wg.Add(1)
+	startedWg.Add(1)
+	go func() {
+		defer wg.Done()
+		startedWg.Done()
+
+		healthLog.V(3).InfoS("Starting node HTTP server", "address", s.address, "tls", s.tls)
+		for {
+			err := listenAndServe()
+			if errors.Is(err, http.ErrServerClosed) {
+				return
+			}
+			msg := fmt.Sprintf("serving HTTP on %s failed: %v", s.address, err)
+			m.recorder.Eventf(m.nodeRef, kapi.EventTypeWarning, "FailedToStartNodeHTTPServer", "StartOVNKubernetesNode", msg)
+			klog.Errorf(msg)
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+
+	startedWg.Wait()
+}
@@ -0,0 +1,70 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+var _ = Describe("Node HTTP servers", func() {
+	const address = "127.0.0.1:10256"
+
+	var servers *httpServerManager
+
+	BeforeEach(func() {
+		servers = newHTTPServerManager("node1", "", "", record.NewFakeRecorder(10))
+	})
+
+	requestCount := func(listener, path, code string) float64 {
+		m := &dto.Metric{}
+		Expect(metrics.MetricNodeHTTPRequests.WithLabelValues(listener, path, code).Write(m)).To(Succeed())
+		return m.GetCounter().GetValue()
+	}
+
+	It("routes the listeners bound to the same address by path", func() {
+		Expect(servers.addListener(healthzListener, address, false)).To(Succeed())
+		Expect(servers.addListener(adminListener, address, false)).To(Succeed())
+		Expect(servers.servers).To(HaveLen(1))
+		servers.handleFunc(healthzListener, "/healthz", func(resp http.ResponseWriter, _ *http.Request) {
+			resp.WriteHeader(http.StatusOK)
+		})
+		servers.handleFunc(adminListener, "/subsystems", func(resp http.ResponseWriter, _ *http.Request) {
+			resp.WriteHeader(http.StatusServiceUnavailable)
+		})
+		healthz := requestCount(healthzListener, "/healthz", "200")
+		subsystems := requestCount(adminListener, "/subsystems", "503")
+
+		mux := servers.servers[address].mux
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		resp = httptest.NewRecorder()
+		mux.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/subsystems", nil))
+		Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+
+		Expect(requestCount(healthzListener, "/healthz", "200")).To(Equal(healthz + 1))
+		Expect(requestCount(adminListener, "/subsystems", "503")).To(Equal(subsystems + 1))
+	})
+
+	It("ignores the disabled listeners", func() {
+		Expect(servers.addListener(adminListener, "", true)).To(Succeed())
+		Expect(servers.enabled(adminListener)).To(BeFalse())
+		servers.handle(adminListener, "/subsystems", http.NotFoundHandler())
+		Expect(servers.servers).To(BeEmpty())
+	})
+
+	It("rejects the listeners that can't serve TLS", func() {
+		Expect(servers.addListener(adminListener, address, true)).NotTo(Succeed())
+
+		servers = newHTTPServerManager("node1", "/etc/ovn/node.crt", "/etc/ovn/node.key", record.NewFakeRecorder(10))
+		Expect(servers.addListener(healthzListener, address, false)).To(Succeed())
+		Expect(servers.addListener(adminListener, address, true)).To(MatchError(ContainSubstring("don't agree on serving TLS")))
+		Expect(servers.addListener(adminListener, "127.0.0.1:10257", true)).To(Succeed())
+	})
+})
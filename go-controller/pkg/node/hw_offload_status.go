@@ -0,0 +1,192 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// hwOffloadStatusInterval is how often the hardware offload status of the node is checked
+const hwOffloadStatusInterval = time.Minute
+
+// datapathFlowInPort matches the input port of a datapath flow dumped with the port names
+var datapathFlowInPort = regexp.MustCompile(`in_port\(([^)]+)\)`)
+
+// hwOffloadStatusReporter periodically detects whether OVS hardware offload offloads the datapath flows
+// of the representor ports of the pods. It only runs on the nodes with OVS hardware offload enabled. The
+// datapath flows of each representor are counted in metrics, only the summary of the node is published
+// in the node hardware offload status annotation.
+type hwOffloadStatusReporter struct {
+	nodeName      string
+	watchFactory  factory.NodeWatchFactory
+	nodeAnnotator kube.Annotator
+	// representors are the representors reported in metrics, so that the metrics of removed
+	// representors are deleted
+	representors sets.Set[string]
+}
+
+func newHwOffloadStatusReporter(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory) *hwOffloadStatusReporter {
+	return &hwOffloadStatusReporter{
+		nodeName:      nodeName,
+		watchFactory:  watchFactory,
+		nodeAnnotator: newNodeAnnotator(k, nodeName),
+		representors:  sets.New[string](),
+	}
+}
+
+func (r *hwOffloadStatusReporter) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, hwOffloadStatusInterval, nil, func() {
+		if err := r.sync(); err != nil {
+			klog.Errorf("Failed to report the hardware offload status of node %s: %v", r.nodeName, err)
+		}
+	})
+}
+
+// sync detects the hardware offload status and reports it
+func (r *hwOffloadStatusReporter) sync() error {
+	status, flows, err := detectHwOffloadStatus()
+	if err != nil {
+		return err
+	}
+	r.updateMetrics(status, flows)
+
+	node, err := r.watchFactory.GetNode(r.nodeName)
+	if err != nil {
+		return err
+	}
+	current, err := util.ParseNodeHwOffloadStatus(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid hardware offload status of node %s: %v", r.nodeName, err)
+	}
+	if reflect.DeepEqual(current, status) {
+		return nil
+	}
+	if status.Enabled && !status.Functioning && (current == nil || current.Functioning) {
+		klog.Warningf("OVS hardware offload is enabled on node %s but does not offload the datapath flows of the representors", r.nodeName)
+	}
+	if err := util.SetNodeHwOffloadStatus(r.nodeAnnotator, status); err != nil {
+		return err
+	}
+	return r.nodeAnnotator.Run()
+}
+
+func (r *hwOffloadStatusReporter) updateMetrics(status *util.HwOffloadStatus, flows map[string]*representorFlows) {
+	functioning := 0.0
+	if status.Functioning {
+		functioning = 1
+	}
+	metrics.MetricNodeHwOffloadFunctioning.Set(functioning)
+
+	for representor := range r.representors {
+		if _, ok := flows[representor]; !ok {
+			metrics.MetricNodeHwOffloadDatapathFlows.DeleteLabelValues(representor, "true")
+			metrics.MetricNodeHwOffloadDatapathFlows.DeleteLabelValues(representor, "false")
+			r.representors.Delete(representor)
+		}
+	}
+	for representor, representorFlows := range flows {
+		metrics.MetricNodeHwOffloadDatapathFlows.WithLabelValues(representor, "true").Set(float64(representorFlows.offloaded))
+		metrics.MetricNodeHwOffloadDatapathFlows.WithLabelValues(representor, "false").Set(float64(representorFlows.nonOffloaded))
+		r.representors.Insert(representor)
+	}
+}
+
+// representorFlows are the datapath flows received on a representor port
+type representorFlows struct {
+	offloaded    int
+	nonOffloaded int
+}
+
+// isHwOffloadEnabled returns whether other_config:hw-offload is set to true in OVS, the hardware offload
+// status reporter is not started otherwise
+func isHwOffloadEnabled() bool {
+	hwOffload, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:hw-offload")
+	if err != nil {
+		klog.Errorf("Failed to get the OVS hw-offload configuration, stderr: %q, error: %v", stderr, err)
+		return false
+	}
+	return strings.Trim(hwOffload, "\"") == "true"
+}
+
+// detectHwOffloadStatus returns the hardware offload status of the node and the datapath flows of its
+// representors, hardware offload must be enabled
+func detectHwOffloadStatus() (*util.HwOffloadStatus, map[string]*representorFlows, error) {
+	status := &util.HwOffloadStatus{Enabled: true}
+	tcPolicy, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "other_config:tc-policy")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the OVS tc-policy, stderr: %q, error: %v", stderr, err)
+	}
+	status.TCPolicy = strings.Trim(tcPolicy, "\"")
+
+	representors, err := listRepresentors()
+	if err != nil {
+		return nil, nil, err
+	}
+	flows, err := dumpRepresentorFlows(representors)
+	if err != nil {
+		return nil, nil, err
+	}
+	status.Functioning = true
+	for _, representorFlows := range flows {
+		if representorFlows.offloaded == 0 && representorFlows.nonOffloaded > 0 {
+			status.Functioning = false
+		}
+	}
+	return status, flows, nil
+}
+
+// listRepresentors returns the names of the OVS interfaces of the pods backed by a representor
+func listRepresentors() (sets.Set[string], error) {
+	stdout, stderr, err := util.RunOVSVsctl("--no-heading", "--data=bare", "--format=csv", "--columns=name",
+		"find", "Interface", `external_ids:sandbox!=""`, `external_ids:vf-netdev-name!=""`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the representor interfaces, stderr: %q, error: %v", stderr, err)
+	}
+	representors := sets.New[string]()
+	if stdout == "" {
+		return representors, nil
+	}
+	representors.Insert(strings.Split(stdout, "\n")...)
+	return representors, nil
+}
+
+// dumpRepresentorFlows counts the offloaded and not offloaded datapath flows received on each of the
+// given representors
+func dumpRepresentorFlows(representors sets.Set[string]) (map[string]*representorFlows, error) {
+	stdout, stderr, err := util.RunOVSAppctl("dpctl/dump-flows", "--names", "-m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump the datapath flows, stderr: %q, error: %v", stderr, err)
+	}
+	flows := map[string]*representorFlows{}
+	for name := range representors {
+		flows[name] = &representorFlows{}
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		match := datapathFlowInPort.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		representorFlows, ok := flows[match[1]]
+		if !ok {
+			continue
+		}
+		// partially offloaded flows are not counted as offloaded, their actions run in software
+		if strings.Contains(line, " offloaded:yes,") {
+			representorFlows.offloaded++
+		} else {
+			representorFlows.nonOffloaded++
+		}
+	}
+	return flows, nil
+}
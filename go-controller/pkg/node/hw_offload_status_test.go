@@ -0,0 +1,71 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Hardware offload status reporter", func() {
+	var execMock *ovntest.FakeExec
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		execMock = ovntest.NewFakeExec()
+		Expect(util.SetExec(execMock)).To(Succeed())
+	})
+
+	It("reports the offload state of every representor", func() {
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:tc-policy",
+			Output: "skip_sw",
+		})
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    `ovs-vsctl --timeout=15 --no-heading --data=bare --format=csv --columns=name find Interface external_ids:sandbox!="" external_ids:vf-netdev-name!=""`,
+			Output: "eth0_3\neth0_4\neth0_5",
+		})
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-appctl --timeout=15 dpctl/dump-flows --names -m",
+			Output: "ufid:1, recirc_id(0),in_port(eth0_3),eth_type(0x0800),ipv4(frag=no), packets:10, bytes:980, used:0.3s, offloaded:yes, dp:tc, actions:genev_sys_6081\n" +
+				"ufid:2, recirc_id(0),in_port(eth0_3),eth_type(0x0806), packets:1, bytes:42, used:1.2s, dp:ovs, actions:genev_sys_6081\n" +
+				"ufid:3, recirc_id(0),in_port(eth0_4),eth_type(0x0800),ipv4(frag=no), packets:3, bytes:294, used:0.8s, offloaded:partial, dp:ovs, actions:genev_sys_6081\n" +
+				"ufid:4, recirc_id(0),in_port(genev_sys_6081),eth_type(0x0800), packets:10, bytes:980, used:0.3s, offloaded:yes, dp:tc, actions:eth0_3",
+		})
+
+		status, flows, err := detectHwOffloadStatus()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+		Expect(status).To(Equal(&util.HwOffloadStatus{
+			Enabled:     true,
+			Functioning: false,
+			TCPolicy:    "skip_sw",
+		}))
+		Expect(flows).To(Equal(map[string]*representorFlows{
+			"eth0_3": {offloaded: 1, nonOffloaded: 1},
+			"eth0_4": {nonOffloaded: 1},
+			"eth0_5": {},
+		}))
+
+		r := &hwOffloadStatusReporter{nodeName: "node1", representors: sets.New[string]()}
+		r.updateMetrics(status, flows)
+		Expect(r.representors).To(Equal(sets.New("eth0_3", "eth0_4", "eth0_5")))
+		r.updateMetrics(&util.HwOffloadStatus{Enabled: true, Functioning: true}, map[string]*representorFlows{"eth0_3": {offloaded: 2}})
+		Expect(r.representors).To(Equal(sets.New("eth0_3")))
+	})
+
+	It("only runs when hardware offload is enabled in OVS", func() {
+		for output, enabled := range map[string]bool{"\"true\"": true, "\"false\"": false, "": false} {
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . other_config:hw-offload",
+				Output: output,
+			})
+			Expect(isHwOffloadEnabled()).To(Equal(enabled), output)
+		}
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+	})
+})
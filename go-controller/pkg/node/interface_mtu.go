@@ -0,0 +1,191 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// interfaceMTUInterval is how often the MTU of the interfaces with an MTU override is reconciled
+const interfaceMTUInterval = 30 * time.Second
+
+// mgmtPortMTU returns the MTU of the management port
+func mgmtPortMTU() int {
+	if config.Default.MgmtPortMTU != 0 {
+		return config.Default.MgmtPortMTU
+	}
+	return config.Default.MTU
+}
+
+// overlayMTU returns the largest MTU of the traffic sent over the overlay, the tunnel interfaces
+// must carry it along with the Geneve header
+func overlayMTU() int {
+	return max(config.Default.MTU, config.Default.MgmtPortMTU, config.Default.GatewayPatchMTU)
+}
+
+// requiredVTEPInterfaceMTU returns the MTU the interface of the tunnel endpoint encapIP needs to carry the
+// traffic of the given MTU along with the Geneve header
+func requiredVTEPInterfaceMTU(mtu int, encapIP net.IP, perFamilyEndpoints bool) int {
+	if config.Gateway.SingleNode {
+		return mtu
+	}
+	if perFamilyEndpoints {
+		// with a tunnel endpoint per IP family, each interface only carries the tunnels of its family
+		if utilnet.IsIPv6(encapIP) {
+			return mtu + types.GeneveHeaderLengthIPv6
+		}
+		return mtu + types.GeneveHeaderLengthIPv4
+	}
+	if config.IPv4Mode && !config.IPv6Mode {
+		// we run in single-stack IPv4 only
+		return mtu + types.GeneveHeaderLengthIPv4
+	}
+	// we run in single-stack IPv6 or dual-stack mode
+	return mtu + types.GeneveHeaderLengthIPv6
+}
+
+// validateGatewayBridgeMTU checks that the uplink of the gateway bridge can carry the overridden MTU of the
+// gateway bridge
+func validateGatewayBridgeMTU(uplinkName string) error {
+	if config.Default.GatewayBridgeMTU == 0 || uplinkName == "" {
+		return nil
+	}
+	uplinkMTU, err := getLinkMTU(uplinkName)
+	if err != nil {
+		return err
+	}
+	if uplinkMTU < config.Default.GatewayBridgeMTU {
+		return fmt.Errorf("MTU (%d) of the gateway bridge uplink %s is too small for the gateway bridge MTU (%d)",
+			uplinkMTU, uplinkName, config.Default.GatewayBridgeMTU)
+	}
+	return nil
+}
+
+// linkMTU reads and sets the MTU of an interface
+type linkMTU interface {
+	getMTU(ifName string) (int, error)
+	setMTU(ifName string, mtu int) error
+}
+
+// netdevMTU sets the MTU of a netdev directly, e.g. of the management port netdev of a VF
+type netdevMTU struct{}
+
+func (netdevMTU) getMTU(ifName string) (int, error) {
+	return getLinkMTU(ifName)
+}
+
+func (netdevMTU) setMTU(ifName string, mtu int) error {
+	link, err := util.GetNetLinkOps().LinkByName(ifName)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", ifName, err)
+	}
+	if err := util.GetNetLinkOps().LinkSetMTU(link, mtu); err != nil {
+		return fmt.Errorf("failed to set the MTU of %s to %d: %w", ifName, mtu, err)
+	}
+	return nil
+}
+
+// ovsInterfaceMTU requests the MTU of an OVS interface, OVS keeps requesting it when the interface
+// is recreated
+type ovsInterfaceMTU struct{}
+
+func (ovsInterfaceMTU) getMTU(ifName string) (int, error) {
+	return getLinkMTU(ifName)
+}
+
+func (ovsInterfaceMTU) setMTU(ifName string, mtu int) error {
+	_, stderr, err := util.RunOVSVsctl("set", "interface", ifName, fmt.Sprintf("mtu_request=%d", mtu))
+	if err != nil {
+		return fmt.Errorf("failed to set the MTU of %s to %d, stderr: %q, error: %v", ifName, mtu, stderr, err)
+	}
+	current, err := getLinkMTU(ifName)
+	if err != nil {
+		return err
+	}
+	if current != mtu {
+		// OVS only applies a changed MTU request, set the MTU changed behind its back directly
+		return netdevMTU{}.setMTU(ifName, mtu)
+	}
+	return nil
+}
+
+// interfaceMTU is the MTU an interface must have
+type interfaceMTU struct {
+	mtu  int
+	link linkMTU
+}
+
+// interfaceMTUReconciler restores the overridden MTU of the management port and of the gateway bridge
+// when something else changes it
+type interfaceMTUReconciler struct {
+	// mtus are the MTUs of the interfaces, by interface name
+	mtus map[string]interfaceMTU
+}
+
+func newInterfaceMTUReconciler(mgmtPortName, bridgeName string) *interfaceMTUReconciler {
+	mtus := map[string]interfaceMTU{}
+	if config.Default.MgmtPortMTU != 0 && mgmtPortName != "" {
+		if config.OvnKubeNode.MgmtPortNetdev == "" {
+			mtus[mgmtPortName] = interfaceMTU{mtu: config.Default.MgmtPortMTU, link: ovsInterfaceMTU{}}
+		} else {
+			// the management port is a netdev, its representor is the OVS interface
+			mtus[mgmtPortName] = interfaceMTU{mtu: config.Default.MgmtPortMTU, link: netdevMTU{}}
+			mtus[mgmtPortName+"_0"] = interfaceMTU{mtu: config.Default.MgmtPortMTU, link: ovsInterfaceMTU{}}
+		}
+	}
+	if config.Default.GatewayBridgeMTU != 0 && bridgeName != "" {
+		mtus[bridgeName] = interfaceMTU{mtu: config.Default.GatewayBridgeMTU, link: ovsInterfaceMTU{}}
+	}
+	return &interfaceMTUReconciler{mtus: mtus}
+}
+
+func (r *interfaceMTUReconciler) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, interfaceMTUInterval, nil, func() {
+		if err := r.sync(); err != nil {
+			klog.Errorf("Failed to reconcile the MTU of the node interfaces: %v", err)
+		}
+	})
+}
+
+func (r *interfaceMTUReconciler) sync() error {
+	ifNames := make([]string, 0, len(r.mtus))
+	for ifName := range r.mtus {
+		ifNames = append(ifNames, ifName)
+	}
+	sort.Strings(ifNames)
+	var errs []error
+	for _, ifName := range ifNames {
+		desired := r.mtus[ifName]
+		mtu, err := desired.link.getMTU(ifName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if mtu == desired.mtu {
+			continue
+		}
+		klog.Infof("Setting the MTU of %s back to %d from %d", ifName, desired.mtu, mtu)
+		if err := desired.link.setMTU(ifName, desired.mtu); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func getLinkMTU(ifName string) (int, error) {
+	link, err := util.GetNetLinkOps().LinkByName(ifName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get link %s: %w", ifName, err)
+	}
+	return link.Attrs().MTU, nil
+}
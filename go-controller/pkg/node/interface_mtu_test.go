@@ -0,0 +1,99 @@
+package node
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// fakeLinkMTU holds the MTU of the interfaces and records the MTUs set
+type fakeLinkMTU struct {
+	mtus map[string]int
+	set  []string
+}
+
+func (f *fakeLinkMTU) getMTU(ifName string) (int, error) {
+	mtu, ok := f.mtus[ifName]
+	if !ok {
+		return 0, fmt.Errorf("link %s not found", ifName)
+	}
+	return mtu, nil
+}
+
+func (f *fakeLinkMTU) setMTU(ifName string, mtu int) error {
+	f.set = append(f.set, fmt.Sprintf("%s=%d", ifName, mtu))
+	f.mtus[ifName] = mtu
+	return nil
+}
+
+var _ = Describe("Interface MTU reconciler", func() {
+	var (
+		r    *interfaceMTUReconciler
+		link *fakeLinkMTU
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Default.MTU = 1400
+		config.Default.GatewayBridgeMTU = 9000
+		link = &fakeLinkMTU{mtus: map[string]int{"ovn-k8s-mp0": 1400, "ovn-k8s-mp0_0": 1400, "breth0": 9000}}
+	})
+
+	JustBeforeEach(func() {
+		r = newInterfaceMTUReconciler("ovn-k8s-mp0", "breth0")
+		for ifName, desired := range r.mtus {
+			desired.link = link
+			r.mtus[ifName] = desired
+		}
+	})
+
+	It("only reconciles the interfaces with an MTU override", func() {
+		Expect(r.mtus).To(Equal(map[string]interfaceMTU{"breth0": {mtu: 9000, link: link}}))
+		Expect(mgmtPortMTU()).To(Equal(1400))
+		Expect(overlayMTU()).To(Equal(1400))
+	})
+
+	It("restores the overridden MTU of an interface changed by something else", func() {
+		Expect(r.sync()).To(Succeed())
+		Expect(link.set).To(BeEmpty())
+
+		link.mtus["breth0"] = 1500
+		Expect(r.sync()).To(Succeed())
+		Expect(link.set).To(Equal([]string{"breth0=9000"}))
+	})
+
+	It("uses the gateway patch MTU for the overlay", func() {
+		config.Default.GatewayPatchMTU = 1500
+		Expect(overlayMTU()).To(Equal(1500))
+	})
+
+	Context("with a management port MTU override", func() {
+		BeforeEach(func() {
+			config.Default.MgmtPortMTU = 1450
+		})
+
+		It("uses the management port MTU for the management port and the overlay", func() {
+			Expect(mgmtPortMTU()).To(Equal(1450))
+			Expect(overlayMTU()).To(Equal(1450))
+			Expect(r.sync()).To(Succeed())
+			Expect(link.set).To(Equal([]string{"ovn-k8s-mp0=1450"}))
+		})
+
+		It("requests the MTU of an OVS internal management port through OVS", func() {
+			Expect(newInterfaceMTUReconciler("ovn-k8s-mp0", "").mtus).To(Equal(map[string]interfaceMTU{
+				"ovn-k8s-mp0": {mtu: 1450, link: ovsInterfaceMTU{}},
+			}))
+		})
+
+		It("sets the MTU of a management port netdev directly and of its representor through OVS", func() {
+			config.OvnKubeNode.MgmtPortNetdev = "enp1s0f0v0"
+			Expect(newInterfaceMTUReconciler("ovn-k8s-mp0", "").mtus).To(Equal(map[string]interfaceMTU{
+				"ovn-k8s-mp0":   {mtu: 1450, link: netdevMTU{}},
+				"ovn-k8s-mp0_0": {mtu: 1450, link: ovsInterfaceMTU{}},
+			}))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 	"github.com/vishvananda/netlink"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
 )
 
@@ -111,7 +112,7 @@ func (rm *Controller) reconcile() error {
 		family = netlink.FAMILY_V6
 	}
 
-	rulesFound, err := netlink.RuleList(family)
+	rulesFound, err := util.GetNetLinkOps().RuleListFiltered(family, nil, 0)
 	if err != nil {
 		return err
 	}
@@ -121,7 +122,7 @@ func (rm *Controller) reconcile() error {
 		// delete IP rule by first checking if it exists and if so, delete it
 		if r.delete {
 			if found, foundRoute := isNetlinkRuleInSlice(rulesFound, r.rule); found {
-				if err = netlink.RuleDel(foundRoute); err != nil {
+				if err = util.GetNetLinkOps().RuleDel(foundRoute); err != nil {
 					// retry later
 					rulesToKeep = append(rulesToKeep, r)
 					errors = append(errors, err)
@@ -131,7 +132,7 @@ func (rm *Controller) reconcile() error {
 			// add IP rule by first checking if it exists and if not, add it
 			rulesToKeep = append(rulesToKeep, r)
 			if found, _ := isNetlinkRuleInSlice(rulesFound, r.rule); !found {
-				if err = netlink.RuleAdd(r.rule); err != nil {
+				if err = util.GetNetLinkOps().RuleAdd(r.rule); err != nil {
 					errors = append(errors, err)
 				}
 			}
@@ -156,7 +157,7 @@ func (rm *Controller) reconcile() error {
 			}
 			if !found {
 				klog.Infof("Rule manager: deleting stale IP rule (%s) found at priority %d", ruleFound.String(), priority)
-				if err = netlink.RuleDel(&ruleFound); err != nil {
+				if err = util.GetNetLinkOps().RuleDel(&ruleFound); err != nil {
 					errors = append(errors, fmt.Errorf("failed to delete stale IP rule (%s) found at priority %d: %v",
 						ruleFound.String(), priority, err))
 				}
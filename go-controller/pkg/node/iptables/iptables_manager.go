@@ -58,6 +58,16 @@ type Controller struct {
 	store map[rulesIndex]rules
 	iptV4 iptables.Interface
 	iptV6 iptables.Interface
+	// paused is set while iptables is not modified, the rules ensured meanwhile are only stored and the rules
+	// deleted meanwhile are kept in pendingDels until resumed
+	paused      bool
+	pendingDels []pendingDel
+}
+
+// pendingDel is a rule deleted while the controller is paused
+type pendingDel struct {
+	index   rulesIndex
+	ruleArg RuleArg
 }
 
 // NewController creates a controller to manage chains and rules. Provides functionality to "own" a chain which
@@ -110,11 +120,56 @@ func (c *Controller) OwnChain(table iptables.Table, chain iptables.Chain, proto
 	return c.reconcile()
 }
 
+// Pause stops modifying iptables until Resume is called, the rules ensured and deleted meanwhile are applied
+// once resumed
+func (c *Controller) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.paused {
+		klog.Info("IPTables manager: paused")
+	}
+	c.paused = true
+}
+
+// Resume applies the rules ensured and deleted while paused and reconciles iptables
+func (c *Controller) Resume() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.paused {
+		return nil
+	}
+	klog.Info("IPTables manager: resuming")
+	c.paused = false
+	pendingDels := c.pendingDels
+	c.pendingDels = nil
+	for _, del := range pendingDels {
+		if err := c.deleteRule(del.index.Table, del.index.Chain, del.index.Proto, del.ruleArg); err != nil {
+			klog.Errorf("IPTables manager: %v", err)
+		}
+	}
+	return c.reconcile()
+}
+
 // DeleteRule deletes an iptable rule
 func (c *Controller) DeleteRule(table iptables.Table, chain iptables.Chain, proto iptables.Protocol, ruleArg RuleArg) error {
 	klog.Infof("IPTables manager: delete rule - table %s, chain %s, protocol %s, rule %v", table, chain, proto, ruleArg)
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.paused {
+		ruleIndex := rulesIndex{
+			Table: table,
+			Chain: chain,
+			Proto: proto,
+		}
+		c.removeRuleFromStore(ruleIndex, ruleArg)
+		c.pendingDels = append(c.pendingDels, pendingDel{index: ruleIndex, ruleArg: ruleArg})
+		return nil
+	}
+	return c.deleteRule(table, chain, proto, ruleArg)
+}
+
+// deleteRule deletes an iptable rule, callers must hold the lock for mutex mu
+func (c *Controller) deleteRule(table iptables.Table, chain iptables.Chain, proto iptables.Protocol, ruleArg RuleArg) error {
 	var err error
 	if proto == iptables.ProtocolIPv4 {
 		err = execIPTablesWithRetry(func() error {
@@ -139,14 +194,19 @@ func (c *Controller) DeleteRule(table iptables.Table, chain iptables.Chain, prot
 			return fmt.Errorf("failed to IPv6 delete rule %v on table %s and chain %s: %v", ruleArg.Args, table, chain, err)
 		}
 	}
-	ruleIndex := rulesIndex{
+	c.removeRuleFromStore(rulesIndex{
 		Table: table,
 		Chain: chain,
 		Proto: proto,
-	}
+	}, ruleArg)
+	return nil
+}
+
+// removeRuleFromStore stops managing the rule
+func (c *Controller) removeRuleFromStore(ruleIndex rulesIndex, ruleArg RuleArg) {
 	savedRules, alreadyExists := c.store[ruleIndex]
 	if !alreadyExists {
-		return nil
+		return
 	}
 	tempRules := newRules()
 	tempRules.exclusive = savedRules.exclusive
@@ -156,7 +216,6 @@ func (c *Controller) DeleteRule(table iptables.Table, chain iptables.Chain, prot
 		}
 	}
 	c.store[ruleIndex] = tempRules
-	return nil
 }
 
 // EnsureRule adds an iptable rule that will persist until deleted
@@ -187,6 +246,14 @@ func (c *Controller) EnsureRule(table iptables.Table, chain iptables.Chain, prot
 		}
 		c.store[ruleIndex] = existingRuleArgs
 	}
+	// a rule ensured again while paused must not be deleted on resume
+	pendingDels := c.pendingDels[:0]
+	for _, del := range c.pendingDels {
+		if del.index != ruleIndex || !del.ruleArg.equal(ruleArg) {
+			pendingDels = append(pendingDels, del)
+		}
+	}
+	c.pendingDels = pendingDels
 	return c.reconcile()
 }
 
@@ -291,8 +358,11 @@ func processRules(ipt iptables.Interface, wantedChainRules map[rulesIndex]rules,
 
 // reconcile configures IPTables to ensure the correct chains and rules.
 // CPU starvation or iptables lock held by an external entity may cause this function to take some time to execute.
-// callers must hold the lock for mutex mu.
+// callers must hold the lock for mutex mu. iptables is not modified while paused.
 func (c *Controller) reconcile() error {
+	if c.paused {
+		return nil
+	}
 	start := time.Now()
 	defer func() {
 		klog.V(5).Infof("Reconciling IPTables rules took %v", time.Since(start))
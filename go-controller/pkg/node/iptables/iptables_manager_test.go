@@ -143,6 +143,29 @@ var _ = ginkgo.Describe("IPTables Manager", func() {
 				return containsRuleArgs(testNS, c.iptV4, utiliptables.TableNAT, testChainName, testRuleArgs)
 			}).WithTimeout(oneSecTimeout).Should(gomega.BeTrue())
 		})
+		ginkgo.It("doesn't modify the rules while paused", func() {
+			gomega.Expect(testNS.Do(func(netNS ns.NetNS) error {
+				return c.EnsureRule(utiliptables.TableNAT, testChainName, utiliptables.ProtocolIPv4, testRuleArgs[0])
+			})).Should(gomega.Succeed())
+			c.Pause()
+			gomega.Expect(testNS.Do(func(netNS ns.NetNS) error {
+				if err := c.iptV4.DeleteRule(utiliptables.TableNAT, testChainName, testRuleArgs[0].Args...); err != nil {
+					return err
+				}
+				return c.EnsureRule(utiliptables.TableNAT, testChainName, utiliptables.ProtocolIPv4, testRuleArgs[1])
+			})).Should(gomega.Succeed())
+			gomega.Consistently(func() bool {
+				return containsRuleArg(testNS, c.iptV4, utiliptables.TableNAT, testChainName, testRuleArgs[0]) ||
+					containsRuleArg(testNS, c.iptV4, utiliptables.TableNAT, testChainName, testRuleArgs[1])
+			}).WithTimeout(200 * time.Millisecond).Should(gomega.BeFalse())
+			// the rules are applied once resumed
+			gomega.Expect(testNS.Do(func(netNS ns.NetNS) error {
+				return c.Resume()
+			})).Should(gomega.Succeed())
+			gomega.Eventually(func() bool {
+				return containsRuleArgs(testNS, c.iptV4, utiliptables.TableNAT, testChainName, testRuleArgs)
+			}).WithTimeout(oneSecTimeout).Should(gomega.BeTrue())
+		})
 		ginkgo.It("doesn't remove unknown rules in un-owned chain", func() {
 			gomega.Eventually(testNS.Do(func(netNS ns.NetNS) error {
 				if _, err := c.iptV4.EnsureChain(utiliptables.TableNAT, testChainName); err != nil {
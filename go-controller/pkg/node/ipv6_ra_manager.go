@@ -0,0 +1,306 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdlayher/ndp"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/net/ipv6"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// ipv6RASyncPeriod is how often the routers learned from router advertisements are checked
+const ipv6RASyncPeriod = 5 * time.Second
+
+// ipv6RAManager applies config.Gateway.IPv6RAPolicy, config.Gateway.IPv6AddrGenMode and
+// config.Gateway.IPv6Token to the gateway bridge. With the managed
+// policy the kernel keeps processing router advertisements for address autoconfiguration, but
+// does not install default routes from them. Instead, the router advertisements received on the
+// bridge are tracked for as long as their router lifetime, and a default route through one of the
+// reachable routers is installed with the route manager, so that it does not race with the routes
+// ovnkube manages.
+type ipv6RAManager struct {
+	bridgeName   string
+	routeManager *routemanager.Controller
+	// defaultRoute is the default route currently installed through the route manager, if any
+	defaultRoute *netlink.Route
+
+	routersLock sync.Mutex
+	// routers are the link local addresses of the routers that advertised themselves as default
+	// routers on the bridge, with the expiration of their router lifetime
+	routers map[string]time.Time
+}
+
+func newIPv6RAManager(bridgeName string, routeManager *routemanager.Controller) *ipv6RAManager {
+	return &ipv6RAManager{
+		bridgeName:   bridgeName,
+		routeManager: routeManager,
+		routers:      map[string]time.Time{},
+	}
+}
+
+// Run configures the address generation and router advertisement sysctls of the bridge and, with
+// the managed policy, keeps the default route in sync with the advertising routers until stopChan
+// is closed
+func (m *ipv6RAManager) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	if err := configureIPv6AddrGen(m.bridgeName, config.Gateway.IPv6AddrGenMode, config.Gateway.IPv6Token); err != nil {
+		klog.Errorf("Failed to configure IPv6 address generation on %s: %v", m.bridgeName, err)
+	}
+	if err := configureIPv6RASysctls(m.bridgeName, config.Gateway.IPv6RAPolicy); err != nil {
+		klog.Errorf("Failed to configure IPv6 router advertisements on %s: %v", m.bridgeName, err)
+	}
+	if config.Gateway.IPv6RAPolicy != config.IPv6RAPolicyManaged {
+		return
+	}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			if err := m.receiveRouterAdvertisements(stopChan); err != nil {
+				klog.Errorf("Failed to receive the IPv6 router advertisements on %s: %v", m.bridgeName, err)
+			}
+		}, ipv6RASyncPeriod, stopChan)
+	}()
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			if err := m.syncDefaultRoute(); err != nil {
+				klog.Errorf("Failed to sync the IPv6 default route learned from router advertisements on %s: %v",
+					m.bridgeName, err)
+			}
+		}, ipv6RASyncPeriod, stopChan)
+	}()
+}
+
+// ipv6RASysctls returns the router advertisement sysctl values of the policy
+func ipv6RASysctls(policy config.IPv6RAPolicy) map[string]string {
+	switch policy {
+	case config.IPv6RAPolicyDisabled:
+		return map[string]string{"accept_ra": "0"}
+	case config.IPv6RAPolicyManaged:
+		// forwarding is enabled globally for IPv6, accept_ra=2 is needed for the kernel to
+		// still process the advertisements
+		return map[string]string{"accept_ra": "2", "accept_ra_defrtr": "0"}
+	}
+	return nil
+}
+
+func configureIPv6RASysctls(bridgeName string, policy config.IPv6RAPolicy) error {
+	return setIPv6Sysctls(bridgeName, ipv6RASysctls(policy))
+}
+
+// ipv6AddrGenSysctls returns the address generation sysctl values of the mode, the temporary
+// addresses are disabled for the addresses of the bridge to remain stable
+func ipv6AddrGenSysctls(mode config.IPv6AddrGenMode) map[string]string {
+	switch mode {
+	case config.IPv6AddrGenModeEUI64:
+		return map[string]string{"addr_gen_mode": "0", "use_tempaddr": "0"}
+	case config.IPv6AddrGenModeStablePrivacy:
+		return map[string]string{"addr_gen_mode": "2", "use_tempaddr": "0"}
+	}
+	return nil
+}
+
+// configureIPv6AddrGen sets the address generation mode and the token of the bridge. The addresses
+// autoconfigured before are replaced as the next router advertisements are received.
+func configureIPv6AddrGen(bridgeName string, mode config.IPv6AddrGenMode, token string) error {
+	if err := setIPv6Sysctls(bridgeName, ipv6AddrGenSysctls(mode)); err != nil {
+		return err
+	}
+	if token == "" {
+		return nil
+	}
+	stdout, stderr, err := util.RunIP("token", "get", "dev", bridgeName)
+	if err != nil {
+		return fmt.Errorf("could not get the IPv6 token of %s: stderr: %s, err: %v", bridgeName, stderr, err)
+	}
+	if current := strings.Fields(stdout); len(current) > 1 && net.ParseIP(current[1]).Equal(net.ParseIP(token)) {
+		return nil
+	}
+	klog.Infof("Setting the IPv6 token of %s to %s", bridgeName, token)
+	if _, stderr, err := util.RunIP("token", "set", token, "dev", bridgeName); err != nil {
+		return fmt.Errorf("could not set the IPv6 token of %s to %s: stderr: %s, err: %v", bridgeName, token, stderr, err)
+	}
+	return nil
+}
+
+func setIPv6Sysctls(bridgeName string, sysctls map[string]string) error {
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := sysctlmanager.Set(fmt.Sprintf("net.ipv6.conf.%s.%s", bridgeName, key), sysctls[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveRouterAdvertisements solicits the routers on the bridge and records the router lifetime of
+// the router advertisements it receives until stopChan is closed
+func (m *ipv6RAManager) receiveRouterAdvertisements(stopChan <-chan struct{}) error {
+	iface, err := net.InterfaceByName(m.bridgeName)
+	if err != nil {
+		return fmt.Errorf("failed finding interface %s: %w", m.bridgeName, err)
+	}
+	c, _, err := ndp.Listen(iface, ndp.LinkLocal)
+	if err != nil {
+		return fmt.Errorf("failed to dial NDP connection on interface %s: %w", m.bridgeName, err)
+	}
+	defer c.Close()
+	var filter ipv6.ICMPFilter
+	filter.SetAll(true)
+	filter.Accept(ipv6.ICMPTypeRouterAdvertisement)
+	if err := c.SetICMPFilter(&filter); err != nil {
+		return fmt.Errorf("failed to filter the router advertisements: %w", err)
+	}
+	rs := &ndp.RouterSolicitation{
+		Options: []ndp.Option{
+			&ndp.LinkLayerAddress{
+				Direction: ndp.Source,
+				Addr:      iface.HardwareAddr,
+			},
+		},
+	}
+	if err := c.WriteTo(rs, nil, netip.IPv6LinkLocalAllRouters()); err != nil {
+		return fmt.Errorf("failed sending router solicitation: %w", err)
+	}
+	for {
+		select {
+		case <-stopChan:
+			return nil
+		default:
+		}
+		if err := c.SetReadDeadline(time.Now().Add(ipv6RASyncPeriod)); err != nil {
+			return err
+		}
+		msg, _, from, err := c.ReadFrom()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+		if ra, ok := msg.(*ndp.RouterAdvertisement); ok {
+			m.updateRouter(net.IP(from.WithZone("").AsSlice()), ra.RouterLifetime, time.Now())
+		}
+	}
+}
+
+// updateRouter records a router advertisement of the router, a router lifetime of zero means the router
+// is no longer a default router
+func (m *ipv6RAManager) updateRouter(router net.IP, lifetime time.Duration, now time.Time) {
+	m.routersLock.Lock()
+	defer m.routersLock.Unlock()
+	if lifetime == 0 {
+		delete(m.routers, router.String())
+		return
+	}
+	m.routers[router.String()] = now.Add(lifetime)
+}
+
+// currentRouters returns the routers whose router lifetime did not expire and forgets the others
+func (m *ipv6RAManager) currentRouters(now time.Time) []net.IP {
+	m.routersLock.Lock()
+	defer m.routersLock.Unlock()
+	var routers []net.IP
+	for router, expiration := range m.routers {
+		if !now.Before(expiration) {
+			klog.Infof("The router lifetime of IPv6 router %s on %s expired", router, m.bridgeName)
+			delete(m.routers, router)
+			continue
+		}
+		routers = append(routers, net.ParseIP(router))
+	}
+	return routers
+}
+
+// reachableRouters splits the routers into the ones the neighbor entries show as reachable and the
+// ones whose reachability is not confirmed, the routers whose neighbor entry failed are left out. Both
+// are sorted so that the selected router is stable.
+func reachableRouters(routers []net.IP, neighs []netlink.Neigh) (reachable, unconfirmed []net.IP) {
+	for _, router := range routers {
+		state := netlink.NUD_NONE
+		for _, neigh := range neighs {
+			if neigh.IP.Equal(router) {
+				state = neigh.State
+				break
+			}
+		}
+		switch {
+		case state&(netlink.NUD_REACHABLE|netlink.NUD_DELAY|netlink.NUD_PERMANENT) != 0:
+			reachable = append(reachable, router)
+		case state&(netlink.NUD_FAILED|netlink.NUD_INCOMPLETE) != 0:
+		default:
+			unconfirmed = append(unconfirmed, router)
+		}
+	}
+	sortIPs(reachable)
+	sortIPs(unconfirmed)
+	return reachable, unconfirmed
+}
+
+func sortIPs(ips []net.IP) {
+	sort.Slice(ips, func(i, j int) bool {
+		return ips[i].String() < ips[j].String()
+	})
+}
+
+func (m *ipv6RAManager) syncDefaultRoute() error {
+	link, err := util.GetNetLinkOps().LinkByName(m.bridgeName)
+	if err != nil {
+		return fmt.Errorf("failed to get link %s: %w", m.bridgeName, err)
+	}
+	neighs, err := util.GetNetLinkOps().NeighList(link.Attrs().Index, netlink.FAMILY_V6)
+	if err != nil {
+		return fmt.Errorf("failed to list neighbors of %s: %w", m.bridgeName, err)
+	}
+	routers, unconfirmed := reachableRouters(m.currentRouters(time.Now()), neighs)
+	// a router not used for a while has a stale neighbor entry, solicit it to confirm it is reachable
+	for _, router := range unconfirmed {
+		if err := probeGatewayNextHop(router, m.bridgeName); err != nil {
+			klog.V(5).Infof("IPv6 router %s did not answer on %s: %v", router, m.bridgeName, err)
+			continue
+		}
+		routers = append(routers, router)
+	}
+	sortIPs(routers)
+
+	// keep the current router as long as it is still advertising itself and reachable
+	if m.defaultRoute != nil {
+		for _, router := range routers {
+			if router.Equal(m.defaultRoute.Gw) && m.defaultRoute.LinkIndex == link.Attrs().Index {
+				return nil
+			}
+		}
+		klog.Infof("IPv6 router %s is no longer reachable on %s, removing the default route through it",
+			m.defaultRoute.Gw, m.bridgeName)
+		m.routeManager.Del(*m.defaultRoute)
+		m.defaultRoute = nil
+	}
+	if len(routers) == 0 {
+		return nil
+	}
+	_, defaultDst, _ := net.ParseCIDR("::/0")
+	m.defaultRoute = &netlink.Route{LinkIndex: link.Attrs().Index, Gw: routers[0], Dst: defaultDst}
+	klog.Infof("Adding the IPv6 default route through router %s learned on %s", routers[0], m.bridgeName)
+	m.routeManager.Add(*m.defaultRoute)
+	return nil
+}
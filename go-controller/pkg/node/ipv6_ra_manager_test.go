@@ -0,0 +1,91 @@
+package node
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("IPv6 router advertisement manager", func() {
+	It("returns the sysctls of each policy", func() {
+		Expect(ipv6RASysctls(config.IPv6RAPolicyUnmanaged)).To(BeEmpty())
+		Expect(ipv6RASysctls(config.IPv6RAPolicyDisabled)).To(Equal(map[string]string{"accept_ra": "0"}))
+		Expect(ipv6RASysctls(config.IPv6RAPolicyManaged)).To(Equal(map[string]string{"accept_ra": "2", "accept_ra_defrtr": "0"}))
+	})
+
+	It("returns the sysctls of each address generation mode", func() {
+		Expect(ipv6AddrGenSysctls(config.IPv6AddrGenModeUnmanaged)).To(BeEmpty())
+		Expect(ipv6AddrGenSysctls(config.IPv6AddrGenModeEUI64)).To(Equal(map[string]string{"addr_gen_mode": "0", "use_tempaddr": "0"}))
+		Expect(ipv6AddrGenSysctls(config.IPv6AddrGenModeStablePrivacy)).To(Equal(map[string]string{"addr_gen_mode": "2", "use_tempaddr": "0"}))
+	})
+
+	It("sets the address generation sysctls and the token of the bridge", func() {
+		fexec := ovntest.NewFakeExec()
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv6.conf.breth0.addr_gen_mode",
+			Output: "1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.ipv6.conf.breth0.addr_gen_mode=0",
+			Output: "net.ipv6.conf.breth0.addr_gen_mode = 0",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv6.conf.breth0.use_tempaddr",
+			Output: "2",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.ipv6.conf.breth0.use_tempaddr=0",
+			Output: "net.ipv6.conf.breth0.use_tempaddr = 0",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{"ip token get dev breth0"})
+		fexec.AddFakeCmdsNoOutputNoError([]string{"ip token set ::10 dev breth0"})
+		// the token is already set
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ip token get dev breth0",
+			Output: "token ::10 dev breth0",
+		})
+		Expect(util.SetExec(fexec)).To(Succeed())
+
+		Expect(configureIPv6AddrGen("breth0", config.IPv6AddrGenModeEUI64, "::10")).To(Succeed())
+		Expect(configureIPv6AddrGen("breth0", config.IPv6AddrGenModeUnmanaged, "::10")).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("selects the reachable routers from the neighbor entries", func() {
+		routers := []net.IP{net.ParseIP("fe80::2"), net.ParseIP("fe80::1"), net.ParseIP("fe80::4"), net.ParseIP("fe80::5"),
+			net.ParseIP("fe80::6")}
+		neighs := []netlink.Neigh{
+			{IP: net.ParseIP("fe80::2"), Flags: netlink.NTF_ROUTER, State: netlink.NUD_DELAY},
+			{IP: net.ParseIP("fe80::1"), Flags: netlink.NTF_ROUTER, State: netlink.NUD_REACHABLE},
+			// not advertising itself
+			{IP: net.ParseIP("fe80::3"), Flags: netlink.NTF_ROUTER, State: netlink.NUD_REACHABLE},
+			// router that stopped answering
+			{IP: net.ParseIP("fe80::4"), Flags: netlink.NTF_ROUTER, State: netlink.NUD_FAILED},
+			// router not used for a while
+			{IP: net.ParseIP("fe80::5"), Flags: netlink.NTF_ROUTER, State: netlink.NUD_STALE},
+		}
+		reachable, unconfirmed := reachableRouters(routers, neighs)
+		Expect(reachable).To(Equal([]net.IP{net.ParseIP("fe80::1"), net.ParseIP("fe80::2")}))
+		Expect(unconfirmed).To(Equal([]net.IP{net.ParseIP("fe80::5"), net.ParseIP("fe80::6")}))
+	})
+
+	It("forgets the routers whose router lifetime expired", func() {
+		now := time.Now()
+		m := newIPv6RAManager("breth0", nil)
+		m.updateRouter(net.ParseIP("fe80::1"), 30*time.Second, now)
+		m.updateRouter(net.ParseIP("fe80::2"), 10*time.Second, now)
+		m.updateRouter(net.ParseIP("fe80::3"), 30*time.Second, now)
+		// the router is no longer a default router
+		m.updateRouter(net.ParseIP("fe80::3"), 0, now)
+		Expect(m.currentRouters(now.Add(5 * time.Second))).To(ConsistOf(net.ParseIP("fe80::1"), net.ParseIP("fe80::2")))
+		Expect(m.currentRouters(now.Add(20 * time.Second))).To(Equal([]net.IP{net.ParseIP("fe80::1")}))
+		Expect(m.routers).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,145 @@
+package node
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// kernelModulesMissingEventReason is the reason of the node events reporting the missing kernel modules
+const kernelModulesMissingEventReason = "KernelModulesMissing"
+
+// kernelModules finds the kernel modules loaded in or built into the running kernel
+type kernelModules struct {
+	// sysModuleDir holds a directory per loaded module, the built-in modules only have one when they have
+	// parameters
+	sysModuleDir string
+	// libModulesDir holds the modules.builtin file of each kernel release, listing the built-in modules
+	libModulesDir string
+}
+
+func newKernelModules() *kernelModules {
+	return &kernelModules{
+		sysModuleDir:  "/sys/module",
+		libModulesDir: "/lib/modules",
+	}
+}
+
+// builtin returns the modules built into the running kernel, none when they can't be listed
+func (k *kernelModules) builtin() map[string]bool {
+	builtin := map[string]bool{}
+	release, err := readSysctl("kernel.osrelease")
+	if err != nil {
+		klog.V(5).Infof("Unable to find the kernel release to list the built-in modules: %v", err)
+		return builtin
+	}
+	file, err := os.Open(filepath.Join(k.libModulesDir, release, "modules.builtin"))
+	if err != nil {
+		klog.V(5).Infof("Unable to list the built-in modules of kernel %s: %v", release, err)
+		return builtin
+	}
+	defer file.Close()
+	// the modules are listed by path, e.g. kernel/net/openvswitch/openvswitch.ko
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		name, _, _ := strings.Cut(filepath.Base(strings.TrimSpace(scanner.Text())), ".")
+		builtin[kernelModuleName(name)] = true
+	}
+	return builtin
+}
+
+// kernelModuleName returns the name the kernel knows a module by, the dashes of the file names are underscores
+func kernelModuleName(module string) string {
+	return strings.ReplaceAll(module, "-", "_")
+}
+
+// missing returns the modules neither loaded nor built into the kernel
+func (k *kernelModules) missing(modules []string) []string {
+	var missing []string
+	var builtin map[string]bool
+	for _, module := range modules {
+		if _, err := os.Stat(filepath.Join(k.sysModuleDir, kernelModuleName(module))); err == nil {
+			continue
+		}
+		if builtin == nil {
+			builtin = k.builtin()
+		}
+		if !builtin[kernelModuleName(module)] {
+			missing = append(missing, module)
+		}
+	}
+	return missing
+}
+
+// check checks the required kernel modules are loaded or built into the kernel, the modules loaded on
+// demand are only reported
+func (k *kernelModules) check() (string, error) {
+	modules := requiredKernelModules()
+	if missing := k.missing(modules); len(missing) > 0 {
+		return "", fmt.Errorf("kernel modules not loaded: %s", strings.Join(missing, ", "))
+	}
+	message := fmt.Sprintf("kernel modules loaded: %s", strings.Join(modules, ", "))
+	if missing := k.missing(onDemandKernelModules()); len(missing) > 0 {
+		message += fmt.Sprintf(", not loaded yet: %s", strings.Join(missing, ", "))
+	}
+	return message, nil
+}
+
+// ensureKernelModules checks the kernel modules the node requires are loaded before anything is configured,
+// rather than failing later in the OVS commands, and loads the missing ones with modprobe when the
+// load-kernel-modules option is set. The modules still missing are reported as a node event, the start only
+// fails on the missing required ones as the kernel and OVS load the others on demand.
+func (nc *DefaultNodeNetworkController) ensureKernelModules() error {
+	required := requiredKernelModules()
+	missing := nc.kernelModules.missing(slices.Concat(required, onDemandKernelModules()))
+	if len(missing) == 0 {
+		return nil
+	}
+	if config.OvnKubeNode.LoadKernelModules {
+		for _, module := range missing {
+			if _, stderr, err := util.RunModprobe(module); err != nil {
+				klog.Errorf("Failed to load kernel module %s, stderr: %q, error: %v", module, stderr, err)
+				continue
+			}
+			klog.Infof("Loaded kernel module %s", module)
+		}
+		missing = nc.kernelModules.missing(missing)
+		if len(missing) == 0 {
+			return nil
+		}
+	}
+	var missingRequired []string
+	for _, module := range missing {
+		if slices.Contains(required, module) {
+			missingRequired = append(missingRequired, module)
+		}
+	}
+	message := fmt.Sprintf("Kernel modules required by the node are not loaded: %s", strings.Join(missing, ", "))
+	if !config.OvnKubeNode.LoadKernelModules {
+		message += ", load them on the host or set the load-kernel-modules option"
+	}
+	if nc.recorder != nil {
+		nodeRef := &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nc.name,
+			UID:  ktypes.UID(nc.name),
+		}
+		nc.recorder.Event(nodeRef, kapi.EventTypeWarning, kernelModulesMissingEventReason, message)
+	}
+	if len(missingRequired) == 0 {
+		klog.Warningf("%s, the kernel loads them on demand", message)
+		return nil
+	}
+	return errors.New(message)
+}
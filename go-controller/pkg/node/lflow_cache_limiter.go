@@ -0,0 +1,211 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// lflowCacheLimitInterval is how often the memory limit of the logical flow cache is adjusted
+	lflowCacheLimitInterval = 30 * time.Second
+	// lflowCacheLowMemoryRatio is the share of available node memory under which the node is under memory pressure
+	lflowCacheLowMemoryRatio = 0.10
+	// lflowCacheSpareMemoryRatio is the share of available node memory above which the node has memory to spare
+	lflowCacheSpareMemoryRatio = 0.25
+)
+
+// procDir is the root the memory of the node and of ovn-controller are read from, overridden by the tests
+var procDir = "/proc"
+
+// nodeMemory is the memory state of the node
+type nodeMemory struct {
+	// pressure is set when the node has the MemoryPressure condition
+	pressure           bool
+	totalKb            uint64
+	availableKb        uint64
+	ovnControllerRSSKb uint64
+}
+
+// lflowCacheLimiter adjusts the memory limit of the ovn-controller logical flow cache within the configured
+// bounds: the limit is halved while the node is under memory pressure and doubled back when the node has
+// memory to spare.
+type lflowCacheLimiter struct {
+	nodeName            string
+	watchFactory        factory.NodeWatchFactory
+	getOVNControllerPID func() (string, error)
+}
+
+func newLFlowCacheLimiter(nodeName string, watchFactory factory.NodeWatchFactory) *lflowCacheLimiter {
+	return &lflowCacheLimiter{
+		nodeName:            nodeName,
+		watchFactory:        watchFactory,
+		getOVNControllerPID: util.GetOvnControllerPID,
+	}
+}
+
+func (l *lflowCacheLimiter) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, lflowCacheLimitInterval, nil, func() {
+		if err := l.sync(); err != nil {
+			klog.Errorf("Failed to adjust the logical flow cache limit of node %s: %v", l.nodeName, err)
+		}
+	})
+}
+
+func (l *lflowCacheLimiter) sync() error {
+	node, err := l.watchFactory.GetNode(l.nodeName)
+	if err != nil {
+		return err
+	}
+	return l.adjust(node)
+}
+
+// adjust sets the logical flow cache limit for the current memory state of the node
+func (l *lflowCacheLimiter) adjust(node *kapi.Node) error {
+	memory, err := l.nodeMemory(node)
+	if err != nil {
+		return err
+	}
+	current, err := getLFlowCacheLimitKb()
+	if err != nil {
+		return err
+	}
+	limit, reason := nextLFlowCacheLimitKb(current, memory)
+	if limit == current {
+		return nil
+	}
+	klog.Infof("Adjusting the logical flow cache limit of ovn-controller from %d KB to %d KB: %s", current, limit, reason)
+	_, stderr, err := util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		fmt.Sprintf("external_ids:ovn-memlimit-lflow-cache-kb=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to set the logical flow cache limit, stderr: %q, error: %v", stderr, err)
+	}
+	return nil
+}
+
+func (l *lflowCacheLimiter) nodeMemory(node *kapi.Node) (*nodeMemory, error) {
+	memory := &nodeMemory{}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == kapi.NodeMemoryPressure && condition.Status == kapi.ConditionTrue {
+			memory.pressure = true
+		}
+	}
+	meminfo, err := readProcKbFields(filepath.Join(procDir, "meminfo"), "MemTotal", "MemAvailable")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the node memory: %w", err)
+	}
+	memory.totalKb, memory.availableKb = meminfo["MemTotal"], meminfo["MemAvailable"]
+	pid, err := l.getOVNControllerPID()
+	if err != nil {
+		return nil, err
+	}
+	status, err := readProcKbFields(filepath.Join(procDir, pid, "status"), "VmRSS")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ovn-controller memory: %w", err)
+	}
+	memory.ovnControllerRSSKb = status["VmRSS"]
+	return memory, nil
+}
+
+// readProcKbFields reads the given fields in kB of a /proc file like /proc/meminfo
+func readProcKbFields(path string, names ...string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fields := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// e.g. "MemAvailable:   12345678 kB"
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		for _, wanted := range names {
+			if name != wanted {
+				continue
+			}
+			kb, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), " kB"), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q in %s", name, value, path)
+			}
+			fields[name] = kb
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if _, ok := fields[name]; !ok {
+			return nil, fmt.Errorf("no %s in %s", name, path)
+		}
+	}
+	return fields, nil
+}
+
+// getLFlowCacheLimitKb returns the current logical flow cache limit, the highest limit when not set
+func getLFlowCacheLimitKb() (uint, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "external_ids:ovn-memlimit-lflow-cache-kb")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the logical flow cache limit, stderr: %q, error: %v", stderr, err)
+	}
+	stdout = strings.Trim(stdout, "\"")
+	if stdout == "" {
+		return config.Default.LFlowCacheLimitKbMax, nil
+	}
+	limit, err := strconv.ParseUint(stdout, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("invalid logical flow cache limit %q: %v", stdout, err)
+	}
+	return uint(limit), nil
+}
+
+// nextLFlowCacheLimitKb returns the logical flow cache limit for the memory state of the node, with the
+// reason of the change
+func nextLFlowCacheLimitKb(current uint, memory *nodeMemory) (uint, string) {
+	minLimit, maxLimit := config.Default.LFlowCacheLimitKbMin, config.Default.LFlowCacheLimitKbMax
+	availableRatio := float64(memory.availableKb) / float64(memory.totalKb)
+
+	var pressure string
+	switch {
+	case memory.pressure:
+		pressure = "the node has the MemoryPressure condition"
+	case availableRatio < lflowCacheLowMemoryRatio:
+		pressure = fmt.Sprintf("only %d%% of the node memory is available", int(availableRatio*100))
+	case memory.ovnControllerRSSKb > memory.availableKb:
+		pressure = fmt.Sprintf("the ovn-controller RSS %d KB exceeds the available node memory %d KB",
+			memory.ovnControllerRSSKb, memory.availableKb)
+	}
+
+	switch {
+	case current < minLimit:
+		return minLimit, "the limit is lower than the lowest limit"
+	case current > maxLimit:
+		return maxLimit, "the limit is higher than the highest limit"
+	case pressure != "":
+		if current/2 < minLimit {
+			return minLimit, pressure
+		}
+		return current / 2, pressure
+	case availableRatio >= lflowCacheSpareMemoryRatio:
+		reason := fmt.Sprintf("%d%% of the node memory is available", int(availableRatio*100))
+		if current*2 > maxLimit {
+			return maxLimit, reason
+		}
+		return current * 2, reason
+	}
+	return current, ""
+}
@@ -0,0 +1,101 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Logical flow cache limiter", func() {
+	var (
+		execMock *ovntest.FakeExec
+		tmpDir   string
+	)
+
+	writeProcFile := func(path, content string) {
+		path = filepath.Join(procDir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Default.LFlowCacheAdaptive = true
+		config.Default.LFlowCacheLimitKbMin = 100000
+		config.Default.LFlowCacheLimitKbMax = 800000
+		execMock = ovntest.NewFakeExec()
+		Expect(util.SetExec(execMock)).To(Succeed())
+		var err error
+		tmpDir, err = os.MkdirTemp("", "lflow-cache")
+		Expect(err).NotTo(HaveOccurred())
+		procDir = tmpDir
+	})
+
+	AfterEach(func() {
+		procDir = "/proc"
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	It("adjusts the limit to the memory state of the node within the bounds", func() {
+		for _, tc := range []struct {
+			current       uint
+			memory        nodeMemory
+			expectedLimit uint
+		}{
+			// memory to spare
+			{400000, nodeMemory{totalKb: 1000, availableKb: 500}, 800000},
+			{600000, nodeMemory{totalKb: 1000, availableKb: 500}, 800000},
+			// neither memory pressure nor memory to spare
+			{400000, nodeMemory{totalKb: 1000, availableKb: 200}, 400000},
+			// memory pressure
+			{400000, nodeMemory{totalKb: 1000, availableKb: 500, pressure: true}, 200000},
+			{400000, nodeMemory{totalKb: 1000, availableKb: 50}, 200000},
+			{400000, nodeMemory{totalKb: 1000, availableKb: 200, ovnControllerRSSKb: 300}, 200000},
+			{150000, nodeMemory{totalKb: 1000, availableKb: 50}, 100000},
+			// out of bounds
+			{50000, nodeMemory{totalKb: 1000, availableKb: 200}, 100000},
+			{900000, nodeMemory{totalKb: 1000, availableKb: 50}, 800000},
+		} {
+			limit, _ := nextLFlowCacheLimitKb(tc.current, &tc.memory)
+			Expect(limit).To(Equal(tc.expectedLimit), "%d KB with %+v", tc.current, tc.memory)
+		}
+	})
+
+	It("halves the limit when the node has the MemoryPressure condition", func() {
+		writeProcFile("meminfo", "MemTotal:       16000000 kB\nMemFree:         2000000 kB\nMemAvailable:    8000000 kB\n")
+		writeProcFile("42/status", "Name:\tovn-controller\nVmRSS:\t  300000 kB\n")
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-memlimit-lflow-cache-kb",
+		})
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-memlimit-lflow-cache-kb=400000",
+		})
+
+		l := &lflowCacheLimiter{nodeName: "node1", getOVNControllerPID: func() (string, error) { return "42", nil }}
+		node := &kapi.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Status: kapi.NodeStatus{
+				Conditions: []kapi.NodeCondition{{Type: kapi.NodeMemoryPressure, Status: kapi.ConditionTrue}},
+			},
+		}
+		Expect(l.adjust(node)).To(Succeed())
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+	})
+
+	It("does not change the limit when the node memory can't be read", func() {
+		writeProcFile("meminfo", "MemTotal:       16000000 kB\n")
+		l := &lflowCacheLimiter{nodeName: "node1", getOVNControllerPID: func() (string, error) { return "42", nil }}
+		err := l.adjust(&kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+		Expect(err).To(MatchError(ContainSubstring("no MemAvailable in")))
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+	})
+})
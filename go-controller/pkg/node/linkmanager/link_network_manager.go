@@ -53,6 +53,7 @@ func (c *Controller) Run(stopCh <-chan struct{}, doneWg *sync.WaitGroup) {
 			klog.Errorf("Failed during LinkSubscribe callback: %v", err)
 			// Note: Not calling sync() from here: it is redundant and unsafe when stopChan is closed.
 		},
+		Namespace: util.NodeNetNSHandle(),
 	}
 
 	subscribe := func() (bool, chan netlink.LinkUpdate, error) {
@@ -0,0 +1,154 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// localnetMisconfiguredEventReason is the reason of the node events reporting a localnet network
+	// that can't be connected to its physical network on the node
+	localnetMisconfiguredEventReason = "LocalnetMisconfigured"
+
+	// localnetTrunkSyncPeriod is the period the allowed VLANs of the localnet networks are reconciled at, the
+	// patch ports are recreated by ovn-controller as the pods of the network come and go on the node
+	localnetTrunkSyncPeriod = 30 * time.Second
+)
+
+// setBridgeMapping maps the physical network to the bridge in ovn-bridge-mappings
+func setBridgeMapping(physicalNetworkName, bridgeName string) error {
+	// ovn-bridge-mappings maps a physical network name to a local ovs bridge
+	// that provides connectivity to that network. It is in the form of physnet1:br1,physnet2:br2.
+	// Note that there may be multiple ovs bridge mappings, be sure not to override
+	// the mappings for the other physical network
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:ovn-bridge-mappings")
+	if err != nil {
+		return fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+	}
+	// skip the existing mapping setting for the specified physicalNetworkName
+	mapString := ""
+	bridgeMappings := strings.Split(stdout, ",")
+	for _, bridgeMapping := range bridgeMappings {
+		m := strings.Split(bridgeMapping, ":")
+		if network := m[0]; network != physicalNetworkName {
+			if len(mapString) != 0 {
+				mapString += ","
+			}
+			mapString += bridgeMapping
+		}
+	}
+	if len(mapString) != 0 {
+		mapString += ","
+	}
+	mapString += physicalNetworkName + ":" + bridgeName
+
+	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		fmt.Sprintf("external_ids:ovn-bridge-mappings=%s", mapString))
+	if err != nil {
+		return fmt.Errorf("failed to set ovn-bridge-mappings for ovs bridge %s"+
+			", stderr:%s (%v)", bridgeName, stderr, err)
+	}
+	return nil
+}
+
+// getLocalnetPatchPortName returns the name of the patch port ovn-controller creates on the bridge of the
+// physical network for the localnet port of the network
+func getLocalnetPatchPortName(netInfo util.NetInfo) string {
+	return types.PatchPortPrefix + netInfo.GetNetworkScopedName(types.OVNLocalnetPort) + types.PatchPortSuffix
+}
+
+// ensureLocalnetBridgeMapping maps the physical network of the localnet network to its bridge in
+// ovn-bridge-mappings. The bridge is the one configured for the physical network in the localnet bridge
+// mappings of the node, or the one it is already mapped to. A physical network without a bridge, or
+// mapped to a missing bridge, is reported as a node event and returns false.
+func (nc *SecondaryNodeNetworkController) ensureLocalnetBridgeMapping() (bool, error) {
+	physNet := nc.PhysicalNetworkName()
+	bridgeMappings, err := util.GetOVNBridgeMappings()
+	if err != nil {
+		return false, err
+	}
+	mappedBridge := bridgeMappings[physNet]
+	bridge, configured := config.LocalnetBridgeMappings()[physNet]
+	if !configured {
+		bridge = mappedBridge
+	}
+	if bridge == "" {
+		nc.reportLocalnetMisconfiguration("physical network %s of localnet network %s is not mapped to a bridge, "+
+			"add it to the localnet bridge mappings of the node", physNet, nc.GetNetworkName())
+		return false, nil
+	}
+	if _, stderr, err := util.RunOVSVsctl("br-exists", bridge); err != nil {
+		nc.reportLocalnetMisconfiguration("bridge %s of physical network %s of localnet network %s does not exist: %s",
+			bridge, physNet, nc.GetNetworkName(), stderr)
+		return false, nil
+	}
+	if bridge != mappedBridge {
+		if err := setBridgeMapping(physNet, bridge); err != nil {
+			return false, err
+		}
+		klog.Infof("Mapped physical network %s of localnet network %s to bridge %s", physNet, nc.GetNetworkName(), bridge)
+	}
+	return true, nil
+}
+
+// syncLocalnetTrunk trunks the allowed VLANs of the localnet network on its patch port, if the patch port
+// was created by ovn-controller
+func (nc *SecondaryNodeNetworkController) syncLocalnetTrunk() error {
+	vlans := nc.AllowedVLANs()
+	trunks := make([]string, 0, len(vlans))
+	for _, vlan := range vlans {
+		trunks = append(trunks, fmt.Sprintf("%d", vlan))
+	}
+	patchPort := getLocalnetPatchPortName(nc.NetInfo)
+	_, stderr, err := util.RunOVSVsctl("--if-exists", "set", "Port", patchPort,
+		fmt.Sprintf("trunks=[%s]", strings.Join(trunks, ",")))
+	if err != nil {
+		return fmt.Errorf("failed to set the allowed VLANs of localnet network %s on port %s, stderr: %s (%v)",
+			nc.GetNetworkName(), patchPort, stderr, err)
+	}
+	return nil
+}
+
+// startLocalnet connects the localnet network to its physical network on the node and keeps its allowed
+// VLANs trunked on its patch port
+func (nc *SecondaryNodeNetworkController) startLocalnet() error {
+	mapped, err := nc.ensureLocalnetBridgeMapping()
+	if err != nil {
+		return fmt.Errorf("failed to map the physical network of localnet network %s: %w", nc.GetNetworkName(), err)
+	}
+	if !mapped || len(nc.AllowedVLANs()) == 0 {
+		return nil
+	}
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		wait.Until(func() {
+			if err := nc.syncLocalnetTrunk(); err != nil {
+				klog.Errorf("Failed to sync the VLAN trunk of localnet network %s: %v", nc.GetNetworkName(), err)
+			}
+		}, localnetTrunkSyncPeriod, nc.stopChan)
+	}()
+	return nil
+}
+
+// reportLocalnetMisconfiguration logs and reports a misconfiguration of a localnet network as a node event
+func (nc *SecondaryNodeNetworkController) reportLocalnetMisconfiguration(messageFmt string, args ...interface{}) {
+	klog.Errorf(messageFmt, args...)
+	nodeRef := &kapi.ObjectReference{
+		Kind: "Node",
+		Name: nc.name,
+		UID:  ktypes.UID(nc.name),
+	}
+	nc.recorder.Eventf(nodeRef, kapi.EventTypeWarning, localnetMisconfiguredEventReason, messageFmt, args...)
+}
@@ -0,0 +1,134 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/record"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Localnet networks", func() {
+	var (
+		fexec    *ovntest.FakeExec
+		recorder *record.FakeRecorder
+	)
+
+	newLocalnetController := func(netconf *ovncnitypes.NetConf) *SecondaryNodeNetworkController {
+		netInfo, err := util.NewNetInfo(netconf)
+		Expect(err).NotTo(HaveOccurred())
+		return &SecondaryNodeNetworkController{
+			BaseNodeNetworkController: BaseNodeNetworkController{
+				CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{
+					name:     "node1",
+					recorder: recorder,
+				},
+				NetInfo:  netInfo,
+				stopChan: make(chan struct{}),
+				wg:       &sync.WaitGroup{},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("maps the physical network to its configured bridge and trunks the allowed VLANs", func() {
+		config.OvnKubeNode.LocalnetBridgeMappings = "physnet1:br-ex1"
+		nc := newLocalnetController(&ovncnitypes.NetConf{
+			NetConf:             cnitypes.NetConf{Name: "tenant-red"},
+			Topology:            types.LocalnetTopology,
+			AllowedVLANs:        "10-11,20",
+			PhysicalNetworkName: "physnet1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 br-exists br-ex1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-bridge-mappings=" + types.PhysicalNetworkName + ":breth0,physnet1:br-ex1",
+			"ovs-vsctl --timeout=15 --if-exists set Port patch-tenant.red_ovn_localnet_port-to-br-int trunks=[10,11,20]",
+		})
+
+		mapped, err := nc.ensureLocalnetBridgeMapping()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mapped).To(BeTrue())
+		Expect(nc.syncLocalnetTrunk()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("keeps the existing mapping of the physical network", func() {
+		nc := newLocalnetController(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: "tenantred"},
+			Topology: types.LocalnetTopology,
+			VLANID:   10,
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0,tenantred:br-red",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 br-exists br-red",
+		})
+
+		Expect(nc.startLocalnet()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	It("reports a physical network without a bridge as a node event", func() {
+		nc := newLocalnetController(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: "tenantred"},
+			Topology: types.LocalnetTopology,
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0",
+		})
+
+		Expect(nc.startLocalnet()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(Receive(ContainSubstring(localnetMisconfiguredEventReason)))
+	})
+
+	It("reports a physical network mapped to a missing bridge as a node event", func() {
+		config.OvnKubeNode.LocalnetBridgeMappings = "tenantred:br-red"
+		nc := newLocalnetController(&ovncnitypes.NetConf{
+			NetConf:      cnitypes.NetConf{Name: "tenantred"},
+			Topology:     types.LocalnetTopology,
+			AllowedVLANs: "10",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: types.PhysicalNetworkName + ":breth0",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 br-exists br-red",
+			Err: fmt.Errorf("exit status 2"),
+		})
+
+		Expect(nc.startLocalnet()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(Receive(ContainSubstring("bridge br-red of physical network tenantred")))
+	})
+})
@@ -0,0 +1,22 @@
+package node
+
+import (
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/logging"
+)
+
+// The loggers of the node subsystems. Their log level can be set independently of the klog level through
+// subsystem-loglevels or at runtime through the /debug/flags/subsystems endpoint of the metrics server.
+var (
+	// nodeLog logs the bring up of the node and of its subsystems
+	nodeLog = logging.Subsystem("node")
+	// gatewayLog logs the gateway bridge, its flows, iptables rules and next hops
+	gatewayLog = logging.Subsystem("gateway")
+	// servicesLog logs the host ports claimed for the services and the session affinity of their endpoints
+	servicesLog = logging.Subsystem("services")
+	// nodeIPLog logs the tracking of the node IP addresses
+	nodeIPLog = logging.Subsystem("node-ip")
+	// dpuLog logs the pod interfaces plugged on DPU nodes
+	dpuLog = logging.Subsystem("dpu")
+	// healthLog logs the health checks served and run by the node
+	healthLog = logging.Subsystem("health")
+)
@@ -5,9 +5,9 @@ import (
 	"net"
 	"time"
 
+	"github.com/vishvananda/netlink"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
-	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -21,19 +21,30 @@ type managementPortRepresentor struct {
 	nodeName    string
 	hostSubnets []*net.IPNet
 	repName     string
+	// lookupRepName looks up the representor of the management port again, when it is not found by its
+	// name anymore, nil when it can't be looked up again
+	lookupRepName func() (string, error)
 }
 
 // newManagementPortRepresentor creates a new managementPortRepresentor
 func newManagementPortRepresentor(nodeName string, hostSubnets []*net.IPNet, rep string) ManagementPort {
-	return &managementPortRepresentor{
+	mp := &managementPortRepresentor{
 		nodeName:    nodeName,
 		hostSubnets: hostSubnets,
 		repName:     rep,
 	}
+	if config.OvnKubeNode.Mode == types.NodeModeFull {
+		// with VF-LAG, the representor of the management port VF may move to another PF of the bond on failover
+		mp.lookupRepName = func() (string, error) {
+			_, rep, err := getMgmtPortAndRepNameModeFull()
+			return rep, err
+		}
+	}
+	return mp
 }
 
 func (mp *managementPortRepresentor) Create(_ *routemanager.Controller, node *v1.Node,
-	nodeLister listers.NodeLister, kubeInterface kube.Interface, waiter *startupWaiter) (*managementPortConfig, error) {
+	nodeAnnotator kube.Annotator, waiter *startupWaiter) (*managementPortConfig, error) {
 	k8sMgmtIntfName := types.K8sMgmtIntfName
 	if config.OvnKubeNode.Mode == types.NodeModeFull {
 		k8sMgmtIntfName += "_0"
@@ -61,7 +72,7 @@ func (mp *managementPortRepresentor) Create(_ *routemanager.Controller, node *v1
 	klog.Infof("Setup representor management port: %s", link.Attrs().Name)
 
 	setName := link.Attrs().Name != k8sMgmtIntfName
-	setMTU := link.Attrs().MTU != config.Default.MTU
+	setMTU := link.Attrs().MTU != mgmtPortMTU()
 
 	if setName || setMTU {
 		if err = util.GetNetLinkOps().LinkSetDown(link); err != nil {
@@ -75,7 +86,7 @@ func (mp *managementPortRepresentor) Create(_ *routemanager.Controller, node *v1
 		}
 
 		if setMTU {
-			if err = util.GetNetLinkOps().LinkSetMTU(link, config.Default.MTU); err != nil {
+			if err = util.GetNetLinkOps().LinkSetMTU(link, mgmtPortMTU()); err != nil {
 				return nil, fmt.Errorf("failed to set link MTU for device %s. %v", link.Attrs().Name, err)
 			}
 		}
@@ -97,7 +108,7 @@ func (mp *managementPortRepresentor) Create(_ *routemanager.Controller, node *v1
 	if br_type == types.DatapathUserspace {
 		dpdkArgs := []string{"type=dpdk"}
 		ovsArgs = append(ovsArgs, dpdkArgs...)
-		ovsArgs = append(ovsArgs, fmt.Sprintf("mtu_request=%v", config.Default.MTU))
+		ovsArgs = append(ovsArgs, fmt.Sprintf("mtu_request=%v", mgmtPortMTU()))
 	}
 
 	// Plug management port representor to OVS.
@@ -114,7 +125,7 @@ func (mp *managementPortRepresentor) Create(_ *routemanager.Controller, node *v1
 	}
 
 	mgmtPortMac := util.IPAddrToHWAddr(util.GetNodeManagementIfAddr(mp.hostSubnets[0]).IP)
-	if err := util.UpdateNodeManagementPortMACAddressesWithRetry(node, nodeLister, kubeInterface, mgmtPortMac, types.DefaultNetworkName); err != nil {
+	if err := setManagementPortMACAddress(nodeAnnotator, node, mgmtPortMac); err != nil {
 		return nil, err
 	}
 	waiter.AddWait(managementPortReady, nil)
@@ -130,7 +141,10 @@ func (mp *managementPortRepresentor) checkRepresentorPortHealth(cfg *managementP
 		link, err := util.GetNetLinkOps().LinkByName(mp.repName)
 		if err != nil {
 			klog.Errorf("Failed to get link device %s, error: %v", mp.repName, err)
-			return
+			if link, err = mp.relookupRepresentor(cfg.ifName); err != nil {
+				klog.Errorf("Failed to look up the management port representor again: %v", err)
+				return
+			}
 		}
 		if err = util.GetNetLinkOps().LinkSetDown(link); err != nil {
 			klog.Errorf("Failed to set link down for device %s. %v", mp.repName, err)
@@ -140,8 +154,8 @@ func (mp *managementPortRepresentor) checkRepresentorPortHealth(cfg *managementP
 			klog.Errorf("Rename link from %s to %s failed: %v", mp.repName, cfg.ifName, err)
 			return
 		}
-		if link.Attrs().MTU != config.Default.MTU {
-			if err = util.GetNetLinkOps().LinkSetMTU(link, config.Default.MTU); err != nil {
+		if link.Attrs().MTU != mgmtPortMTU() {
+			if err = util.GetNetLinkOps().LinkSetMTU(link, mgmtPortMTU()); err != nil {
 				klog.Errorf("Failed to set link MTU for device %s. %v", cfg.ifName, err)
 			}
 		}
@@ -156,6 +170,33 @@ func (mp *managementPortRepresentor) checkRepresentorPortHealth(cfg *managementP
 	}
 }
 
+// relookupRepresentor looks up the representor of the management port again and returns its link. When
+// the representor changed, its name is updated, along with the original name the management port interface
+// ifName is renamed back to on cleanup.
+func (mp *managementPortRepresentor) relookupRepresentor(ifName string) (netlink.Link, error) {
+	if mp.lookupRepName == nil {
+		return nil, fmt.Errorf("representor %s can't be looked up again", mp.repName)
+	}
+	repName, err := mp.lookupRepName()
+	if err != nil {
+		return nil, err
+	}
+	link, err := util.GetNetLinkOps().LinkByName(repName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link device %s: %w", repName, err)
+	}
+	if repName != mp.repName {
+		klog.Infof("Management port representor moved from %s to %s", mp.repName, repName)
+		if _, stderr, err := util.RunOVSVsctl("set", "interface", ifName,
+			"external-ids:ovn-orig-mgmt-port-rep-name="+repName); err != nil {
+			return nil, fmt.Errorf("failed to store the original name %s of management port %s, stderr: %q: %w",
+				repName, ifName, stderr, err)
+		}
+		mp.repName = repName
+	}
+	return link, nil
+}
+
 func (mp *managementPortRepresentor) CheckManagementPortHealth(_ *routemanager.Controller, cfg *managementPortConfig, stopChan chan struct{}) {
 	go wait.Until(
 		func() {
@@ -184,7 +225,7 @@ func newManagementPortNetdev(hostSubnets []*net.IPNet, netdevName string) Manage
 }
 
 func (mp *managementPortNetdev) Create(routeManager *routemanager.Controller, node *v1.Node,
-	nodeLister listers.NodeLister, kubeInterface kube.Interface, waiter *startupWaiter) (*managementPortConfig, error) {
+	nodeAnnotator kube.Annotator, waiter *startupWaiter) (*managementPortConfig, error) {
 	klog.Infof("Lookup netdevice link and existing management port using '%v'", mp.netdevName)
 	link, err := util.GetNetLinkOps().LinkByName(mp.netdevName)
 	if err != nil {
@@ -204,7 +245,7 @@ func (mp *managementPortNetdev) Create(routeManager *routemanager.Controller, no
 	mgmtPortMac := util.IPAddrToHWAddr(util.GetNodeManagementIfAddr(mp.hostSubnets[0]).IP)
 	setMac := link.Attrs().HardwareAddr.String() != mgmtPortMac.String()
 	setName := link.Attrs().Name != types.K8sMgmtIntfName
-	setMTU := link.Attrs().MTU != config.Default.MTU
+	setMTU := link.Attrs().MTU != mgmtPortMTU()
 
 	if setMac || setName || setMTU {
 		err := util.GetNetLinkOps().LinkSetDown(link)
@@ -227,7 +268,7 @@ func (mp *managementPortNetdev) Create(routeManager *routemanager.Controller, no
 		}
 
 		if setMTU {
-			err := util.GetNetLinkOps().LinkSetMTU(link, config.Default.MTU)
+			err := util.GetNetLinkOps().LinkSetMTU(link, mgmtPortMTU())
 			if err != nil {
 				return nil, fmt.Errorf("failed to set management port MTU. %v", err)
 			}
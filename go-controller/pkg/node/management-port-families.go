@@ -0,0 +1,85 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// managementPortFamiliesRetryPeriod is how often the address families of the node subnets are checked again
+// after a failure
+const managementPortFamiliesRetryPeriod = time.Minute
+
+// managementPortFamiliesController configures the management port with the address family of the node subnets it
+// was created without, once the cluster is converted from single-stack to dual-stack and the node gets a subnet of
+// the other family, so that ovnkube-node does not need to be restarted for it
+type managementPortFamiliesController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	routeManager *routemanager.Controller
+	cfg          *managementPortConfig
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+}
+
+func newManagementPortFamiliesController(nodeName string, watchFactory factory.NodeWatchFactory,
+	routeManager *routemanager.Controller, cfg *managementPortConfig, stopChan <-chan struct{}) *managementPortFamiliesController {
+	return &managementPortFamiliesController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		routeManager: routeManager,
+		cfg:          cfg,
+		stopChan:     stopChan,
+		trigger:      make(chan struct{}, 1),
+	}
+}
+
+func (c *managementPortFamiliesController) Run(doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && util.NodeSubnetAnnotationChanged(oldNode, newNode) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the management port address families: %w", err)
+	}
+
+	runPeriodicSync(c.stopChan, doneWg, managementPortFamiliesRetryPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to configure the management port with the node %s subnets: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *managementPortFamiliesController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *managementPortFamiliesController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return fmt.Errorf("failed to parse the node subnets: %w", err)
+	}
+	return addManagementPortHostSubnets(c.routeManager, c.cfg, hostSubnets)
+}
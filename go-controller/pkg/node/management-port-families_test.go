@@ -0,0 +1,50 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Management port address families", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		config.IPv6Mode = false
+		util.SetFakeIPTablesHelpers()
+	})
+
+	It("ignores the node subnets of the families without cluster networks", func() {
+		v4Subnet := ovntest.MustParseIPNet("10.1.1.0/24")
+		v6Subnet := ovntest.MustParseIPNet("fda6:0:0:1::/64")
+		annotations, err := util.UpdateNodeHostSubnetAnnotation(map[string]string{}, []*net.IPNet{v4Subnet, v6Subnet},
+			types.DefaultNetworkName)
+		Expect(err).NotTo(HaveOccurred())
+		node := &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: annotations}}
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fake.NewSimpleClientset(node)}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		ipv4, err := newManagementPortIPFamilyConfig(v4Subnet, false)
+		Expect(err).NotTo(HaveOccurred())
+		cfg := &managementPortConfig{ifName: types.K8sMgmtIntfName, ipv4: ipv4}
+		c := newManagementPortFamiliesController("node1", wf, nil, cfg, nil)
+		Expect(c.sync()).To(Succeed())
+		Expect(cfg.ipv4).To(Equal(ipv4))
+		Expect(cfg.ipv6).To(BeNil())
+		Expect(cfg.isManagementPortIP(net.ParseIP("10.1.1.2"))).To(BeTrue())
+		Expect(cfg.isManagementPortIP(net.ParseIP("fda6:0:0:1::2"))).To(BeFalse())
+	})
+})
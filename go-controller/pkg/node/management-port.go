@@ -2,13 +2,13 @@ package node
 
 import (
 	"fmt"
+	"maps"
 	"net"
 	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
-	listers "k8s.io/client-go/listers/core/v1"
 
 	"k8s.io/klog/v2"
 
@@ -23,7 +23,7 @@ import (
 type ManagementPort interface {
 	// Create Management port, use annotator to update node annotation with management port details
 	// and waiter to set up condition to wait on for management port creation
-	Create(routeManager *routemanager.Controller, node *v1.Node, nodeLister listers.NodeLister, kubeInterface kube.Interface, waiter *startupWaiter) (*managementPortConfig, error)
+	Create(routeManager *routemanager.Controller, node *v1.Node, nodeAnnotator kube.Annotator, waiter *startupWaiter) (*managementPortConfig, error)
 	// CheckManagementPortHealth checks periodically for management port health until stopChan is posted
 	// or closed and reports any warnings/errors to log
 	CheckManagementPortHealth(routeManager *routemanager.Controller, cfg *managementPortConfig, stopChan chan struct{})
@@ -78,8 +78,25 @@ func newManagementPort(nodeName string, hostSubnets []*net.IPNet) ManagementPort
 	}
 }
 
+// setManagementPortMACAddress sets the MAC address of the default network management port in the node
+// annotations written with the other annotations of the node bring up. The MAC addresses of the other
+// networks are kept, their management ports are only created once the node is up.
+func setManagementPortMACAddress(nodeAnnotator kube.Annotator, node *v1.Node, macAddress net.HardwareAddr) error {
+	if current, err := util.ParseNodeManagementPortMACAddresses(node, types.DefaultNetworkName); err == nil &&
+		current.String() == macAddress.String() {
+		return nil
+	}
+	annotations, err := util.UpdateManagementPortMACAddressesAnnotation(maps.Clone(node.Annotations),
+		types.DefaultNetworkName, macAddress)
+	if err != nil {
+		return fmt.Errorf("failed to update node %q management port mac address annotation %s: %w",
+			node.Name, macAddress.String(), err)
+	}
+	return nodeAnnotator.Set(util.OvnNodeManagementPortMacAddresses, annotations[util.OvnNodeManagementPortMacAddresses])
+}
+
 func (mp *managementPort) Create(routeManager *routemanager.Controller, node *v1.Node,
-	nodeLister listers.NodeLister, kubeInterface kube.Interface, waiter *startupWaiter) (*managementPortConfig, error) {
+	nodeAnnotator kube.Annotator, waiter *startupWaiter) (*managementPortConfig, error) {
 	for _, mgmtPortName := range []string{types.K8sMgmtIntfName, types.K8sMgmtIntfName + "_0"} {
 		if err := syncMgmtPortInterface(mp.hostSubnets, mgmtPortName, true); err != nil {
 			return nil, fmt.Errorf("failed to sync management port: %v", err)
@@ -92,7 +109,7 @@ func (mp *managementPort) Create(routeManager *routemanager.Controller, node *v1
 		"--", "--if-exists", "del-port", "br-int", legacyMgmtIntfName,
 		"--", "--may-exist", "add-port", "br-int", types.K8sMgmtIntfName,
 		"--", "set", "interface", types.K8sMgmtIntfName,
-		"type=internal", "mtu_request="+fmt.Sprintf("%d", config.Default.MTU),
+		"type=internal", "mtu_request="+fmt.Sprintf("%d", mgmtPortMTU()),
 		"external-ids:iface-id="+types.K8sPrefix+mp.nodeName)
 	if err != nil {
 		klog.Errorf("Failed to add port to br-int, stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
@@ -117,7 +134,7 @@ func (mp *managementPort) Create(routeManager *routemanager.Controller, node *v1
 		return nil, err
 	}
 
-	if err := util.UpdateNodeManagementPortMACAddressesWithRetry(node, nodeLister, kubeInterface, macAddress, types.DefaultNetworkName); err != nil {
+	if err := setManagementPortMACAddress(nodeAnnotator, node, macAddress); err != nil {
 		return nil, err
 	}
 
@@ -74,7 +74,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 			netlinkOpsMock.On("LinkByName", "non-existent-netdev").Return(nil, fmt.Errorf("netlink mock error"))
 			netlinkOpsMock.On("IsLinkNotFoundError", mock.Anything).Return(false)
 
-			_, err := mgmtPortDpu.Create(nil, nil, nil, nil, waiter)
+			_, err := mgmtPortDpu.Create(nil, nil, nil, waiter)
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
 			Expect(err).To(HaveOccurred())
 		})
@@ -89,7 +89,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 				nil, fmt.Errorf("failed to get interface"))
 			netlinkOpsMock.On("IsLinkNotFoundError", mock.Anything).Return(true)
 
-			_, err := mgmtPortDpu.Create(nil, nil, nil, nil, waiter)
+			_, err := mgmtPortDpu.Create(nil, nil, nil, waiter)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -112,7 +112,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 			})
 			mockOVSListInterfaceMgmtPortNotExistCmd(execMock, types.K8sMgmtIntfName+"_0")
 
-			_, err := mgmtPortDpu.Create(nil, nil, nil, nil, waiter)
+			_, err := mgmtPortDpu.Create(nil, nil, nil, waiter)
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
 			Expect(err).To(HaveOccurred())
 		})
@@ -156,7 +156,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 				Cmd: genOVSAddMgmtPortCmd(mgmtPortDpu.nodeName, mgmtPortDpu.repName),
 			})
 
-			mpcfg, err := mgmtPortDpu.Create(nil, node, nil, nil, waiter)
+			mpcfg, err := mgmtPortDpu.Create(nil, node, nil, waiter)
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(mpcfg.ifName).To(Equal(types.K8sMgmtIntfName + "_0"))
@@ -195,7 +195,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 				Cmd: genOVSAddMgmtPortCmd(mgmtPortDpu.nodeName, mgmtPortDpu.repName),
 			})
 
-			mpcfg, err := mgmtPortDpu.Create(nil, node, nil, nil, waiter)
+			mpcfg, err := mgmtPortDpu.Create(nil, node, nil, waiter)
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(mpcfg.ifName).To(Equal(types.K8sMgmtIntfName + "_0"))
@@ -211,7 +211,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 			netlinkOpsMock.On("LinkByName", "non-existent-netdev").Return(nil, fmt.Errorf("netlink mock error"))
 			netlinkOpsMock.On("IsLinkNotFoundError", mock.Anything).Return(false)
 
-			_, err := mgmtPortDpuHost.Create(nil, nil, nil, nil, waiter)
+			_, err := mgmtPortDpuHost.Create(nil, nil, nil, waiter)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -225,7 +225,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 				nil, fmt.Errorf("failed to get interface"))
 			netlinkOpsMock.On("IsLinkNotFoundError", mock.Anything).Return(true)
 
-			_, err := mgmtPortDpuHost.Create(nil, nil, nil, nil, waiter)
+			_, err := mgmtPortDpuHost.Create(nil, nil, nil, waiter)
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -262,7 +262,7 @@ var _ = Describe("Mananagement port DPU tests", func() {
 			netlinkOpsMock.On("LinkByName", mock.Anything).Return(nil, fmt.Errorf(
 				"createPlatformManagementPort error"))
 
-			_, err = mgmtPortDpuHost.Create(nil, nil, nil, nil, nil)
+			_, err = mgmtPortDpuHost.Create(nil, nil, nil, nil)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("createPlatformManagementPort error"))
 		})
@@ -288,10 +288,54 @@ var _ = Describe("Mananagement port DPU tests", func() {
 			netlinkOpsMock.On("LinkByName", mock.Anything).Return(nil, fmt.Errorf(
 				"createPlatformManagementPort error")).Once()
 
-			_, err = mgmtPortDpuHost.Create(nil, nil, nil, nil, nil)
+			_, err = mgmtPortDpuHost.Create(nil, nil, nil, nil)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring(
 				"createPlatformManagementPort error"))
 		})
 	})
+
+	Context("Check Management port DPU health", func() {
+		It("Restores the management port from the representor found again after a VF-LAG failover", func() {
+			config.Default.MTU = 1400
+			mgmtPortDpu := managementPortRepresentor{
+				repName: "enp3s0f0v0",
+				lookupRepName: func() (string, error) {
+					return "enp3s0f1v0", nil
+				},
+			}
+			cfg := &managementPortConfig{ifName: types.K8sMgmtIntfName + "_0"}
+			linkMock := &mocks.Link{}
+			linkMock.On("Attrs").Return(&netlink.LinkAttrs{Name: "enp3s0f1v0", MTU: 1400})
+
+			netlinkOpsMock.On("LinkByName", types.K8sMgmtIntfName+"_0").Return(nil, fmt.Errorf("link not found"))
+			netlinkOpsMock.On("LinkByName", "enp3s0f0v0").Return(nil, fmt.Errorf("link not found"))
+			netlinkOpsMock.On("LinkByName", "enp3s0f1v0").Return(linkMock, nil)
+			netlinkOpsMock.On("LinkSetDown", linkMock).Return(nil)
+			netlinkOpsMock.On("LinkSetName", linkMock, types.K8sMgmtIntfName+"_0").Return(nil)
+			netlinkOpsMock.On("LinkSetUp", linkMock).Return(nil)
+			execMock.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=15 set interface " + types.K8sMgmtIntfName + "_0 external-ids:ovn-orig-mgmt-port-rep-name=enp3s0f1v0",
+			})
+
+			mgmtPortDpu.checkRepresentorPortHealth(cfg)
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
+			Expect(mgmtPortDpu.repName).To(Equal("enp3s0f1v0"))
+			Expect(cfg.link).To(Equal(linkMock))
+			netlinkOpsMock.AssertExpectations(GinkgoT())
+		})
+
+		It("Keeps the management port representor that can't be looked up again", func() {
+			mgmtPortDpu := managementPortRepresentor{
+				repName: "enp3s0f0v0",
+			}
+			cfg := &managementPortConfig{ifName: types.K8sMgmtIntfName}
+			netlinkOpsMock.On("LinkByName", types.K8sMgmtIntfName).Return(nil, fmt.Errorf("link not found"))
+			netlinkOpsMock.On("LinkByName", "enp3s0f0v0").Return(nil, fmt.Errorf("link not found"))
+
+			mgmtPortDpu.checkRepresentorPortHealth(cfg)
+			Expect(mgmtPortDpu.repName).To(Equal("enp3s0f0v0"))
+			Expect(cfg.link).To(BeNil())
+		})
+	})
 })
@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/go-iptables/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/vishvananda/netlink"
@@ -36,8 +39,14 @@ type managementPortConfig struct {
 	link      netlink.Link
 	routerMAC net.HardwareAddr
 
+	// RWMutex protects ipv4 and ipv6, the address family missing when the port is created is added once the
+	// node subnets have a subnet of the family
+	sync.RWMutex
 	ipv4 *managementPortIPFamilyConfig
 	ipv6 *managementPortIPFamilyConfig
+
+	// reconcilePaused stops the health check from repairing the port while the reconciliation of the node is paused
+	reconcilePaused atomic.Bool
 }
 
 func newManagementPortIPFamilyConfig(hostSubnet *net.IPNet, isIPv6 bool) (*managementPortIPFamilyConfig, error) {
@@ -234,11 +243,10 @@ func setupManagementPortIPFamilyConfig(routeManager *routemanager.Controller, mp
 	}
 
 	// IPv6 forwarding is enabled globally
-	if mpcfg.ipv4 != nil && cfg == mpcfg.ipv4 {
-		stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("net.ipv4.conf.%s.forwarding=1", types.K8sMgmtIntfName))
-		if err != nil || stdout != fmt.Sprintf("net.ipv4.conf.%s.forwarding = 1", types.K8sMgmtIntfName) {
-			return warnings, fmt.Errorf("could not set the correct forwarding value for interface %s: stdout: %v, stderr: %v, err: %v",
-				types.K8sMgmtIntfName, stdout, stderr, err)
+	if !utilnet.IsIPv6CIDR(cfg.ifAddr) {
+		if err := sysctlmanager.Set(fmt.Sprintf("net.ipv4.conf.%s.forwarding", types.K8sMgmtIntfName), "1"); err != nil {
+			return warnings, fmt.Errorf("could not set the correct forwarding value for interface %s: %v",
+				types.K8sMgmtIntfName, err)
 		}
 	}
 
@@ -281,18 +289,83 @@ func setupManagementPortConfig(routeManager *routemanager.Controller, cfg *manag
 	var warnings, allWarnings []string
 	var err error
 
-	if cfg.ipv4 != nil {
-		warnings, err = setupManagementPortIPFamilyConfig(routeManager, cfg, cfg.ipv4)
+	cfg.RLock()
+	ipv4, ipv6 := cfg.ipv4, cfg.ipv6
+	cfg.RUnlock()
+	if ipv4 != nil {
+		warnings, err = setupManagementPortIPFamilyConfig(routeManager, cfg, ipv4)
 		allWarnings = append(allWarnings, warnings...)
 	}
-	if cfg.ipv6 != nil && err == nil {
-		warnings, err = setupManagementPortIPFamilyConfig(routeManager, cfg, cfg.ipv6)
+	if ipv6 != nil && err == nil {
+		warnings, err = setupManagementPortIPFamilyConfig(routeManager, cfg, ipv6)
 		allWarnings = append(allWarnings, warnings...)
 	}
 
 	return allWarnings, err
 }
 
+// addManagementPortHostSubnets configures the management port with the address families of the host subnets it
+// lacks, i.e. added to the node subnets after the port creation when the cluster is converted from single-stack to
+// dual-stack. In local gateway mode the traffic of the new family leaving the host from the port is masqueraded too.
+func addManagementPortHostSubnets(routeManager *routemanager.Controller, cfg *managementPortConfig, hostSubnets []*net.IPNet) error {
+	for _, hostSubnet := range hostSubnets {
+		isIPv6 := utilnet.IsIPv6CIDR(hostSubnet)
+		cfg.RLock()
+		configured := (isIPv6 && cfg.ipv6 != nil) || (!isIPv6 && cfg.ipv4 != nil)
+		cfg.RUnlock()
+		if configured {
+			continue
+		}
+		family := "IPv4"
+		if isIPv6 {
+			family = "IPv6"
+		}
+		if (isIPv6 && !config.IPv6Mode) || (!isIPv6 && !config.IPv4Mode) {
+			klog.Warningf("Ignoring %s hostSubnet %s due to lack of %s cluster networks", family, hostSubnet, family)
+			continue
+		}
+		familyCfg, err := newManagementPortIPFamilyConfig(hostSubnet, isIPv6)
+		if err != nil {
+			return err
+		}
+		klog.Infof("Adding %s hostSubnet %s to management port %s", family, hostSubnet, cfg.ifName)
+		cfg.Lock()
+		if isIPv6 {
+			cfg.ipv6 = familyCfg
+		} else {
+			cfg.ipv4 = familyCfg
+		}
+		cfg.Unlock()
+
+		// the management port health check retries on failure
+		warnings, err := setupManagementPortIPFamilyConfig(routeManager, cfg, familyCfg)
+		for _, warning := range warnings {
+			klog.V(5).Info(warning)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to configure %s hostSubnet %s on management port %s: %w", family, hostSubnet,
+				cfg.ifName, err)
+		}
+		if config.Gateway.Mode == config.GatewayModeLocal {
+			cidr := &net.IPNet{IP: familyCfg.ifAddr.IP.Mask(familyCfg.ifAddr.Mask), Mask: familyCfg.ifAddr.Mask}
+			if err := initLocalGatewayNATRules(cfg.ifName, cidr); err != nil {
+				return fmt.Errorf("failed to add local NAT rules for: %s, err: %v", cfg.ifName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isManagementPortIP returns whether the address is the IP of the management port
+func (mpcfg *managementPortConfig) isManagementPortIP(addr net.IP) bool {
+	mpcfg.RLock()
+	defer mpcfg.RUnlock()
+	if utilnet.IsIPv4(addr) {
+		return mpcfg.ipv4 != nil && mpcfg.ipv4.ifAddr.IP.Equal(addr)
+	}
+	return mpcfg.ipv6 != nil && mpcfg.ipv6.ifAddr.IP.Equal(addr)
+}
+
 // createPlatformManagementPort creates a management port attached to the node switch
 // that lets the node access its pods via their private IP address. This is used
 // for health checking and other management tasks.
@@ -479,6 +552,9 @@ func DelMgtPortIptRules() {
 // 2. ARP entry for the node subnet's gateway ip
 // 3. IPtables chain and rule for SNATing packets entering the logical topology
 func checkManagementPortHealth(routeManager *routemanager.Controller, cfg *managementPortConfig) {
+	if cfg.reconcilePaused.Load() {
+		return
+	}
 	warnings, err := setupManagementPortConfig(routeManager, cfg)
 	for _, warning := range warnings {
 		klog.Warningf(warning)
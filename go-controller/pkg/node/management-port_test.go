@@ -1,7 +1,10 @@
 package node
 
 import (
+	"fmt"
 	"reflect"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -52,4 +55,48 @@ var _ = Describe("Mananagement port tests", func() {
 			Expect(port.repName).To(Equal("ens1f0_0"))
 		})
 	})
+
+	Context("createManagementPorts creates the management ports in parallel", func() {
+		netdev, rep := &managementPortNetdev{netdevName: "ens1f0v0"}, &managementPortRepresentor{repName: "ens1f0_0"}
+
+		It("returns the created ports in their order", func() {
+			// the representor is only created once the netdevice creation started
+			netdevStarted := make(chan struct{})
+			mgmtPorts, err := createManagementPorts([]ManagementPort{netdev, rep}, time.Minute, func(port ManagementPort) (*managementPortConfig, error) {
+				if port == netdev {
+					close(netdevStarted)
+					return &managementPortConfig{ifName: "netdev"}, nil
+				}
+				<-netdevStarted
+				return &managementPortConfig{ifName: "rep"}, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mgmtPorts).To(Equal([]managementPortEntry{
+				{port: netdev, config: &managementPortConfig{ifName: "netdev"}},
+				{port: rep, config: &managementPortConfig{ifName: "rep"}},
+			}))
+		})
+
+		It("returns the errors of all the failed ports", func() {
+			_, err := createManagementPorts([]ManagementPort{netdev, rep}, time.Minute, func(port ManagementPort) (*managementPortConfig, error) {
+				return nil, fmt.Errorf("%s not found", reflect.TypeOf(port).Elem().Name())
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to create management port *node.managementPortNetdev: managementPortNetdev not found"))
+			Expect(err.Error()).To(ContainSubstring("failed to create management port *node.managementPortRepresentor: managementPortRepresentor not found"))
+		})
+
+		It("fails a port not created before the timeout once its creation returned", func() {
+			var returned atomic.Bool
+			_, err := createManagementPorts([]ManagementPort{netdev, rep}, 100*time.Millisecond, func(port ManagementPort) (*managementPortConfig, error) {
+				if port == rep {
+					time.Sleep(300 * time.Millisecond)
+					returned.Store(true)
+				}
+				return &managementPortConfig{}, nil
+			})
+			Expect(err).To(MatchError("timed out after 100ms creating management port *node.managementPortRepresentor"))
+			Expect(returned.Load()).To(BeTrue())
+		})
+	})
 })
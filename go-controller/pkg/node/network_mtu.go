@@ -0,0 +1,80 @@
+package node
+
+import (
+	"fmt"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// networkMTUUnsupportedEventReason is the reason of the node events reporting a secondary network
+// whose MTU can't be carried by the provider interface of the node
+const networkMTUUnsupportedEventReason = "NetworkMTUUnsupported"
+
+// validateNetworkMTU checks that the provider interfaces of the node can carry the traffic of the pods of
+// the network at the MTU of its NetworkAttachmentDefinition, which the CNI server applies to the pod
+// interfaces. The traffic of a localnet network leaves through the bridge of its physical network, the
+// traffic of the overlay networks through the tunnel interfaces. An MTU the node can't satisfy is reported
+// as a node event and returns false.
+func (nc *SecondaryNodeNetworkController) validateNetworkMTU() (bool, error) {
+	mtu := nc.MTU()
+	if mtu == 0 {
+		return true, nil
+	}
+	if nc.TopologyType() == types.LocalnetTopology {
+		bridgeMappings, err := util.GetOVNBridgeMappings()
+		if err != nil {
+			return false, err
+		}
+		bridge, ok := bridgeMappings[nc.PhysicalNetworkName()]
+		if !ok {
+			// reported when mapping the physical network
+			return true, nil
+		}
+		bridgeMTU, err := getLinkMTU(bridge)
+		if err != nil {
+			return false, err
+		}
+		if bridgeMTU < mtu {
+			nc.reportNetworkMTUUnsupported("MTU (%d) of network %s is larger than the MTU (%d) of bridge %s of physical network %s",
+				mtu, nc.GetNetworkName(), bridgeMTU, bridge, nc.PhysicalNetworkName())
+			return false, nil
+		}
+		return true, nil
+	}
+
+	ovnEncapIPs, err := util.ParseEncapIPs(config.Default.EncapIP)
+	if err != nil {
+		return false, fmt.Errorf("invalid encap-ip setting: %w", err)
+	}
+	perFamilyEndpoints, _ := utilnet.IsDualStackIPs(ovnEncapIPs)
+	for _, ovnEncapIP := range ovnEncapIPs {
+		interfaceName, ifMTU, err := util.GetIFNameAndMTUForAddress(ovnEncapIP)
+		if err != nil {
+			return false, fmt.Errorf("could not get MTU for the interface with address %s: %w", ovnEncapIP, err)
+		}
+		if requiredMTU := requiredVTEPInterfaceMTU(mtu, ovnEncapIP, perFamilyEndpoints); ifMTU < requiredMTU {
+			nc.reportNetworkMTUUnsupported("MTU (%d) of network interface %s is too small for MTU (%d) of network %s, it requires %d",
+				ifMTU, interfaceName, mtu, nc.GetNetworkName(), requiredMTU)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// reportNetworkMTUUnsupported logs and reports an MTU of a secondary network the node can't satisfy as a node event
+func (nc *SecondaryNodeNetworkController) reportNetworkMTUUnsupported(messageFmt string, args ...interface{}) {
+	klog.Warningf(messageFmt, args...)
+	nodeRef := &kapi.ObjectReference{
+		Kind: "Node",
+		Name: nc.name,
+		UID:  ktypes.UID(nc.name),
+	}
+	nc.recorder.Eventf(nodeRef, kapi.EventTypeWarning, networkMTUUnsupportedEventReason, messageFmt, args...)
+}
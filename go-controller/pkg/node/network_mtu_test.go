@@ -0,0 +1,127 @@
+package node
+
+import (
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"k8s.io/client-go/tools/record"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+var _ = Describe("Secondary network MTU", func() {
+	var (
+		fexec           *ovntest.FakeExec
+		recorder        *record.FakeRecorder
+		netlinkOpsMock  *utilMocks.NetLinkOps
+		netlinkLinkMock *netlink_mocks.Link
+	)
+
+	newController := func(netconf *ovncnitypes.NetConf) *SecondaryNodeNetworkController {
+		netInfo, err := util.NewNetInfo(netconf)
+		Expect(err).NotTo(HaveOccurred())
+		return &SecondaryNodeNetworkController{
+			BaseNodeNetworkController: BaseNodeNetworkController{
+				CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{
+					name:     "node1",
+					recorder: recorder,
+				},
+				NetInfo: netInfo,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+		netlinkOpsMock = new(utilMocks.NetLinkOps)
+		netlinkLinkMock = new(netlink_mocks.Link)
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+	})
+
+	AfterEach(func() {
+		util.ResetNetLinkOpMockInst()
+	})
+
+	Context("of a localnet network", func() {
+		BeforeEach(func() {
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+				Output: types.PhysicalNetworkName + ":breth0,tenantred:br-red",
+			})
+			netlinkOpsMock.On("LinkByName", "br-red").Return(netlinkLinkMock, nil)
+			netlinkLinkMock.On("Attrs").Return(&netlink.LinkAttrs{Name: "br-red", MTU: 9000})
+		})
+
+		It("is supported by the bridge of the physical network", func() {
+			nc := newController(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantred"},
+				Topology: types.LocalnetTopology,
+				MTU:      9000,
+			})
+			valid, err := nc.validateNetworkMTU()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeTrue())
+			Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("is reported as a node event when larger than the MTU of the bridge", func() {
+			nc := newController(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantred"},
+				Topology: types.LocalnetTopology,
+				MTU:      9216,
+			})
+			valid, err := nc.validateNetworkMTU()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeFalse())
+			Expect(recorder.Events).To(Receive(ContainSubstring(networkMTUUnsupportedEventReason)))
+		})
+	})
+
+	Context("of an overlay network", func() {
+		BeforeEach(func() {
+			config.IPv4Mode = true
+			config.Default.EncapIP = "10.1.0.40"
+			netlinkOpsMock.On("AddrList", nil, netlink.FAMILY_V4).
+				Return([]netlink.Addr{{LinkIndex: 4, IPNet: ovntest.MustParseIPNet("10.1.0.40/32")}}, nil)
+			netlinkOpsMock.On("LinkByIndex", 4).Return(netlinkLinkMock, nil)
+			netlinkLinkMock.On("Attrs").Return(&netlink.LinkAttrs{Name: "eth0", MTU: 1500})
+		})
+
+		It("is reported as a node event when the tunnel interface can't carry it", func() {
+			nc := newController(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantblue"},
+				Topology: types.Layer2Topology,
+				Subnets:  "192.168.100.0/24",
+				MTU:      1500,
+			})
+			valid, err := nc.validateNetworkMTU()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeFalse())
+			Expect(recorder.Events).To(Receive(ContainSubstring("too small for MTU (1500) of network tenantblue")))
+		})
+
+		It("is supported by the tunnel interface with the Geneve header", func() {
+			nc := newController(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantblue"},
+				Topology: types.Layer2Topology,
+				Subnets:  "192.168.100.0/24",
+				MTU:      1500 - types.GeneveHeaderLengthIPv4,
+			})
+			valid, err := nc.validateNetworkMTU()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeTrue())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,142 @@
+package node
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// nodeAnnotationPruningPeriod is how often the obsolete annotations of the node are pruned
+const nodeAnnotationPruningPeriod = time.Hour
+
+// featureNodeAnnotation is a set of node annotations ovnkube-node writes for a feature, stale while the
+// feature is disabled
+type featureNodeAnnotation struct {
+	enabled func() bool
+	keys    []string
+}
+
+// featureNodeAnnotations are the node annotations of the features by the name they are cleaned up with. Only the
+// annotations the node admission webhook allows ovnkube-node to change whatever the features enabled can be
+// listed here, the webhook rejects the pruning of the others.
+var featureNodeAnnotations = map[string]featureNodeAnnotation{
+	"egress-ip": {
+		enabled: func() bool { return config.OVNKubernetesFeature.EnableEgressIP },
+		keys:    []string{util.OVNNodeSecondaryHostEgressIPs},
+	},
+	"zone-migration": {
+		enabled: func() bool { return config.OVNKubernetesFeature.EnableInterconnect },
+		keys:    []string{util.OvnNodeZoneMigrationStatus},
+	},
+	"ovs-upgrade-quiesce": {
+		enabled: func() bool { return config.OvnKubeNode.Mode == types.NodeModeFull },
+		keys:    []string{util.OvnNodeOVSUpgradeQuiesceStatus},
+	},
+	"dpu": {
+		enabled: func() bool { return config.OvnKubeNode.Mode != types.NodeModeFull },
+		keys:    []string{util.DPUGatewayIntentAnnot},
+	},
+}
+
+// nodeAnnotationPruner removes the annotations of the node written by former versions of ovn-kubernetes and by
+// the features disabled on the node. An annotation set again after it was pruned is still written by a component
+// of an older version during an upgrade, it is left on the node until ovnkube-node restarts.
+type nodeAnnotationPruner struct {
+	nodeName     string
+	kube         kube.Interface
+	watchFactory factory.NodeWatchFactory
+	// version is the version of ovn-kubernetes run by the node
+	version string
+
+	// pruned are the annotations pruned since the start
+	pruned sets.Set[string]
+	// rewritten are the annotations set again after they were pruned
+	rewritten sets.Set[string]
+}
+
+func newNodeAnnotationPruner(nodeName string, kube kube.Interface, watchFactory factory.NodeWatchFactory) *nodeAnnotationPruner {
+	return &nodeAnnotationPruner{
+		nodeName:     nodeName,
+		kube:         kube,
+		watchFactory: watchFactory,
+		version:      config.Version,
+		pruned:       sets.New[string](),
+		rewritten:    sets.New[string](),
+	}
+}
+
+func (p *nodeAnnotationPruner) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, nodeAnnotationPruningPeriod, nil, func() {
+		if err := p.sync(); err != nil {
+			klog.Errorf("Failed to prune the obsolete annotations of node %s: %v", p.nodeName, err)
+		}
+	})
+}
+
+// staleAnnotations returns the annotations of the former versions up to the running one and of the disabled
+// features
+func (p *nodeAnnotationPruner) staleAnnotations() sets.Set[string] {
+	stale := sets.New[string]()
+	current, err := version.ParseGeneric(p.version)
+	if err != nil {
+		klog.V(5).Infof("Not pruning the annotations of the former versions from node %s, version %q: %v",
+			p.nodeName, p.version, err)
+	}
+	for obsoleteVersion, keys := range util.ObsoleteNodeAnnotations {
+		if current != nil && current.AtLeast(version.MustParseGeneric(obsoleteVersion)) {
+			stale.Insert(keys...)
+		}
+	}
+	for _, feature := range featureNodeAnnotations {
+		if !feature.enabled() {
+			stale.Insert(feature.keys...)
+		}
+	}
+	return stale
+}
+
+func (p *nodeAnnotationPruner) sync() error {
+	node, err := p.watchFactory.GetNode(p.nodeName)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for key := range p.staleAnnotations() {
+		if _, ok := node.Annotations[key]; !ok {
+			continue
+		}
+		if p.pruned.Has(key) {
+			if !p.rewritten.Has(key) {
+				klog.Warningf("Annotation %s of node %s was set again after it was pruned, leaving it to the "+
+					"component still writing it", key, p.nodeName)
+				p.rewritten.Insert(key)
+			}
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	annotator := newNodeAnnotator(p.kube, p.nodeName)
+	for _, key := range keys {
+		annotator.Delete(key)
+	}
+	if err := annotator.Run(); err != nil {
+		return err
+	}
+	klog.Infof("Pruned the obsolete annotations %v of node %s", keys, p.nodeName)
+	p.pruned.Insert(keys...)
+	return nil
+}
@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node annotation pruning", func() {
+	var (
+		client *fake.Clientset
+		wf     *factory.WatchFactory
+		pruner *nodeAnnotationPruner
+	)
+
+	getAnnotations := func() map[string]string {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return node.Annotations
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableEgressIP = false
+		config.OVNKubernetesFeature.EnableInterconnect = true
+		client = fake.NewSimpleClientset(&kapi.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Annotations: map[string]string{
+				types.OvnK8sTopoAnno:                     "7",
+				util.OVNNodeSecondaryHostEgressIPs:       "[]",
+				util.OvnNodeZoneMigrationStatus:          "{}",
+				util.OvnNodeZoneName:                     "global",
+				"k8s.ovn.org/some-other-annotation":      "kept",
+				"k8s.ovn.org/node-mgmt-port-mac-address": "fa:f1:27:f5:54:69",
+			},
+		}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: client}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		pruner = newNodeAnnotationPruner("node1", &kube.Kube{KClient: client}, wf)
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	It("prunes the annotations of the former versions and of the disabled features", func() {
+		Expect(pruner.sync()).To(Succeed())
+		Expect(getAnnotations()).To(Equal(map[string]string{
+			util.OvnNodeZoneMigrationStatus:     "{}",
+			util.OvnNodeZoneName:                "global",
+			"k8s.ovn.org/some-other-annotation": "kept",
+		}))
+	})
+
+	It("keeps the annotations of the former versions on a node running an older version", func() {
+		pruner.version = "0.9.0"
+		Expect(pruner.sync()).To(Succeed())
+		Expect(getAnnotations()).To(HaveKey(types.OvnK8sTopoAnno))
+		Expect(getAnnotations()).NotTo(HaveKey(util.OVNNodeSecondaryHostEgressIPs))
+	})
+
+	It("leaves an annotation set again after it was pruned", func() {
+		Expect(pruner.sync()).To(Succeed())
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		node.Annotations[types.OvnK8sTopoAnno] = "7"
+		_, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			node, err := wf.GetNode("node1")
+			return err == nil && node.Annotations[types.OvnK8sTopoAnno] == "7"
+		}).Should(BeTrue())
+
+		Expect(pruner.sync()).To(Succeed())
+		Expect(getAnnotations()).To(HaveKeyWithValue(types.OvnK8sTopoAnno, "7"))
+	})
+})
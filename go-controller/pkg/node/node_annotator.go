@@ -0,0 +1,154 @@
+package node
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientretry "k8s.io/client-go/util/retry"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// nodeAnnotationFieldManager is the field manager of the node annotations written with a server-side apply
+// that are not owned by a subsystem
+const nodeAnnotationFieldManager = "ovnkube-node"
+
+// nodeAnnotationOwners are the field managers of the subsystems of ovnkube-node writing node annotations
+// with a server-side apply and the annotations each of them manages. An annotation removed from its
+// subsystem is pruned from the node the next time the subsystem writes its annotations.
+var nodeAnnotationOwners = map[string]sets.Set[string]{
+	"ovnkube-node-zone": sets.New[string](
+		util.OvnNodeZoneName,
+		util.OvnNodeMigratedZoneName,
+		util.OvnNodeZoneMigrationStatus,
+		util.OvnNodeEncapIp,
+	),
+	"ovnkube-node-gateway": sets.New[string](
+		util.OvnNodeL3GatewayConfig,
+		util.OvnNodeChassisID,
+		util.OvnNodeIfAddr,
+		"k8s.ovn.org/node-primary-ifaddr-dpf",
+		util.OVNNodeHostCIDRs,
+		util.OvnNodeGatewayMtuSupport,
+	),
+	"ovnkube-node-mgmt-port": sets.New[string](
+		util.OvnNodeManagementPort,
+	),
+	"ovnkube-node-interconnect": sets.New[string](
+		util.OvnNodeZoneReachability,
+	),
+	"ovnkube-node-dpu": sets.New[string](
+		util.DPUGatewayIntentAnnot,
+	),
+	"ovnkube-node-ovs-upgrade": sets.New[string](
+		util.OvnNodeOVSUpgradeQuiesceStatus,
+	),
+	"ovnkube-node-chassis": sets.New[string](
+		util.OvnNodeChassisInfo,
+	),
+}
+
+// newNodeAnnotator returns the annotator of the node annotations written by ovnkube-node: the writes
+// failing with a transient API error are retried, and the attempts are reported in metrics. The writes wait
+// for the API write throttle of the node when it is configured.
+// With server-side apply, each annotation is applied by the field manager of the subsystem owning it and
+// an annotation applied by another field manager is returned as a conflict right away.
+func newNodeAnnotator(k kube.Interface, nodeName string) kube.Annotator {
+	opts := kube.NodeAnnotatorOptions{
+		Backoff: &clientretry.DefaultBackoff,
+		OnWrite: func(attempt int, err error) {
+			if attempt > 1 {
+				metrics.MetricNodeAnnotationWriteRetries.Inc()
+			}
+			result := "success"
+			if kerrors.IsConflict(err) {
+				result = "conflict"
+			} else if err != nil {
+				result = "error"
+			}
+			metrics.MetricNodeAnnotationWrites.WithLabelValues(result).Inc()
+		},
+	}
+	if throttle := util.NodeAPIWriteThrottle(nodeName); throttle != nil {
+		opts.Backoff = throttle.Backoff()
+		opts.BeforeWrite = func() error { return throttle.Wait(context.TODO()) }
+	}
+	if !config.OvnKubeNode.AnnotationServerSideApply {
+		return kube.NewNodeAnnotatorWithOptions(k, nodeName, opts)
+	}
+	return &subsystemNodeAnnotator{
+		kube:       k,
+		nodeName:   nodeName,
+		opts:       opts,
+		annotators: map[string]kube.Annotator{},
+	}
+}
+
+// subsystemNodeAnnotator dispatches the node annotations to the annotators of the field managers of the
+// subsystems owning them
+type subsystemNodeAnnotator struct {
+	kube     kube.Interface
+	nodeName string
+	opts     kube.NodeAnnotatorOptions
+
+	// annotators are the annotators of the field managers with changes
+	annotators map[string]kube.Annotator
+	sync.Mutex
+}
+
+func (a *subsystemNodeAnnotator) annotator(key string) kube.Annotator {
+	a.Lock()
+	defer a.Unlock()
+	fieldManager := nodeAnnotationFieldManager
+	var owned sets.Set[string]
+	for manager, annotations := range nodeAnnotationOwners {
+		if annotations.Has(key) {
+			fieldManager = manager
+			owned = annotations
+			break
+		}
+	}
+	if annotator, ok := a.annotators[fieldManager]; ok {
+		return annotator
+	}
+	opts := a.opts
+	opts.FieldManager = fieldManager
+	opts.OwnedAnnotations = owned
+	annotator := kube.NewNodeAnnotatorWithOptions(a.kube, a.nodeName, opts)
+	a.annotators[fieldManager] = annotator
+	return annotator
+}
+
+func (a *subsystemNodeAnnotator) Set(key string, value interface{}) error {
+	return a.annotator(key).Set(key, value)
+}
+
+func (a *subsystemNodeAnnotator) Delete(key string) {
+	a.annotator(key).Delete(key)
+}
+
+// Run applies the annotations of every field manager with changes, all of them are applied even if
+// one fails
+func (a *subsystemNodeAnnotator) Run() error {
+	a.Lock()
+	defer a.Unlock()
+	fieldManagers := make([]string, 0, len(a.annotators))
+	for fieldManager := range a.annotators {
+		fieldManagers = append(fieldManagers, fieldManager)
+	}
+	sort.Strings(fieldManagers)
+	var errs []error
+	for _, fieldManager := range fieldManagers {
+		if err := a.annotators[fieldManager].Run(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node annotator", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	It("has a single owner per annotation", func() {
+		owned := sets.New[string]()
+		for fieldManager, annotations := range nodeAnnotationOwners {
+			Expect(owned.Intersection(annotations).UnsortedList()).To(BeEmpty(), fieldManager)
+			owned.Insert(annotations.UnsortedList()...)
+		}
+	})
+
+	It("writes the annotations with a merge patch without server-side apply", func() {
+		_, ok := newNodeAnnotator(&kube.Kube{}, "node1").(*subsystemNodeAnnotator)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("dispatches the annotations to the field managers of their subsystems", func() {
+		config.OvnKubeNode.AnnotationServerSideApply = true
+		client := fake.NewSimpleClientset(&kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+		annotator, ok := newNodeAnnotator(&kube.Kube{KClient: client}, "node1").(*subsystemNodeAnnotator)
+		Expect(ok).To(BeTrue())
+
+		Expect(util.SetNodeZone(annotator, "zone-a")).To(Succeed())
+		Expect(util.SetNodeEncapIp(annotator, "10.0.0.1")).To(Succeed())
+		Expect(util.SetGatewayMTUSupport(annotator, false)).To(Succeed())
+		Expect(annotator.Set("k8s.ovn.org/unknown", "value")).To(Succeed())
+		Expect(sets.KeySet(annotator.annotators)).To(Equal(sets.New[string](
+			"ovnkube-node-zone", "ovnkube-node-gateway", nodeAnnotationFieldManager)))
+	})
+})
@@ -0,0 +1,56 @@
+package node
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// ovnNodeExternalIDs are the Open_vSwitch external_ids ovnkube-node sets for ovn-controller
+var ovnNodeExternalIDs = []string{
+	"ovn-encap-type",
+	"ovn-encap-ip",
+	"hostname",
+	"ovn-is-interconn",
+	"ovn-monitor-all",
+	"ovn-ofctrl-wait-before-clear",
+	"ovn-enable-lflow-cache",
+	"ovn-set-local-ip",
+	"ovn-limit-lflow-cache",
+	"ovn-memlimit-lflow-cache-kb",
+	"ovn-remote-probe-interval",
+	"ovn-openflow-probe-interval",
+}
+
+// cleanupOVNNodeExternalIDs removes the Open_vSwitch external_ids set by ovnkube-node
+func cleanupOVNNodeExternalIDs() error {
+	args := append([]string{"--", "--if-exists", "remove", "Open_vSwitch", ".", "external_ids"}, ovnNodeExternalIDs...)
+	if stdout, stderr, err := util.RunOVSVsctl(args...); err != nil {
+		return fmt.Errorf("failed to delete the OVS external_ids, stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
+	}
+	return nil
+}
+
+// deleteManagementPort deletes the management port OVS internal interface from the integration bridge
+func deleteManagementPort() error {
+	if _, stderr, err := util.RunOVSVsctl("--", "--if-exists", "del-port", "br-int", types.K8sMgmtIntfName); err != nil {
+		return fmt.Errorf("failed to delete port %s, stderr: %q, error: %v", types.K8sMgmtIntfName, stderr, err)
+	}
+	return nil
+}
+
+// restoreGatewayUplink moves the IP addresses and routes of the gateway bridge back to its uplink and deletes
+// the bridge, undoing NicToBridge
+func restoreGatewayUplink(bridgeName string) error {
+	if bridgeName == "" {
+		return nil
+	}
+	if err := util.BridgeToNic(bridgeName); err != nil {
+		return fmt.Errorf("failed to move the addresses and routes of bridge %s back to its uplink: %w", bridgeName, err)
+	}
+	klog.Infof("Moved the addresses and routes of bridge %s back to its uplink", bridgeName)
+	return nil
+}
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+var _ = Describe("Node cleanup", func() {
+	origNetlinkInst := util.GetNetLinkOps()
+
+	AfterEach(func() {
+		util.SetNetLinkOpMockInst(origNetlinkInst)
+	})
+
+	It("removes the management port, the OVS external_ids, the iptables chains and the CNI config", func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		confDir, err := os.MkdirTemp("", "cni-conf")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(confDir)
+		config.CNI.ConfDir = confDir
+		Expect(config.WriteCNIConfig()).To(Succeed())
+
+		fexec := ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 -- --if-exists remove Open_vSwitch . external_ids ovn-bridge-mappings",
+			"ovs-vsctl --timeout=15 --if-exists del-br br-ext",
+			"ovs-vsctl --timeout=15 --if-exists del-port br-int int",
+			"ovs-vsctl --timeout=15 -- --if-exists del-port br-int ovn-k8s-mp0",
+			"ovs-vsctl --timeout=15 -- --if-exists remove Open_vSwitch . external_ids ovn-encap-type ovn-encap-ip " +
+				"hostname ovn-is-interconn ovn-monitor-all ovn-ofctrl-wait-before-clear ovn-enable-lflow-cache " +
+				"ovn-set-local-ip ovn-limit-lflow-cache ovn-memlimit-lflow-cache-kb ovn-remote-probe-interval " +
+				"ovn-openflow-probe-interval",
+		})
+
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+		Expect(iptV4.NewChain("nat", "PREROUTING")).To(Succeed())
+		Expect(iptV4.NewChain("nat", iptableNodePortChain)).To(Succeed())
+		Expect(iptV4.Append("nat", "PREROUTING", "-j", iptableNodePortChain)).To(Succeed())
+
+		netlinkMock := &mocks.NetLinkOps{}
+		util.SetNetLinkOpMockInst(netlinkMock)
+		netlinkMock.On("RuleListFiltered", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+		Expect(CleanupClusterNode("node1")).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		var exists bool
+		exists, err = iptV4.Exists("nat", "PREROUTING", "-j", iptableNodePortChain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+		Expect(iptV4.ListChains("nat")).NotTo(ContainElement(iptableNodePortChain))
+		_, err = os.Stat(filepath.Join(config.CNI.ConfDir, config.CNIConfFileName))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
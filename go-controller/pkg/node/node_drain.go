@@ -0,0 +1,161 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// nodeDrainSyncPeriod is how often the connections through the gateway of a cordoned node are counted
+const nodeDrainSyncPeriod = 10 * time.Second
+
+var conntrackTotalRe = regexp.MustCompile(`(?m)^\s*Total:\s*(\d+)`)
+
+// nodeDrainCoordinator stops a cordoned node from taking new connections while it lets the established ones
+// drain: the node fails its health checks right away so that the load balancers stop sending it NodePort and
+// LoadBalancer traffic, the gateway flows are kept, and the egress IP health checks fail once the gateway
+// conntrack zone is empty or the drain timeout elapses, so that the egress IPs move to other nodes last
+type nodeDrainCoordinator struct {
+	nodeName      string
+	watchFactory  factory.NodeWatchFactory
+	healthz       *proxierHealthUpdater
+	egressServers []healthcheck.EgressIPHealthServer
+	timeout       time.Duration
+	c             clock.Clock
+	// countConnections returns the number of connections tracked in the gateway conntrack zone
+	countConnections func() (int, error)
+	trigger          chan struct{}
+
+	// drainStart is when the node was seen cordoned, zero when it is not
+	drainStart time.Time
+	// drained is set once the egress IPs are no longer served
+	drained bool
+}
+
+func newNodeDrainCoordinator(nodeName string, watchFactory factory.NodeWatchFactory, healthz *proxierHealthUpdater,
+	egressServers []healthcheck.EgressIPHealthServer, timeout time.Duration) *nodeDrainCoordinator {
+	return &nodeDrainCoordinator{
+		nodeName:         nodeName,
+		watchFactory:     watchFactory,
+		healthz:          healthz,
+		egressServers:    egressServers,
+		timeout:          timeout,
+		c:                clock.RealClock{},
+		countConnections: countGatewayConnections,
+		trigger:          make(chan struct{}, 1),
+	}
+}
+
+func (d *nodeDrainCoordinator) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	_, err := d.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == d.nodeName && oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable {
+				d.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the node drain: %w", err)
+	}
+
+	runPeriodicSync(stopChan, doneWg, nodeDrainSyncPeriod, d.trigger, func() {
+		if err := d.sync(); err != nil {
+			klog.Errorf("Failed to sync the drain of node %s: %v", d.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (d *nodeDrainCoordinator) requestSync() {
+	select {
+	case d.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (d *nodeDrainCoordinator) sync() error {
+	node, err := d.watchFactory.GetNode(d.nodeName)
+	if err != nil {
+		return err
+	}
+	if !node.Spec.Unschedulable {
+		if !d.drainStart.IsZero() {
+			klog.Infof("Node %s is uncordoned, serving its health checks and egress IPs again", d.nodeName)
+			d.setDraining(false)
+			d.setEgressServing(true)
+			d.drainStart = time.Time{}
+			d.drained = false
+		}
+		return nil
+	}
+	if d.drainStart.IsZero() {
+		klog.Infof("Node %s is cordoned, failing its health checks to drain the connections through its gateway", d.nodeName)
+		d.drainStart = d.c.Now()
+		d.setDraining(true)
+	}
+	if d.drained {
+		return nil
+	}
+	if elapsed := d.c.Since(d.drainStart); elapsed < d.timeout {
+		count, err := d.countConnections()
+		if err != nil {
+			return fmt.Errorf("failed to count the gateway connections: %w", err)
+		}
+		if count > 0 {
+			klog.V(5).Infof("Node %s is draining, %d gateway connections left after %v", d.nodeName, count, elapsed)
+			return nil
+		}
+		klog.Infof("The gateway connections of node %s are drained, no longer serving its egress IPs", d.nodeName)
+	} else {
+		klog.Infof("The gateway connections of node %s did not drain within %v, no longer serving its egress IPs",
+			d.nodeName, d.timeout)
+	}
+	d.setEgressServing(false)
+	d.drained = true
+	return nil
+}
+
+func (d *nodeDrainCoordinator) setDraining(draining bool) {
+	if d.healthz != nil {
+		d.healthz.SetDraining(draining)
+	}
+}
+
+func (d *nodeDrainCoordinator) setEgressServing(serving bool) {
+	for _, s := range d.egressServers {
+		s.SetServing(serving)
+	}
+}
+
+// countGatewayConnections returns the number of connections tracked by OVS in the gateway conntrack zone
+func countGatewayConnections() (int, error) {
+	stdout, stderr, err := util.RunOVSAppctl("dpctl/ct-stats-show", fmt.Sprintf("zone=%d", config.Default.ConntrackZone))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the conntrack statistics of zone %d, stderr: %q, error: %v",
+			config.Default.ConntrackZone, stderr, err)
+	}
+	return parseConntrackTotal(stdout)
+}
+
+func parseConntrackTotal(stats string) (int, error) {
+	match := conntrackTotalRe.FindStringSubmatch(stats)
+	if match == nil {
+		// no connection is tracked in the zone
+		return 0, nil
+	}
+	return strconv.Atoi(match[1])
+}
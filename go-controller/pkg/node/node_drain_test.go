@@ -0,0 +1,111 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+type fakeEgressIPHealthServer struct {
+	serving bool
+}
+
+func (s *fakeEgressIPHealthServer) Run(<-chan struct{}) {}
+
+func (s *fakeEgressIPHealthServer) SetServing(serving bool) {
+	s.serving = serving
+}
+
+var _ = Describe("Node drain coordination", func() {
+	var (
+		client      *fake.Clientset
+		wf          *factory.WatchFactory
+		fakeClock   *clocktesting.FakeClock
+		healthz     *proxierHealthUpdater
+		eipServer   *fakeEgressIPHealthServer
+		connections int
+		coordinator *nodeDrainCoordinator
+	)
+
+	setUnschedulable := func(unschedulable bool) {
+		node, err := client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		node.Spec.Unschedulable = unschedulable
+		_, err = client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			node, err := wf.GetNode("node1")
+			return err == nil && node.Spec.Unschedulable == unschedulable
+		}).Should(BeTrue())
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		client = fake.NewSimpleClientset(&kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: client}, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+
+		fakeClock = clocktesting.NewFakeClock(time.Now())
+		healthz = &proxierHealthUpdater{c: fakeClock}
+		eipServer = &fakeEgressIPHealthServer{serving: true}
+		connections = 3
+		coordinator = newNodeDrainCoordinator("node1", wf, healthz, []healthcheck.EgressIPHealthServer{eipServer}, time.Minute)
+		coordinator.c = fakeClock
+		coordinator.countConnections = func() (int, error) { return connections, nil }
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	It("stops serving the egress IPs once the gateway connections are drained", func() {
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(healthz.draining).To(BeFalse())
+
+		setUnschedulable(true)
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(healthz.draining).To(BeTrue())
+		Expect(healthz.isOvnkNodePodHealthy()).To(BeFalse())
+		Expect(eipServer.serving).To(BeTrue())
+
+		connections = 0
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(eipServer.serving).To(BeFalse())
+
+		setUnschedulable(false)
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(healthz.draining).To(BeFalse())
+		Expect(eipServer.serving).To(BeTrue())
+	})
+
+	It("stops serving the egress IPs when the drain times out", func() {
+		setUnschedulable(true)
+		Expect(coordinator.sync()).To(Succeed())
+		fakeClock.Step(30 * time.Second)
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(eipServer.serving).To(BeTrue())
+
+		fakeClock.Step(30 * time.Second)
+		Expect(coordinator.sync()).To(Succeed())
+		Expect(eipServer.serving).To(BeFalse())
+	})
+
+	It("parses the total of the conntrack statistics", func() {
+		Expect(parseConntrackTotal("Connections Stats:\n    Total: 42\n    TCP: 40\n    UDP: 2\n")).To(Equal(42))
+		Expect(parseConntrackTotal("")).To(Equal(0))
+	})
+})
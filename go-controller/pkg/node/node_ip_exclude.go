@@ -0,0 +1,46 @@
+package node
+
+import (
+	"net"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// nodeIPExclusions returns the networks and interfaces never selected for the encap IP or the gateway interface of
+// the node, from its k8s.ovn.org/node-ip-exclude annotation or else the node-ip-exclude configuration
+func nodeIPExclusions(node *kapi.Node) config.NodeIPExclusions {
+	exclusions, err := util.ParseNodeIPExclude(node)
+	if err == nil {
+		return exclusions
+	}
+	if !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Ignoring the node IP exclusions of node %s: %v", node.Name, err)
+	}
+	return config.Default.NodeIPExclude
+}
+
+// nodeIPExcluded returns whether the IP is in a network or on an interface excluded by node-ip-exclude
+func nodeIPExcluded(ip net.IP) bool {
+	exclusions := config.Default.NodeIPExclude
+	if cidr := exclusions.ExcludedNetwork(ip); cidr != nil {
+		klog.Infof("Not selecting node IP %s in excluded network %s", ip, cidr)
+		return true
+	}
+	if len(exclusions.Interfaces) == 0 {
+		return false
+	}
+	// the IPs that are not local can't be the encap IP anyway
+	ifName, _, err := util.GetIFNameAndMTUForAddress(ip)
+	if err != nil {
+		return false
+	}
+	if pattern := exclusions.ExcludedInterfacePattern(ifName); pattern != "" {
+		klog.Infof("Not selecting node IP %s of interface %s excluded by pattern %q", ip, ifName, pattern)
+		return true
+	}
+	return false
+}
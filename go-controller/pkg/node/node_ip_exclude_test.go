@@ -0,0 +1,49 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node IP exclusions", func() {
+	newNodeIPExcludeNode := func(exclude string) *kapi.Node {
+		node := &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: map[string]string{}}}
+		if exclude != "" {
+			node.Annotations[util.OvnNodeIPExclude] = exclude
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	It("overrides the configured exclusions with the ones of the node annotation", func() {
+		configured, err := config.ParseNodeIPExclusions("192.168.100.0/24,bmc*")
+		Expect(err).NotTo(HaveOccurred())
+		config.Default.NodeIPExclude = configured
+		Expect(nodeIPExclusions(newNodeIPExcludeNode(""))).To(Equal(configured))
+
+		exclusions := nodeIPExclusions(newNodeIPExcludeNode("10.10.0.0/16,stor?"))
+		Expect(exclusions.CIDRs).To(Equal(ovntest.MustParseIPNets("10.10.0.0/16")))
+		Expect(exclusions.Interfaces).To(Equal([]string{"stor?"}))
+		// an invalid annotation is ignored
+		Expect(nodeIPExclusions(newNodeIPExcludeNode("10.10.0.0/33"))).To(Equal(configured))
+	})
+
+	It("excludes the IPs in the excluded networks", func() {
+		exclusions, err := config.ParseNodeIPExclusions("192.168.100.0/24,fd00:100::/64")
+		Expect(err).NotTo(HaveOccurred())
+		config.Default.NodeIPExclude = exclusions
+		Expect(nodeIPExcluded(ovntest.MustParseIP("192.168.100.5"))).To(BeTrue())
+		Expect(nodeIPExcluded(ovntest.MustParseIP("fd00:100::5"))).To(BeTrue())
+		Expect(nodeIPExcluded(ovntest.MustParseIP("192.168.1.5"))).To(BeFalse())
+	})
+})
@@ -21,7 +21,6 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
-	utilnet "k8s.io/utils/net"
 )
 
 type addressManager struct {
@@ -34,6 +33,9 @@ type addressManager struct {
 	// information from netlink. Set to false for testcases.
 	useNetlink bool
 	syncPeriod time.Duration
+	// annotationDelay is how long the node address annotations are updated after an address change event,
+	// coalescing the events of the addresses changed together into a single update
+	annotationDelay time.Duration
 	// compare node primary IP change
 	nodePrimaryAddr net.IP
 	gatewayBridge   *bridgeConfiguration
@@ -52,16 +54,17 @@ func newAddressManager(nodeName string, k kube.Interface, config *managementPort
 // reproducibility of unit tests.
 func newAddressManagerInternal(nodeName string, k kube.Interface, config *managementPortConfig, watchFactory factory.NodeWatchFactory, gwBridge *bridgeConfiguration, useNetlink bool) *addressManager {
 	mgr := &addressManager{
-		nodeName:       nodeName,
-		watchFactory:   watchFactory,
-		cidrs:          sets.New[string](),
-		mgmtPortConfig: config,
-		gatewayBridge:  gwBridge,
-		OnChanged:      func() {},
-		useNetlink:     useNetlink,
-		syncPeriod:     30 * time.Second,
-	}
-	mgr.nodeAnnotator = kube.NewNodeAnnotator(k, nodeName)
+		nodeName:        nodeName,
+		watchFactory:    watchFactory,
+		cidrs:           sets.New[string](),
+		mgmtPortConfig:  config,
+		gatewayBridge:   gwBridge,
+		OnChanged:       func() {},
+		useNetlink:      useNetlink,
+		syncPeriod:      30 * time.Second,
+		annotationDelay: 500 * time.Millisecond,
+	}
+	mgr.nodeAnnotator = newNodeAnnotator(k, nodeName)
 	mgr.sync()
 
 	return mgr
@@ -95,6 +98,23 @@ func (c *addressManager) delAddr(ipnet net.IPNet) bool {
 	return false
 }
 
+// setDPUHostIPs replaces the addresses with the ones of the DPU host declared in its gateway
+// intent. Only used in DPU mode, where the addresses are not discovered from the node.
+// returns true if there was an update
+func (c *addressManager) setDPUHostIPs(ips []net.IP) bool {
+	c.Lock()
+	defer c.Unlock()
+	cidrs := sets.New[string]()
+	for _, ip := range ips {
+		cidrs.Insert(util.GetIPNetFullMaskFromIP(ip).String())
+	}
+	if c.cidrs.Equal(cidrs) {
+		return false
+	}
+	c.cidrs = cidrs
+	return true
+}
+
 // ListAddresses returns all the addresses we know about
 func (c *addressManager) ListAddresses() []net.IP {
 	c.Lock()
@@ -128,15 +148,27 @@ func (c *addressManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
 
 // runInternal gathers node IP information and publishes it on the k8 node annotations.
 // The annotations it updates are k8s.ovn.org/host-cidrs, k8s.ovn.org/node-primary-ifaddr and k8s.ovn.org/l3-gateway-config.
-// It waits on 3 events and only the "stop" event may end execution.
+// It waits on 4 events and only the "stop" event may end execution.
 // Event 1: Address change events using a subscription func. In normal execution, this is a netlink addr subscription func that returns a channel that
-// conveys address updates that can be processed upon immediately.
-// Event 2: Ticker events which is used to trigger a sync func. This is required in-case address change events are missed.
-// Event 3: Stop events which stops event watching and returns.
+// conveys address updates that are applied to the host CIDRs immediately.
+// Event 2: Annotation timer events, fired annotationDelay after the first address change event not published yet, which publish the host CIDRs
+// changed by all the address change events received meanwhile in a single node update.
+// Event 3: Ticker events which is used to trigger a sync func. This is required in-case address change events are missed.
+// Event 4: Stop events which stops event watching and returns.
 func (c *addressManager) runInternal(stopChan <-chan struct{}, subscribe subscribeFn) {
 	addressSyncTimer := time.NewTicker(c.syncPeriod)
 	defer addressSyncTimer.Stop()
 
+	// annotationTimer is set while address changes are waiting to be published
+	var annotationTimer *time.Timer
+	var annotationTimerC <-chan time.Time
+	addrChanged := false
+	defer func() {
+		if annotationTimer != nil {
+			annotationTimer.Stop()
+		}
+	}()
+
 	subscribed, addrChan, err := subscribe()
 	if err != nil {
 		klog.Errorf("Error during netlink subscribe for IP Manager: %v", err)
@@ -152,15 +184,21 @@ func (c *addressManager) runInternal(stopChan <-chan struct{}, subscribe subscri
 				}
 				continue
 			}
-			addrChanged := false
+			var changed bool
 			if a.NewAddr {
-				addrChanged = c.addAddr(a.LinkAddress)
+				changed = c.addAddr(a.LinkAddress)
 			} else {
-				addrChanged = c.delAddr(a.LinkAddress)
+				changed = c.delAddr(a.LinkAddress)
 			}
-
+			addrChanged = addrChanged || changed
+			if annotationTimerC == nil {
+				annotationTimer = time.NewTimer(c.annotationDelay)
+				annotationTimerC = annotationTimer.C
+			}
+		case <-annotationTimerC:
+			annotationTimer, annotationTimerC = nil, nil
 			c.handleNodePrimaryAddrChange()
-			if addrChanged || !c.doNodeHostCIDRsMatch() {
+			if addrChanged || !c.doNodeHostCIDRsMatch() || c.gatewayBridgeAddrsChanged() {
 				klog.Infof("Host CIDRs changed to %v. Updating node address annotations.", c.cidrs)
 				err := c.updateNodeAddressAnnotations()
 				if err != nil {
@@ -168,9 +206,10 @@ func (c *addressManager) runInternal(stopChan <-chan struct{}, subscribe subscri
 				}
 				c.OnChanged()
 			}
+			addrChanged = false
 		case <-addressSyncTimer.C:
 			if subscribed {
-				klog.V(5).Info("Node IP manager calling sync() explicitly")
+				nodeIPLog.V(5).Info("Node IP manager calling sync() explicitly")
 				c.sync()
 			} else {
 				if subscribed, addrChan, err = subscribe(); err != nil {
@@ -190,6 +229,7 @@ func (c *addressManager) getNetlinkAddrSubFunc(stopChan <-chan struct{}) func()
 			klog.Errorf("Failed during AddrSubscribe callback: %v", err)
 			// Note: Not calling sync() from here: it is redudant and unsafe when stopChan is closed.
 		},
+		Namespace: util.NodeNetNSHandle(),
 	}
 	return func() (bool, chan netlink.AddrUpdate, error) {
 		addrChan := make(chan netlink.AddrUpdate)
@@ -328,6 +368,30 @@ func (c *addressManager) doNodeHostCIDRsMatch() bool {
 	return nodeHostAddresses.Equal(c.cidrs)
 }
 
+// gatewayBridgeAddrsChanged returns whether the addresses selected on the gateway bridge differ from
+// the addresses of the l3 gateway config annotation, as when the selected IPv6 address got deprecated
+// or a more stable one was autoconfigured, without the host CIDRs changing
+func (c *addressManager) gatewayBridgeAddrsChanged() bool {
+	if !c.useNetlink || config.OvnKubeNode.Mode != types.NodeModeFull {
+		return false
+	}
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		klog.Errorf("Unable to get node from informer")
+		return false
+	}
+	gatewayCfg, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return false
+	}
+	ifAddrs, err := getNetworkInterfaceIPAddresses(c.gatewayBridge.bridgeName)
+	if err != nil {
+		klog.Errorf("Failed to get the addresses of the gateway bridge %s: %v", c.gatewayBridge.bridgeName, err)
+		return false
+	}
+	return !sets.New(util.IPNetsIPToStringSlice(ifAddrs)...).Equal(sets.New(util.IPNetsIPToStringSlice(gatewayCfg.IPAddresses)...))
+}
+
 // nodePrimaryAddrChanged returns false if there is an error or if the IP does
 // match, otherwise it returns true and updates the current primary IP address.
 func (c *addressManager) nodePrimaryAddrChanged() (bool, error) {
@@ -337,7 +401,7 @@ func (c *addressManager) nodePrimaryAddrChanged() (bool, error) {
 	}
 	// check to see if ips on the node differ from what we stored
 	// in addressManager and it's an address that is known locally
-	nodePrimaryAddrStr, err := util.GetNodePrimaryIP(node)
+	nodePrimaryAddrStr, err := util.GetNodePrimaryIPExcluding(node, nodeIPExcluded)
 	if err != nil {
 		return false, err
 	}
@@ -382,14 +446,8 @@ func (c *addressManager) isValidNodeIP(addr net.IP) bool {
 		return false
 	}
 
-	if utilnet.IsIPv4(addr) {
-		if c.mgmtPortConfig.ipv4 != nil && c.mgmtPortConfig.ipv4.ifAddr.IP.Equal(addr) {
-			return false
-		}
-	} else if utilnet.IsIPv6(addr) {
-		if c.mgmtPortConfig.ipv6 != nil && c.mgmtPortConfig.ipv6.ifAddr.IP.Equal(addr) {
-			return false
-		}
+	if c.mgmtPortConfig.isManagementPortIP(addr) {
+		return false
 	}
 
 	if util.IsAddressReservedForInternalUse(addr) {
@@ -417,13 +475,13 @@ func (c *addressManager) sync() {
 	var addrs []netlink.Addr
 
 	if c.useNetlink {
-		links, err := netlink.LinkList()
+		links, err := util.GetNetLinkOps().LinkList()
 		if err != nil {
 			klog.Errorf("Failed sync due to being unable to list links: %v", err)
 			return
 		}
 		for _, link := range links {
-			foundAddrs, err := netlink.AddrList(link, getSupportedIPFamily())
+			foundAddrs, err := util.GetNetLinkOps().AddrList(link, getSupportedIPFamily())
 			if err != nil {
 				klog.Errorf("Failed sync due to being unable to list addresses for %q: %v", link.Attrs().Name, err)
 				return
@@ -435,7 +493,7 @@ func (c *addressManager) sync() {
 	currAddresses := sets.New[string]()
 	for _, addr := range addrs {
 		if !c.isValidNodeIP(addr.IP) {
-			klog.V(5).Infof("Skipping non-useable IP address for host: %s", addr.String())
+			nodeIPLog.V(5).Infof("Skipping non-useable IP address for host: %s", addr.String())
 			continue
 		}
 		netAddr := net.IPNet{IP: addr.IP, Mask: addr.Mask}
@@ -444,7 +502,7 @@ func (c *addressManager) sync() {
 
 	addrChanged := c.assignCIDRs(currAddresses)
 	c.handleNodePrimaryAddrChange()
-	if addrChanged || !c.doNodeHostCIDRsMatch() {
+	if addrChanged || !c.doNodeHostCIDRsMatch() || c.gatewayBridgeAddrsChanged() {
 		klog.Infof("Node address changed to %v. Updating annotations.", currAddresses)
 		err := c.updateNodeAddressAnnotations()
 		if err != nil {
@@ -486,7 +544,7 @@ func updateOVNEncapIPAndReconnect(newIP net.IP) {
 	} else {
 		encapIP = strings.TrimSuffix(encapIP, "\n")
 		if len(encapIP) > 0 && newIP.String() == encapIP {
-			klog.V(4).Infof("Will not update encap IP %s - it is already configured", newIP.String())
+			nodeIPLog.V(4).Infof("Will not update encap IP %s - it is already configured", newIP.String())
 			return
 		}
 	}
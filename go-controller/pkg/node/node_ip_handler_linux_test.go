@@ -124,6 +124,34 @@ var _ = Describe("Node IP Handler event tests", func() {
 			})
 		})
 
+		Context("by adding several valid IPs at once", func() {
+			It("should update node annotations once", func() {
+				nodePatches := func() int {
+					patches := 0
+					for _, action := range tc.fakeClient.(*fake.Clientset).Actions() {
+						if action.GetVerb() == "patch" && action.GetResource().Resource == "nodes" {
+							patches++
+						}
+					}
+					return patches
+				}
+				patches := nodePatches()
+				var ipNets []*net.IPNet
+				for _, addr := range []string{nodeAddr4, nodeAddr6, "10.1.2.10/24", "2001:db9::10/64"} {
+					ipNets = append(ipNets, ipEvent(addr, true, tc.addrChan))
+				}
+				Eventually(func() bool {
+					for _, ipNet := range ipNets {
+						if !nodeHasAddress(tc.fakeClient, nodeName, ipNet) {
+							return false
+						}
+					}
+					return true
+				}, 5).Should(BeTrue())
+				Consistently(nodePatches, 1).Should(Equal(patches + 1))
+			})
+		})
+
 		Context("by adding and deleting an invalid IP", func() {
 			It("should not update node annotations", func() {
 				for _, addr := range []string{tc.mgmtPortIP4.String(), tc.mgmtPortIP6.String(), config.Gateway.MasqueradeIPs.V4HostMasqueradeIP.String() + "/29", config.Gateway.MasqueradeIPs.V6HostMasqueradeIP.String() + "/125"} {
@@ -280,6 +308,52 @@ var _ = Describe("Node IP Handler tests", func() {
 			}, 3).Should(BeTrue())
 		})
 
+		ovntest.OnSupportedPlatformsIt("keeps the gateway addresses while a control plane VIP fails over", func() {
+			config.Gateway.ControlPlaneVIPs = "10.1.1.100"
+			vip := ovntest.MustParseIPNet("10.1.1.100/32")
+			var gatewayAddrs []*net.IPNet
+			Expect(tc.ns.Do(func(netNS ns.NetNS) error {
+				var err error
+				gatewayAddrs, err = getNetworkInterfaceIPAddresses(dummyBrName)
+				return err
+			})).ShouldNot(HaveOccurred())
+
+			vipAddr := func(add bool) {
+				Expect(tc.ns.Do(func(netNS ns.NetNS) error {
+					link, err := netlink.LinkByName(dummyBrName)
+					if err != nil {
+						return err
+					}
+					addr := &netlink.Addr{LinkIndex: link.Attrs().Index, Scope: unix.RT_SCOPE_UNIVERSE, IPNet: vip}
+					if add {
+						return netlink.AddrAdd(link, addr)
+					}
+					return netlink.AddrDel(link, addr)
+				})).ShouldNot(HaveOccurred())
+			}
+			expectGatewayAddrs := func() {
+				Expect(tc.ns.Do(func(netNS ns.NetNS) error {
+					addrs, err := getNetworkInterfaceIPAddresses(dummyBrName)
+					Expect(addrs).To(Equal(gatewayAddrs))
+					return err
+				})).ShouldNot(HaveOccurred())
+			}
+
+			// the VIP fails over to the node: it is an address of the host but not of the gateway
+			vipAddr(true)
+			Eventually(func() bool {
+				return nodeHasAddress(tc.fakeClient, nodeName, vip)
+			}, 5).Should(BeTrue())
+			expectGatewayAddrs()
+
+			// the VIP fails over away from the node
+			vipAddr(false)
+			Eventually(func() bool {
+				return nodeHasAddress(tc.fakeClient, nodeName, vip)
+			}, 5).Should(BeFalse())
+			expectGatewayAddrs()
+		})
+
 		ovntest.OnSupportedPlatformsIt("doesn't allow OVN reserved IPs", func() {
 			config.Gateway.MasqueradeIPs.V4OVNMasqueradeIP = ovntest.MustParseIP(dummyMasqIPv4)
 			config.Gateway.MasqueradeIPs.V6OVNMasqueradeIP = ovntest.MustParseIP(dummyMasqIPv6)
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+// nodeSysctls returns the sysctl values the node networking requires, keyed by sysctl name.
+// bridgeName is the gateway bridge, empty when the node has none.
+func nodeSysctls(bridgeName string) map[string]string {
+	sysctls := map[string]string{}
+	// the interfaces whose neighbor entries ovnkube relies on
+	var neighIfaces []string
+	if config.OvnKubeNode.Mode != types.NodeModeDPU {
+		neighIfaces = append(neighIfaces, types.K8sMgmtIntfName)
+	}
+	if bridgeName != "" {
+		neighIfaces = append(neighIfaces, bridgeName)
+	}
+	if config.IPv4Mode {
+		// with forwarding disabled, IPv4 forwarding is only enabled on the interfaces ovnkube uses
+		if !config.Gateway.DisableForwarding {
+			sysctls["net.ipv4.ip_forward"] = "1"
+		}
+		if config.OvnKubeNode.Mode != types.NodeModeDPU {
+			sysctls[fmt.Sprintf("net.ipv4.conf.%s.forwarding", types.K8sMgmtIntfName)] = "1"
+			if config.OvnKubeNode.Mode == types.NodeModeFull && config.Gateway.NodeportEnable {
+				// loose mode, service traffic routed via the management port would be dropped otherwise
+				sysctls[fmt.Sprintf("net.ipv4.conf.%s.rp_filter", types.K8sMgmtIntfName)] = "2"
+			}
+		}
+		if bridgeName != "" {
+			sysctls[fmt.Sprintf("net.ipv4.conf.%s.forwarding", bridgeName)] = "1"
+		}
+	}
+	if config.IPv6Mode {
+		// IPv6 forwarding can only be enabled globally
+		sysctls["net.ipv6.conf.all.forwarding"] = "1"
+	}
+	if config.OvnKubeNode.ARPTimeout != 0 {
+		timeout := strconv.Itoa(config.OvnKubeNode.ARPTimeout * 1000)
+		for _, iface := range neighIfaces {
+			if config.IPv4Mode {
+				sysctls[fmt.Sprintf("net.ipv4.neigh.%s.base_reachable_time_ms", iface)] = timeout
+			}
+			if config.IPv6Mode {
+				sysctls[fmt.Sprintf("net.ipv6.neigh.%s.base_reachable_time_ms", iface)] = timeout
+			}
+		}
+	}
+	return sysctls
+}
@@ -0,0 +1,53 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+var _ = Describe("Node sysctls", func() {
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+	})
+
+	It("requires forwarding on the interfaces used by ovnkube", func() {
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		config.Gateway.NodeportEnable = true
+		Expect(nodeSysctls("breth0")).To(Equal(map[string]string{
+			"net.ipv4.ip_forward":                  "1",
+			"net.ipv4.conf.ovn-k8s-mp0.forwarding": "1",
+			"net.ipv4.conf.ovn-k8s-mp0.rp_filter":  "2",
+			"net.ipv4.conf.breth0.forwarding":      "1",
+			"net.ipv6.conf.all.forwarding":         "1",
+		}))
+	})
+
+	It("does not require global IPv4 forwarding when forwarding is disabled", func() {
+		config.IPv4Mode = true
+		config.Gateway.DisableForwarding = true
+		config.OvnKubeNode.Mode = types.NodeModeDPUHost
+		Expect(nodeSysctls("")).To(Equal(map[string]string{
+			"net.ipv4.conf.ovn-k8s-mp0.forwarding": "1",
+		}))
+	})
+
+	It("sets the ARP timeout of the gateway bridge and the management port", func() {
+		config.IPv4Mode = true
+		config.IPv6Mode = true
+		config.Gateway.DisableForwarding = true
+		config.OvnKubeNode.ARPTimeout = 60
+		Expect(nodeSysctls("breth0")).To(Equal(map[string]string{
+			"net.ipv4.conf.ovn-k8s-mp0.forwarding":              "1",
+			"net.ipv4.conf.breth0.forwarding":                   "1",
+			"net.ipv6.conf.all.forwarding":                      "1",
+			"net.ipv4.neigh.ovn-k8s-mp0.base_reachable_time_ms": "60000",
+			"net.ipv6.neigh.ovn-k8s-mp0.base_reachable_time_ms": "60000",
+			"net.ipv4.neigh.breth0.base_reachable_time_ms":      "60000",
+			"net.ipv6.neigh.breth0.base_reachable_time_ms":      "60000",
+		}))
+	})
+})
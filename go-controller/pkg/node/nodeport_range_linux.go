@@ -0,0 +1,313 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	knet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/sysctlmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// nodePortRangeCheckInterval is how often host sockets are compared against service node ports
+	nodePortRangeCheckInterval = time.Minute
+	// tcpListenState is the /proc/net/tcp state value of a listening socket
+	tcpListenState = "0A"
+	// reservedPortsSysctl is the sysctl holding the ports the kernel won't use as ephemeral ports.
+	// It applies to both IPv4 and IPv6.
+	reservedPortsSysctl = "net.ipv4.ip_local_reserved_ports"
+)
+
+var (
+	// procNetDir and procSelfFdDir are variables so that unit tests can point them at fixtures
+	procNetDir    = "/proc/net"
+	procSelfFdDir = "/proc/self/fd"
+)
+
+// hostSocket is a socket bound on the host, as read from /proc/net/{tcp,udp}[6]
+type hostSocket struct {
+	protocol kapi.Protocol
+	port     int32
+	inode    string
+}
+
+// nodePortConflict is a service node port that a host process is also listening on
+type nodePortConflict struct {
+	service  ktypes.NamespacedName
+	protocol kapi.Protocol
+	port     int32
+}
+
+// nodePortRangeChecker periodically validates that the node ports of all services
+// fall into the configured node port range and that no host process, other than
+// ovnkube-node itself through the port claim watcher, is listening on one of them.
+// Problems are reported as warning events on the offending service.
+type nodePortRangeChecker struct {
+	sync.Mutex
+	nodeName     string
+	portRange    *knet.PortRange
+	recorder     record.EventRecorder
+	watchFactory factory.NodeWatchFactory
+	// reported keeps track of the conflicts and out of range ports already
+	// reported so that events are only emitted when a problem first shows up
+	reported sets.Set[string]
+}
+
+func newNodePortRangeChecker(nodeName string, recorder record.EventRecorder, watchFactory factory.NodeWatchFactory) (*nodePortRangeChecker, error) {
+	portRange, err := knet.ParsePortRange(config.Gateway.NodePortRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nodeport range %q: %w", config.Gateway.NodePortRange, err)
+	}
+	return &nodePortRangeChecker{
+		nodeName:     nodeName,
+		portRange:    portRange,
+		recorder:     recorder,
+		watchFactory: watchFactory,
+		reported:     sets.New[string](),
+	}, nil
+}
+
+// Run reserves the node port range if requested and checks for conflicts until stopChan is closed
+func (c *nodePortRangeChecker) Run(stopChan <-chan struct{}, wg *sync.WaitGroup) {
+	if config.Gateway.ReserveNodePortRange {
+		if err := reserveNodePortRange(c.portRange); err != nil {
+			klog.Errorf("Failed to reserve nodeport range %s: %v", c.portRange, err)
+		}
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.Until(func() {
+			if err := c.check(); err != nil {
+				klog.Errorf("Failed to check node ports for conflicts: %v", err)
+			}
+		}, nodePortRangeCheckInterval, stopChan)
+	}()
+}
+
+func (c *nodePortRangeChecker) check() error {
+	services, err := c.watchFactory.GetServices()
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	sockets, err := listHostSockets()
+	if err != nil {
+		return err
+	}
+	ownInodes, err := listOwnSocketInodes()
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	current := sets.New[string]()
+	for _, svc := range services {
+		for _, port := range outOfRangeNodePorts(svc, c.portRange) {
+			key := fmt.Sprintf("range/%s/%s/%d", svc.Namespace, svc.Name, port)
+			current.Insert(key)
+			if !c.reported.Has(key) {
+				c.emitEvent(svc, "NodePortOutOfRange",
+					"Service %s/%s uses node port %d which is outside of the node port range %s configured on node %s",
+					svc.Namespace, svc.Name, port, c.portRange, c.nodeName)
+			}
+		}
+	}
+	for _, conflict := range findNodePortConflicts(services, sockets, ownInodes) {
+		key := fmt.Sprintf("conflict/%s/%s/%d", conflict.service, conflict.protocol, conflict.port)
+		current.Insert(key)
+		if c.reported.Has(key) {
+			continue
+		}
+		svc, err := c.watchFactory.GetService(conflict.service.Namespace, conflict.service.Name)
+		if err != nil {
+			continue
+		}
+		c.emitEvent(svc, "NodePortConflict",
+			"Service %s/%s node port %d/%s is also used by a host process on node %s, traffic to that port may not reach the service",
+			svc.Namespace, svc.Name, conflict.port, conflict.protocol, c.nodeName)
+	}
+	c.reported = current
+	return nil
+}
+
+func (c *nodePortRangeChecker) emitEvent(svc *kapi.Service, reason, messageFmt string, args ...interface{}) {
+	serviceRef := kapi.ObjectReference{
+		Kind:      "Service",
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+	}
+	c.recorder.Eventf(&serviceRef, kapi.EventTypeWarning, reason, messageFmt, args...)
+	klog.Warningf(messageFmt, args...)
+}
+
+// outOfRangeNodePorts returns the node ports of the service that are not part of portRange
+func outOfRangeNodePorts(svc *kapi.Service, portRange *knet.PortRange) []int32 {
+	var ports []int32
+	if !util.ServiceTypeHasNodePort(svc) {
+		return ports
+	}
+	for _, svcPort := range svc.Spec.Ports {
+		if svcPort.NodePort != 0 && !portRange.Contains(int(svcPort.NodePort)) {
+			ports = append(ports, svcPort.NodePort)
+		}
+	}
+	return ports
+}
+
+// findNodePortConflicts returns the service node ports that are bound by sockets not
+// present in ownInodes. SCTP node ports are never claimed on the host and are skipped.
+func findNodePortConflicts(services []*kapi.Service, sockets []hostSocket, ownInodes sets.Set[string]) []nodePortConflict {
+	bound := make(map[kapi.Protocol]sets.Set[int32])
+	for _, socket := range sockets {
+		if ownInodes.Has(socket.inode) {
+			continue
+		}
+		if bound[socket.protocol] == nil {
+			bound[socket.protocol] = sets.New[int32]()
+		}
+		bound[socket.protocol].Insert(socket.port)
+	}
+	var conflicts []nodePortConflict
+	for _, svc := range services {
+		if !util.ServiceTypeHasNodePort(svc) {
+			continue
+		}
+		for _, svcPort := range svc.Spec.Ports {
+			if svcPort.NodePort == 0 || !bound[svcPort.Protocol].Has(svcPort.NodePort) {
+				continue
+			}
+			conflicts = append(conflicts, nodePortConflict{
+				service:  ktypes.NamespacedName{Namespace: svc.Namespace, Name: svc.Name},
+				protocol: svcPort.Protocol,
+				port:     svcPort.NodePort,
+			})
+		}
+	}
+	return conflicts
+}
+
+// listHostSockets returns the listening TCP sockets and bound UDP sockets of the host
+func listHostSockets() ([]hostSocket, error) {
+	var sockets []hostSocket
+	for file, protocol := range map[string]kapi.Protocol{
+		"tcp":  kapi.ProtocolTCP,
+		"tcp6": kapi.ProtocolTCP,
+		"udp":  kapi.ProtocolUDP,
+		"udp6": kapi.ProtocolUDP,
+	} {
+		f, err := os.Open(filepath.Join(procNetDir, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// e.g. IPv6 disabled on the host
+				continue
+			}
+			return nil, err
+		}
+		parsed, err := parseProcNetSockets(f, protocol)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		sockets = append(sockets, parsed...)
+	}
+	return sockets, nil
+}
+
+// parseProcNetSockets parses the content of a /proc/net/{tcp,udp}[6] file. Only
+// listening sockets are returned for TCP.
+func parseProcNetSockets(r io.Reader, protocol kapi.Protocol) ([]hostSocket, error) {
+	var sockets []hostSocket
+	scanner := bufio.NewScanner(r)
+	// skip the header
+	scanner.Scan()
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		if protocol == kapi.ProtocolTCP && fields[3] != tcpListenState {
+			continue
+		}
+		idx := strings.LastIndex(fields[1], ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("unexpected local address %q", fields[1])
+		}
+		port, err := strconv.ParseUint(fields[1][idx+1:], 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected local address %q: %w", fields[1], err)
+		}
+		sockets = append(sockets, hostSocket{protocol: protocol, port: int32(port), inode: fields[9]})
+	}
+	return sockets, scanner.Err()
+}
+
+// listOwnSocketInodes returns the inodes of the sockets opened by this process
+func listOwnSocketInodes() (sets.Set[string], error) {
+	inodes := sets.New[string]()
+	entries, err := os.ReadDir(procSelfFdDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		link, err := os.Readlink(filepath.Join(procSelfFdDir, entry.Name()))
+		if err != nil {
+			// the fd may have been closed in the meantime
+			continue
+		}
+		if strings.HasPrefix(link, "socket:[") {
+			inodes.Insert(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"))
+		}
+	}
+	return inodes, nil
+}
+
+// reserveNodePortRange adds portRange to the kernel reserved ports, keeping any
+// existing reservations
+func reserveNodePortRange(portRange *knet.PortRange) error {
+	return sysctlmanager.Merge(reservedPortsSysctl, func(current string) string {
+		reserved, _ := mergeReservedPorts(current, portRange)
+		return reserved
+	})
+}
+
+// mergeReservedPorts adds portRange to the comma separated list of reserved ports
+// and ranges, unless an existing entry already covers it
+func mergeReservedPorts(current string, portRange *knet.PortRange) (string, bool) {
+	current = strings.TrimSpace(current)
+	var entries []string
+	if current != "" {
+		entries = strings.Split(current, ",")
+	}
+	for _, entry := range entries {
+		existing, err := knet.ParsePortRange(strings.TrimSpace(entry))
+		if err != nil {
+			continue
+		}
+		if existing.Contains(portRange.Base) && existing.Contains(portRange.Base+portRange.Size-1) {
+			return current, false
+		}
+	}
+	entries = append(entries, portRange.String())
+	return strings.Join(entries, ","), true
+}
@@ -0,0 +1,104 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const procNetTCPFixture = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:7918 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1001 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:7919 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1002 1 0000000000000000 100 0 0 10 0
+   2: 0A000001:7530 0A000002:D431 01 00000000:00000000 00:00000000 00000000     0        0 1003 1 0000000000000000 20 4 30 10 -1
+`
+
+func newNodePortService(name string, svcType kapi.ServiceType, ports ...kapi.ServicePort) *kapi.Service {
+	return &kapi.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: kapi.ServiceSpec{
+			Type:  svcType,
+			Ports: ports,
+		},
+	}
+}
+
+var _ = Describe("Node port range checker", func() {
+	var portRange *knet.PortRange
+
+	BeforeEach(func() {
+		var err error
+		portRange, err = knet.ParsePortRange("30000-32767")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("parses listening TCP sockets from /proc/net/tcp", func() {
+		sockets, err := parseProcNetSockets(strings.NewReader(procNetTCPFixture), kapi.ProtocolTCP)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sockets).To(Equal([]hostSocket{
+			{protocol: kapi.ProtocolTCP, port: 31000, inode: "1001"},
+			{protocol: kapi.ProtocolTCP, port: 31001, inode: "1002"},
+		}))
+	})
+
+	It("parses all UDP sockets regardless of state", func() {
+		sockets, err := parseProcNetSockets(strings.NewReader(procNetTCPFixture), kapi.ProtocolUDP)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sockets).To(HaveLen(3))
+	})
+
+	It("reports node ports outside of the configured range", func() {
+		svc := newNodePortService("svc", kapi.ServiceTypeNodePort,
+			kapi.ServicePort{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 31000},
+			kapi.ServicePort{Protocol: kapi.ProtocolTCP, Port: 81, NodePort: 8080},
+		)
+		Expect(outOfRangeNodePorts(svc, portRange)).To(Equal([]int32{8080}))
+
+		clusterIP := newNodePortService("cip", kapi.ServiceTypeClusterIP,
+			kapi.ServicePort{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 8080},
+		)
+		Expect(outOfRangeNodePorts(clusterIP, portRange)).To(BeEmpty())
+	})
+
+	It("finds conflicts with host sockets not owned by ovnkube-node", func() {
+		services := []*kapi.Service{
+			newNodePortService("owned", kapi.ServiceTypeNodePort,
+				kapi.ServicePort{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 31000}),
+			newNodePortService("conflict", kapi.ServiceTypeLoadBalancer,
+				kapi.ServicePort{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 31001}),
+			newNodePortService("udp", kapi.ServiceTypeNodePort,
+				kapi.ServicePort{Protocol: kapi.ProtocolUDP, Port: 53, NodePort: 31001}),
+		}
+		sockets := []hostSocket{
+			{protocol: kapi.ProtocolTCP, port: 31000, inode: "1001"},
+			{protocol: kapi.ProtocolTCP, port: 31001, inode: "1002"},
+		}
+		conflicts := findNodePortConflicts(services, sockets, sets.New[string]("1001"))
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].service.Name).To(Equal("conflict"))
+		Expect(conflicts[0].protocol).To(Equal(kapi.ProtocolTCP))
+		Expect(conflicts[0].port).To(Equal(int32(31001)))
+	})
+
+	It("merges the node port range into the reserved ports", func() {
+		reserved, changed := mergeReservedPorts("", portRange)
+		Expect(changed).To(BeTrue())
+		Expect(reserved).To(Equal("30000-32767"))
+
+		reserved, changed = mergeReservedPorts("22,8080\n", portRange)
+		Expect(changed).To(BeTrue())
+		Expect(reserved).To(Equal("22,8080,30000-32767"))
+
+		reserved, changed = mergeReservedPorts("22,29000-33000", portRange)
+		Expect(changed).To(BeFalse())
+		Expect(reserved).To(Equal("22,29000-33000"))
+	})
+})
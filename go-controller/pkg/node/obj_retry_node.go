@@ -99,7 +99,7 @@ func (h *nodeEventHandler) AreResourcesEqual(obj1, obj2 interface{}) (bool, erro
 			return false, fmt.Errorf("could not cast obj2 of type %T to *kapi.Namespace", obj2)
 		}
 
-		return !exGatewayPodsAnnotationsChanged(ns1, ns2), nil
+		return !exGatewayNamespaceChanged(ns1, ns2), nil
 
 	case factory.EndpointSliceForStaleConntrackRemovalType:
 		// always run update code
@@ -172,7 +172,13 @@ func (h *nodeEventHandler) UpdateResource(oldObj, newObj interface{}, inRetryCac
 			return fmt.Errorf("error retrieving node %s: %v", h.nc.name, err)
 		}
 		if !config.OVNKubernetesFeature.EnableInterconnect || util.GetNodeZone(node) == types.OvnDefaultZone {
+			oldNs := oldObj.(*kapi.Namespace)
 			newNs := newObj.(*kapi.Namespace)
+			// a label change only matters if it selects the namespace as target of an Admin Policy Based External
+			// Route, or no longer does
+			if !h.nc.isExternalGatewayNamespace(oldNs) && !h.nc.isExternalGatewayNamespace(newNs) {
+				return nil
+			}
 			return h.nc.syncConntrackForExternalGateways(newNs)
 		}
 		return nil
@@ -1,19 +1,24 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
@@ -27,6 +32,30 @@ type openflowManager struct {
 	exGWFlowMutex sync.Mutex
 	// channel to indicate we need to update flows immediately
 	flowChan chan struct{}
+	// cacheGeneration is bumped on every change of the flow caches
+	cacheGeneration atomic.Uint64
+	// syncObserver is told about the requested and completed flow syncs, nil when nothing observes them
+	syncObserver flowSyncObserver
+	// vswitchd detects the restarts of ovs-vswitchd, the flows are reprogrammed right away after them
+	vswitchd *vswitchdRestartDetector
+	// pausedBy are the owners of the pauses of the flow syncs in effect, e.g. while OVS is upgraded on the node
+	// or the node reconciliation is paused, the flows are not synced while any is. Protected by pauseLock.
+	pausedBy  sets.Set[string]
+	pauseLock sync.Mutex
+	// meters are the meters of the default bridge the flows of the cache may refer to, by meter ID, as their
+	// specification without the ID, nil while no meter was ever set. Protected by flowMutex.
+	meters map[uint32]string
+	// installedMeters are the meters last installed on the default bridge, nil when they are unknown, e.g.
+	// on startup or after a restart of ovs-vswitchd. Protected by flowMutex.
+	installedMeters map[uint32]string
+}
+
+// flowSyncObserver tracks the syncs of the gateway flows, e.g. to report the node not live when they stall
+type flowSyncObserver interface {
+	// QueuedUpdate is called when a flow sync is requested
+	QueuedUpdate()
+	// Updated is called when the flows are synced
+	Updated()
 }
 
 // UTILs Needed for UDN (also leveraged for default netInfo) in openflowmanager
@@ -77,30 +106,147 @@ func (c *openflowManager) updateFlowCacheEntry(key string, flows []string) {
 	c.flowMutex.Lock()
 	defer c.flowMutex.Unlock()
 	c.flowCache[key] = flows
+	c.bumpCacheGeneration()
 }
 
 func (c *openflowManager) deleteFlowsByKey(key string) {
 	c.flowMutex.Lock()
 	defer c.flowMutex.Unlock()
 	delete(c.flowCache, key)
+	c.bumpCacheGeneration()
+}
+
+// setMeter adds or updates a meter of the default bridge, it is installed on the next flow sync before the flows
+// referring to it
+func (c *openflowManager) setMeter(id uint32, spec string) {
+	c.flowMutex.Lock()
+	defer c.flowMutex.Unlock()
+	if c.meters == nil {
+		c.meters = map[uint32]string{}
+	}
+	c.meters[id] = spec
+}
+
+// deleteMeter removes a meter of the default bridge, it is deleted on the next flow sync once the flows
+// referring to it are gone
+func (c *openflowManager) deleteMeter(id uint32) {
+	c.flowMutex.Lock()
+	defer c.flowMutex.Unlock()
+	delete(c.meters, id)
+}
+
+// forgetInstalledMeters makes the next flow sync replace all the meters of the default bridge
+func (c *openflowManager) forgetInstalledMeters() {
+	c.flowMutex.Lock()
+	defer c.flowMutex.Unlock()
+	c.installedMeters = nil
+}
+
+// installMeters adds or modifies the meters of the default bridge that are not installed as they are set. It must
+// run before the flows are replaced since OVS rejects the whole bundle when a flow refers to an unknown meter.
+// c.flowMutex must be held.
+func (c *openflowManager) installMeters(bridgeName string) error {
+	if c.meters == nil {
+		return nil
+	}
+	if c.installedMeters == nil {
+		// the meters left by a previous run or ovs-vswitchd are unknown
+		if _, stderr, err := util.RunOVSOfctl("-O", "OpenFlow13", "del-meters", bridgeName); err != nil {
+			return fmt.Errorf("failed to delete the meters of bridge %s, stderr: %q: %w", bridgeName, stderr, err)
+		}
+		c.installedMeters = map[uint32]string{}
+	}
+	for id, spec := range c.meters {
+		installed, ok := c.installedMeters[id]
+		if ok && installed == spec {
+			continue
+		}
+		command := "add-meter"
+		if ok {
+			command = "mod-meter"
+		}
+		meter := fmt.Sprintf("meter=%d,%s", id, spec)
+		if _, stderr, err := util.RunOVSOfctl("-O", "OpenFlow13", command, bridgeName, meter); err != nil {
+			return fmt.Errorf("failed to %s %s on bridge %s, stderr: %q: %w", command, meter, bridgeName, stderr, err)
+		}
+		c.installedMeters[id] = spec
+	}
+	return nil
+}
+
+// deleteStaleMeters deletes the installed meters of the default bridge that are no longer set, once the flows
+// referring to them are replaced. c.flowMutex must be held.
+func (c *openflowManager) deleteStaleMeters(bridgeName string) {
+	for id := range c.installedMeters {
+		if _, ok := c.meters[id]; ok {
+			continue
+		}
+		meter := fmt.Sprintf("meter=%d", id)
+		if _, stderr, err := util.RunOVSOfctl("-O", "OpenFlow13", "del-meter", bridgeName, meter); err != nil {
+			klog.Errorf("Failed to delete %s on bridge %s, stderr: %q: %v", meter, bridgeName, stderr, err)
+			continue
+		}
+		delete(c.installedMeters, id)
+	}
+}
+
+// ndpProxyFlowsKey is the flow cache key of the egress IP neighbor discovery proxy flows
+const ndpProxyFlowsKey = "ndp_proxy"
+
+// setNDPProxyFlows sends the neighbor solicitations received on the physical port for the IPs to the host,
+// which answers them for its proxy entries
+func (c *openflowManager) setNDPProxyFlows(ips []string) {
+	if len(ips) == 0 {
+		c.deleteFlowsByKey(ndpProxyFlowsKey)
+		c.requestFlowSync()
+		return
+	}
+	c.defaultBridge.Lock()
+	ofPortPhys := c.defaultBridge.ofPortPhys
+	c.defaultBridge.Unlock()
+	flows := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		flows = append(flows, fmt.Sprintf("cookie=%s, priority=110, in_port=%s, icmp6, icmp_type=135, icmp_code=0, "+
+			"nd_target=%s, actions=output:LOCAL", defaultOpenFlowCookie, ofPortPhys, ip))
+	}
+	c.updateFlowCacheEntry(ndpProxyFlowsKey, flows)
+	c.requestFlowSync()
 }
 
 func (c *openflowManager) updateExBridgeFlowCacheEntry(key string, flows []string) {
 	c.exGWFlowMutex.Lock()
 	defer c.exGWFlowMutex.Unlock()
 	c.exGWFlowCache[key] = flows
+	c.bumpCacheGeneration()
+}
+
+func (c *openflowManager) bumpCacheGeneration() {
+	metrics.MetricGatewayOpenFlowCacheGeneration.Set(float64(c.cacheGeneration.Add(1)))
 }
 
 func (c *openflowManager) requestFlowSync() {
+	if c.syncObserver != nil {
+		c.syncObserver.QueuedUpdate()
+	}
 	select {
 	case c.flowChan <- struct{}{}:
-		klog.V(5).Infof("Gateway OpenFlow sync requested")
+		gatewayLog.V(5).Infof("Gateway OpenFlow sync requested")
 	default:
-		klog.V(5).Infof("Gateway OpenFlow sync already requested")
+		gatewayLog.V(5).Infof("Gateway OpenFlow sync already requested")
 	}
 }
 
-func (c *openflowManager) syncFlows() {
+// syncFlows replaces the flows of the gateway bridges with the flows of the caches, it returns whether
+// the flows of all the bridges were replaced
+func (c *openflowManager) syncFlows() bool {
+	if c.paused() {
+		// the syncs are deferred on purpose until they are resumed, they don't stall
+		if c.syncObserver != nil {
+			c.syncObserver.Updated()
+		}
+		return false
+	}
+
 	// protect gwBridge config from being updated by gw.nodeIPManager
 	c.defaultBridge.Lock()
 	defer c.defaultBridge.Unlock()
@@ -108,15 +254,30 @@ func (c *openflowManager) syncFlows() {
 	c.flowMutex.Lock()
 	defer c.flowMutex.Unlock()
 
+	_, span := metrics.StartSpan(context.Background(), "openflowManager.syncFlows",
+		attribute.String("ovs.bridge", c.defaultBridge.bridgeName))
+	var syncErr error
+	defer func() { metrics.EndSpan(span, syncErr) }()
+
 	flows := []string{}
 	for _, entry := range c.flowCache {
 		flows = append(flows, entry...)
 	}
 
-	_, stderr, err := util.ReplaceOFFlows(c.defaultBridge.bridgeName, flows)
-	if err != nil {
+	synced := true
+	if err := c.installMeters(c.defaultBridge.bridgeName); err != nil {
+		klog.Errorf("Failed to add meters: %v", err)
+		syncErr = err
+		synced = false
+	} else if _, stderr, err := util.ReplaceOFFlows(c.defaultBridge.bridgeName, flows); err != nil {
 		klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.flowCache)
+		syncErr = fmt.Errorf("failed to replace the flows of bridge %s: %w", c.defaultBridge.bridgeName, err)
+		synced = false
+	} else {
+		c.deleteStaleMeters(c.defaultBridge.bridgeName)
 	}
+	metrics.MetricGatewayOpenFlowCacheFlows.WithLabelValues(c.defaultBridge.bridgeName).Set(float64(len(flows)))
+	span.SetAttributes(attribute.Int("ovs.flows", len(flows)))
 
 	if c.externalGatewayBridge != nil {
 		c.externalGatewayBridge.Lock()
@@ -133,8 +294,54 @@ func (c *openflowManager) syncFlows() {
 		_, stderr, err := util.ReplaceOFFlows(c.externalGatewayBridge.bridgeName, flows)
 		if err != nil {
 			klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.exGWFlowCache)
+			syncErr = fmt.Errorf("failed to replace the flows of bridge %s: %w", c.externalGatewayBridge.bridgeName, err)
+			synced = false
 		}
+		metrics.MetricGatewayOpenFlowCacheFlows.WithLabelValues(c.externalGatewayBridge.bridgeName).Set(float64(len(flows)))
 	}
+
+	if synced {
+		metrics.MetricGatewayOpenFlowLastSyncTimestamp.SetToCurrentTime()
+		if c.syncObserver != nil {
+			c.syncObserver.Updated()
+		}
+	}
+	return synced
+}
+
+// pauseFlowSyncs stops syncing the flows of the gateway bridges for the owner until it resumes them, the flow
+// caches are still updated meanwhile. It returns once the sync in progress, if any, is done.
+func (c *openflowManager) pauseFlowSyncs(owner string) {
+	c.flowMutex.Lock()
+	defer c.flowMutex.Unlock()
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	if c.pausedBy == nil {
+		c.pausedBy = sets.New[string]()
+	}
+	c.pausedBy.Insert(owner)
+}
+
+// resumeFlowSyncs releases the pause of the flow syncs of the owner, the flows are synced right away once none
+// is left
+func (c *openflowManager) resumeFlowSyncs(owner string) {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	if !c.pausedBy.Has(owner) {
+		klog.Warningf("Ignoring the resume of the gateway flow syncs by %s, they were not paused by it", owner)
+		return
+	}
+	c.pausedBy.Delete(owner)
+	if c.pausedBy.Len() == 0 {
+		c.requestFlowSync()
+	}
+}
+
+// paused returns whether the flow syncs are paused
+func (c *openflowManager) paused() bool {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	return c.pausedBy.Len() > 0
 }
 
 // since we share the host's k8s node IP, add OpenFlow flows
@@ -155,6 +362,7 @@ func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, subnet
 		exGWFlowCache:         make(map[string][]string),
 		exGWFlowMutex:         sync.Mutex{},
 		flowChan:              make(chan struct{}, 1),
+		vswitchd:              newVswitchdRestartDetector(),
 	}
 
 	if err := ofm.updateBridgeFlowCache(subnets, extraIPs); err != nil {
@@ -173,10 +381,20 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 		syncPeriod := 15 * time.Second
 		timer := time.NewTicker(syncPeriod)
 		defer timer.Stop()
+		vswitchdTimer := time.NewTicker(vswitchdRestartCheckInterval)
+		defer vswitchdTimer.Stop()
+		// the PID of the running ovs-vswitchd is the one the flows were last synced to
+		c.vswitchd.restarted()
+		reprogramPending := false
 		for {
 			select {
+			case <-vswitchdTimer.C:
+				reprogramPending = c.reprogramAfterVswitchdRestart(reprogramPending)
 			case <-timer.C:
-
+				if c.paused() {
+					// the ports may be recreated while the syncs are paused
+					continue
+				}
 				if err := checkPorts(c.getDefaultBridgePortConfigurations()); err != nil {
 					klog.Errorf("Checkports failed %v", err)
 					continue
@@ -199,6 +417,22 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 	}()
 }
 
+// normalFlows returns the lowest priority flows of the default bridge, switching the traffic not matched by
+// the other flows normally
+func normalFlows(encapIP string) []string {
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		return []string{
+			fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction),
+			fmt.Sprintf("table=0,priority=999,udp,tp_dst=67,actions=%s\n", util.NormalAction),
+			fmt.Sprintf("table=0,priority=999,udp,nw_dst=%s,tp_dst=6081,actions=LOCAL\n", encapIP),
+			fmt.Sprintf("table=0,priority=998,ip,nw_dst=%s,actions=%s\n", encapIP, util.NormalAction),
+		}
+	}
+	return []string{
+		fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction),
+	}
+}
+
 // updateBridgeFlowCache generates the "static" per-bridge flows
 // note: this is shared between shared and local gateway modes
 func (c *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs []net.IP) error {
@@ -232,18 +466,7 @@ func (c *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs [
 		encapIP = strings.TrimSuffix(encapIP, "\n")
 	}
 
-	if config.OvnKubeNode.Mode == types.NodeModeDPU {
-		c.updateFlowCacheEntry("NORMAL", []string{
-			fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction),
-			fmt.Sprintf("table=0,priority=999,udp,tp_dst=67,actions=%s\n", util.NormalAction),
-			fmt.Sprintf("table=0,priority=999,udp,nw_dst=%s,tp_dst=6081,actions=LOCAL\n", encapIP),
-			fmt.Sprintf("table=0,priority=998,ip,nw_dst=%s,actions=%s\n", encapIP, util.NormalAction),
-		})
-	} else {
-		c.updateFlowCacheEntry("NORMAL", []string{
-			fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction),
-		})
-	}
+	c.updateFlowCacheEntry("NORMAL", normalFlows(encapIP))
 	c.updateFlowCacheEntry("DEFAULT", dftFlows)
 
 	// we consume ex gw bridge flows only if that is enabled
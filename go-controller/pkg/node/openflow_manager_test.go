@@ -77,3 +77,40 @@ patch-br-ex_multi-homing-worker-0.maiqueb.org-to-br-int`,
 		})
 	}
 }
+
+func TestOpenFlowManagerFlowSyncPauses(t *testing.T) {
+	ofm := &openflowManager{flowChan: make(chan struct{}, 1)}
+
+	// a resume without pause, e.g. of a reconciler registered again, is ignored
+	ofm.resumeFlowSyncs("reconciliation-pause")
+	if ofm.paused() {
+		t.Fatalf("Expected the flow syncs not paused")
+	}
+
+	ofm.pauseFlowSyncs("ovs-upgrade-quiesce")
+	ofm.pauseFlowSyncs("reconciliation-pause")
+	ofm.pauseFlowSyncs("reconciliation-pause")
+	ofm.resumeFlowSyncs("reconciliation-pause")
+	if !ofm.paused() {
+		t.Fatalf("Expected the flow syncs paused by the OVS upgrade")
+	}
+	ofm.resumeFlowSyncs("reconciliation-pause")
+	if !ofm.paused() {
+		t.Fatalf("Expected the flow syncs paused by the OVS upgrade")
+	}
+	select {
+	case <-ofm.flowChan:
+		t.Fatalf("Expected no flow sync requested while paused")
+	default:
+	}
+
+	ofm.resumeFlowSyncs("ovs-upgrade-quiesce")
+	if ofm.paused() {
+		t.Fatalf("Expected the flow syncs resumed")
+	}
+	select {
+	case <-ofm.flowChan:
+	default:
+		t.Fatalf("Expected a flow sync requested once resumed")
+	}
+}
@@ -84,8 +84,10 @@ func (o *FakeOVNNode) init() {
 	o.watcher, err = factory.NewNodeWatchFactory(o.fakeClient, fakeNodeName)
 	Expect(err).NotTo(HaveOccurred())
 
-	cnnci := NewCommonNodeNetworkControllerInfo(o.fakeClient.KubeClient, o.fakeClient.AdminPolicyRouteClient, o.watcher, o.recorder, fakeNodeName, routemanager.NewController())
+	cnnci := NewCommonNodeNetworkControllerInfo(o.fakeClient.KubeClient, o.fakeClient.AdminPolicyRouteClient, nil, o.watcher, o.recorder, fakeNodeName, routemanager.NewController())
 	o.nc = newDefaultNodeNetworkController(cnnci, o.stopChan, o.errChan, o.wg, routemanager.NewController())
+	// the required kernel modules are loaded in the sysfs fixture
+	o.nc.kernelModules = &kernelModules{sysModuleDir: "testdata/sys/module"}
 	// watcher is started by nodeNetworkControllerManager, not by nodeNetworkcontroller, so start it here.
 	o.watcher.Start()
 	o.nc.Start(context.TODO())
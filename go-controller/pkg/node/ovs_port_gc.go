@@ -0,0 +1,150 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// ovsPortGCInterval is how often br-int is scanned for the OVS ports of pods that no longer exist
+const ovsPortGCInterval = 5 * time.Minute
+
+const (
+	ovsPortGCRemoved = "removed"
+	ovsPortGCDryRun  = "dry-run"
+	ovsPortGCError   = "error"
+)
+
+// ovsPortGC periodically removes the OVS ports the CNI server added to br-int for pods that no longer
+// exist, left behind when the CNI DEL of the pod was never received (a crashed kubelet or container
+// runtime, or ovnkube-node not running at the time). A port is removed once it is found stale by two
+// consecutive scans, so that a port added by a CNI ADD the pod informer didn't catch up with yet is
+// left alone.
+type ovsPortGC struct {
+	dryRun   bool
+	listPods func() ([]*kapi.Pod, error)
+	// suspects are the ports found stale by the previous scan
+	suspects sets.Set[string]
+}
+
+func newOVSPortGC(dryRun bool, listPods func() ([]*kapi.Pod, error)) *ovsPortGC {
+	return &ovsPortGC{
+		dryRun:   dryRun,
+		listPods: listPods,
+		suspects: sets.New[string](),
+	}
+}
+
+func (gc *ovsPortGC) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, ovsPortGCInterval, nil, func() {
+		if err := gc.sync(); err != nil {
+			klog.Errorf("Failed to garbage collect the stale OVS ports: %v", err)
+		}
+	})
+}
+
+// sync removes the ports of pods that no longer exist that were already found by the previous scan
+func (gc *ovsPortGC) sync() error {
+	ports, err := listPodPorts()
+	if err != nil {
+		return err
+	}
+	pods, err := gc.listPods()
+	if err != nil {
+		return fmt.Errorf("failed to list the pods: %w", err)
+	}
+	podUIDs := sets.New[string]()
+	for _, pod := range pods {
+		podUIDs.Insert(string(pod.UID))
+	}
+
+	stale := sets.New[string]()
+	for name, port := range ports {
+		// ports added before the pod UID was recorded can't be told apart
+		if port.podUID != "" && !podUIDs.Has(port.podUID) {
+			stale.Insert(name)
+		}
+	}
+	metrics.MetricNodeStaleOVSPorts.Set(float64(stale.Len()))
+
+	for _, name := range sets.List(stale.Intersection(gc.suspects)) {
+		port := ports[name]
+		if gc.dryRun {
+			klog.Infof("Dry run: would remove OVS port %s of pod %s (UID %s) that no longer exists", name, port.ifaceID, port.podUID)
+			metrics.MetricNodeStaleOVSPortRemovals.WithLabelValues(ovsPortGCDryRun).Inc()
+			continue
+		}
+		klog.Infof("Removing OVS port %s of pod %s (UID %s) that no longer exists", name, port.ifaceID, port.podUID)
+		if err := deletePodPort(name, port); err != nil {
+			klog.Errorf("Failed to remove stale OVS port %s: %v", name, err)
+			metrics.MetricNodeStaleOVSPortRemovals.WithLabelValues(ovsPortGCError).Inc()
+			continue
+		}
+		metrics.MetricNodeStaleOVSPortRemovals.WithLabelValues(ovsPortGCRemoved).Inc()
+	}
+	gc.suspects = stale
+	return nil
+}
+
+// podPort is an OVS interface of br-int the CNI server added for a pod sandbox
+type podPort struct {
+	ifaceID string
+	podUID  string
+	// representor is set for the ports of VF representors, whose netdev is not owned by the pod
+	representor bool
+}
+
+// listPodPorts returns the OVS interfaces the CNI server added for pod sandboxes, by interface name
+func listPodPorts() (map[string]podPort, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--no-heading", "--data=bare", "--format=csv", "--columns=name,external_ids",
+		"find", "Interface", `external_ids:sandbox!=""`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pod interfaces, stderr: %q, error: %v", stderr, err)
+	}
+	ports := map[string]podPort{}
+	if stdout == "" {
+		return ports, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.SplitN(line, ",", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected pod interface %q", line)
+		}
+		ports[fields[0]] = podPort{
+			ifaceID:     util.GetExternalIDValByKey(fields[1], "iface-id"),
+			podUID:      util.GetExternalIDValByKey(fields[1], "iface-id-ver"),
+			representor: util.GetExternalIDValByKey(fields[1], "vf-netdev-name") != "",
+		}
+	}
+	return ports, nil
+}
+
+// deletePodPort removes the OVS port and, unless it is a representor, the host side veth of a pod interface
+func deletePodPort(name string, port podPort) error {
+	if stdout, stderr, err := util.RunOVSVsctl("--if-exists", "--with-iface", "del-port", "br-int", name); err != nil {
+		return fmt.Errorf("failed to delete OVS port %s, stdout: %q, stderr: %q, error: %v", name, stdout, stderr, err)
+	}
+	if port.representor {
+		return nil
+	}
+	// the veth is gone already if the network namespace of the pod was destroyed
+	link, err := util.GetNetLinkOps().LinkByName(name)
+	if err != nil {
+		if util.GetNetLinkOps().IsLinkNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to lookup link %s: %v", name, err)
+	}
+	if err := util.GetNetLinkOps().LinkDelete(link); err != nil {
+		return fmt.Errorf("failed to remove link %s: %v", name, err)
+	}
+	return nil
+}
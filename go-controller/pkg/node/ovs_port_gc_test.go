@@ -0,0 +1,116 @@
+package node
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/mock"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+func staleOVSPortRemovals(result string) float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeStaleOVSPortRemovals.WithLabelValues(result).Write(m)).To(Succeed())
+	return m.GetCounter().GetValue()
+}
+
+func staleOVSPorts() float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeStaleOVSPorts.Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
+
+var _ = Describe("OVS port garbage collection", func() {
+	const listPortsCmd = `ovs-vsctl --timeout=15 --no-heading --data=bare --format=csv --columns=name,external_ids find Interface external_ids:sandbox!=""`
+	const ports = "live_port,iface-id=ns_live iface-id-ver=uid-live sandbox=111\n" +
+		"old_port,iface-id=ns_old sandbox=222\n" +
+		"gone_veth,iface-id=ns_gone iface-id-ver=uid-gone sandbox=333\n" +
+		"gone_rep,iface-id=ns_gonerep iface-id-ver=uid-gonerep sandbox=444 vf-netdev-name=ens1f0v2"
+
+	var (
+		fexec          *ovntest.FakeExec
+		netlinkOpsMock *utilMocks.NetLinkOps
+		gc             *ovsPortGC
+	)
+
+	listPods := func() ([]*kapi.Pod, error) {
+		return []*kapi.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "live", UID: "uid-live"}}}, nil
+	}
+
+	addListPorts := func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: listPortsCmd, Output: ports})
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		netlinkOpsMock = new(utilMocks.NetLinkOps)
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+		metrics.MetricNodeStaleOVSPorts.Set(0)
+		metrics.MetricNodeStaleOVSPortRemovals.Reset()
+	})
+
+	AfterEach(func() {
+		util.ResetNetLinkOpMockInst()
+	})
+
+	It("removes the ports of pods that no longer exist once found by two scans", func() {
+		gc = newOVSPortGC(false, listPods)
+		addListPorts()
+		Expect(gc.sync()).To(Succeed())
+		Expect(staleOVSPorts()).To(Equal(2.0))
+		Expect(staleOVSPortRemovals(ovsPortGCRemoved)).To(Equal(0.0))
+
+		addListPorts()
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists --with-iface del-port br-int gone_rep",
+			"ovs-vsctl --timeout=15 --if-exists --with-iface del-port br-int gone_veth",
+		})
+		link := new(netlink_mocks.Link)
+		netlinkOpsMock.On("LinkByName", "gone_veth").Return(link, nil)
+		netlinkOpsMock.On("LinkDelete", link).Return(nil)
+		Expect(gc.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		netlinkOpsMock.AssertExpectations(GinkgoT())
+		Expect(staleOVSPortRemovals(ovsPortGCRemoved)).To(Equal(2.0))
+	})
+
+	It("ignores the veth of a removed port that is gone already", func() {
+		gc = newOVSPortGC(false, listPods)
+		gc.suspects.Insert("gone_veth")
+		addListPorts()
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists --with-iface del-port br-int gone_veth",
+		})
+		netlinkOpsMock.On("LinkByName", "gone_veth").Return(nil, fmt.Errorf("link not found"))
+		netlinkOpsMock.On("IsLinkNotFoundError", mock.Anything).Return(true)
+		Expect(gc.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(staleOVSPortRemovals(ovsPortGCRemoved)).To(Equal(1.0))
+		Expect(staleOVSPortRemovals(ovsPortGCError)).To(Equal(0.0))
+	})
+
+	It("only reports the ports of pods that no longer exist in dry run", func() {
+		gc = newOVSPortGC(true, listPods)
+		addListPorts()
+		Expect(gc.sync()).To(Succeed())
+		addListPorts()
+		Expect(gc.sync()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(staleOVSPortRemovals(ovsPortGCDryRun)).To(Equal(2.0))
+		Expect(staleOVSPortRemovals(ovsPortGCRemoved)).To(Equal(0.0))
+	})
+})
@@ -0,0 +1,181 @@
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// ovsUpgradeQuiesceDefaultTimeout is how long the node stays quiesced when the request sets no timeout
+	ovsUpgradeQuiesceDefaultTimeout = 10 * time.Minute
+	// ovsUpgradeQuiesceMaxTimeout caps the timeout of the requests, the node resumes on its own after it
+	ovsUpgradeQuiesceMaxTimeout = time.Hour
+	// ovsUpgradeQuiesceSyncPeriod is how often a quiesced node checks whether it timed out
+	ovsUpgradeQuiesceSyncPeriod = 10 * time.Second
+)
+
+// ovsUpgradeQuiesceController quiesces the node while the OVS upgrade quiesce annotation is set, so that an
+// external agent upgrading OVS or ovn-controller on the node doesn't race with ovnkube-node: the CNI server
+// rejects the pod requests and the gateway flows are not synced. The node resumes once the annotation is
+// removed, or on its own once the timeout of the request expires; a request that timed out is only honored
+// again once its id or timeout changes.
+type ovsUpgradeQuiesceController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	kube         kube.Interface
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+	clock        clock.Clock
+	// quiesce and resume quiesce and resume the node
+	quiesce func()
+	resume  func()
+
+	// quiesced is the request the node is quiesced for, nil while the node runs normally
+	quiesced *util.OVSUpgradeQuiesce
+	// resumeAt is when the quiesced node resumes on its own
+	resumeAt time.Time
+	// timedOut is the last request the node resumed on its own for
+	timedOut *util.OVSUpgradeQuiesce
+}
+
+func newOVSUpgradeQuiesceController(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory,
+	quiesce, resume func(), stopChan <-chan struct{}) *ovsUpgradeQuiesceController {
+	return &ovsUpgradeQuiesceController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		kube:         k,
+		stopChan:     stopChan,
+		trigger:      make(chan struct{}, 1),
+		clock:        clock.RealClock{},
+		quiesce:      quiesce,
+		resume:       resume,
+	}
+}
+
+func (c *ovsUpgradeQuiesceController) Run(doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && util.NodeOVSUpgradeQuiesceAnnotationChanged(oldNode, newNode) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the OVS upgrade quiesce: %w", err)
+	}
+
+	runPeriodicSync(c.stopChan, doneWg, ovsUpgradeQuiesceSyncPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to sync the OVS upgrade quiesce of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *ovsUpgradeQuiesceController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync quiesces the node while a quiesce is requested and resumes it once the request is removed or timed out
+func (c *ovsUpgradeQuiesceController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	status, err := util.ParseNodeOVSUpgradeQuiesceStatus(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid OVS upgrade quiesce status of node %s: %v", c.nodeName, err)
+	}
+	request, err := util.ParseNodeOVSUpgradeQuiesce(node)
+	if err != nil {
+		c.resumeNode("the quiesce request was removed")
+		if util.IsAnnotationNotSetError(err) {
+			c.timedOut = nil
+			return c.setStatus(status, nil)
+		}
+		return c.setStatus(status, &util.OVSUpgradeQuiesceStatus{Phase: util.OVSUpgradeFailed, Message: err.Error()})
+	}
+	if c.timedOut != nil && *c.timedOut == *request {
+		return nil
+	}
+
+	if c.quiesced == nil || *c.quiesced != *request {
+		c.resumeAt = c.clock.Now().Add(ovsUpgradeQuiesceTimeout(request)).UTC().Truncate(time.Second)
+		if c.quiesced == nil && status != nil && status.ID == request.ID {
+			// ovnkube-node restarted while the node was quiesced, keep the deadline of the request
+			if status.Phase == util.OVSUpgradeTimedOut {
+				c.timedOut = request
+				return nil
+			}
+			if status.Phase == util.OVSUpgradeQuiesced && status.ResumeAt != nil {
+				c.resumeAt = status.ResumeAt.UTC()
+			}
+		}
+		if c.quiesced == nil {
+			klog.Infof("Quiescing node %s for the OVS upgrade %s until %s", c.nodeName, request.ID, c.resumeAt)
+			c.quiesce()
+			metrics.MetricNodeOVSUpgradeQuiesced.Set(1)
+		}
+		c.quiesced = request
+	}
+
+	if !c.clock.Now().Before(c.resumeAt) {
+		c.resumeNode(fmt.Sprintf("the OVS upgrade %s timed out", request.ID))
+		c.timedOut = request
+		return c.setStatus(status, &util.OVSUpgradeQuiesceStatus{ID: request.ID, Phase: util.OVSUpgradeTimedOut,
+			Message: fmt.Sprintf("resumed on its own after %s", ovsUpgradeQuiesceTimeout(request))})
+	}
+	resumeAt := c.resumeAt
+	return c.setStatus(status, &util.OVSUpgradeQuiesceStatus{ID: request.ID, Phase: util.OVSUpgradeQuiesced, ResumeAt: &resumeAt})
+}
+
+// resumeNode resumes the node if it is quiesced
+func (c *ovsUpgradeQuiesceController) resumeNode(reason string) {
+	if c.quiesced == nil {
+		return
+	}
+	klog.Infof("Resuming node %s quiesced for the OVS upgrade %s: %s", c.nodeName, c.quiesced.ID, reason)
+	c.resume()
+	metrics.MetricNodeOVSUpgradeQuiesced.Set(0)
+	c.quiesced = nil
+}
+
+func (c *ovsUpgradeQuiesceController) setStatus(current, status *util.OVSUpgradeQuiesceStatus) error {
+	if reflect.DeepEqual(current, status) {
+		return nil
+	}
+	nodeAnnotator := newNodeAnnotator(c.kube, c.nodeName)
+	if err := util.SetNodeOVSUpgradeQuiesceStatus(nodeAnnotator, status); err != nil {
+		return err
+	}
+	return nodeAnnotator.Run()
+}
+
+// ovsUpgradeQuiesceTimeout returns how long the node stays quiesced for the request
+func ovsUpgradeQuiesceTimeout(request *util.OVSUpgradeQuiesce) time.Duration {
+	if request.Timeout == 0 {
+		return ovsUpgradeQuiesceDefaultTimeout
+	}
+	timeout := time.Duration(request.Timeout) * time.Second
+	if timeout > ovsUpgradeQuiesceMaxTimeout {
+		return ovsUpgradeQuiesceMaxTimeout
+	}
+	return timeout
+}
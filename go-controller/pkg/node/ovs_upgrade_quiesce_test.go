@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("OVS upgrade quiesce", func() {
+	const nodeName = "node1"
+	var (
+		kubeFakeClient *fake.Clientset
+		wf             *factory.WatchFactory
+		fakeClock      *clocktesting.FakeClock
+		c              *ovsUpgradeQuiesceController
+		quiesced       bool
+		quiesces       int
+	)
+
+	start := func(annotations map[string]string) {
+		node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName, Annotations: annotations}}
+		kubeFakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{node}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		c = newOVSUpgradeQuiesceController(nodeName, &kube.Kube{KClient: kubeFakeClient}, wf,
+			func() {
+				quiesced = true
+				quiesces++
+			},
+			func() { quiesced = false }, make(chan struct{}))
+		c.clock = fakeClock
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		fakeClock = clocktesting.NewFakeClock(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC))
+		quiesced = false
+		quiesces = 0
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	getNode := func() *v1.Node {
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return node
+	}
+
+	getStatus := func() *util.OVSUpgradeQuiesceStatus {
+		status, err := util.ParseNodeOVSUpgradeQuiesceStatus(getNode())
+		if util.IsAnnotationNotSetError(err) {
+			return nil
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return status
+	}
+
+	// setRequest sets the quiesce request of the node, or removes it when empty, and waits for the informer
+	setRequest := func(request string) {
+		node := getNode()
+		if request == "" {
+			delete(node.Annotations, util.OvnNodeOVSUpgradeQuiesce)
+		} else {
+			node.Annotations[util.OvnNodeOVSUpgradeQuiesce] = request
+		}
+		_, err := kubeFakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() string {
+			node, _ := wf.GetNode(nodeName)
+			return node.Annotations[util.OvnNodeOVSUpgradeQuiesce]
+		}).Should(Equal(request))
+	}
+
+	It("quiesces the node until the request is removed", func() {
+		start(map[string]string{util.OvnNodeOVSUpgradeQuiesce: `{"id": "ovs-3.4.1", "timeout": 600}`})
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeTrue())
+		resumeAt := time.Date(2024, 5, 2, 10, 10, 0, 0, time.UTC)
+		Expect(getStatus()).To(Equal(&util.OVSUpgradeQuiesceStatus{
+			ID: "ovs-3.4.1", Phase: util.OVSUpgradeQuiesced, ResumeAt: &resumeAt}))
+
+		fakeClock.Step(time.Minute)
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeTrue())
+		Expect(quiesces).To(Equal(1))
+
+		setRequest("")
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeFalse())
+		Expect(getStatus()).To(BeNil())
+	})
+
+	It("resumes the node on its own once the request times out", func() {
+		start(map[string]string{util.OvnNodeOVSUpgradeQuiesce: `{"id": "ovs-3.4.1"}`})
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeTrue())
+
+		fakeClock.Step(ovsUpgradeQuiesceDefaultTimeout)
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeFalse())
+		Expect(getStatus().Phase).To(Equal(util.OVSUpgradeTimedOut))
+
+		// the request that timed out is only honored again once requested again
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeFalse())
+		setRequest(`{"id": "ovs-3.4.1-retry"}`)
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeTrue())
+		Expect(getStatus().ID).To(Equal("ovs-3.4.1-retry"))
+	})
+
+	It("keeps the deadline of the request across restarts", func() {
+		start(map[string]string{
+			util.OvnNodeOVSUpgradeQuiesce:       `{"id": "ovs-3.4.1", "timeout": 600}`,
+			util.OvnNodeOVSUpgradeQuiesceStatus: `{"id": "ovs-3.4.1", "phase": "Quiesced", "resume-at": "2024-05-02T10:01:00Z"}`,
+		})
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeTrue())
+
+		fakeClock.Step(time.Minute)
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeFalse())
+		Expect(getStatus().Phase).To(Equal(util.OVSUpgradeTimedOut))
+	})
+
+	It("reports an invalid request", func() {
+		start(map[string]string{util.OvnNodeOVSUpgradeQuiesce: `{"timeout": 600}`})
+		Expect(c.sync()).To(Succeed())
+		Expect(quiesced).To(BeFalse())
+		Expect(getStatus().Phase).To(Equal(util.OVSUpgradeFailed))
+	})
+})
@@ -0,0 +1,11 @@
+package ovspinning
+
+import (
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// NodeTuningEnabled returns whether the configuration enables the node tuning controller
+func NodeTuningEnabled() bool {
+	return config.OvnKubeNode.OVSCPUSet != "" || config.OvnKubeNode.OVNControllerCPUSet != "" ||
+		config.OvnKubeNode.UplinkRxQueueCPUSet != "" || config.OvnKubeNode.TuningNUMALocal
+}
@@ -0,0 +1,439 @@
+//go:build linux
+// +build linux
+
+package ovspinning
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+// These variables are meant to be used in unit tests
+var tuningTickDuration time.Duration = 10 * time.Second
+var getOvnControllerPIDFn func() (string, error) = util.GetOvnControllerPID
+var sysClassNetDir string = "/sys/class/net"
+var sysNodeDir string = "/sys/devices/system/node"
+var procDir string = "/proc"
+var sysKernelIRQDir string = "/sys/kernel/irq"
+
+// Settings changed by the node tuning, reported in metrics
+const (
+	settingCPUAffinity     = "cpu-affinity"
+	settingMemoryNodes     = "memory-nodes"
+	settingRxQueueAffinity = "rx-queue-affinity"
+)
+
+// NodeTuningController pins the OVS and OVN daemons to their configured CPU sets, steers the interrupts
+// of the gateway uplink rx queues to the configured CPUs and restricts the memory of the pinned daemons
+// running in their own cgroup to the NUMA node of the uplink. The settings are checked periodically so that they are applied again
+// to the restarted daemons. In dry-run mode, the changes are logged instead of applied.
+type NodeTuningController struct {
+	uplink  string
+	dryRun  bool
+	daemons []tunedDaemon
+	// rxQueueCPUs are the CPUs of the uplink rx queues interrupts, nil when they are not steered
+	rxQueueCPUs *unix.CPUSet
+	// memoryNodes is the NUMA node list the memory of the daemons is restricted to, empty when not restricted
+	memoryNodes string
+	// dryRunChanges are the last changes logged in dry-run mode by setting, so that each change is logged once
+	dryRunChanges map[string]string
+	// skipped are the last reasons a setting was not applied by setting, so that each reason is logged once
+	skipped map[string]string
+}
+
+// tunedDaemon is a daemon pinned to a CPU set
+type tunedDaemon struct {
+	name   string
+	getPID func() (string, error)
+	cpus   unix.CPUSet
+}
+
+// NewNodeTuningController returns the node tuning controller of the ovnkube-node configuration, uplink is
+// the gateway uplink interface of the node, empty when it has none
+func NewNodeTuningController(uplink string) (*NodeTuningController, error) {
+	c := &NodeTuningController{
+		uplink:        uplink,
+		dryRun:        config.OvnKubeNode.TuningDryRun,
+		dryRunChanges: map[string]string{},
+		skipped:       map[string]string{},
+	}
+	if config.OvnKubeNode.OVSCPUSet != "" {
+		cpus, err := parseCPUList(config.OvnKubeNode.OVSCPUSet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OVS CPU set: %w", err)
+		}
+		c.daemons = append(c.daemons,
+			tunedDaemon{name: "ovs-vswitchd", getPID: getOvsVSwitchdPIDFn, cpus: cpus},
+			tunedDaemon{name: "ovsdb-server", getPID: getOvsDBServerPIDFn, cpus: cpus})
+	}
+	if config.OvnKubeNode.OVNControllerCPUSet != "" {
+		cpus, err := parseCPUList(config.OvnKubeNode.OVNControllerCPUSet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ovn-controller CPU set: %w", err)
+		}
+		c.daemons = append(c.daemons, tunedDaemon{name: "ovn-controller", getPID: getOvnControllerPIDFn, cpus: cpus})
+	}
+	// the tuning of the uplink is left out when the node has no gateway uplink, the daemons are still pinned
+	if config.OvnKubeNode.UplinkRxQueueCPUSet != "" {
+		cpus, err := parseCPUList(config.OvnKubeNode.UplinkRxQueueCPUSet)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uplink rx queue CPU set: %w", err)
+		}
+		if uplink == "" {
+			klog.Warningf("Node tuning: no gateway uplink to steer the rx queues of, the uplink rx queue CPU set is ignored")
+		} else {
+			c.rxQueueCPUs = &cpus
+		}
+	}
+	if config.OvnKubeNode.TuningNUMALocal {
+		if uplink == "" {
+			klog.Warningf("Node tuning: no gateway uplink to get the NUMA node of, the node tuning is not NUMA local")
+		} else if err := c.setNUMALocal(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// setNUMALocal restricts the memory of the daemons to the NUMA node of the uplink and, unless configured,
+// steers the uplink rx queues to the CPUs of that NUMA node
+func (c *NodeTuningController) setNUMALocal() error {
+	numaNode, err := readTrimmedFile(filepath.Join(sysClassNetDir, c.uplink, "device", "numa_node"))
+	if err != nil {
+		return fmt.Errorf("failed to get the NUMA node of uplink %s: %w", c.uplink, err)
+	}
+	if numaNode == "-1" {
+		klog.Warningf("Uplink %s is not attached to a NUMA node, the node tuning is not NUMA local", c.uplink)
+		return nil
+	}
+	c.memoryNodes = numaNode
+	if c.rxQueueCPUs != nil {
+		return nil
+	}
+	cpuList, err := readTrimmedFile(filepath.Join(sysNodeDir, "node"+numaNode, "cpulist"))
+	if err != nil {
+		return fmt.Errorf("failed to get the CPUs of NUMA node %s: %w", numaNode, err)
+	}
+	cpus, err := parseCPUList(cpuList)
+	if err != nil {
+		return fmt.Errorf("invalid CPU list of NUMA node %s: %w", numaNode, err)
+	}
+	c.rxQueueCPUs = &cpus
+	return nil
+}
+
+// Run applies the node tuning until stopCh is closed
+func (c *NodeTuningController) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting node tuning (dry-run: %t)", c.dryRun)
+	defer klog.Infof("Stopping node tuning")
+
+	ticker := time.NewTicker(tuningTickDuration)
+	defer ticker.Stop()
+	for {
+		if err := c.apply(); err != nil {
+			klog.Warningf("Error while tuning the node: %v", err)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// apply applies all the settings, it does not stop at the first setting failing to apply
+func (c *NodeTuningController) apply() error {
+	var errs []error
+	for _, daemon := range c.daemons {
+		pidStr, err := daemon.getPID()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't retrieve %s PID: %w", daemon.name, err))
+			continue
+		}
+		pid, err := strconv.Atoi(pidStr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can't convert %s PID[%s] to integer: %w", daemon.name, pidStr, err))
+			continue
+		}
+		if err := c.tuneCPUAffinity(daemon, pid); err != nil {
+			errs = append(errs, err)
+		}
+		if c.memoryNodes != "" {
+			if err := c.tuneMemoryNodes(daemon.name, pid); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if c.rxQueueCPUs != nil {
+		if err := c.tuneRxQueues(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func (c *NodeTuningController) tuneCPUAffinity(daemon tunedDaemon, pid int) error {
+	var current unix.CPUSet
+	if err := unix.SchedGetaffinity(pid, &current); err != nil {
+		return fmt.Errorf("can't get %s (PID:%d) CPU affinity: %w", daemon.name, pid, err)
+	}
+	if current == daemon.cpus {
+		return nil
+	}
+	change := fmt.Sprintf("set CPU affinity of %s (PID:%d) to %s, was %s", daemon.name, pid, printCPUSet(daemon.cpus), printCPUSet(current))
+	return c.change(daemon.name, settingCPUAffinity, change, func() error {
+		taskIDs, err := getThreadsOfProcess(pid)
+		if err != nil {
+			return fmt.Errorf("can't get tasks of %s (PID:%d): %w", daemon.name, pid, err)
+		}
+		setTasksCPUAffinity(pid, taskIDs, daemon.cpus)
+		return nil
+	})
+}
+
+// tuneMemoryNodes restricts the memory of the daemon to the NUMA nodes with the cpuset controller of its
+// cgroup. The cgroup is only changed when the daemon is its only process, the memory of the other
+// processes of a shared cgroup is not restricted.
+func (c *NodeTuningController) tuneMemoryNodes(name string, pid int) error {
+	cgroupDir, err := cpusetCgroupDir(pid)
+	if err != nil {
+		return fmt.Errorf("can't get the cpuset cgroup of %s (PID:%d): %w", name, pid, err)
+	}
+	procs, err := readTrimmedFile(filepath.Join(cgroupDir, "cgroup.procs"))
+	if err != nil {
+		return fmt.Errorf("can't get the processes of the cgroup of %s (PID:%d): %w", name, pid, err)
+	}
+	if procs != strconv.Itoa(pid) {
+		c.skip(name, settingMemoryNodes, fmt.Sprintf("not restricting the memory nodes of %s (PID:%d), cgroup %s has other processes",
+			name, pid, cgroupDir))
+		return nil
+	}
+	memsPath := filepath.Join(cgroupDir, "cpuset.mems")
+	current, err := readTrimmedFile(memsPath)
+	if err != nil {
+		return fmt.Errorf("can't get the memory nodes of %s (PID:%d): %w", name, pid, err)
+	}
+	if current == c.memoryNodes {
+		return nil
+	}
+	change := fmt.Sprintf("set memory nodes of %s (PID:%d) to %s in %s, was %q", name, pid, c.memoryNodes, memsPath, current)
+	return c.change(name, settingMemoryNodes, change, func() error {
+		return os.WriteFile(memsPath, []byte(c.memoryNodes), 0)
+	})
+}
+
+// tuneRxQueues steers the interrupts of the uplink rx queues to the rx queue CPUs. The rx queue interrupts
+// are the MSI interrupts of the uplink device named after the uplink and its rx queues, e.g. eth0-rx-0 or
+// eth0-TxRx-0; the other interrupts of the device are left alone.
+func (c *NodeTuningController) tuneRxQueues() error {
+	irqs, err := os.ReadDir(filepath.Join(sysClassNetDir, c.uplink, "device", "msi_irqs"))
+	if err != nil {
+		return fmt.Errorf("can't get the interrupts of uplink %s: %w", c.uplink, err)
+	}
+	var errs []error
+	rxQueues := 0
+	for _, irq := range irqs {
+		actions, err := readTrimmedFile(filepath.Join(sysKernelIRQDir, irq.Name(), "actions"))
+		if err != nil {
+			// the interrupt may have been freed
+			klog.V(5).Infof("Can't get the actions of interrupt %s of uplink %s: %v", irq.Name(), c.uplink, err)
+			continue
+		}
+		if !isRxQueueInterrupt(c.uplink, actions) {
+			continue
+		}
+		rxQueues++
+		affinityPath := filepath.Join(procDir, "irq", irq.Name(), "smp_affinity_list")
+		current, err := readTrimmedFile(affinityPath)
+		if err != nil {
+			klog.V(5).Infof("Can't get the affinity of interrupt %s of uplink %s: %v", irq.Name(), c.uplink, err)
+			continue
+		}
+		if currentCPUs, err := parseCPUList(current); err == nil && currentCPUs == *c.rxQueueCPUs {
+			continue
+		}
+		cpuList := printCPUSet(*c.rxQueueCPUs)
+		change := fmt.Sprintf("set affinity of interrupt %s (%s) of uplink %s to %s, was %s", irq.Name(), actions, c.uplink, cpuList, current)
+		if err := c.change(c.uplink+"/"+irq.Name(), settingRxQueueAffinity, change, func() error {
+			return os.WriteFile(affinityPath, []byte(cpuList), 0)
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if rxQueues == 0 {
+		c.skip(c.uplink, settingRxQueueAffinity, fmt.Sprintf("not steering the rx queues of uplink %s, none of its interrupts is named after an rx queue",
+			c.uplink))
+	}
+	return utilerrors.Join(errs...)
+}
+
+// isRxQueueInterrupt returns whether the actions of an interrupt of the uplink are the ones of an rx queue
+func isRxQueueInterrupt(uplink, actions string) bool {
+	for _, action := range strings.Split(actions, ",") {
+		action = strings.ToLower(strings.TrimSpace(action))
+		if strings.Contains(action, strings.ToLower(uplink)+"-") && strings.Contains(action, "rx") {
+			return true
+		}
+	}
+	return false
+}
+
+// skip logs why a setting is not applied, once for each reason
+func (c *NodeTuningController) skip(target, setting, reason string) {
+	key := target + "/" + setting
+	if c.skipped[key] != reason {
+		klog.Warningf("Node tuning: %s", reason)
+		c.skipped[key] = reason
+	}
+}
+
+// change applies a setting change, or logs it in dry-run mode, and reports it in metrics
+func (c *NodeTuningController) change(target, setting, change string, apply func() error) error {
+	metricTarget := strings.Split(target, "/")[0]
+	if c.dryRun {
+		key := target + "/" + setting
+		if c.dryRunChanges[key] != change {
+			klog.Infof("Node tuning dry-run: would %s", change)
+			c.dryRunChanges[key] = change
+		}
+		metrics.MetricNodeTuningChanges.WithLabelValues(metricTarget, setting, "dry-run").Inc()
+		return nil
+	}
+	if err := apply(); err != nil {
+		metrics.MetricNodeTuningChanges.WithLabelValues(metricTarget, setting, "error").Inc()
+		return fmt.Errorf("failed to %s: %w", change, err)
+	}
+	klog.Infof("Node tuning: %s", change)
+	metrics.MetricNodeTuningChanges.WithLabelValues(metricTarget, setting, "applied").Inc()
+	return nil
+}
+
+// cpusetCgroupDir returns the directory of the cgroup of the process in the cgroup v1 cpuset hierarchy or
+// the cgroup v2 unified hierarchy. The cgroup paths of the process are relative to the cgroup namespace of
+// ovnkube-node, as are the roots of the cgroup mounts, so the cgroup directory is found under the mount
+// of the hierarchy, provided the cgroup is visible in the cgroup namespace of ovnkube-node.
+func cpusetCgroupDir(pid int) (string, error) {
+	cgroups, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	cpusetPath, unifiedPath := "", ""
+	for _, line := range strings.Split(strings.TrimSpace(string(cgroups)), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			if controller == "cpuset" {
+				cpusetPath = fields[2]
+			}
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			unifiedPath = fields[2]
+		}
+	}
+	cgroupPath := cpusetPath
+	if cgroupPath == "" {
+		cgroupPath = unifiedPath
+	}
+	if cgroupPath == "" {
+		return "", fmt.Errorf("no cpuset cgroup")
+	}
+	mountRoot, mountPoint, err := cgroupMount(cpusetPath != "")
+	if err != nil {
+		return "", err
+	}
+	relPath, err := filepath.Rel(mountRoot, cgroupPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return "", fmt.Errorf("cgroup %s is not visible under the cgroup mount %s of root %s", cgroupPath, mountPoint, mountRoot)
+	}
+	return filepath.Join(mountPoint, relPath), nil
+}
+
+// cgroupMount returns the root and the mount point of the cgroup v1 cpuset hierarchy, or of the cgroup v2
+// unified hierarchy, mounted in the mount namespace of ovnkube-node
+func cgroupMount(cpusetV1 bool) (string, string, error) {
+	mountInfo, err := os.ReadFile(filepath.Join(procDir, "self", "mountinfo"))
+	if err != nil {
+		return "", "", err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(mountInfo)), "\n") {
+		// ID parent-ID major:minor root mount-point options [optional-fields...] - fs-type source super-options
+		fields := strings.Fields(line)
+		separator := -1
+		for i, field := range fields {
+			if field == "-" {
+				separator = i
+				break
+			}
+		}
+		if separator < 5 || len(fields) < separator+4 {
+			continue
+		}
+		fsType, superOptions := fields[separator+1], fields[separator+3]
+		if cpusetV1 {
+			if fsType != "cgroup" {
+				continue
+			}
+			for _, option := range strings.Split(superOptions, ",") {
+				if option == "cpuset" {
+					return fields[3], fields[4], nil
+				}
+			}
+		} else if fsType == "cgroup2" {
+			return fields[3], fields[4], nil
+		}
+	}
+	if cpusetV1 {
+		return "", "", fmt.Errorf("the cgroup v1 cpuset hierarchy is not mounted")
+	}
+	return "", "", fmt.Errorf("the cgroup v2 unified hierarchy is not mounted")
+}
+
+// parseCPUList parses a CPU list in the linux CPU list format, e.g. 0-5,8,10
+func parseCPUList(cpuList string) (unix.CPUSet, error) {
+	var cpus unix.CPUSet
+	if strings.TrimSpace(cpuList) == "" {
+		return cpus, fmt.Errorf("empty CPU list")
+	}
+	for _, part := range strings.Split(cpuList, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return cpus, fmt.Errorf("invalid CPU %q in CPU list %q", bounds[0], cpuList)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return cpus, fmt.Errorf("invalid CPU %q in CPU list %q", bounds[1], cpuList)
+			}
+		}
+		if start < 0 || end < start || end >= len(cpus)*64 {
+			return cpus, fmt.Errorf("invalid CPU range %q in CPU list %q", part, cpuList)
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus.Set(cpu)
+		}
+	}
+	return cpus, nil
+}
+
+func readTrimmedFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
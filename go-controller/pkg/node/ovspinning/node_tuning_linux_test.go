@@ -0,0 +1,169 @@
+//go:build linux
+// +build linux
+
+package ovspinning
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+func TestParseCPUList(t *testing.T) {
+	cpus, err := parseCPUList("2-3, 6-8,14")
+	assert.NoError(t, err)
+	assert.Equal(t, "2-3,6-8,14", printCPUSet(cpus))
+
+	for _, invalid := range []string{"", "a", "3-1", "1-", "-1", "1024"} {
+		_, err = parseCPUList(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNodeTuningRxQueues(t *testing.T) {
+	defer mockTuningDirs(t)()
+	defer mockNodeTuningConfig(t)()
+
+	writeFile(t, filepath.Join(sysClassNetDir, "eth0", "device", "numa_node"), "1")
+	writeFile(t, filepath.Join(sysNodeDir, "node1", "cpulist"), "4-7")
+	for irq, actions := range map[string]string{"39": "mlx5_async0", "40": "eth0-TxRx-0", "41": "eth0-rx-1", "42": "eth0-tx-0"} {
+		writeFile(t, filepath.Join(sysClassNetDir, "eth0", "device", "msi_irqs", irq), "msix")
+		writeFile(t, filepath.Join(sysKernelIRQDir, irq, "actions"), actions+"\n")
+		writeFile(t, filepath.Join(procDir, "irq", irq, "smp_affinity_list"), "0-7")
+	}
+	writeFile(t, filepath.Join(procDir, "irq", "41", "smp_affinity_list"), "4-7")
+
+	// the rx queues are steered to the CPUs of the NUMA node of the uplink by default
+	config.OvnKubeNode.TuningNUMALocal = true
+	c, err := NewNodeTuningController("eth0")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", c.memoryNodes)
+	assert.Equal(t, "4-7", printCPUSet(*c.rxQueueCPUs))
+
+	assert.NoError(t, c.tuneRxQueues())
+	assert.Equal(t, "4-7", readFile(t, filepath.Join(procDir, "irq", "40", "smp_affinity_list")))
+	// only the rx queue interrupts are steered
+	assert.Equal(t, "0-7", readFile(t, filepath.Join(procDir, "irq", "39", "smp_affinity_list")))
+	assert.Equal(t, "0-7", readFile(t, filepath.Join(procDir, "irq", "42", "smp_affinity_list")))
+
+	config.OvnKubeNode.UplinkRxQueueCPUSet = "2"
+	config.OvnKubeNode.TuningDryRun = true
+	c, err = NewNodeTuningController("eth0")
+	assert.NoError(t, err)
+	assert.NoError(t, c.tuneRxQueues())
+	assert.Equal(t, "4-7", readFile(t, filepath.Join(procDir, "irq", "40", "smp_affinity_list")))
+	assert.Equal(t, map[string]string{
+		"eth0/40/rx-queue-affinity": "set affinity of interrupt 40 (eth0-TxRx-0) of uplink eth0 to 2, was 4-7",
+		"eth0/41/rx-queue-affinity": "set affinity of interrupt 41 (eth0-rx-1) of uplink eth0 to 2, was 4-7",
+	}, c.dryRunChanges)
+
+	// the tuning of the uplink is left out without gateway uplink
+	c, err = NewNodeTuningController("")
+	assert.NoError(t, err)
+	assert.Nil(t, c.rxQueueCPUs)
+	assert.Empty(t, c.memoryNodes)
+}
+
+func TestNodeTuningMemoryNodes(t *testing.T) {
+	defer mockTuningDirs(t)()
+	defer mockNodeTuningConfig(t)()
+
+	pid := 1234
+	cgroupRoot := filepath.Join(procDir, "..", "cgroup")
+	writeFile(t, filepath.Join(procDir, "self", "mountinfo"),
+		"22 1 0:21 / /proc rw,nosuid - proc proc rw\n"+
+			"30 22 0:26 / "+cgroupRoot+" rw,nosuid,nodev,noexec,relatime - cgroup2 cgroup2 rw,nsdelegate\n")
+	memsPath := filepath.Join(cgroupRoot, "system.slice", "ovs-vswitchd.service", "cpuset.mems")
+	procsPath := filepath.Join(cgroupRoot, "system.slice", "ovs-vswitchd.service", "cgroup.procs")
+	writeFile(t, filepath.Join(procDir, fmt.Sprint(pid), "cgroup"), "0::/system.slice/ovs-vswitchd.service\n")
+	writeFile(t, memsPath, "")
+	writeFile(t, procsPath, "1234\n5678\n")
+
+	// the memory of the other processes of the cgroup is not restricted
+	c := &NodeTuningController{memoryNodes: "1", dryRunChanges: map[string]string{}, skipped: map[string]string{}}
+	assert.NoError(t, c.tuneMemoryNodes("ovs-vswitchd", pid))
+	assert.Equal(t, "", readFile(t, memsPath))
+	assert.Len(t, c.skipped, 1)
+
+	writeFile(t, procsPath, "1234\n")
+	assert.NoError(t, c.tuneMemoryNodes("ovs-vswitchd", pid))
+	assert.Equal(t, "1", readFile(t, memsPath))
+
+	// the cgroup paths are relative to the cgroup namespace root of the mount
+	writeFile(t, filepath.Join(procDir, "self", "mountinfo"),
+		"30 22 0:26 /kubepods/pod1 "+cgroupRoot+" rw,nosuid,nodev,noexec,relatime - cgroup2 cgroup2 rw\n")
+	writeFile(t, filepath.Join(procDir, fmt.Sprint(pid), "cgroup"), "0::/kubepods/pod1/ovs\n")
+	dir, err := cpusetCgroupDir(pid)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cgroupRoot, "ovs"), dir)
+	writeFile(t, filepath.Join(procDir, fmt.Sprint(pid), "cgroup"), "0::/system.slice/ovs-vswitchd.service\n")
+	_, err = cpusetCgroupDir(pid)
+	assert.Error(t, err)
+
+	// the cgroup v1 cpuset hierarchy is used when mounted
+	writeFile(t, filepath.Join(procDir, "self", "mountinfo"),
+		"31 22 0:27 / "+filepath.Join(cgroupRoot, "cpuset")+" rw,nosuid shared:9 - cgroup cgroup rw,cpuset\n"+
+			"32 22 0:28 / "+filepath.Join(cgroupRoot, "cpu,cpuacct")+" rw,nosuid shared:10 - cgroup cgroup rw,cpu,cpuacct\n")
+	writeFile(t, filepath.Join(procDir, fmt.Sprint(pid), "cgroup"), "5:cpu,cpuacct:/ovs\n4:cpuset:/ovs\n0::/ovs\n")
+	dir, err = cpusetCgroupDir(pid)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cgroupRoot, "cpuset", "ovs"), dir)
+}
+
+func TestNodeTuningCPUAffinityDryRun(t *testing.T) {
+	var current unix.CPUSet
+	assert.NoError(t, unix.SchedGetaffinity(os.Getpid(), &current))
+	var target unix.CPUSet
+	target.Set(1023)
+
+	c := &NodeTuningController{dryRun: true, dryRunChanges: map[string]string{}}
+	daemon := tunedDaemon{name: "ovn-controller", cpus: target}
+	assert.NoError(t, c.tuneCPUAffinity(daemon, os.Getpid()))
+
+	var after unix.CPUSet
+	assert.NoError(t, unix.SchedGetaffinity(os.Getpid(), &after))
+	assert.Equal(t, current, after)
+	assert.Equal(t, map[string]string{
+		"ovn-controller/cpu-affinity": fmt.Sprintf("set CPU affinity of ovn-controller (PID:%d) to 1023, was %s", os.Getpid(), printCPUSet(current)),
+	}, c.dryRunChanges)
+}
+
+func mockTuningDirs(t *testing.T) func() {
+	tmpDir, err := os.MkdirTemp("", "node-tuning")
+	assert.NoError(t, err)
+
+	previousSysClassNetDir, previousSysNodeDir, previousProcDir, previousSysKernelIRQDir := sysClassNetDir, sysNodeDir, procDir, sysKernelIRQDir
+	sysClassNetDir = filepath.Join(tmpDir, "net")
+	sysNodeDir = filepath.Join(tmpDir, "node")
+	procDir = filepath.Join(tmpDir, "proc")
+	sysKernelIRQDir = filepath.Join(tmpDir, "irq")
+
+	return func() {
+		sysClassNetDir, sysNodeDir, procDir, sysKernelIRQDir = previousSysClassNetDir, previousSysNodeDir, previousProcDir, previousSysKernelIRQDir
+		os.RemoveAll(tmpDir)
+	}
+}
+
+func mockNodeTuningConfig(t *testing.T) func() {
+	assert.NoError(t, config.PrepareTestConfig())
+	return func() {
+		assert.NoError(t, config.PrepareTestConfig())
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package ovspinning
+
+import (
+	"fmt"
+)
+
+// NodeTuningController is not supported on non linux platforms
+type NodeTuningController struct{}
+
+func NewNodeTuningController(_ string) (*NodeTuningController, error) {
+	return nil, fmt.Errorf("node tuning is supported on linux platform only")
+}
+
+func (c *NodeTuningController) Run(_ <-chan struct{}) {}
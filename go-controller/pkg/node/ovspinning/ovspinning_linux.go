@@ -188,15 +188,20 @@ func setProcessCPUAffinity(targetPIDStr string) error {
 	}
 
 	klog.Infof("Setting CPU affinity of PID(%d) (ntasks=%d) to %s, was %s", targetPID, len(taskIDs), printCPUSet(currentProcessCPUs), printCPUSet(targetProcessCPUs))
+	setTasksCPUAffinity(targetPID, taskIDs, currentProcessCPUs)
+
+	return nil
+}
+
+// setTasksCPUAffinity sets the CPU affinity of the given tasks of a process
+func setTasksCPUAffinity(pid int, taskIDs []int, cpus unix.CPUSet) {
 	for _, taskID := range taskIDs {
-		err = unix.SchedSetaffinity(taskID, &currentProcessCPUs)
+		err := unix.SchedSetaffinity(taskID, &cpus)
 		if err != nil {
 			// The task may have been stopped, don't break the loop and continue setting CPU affinity on other tasks.
-			klog.Warningf("Error while setting CPU affinity of task(%d) PID(%d) to %s: %v", taskID, targetPID, printCPUSet(currentProcessCPUs), err)
+			klog.Warningf("Error while setting CPU affinity of task(%d) PID(%d) to %s: %v", taskID, pid, printCPUSet(cpus), err)
 		}
 	}
-
-	return nil
 }
 
 // printCPUSet takes a unix.CPUSet and returns a string representation in canonical linux CPU list format.
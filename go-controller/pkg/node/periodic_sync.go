@@ -0,0 +1,27 @@
+package node
+
+import (
+	"sync"
+	"time"
+)
+
+// runPeriodicSync runs sync in a goroutine tracked by doneWg, right away and then every period and
+// every time trigger fires, until stopChan is closed. trigger may be nil.
+func runPeriodicSync(stopChan <-chan struct{}, doneWg *sync.WaitGroup, period time.Duration,
+	trigger <-chan struct{}, sync func()) {
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			sync()
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+			case <-trigger:
+			}
+		}
+	}()
+}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// podConntrackInterval is how often the conntrack entries of the pods are counted
+const podConntrackInterval = time.Minute
+
+// podConntrackCounts are the conntrack entries of the connections originated by a pod
+type podConntrackCounts struct {
+	entries     int
+	snatEntries int
+	txBytes     uint64
+	rxBytes     uint64
+}
+
+// podConntrackCollector periodically counts the conntrack entries of the connections originated by the pods of
+// the node, matched by the original source IP of the entries, and reports them in metrics by pod. The entries
+// whose reply destination is not the pod IP had their source translated, e.g. by the gateway SNAT, to find the
+// pods exhausting the source ports of the node IP.
+type podConntrackCollector struct {
+	listPods func() ([]*kapi.Pod, error)
+	// listConntrack dumps the conntrack entries of the IP family
+	listConntrack func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error)
+}
+
+func newPodConntrackCollector(listPods func() ([]*kapi.Pod, error)) *podConntrackCollector {
+	return &podConntrackCollector{
+		listPods: listPods,
+		listConntrack: func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+			return util.GetNetLinkOps().ConntrackTableList(netlink.ConntrackTable, family)
+		},
+	}
+}
+
+func (c *podConntrackCollector) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, podConntrackInterval, nil, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to count the conntrack entries of the pods: %v", err)
+		}
+	})
+}
+
+// sync counts the conntrack entries of the pods and reports them, the pods that are gone are no longer reported
+func (c *podConntrackCollector) sync() error {
+	podsByIP, err := c.podsByIP()
+	if err != nil {
+		return err
+	}
+	counts := make(map[ktypes.NamespacedName]*podConntrackCounts, len(podsByIP))
+	for _, pod := range podsByIP {
+		counts[pod] = &podConntrackCounts{}
+	}
+	var families []netlink.InetFamily
+	if config.IPv4Mode {
+		families = append(families, netlink.FAMILY_V4)
+	}
+	if config.IPv6Mode {
+		families = append(families, netlink.FAMILY_V6)
+	}
+	for _, family := range families {
+		flows, err := c.listConntrack(family)
+		if err != nil {
+			return fmt.Errorf("failed to list the conntrack entries: %w", err)
+		}
+		for _, flow := range flows {
+			pod, ok := podsByIP[flow.Forward.SrcIP.String()]
+			if !ok {
+				continue
+			}
+			count := counts[pod]
+			count.entries++
+			if !flow.Reverse.DstIP.Equal(flow.Forward.SrcIP) {
+				count.snatEntries++
+			}
+			count.txBytes += flow.Forward.Bytes
+			count.rxBytes += flow.Reverse.Bytes
+		}
+	}
+
+	metrics.MetricNodePodConntrackEntries.Reset()
+	metrics.MetricNodePodConntrackSNATEntries.Reset()
+	metrics.MetricNodePodConntrackBytes.Reset()
+	for pod, count := range counts {
+		metrics.MetricNodePodConntrackEntries.WithLabelValues(pod.Namespace, pod.Name).Set(float64(count.entries))
+		metrics.MetricNodePodConntrackSNATEntries.WithLabelValues(pod.Namespace, pod.Name).Set(float64(count.snatEntries))
+		metrics.MetricNodePodConntrackBytes.WithLabelValues(pod.Namespace, pod.Name, "tx").Set(float64(count.txBytes))
+		metrics.MetricNodePodConntrackBytes.WithLabelValues(pod.Namespace, pod.Name, "rx").Set(float64(count.rxBytes))
+	}
+	return nil
+}
+
+// podsByIP returns the running pods of the node by IP address, the host network pods share the node IPs and
+// can't be told apart
+func (c *podConntrackCollector) podsByIP() (map[string]ktypes.NamespacedName, error) {
+	pods, err := c.listPods()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods: %w", err)
+	}
+	podsByIP := map[string]ktypes.NamespacedName{}
+	for _, pod := range pods {
+		if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		ips, err := util.DefaultNetworkPodIPs(pod)
+		if err != nil {
+			klog.V(5).Infof("Not counting the conntrack entries of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		for _, ip := range ips {
+			podsByIP[ip.String()] = ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		}
+	}
+	return podsByIP, nil
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+func podConntrackMetric(gauge *prometheus.GaugeVec, labels ...string) float64 {
+	m := &dto.Metric{}
+	Expect(gauge.WithLabelValues(labels...).Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
+
+func podConntrackMetricCount() int {
+	ch := make(chan prometheus.Metric, 10)
+	metrics.MetricNodePodConntrackEntries.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+func podConntrackFlow(srcIP, replyDstIP string, txBytes, rxBytes uint64) *netlink.ConntrackFlow {
+	flow := &netlink.ConntrackFlow{}
+	flow.Forward.SrcIP = net.ParseIP(srcIP)
+	flow.Forward.Bytes = txBytes
+	flow.Reverse.DstIP = net.ParseIP(replyDstIP)
+	flow.Reverse.Bytes = rxBytes
+	return flow
+}
+
+var _ = Describe("Pod conntrack metrics", func() {
+	var (
+		c     *podConntrackCollector
+		pods  []*kapi.Pod
+		flows []*netlink.ConntrackFlow
+	)
+
+	newPod := func(name, ip string) *kapi.Pod {
+		return &kapi.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Status:     kapi.PodStatus{Phase: kapi.PodRunning, PodIPs: []kapi.PodIP{{IP: ip}}},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		metrics.MetricNodePodConntrackEntries.Reset()
+		metrics.MetricNodePodConntrackSNATEntries.Reset()
+		metrics.MetricNodePodConntrackBytes.Reset()
+		hostNetworkPod := newPod("host", "172.18.0.2")
+		hostNetworkPod.Spec.HostNetwork = true
+		pods = []*kapi.Pod{newPod("client", "10.244.0.5"), newPod("idle", "10.244.0.6"), hostNetworkPod}
+		flows = []*netlink.ConntrackFlow{
+			// translated by the gateway SNAT
+			podConntrackFlow("10.244.0.5", "172.18.0.2", 100, 1000),
+			podConntrackFlow("10.244.0.5", "172.18.0.2", 200, 2000),
+			// east-west, not translated
+			podConntrackFlow("10.244.0.5", "10.244.0.5", 10, 20),
+			// not originated by a pod
+			podConntrackFlow("172.18.0.2", "172.18.0.2", 1, 1),
+			podConntrackFlow("10.244.1.5", "10.244.1.5", 1, 1),
+		}
+		c = newPodConntrackCollector(func() ([]*kapi.Pod, error) { return pods, nil })
+		c.listConntrack = func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+			Expect(family).To(Equal(netlink.InetFamily(netlink.FAMILY_V4)))
+			return flows, nil
+		}
+	})
+
+	It("counts the conntrack entries of the pods", func() {
+		Expect(c.sync()).To(Succeed())
+		Expect(podConntrackMetricCount()).To(Equal(2))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackEntries, "ns", "client")).To(Equal(3.0))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackSNATEntries, "ns", "client")).To(Equal(2.0))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackBytes, "ns", "client", "tx")).To(Equal(310.0))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackBytes, "ns", "client", "rx")).To(Equal(3020.0))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackEntries, "ns", "idle")).To(Equal(0.0))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackSNATEntries, "ns", "idle")).To(Equal(0.0))
+	})
+
+	It("stops reporting the pods that are gone", func() {
+		Expect(c.sync()).To(Succeed())
+		Expect(podConntrackMetricCount()).To(Equal(2))
+
+		pods = pods[1:]
+		Expect(c.sync()).To(Succeed())
+		Expect(podConntrackMetricCount()).To(Equal(1))
+		Expect(podConntrackMetric(metrics.MetricNodePodConntrackEntries, "ns", "idle")).To(Equal(0.0))
+	})
+})
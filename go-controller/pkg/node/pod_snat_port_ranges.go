@@ -0,0 +1,242 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// podSNATPortRangeSyncPeriod is how often the pod SNAT port ranges are synced, which picks up the changes of the
+// ephemeral port range of the node
+const podSNATPortRangeSyncPeriod = time.Minute
+
+// podSNATPortRangeAllocator partitions the ephemeral port range of the node into ranges of the gateway pod SNAT
+// port range size and allocates one of them to each IP of the local pods, so that the gateway router SNATs the
+// egress traffic of a pod to its own source ports of the node IP and a chatty pod can't exhaust them for the
+// other pods. The ranges are published in the node pod SNAT port ranges annotation for ovnkube-controller to set
+// them on the pod SNATs. A pod keeps its range as long as it runs and the range is within the ephemeral port
+// range of the node, and the pods share the least used ranges once all of them are allocated.
+type podSNATPortRangeAllocator struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	kube         kube.Interface
+	size         int
+	// localPortRange returns the ephemeral port range of the node
+	localPortRange func() (int, int, error)
+	trigger        chan struct{}
+}
+
+func newPodSNATPortRangeAllocator(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory,
+	size int) *podSNATPortRangeAllocator {
+	return &podSNATPortRangeAllocator{
+		nodeName:       nodeName,
+		watchFactory:   watchFactory,
+		kube:           k,
+		size:           size,
+		localPortRange: getLocalPortRange,
+		trigger:        make(chan struct{}, 1),
+	}
+}
+
+// Start allocates the port ranges of the local pods and keeps them in sync
+func (a *podSNATPortRangeAllocator) Start(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	_, err := a.watchFactory.AddPodHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) {
+			a.requestSync()
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldPod := old.(*kapi.Pod)
+			newPod := new.(*kapi.Pod)
+			if !reflect.DeepEqual(oldPod.Status.PodIPs, newPod.Status.PodIPs) ||
+				util.PodCompleted(oldPod) != util.PodCompleted(newPod) {
+				a.requestSync()
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			a.requestSync()
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("could not add pod event handler for the pod SNAT port ranges: %w", err)
+	}
+	_, err = a.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == a.nodeName && util.NodePodSNATPortRangesAnnotationChanged(oldNode, newNode) {
+				a.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the pod SNAT port ranges: %w", err)
+	}
+
+	runPeriodicSync(stopChan, doneWg, podSNATPortRangeSyncPeriod, a.trigger, func() {
+		if err := a.sync(); err != nil {
+			klog.Errorf("Failed to allocate the pod SNAT port ranges of node %s: %v", a.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (a *podSNATPortRangeAllocator) requestSync() {
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync allocates the port ranges of the IPs of the local pods and updates the node annotation when they differ
+func (a *podSNATPortRangeAllocator) sync() error {
+	low, high, err := a.localPortRange()
+	if err != nil {
+		return fmt.Errorf("failed to get the ephemeral port range: %w", err)
+	}
+	pods, err := a.watchFactory.GetAllPods()
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	var podIPs []net.IP
+	for _, pod := range pods {
+		if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		ips, err := util.DefaultNetworkPodIPs(pod)
+		if err != nil {
+			klog.V(5).Infof("Not allocating a SNAT port range to pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		podIPs = append(podIPs, ips...)
+	}
+	node, err := a.watchFactory.GetNode(a.nodeName)
+	if err != nil {
+		return err
+	}
+	current, err := util.ParseNodePodSNATPortRanges(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid pod SNAT port ranges annotation of node %s: %v", a.nodeName, err)
+	}
+	if (high-low+1)/a.size == 0 {
+		klog.Warningf("The ephemeral port range %d-%d of node %s is smaller than the pod SNAT port range size %d, "+
+			"the pods share all its ports", low, high, a.nodeName, a.size)
+	}
+	portRanges := allocatePodSNATPortRanges(current, podIPs, low, high, a.size)
+	if reflect.DeepEqual(current, portRanges) {
+		return nil
+	}
+	nodeAnnotator := newNodeAnnotator(a.kube, a.nodeName)
+	if err := util.SetNodePodSNATPortRanges(nodeAnnotator, portRanges); err != nil {
+		return err
+	}
+	return nodeAnnotator.Run()
+}
+
+// deletePodSNATPortRanges removes the pod SNAT port ranges annotation left over from when the gateway pod SNAT
+// port range size was set
+func deletePodSNATPortRanges(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory) error {
+	node, err := watchFactory.GetNode(nodeName)
+	if err != nil {
+		return err
+	}
+	if _, ok := node.Annotations[util.OvnNodePodSNATPortRanges]; !ok {
+		return nil
+	}
+	nodeAnnotator := newNodeAnnotator(k, nodeName)
+	nodeAnnotator.Delete(util.OvnNodePodSNATPortRanges)
+	return nodeAnnotator.Run()
+}
+
+// allocatePodSNATPortRanges returns the port ranges of the pod IPs: the ranges of current still within the
+// partition of the ports low to high are kept, and the other pod IPs get the least used range of their IP
+// family, the lowest one among them. No range is allocated when the ports don't hold a range of the size.
+func allocatePodSNATPortRanges(current map[string]string, podIPs []net.IP, low, high, size int) map[string]string {
+	portRanges := map[string]string{}
+	count := (high - low + 1) / size
+	if count <= 0 {
+		return portRanges
+	}
+	// users are the numbers of pod IPs of each range by IP family
+	users := map[bool][]int{false: make([]int, count), true: make([]int, count)}
+	var unallocated []net.IP
+	for _, ip := range podIPs {
+		if index, ok := podSNATPortRangeIndex(current[ip.String()], low, size, count); ok {
+			portRanges[ip.String()] = current[ip.String()]
+			users[utilnet.IsIPv6(ip)][index]++
+			continue
+		}
+		unallocated = append(unallocated, ip)
+	}
+	// the pod IPs are allocated in order so that the allocation does not depend on the order of the pods
+	sort.Slice(unallocated, func(i, j int) bool { return bytes.Compare(unallocated[i], unallocated[j]) < 0 })
+	for _, ip := range unallocated {
+		familyUsers := users[utilnet.IsIPv6(ip)]
+		index := 0
+		for i := range familyUsers {
+			if familyUsers[i] < familyUsers[index] {
+				index = i
+			}
+		}
+		familyUsers[index]++
+		start := low + index*size
+		portRanges[ip.String()] = fmt.Sprintf("%d-%d", start, start+size-1)
+	}
+	return portRanges
+}
+
+// podSNATPortRangeIndex returns the index of the port range in the partition of count ranges of the size from
+// low, and whether it is one of them
+func podSNATPortRangeIndex(portRange string, low, size, count int) (int, bool) {
+	startStr, endStr, found := strings.Cut(portRange, "-")
+	if !found {
+		return 0, false
+	}
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, false
+	}
+	end, err := strconv.Atoi(endStr)
+	if err != nil {
+		return 0, false
+	}
+	if start < low || end-start+1 != size || (start-low)%size != 0 || (start-low)/size >= count {
+		return 0, false
+	}
+	return (start - low) / size, true
+}
+
+// getLocalPortRange returns the ephemeral port range of the node
+func getLocalPortRange() (int, int, error) {
+	value, err := readSysctl("net.ipv4.ip_local_port_range")
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("invalid ephemeral port range %q", value)
+	}
+	low, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ephemeral port range %q: %v", value, err)
+	}
+	high, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ephemeral port range %q: %v", value, err)
+	}
+	return low, high, nil
+}
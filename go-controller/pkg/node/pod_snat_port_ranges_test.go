@@ -0,0 +1,118 @@
+package node
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Pod SNAT port range allocator", func() {
+	const nodeName = "node1"
+
+	parseIPs := func(ips ...string) []net.IP {
+		parsed := make([]net.IP, 0, len(ips))
+		for _, ip := range ips {
+			parsed = append(parsed, net.ParseIP(ip))
+		}
+		return parsed
+	}
+
+	It("allocates the lowest free range of their IP family to the pod IPs", func() {
+		Expect(allocatePodSNATPortRanges(nil, parseIPs("10.244.0.6", "fd00:10:244::5", "10.244.0.5"),
+			32768, 33791, 256)).To(Equal(map[string]string{
+			"10.244.0.5":     "32768-33023",
+			"10.244.0.6":     "33024-33279",
+			"fd00:10:244::5": "32768-33023",
+		}))
+	})
+
+	It("keeps the ranges of the pod IPs and reallocates the ones out of the ephemeral port range", func() {
+		current := map[string]string{
+			"10.244.0.5": "33280-33535",
+			"10.244.0.6": "32768-33023",
+			"10.244.0.7": "32000-32255",
+			"10.244.0.9": "33024-33279",
+		}
+		Expect(allocatePodSNATPortRanges(current, parseIPs("10.244.0.5", "10.244.0.6", "10.244.0.7", "10.244.0.8"),
+			32768, 33791, 256)).To(Equal(map[string]string{
+			"10.244.0.5": "33280-33535",
+			"10.244.0.6": "32768-33023",
+			"10.244.0.7": "33024-33279",
+			"10.244.0.8": "33536-33791",
+		}))
+	})
+
+	It("shares the least used ranges once all of them are allocated", func() {
+		Expect(allocatePodSNATPortRanges(nil, parseIPs("10.244.0.5", "10.244.0.6", "10.244.0.7"),
+			32768, 33279, 256)).To(Equal(map[string]string{
+			"10.244.0.5": "32768-33023",
+			"10.244.0.6": "33024-33279",
+			"10.244.0.7": "32768-33023",
+		}))
+	})
+
+	It("allocates no range when the ephemeral port range is smaller than the range size", func() {
+		Expect(allocatePodSNATPortRanges(nil, parseIPs("10.244.0.5"), 32768, 32867, 256)).To(BeEmpty())
+	})
+
+	It("publishes the ranges of the local pods and follows the ephemeral port range of the node", func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "client"},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, PodIPs: []v1.PodIP{{IP: "10.244.0.5"}}},
+		}
+		hostNetworkPod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "host"},
+			Spec:       v1.PodSpec{NodeName: nodeName, HostNetwork: true},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, PodIPs: []v1.PodIP{{IP: "172.18.0.2"}}},
+		}
+		kubeFakeClient := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+			pod, hostNetworkPod)
+		wf, err := factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		defer wf.Shutdown()
+
+		a := newPodSNATPortRangeAllocator(nodeName, &kube.Kube{KClient: kubeFakeClient}, wf, 256)
+		low, high := 32768, 60999
+		a.localPortRange = func() (int, int, error) {
+			return low, high, nil
+		}
+		getPortRanges := func() map[string]string {
+			node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() string {
+				cached, err := wf.GetNode(nodeName)
+				Expect(err).NotTo(HaveOccurred())
+				return cached.Annotations[util.OvnNodePodSNATPortRanges]
+			}).Should(Equal(node.Annotations[util.OvnNodePodSNATPortRanges]))
+			portRanges, err := util.ParseNodePodSNATPortRanges(node)
+			Expect(err).NotTo(HaveOccurred())
+			return portRanges
+		}
+
+		Expect(a.sync()).To(Succeed())
+		Expect(getPortRanges()).To(Equal(map[string]string{"10.244.0.5": "32768-33023"}))
+
+		low = 40000
+		Expect(a.sync()).To(Succeed())
+		Expect(getPortRanges()).To(Equal(map[string]string{"10.244.0.5": "40000-40255"}))
+
+		Expect(deletePodSNATPortRanges(nodeName, &kube.Kube{KClient: kubeFakeClient}, wf)).To(Succeed())
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Annotations).NotTo(HaveKey(util.OvnNodePodSNATPortRanges))
+	})
+})
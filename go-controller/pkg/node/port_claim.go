@@ -38,11 +38,11 @@ type localPortManager struct {
 }
 
 func (p *localPortManager) open(desc string, ip string, port int32, protocol kapi.Protocol, svc *kapi.Service) error {
-	klog.V(5).Infof("Opening socket for service: %s/%s, port: %v and protocol %s", svc.Namespace, svc.Name, port, protocol)
+	servicesLog.V(5).Infof("Opening socket for service: %s/%s, port: %v and protocol %s", svc.Namespace, svc.Name, port, protocol)
 
 	if ip != "" {
 		if _, exists := p.localAddrSet[ip]; !exists {
-			klog.V(5).Infof("The IP %s is not one of the node local ports", ip)
+			servicesLog.V(5).Infof("The IP %s is not one of the node local ports", ip)
 			return nil
 		}
 	}
@@ -61,7 +61,7 @@ func (p *localPortManager) open(desc string, ip string, port int32, protocol kap
 		p.emitPortClaimEvent(svc, port, portError)
 		return portError
 	}
-	klog.V(5).Infof("Opening socket for LocalPort %v", localPort)
+	servicesLog.V(5).Infof("Opening socket for LocalPort %v", localPort)
 	p.activeSocketsLock.Lock()
 	defer p.activeSocketsLock.Unlock()
 
@@ -82,14 +82,14 @@ func (p *localPortManager) open(desc string, ip string, port int32, protocol kap
 }
 
 func (p *localPortManager) close(desc string, ip string, port int32, protocol kapi.Protocol, svc *kapi.Service) error {
-	klog.V(5).Infof("Closing socket claimed for service: %s/%s and port: %v", svc.Namespace, svc.Name, port)
+	servicesLog.V(5).Infof("Closing socket claimed for service: %s/%s and port: %v", svc.Namespace, svc.Name, port)
 
 	if protocol != kapi.ProtocolTCP && protocol != kapi.ProtocolUDP {
 		return nil
 	}
 	if ip != "" {
 		if _, exists := p.localAddrSet[ip]; !exists {
-			klog.V(5).Infof("The IP %s is not one of the node local ports", ip)
+			servicesLog.V(5).Infof("The IP %s is not one of the node local ports", ip)
 			return nil
 		}
 	}
@@ -97,7 +97,7 @@ func (p *localPortManager) close(desc string, ip string, port int32, protocol ka
 	if err != nil {
 		return fmt.Errorf("error localPort creation for svc: %s/%s on port: %v, err: %v", svc.Namespace, svc.Name, port, err)
 	}
-	klog.V(5).Infof("Closing socket for LocalPort %v", localPort)
+	servicesLog.V(5).Infof("Closing socket for LocalPort %v", localPort)
 
 	p.activeSocketsLock.Lock()
 	defer p.activeSocketsLock.Unlock()
@@ -194,13 +194,13 @@ func handleService(svc *kapi.Service, handler handler) []error {
 
 	for _, svcPort := range svc.Spec.Ports {
 		if util.ServiceTypeHasNodePort(svc) {
-			klog.V(5).Infof("Handle NodePort service %s port %d", svc.Name, svcPort.NodePort)
+			servicesLog.V(5).Infof("Handle NodePort service %s port %d", svc.Name, svcPort.NodePort)
 			if err := handlePort(getDescription(svcPort.Name, svc, true), svc, "", svcPort.NodePort, svcPort.Protocol, handler); err != nil {
 				errors = append(errors, err)
 			}
 		}
 		for _, externalIP := range svc.Spec.ExternalIPs {
-			klog.V(5).Infof("Handle ExternalIPs service %s external IP %s port %d", svc.Name, externalIP, svcPort.Port)
+			servicesLog.V(5).Infof("Handle ExternalIPs service %s external IP %s port %d", svc.Name, externalIP, svcPort.Port)
 			if err := handlePort(getDescription(svcPort.Name, svc, false), svc, utilnet.ParseIPSloppy(externalIP).String(), svcPort.Port, svcPort.Protocol, handler); err != nil {
 				errors = append(errors, err)
 			}
@@ -0,0 +1,241 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/conntrackmanager"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// minOVSVersion is the oldest Open vSwitch version the node networking is validated with
+	minOVSVersion = "2.17.0"
+	// minConntrackMax is the lowest conntrack table size accepted when ovnkube-node does not manage it,
+	// it matches the minimum kube-proxy sets
+	minConntrackMax = 131072
+)
+
+// procSysDir is the root the sysctls are read from, overridden by the tests
+var procSysDir = "/proc/sys"
+
+// PreflightCheckResult is the result of a node preflight check
+type PreflightCheckResult struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// Skipped is set when the check does not apply to the configuration of the node, a skipped check
+	// is passed
+	Skipped bool   `json:"skipped,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// PreflightReport is the report of the node preflight checks
+type PreflightReport struct {
+	Passed bool                   `json:"passed"`
+	Checks []PreflightCheckResult `json:"checks"`
+}
+
+// errPreflightSkipped is returned by a preflight check not applying to the configuration of the node
+type errPreflightSkipped struct {
+	reason string
+}
+
+func (e *errPreflightSkipped) Error() string {
+	return e.reason
+}
+
+type preflightCheck struct {
+	name string
+	// run returns a message describing the passed check, or the reason the check failed
+	run func() (string, error)
+}
+
+// RunPreflightChecks validates the host prerequisites of ovnkube-node with the current configuration, it
+// runs all the checks even if some fail
+func RunPreflightChecks() *PreflightReport {
+	return runPreflightChecks([]preflightCheck{
+		{name: "kernel-modules", run: newKernelModules().check},
+		{name: "ovs-version", run: checkOVSVersion},
+		{name: "br-int", run: checkBrInt},
+		{name: "mtu-headroom", run: checkMTUHeadroom},
+		{name: "conntrack-limits", run: checkConntrackLimits},
+		{name: "sysctls", run: checkSysctls},
+		{name: "ovn-controller", run: checkOVNController},
+	})
+}
+
+func runPreflightChecks(checks []preflightCheck) *PreflightReport {
+	report := &PreflightReport{Passed: true}
+	for _, check := range checks {
+		result := PreflightCheckResult{Name: check.name, Passed: true}
+		message, err := check.run()
+		if skipped, ok := err.(*errPreflightSkipped); ok {
+			result.Skipped = true
+			result.Message = skipped.reason
+		} else if err != nil {
+			result.Passed = false
+			result.Message = err.Error()
+			report.Passed = false
+		} else {
+			result.Message = message
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+// requiredKernelModules returns the kernel modules the node does not start without
+func requiredKernelModules() []string {
+	modules := []string{"nf_conntrack"}
+	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		modules = append(modules, "openvswitch")
+	}
+	return modules
+}
+
+// onDemandKernelModules returns the kernel modules the node uses that the kernel or OVS load on demand,
+// when the tunnel port or the VRF is created, so that they may not be loaded yet on start
+func onDemandKernelModules() []string {
+	var modules []string
+	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		if !config.Gateway.SingleNode {
+			modules = append(modules, config.Default.EncapType)
+		}
+		// the gateways of the user defined networks are VRFs
+		if config.OVNKubernetesFeature.EnableNetworkSegmentation {
+			modules = append(modules, "vrf")
+		}
+	}
+	return modules
+}
+
+func checkOVSVersion() (string, error) {
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		return "", &errPreflightSkipped{reason: "no Open vSwitch on a DPU host"}
+	}
+	stdout, stderr, err := util.RunOvsVswitchdAppCtl("version")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the ovs-vswitchd version, stderr: %q, error: %v", stderr, err)
+	}
+	if !strings.HasPrefix(stdout, "ovs-vswitchd (Open vSwitch)") {
+		return "", fmt.Errorf("unexpected ovs-vswitchd version output: %q", stdout)
+	}
+	current, err := version.ParseGeneric(strings.Fields(stdout)[3])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the ovs-vswitchd version: %v", err)
+	}
+	if current.LessThan(version.MustParseGeneric(minOVSVersion)) {
+		return "", fmt.Errorf("Open vSwitch %s is older than %s", current, minOVSVersion)
+	}
+	return fmt.Sprintf("Open vSwitch %s", current), nil
+}
+
+func checkBrInt() (string, error) {
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		return "", &errPreflightSkipped{reason: "no Open vSwitch on a DPU host"}
+	}
+	if _, stderr, err := util.RunOVSVsctl("--", "br-exists", "br-int"); err != nil {
+		return "", fmt.Errorf("bridge br-int does not exist, stderr: %q, error: %v", stderr, err)
+	}
+	return "bridge br-int exists", nil
+}
+
+// checkMTUHeadroom checks the interface of the encap IP can carry the overlay MTU and the tunnel header,
+// the encap IP is the configured one or the one ovnkube-node set on a previous run
+func checkMTUHeadroom() (string, error) {
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		return "", &errPreflightSkipped{reason: "the overlay is terminated on the DPU"}
+	}
+	if config.Default.EncapIP == "" {
+		encapIP, _, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".", "external_ids:ovn-encap-ip")
+		if err != nil || encapIP == "" {
+			return "", &errPreflightSkipped{reason: "no encap IP configured yet, it defaults to the node primary IP"}
+		}
+		config.Default.EncapIP = strings.Trim(encapIP, "\"")
+	}
+	if err := (&DefaultNodeNetworkController{}).validateVTEPInterfaceMTU(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("the interfaces of encap IP %s can carry the overlay MTU %d", config.Default.EncapIP, config.Default.MTU), nil
+}
+
+func readSysctl(name string) (string, error) {
+	value, err := os.ReadFile(filepath.Join(procSysDir, strings.ReplaceAll(name, ".", "/")))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// checkConntrackLimits checks the conntrack table is big enough when ovnkube-node does not size it
+func checkConntrackLimits() (string, error) {
+	value, err := readSysctl("net.netfilter.nf_conntrack_max")
+	if err != nil {
+		return "", fmt.Errorf("failed to read the conntrack table size: %v", err)
+	}
+	if config.OvnKubeNode.ConntrackMax != 0 {
+		return fmt.Sprintf("conntrack table size %s, set to %d by ovnkube-node", value, config.OvnKubeNode.ConntrackMax), nil
+	}
+	conntrackMax, err := strconv.Atoi(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid conntrack table size %q: %v", value, err)
+	}
+	if conntrackMax < minConntrackMax {
+		return "", fmt.Errorf("conntrack table size %d is lower than %d", conntrackMax, minConntrackMax)
+	}
+	return fmt.Sprintf("conntrack table size %d", conntrackMax), nil
+}
+
+// checkSysctls checks the kernel supports the sysctls ovnkube-node sets on start. The sysctls of the
+// interfaces ovnkube-node creates are not checked, and the values ovnkube-node sets are only reported.
+func checkSysctls() (string, error) {
+	var missing, pending []string
+	sysctls := nodeSysctls("")
+	for name, value := range conntrackmanager.NewController().Sysctls() {
+		sysctls[name] = value
+	}
+	for name, expected := range sysctls {
+		if strings.Contains(name, types.K8sMgmtIntfName) {
+			continue
+		}
+		value, err := readSysctl(name)
+		if err != nil {
+			missing = append(missing, name)
+			continue
+		}
+		if value != expected {
+			pending = append(pending, fmt.Sprintf("%s=%s (currently %s)", name, expected, value))
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(pending)
+	if len(missing) > 0 {
+		return "", fmt.Errorf("sysctls not supported by the kernel: %s", strings.Join(missing, ", "))
+	}
+	if len(pending) > 0 {
+		return fmt.Sprintf("sysctls set by ovnkube-node on start: %s", strings.Join(pending, ", ")), nil
+	}
+	return "sysctls set", nil
+}
+
+func checkOVNController() (string, error) {
+	if !util.IsLocalOVNAvailable() {
+		return "", &errPreflightSkipped{reason: "no local ovn-controller"}
+	}
+	ready, err := isOVNControllerReady()
+	if err != nil {
+		return "", err
+	}
+	if !ready {
+		return "", fmt.Errorf("ovn-controller is not connected to the Southbound database or has not programmed br-int")
+	}
+	return "ovn-controller is ready", nil
+}
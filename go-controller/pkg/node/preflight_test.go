@@ -0,0 +1,174 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node preflight checks", func() {
+	var (
+		tmpDir  string
+		modules *kernelModules
+	)
+
+	writeFile := func(path, content string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+	}
+	writeSysctl := func(name, value string) {
+		writeFile(filepath.Join(procSysDir, strings.ReplaceAll(name, ".", "/")), value+"\n")
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		var err error
+		tmpDir, err = os.MkdirTemp("", "preflight")
+		Expect(err).NotTo(HaveOccurred())
+		modules = &kernelModules{
+			sysModuleDir:  filepath.Join(tmpDir, "module"),
+			libModulesDir: filepath.Join(tmpDir, "lib", "modules"),
+		}
+		procSysDir = filepath.Join(tmpDir, "sys")
+	})
+
+	AfterEach(func() {
+		procSysDir = "/proc/sys"
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	It("reports all the checks and fails if one of them fails", func() {
+		report := runPreflightChecks([]preflightCheck{
+			{name: "passing", run: func() (string, error) { return "ok", nil }},
+			{name: "failing", run: func() (string, error) { return "", fmt.Errorf("boom") }},
+			{name: "skipped", run: func() (string, error) { return "", &errPreflightSkipped{reason: "not applicable"} }},
+		})
+		Expect(report).To(Equal(&PreflightReport{
+			Passed: false,
+			Checks: []PreflightCheckResult{
+				{Name: "passing", Passed: true, Message: "ok"},
+				{Name: "failing", Passed: false, Message: "boom"},
+				{Name: "skipped", Passed: true, Skipped: true, Message: "not applicable"},
+			},
+		}))
+	})
+
+	It("checks the kernel modules are loaded", func() {
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "openvswitch"), 0o755)).To(Succeed())
+		_, err := modules.check()
+		Expect(err).To(MatchError("kernel modules not loaded: nf_conntrack"))
+
+		// the tunnel module is loaded on demand, it is only reported
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "nf_conntrack"), 0o755)).To(Succeed())
+		message, err := modules.check()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("kernel modules loaded: nf_conntrack, openvswitch, not loaded yet: geneve"))
+
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "geneve"), 0o755)).To(Succeed())
+		message, err = modules.check()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("kernel modules loaded: nf_conntrack, openvswitch"))
+	})
+
+	It("finds the modules built into the kernel", func() {
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "nf_conntrack"), 0o755)).To(Succeed())
+		writeSysctl("kernel.osrelease", "6.8.0-40-generic")
+		writeFile(filepath.Join(modules.libModulesDir, "6.8.0-40-generic", "modules.builtin"),
+			"kernel/net/openvswitch/openvswitch.ko\nkernel/drivers/net/geneve.ko\n")
+		message, err := modules.check()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("kernel modules loaded: nf_conntrack, openvswitch"))
+
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		message, err = modules.check()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("kernel modules loaded: nf_conntrack, openvswitch, not loaded yet: vrf"))
+	})
+
+	It("only fails the start on the missing required kernel modules", func() {
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "nf_conntrack"), 0o755)).To(Succeed())
+		recorder := record.NewFakeRecorder(10)
+		nc := &DefaultNodeNetworkController{BaseNodeNetworkController: BaseNodeNetworkController{
+			CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{name: "node1", recorder: recorder},
+		}, kernelModules: modules}
+		Expect(nc.ensureKernelModules()).To(MatchError("Kernel modules required by the node are not loaded: " +
+			"openvswitch, geneve, vrf, load them on the host or set the load-kernel-modules option"))
+		Expect(recorder.Events).To(Receive(ContainSubstring(kernelModulesMissingEventReason)))
+
+		// the modules loaded on demand are only reported
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "openvswitch"), 0o755)).To(Succeed())
+		Expect(nc.ensureKernelModules()).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring("geneve, vrf")))
+	})
+
+	It("loads the missing kernel modules and reports those still missing", func() {
+		config.OVNKubernetesFeature.EnableNetworkSegmentation = true
+		config.OvnKubeNode.LoadKernelModules = true
+		Expect(os.MkdirAll(filepath.Join(modules.sysModuleDir, "nf_conntrack"), 0o755)).To(Succeed())
+		recorder := record.NewFakeRecorder(10)
+		nc := &DefaultNodeNetworkController{BaseNodeNetworkController: BaseNodeNetworkController{
+			CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{name: "node1", recorder: recorder},
+		}, kernelModules: modules}
+
+		fexec := ovntest.NewFakeExec()
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: "modprobe openvswitch", Err: fmt.Errorf("module openvswitch not found")})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "modprobe geneve",
+			Action: func() error {
+				return os.MkdirAll(filepath.Join(modules.sysModuleDir, "geneve"), 0o755)
+			},
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: "modprobe vrf", Err: fmt.Errorf("module vrf not found")})
+		Expect(util.SetExec(fexec)).To(Succeed())
+		Expect(nc.ensureKernelModules()).To(MatchError("Kernel modules required by the node are not loaded: openvswitch, vrf"))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(Receive(ContainSubstring(kernelModulesMissingEventReason)))
+
+		// a module loaded on demand failing to load does not fail the start
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "modprobe openvswitch",
+			Action: func() error {
+				return os.MkdirAll(filepath.Join(modules.sysModuleDir, "openvswitch"), 0o755)
+			},
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: "modprobe vrf", Err: fmt.Errorf("module vrf not found")})
+		Expect(nc.ensureKernelModules()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(recorder.Events).To(Receive(ContainSubstring("Kernel modules required by the node are not loaded: vrf")))
+	})
+
+	It("checks the conntrack table size unless ovnkube-node sets it", func() {
+		writeSysctl("net.netfilter.nf_conntrack_max", "65536")
+		_, err := checkConntrackLimits()
+		Expect(err).To(MatchError("conntrack table size 65536 is lower than 131072"))
+
+		config.OvnKubeNode.ConntrackMax = 262144
+		message, err := checkConntrackLimits()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("conntrack table size 65536, set to 262144 by ovnkube-node"))
+	})
+
+	It("checks the kernel supports the sysctls set by ovnkube-node", func() {
+		config.IPv4Mode = true
+		config.OvnKubeNode.ConntrackMax = 262144
+		_, err := checkSysctls()
+		Expect(err).To(MatchError("sysctls not supported by the kernel: net.ipv4.ip_forward, net.netfilter.nf_conntrack_max"))
+
+		writeSysctl("net.ipv4.ip_forward", "1")
+		writeSysctl("net.netfilter.nf_conntrack_max", "65536")
+		message, err := checkSysctls()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("sysctls set by ovnkube-node on start: net.netfilter.nf_conntrack_max=262144 (currently 65536)"))
+	})
+})
@@ -0,0 +1,138 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// probeIntervalsRetryPeriod is how often the probe intervals are applied again after a failure
+const probeIntervalsRetryPeriod = time.Minute
+
+// probeIntervals are the probe intervals of ovn-controller on the node
+type probeIntervals struct {
+	openFlowProbe     int
+	remoteProbe       int
+	bundleIdleTimeout int
+}
+
+// nodeProbeIntervals returns the cluster probe intervals overridden by the probe intervals annotation of
+// the node, an invalid annotation is ignored
+func nodeProbeIntervals(node *kapi.Node) probeIntervals {
+	// bundle-idle-timeout default value is 10s, it should be set
+	// as high as the ovn-openflow-probe-interval to allow ovn-controller
+	// to finish computation specially with complex acl configuration with port range.
+	intervals := probeIntervals{
+		openFlowProbe:     config.Default.OpenFlowProbe,
+		remoteProbe:       config.Default.InactivityProbe,
+		bundleIdleTimeout: config.Default.OpenFlowProbe,
+	}
+	overrides, err := util.ParseNodeProbeIntervals(node)
+	if err != nil {
+		if !util.IsAnnotationNotSetError(err) {
+			klog.Warningf("Ignoring the probe intervals overridden on node %s: %v", node.Name, err)
+		}
+		return intervals
+	}
+	if overrides.OpenFlowProbe != nil {
+		intervals.openFlowProbe = *overrides.OpenFlowProbe
+		intervals.bundleIdleTimeout = *overrides.OpenFlowProbe
+	}
+	if overrides.RemoteProbe != nil {
+		intervals.remoteProbe = *overrides.RemoteProbe
+	}
+	if overrides.BundleIdleTimeout != nil {
+		intervals.bundleIdleTimeout = *overrides.BundleIdleTimeout
+	}
+	return intervals
+}
+
+// ovsSettings returns the Open_vSwitch table settings of the probe intervals
+func (p probeIntervals) ovsSettings() []string {
+	return []string{
+		fmt.Sprintf("external_ids:ovn-remote-probe-interval=%d", p.remoteProbe),
+		fmt.Sprintf("external_ids:ovn-openflow-probe-interval=%d", p.openFlowProbe),
+		fmt.Sprintf("other_config:bundle-idle-timeout=%d", p.bundleIdleTimeout),
+	}
+}
+
+// probeIntervalsController applies the probe intervals overridden in the node probe intervals annotation
+// when the annotation changes, the cluster probe intervals are applied back when it is removed
+type probeIntervalsController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+	// applied are the probe intervals applied last
+	applied *probeIntervals
+}
+
+func newProbeIntervalsController(nodeName string, watchFactory factory.NodeWatchFactory, stopChan <-chan struct{}) *probeIntervalsController {
+	return &probeIntervalsController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		stopChan:     stopChan,
+		trigger:      make(chan struct{}, 1),
+	}
+}
+
+func (c *probeIntervalsController) Run(doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && util.NodeProbeIntervalsAnnotationChanged(oldNode, newNode) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the probe intervals: %w", err)
+	}
+
+	runPeriodicSync(c.stopChan, doneWg, probeIntervalsRetryPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to apply the probe intervals of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *probeIntervalsController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (c *probeIntervalsController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	return c.apply(node)
+}
+
+// apply sets the probe intervals of the node when they changed since they were last applied
+func (c *probeIntervalsController) apply(node *kapi.Node) error {
+	intervals := nodeProbeIntervals(node)
+	if c.applied != nil && *c.applied == intervals {
+		return nil
+	}
+	args := append([]string{"set", "Open_vSwitch", "."}, intervals.ovsSettings()...)
+	if _, stderr, err := util.RunOVSVsctl(args...); err != nil {
+		return fmt.Errorf("error setting the probe intervals: %v\n  %q", err, stderr)
+	}
+	klog.Infof("Applied the probe intervals of node %s: openflow probe %ds, remote probe %dms, bundle idle timeout %ds",
+		c.nodeName, intervals.openFlowProbe, intervals.remoteProbe, intervals.bundleIdleTimeout)
+	c.applied = &intervals
+	return nil
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node probe intervals", func() {
+	var execMock *ovntest.FakeExec
+
+	newProbeIntervalsNode := func(annotation string) *kapi.Node {
+		node := &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: map[string]string{}}}
+		if annotation != "" {
+			node.Annotations[util.OvnNodeProbeIntervals] = annotation
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Default.OpenFlowProbe = 180
+		config.Default.InactivityProbe = 100000
+		execMock = ovntest.NewFakeExec()
+		Expect(util.SetExec(execMock)).To(Succeed())
+	})
+
+	It("overrides the cluster probe intervals with the ones of the node annotation", func() {
+		Expect(nodeProbeIntervals(newProbeIntervalsNode(""))).To(Equal(
+			probeIntervals{openFlowProbe: 180, remoteProbe: 100000, bundleIdleTimeout: 180}))
+		// the bundle idle timeout follows the openflow probe interval unless overridden
+		Expect(nodeProbeIntervals(newProbeIntervalsNode(`{"openflow-probe-interval": 300}`))).To(Equal(
+			probeIntervals{openFlowProbe: 300, remoteProbe: 100000, bundleIdleTimeout: 300}))
+		Expect(nodeProbeIntervals(newProbeIntervalsNode(`{"remote-probe-interval": 300000, "bundle-idle-timeout": 600}`))).To(Equal(
+			probeIntervals{openFlowProbe: 180, remoteProbe: 300000, bundleIdleTimeout: 600}))
+		// an invalid annotation is ignored
+		Expect(nodeProbeIntervals(newProbeIntervalsNode(`{"openflow-probe-interval": -1}`))).To(Equal(
+			probeIntervals{openFlowProbe: 180, remoteProbe: 100000, bundleIdleTimeout: 180}))
+	})
+
+	It("applies the probe intervals when they change", func() {
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-remote-probe-interval=100000 " +
+				"external_ids:ovn-openflow-probe-interval=300 other_config:bundle-idle-timeout=300",
+		})
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-remote-probe-interval=100000 " +
+				"external_ids:ovn-openflow-probe-interval=180 other_config:bundle-idle-timeout=180",
+		})
+
+		c := &probeIntervalsController{nodeName: "node1"}
+		Expect(c.apply(newProbeIntervalsNode(`{"openflow-probe-interval": 300}`))).To(Succeed())
+		// unchanged intervals are not applied again
+		Expect(c.apply(newProbeIntervalsNode(`{"openflow-probe-interval": 300, "bundle-idle-timeout": 300}`))).To(Succeed())
+		// the cluster probe intervals are applied back when the annotation is removed
+		Expect(c.apply(newProbeIntervalsNode(""))).To(Succeed())
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+	})
+})
@@ -0,0 +1,160 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// reconciliationPauseSyncPeriod is how often the pause node reconciliation annotation is checked
+	reconciliationPauseSyncPeriod = time.Minute
+	// reconciliationPausedEventReason is the reason of the node events reporting the pause of the reconciliation
+	reconciliationPausedEventReason = "NodeReconciliationPaused"
+	// reconciliationResumedEventReason is the reason of the node events reporting the end of the pause
+	reconciliationResumedEventReason = "NodeReconciliationResumed"
+)
+
+// hostReconciler is a reconciler of the host networking of the node that can be paused
+type hostReconciler struct {
+	name   string
+	pause  func()
+	resume func() error
+}
+
+// reconciliationPauseController pauses the reconciliation of the host networking of the node while the pause
+// node reconciliation annotation is set, so that operators can debug the host state without ovnkube-node
+// repairing it underneath them: the routes, the iptables rules and the gateway flows are neither modified
+// nor restored, the changes meanwhile are applied once the annotation is removed. The CNI server keeps
+// serving the pods, and the setup of the node at startup is not paused.
+type reconciliationPauseController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	nodeRef      *kapi.ObjectReference
+	recorder     record.EventRecorder
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+
+	lock   sync.Mutex
+	paused bool
+	// reconcilers are the host networking reconcilers paused with the node reconciliation
+	reconcilers []hostReconciler
+}
+
+func newReconciliationPauseController(nodeName string, watchFactory factory.NodeWatchFactory,
+	recorder record.EventRecorder, stopChan <-chan struct{}) *reconciliationPauseController {
+	return &reconciliationPauseController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		nodeRef: &kapi.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  ktypes.UID(nodeName),
+		},
+		recorder: recorder,
+		stopChan: stopChan,
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+// register adds a reconciler paused with the node reconciliation, replacing the reconciler registered with
+// the same name, e.g. by a restarted subsystem. It is paused right away when the node reconciliation is paused.
+func (c *reconciliationPauseController) register(name string, pause func(), resume func() error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	reconciler := hostReconciler{name: name, pause: pause, resume: resume}
+	if c.paused {
+		pause()
+	}
+	for i := range c.reconcilers {
+		if c.reconcilers[i].name == name {
+			c.reconcilers[i] = reconciler
+			return
+		}
+	}
+	c.reconcilers = append(c.reconcilers, reconciler)
+}
+
+func (c *reconciliationPauseController) Run(doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && util.NodePauseReconciliationAnnotationChanged(oldNode, newNode) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the reconciliation pause: %w", err)
+	}
+
+	runPeriodicSync(c.stopChan, doneWg, reconciliationPauseSyncPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to sync the reconciliation pause of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *reconciliationPauseController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync pauses or resumes the reconciliation of the node as requested by the node annotation, an invalid
+// annotation leaves it as it is
+func (c *reconciliationPauseController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	paused, err := util.ParseNodePauseReconciliation(node)
+	if err != nil {
+		return err
+	}
+	c.setPaused(paused)
+	return nil
+}
+
+func (c *reconciliationPauseController) setPaused(paused bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if paused == c.paused {
+		return
+	}
+	c.paused = paused
+	if paused {
+		klog.Warningf("Pausing the reconciliation of the host networking of node %s as requested by the %s annotation",
+			c.nodeName, util.OvnNodePauseReconciliation)
+		for _, reconciler := range c.reconcilers {
+			reconciler.pause()
+		}
+		metrics.MetricNodeReconciliationPaused.Set(1)
+		c.recorder.Eventf(c.nodeRef, kapi.EventTypeWarning, reconciliationPausedEventReason,
+			"The routes, iptables rules and gateway flows of the node are not reconciled until the %s annotation is removed",
+			util.OvnNodePauseReconciliation)
+		return
+	}
+	klog.Infof("Resuming the reconciliation of the host networking of node %s", c.nodeName)
+	for _, reconciler := range c.reconcilers {
+		if err := reconciler.resume(); err != nil {
+			klog.Errorf("Failed to resume the %s reconciliation of node %s: %v", reconciler.name, c.nodeName, err)
+		}
+	}
+	metrics.MetricNodeReconciliationPaused.Set(0)
+	c.recorder.Eventf(c.nodeRef, kapi.EventTypeNormal, reconciliationResumedEventReason,
+		"The host networking of the node is reconciled again")
+}
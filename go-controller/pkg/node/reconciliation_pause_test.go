@@ -0,0 +1,106 @@
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Node reconciliation pause", func() {
+	const nodeName = "node1"
+
+	var (
+		kubeFakeClient *fake.Clientset
+		wf             *factory.WatchFactory
+		recorder       *record.FakeRecorder
+		c              *reconciliationPauseController
+		states         map[string]string
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		kubeFakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+		recorder = record.NewFakeRecorder(10)
+		c = newReconciliationPauseController(nodeName, wf, recorder, make(chan struct{}))
+		states = map[string]string{}
+	})
+
+	AfterEach(func() {
+		wf.Shutdown()
+	})
+
+	register := func(name string) {
+		c.register(name, func() { states[name] = "paused" }, func() error {
+			states[name] = "resumed"
+			return nil
+		})
+	}
+
+	setAnnotation := func(value *string) {
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		node.Annotations = map[string]string{}
+		if value != nil {
+			node.Annotations[util.OvnNodePauseReconciliation] = *value
+		}
+		_, err = kubeFakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() string {
+			node, err := wf.GetNode(nodeName)
+			Expect(err).NotTo(HaveOccurred())
+			return node.Annotations[util.OvnNodePauseReconciliation]
+		}).Should(Equal(node.Annotations[util.OvnNodePauseReconciliation]))
+	}
+
+	It("pauses and resumes the reconcilers as requested", func() {
+		register("routes")
+		Expect(c.sync()).To(Succeed())
+		Expect(states).To(BeEmpty())
+
+		paused := "true"
+		setAnnotation(&paused)
+		Expect(c.sync()).To(Succeed())
+		Expect(states).To(Equal(map[string]string{"routes": "paused"}))
+		Expect(<-recorder.Events).To(ContainSubstring(reconciliationPausedEventReason))
+
+		// the reconcilers registered while paused are paused right away
+		register("gateway-flows")
+		Expect(states).To(Equal(map[string]string{"routes": "paused", "gateway-flows": "paused"}))
+
+		setAnnotation(nil)
+		Expect(c.sync()).To(Succeed())
+		Expect(states).To(Equal(map[string]string{"routes": "resumed", "gateway-flows": "resumed"}))
+		Expect(<-recorder.Events).To(ContainSubstring(reconciliationResumedEventReason))
+	})
+
+	It("keeps the reconcilers paused on an invalid annotation", func() {
+		register("routes")
+		paused := "true"
+		setAnnotation(&paused)
+		Expect(c.sync()).To(Succeed())
+
+		invalid := "maybe"
+		setAnnotation(&invalid)
+		Expect(c.sync()).NotTo(Succeed())
+		Expect(states).To(Equal(map[string]string{"routes": "paused"}))
+
+		paused = "false"
+		setAnnotation(&paused)
+		Expect(c.sync()).To(Succeed())
+		Expect(states).To(Equal(map[string]string{"routes": "resumed"}))
+	})
+})
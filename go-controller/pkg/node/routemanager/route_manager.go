@@ -21,6 +21,16 @@ type Controller struct {
 	store      map[int][]netlink.Route // key is link index
 	addRouteCh chan netlink.Route
 	delRouteCh chan netlink.Route
+	replaceCh  chan routeReplacement
+	pauseCh    chan bool
+	// paused is set while the routes are not applied, the routes added meanwhile are only stored and the routes
+	// deleted meanwhile are kept in pendingDels until resumed
+	paused      bool
+	pendingDels []netlink.Route
+	// excluded are the subnets claimed by other agents, the managed routes to destinations within them are kept
+	// in the store but not applied
+	excluded  []*net.IPNet
+	excludeCh chan []*net.IPNet
 }
 
 // NewController manages routes which include adding and deletion of routes. It also manages restoration of managed routes.
@@ -32,6 +42,9 @@ func NewController() *Controller {
 		store:      make(map[int][]netlink.Route),
 		addRouteCh: make(chan netlink.Route, 5),
 		delRouteCh: make(chan netlink.Route, 5),
+		replaceCh:  make(chan routeReplacement),
+		pauseCh:    make(chan bool),
+		excludeCh:  make(chan []*net.IPNet),
 	}
 }
 
@@ -56,6 +69,9 @@ func (c *Controller) Run(stopCh <-chan struct{}, syncPeriod time.Duration) {
 				subscribed, routeEventCh = subscribeNetlinkRouteEvents(stopCh)
 				continue
 			}
+			if c.paused {
+				continue
+			}
 			if err = c.processNetlinkEvent(newRouteEvent); err != nil {
 				// TODO: make util.GetNetLinkOps().IsLinkNotFoundError(err) smarter to unwrap error
 				// and use it here to log errors that are not IsLinkNotFoundError
@@ -66,7 +82,9 @@ func (c *Controller) Run(stopCh <-chan struct{}, syncPeriod time.Duration) {
 				klog.Info("Route Manager: netlink route events aren't subscribed - resubscribing")
 				subscribed, routeEventCh = subscribeNetlinkRouteEvents(stopCh)
 			}
-			c.sync()
+			if !c.paused {
+				c.sync()
+			}
 		case newRoute := <-c.addRouteCh:
 			if err = c.addRoute(newRoute); err != nil {
 				klog.Errorf("Route Manager: failed to add route (%s): %v", newRoute.String(), err)
@@ -75,6 +93,93 @@ func (c *Controller) Run(stopCh <-chan struct{}, syncPeriod time.Duration) {
 			if err = c.delRoute(delRoute); err != nil {
 				klog.Errorf("Route Manager: failed to delete route (%s): %v", delRoute.String(), err)
 			}
+		case replacement := <-c.replaceCh:
+			replacement.errCh <- c.replaceRoute(replacement.oldRoute, replacement.newRoute)
+		case paused := <-c.pauseCh:
+			c.setPaused(paused)
+		case excluded := <-c.excludeCh:
+			c.setExcluded(excluded)
+		}
+	}
+}
+
+// Pause stops applying and restoring the managed routes until Resume is called, the routes added and deleted
+// meanwhile are applied once resumed
+func (c *Controller) Pause() {
+	c.pauseCh <- true
+}
+
+// Resume applies the routes added and deleted while paused and restores the managed routes
+func (c *Controller) Resume() {
+	c.pauseCh <- false
+}
+
+func (c *Controller) setPaused(paused bool) {
+	if paused == c.paused {
+		return
+	}
+	c.paused = paused
+	if paused {
+		klog.Info("Route Manager: paused")
+		return
+	}
+	klog.Info("Route Manager: resuming")
+	pendingDels := c.pendingDels
+	c.pendingDels = nil
+	for _, r := range pendingDels {
+		if err := c.delRoute(r); err != nil {
+			klog.Errorf("Route Manager: failed to delete route (%s): %v", r.String(), err)
+		}
+	}
+	c.removeExcludedRoutes()
+	c.sync()
+}
+
+// SetExcludedSubnets stops applying the managed routes to destinations within the subnets, claimed by another
+// agent, and removes them. The routes are applied again once their subnet is no longer excluded.
+func (c *Controller) SetExcludedSubnets(subnets []*net.IPNet) {
+	c.excludeCh <- subnets
+}
+
+func (c *Controller) setExcluded(subnets []*net.IPNet) {
+	c.excluded = subnets
+	if c.paused {
+		// removed on resume
+		return
+	}
+	c.removeExcludedRoutes()
+	c.sync()
+}
+
+// isExcluded returns true if the destination of the route is within an excluded subnet
+func (c *Controller) isExcluded(r netlink.Route) bool {
+	if r.Dst == nil {
+		return false
+	}
+	routeOnes, _ := r.Dst.Mask.Size()
+	for _, subnet := range c.excluded {
+		if ones, _ := subnet.Mask.Size(); ones <= routeOnes && subnet.Contains(r.Dst.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeExcludedRoutes removes the managed routes to destinations within the excluded subnets
+func (c *Controller) removeExcludedRoutes() {
+	for linkIndex, managedRoutes := range c.store {
+		for _, managedRoute := range managedRoutes {
+			if !c.isExcluded(managedRoute) {
+				continue
+			}
+			link, err := util.GetNetLinkOps().LinkByIndex(linkIndex)
+			if err != nil {
+				continue
+			}
+			klog.Infof("Route Manager: removing route (%s) to a subnet claimed by another agent", managedRoute.String())
+			if err := c.netlinkDelRoute(link, managedRoute.Dst, managedRoute.Table); err != nil {
+				klog.Errorf("Route Manager: failed to delete route (%s): %v", managedRoute.String(), err)
+			}
 		}
 	}
 }
@@ -89,6 +194,48 @@ func (c *Controller) Del(r netlink.Route) {
 	c.delRouteCh <- r
 }
 
+// routeReplacement is a request to replace a managed route, its result is sent to errCh
+type routeReplacement struct {
+	oldRoute netlink.Route
+	newRoute netlink.Route
+	errCh    chan error
+}
+
+// Replace replaces a managed route with a route of the same destination, table and priority, e.g. through
+// another gateway, and returns once it is applied. The new route is applied over the old one so that the
+// destination stays routed, and the old one is only forgotten once the new one is applied: when applying the
+// new route fails, the error is returned and the old route is still managed.
+func (c *Controller) Replace(oldRoute, newRoute netlink.Route) error {
+	errCh := make(chan error, 1)
+	c.replaceCh <- routeReplacement{oldRoute: oldRoute, newRoute: newRoute, errCh: errCh}
+	return <-errCh
+}
+
+// replaceRoute applies the new route over the old one and then replaces the old one with it in the store
+func (c *Controller) replaceRoute(oldRoute, newRoute netlink.Route) error {
+	klog.Infof("Route Manager: attempting to replace route %s with route %s", oldRoute.String(), newRoute.String())
+	if oldRoute.Table == 0 {
+		oldRoute.Table = MainTableID
+	}
+	if newRoute.Table == 0 {
+		newRoute.Table = MainTableID
+	}
+	// while paused or excluded, the new route is applied by the sync once resumed or no longer excluded
+	if !c.paused && !c.isExcluded(newRoute) {
+		link, err := util.GetNetLinkOps().LinkByIndex(newRoute.LinkIndex)
+		if err != nil {
+			return fmt.Errorf("failed to apply route (%s) because unable to get link: %v", newRoute.String(), err)
+		}
+		if err := c.applyRoute(link, newRoute); err != nil {
+			return fmt.Errorf("failed to apply route (%s): %v", newRoute.String(), err)
+		}
+	}
+	c.removeRouteFromStore(oldRoute)
+	c.addRouteToStore(newRoute)
+	klog.Infof("Route Manager: completed replacing route %s with route %s", oldRoute.String(), newRoute.String())
+	return nil
+}
+
 // addRoute attempts to add the route and returns with error
 // if it fails to do so.
 func (c *Controller) addRoute(r netlink.Route) error {
@@ -98,15 +245,32 @@ func (c *Controller) addRoute(r netlink.Route) error {
 	if r.Table == 0 {
 		r.Table = MainTableID
 	}
-	if addedToStore := c.addRouteToStore(r); !addedToStore {
-		// already managed - nothing to do
+	if c.paused {
+		// a route added back while paused must not be deleted on resume
+		pendingDels := c.pendingDels[:0]
+		for _, pendingDel := range c.pendingDels {
+			if !RoutePartiallyEqual(pendingDel, r) {
+				pendingDels = append(pendingDels, pendingDel)
+			}
+		}
+		c.pendingDels = pendingDels
+	}
+	// an already managed route is applied again: the routes of the table of a VRF are gone when the VRF is
+	// recreated, e.g. for another network reusing the same subnet, and are added back by the VRF manager
+	c.addRouteToStore(r)
+	if c.paused {
+		// applied by the sync on resume
+		return nil
+	}
+	if c.isExcluded(r) {
+		klog.Infof("Route Manager: not applying route (%s) to a subnet claimed by another agent", r.String())
 		return nil
 	}
 	link, err := util.GetNetLinkOps().LinkByIndex(r.LinkIndex)
 	if err != nil {
 		return fmt.Errorf("failed to apply route (%s) because unable to get link: %v", r.String(), err)
 	}
-	if err := c.applyRoute(link, r.Gw, r.Dst, r.MTU, r.Src, r.Table); err != nil {
+	if err := c.applyRoute(link, r); err != nil {
 		return fmt.Errorf("failed to apply route (%s): %v", r.String(), err)
 	}
 	klog.Infof("Route Manager: completed adding route: %s", r.String())
@@ -117,6 +281,11 @@ func (c *Controller) addRoute(r netlink.Route) error {
 // if it fails to do so.
 func (c *Controller) delRoute(r netlink.Route) error {
 	klog.Infof("Route Manager: attempting to delete route: %s", r.String())
+	if c.paused {
+		c.removeRouteFromStore(r)
+		c.pendingDels = append(c.pendingDels, r)
+		return nil
+	}
 	link, err := util.GetNetLinkOps().LinkByIndex(r.LinkIndex)
 	if err != nil {
 		if util.GetNetLinkOps().IsLinkNotFoundError(err) {
@@ -128,9 +297,16 @@ func (c *Controller) delRoute(r netlink.Route) error {
 	if err := c.netlinkDelRoute(link, r.Dst, r.Table); err != nil {
 		return fmt.Errorf("failed to delete route (%s): %v", r.String(), err)
 	}
+	c.removeRouteFromStore(r)
+	klog.Infof("Route Manager: deletion of routes for link complete: %s", r.String())
+	return nil
+}
+
+// removeRouteFromStore stops managing the route
+func (c *Controller) removeRouteFromStore(r netlink.Route) {
 	managedRoutes, ok := c.store[r.LinkIndex]
 	if !ok {
-		return nil
+		return
 	}
 	// remove route from existing routes
 	managedRoutesTemp := make([]netlink.Route, 0, len(managedRoutes))
@@ -144,8 +320,6 @@ func (c *Controller) delRoute(r netlink.Route) error {
 	} else {
 		c.store[r.LinkIndex] = managedRoutesTemp
 	}
-	klog.Infof("Route Manager: deletion of routes for link complete: %s", r.String())
-	return nil
 }
 
 // processNetlinkEvent will check if a deleted route is managed by route manager and if so, determine if a sync is needed
@@ -167,13 +341,13 @@ func (c *Controller) processNetlinkEvent(ru netlink.RouteUpdate) error {
 		return nil
 	}
 	for _, managedRoute := range managedRoutes {
-		if RoutePartiallyEqual(managedRoute, ru.Route) {
+		if RoutePartiallyEqual(managedRoute, ru.Route) && !c.isExcluded(managedRoute) {
 			link, err := util.GetNetLinkOps().LinkByIndex(managedRoute.LinkIndex)
 			if err != nil {
 				klog.Errorf("Route Manager: failed to restore route because unable to get link by index %d: %v", managedRoute.LinkIndex, err)
 				continue
 			}
-			if err = c.applyRoute(link, managedRoute.Gw, managedRoute.Dst, managedRoute.MTU, managedRoute.Src, managedRoute.Table); err != nil {
+			if err = c.applyRoute(link, managedRoute); err != nil {
 				klog.Errorf("Route Manager: failed to apply route (%s): %v", managedRoute.String(), err)
 			}
 		}
@@ -181,35 +355,45 @@ func (c *Controller) processNetlinkEvent(ru netlink.RouteUpdate) error {
 	return nil
 }
 
-func (c *Controller) applyRoute(link netlink.Link, gwIP net.IP, subnet *net.IPNet, mtu int, src net.IP, table int) error {
-	filterRoute, filterMask := filterRouteByDstAndTable(link.Attrs().Index, subnet, table)
-	existingRoutes, err := util.GetNetLinkOps().RouteListFiltered(getNetlinkIPFamily(subnet), filterRoute, filterMask)
+// applyRoute adds the route, or replaces the gateway, MTU and source of the existing route with the same
+// destination, table and, when the route has one, priority. The other attributes of the existing route are kept.
+func (c *Controller) applyRoute(link netlink.Link, r netlink.Route) error {
+	filterRoute, filterMask := filterRouteByDstAndTable(link.Attrs().Index, r.Dst, r.Table)
+	existingRoutes, err := util.GetNetLinkOps().RouteListFiltered(getNetlinkIPFamily(r.Dst), filterRoute, filterMask)
 	if err != nil {
 		return fmt.Errorf("failed to list filtered routes: %v", err)
 	}
-	if len(existingRoutes) == 0 {
-		return c.netlinkAddRoute(link, gwIP, subnet, mtu, src, table)
+	var netlinkRoute *netlink.Route
+	for i := range existingRoutes {
+		// routes with a different priority are different routes
+		if r.Priority == 0 || existingRoutes[i].Priority == r.Priority {
+			netlinkRoute = &existingRoutes[i]
+			break
+		}
 	}
-	netlinkRoute := &existingRoutes[0]
-	if netlinkRoute.MTU != mtu || !src.Equal(netlinkRoute.Src) || !gwIP.Equal(netlinkRoute.Gw) {
-		netlinkRoute.MTU = mtu
-		netlinkRoute.Src = src
-		netlinkRoute.Gw = gwIP
+	if netlinkRoute == nil {
+		return c.netlinkAddRoute(link, r.Gw, r.Dst, r.MTU, r.Src, r.Table, r.Priority)
+	}
+	if netlinkRoute.MTU != r.MTU || !r.Src.Equal(netlinkRoute.Src) || !r.Gw.Equal(netlinkRoute.Gw) {
+		netlinkRoute.MTU = r.MTU
+		netlinkRoute.Src = r.Src
+		netlinkRoute.Gw = r.Gw
 		err = util.GetNetLinkOps().RouteReplace(netlinkRoute)
 		if err != nil {
 			return fmt.Errorf("failed to replace route for subnet %s via gateway %s with mtu %d: %v",
-				subnet.String(), gwIP.String(), mtu, err)
+				r.Dst.String(), r.Gw.String(), r.MTU, err)
 		}
 	}
 	return nil
 }
 
-func (c *Controller) netlinkAddRoute(link netlink.Link, gwIP net.IP, subnet *net.IPNet, mtu int, srcIP net.IP, table int) error {
+func (c *Controller) netlinkAddRoute(link netlink.Link, gwIP net.IP, subnet *net.IPNet, mtu int, srcIP net.IP, table, priority int) error {
 	newNlRoute := &netlink.Route{
 		Dst:       subnet,
 		LinkIndex: link.Attrs().Index,
 		Scope:     netlink.SCOPE_UNIVERSE,
 		Table:     table,
+		Priority:  priority,
 	}
 	if len(gwIP) > 0 {
 		newNlRoute.Gw = gwIP
@@ -222,7 +406,7 @@ func (c *Controller) netlinkAddRoute(link netlink.Link, gwIP net.IP, subnet *net
 	}
 	err := util.GetNetLinkOps().RouteAdd(newNlRoute)
 	if err != nil {
-		return fmt.Errorf("failed to add route (gw: %v, subnet %v, mtu %d, src IP %v): %v", gwIP, subnet, mtu, srcIP, err)
+		return fmt.Errorf("failed to add route (gw: %v, subnet %v, mtu %d, src IP %v, priority %d): %v", gwIP, subnet, mtu, srcIP, priority, err)
 	}
 	return nil
 }
@@ -265,6 +449,9 @@ func (c *Controller) sync() {
 	deletedLinkIndexes := make([]int, 0)
 	for linkIndex, managedRoutes := range c.store {
 		for _, managedRoute := range managedRoutes {
+			if c.isExcluded(managedRoute) {
+				continue
+			}
 			filterRoute, filterMask := filterRouteByDstAndTable(linkIndex, managedRoute.Dst, managedRoute.Table)
 			existingRoutes, err := util.GetNetLinkOps().RouteListFiltered(netlink.FAMILY_ALL, filterRoute, filterMask)
 			if err != nil {
@@ -288,7 +475,7 @@ func (c *Controller) sync() {
 					}
 					continue
 				}
-				if err := c.applyRoute(link, managedRoute.Gw, managedRoute.Dst, managedRoute.MTU, managedRoute.Src, managedRoute.Table); err != nil {
+				if err := c.applyRoute(link, managedRoute); err != nil {
 					klog.Errorf("Route Manager: failed to apply route (%s): %v", managedRoute.String(), err)
 				}
 			}
@@ -327,7 +514,9 @@ func filterRouteByTable(linkIndex, table int) (*netlink.Route, uint64) {
 
 func subscribeNetlinkRouteEvents(stopCh <-chan struct{}) (bool, chan netlink.RouteUpdate) {
 	routeEventCh := make(chan netlink.RouteUpdate, 20)
-	if err := netlink.RouteSubscribe(routeEventCh, stopCh); err != nil {
+	if err := netlink.RouteSubscribeWithOptions(routeEventCh, stopCh, netlink.RouteSubscribeOptions{
+		Namespace: util.NodeNetNSHandle(),
+	}); err != nil {
 		klog.Errorf("Route Manager: failed to subscribe to netlink route events: %v", err)
 		return false, routeEventCh
 	}
@@ -178,6 +178,52 @@ var _ = ginkgo.Describe("Route Manager", func() {
 			// validate it is restored in table 6
 			gomega.Eventually(validateRoute(testNS, loLink, r, netlink.FAMILY_V4, 6), time.Second).Should(gomega.BeTrue())
 		})
+
+		ginkgo.It("applies an already managed route again", func() {
+			// not running, the route is only restored by adding it again
+			c := NewController()
+			r := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: v4DefaultRouteIPNet, Table: customTableID}
+			gomega.Expect(testNS.Do(func(netNS ns.NetNS) error {
+				if err := c.addRoute(r); err != nil {
+					return err
+				}
+				if err := netlink.RouteDel(&r); err != nil {
+					return err
+				}
+				return c.addRoute(r)
+			})).To(gomega.Succeed())
+			gomega.Expect(isRouteInTable(testNS, r, loLink.Attrs().Index, customTableID)).To(gomega.BeTrue())
+		})
+	})
+
+	ginkgo.Context("replace route", func() {
+		ginkgo.It("replaces the gateway of a managed route and forgets the old route", func() {
+			rOld := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Gw: loGWIP, Table: MainTableID}
+			rm.Add(rOld)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, rOld, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+			rNew := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Gw: loIPDiff, Table: MainTableID}
+			gomega.Expect(rm.Replace(rOld, rNew)).To(gomega.Succeed())
+			gomega.Expect(isRouteInTable(testNS, rNew, loLink.Attrs().Index, MainTableID)).To(gomega.BeTrue())
+			gomega.Expect(isRouteInTable(testNS, rOld, loLink.Attrs().Index, MainTableID)).To(gomega.BeFalse())
+			// the old route is not restored by the sync
+			gomega.Consistently(func() bool {
+				return isRouteInTable(testNS, rOld, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeFalse())
+		})
+
+		ginkgo.It("keeps the old route when the new route fails to apply", func() {
+			rOld := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Gw: loGWIP, Table: MainTableID}
+			rm.Add(rOld)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, rOld, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+			// the link of the new route does not exist
+			rNew := netlink.Route{LinkIndex: 1000, Dst: altSubnet, Gw: loIPDiff, Table: MainTableID}
+			gomega.Expect(rm.Replace(rOld, rNew)).NotTo(gomega.Succeed())
+			gomega.Expect(isRouteInTable(testNS, rOld, loLink.Attrs().Index, MainTableID)).To(gomega.BeTrue())
+		})
 	})
 
 	ginkgo.Context("del route", func() {
@@ -314,6 +360,63 @@ var _ = ginkgo.Describe("Route Manager", func() {
 			}, time.Second).Should(gomega.BeTrue())
 		})
 
+		ginkgo.It("doesn't apply nor reapply managed routes while paused", func() {
+			r := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: loSubnet, MTU: loMTU, Src: loIP, Table: MainTableID}
+			added := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Table: MainTableID}
+			rm.Add(r)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+			rm.Pause()
+			gomega.Expect(deleteRoutes(testNS, r)).ShouldNot(gomega.HaveOccurred())
+			rm.Add(added)
+			gomega.Consistently(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID) ||
+					isRouteInTable(testNS, added, loLink.Attrs().Index, MainTableID)
+			}, 700*time.Millisecond).Should(gomega.BeFalse())
+			// the managed routes are applied again once resumed
+			rm.Resume()
+			gomega.Eventually(func() bool {
+				return isRoutesInTable(testNS, []netlink.Route{r, added}, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+		})
+
+		ginkgo.It("deletes the routes deleted while paused once resumed", func() {
+			r := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Table: MainTableID}
+			rm.Add(r)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+			rm.Pause()
+			rm.Del(r)
+			gomega.Consistently(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, 700*time.Millisecond).Should(gomega.BeTrue())
+			rm.Resume()
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeFalse())
+		})
+
+		ginkgo.It("removes the managed routes within the excluded subnets until they are no longer excluded", func() {
+			r := netlink.Route{LinkIndex: loLink.Attrs().Index, Dst: altSubnet, Table: MainTableID}
+			rm.Add(r)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+			rm.SetExcludedSubnets([]*net.IPNet{{IP: net.IPv4(10, 10, 0, 0), Mask: net.CIDRMask(16, 32)}})
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeFalse())
+			gomega.Consistently(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, 700*time.Millisecond).Should(gomega.BeFalse())
+			rm.SetExcludedSubnets(nil)
+			gomega.Eventually(func() bool {
+				return isRouteInTable(testNS, r, loLink.Attrs().Index, MainTableID)
+			}, time.Second).Should(gomega.BeTrue())
+		})
+
 		ginkgo.It("deleting link doesn't cause panic", func() {
 			var link netlink.Link
 			var err error
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iprulemanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/vrfmanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -31,7 +32,7 @@ type SecondaryNodeNetworkController struct {
 // infrastructure and policy for the given secondary network. It supports layer3, layer2 and
 // localnet topology types.
 func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, netInfo util.NetInfo,
-	vrfManager *vrfmanager.Controller, defaultNetworkGateway Gateway) (*SecondaryNodeNetworkController, error) {
+	vrfManager *vrfmanager.Controller, ruleManager *iprulemanager.Controller, defaultNetworkGateway Gateway) (*SecondaryNodeNetworkController, error) {
 	snnc := &SecondaryNodeNetworkController{
 		BaseNodeNetworkController: BaseNodeNetworkController{
 			CommonNodeNetworkControllerInfo: *cnnci,
@@ -52,7 +53,7 @@ func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, n
 		}
 		// FIXME (tssurya): Remove this match when L2 networks are supported
 		if snnc.NetInfo.TopologyType() == types.Layer3Topology {
-			snnc.gateway, err = NewUserDefinedNetworkGateway(snnc.NetInfo, networkID, node, snnc.watchFactory.NodeCoreInformer().Lister(), snnc.Kube, vrfManager, defaultNetworkGateway)
+			snnc.gateway, err = NewUserDefinedNetworkGateway(snnc.NetInfo, networkID, node, snnc.watchFactory.NodeCoreInformer().Lister(), snnc.Kube, vrfManager, ruleManager, defaultNetworkGateway)
 			if err != nil {
 				return nil, fmt.Errorf("error creating UDN gateway for network %s: %v", netInfo.GetNetworkName(), err)
 			}
@@ -73,6 +74,18 @@ func (nc *SecondaryNodeNetworkController) Start(ctx context.Context) error {
 		}
 		nc.podHandler = handler
 	}
+	// there is no OVS on the DPU host, the physical networks are mapped on the DPU
+	if nc.TopologyType() == types.LocalnetTopology && config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		if err := nc.startLocalnet(); err != nil {
+			return err
+		}
+	}
+	// the provider interfaces of the DPU host are on the DPU
+	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
+		if _, err := nc.validateNetworkMTU(); err != nil {
+			klog.Warningf("Failed to validate the MTU of network %s: %v", nc.GetNetworkName(), err)
+		}
+	}
 	// FIXME (tssurya): Remove L3 match when L2 networks are supported
 	if util.IsNetworkSegmentationSupportEnabled() && nc.IsPrimaryNetwork() && nc.TopologyType() == types.Layer3Topology {
 		if err := nc.gateway.AddNetwork(); err != nil {
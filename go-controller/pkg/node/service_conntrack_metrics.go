@@ -0,0 +1,210 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// serviceConntrackInterval is how often the conntrack entries of the services are counted
+const serviceConntrackInterval = time.Minute
+
+const (
+	serviceConntrackClusterIP = "cluster_ip"
+	serviceConntrackNodePort  = "node_port"
+)
+
+// serviceConntrackPort is the original destination protocol and port of the conntrack entries of a service
+type serviceConntrackPort struct {
+	protocol uint8
+	port     uint16
+}
+
+// serviceConntrackFilter matches the conntrack entries destined to an IP and port of a service
+type serviceConntrackFilter struct {
+	filter *netlink.ConntrackFilter
+	key    serviceConntrackKey
+}
+
+// serviceConntrackKey identifies the ClusterIPs or the NodePorts of a service
+type serviceConntrackKey struct {
+	service  ktypes.NamespacedName
+	destType string
+}
+
+// serviceConntrackCollector periodically counts the conntrack entries destined to the ClusterIPs and the
+// NodePorts of the services and reports the services with the most entries in metrics, to find the services
+// exhausting the conntrack table of the node
+type serviceConntrackCollector struct {
+	topN         int
+	listServices func() ([]*kapi.Service, error)
+	// nodeIPs returns the addresses of the node the NodePorts are reached on, the NodePorts are not
+	// counted when it is nil
+	nodeIPs func() []net.IP
+	// listConntrack dumps the conntrack entries of the IP family. The vendored netlink library does not
+	// filter the dump in the kernel, the entries are matched with netlink conntrack filters afterwards.
+	listConntrack func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error)
+}
+
+func newServiceConntrackCollector(topN int, listServices func() ([]*kapi.Service, error),
+	nodeIPs func() []net.IP) *serviceConntrackCollector {
+	return &serviceConntrackCollector{
+		topN:         topN,
+		listServices: listServices,
+		nodeIPs:      nodeIPs,
+		listConntrack: func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+			return util.GetNetLinkOps().ConntrackTableList(netlink.ConntrackTable, family)
+		},
+	}
+}
+
+func (c *serviceConntrackCollector) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, serviceConntrackInterval, nil, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to count the conntrack entries of the services: %v", err)
+		}
+	})
+}
+
+// sync counts the conntrack entries of the services and reports the top N
+func (c *serviceConntrackCollector) sync() error {
+	counts, err := c.count()
+	if err != nil {
+		return err
+	}
+	keys := make([]serviceConntrackKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		if keys[i].service != keys[j].service {
+			return keys[i].service.String() < keys[j].service.String()
+		}
+		return keys[i].destType < keys[j].destType
+	})
+	if len(keys) > c.topN {
+		keys = keys[:c.topN]
+	}
+	metrics.MetricNodeServiceConntrackEntries.Reset()
+	for _, key := range keys {
+		metrics.MetricNodeServiceConntrackEntries.WithLabelValues(key.service.Namespace, key.service.Name, key.destType).
+			Set(float64(counts[key]))
+	}
+	return nil
+}
+
+// count returns the number of conntrack entries by service and destination type
+func (c *serviceConntrackCollector) count() (map[serviceConntrackKey]int, error) {
+	filters, err := c.filters()
+	if err != nil {
+		return nil, err
+	}
+	counts := map[serviceConntrackKey]int{}
+	if len(filters) == 0 {
+		return counts, nil
+	}
+	var families []netlink.InetFamily
+	if config.IPv4Mode {
+		families = append(families, netlink.FAMILY_V4)
+	}
+	if config.IPv6Mode {
+		families = append(families, netlink.FAMILY_V6)
+	}
+	for _, family := range families {
+		flows, err := c.listConntrack(family)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the conntrack entries: %w", err)
+		}
+		for _, flow := range flows {
+			// only the filters of the destination port of the entry may match it
+			for _, f := range filters[serviceConntrackPort{protocol: flow.Forward.Protocol, port: flow.Forward.DstPort}] {
+				if f.filter.MatchConntrackFlow(flow) {
+					counts[f.key]++
+					break
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+// filters returns the conntrack filters of the ClusterIPs of the services and of their NodePorts on the node
+// IPs, by destination protocol and port
+func (c *serviceConntrackCollector) filters() (map[serviceConntrackPort][]serviceConntrackFilter, error) {
+	services, err := c.listServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	var nodeIPs []net.IP
+	if c.nodeIPs != nil {
+		nodeIPs = c.nodeIPs()
+	}
+	filters := map[serviceConntrackPort][]serviceConntrackFilter{}
+	addFilter := func(key serviceConntrackKey, protocol uint8, ip net.IP, port uint16) error {
+		filter := &netlink.ConntrackFilter{}
+		if err := filter.AddProtocol(protocol); err != nil {
+			return err
+		}
+		if err := filter.AddIP(netlink.ConntrackOrigDstIP, ip); err != nil {
+			return err
+		}
+		if err := filter.AddPort(netlink.ConntrackOrigDstPort, port); err != nil {
+			return err
+		}
+		dest := serviceConntrackPort{protocol: protocol, port: port}
+		filters[dest] = append(filters[dest], serviceConntrackFilter{filter: filter, key: key})
+		return nil
+	}
+	for _, svc := range services {
+		if !util.ServiceTypeHasClusterIP(svc) || !util.IsClusterIPSet(svc) {
+			continue
+		}
+		name := ktypes.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+		for _, port := range svc.Spec.Ports {
+			protocol := conntrackProtocol(port.Protocol)
+			for _, clusterIP := range util.GetClusterIPs(svc) {
+				key := serviceConntrackKey{service: name, destType: serviceConntrackClusterIP}
+				if err := addFilter(key, protocol, net.ParseIP(clusterIP), uint16(port.Port)); err != nil {
+					return nil, fmt.Errorf("failed to build the conntrack filter of service %s: %w", name, err)
+				}
+			}
+			if port.NodePort == 0 {
+				continue
+			}
+			for _, nodeIP := range nodeIPs {
+				key := serviceConntrackKey{service: name, destType: serviceConntrackNodePort}
+				if err := addFilter(key, protocol, nodeIP, uint16(port.NodePort)); err != nil {
+					return nil, fmt.Errorf("failed to build the conntrack filter of service %s: %w", name, err)
+				}
+			}
+		}
+	}
+	return filters, nil
+}
+
+func conntrackProtocol(protocol kapi.Protocol) uint8 {
+	switch protocol {
+	case kapi.ProtocolUDP:
+		return syscall.IPPROTO_UDP
+	case kapi.ProtocolSCTP:
+		return syscall.IPPROTO_SCTP
+	default:
+		return syscall.IPPROTO_TCP
+	}
+}
@@ -0,0 +1,121 @@
+package node
+
+import (
+	"net"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+func serviceConntrackMetric(namespace, name, destType string) float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeServiceConntrackEntries.WithLabelValues(namespace, name, destType).Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
+
+func serviceConntrackMetricCount() int {
+	ch := make(chan prometheus.Metric, 10)
+	metrics.MetricNodeServiceConntrackEntries.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+func conntrackFlow(protocol uint8, dstIP string, dstPort uint16) *netlink.ConntrackFlow {
+	flow := &netlink.ConntrackFlow{}
+	flow.Forward.Protocol = protocol
+	flow.Forward.DstIP = net.ParseIP(dstIP)
+	flow.Forward.DstPort = dstPort
+	return flow
+}
+
+var _ = Describe("Service conntrack metrics", func() {
+	var (
+		c     *serviceConntrackCollector
+		flows []*netlink.ConntrackFlow
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.IPv4Mode = true
+		metrics.MetricNodeServiceConntrackEntries.Reset()
+		services := []*kapi.Service{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+				Spec: kapi.ServiceSpec{
+					Type:       kapi.ServiceTypeNodePort,
+					ClusterIP:  "10.96.0.10",
+					ClusterIPs: []string{"10.96.0.10"},
+					Ports:      []kapi.ServicePort{{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: 30080}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dns"},
+				Spec: kapi.ServiceSpec{
+					Type:       kapi.ServiceTypeClusterIP,
+					ClusterIP:  "10.96.0.53",
+					ClusterIPs: []string{"10.96.0.53"},
+					Ports:      []kapi.ServicePort{{Protocol: kapi.ProtocolUDP, Port: 53}},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "headless"},
+				Spec: kapi.ServiceSpec{
+					Type:       kapi.ServiceTypeClusterIP,
+					ClusterIP:  kapi.ClusterIPNone,
+					ClusterIPs: []string{kapi.ClusterIPNone},
+					Ports:      []kapi.ServicePort{{Protocol: kapi.ProtocolTCP, Port: 80}},
+				},
+			},
+		}
+		flows = []*netlink.ConntrackFlow{
+			conntrackFlow(syscall.IPPROTO_TCP, "10.96.0.10", 80),
+			conntrackFlow(syscall.IPPROTO_TCP, "172.18.0.2", 30080),
+			conntrackFlow(syscall.IPPROTO_TCP, "172.18.0.3", 30080),
+			conntrackFlow(syscall.IPPROTO_UDP, "10.96.0.53", 53),
+			conntrackFlow(syscall.IPPROTO_UDP, "10.96.0.53", 53),
+			conntrackFlow(syscall.IPPROTO_UDP, "10.96.0.53", 53),
+			// wrong protocol and unknown destinations
+			conntrackFlow(syscall.IPPROTO_UDP, "10.96.0.10", 80),
+			conntrackFlow(syscall.IPPROTO_TCP, "10.244.0.5", 8080),
+			// the NodePort of a pod IP is not the NodePort of the service
+			conntrackFlow(syscall.IPPROTO_TCP, "10.244.0.6", 30080),
+		}
+		nodeIPs := func() []net.IP { return []net.IP{net.ParseIP("172.18.0.2"), net.ParseIP("172.18.0.3")} }
+		c = newServiceConntrackCollector(3, func() ([]*kapi.Service, error) { return services, nil }, nodeIPs)
+		c.listConntrack = func(family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+			Expect(family).To(Equal(netlink.InetFamily(netlink.FAMILY_V4)))
+			return flows, nil
+		}
+	})
+
+	It("counts the conntrack entries by ClusterIP and NodePort of the services", func() {
+		Expect(c.sync()).To(Succeed())
+		Expect(serviceConntrackMetricCount()).To(Equal(3))
+		Expect(serviceConntrackMetric("ns", "dns", serviceConntrackClusterIP)).To(Equal(3.0))
+		Expect(serviceConntrackMetric("ns", "web", serviceConntrackNodePort)).To(Equal(2.0))
+		Expect(serviceConntrackMetric("ns", "web", serviceConntrackClusterIP)).To(Equal(1.0))
+	})
+
+	It("only reports the services with the most entries", func() {
+		c.topN = 1
+		Expect(c.sync()).To(Succeed())
+		Expect(serviceConntrackMetricCount()).To(Equal(1))
+		Expect(serviceConntrackMetric("ns", "dns", serviceConntrackClusterIP)).To(Equal(3.0))
+
+		flows = flows[:3]
+		Expect(c.sync()).To(Succeed())
+		Expect(serviceConntrackMetricCount()).To(Equal(1))
+		Expect(serviceConntrackMetric("ns", "web", serviceConntrackNodePort)).To(Equal(2.0))
+	})
+})
@@ -0,0 +1,268 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// serviceProbeSampleSize is the number of services probed by each round
+	serviceProbeSampleSize = 10
+	// serviceProbeTimeout is how long a probe waits for the connection to a service
+	serviceProbeTimeout = 2 * time.Second
+	// serviceProbeFailureThreshold is the number of consecutive rounds the probes of a service must fail in
+	// before the gateway flows are resynced
+	serviceProbeFailureThreshold = 3
+	// serviceProbeResyncInterval is the minimum time between two resyncs of the gateway flows
+	serviceProbeResyncInterval = 5 * time.Minute
+)
+
+const (
+	serviceProbeClusterIP = "cluster-ip"
+	serviceProbeNodePort  = "node-port"
+
+	serviceProbeSuccess = "success"
+	serviceProbeFailure = "failure"
+)
+
+// serviceProber periodically opens TCP connections from the node to the ClusterIP and NodePort of a
+// sample of the services with ready endpoints, through the gateway path, to detect the services the
+// data plane blackholes. The sample rotates over the services from one round to the next. The NodePorts
+// of the services with externalTrafficPolicy=Local are only probed when the service has ready endpoints
+// on the node.
+//
+// A probe succeeds when the connection is established or refused, a refusal means the SYN made it to
+// an endpoint; it fails when the connection times out. A service whose probes fail is probed again in
+// the next rounds; as it has ready endpoints, failed probes over serviceProbeFailureThreshold consecutive
+// rounds point at the gateway flows and a resync of them is requested, at most every
+// serviceProbeResyncInterval.
+type serviceProber struct {
+	interval          time.Duration
+	nodeName          string
+	listServices      func() ([]*kapi.Service, error)
+	getEndpointSlices func(namespace, name string) ([]*discovery.EndpointSlice, error)
+	// nodeIPs returns the addresses of the gateway bridge the NodePorts are probed on
+	nodeIPs func() []net.IP
+	// dial connects to the address over TCP
+	dial func(address string) error
+	// resync reinstalls the gateway flows
+	resync func()
+	clock  clock.Clock
+	// next is the index in the sorted services of the first service of the next sample
+	next int
+	// failures are the numbers of consecutive rounds the probes of the failing services failed in
+	failures map[ktypes.NamespacedName]int
+	// lastResync is when the gateway flows were last resynced
+	lastResync time.Time
+}
+
+func newServiceProber(interval time.Duration, nodeName string, listServices func() ([]*kapi.Service, error),
+	getEndpointSlices func(namespace, name string) ([]*discovery.EndpointSlice, error), nodeIPs func() []net.IP,
+	resync func()) *serviceProber {
+	return &serviceProber{
+		interval:          interval,
+		nodeName:          nodeName,
+		listServices:      listServices,
+		getEndpointSlices: getEndpointSlices,
+		nodeIPs:           nodeIPs,
+		dial: func(address string) error {
+			conn, err := net.DialTimeout("tcp", address, serviceProbeTimeout)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+		resync:   resync,
+		clock:    clock.RealClock{},
+		failures: map[ktypes.NamespacedName]int{},
+	}
+}
+
+func (p *serviceProber) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, p.interval, nil, func() {
+		if err := p.probe(); err != nil {
+			klog.Errorf("Failed to probe the services: %v", err)
+		}
+	})
+}
+
+// probe probes the failing services and the next sample of services, and requests a resync of the gateway
+// flows when the probes of a service keep failing
+func (p *serviceProber) probe() error {
+	services, err := p.listServices()
+	if err != nil {
+		return fmt.Errorf("failed to list the services: %w", err)
+	}
+	var probed []*kapi.Service
+	for _, service := range services {
+		if util.ServiceTypeHasClusterIP(service) && util.IsClusterIPSet(service) && serviceTCPPort(service) != nil {
+			probed = append(probed, service)
+		}
+	}
+	sort.Slice(probed, func(i, j int) bool {
+		if probed[i].Namespace != probed[j].Namespace {
+			return probed[i].Namespace < probed[j].Namespace
+		}
+		return probed[i].Name < probed[j].Name
+	})
+
+	// the failing services are probed again along with the sample
+	var sample []*kapi.Service
+	inSample := map[ktypes.NamespacedName]bool{}
+	for _, service := range probed {
+		name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+		if p.failures[name] > 0 {
+			sample = append(sample, service)
+			inSample[name] = true
+		}
+	}
+	if len(probed) > 0 {
+		p.next %= len(probed)
+		for i := 0; i < serviceProbeSampleSize && i < len(probed); i++ {
+			service := probed[(p.next+i)%len(probed)]
+			name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+			if !inSample[name] {
+				sample = append(sample, service)
+				inSample[name] = true
+			}
+		}
+		p.next += serviceProbeSampleSize
+	}
+
+	failures := map[ktypes.NamespacedName]int{}
+	var failing []string
+	for _, service := range sample {
+		name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+		ready, localReady, err := p.readyEndpoints(service)
+		if err != nil {
+			klog.Warningf("Not probing service %s: %v", name, err)
+			continue
+		}
+		if !ready {
+			continue
+		}
+		failed := false
+		for _, address := range p.probeAddresses(service, localReady) {
+			if err := p.probeAddress(address.address); err != nil {
+				klog.Warningf("Probe of the %s %s of service %s with ready endpoints failed: %v",
+					address.target, address.address, name, err)
+				metrics.MetricNodeServiceProbes.WithLabelValues(address.target, serviceProbeFailure).Inc()
+				failed = true
+				continue
+			}
+			metrics.MetricNodeServiceProbes.WithLabelValues(address.target, serviceProbeSuccess).Inc()
+		}
+		if failed {
+			failures[name] = p.failures[name] + 1
+			if failures[name] >= serviceProbeFailureThreshold {
+				failing = append(failing, name.String())
+			}
+		}
+	}
+	// the services that were not probed again, e.g. deleted, or whose probes succeeded are not failing anymore
+	p.failures = failures
+
+	if len(failing) == 0 {
+		return nil
+	}
+	if since := p.clock.Since(p.lastResync); since < serviceProbeResyncInterval {
+		klog.Warningf("The probes of services %v with ready endpoints keep failing, the gateway flows were resynced %s ago",
+			failing, since.Round(time.Second))
+		return nil
+	}
+	klog.Warningf("The probes of services %v with ready endpoints failed %d times in a row, resyncing the gateway flows",
+		failing, serviceProbeFailureThreshold)
+	metrics.MetricNodeServiceProbeResyncs.Inc()
+	p.lastResync = p.clock.Now()
+	p.resync()
+	return nil
+}
+
+// probeAddress returns an error when the connection to the address did not reach an endpoint
+func (p *serviceProber) probeAddress(address string) error {
+	if err := p.dial(address); err != nil && !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+	return nil
+}
+
+type serviceProbeAddress struct {
+	target  string
+	address string
+}
+
+// probeAddresses returns the ClusterIPs and the NodePorts on the node IPs of the same families to probe
+// the first TCP port of the service on. The NodePorts of a service with externalTrafficPolicy=Local are
+// only probed when it has local ready endpoints, the node drops the traffic otherwise.
+func (p *serviceProber) probeAddresses(service *kapi.Service, localReady bool) []serviceProbeAddress {
+	port := serviceTCPPort(service)
+	probeNodePort := port.NodePort != 0 && (localReady || !util.ServiceExternalTrafficPolicyLocal(service))
+	var addresses []serviceProbeAddress
+	nodeIPs := p.nodeIPs()
+	for _, clusterIP := range util.GetClusterIPs(service) {
+		addresses = append(addresses, serviceProbeAddress{
+			target:  serviceProbeClusterIP,
+			address: net.JoinHostPort(clusterIP, strconv.Itoa(int(port.Port))),
+		})
+		if !probeNodePort {
+			continue
+		}
+		isIPv6 := utilnet.IsIPv6String(clusterIP)
+		for _, nodeIP := range nodeIPs {
+			if utilnet.IsIPv6(nodeIP) == isIPv6 {
+				addresses = append(addresses, serviceProbeAddress{
+					target:  serviceProbeNodePort,
+					address: net.JoinHostPort(nodeIP.String(), strconv.Itoa(int(port.NodePort))),
+				})
+				break
+			}
+		}
+	}
+	return addresses
+}
+
+// readyEndpoints returns whether the service has ready endpoints, and ready endpoints on the node
+func (p *serviceProber) readyEndpoints(service *kapi.Service) (bool, bool, error) {
+	slices, err := p.getEndpointSlices(service.Namespace, service.Name)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get the endpoint slices: %w", err)
+	}
+	var ready, localReady bool
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready = true
+				if endpoint.NodeName != nil && *endpoint.NodeName == p.nodeName {
+					localReady = true
+				}
+			}
+		}
+	}
+	return ready, localReady, nil
+}
+
+// serviceTCPPort returns the first TCP port of the service, nil if it has none
+func serviceTCPPort(service *kapi.Service) *kapi.ServicePort {
+	for i := range service.Spec.Ports {
+		if service.Spec.Ports[i].Protocol == kapi.ProtocolTCP {
+			return &service.Spec.Ports[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dto "github.com/prometheus/client_model/go"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+func serviceProbes(target, result string) float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeServiceProbes.WithLabelValues(target, result).Write(m)).To(Succeed())
+	return m.GetCounter().GetValue()
+}
+
+func serviceProbeResyncs() float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeServiceProbeResyncs.Write(m)).To(Succeed())
+	return m.GetCounter().GetValue()
+}
+
+var _ = Describe("Service probes", func() {
+	var (
+		services  []*kapi.Service
+		endpoints map[string][]*discovery.EndpointSlice
+		dialed    []string
+		dialErrs  map[string]error
+		resyncs   int
+		fakeClock *clocktesting.FakeClock
+		prober    *serviceProber
+	)
+
+	newService := func(name, clusterIP string, nodePort int32) *kapi.Service {
+		return &kapi.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: kapi.ServiceSpec{
+				Type:       kapi.ServiceTypeNodePort,
+				ClusterIP:  clusterIP,
+				ClusterIPs: []string{clusterIP},
+				Ports: []kapi.ServicePort{
+					{Protocol: kapi.ProtocolUDP, Port: 53},
+					{Protocol: kapi.ProtocolTCP, Port: 80, NodePort: nodePort},
+				},
+			},
+		}
+	}
+
+	newEndpointSlice := func(ready bool) *discovery.EndpointSlice {
+		return &discovery.EndpointSlice{
+			Endpoints: []discovery.Endpoint{{
+				Addresses:  []string{"10.128.0.5"},
+				Conditions: discovery.EndpointConditions{Ready: ptr.To(ready)},
+				NodeName:   ptr.To("node2"),
+			}},
+		}
+	}
+
+	probeTimes := func(n int) {
+		for i := 0; i < n; i++ {
+			Expect(prober.probe()).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		services = nil
+		endpoints = map[string][]*discovery.EndpointSlice{}
+		dialed = nil
+		dialErrs = map[string]error{}
+		resyncs = 0
+		metrics.MetricNodeServiceProbes.Reset()
+		fakeClock = clocktesting.NewFakeClock(time.Now())
+		prober = newServiceProber(time.Minute, "node1",
+			func() ([]*kapi.Service, error) { return services, nil },
+			func(namespace, name string) ([]*discovery.EndpointSlice, error) { return endpoints[name], nil },
+			func() []net.IP { return []net.IP{net.ParseIP("fd00::10"), net.ParseIP("172.18.0.2")} },
+			func() { resyncs++ })
+		prober.dial = func(address string) error {
+			dialed = append(dialed, address)
+			return dialErrs[address]
+		}
+		prober.clock = fakeClock
+	})
+
+	It("probes the ClusterIP and NodePort of the services with ready endpoints", func() {
+		services = []*kapi.Service{
+			newService("ready", "10.96.0.10", 30080),
+			newService("not-ready", "10.96.0.11", 30081),
+			newService("no-endpoints", "10.96.0.12", 0),
+		}
+		endpoints["ready"] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		endpoints["not-ready"] = []*discovery.EndpointSlice{newEndpointSlice(false)}
+		// a refused connection made it to the endpoint
+		dialErrs["172.18.0.2:30080"] = &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+		resyncsBefore := serviceProbeResyncs()
+		Expect(prober.probe()).To(Succeed())
+		Expect(dialed).To(Equal([]string{"10.96.0.10:80", "172.18.0.2:30080"}))
+		Expect(serviceProbes(serviceProbeClusterIP, serviceProbeSuccess)).To(Equal(1.0))
+		Expect(serviceProbes(serviceProbeNodePort, serviceProbeSuccess)).To(Equal(1.0))
+		Expect(resyncs).To(BeZero())
+		Expect(serviceProbeResyncs()).To(Equal(resyncsBefore))
+	})
+
+	It("resyncs the gateway flows when the probes of a service with ready endpoints keep timing out", func() {
+		services = []*kapi.Service{newService("ready", "10.96.0.10", 30080)}
+		endpoints["ready"] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		dialErrs["10.96.0.10:80"] = fmt.Errorf("dial tcp 10.96.0.10:80: i/o timeout")
+
+		resyncsBefore := serviceProbeResyncs()
+		probeTimes(serviceProbeFailureThreshold - 1)
+		Expect(resyncs).To(BeZero())
+
+		probeTimes(1)
+		Expect(serviceProbes(serviceProbeClusterIP, serviceProbeFailure)).To(Equal(float64(serviceProbeFailureThreshold)))
+		Expect(serviceProbes(serviceProbeNodePort, serviceProbeSuccess)).To(Equal(float64(serviceProbeFailureThreshold)))
+		Expect(resyncs).To(Equal(1))
+		Expect(serviceProbeResyncs()).To(Equal(resyncsBefore + 1))
+	})
+
+	It("does not resync the gateway flows when the probes of a service recover", func() {
+		services = []*kapi.Service{newService("ready", "10.96.0.10", 30080)}
+		endpoints["ready"] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		dialErrs["10.96.0.10:80"] = fmt.Errorf("dial tcp 10.96.0.10:80: i/o timeout")
+		probeTimes(serviceProbeFailureThreshold - 1)
+
+		delete(dialErrs, "10.96.0.10:80")
+		probeTimes(1)
+		dialErrs["10.96.0.10:80"] = fmt.Errorf("dial tcp 10.96.0.10:80: i/o timeout")
+		probeTimes(serviceProbeFailureThreshold - 1)
+		Expect(resyncs).To(BeZero())
+	})
+
+	It("probes the failing services again until they recover", func() {
+		for i := 0; i < serviceProbeSampleSize*2; i++ {
+			name := fmt.Sprintf("svc%02d", i)
+			services = append(services, newService(name, fmt.Sprintf("10.96.0.%d", i+1), 0))
+			endpoints[name] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		}
+		dialErrs["10.96.0.1:80"] = fmt.Errorf("dial tcp 10.96.0.1:80: i/o timeout")
+		probeTimes(1)
+
+		dialed = nil
+		probeTimes(1)
+		Expect(dialed).To(HaveLen(serviceProbeSampleSize + 1))
+		Expect(dialed[0]).To(Equal("10.96.0.1:80"))
+
+		delete(dialErrs, "10.96.0.1:80")
+		probeTimes(1)
+		dialed = nil
+		probeTimes(1)
+		Expect(dialed).To(HaveLen(serviceProbeSampleSize))
+		Expect(dialed).NotTo(ContainElement("10.96.0.1:80"))
+	})
+
+	It("rate-limits the resyncs of the gateway flows", func() {
+		services = []*kapi.Service{newService("ready", "10.96.0.10", 30080)}
+		endpoints["ready"] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		dialErrs["10.96.0.10:80"] = fmt.Errorf("dial tcp 10.96.0.10:80: i/o timeout")
+		probeTimes(serviceProbeFailureThreshold)
+		Expect(resyncs).To(Equal(1))
+
+		fakeClock.Step(serviceProbeResyncInterval - time.Second)
+		probeTimes(serviceProbeFailureThreshold)
+		Expect(resyncs).To(Equal(1))
+
+		fakeClock.Step(time.Second)
+		probeTimes(1)
+		Expect(resyncs).To(Equal(2))
+	})
+
+	It("only probes the NodePort of an externalTrafficPolicy=Local service with local ready endpoints", func() {
+		remote := newService("remote", "10.96.0.10", 30080)
+		remote.Spec.ExternalTrafficPolicy = kapi.ServiceExternalTrafficPolicyLocal
+		local := newService("local", "10.96.0.11", 30081)
+		local.Spec.ExternalTrafficPolicy = kapi.ServiceExternalTrafficPolicyLocal
+		services = []*kapi.Service{local, remote}
+		endpoints["remote"] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		localSlice := newEndpointSlice(true)
+		localSlice.Endpoints[0].NodeName = ptr.To("node1")
+		endpoints["local"] = []*discovery.EndpointSlice{localSlice}
+
+		probeTimes(1)
+		Expect(dialed).To(Equal([]string{"10.96.0.11:80", "172.18.0.2:30081", "10.96.0.10:80"}))
+	})
+
+	It("rotates the sample over the services", func() {
+		for i := 0; i < serviceProbeSampleSize+2; i++ {
+			name := fmt.Sprintf("svc%02d", i)
+			services = append(services, newService(name, fmt.Sprintf("10.96.0.%d", i+1), 0))
+			endpoints[name] = []*discovery.EndpointSlice{newEndpointSlice(true)}
+		}
+		Expect(prober.probe()).To(Succeed())
+		Expect(dialed).To(HaveLen(serviceProbeSampleSize))
+		Expect(dialed[0]).To(Equal("10.96.0.1:80"))
+
+		dialed = nil
+		Expect(prober.probe()).To(Succeed())
+		Expect(dialed).To(HaveLen(serviceProbeSampleSize))
+		Expect(dialed[0]).To(Equal("10.96.0.11:80"))
+		Expect(dialed[2]).To(Equal("10.96.0.1:80"))
+	})
+})
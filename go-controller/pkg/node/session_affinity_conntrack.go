@@ -0,0 +1,121 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// getSessionAffinityTimeout returns the ClientIP session affinity timeout of the service and
+// whether the service uses ClientIP session affinity at all
+func getSessionAffinityTimeout(service *kapi.Service) (time.Duration, bool) {
+	if service == nil || service.Spec.SessionAffinity != kapi.ServiceAffinityClientIP {
+		return 0, false
+	}
+	// the API always defaults the timeout when affinity is ClientIP, this is only a safeguard
+	timeout := kapi.DefaultClientIPServiceAffinitySeconds
+	if service.Spec.SessionAffinityConfig != nil &&
+		service.Spec.SessionAffinityConfig.ClientIP != nil &&
+		service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds != nil {
+		timeout = *service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds
+	}
+	return time.Duration(timeout) * time.Second, true
+}
+
+// affinityConntrackCleaner flushes the conntrack entries of endpoints removed from services
+// that use ClientIP session affinity. While the affinity of a client has not expired, new
+// connections from that client may still be steered to the removed endpoint and create new
+// conntrack entries after the initial flush done upon the endpoint removal. The cleaner
+// therefore flushes the entries of the endpoint a second time once the affinity timeout has
+// elapsed, unless the endpoint has been added back to the service in the meantime.
+type affinityConntrackCleaner struct {
+	sync.Mutex
+	// timers holds the pending cleanups, keyed by endpoint IP, port and protocol
+	timers map[string]*time.Timer
+	// deleteConntrack is a variable so that unit tests can intercept the cleanups
+	deleteConntrack func(ip string, port int32, protocol kapi.Protocol) error
+}
+
+func newAffinityConntrackCleaner() *affinityConntrackCleaner {
+	return &affinityConntrackCleaner{
+		timers: make(map[string]*time.Timer),
+		deleteConntrack: func(ip string, port int32, protocol kapi.Protocol) error {
+			return util.DeleteConntrackServicePort(ip, port, protocol, netlink.ConntrackReplyAnyIP, nil)
+		},
+	}
+}
+
+func affinityConntrackKey(ip string, port int32, protocol kapi.Protocol) string {
+	return fmt.Sprintf("%s/%d/%s", ip, port, protocol)
+}
+
+// schedule flushes the conntrack entries of the endpoint once timeout has elapsed. A cleanup
+// already pending for the same endpoint is postponed.
+func (c *affinityConntrackCleaner) schedule(ip string, port int32, protocol kapi.Protocol, timeout time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	key := affinityConntrackKey(ip, port, protocol)
+	if timer, ok := c.timers[key]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		c.Lock()
+		// skip if the cleanup was cancelled or rescheduled while this timer fired
+		if c.timers[key] != timer {
+			c.Unlock()
+			return
+		}
+		delete(c.timers, key)
+		c.Unlock()
+		servicesLog.V(5).Infof("Session affinity timeout elapsed for removed endpoint %s, flushing its conntrack entries", key)
+		if err := c.deleteConntrack(ip, port, protocol); err != nil {
+			klog.Errorf("Failed to delete conntrack entry for %s: %v", ip, err)
+		}
+	})
+	c.timers[key] = timer
+}
+
+// cancel drops the pending cleanup of the endpoint, if any
+func (c *affinityConntrackCleaner) cancel(ip string, port int32, protocol kapi.Protocol) {
+	c.Lock()
+	defer c.Unlock()
+	key := affinityConntrackKey(ip, port, protocol)
+	if timer, ok := c.timers[key]; ok {
+		timer.Stop()
+		delete(c.timers, key)
+	}
+}
+
+// cancelEndpointSlice drops the pending cleanups of all the endpoints of the endpoint slice
+func (c *affinityConntrackCleaner) cancelEndpointSlice(endpointSlice *discovery.EndpointSlice) {
+	for _, port := range endpointSlice.Ports {
+		if port.Port == nil || port.Protocol == nil {
+			continue
+		}
+		for _, endpoint := range endpointSlice.Endpoints {
+			for _, ip := range endpoint.Addresses {
+				c.cancel(utilnet.ParseIPSloppy(ip).String(), *port.Port, *port.Protocol)
+			}
+		}
+	}
+}
+
+// stop drops all the pending cleanups
+func (c *affinityConntrackCleaner) stop() {
+	c.Lock()
+	defer c.Unlock()
+	for key, timer := range c.timers {
+		timer.Stop()
+		delete(c.timers, key)
+	}
+}
@@ -0,0 +1,80 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Session affinity conntrack cleanup", func() {
+	var (
+		cleaner *affinityConntrackCleaner
+		lock    sync.Mutex
+		deleted []string
+	)
+
+	BeforeEach(func() {
+		deleted = nil
+		cleaner = newAffinityConntrackCleaner()
+		cleaner.deleteConntrack = func(ip string, port int32, protocol kapi.Protocol) error {
+			lock.Lock()
+			defer lock.Unlock()
+			deleted = append(deleted, affinityConntrackKey(ip, port, protocol))
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		cleaner.stop()
+	})
+
+	getDeleted := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, deleted...)
+	}
+
+	It("returns the affinity timeout of ClientIP services only", func() {
+		svc := &kapi.Service{}
+		_, hasAffinity := getSessionAffinityTimeout(svc)
+		Expect(hasAffinity).To(BeFalse())
+
+		svc.Spec.SessionAffinity = kapi.ServiceAffinityClientIP
+		timeout, hasAffinity := getSessionAffinityTimeout(svc)
+		Expect(hasAffinity).To(BeTrue())
+		Expect(timeout).To(Equal(time.Duration(kapi.DefaultClientIPServiceAffinitySeconds) * time.Second))
+
+		svc.Spec.SessionAffinityConfig = &kapi.SessionAffinityConfig{
+			ClientIP: &kapi.ClientIPConfig{TimeoutSeconds: ptr.To[int32](30)},
+		}
+		timeout, _ = getSessionAffinityTimeout(svc)
+		Expect(timeout).To(Equal(30 * time.Second))
+	})
+
+	It("flushes the conntrack entries of a removed endpoint once the timeout elapses", func() {
+		cleaner.schedule("10.128.0.5", 8080, kapi.ProtocolTCP, 50*time.Millisecond)
+		Eventually(getDeleted).Should(Equal([]string{"10.128.0.5/8080/TCP"}))
+		cleaner.Lock()
+		Expect(cleaner.timers).To(BeEmpty())
+		cleaner.Unlock()
+	})
+
+	It("does not flush endpoints that are added back to the service", func() {
+		cleaner.schedule("10.128.0.5", 8080, kapi.ProtocolTCP, 50*time.Millisecond)
+		cleaner.schedule("10.128.0.6", 8080, kapi.ProtocolTCP, 50*time.Millisecond)
+		cleaner.cancelEndpointSlice(&discovery.EndpointSlice{
+			Ports: []discovery.EndpointPort{{Port: ptr.To[int32](8080), Protocol: ptr.To(kapi.ProtocolTCP)}},
+			Endpoints: []discovery.Endpoint{
+				{Addresses: []string{"10.128.0.5"}},
+			},
+		})
+		Eventually(getDeleted).Should(Equal([]string{"10.128.0.6/8080/TCP"}))
+		Consistently(getDeleted, 200*time.Millisecond).Should(HaveLen(1))
+	})
+})
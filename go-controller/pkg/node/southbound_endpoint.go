@@ -0,0 +1,90 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// southboundEndpoint owns the Southbound database ovnkube-node and ovn-controller are connected to once
+// the node runs. The zone migration and the OVN databases discovery move the node to another Southbound
+// database through it, so that config.OvnSouth.Address, the node zone and ovn-remote are only updated
+// by one of them at a time.
+// While the node is migrated to another zone, the discovered endpoints are ignored: they are the endpoints
+// of the database of the zone the node was configured with, following them would revert the migration.
+type southboundEndpoint struct {
+	sync.Mutex
+	// configuredZone is the zone the node was configured with
+	configuredZone string
+	// migrated is set while the node is migrated to another zone than configuredZone
+	migrated bool
+	// apply writes the Southbound database configuration to OVS for ovn-controller
+	apply func() error
+}
+
+func newSouthboundEndpoint() *southboundEndpoint {
+	return &southboundEndpoint{
+		configuredZone: config.Default.Zone,
+		apply:          config.OvnSouth.SetDBAuth,
+	}
+}
+
+// setDBAuth writes the Southbound database configuration of the node to OVS
+func (e *southboundEndpoint) setDBAuth() error {
+	e.Lock()
+	defer e.Unlock()
+	return e.apply()
+}
+
+// connect points ovnkube-node and ovn-controller to the Southbound database at address, e must be locked
+func (e *southboundEndpoint) connect(address string) error {
+	config.OvnSouth.SetAddress(address)
+	return e.apply()
+}
+
+// follow connects the node to the discovered Southbound database at address, unless the node was
+// migrated to another zone
+func (e *southboundEndpoint) follow(address string) error {
+	e.Lock()
+	defer e.Unlock()
+	current := config.OvnSouth.GetURL()
+	if address == current {
+		return nil
+	}
+	if e.migrated {
+		klog.Infof("Ignoring the discovered OVN Southbound database endpoints %s, the node was migrated to zone %s",
+			address, config.GetZone())
+		return nil
+	}
+	klog.Infof("OVN Southbound database endpoints changed from %s to %s", current, address)
+	return e.connect(address)
+}
+
+// reconnect makes ovn-controller reconnect to the Southbound database of the node, e.g. after the
+// Southbound database endpoints changed. ovn-controller only reconnects when ovn-remote changes, so
+// ovn-remote is removed and then written back.
+func (e *southboundEndpoint) reconnect() error {
+	e.Lock()
+	defer e.Unlock()
+	klog.Infof("Forcing ovn-controller to reconnect to the Southbound database %s", config.OvnSouth.GetURL())
+	if _, stderr, err := util.RunOVSVsctl("remove", "Open_vSwitch", ".", "external_ids", "ovn-remote"); err != nil {
+		return fmt.Errorf("failed to remove ovn-remote, stderr: %q, error: %v", stderr, err)
+	}
+	// give ovn-controller the time to drop the connection before ovn-remote is set back
+	err := wait.PollUntilContextTimeout(context.Background(), 100*time.Millisecond, 5*time.Second, true,
+		func(context.Context) (bool, error) {
+			status, _, err := util.RunOVNControllerAppCtl("connection-status")
+			return err == nil && status != "connected", nil
+		})
+	if err != nil {
+		klog.Warningf("ovn-controller did not drop its Southbound database connection: %v", err)
+	}
+	return e.apply()
+}
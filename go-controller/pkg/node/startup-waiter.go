@@ -10,6 +10,8 @@ import (
 )
 
 type startupWaiter struct {
+	// lock protects the tasks, added concurrently by the management ports being created
+	lock    sync.Mutex
 	tasks   []*waitTask
 	wg      *sync.WaitGroup
 	timeout time.Duration
@@ -36,6 +38,8 @@ func newStartupWaiter() *startupWaiter {
 }
 
 func (w *startupWaiter) AddWait(waitFn waitFunc, postFn postWaitFunc) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 	w.tasks = append(w.tasks, &waitTask{
 		waitFn: waitFn,
 		postFn: postFn,
@@ -43,8 +47,11 @@ func (w *startupWaiter) AddWait(waitFn waitFunc, postFn postWaitFunc) {
 }
 
 func (w *startupWaiter) Wait() error {
-	errors := make(chan error, len(w.tasks))
-	for _, t := range w.tasks {
+	w.lock.Lock()
+	tasks := w.tasks
+	w.lock.Unlock()
+	errors := make(chan error, len(tasks))
+	for _, t := range tasks {
 		w.wg.Add(1)
 		go func(task *waitTask) {
 			defer w.wg.Done()
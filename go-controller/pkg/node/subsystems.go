@@ -0,0 +1,394 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+// subsystemState is the state of a node subsystem
+type subsystemState string
+
+const (
+	// subsystemRunning is the state of a started subsystem
+	subsystemRunning subsystemState = "running"
+	// subsystemDisabled is the state of a subsystem disabled by the configuration or by the admin
+	subsystemDisabled subsystemState = "disabled"
+	// subsystemBlocked is the state of a subsystem not started as one of its dependencies is not running
+	subsystemBlocked subsystemState = "blocked"
+	// subsystemFailed is the state of a subsystem that failed to start or to stop
+	subsystemFailed subsystemState = "failed"
+	// subsystemStopped is the state of a subsystem stopped with the node controller
+	subsystemStopped subsystemState = "stopped"
+	// subsystemDegraded is the state of a subsystem not started as the node runs without what it requires,
+	// without failing the node controller
+	subsystemDegraded subsystemState = "degraded"
+)
+
+var subsystemStates = []subsystemState{subsystemRunning, subsystemDisabled, subsystemBlocked, subsystemFailed,
+	subsystemStopped, subsystemDegraded}
+
+// subsystemDegradedError is returned by the start of a subsystem that can't start as the node runs without
+// what it requires, the node controller keeps running without it
+type subsystemDegradedError struct {
+	err error
+}
+
+func (e *subsystemDegradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *subsystemDegradedError) Unwrap() error {
+	return e.err
+}
+
+// nodeSubsystem is an optional part of ovnkube-node started by the node controller
+type nodeSubsystem struct {
+	name string
+	// enabled returns whether the configuration enables the subsystem, the subsystem is always enabled
+	// when not set
+	enabled func() bool
+	// dependsOn are the subsystems that must be running for the subsystem to be started, they must be
+	// registered before the subsystem
+	dependsOn []string
+	// required subsystems can't be disabled with the disable-subsystems option
+	required bool
+	// start starts the subsystem
+	start func() error
+	// stop stops the subsystem before the node controller stop channel is closed, the subsystems without
+	// it are stopped with the node controller
+	stop func() error
+	// healthy returns why the running subsystem is not healthy, the subsystem is always healthy when not set
+	healthy func() error
+}
+
+// subsystemStatus is the runtime status of a node subsystem
+type subsystemStatus struct {
+	State subsystemState `json:"state"`
+	// Reason is why the subsystem is not running
+	Reason string `json:"reason,omitempty"`
+}
+
+// subsystemRegistry starts the node subsystems in their registration order, stops them in the reverse
+// order and keeps their status. A subsystem is started unless the configuration disables it, the admin
+// disabled it with the disable-subsystems option or one of the subsystems it depends on is not running.
+type subsystemRegistry struct {
+	// disabled are the subsystems disabled by the admin
+	disabled   sets.Set[string]
+	subsystems []*nodeSubsystem
+	// pending is the index of the first registered subsystem not started yet
+	pending int
+	// running are the started subsystems, in their start order
+	running []*nodeSubsystem
+	// lifecycleLock serializes the reconciles of the subsystems with their stop, the subsystems are no longer
+	// reconciled once stopped
+	lifecycleLock sync.Mutex
+	stopped       bool
+
+	status map[string]subsystemStatus
+	sync.RWMutex
+}
+
+func newSubsystemRegistry() *subsystemRegistry {
+	r := &subsystemRegistry{
+		disabled: sets.New[string](),
+		status:   map[string]subsystemStatus{},
+	}
+	for _, name := range strings.Split(config.OvnKubeNode.DisableSubsystems, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			r.disabled.Insert(name)
+		}
+	}
+	return r
+}
+
+func (r *subsystemRegistry) register(subsystem *nodeSubsystem) error {
+	r.Lock()
+	defer r.Unlock()
+	for _, registered := range r.subsystems {
+		if registered.name == subsystem.name {
+			return fmt.Errorf("node subsystem %s is already registered", subsystem.name)
+		}
+	}
+	for _, dependency := range subsystem.dependsOn {
+		if !r.registered(dependency) {
+			return fmt.Errorf("node subsystem %s depends on %s that is not registered before it", subsystem.name, dependency)
+		}
+	}
+	r.subsystems = append(r.subsystems, subsystem)
+	return nil
+}
+
+func (r *subsystemRegistry) registered(name string) bool {
+	for _, subsystem := range r.subsystems {
+		if subsystem.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// startPending starts the subsystems registered since the last call, in their registration order. It
+// stops at the first subsystem failing to start and returns its error. The starts are traced as part of ctx.
+func (r *subsystemRegistry) startPending(ctx context.Context) error {
+	r.Lock()
+	subsystems := r.subsystems[r.pending:]
+	r.pending = len(r.subsystems)
+	r.Unlock()
+
+	for _, subsystem := range subsystems {
+		_, span := metrics.StartSpan(ctx, "startSubsystem", attribute.String("node.subsystem", subsystem.name))
+		status, err := r.startSubsystem(subsystem)
+		span.SetAttributes(attribute.String("node.subsystem.state", string(status.State)))
+		metrics.EndSpan(span, err)
+		r.setStatus(subsystem.name, status)
+		if status.State == subsystemRunning {
+			r.Lock()
+			r.running = append(r.running, subsystem)
+			r.Unlock()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to start node subsystem %s: %w", subsystem.name, err)
+		}
+	}
+	return nil
+}
+
+func (r *subsystemRegistry) startSubsystem(subsystem *nodeSubsystem) (subsystemStatus, error) {
+	if subsystem.required && r.disabled.Has(subsystem.name) {
+		klog.Warningf("Node subsystem %s is required and can't be disabled by the disable-subsystems option", subsystem.name)
+	} else if r.disabled.Has(subsystem.name) {
+		klog.Infof("Node subsystem %s is disabled by the disable-subsystems option", subsystem.name)
+		return subsystemStatus{State: subsystemDisabled, Reason: "disabled by the admin"}, nil
+	}
+	if subsystem.enabled != nil && !subsystem.enabled() {
+		nodeLog.V(5).Infof("Node subsystem %s is disabled by the configuration", subsystem.name)
+		return subsystemStatus{State: subsystemDisabled, Reason: "disabled by the configuration"}, nil
+	}
+	for _, dependency := range subsystem.dependsOn {
+		if state := r.getStatus(dependency).State; state != subsystemRunning {
+			klog.Infof("Node subsystem %s is not started: it depends on %s that is %s", subsystem.name, dependency, state)
+			return subsystemStatus{State: subsystemBlocked, Reason: fmt.Sprintf("dependency %s is %s", dependency, state)}, nil
+		}
+	}
+	if err := subsystem.start(); err != nil {
+		var degraded *subsystemDegradedError
+		if errors.As(err, &degraded) {
+			klog.Warningf("Node subsystem %s is not started, running degraded: %v", subsystem.name, err)
+			return subsystemStatus{State: subsystemDegraded, Reason: err.Error()}, nil
+		}
+		return subsystemStatus{State: subsystemFailed, Reason: err.Error()}, err
+	}
+	klog.Infof("Node subsystem %s started", subsystem.name)
+	return subsystemStatus{State: subsystemRunning}, nil
+}
+
+// stopAll stops the running subsystems in the reverse order of their start. All of them are stopped
+// even if some fail to stop, and the errors of the failing ones are returned.
+func (r *subsystemRegistry) stopAll() error {
+	r.lifecycleLock.Lock()
+	defer r.lifecycleLock.Unlock()
+	r.stopped = true
+	r.Lock()
+	running := r.running
+	r.running = nil
+	r.Unlock()
+
+	var errs []error
+	for i := len(running) - 1; i >= 0; i-- {
+		subsystem := running[i]
+		if subsystem.stop == nil {
+			r.setStatus(subsystem.name, subsystemStatus{State: subsystemStopped})
+			continue
+		}
+		if err := subsystem.stop(); err != nil {
+			klog.Errorf("Failed to stop node subsystem %s: %v", subsystem.name, err)
+			r.setStatus(subsystem.name, subsystemStatus{State: subsystemFailed, Reason: err.Error()})
+			errs = append(errs, fmt.Errorf("failed to stop node subsystem %s: %w", subsystem.name, err))
+			continue
+		}
+		klog.Infof("Node subsystem %s stopped", subsystem.name)
+		r.setStatus(subsystem.name, subsystemStatus{State: subsystemStopped})
+	}
+	return utilerrors.Join(errs...)
+}
+
+// reconcile applies a change of the enabled state of the named subsystems, e.g. when it follows the node: the
+// running ones that got disabled are stopped, in the reverse order of their names, and the disabled or blocked
+// ones are started again when they got enabled, in the order of their names. Only the subsystems with a stop
+// function can be reconciled.
+func (r *subsystemRegistry) reconcile(names ...string) error {
+	r.lifecycleLock.Lock()
+	defer r.lifecycleLock.Unlock()
+	if r.stopped {
+		return nil
+	}
+	var subsystems []*nodeSubsystem
+	r.RLock()
+	for _, name := range names {
+		for _, subsystem := range r.subsystems[:r.pending] {
+			if subsystem.name == name {
+				subsystems = append(subsystems, subsystem)
+			}
+		}
+	}
+	r.RUnlock()
+
+	var errs []error
+	for i := len(subsystems) - 1; i >= 0; i-- {
+		subsystem := subsystems[i]
+		if subsystem.stop == nil {
+			errs = append(errs, fmt.Errorf("node subsystem %s can't be reconciled without a stop", subsystem.name))
+			continue
+		}
+		if !r.isRunning(subsystem) || subsystem.enabled == nil || subsystem.enabled() {
+			continue
+		}
+		r.Lock()
+		for j, running := range r.running {
+			if running == subsystem {
+				r.running = append(r.running[:j:j], r.running[j+1:]...)
+				break
+			}
+		}
+		r.Unlock()
+		if err := subsystem.stop(); err != nil {
+			klog.Errorf("Failed to stop node subsystem %s: %v", subsystem.name, err)
+			r.setStatus(subsystem.name, subsystemStatus{State: subsystemFailed, Reason: err.Error()})
+			errs = append(errs, fmt.Errorf("failed to stop node subsystem %s: %w", subsystem.name, err))
+			continue
+		}
+		klog.Infof("Node subsystem %s stopped, it is disabled by the configuration", subsystem.name)
+		r.setStatus(subsystem.name, subsystemStatus{State: subsystemDisabled, Reason: "disabled by the configuration"})
+	}
+	for _, subsystem := range subsystems {
+		if state := r.getStatus(subsystem.name).State; subsystem.stop == nil ||
+			(state != subsystemDisabled && state != subsystemBlocked) {
+			continue
+		}
+		status, err := r.startSubsystem(subsystem)
+		r.setStatus(subsystem.name, status)
+		if status.State == subsystemRunning {
+			r.Lock()
+			r.running = append(r.running, subsystem)
+			r.Unlock()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to start node subsystem %s: %w", subsystem.name, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func (r *subsystemRegistry) isRunning(subsystem *nodeSubsystem) bool {
+	r.RLock()
+	defer r.RUnlock()
+	for _, running := range r.running {
+		if running == subsystem {
+			return true
+		}
+	}
+	return false
+}
+
+// checkHealth runs the health checks of the running subsystems and returns the errors of the unhealthy
+// ones
+func (r *subsystemRegistry) checkHealth() error {
+	r.RLock()
+	running := r.running
+	r.RUnlock()
+
+	var errs []error
+	for _, subsystem := range running {
+		if subsystem.healthy == nil {
+			continue
+		}
+		if err := subsystem.healthy(); err != nil {
+			errs = append(errs, fmt.Errorf("node subsystem %s is not healthy: %w", subsystem.name, err))
+		}
+	}
+	return utilerrors.Join(errs...)
+}
+
+func (r *subsystemRegistry) setStatus(name string, status subsystemStatus) {
+	r.Lock()
+	defer r.Unlock()
+	r.status[name] = status
+	for _, state := range subsystemStates {
+		value := 0.0
+		if state == status.State {
+			value = 1
+		}
+		metrics.MetricNodeSubsystemState.WithLabelValues(name, string(state)).Set(value)
+	}
+}
+
+func (r *subsystemRegistry) getStatus(name string) subsystemStatus {
+	r.RLock()
+	defer r.RUnlock()
+	return r.status[name]
+}
+
+// ServeHTTP serves the status of the subsystems by name
+func (r *subsystemRegistry) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	r.RLock()
+	status := make(map[string]subsystemStatus, len(r.status))
+	for name, subsystemStatus := range r.status {
+		status[name] = subsystemStatus
+	}
+	r.RUnlock()
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	if err := json.NewEncoder(resp).Encode(status); err != nil {
+		klog.Errorf("Failed to serve the status of the node subsystems: %v", err)
+	}
+}
+
+// warnUnknownDisabled warns about the subsystems disabled by the admin that are not registered, to be
+// called once all the subsystems are registered
+func (r *subsystemRegistry) warnUnknownDisabled() {
+	r.RLock()
+	defer r.RUnlock()
+	for _, name := range sets.List(r.disabled) {
+		if !r.registered(name) {
+			klog.Warningf("Unknown node subsystem %s in the disable-subsystems option", name)
+		}
+	}
+}
+
+// subsystemTeardown runs the goroutines of a subsystem with their own stop channel, so that the
+// subsystem is stopped in its turn rather than with the node controller
+type subsystemTeardown struct {
+	stopChan chan struct{}
+	wg       *sync.WaitGroup
+}
+
+func newSubsystemTeardown() *subsystemTeardown {
+	return &subsystemTeardown{
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+}
+
+// stop closes the stop channel of the subsystem and waits for its goroutines to return, the subsystem gets a new
+// stop channel to be started again
+func (t *subsystemTeardown) stop() error {
+	close(t.stopChan)
+	t.wg.Wait()
+	t.stopChan = make(chan struct{})
+	t.wg = &sync.WaitGroup{}
+	return nil
+}
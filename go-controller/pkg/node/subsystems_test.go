@@ -0,0 +1,230 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+func subsystemStateMetric(subsystem, state string) float64 {
+	m := &dto.Metric{}
+	Expect(metrics.MetricNodeSubsystemState.WithLabelValues(subsystem, state).Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
+
+var _ = Describe("Node subsystems registry", func() {
+	var started []string
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		started = nil
+	})
+
+	newSubsystem := func(name string, enabled bool, dependsOn ...string) *nodeSubsystem {
+		return &nodeSubsystem{
+			name:      name,
+			enabled:   func() bool { return enabled },
+			dependsOn: dependsOn,
+			start: func() error {
+				started = append(started, name)
+				return nil
+			},
+		}
+	}
+
+	It("starts the enabled subsystems whose dependencies are running", func() {
+		config.OvnKubeNode.DisableSubsystems = "admin-disabled, unknown"
+		r := newSubsystemRegistry()
+		for _, subsystem := range []*nodeSubsystem{
+			newSubsystem("base", true),
+			newSubsystem("config-disabled", false),
+			newSubsystem("admin-disabled", true),
+			newSubsystem("dependent", true, "base"),
+			newSubsystem("blocked", true, "config-disabled"),
+			newSubsystem("transitively-blocked", true, "blocked"),
+		} {
+			Expect(r.register(subsystem)).To(Succeed())
+		}
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(started).To(Equal([]string{"base", "dependent"}))
+
+		Expect(r.getStatus("base")).To(Equal(subsystemStatus{State: subsystemRunning}))
+		Expect(r.getStatus("config-disabled")).To(Equal(subsystemStatus{State: subsystemDisabled, Reason: "disabled by the configuration"}))
+		Expect(r.getStatus("admin-disabled")).To(Equal(subsystemStatus{State: subsystemDisabled, Reason: "disabled by the admin"}))
+		Expect(r.getStatus("blocked")).To(Equal(subsystemStatus{State: subsystemBlocked, Reason: "dependency config-disabled is disabled"}))
+		Expect(r.getStatus("transitively-blocked")).To(Equal(subsystemStatus{State: subsystemBlocked, Reason: "dependency blocked is blocked"}))
+
+		Expect(subsystemStateMetric("base", "running")).To(Equal(1.0))
+		Expect(subsystemStateMetric("base", "disabled")).To(Equal(0.0))
+		Expect(subsystemStateMetric("blocked", "blocked")).To(Equal(1.0))
+
+		// the subsystems registered later are started on the next call only
+		Expect(r.register(newSubsystem("late", true, "dependent"))).To(Succeed())
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(started).To(Equal([]string{"base", "dependent", "late"}))
+	})
+
+	It("stops at the first subsystem failing to start", func() {
+		r := newSubsystemRegistry()
+		failing := newSubsystem("failing", true)
+		failing.start = func() error { return fmt.Errorf("boom") }
+		Expect(r.register(failing)).To(Succeed())
+		Expect(r.register(newSubsystem("next", true))).To(Succeed())
+		Expect(r.startPending(context.Background())).To(MatchError("failed to start node subsystem failing: boom"))
+		Expect(started).To(BeEmpty())
+		Expect(r.getStatus("failing")).To(Equal(subsystemStatus{State: subsystemFailed, Reason: "boom"}))
+	})
+
+	It("keeps starting the subsystems after a subsystem running degraded", func() {
+		r := newSubsystemRegistry()
+		degraded := newSubsystem("degraded", true)
+		degraded.start = func() error { return &subsystemDegradedError{fmt.Errorf("no dpu")} }
+		Expect(r.register(degraded)).To(Succeed())
+		Expect(r.register(newSubsystem("dependent", true, "degraded"))).To(Succeed())
+		Expect(r.register(newSubsystem("next", true))).To(Succeed())
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(started).To(Equal([]string{"next"}))
+		Expect(r.getStatus("degraded")).To(Equal(subsystemStatus{State: subsystemDegraded, Reason: "no dpu"}))
+		Expect(r.getStatus("dependent")).To(Equal(subsystemStatus{State: subsystemBlocked, Reason: "dependency degraded is degraded"}))
+		Expect(r.checkHealth()).To(Succeed())
+	})
+
+	It("does not let the admin disable the required subsystems", func() {
+		config.OvnKubeNode.DisableSubsystems = "required"
+		r := newSubsystemRegistry()
+		required := newSubsystem("required", true)
+		required.required = true
+		Expect(r.register(required)).To(Succeed())
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(started).To(Equal([]string{"required"}))
+	})
+
+	It("stops the running subsystems in the reverse order of their start", func() {
+		var stopped []string
+		r := newSubsystemRegistry()
+		for _, subsystem := range []*nodeSubsystem{
+			newSubsystem("first", true),
+			newSubsystem("disabled", false),
+			newSubsystem("failing", true),
+			newSubsystem("without-stop", true),
+			newSubsystem("last", true),
+		} {
+			name := subsystem.name
+			if name != "without-stop" {
+				subsystem.stop = func() error {
+					stopped = append(stopped, name)
+					if name == "failing" {
+						return fmt.Errorf("boom")
+					}
+					return nil
+				}
+			}
+			Expect(r.register(subsystem)).To(Succeed())
+		}
+		Expect(r.startPending(context.Background())).To(Succeed())
+
+		Expect(r.stopAll()).To(MatchError("failed to stop node subsystem failing: boom"))
+		Expect(stopped).To(Equal([]string{"last", "failing", "first"}))
+		Expect(r.getStatus("last")).To(Equal(subsystemStatus{State: subsystemStopped}))
+		Expect(r.getStatus("without-stop")).To(Equal(subsystemStatus{State: subsystemStopped}))
+		Expect(r.getStatus("failing")).To(Equal(subsystemStatus{State: subsystemFailed, Reason: "boom"}))
+		Expect(r.getStatus("disabled").State).To(Equal(subsystemDisabled))
+		Expect(subsystemStateMetric("first", "stopped")).To(Equal(1.0))
+
+		// the subsystems are stopped once
+		stopped = nil
+		Expect(r.stopAll()).To(Succeed())
+		Expect(stopped).To(BeEmpty())
+	})
+
+	It("reports the unhealthy running subsystems", func() {
+		r := newSubsystemRegistry()
+		healthy := newSubsystem("healthy", true)
+		healthy.healthy = func() error { return nil }
+		unhealthy := newSubsystem("unhealthy", true)
+		unhealthy.healthy = func() error { return fmt.Errorf("socket removed") }
+		disabled := newSubsystem("disabled", false)
+		disabled.healthy = func() error { return fmt.Errorf("not running") }
+		for _, subsystem := range []*nodeSubsystem{healthy, unhealthy, disabled} {
+			Expect(r.register(subsystem)).To(Succeed())
+		}
+		Expect(r.checkHealth()).To(Succeed())
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(r.checkHealth()).To(MatchError("node subsystem unhealthy is not healthy: socket removed"))
+	})
+
+	It("stops the goroutines of a subsystem with its teardown", func() {
+		teardown := newSubsystemTeardown()
+		done := false
+		teardown.wg.Add(1)
+		go func() {
+			defer teardown.wg.Done()
+			<-teardown.stopChan
+			done = true
+		}()
+		Expect(teardown.stop()).To(Succeed())
+		Expect(done).To(BeTrue())
+	})
+
+	It("stops and starts again the subsystems following their enabled state", func() {
+		var stopped []string
+		enabled := true
+		r := newSubsystemRegistry()
+		for _, name := range []string{"first", "second", "other"} {
+			subsystem := newSubsystem(name, true)
+			if name != "other" {
+				subsystem.enabled = func() bool { return enabled }
+			}
+			subsystem.stop = func() error {
+				stopped = append(stopped, name)
+				return nil
+			}
+			Expect(r.register(subsystem)).To(Succeed())
+		}
+		Expect(r.startPending(context.Background())).To(Succeed())
+		Expect(r.reconcile("first", "second")).To(Succeed())
+		Expect(started).To(Equal([]string{"first", "second", "other"}))
+		Expect(stopped).To(BeEmpty())
+
+		enabled = false
+		Expect(r.reconcile("first", "second")).To(Succeed())
+		Expect(stopped).To(Equal([]string{"second", "first"}))
+		Expect(r.getStatus("first")).To(Equal(subsystemStatus{State: subsystemDisabled, Reason: "disabled by the configuration"}))
+		Expect(r.getStatus("other")).To(Equal(subsystemStatus{State: subsystemRunning}))
+
+		enabled = true
+		Expect(r.reconcile("first", "second")).To(Succeed())
+		Expect(started).To(Equal([]string{"first", "second", "other", "first", "second"}))
+		Expect(r.getStatus("second")).To(Equal(subsystemStatus{State: subsystemRunning}))
+
+		// the subsystems are no longer reconciled once stopped
+		stopped = nil
+		Expect(r.stopAll()).To(Succeed())
+		Expect(stopped).To(Equal([]string{"second", "first", "other"}))
+		enabled = false
+		Expect(r.reconcile("first", "second")).To(Succeed())
+		Expect(stopped).To(HaveLen(3))
+	})
+
+	It("restarts the goroutines of a subsystem stopped with its teardown", func() {
+		teardown := newSubsystemTeardown()
+		Expect(teardown.stop()).To(Succeed())
+		Expect(teardown.stopChan).NotTo(BeClosed())
+		Expect(teardown.stop()).To(Succeed())
+	})
+
+	It("requires the dependencies to be registered first", func() {
+		r := newSubsystemRegistry()
+		Expect(r.register(newSubsystem("dependent", true, "base"))).To(
+			MatchError("node subsystem dependent depends on base that is not registered before it"))
+		Expect(r.register(newSubsystem("base", true))).To(Succeed())
+		Expect(r.register(newSubsystem("base", true))).To(MatchError("node subsystem base is already registered"))
+	})
+})
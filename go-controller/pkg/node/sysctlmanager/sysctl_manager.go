@@ -0,0 +1,185 @@
+package sysctlmanager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilerrors "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/errors"
+)
+
+const defaultSysctlReconcileSyncInterval = time.Minute
+
+// desiredSysctl is the value a managed sysctl must have
+type desiredSysctl struct {
+	// value is the value of the sysctl, unless merge is set
+	value string
+	// merge returns the value of the sysctl from its current value, for the sysctls holding a list
+	// ovnkube-node only adds entries to
+	merge func(current string) string
+}
+
+func (d desiredSysctl) from(current string) string {
+	if d.merge != nil {
+		return d.merge(current)
+	}
+	return d.value
+}
+
+// Controller holds the sysctl values the node requires and restores them when they are
+// changed by something else
+type Controller struct {
+	sync.Mutex
+	// sysctls are the desired values keyed by sysctl name
+	sysctls map[string]desiredSysctl
+	// order are the names of the sysctls in the order they were first declared, they are reconciled in
+	// that order, e.g. the conntrack hash table is resized before the conntrack max is raised
+	order []string
+	// applied holds the sysctls set at least once, a later mismatch of one of them is a drift
+	applied map[string]bool
+}
+
+// NewController creates a sysctl manager without any managed sysctl
+func NewController() *Controller {
+	return &Controller{
+		sysctls: make(map[string]desiredSysctl),
+		applied: make(map[string]bool),
+	}
+}
+
+// defaultController is the sysctl manager of the node, the sysctls ovnkube-node requires are all
+// set through it
+var defaultController = NewController()
+
+// Add declares the values the sysctls of the node must have. They are applied on the next reconciliation.
+func Add(sysctls map[string]string) {
+	defaultController.Add(sysctls)
+}
+
+// Set sets the sysctl of the node to value and keeps it managed
+func Set(name, value string) error {
+	return defaultController.Set(name, value)
+}
+
+// Merge sets the sysctl of the node to the value merge returns from its current value and keeps it managed
+func Merge(name string, merge func(current string) string) error {
+	return defaultController.Merge(name, merge)
+}
+
+// Run reconciles the sysctls of the node every syncPeriod until stopCh is closed
+func Run(stopCh <-chan struct{}, syncPeriod time.Duration) {
+	defaultController.Run(stopCh, syncPeriod)
+}
+
+// Add declares the values the sysctls must have. They are applied on the next reconciliation, in the
+// order of their names.
+func (c *Controller) Add(sysctls map[string]string) {
+	c.Lock()
+	defer c.Unlock()
+	names := make([]string, 0, len(sysctls))
+	for name := range sysctls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.declare(name, desiredSysctl{value: sysctls[name]})
+	}
+}
+
+// Set sets the sysctl to value now, if it differs, and restores the value on the next reconciliations
+// if it drifts
+func (c *Controller) Set(name, value string) error {
+	c.Lock()
+	defer c.Unlock()
+	c.declare(name, desiredSysctl{value: value})
+	return c.reconcileSysctl(name)
+}
+
+// Merge sets the sysctl to the value merge returns from its current value now, if it differs, and
+// merges it again on the next reconciliations
+func (c *Controller) Merge(name string, merge func(current string) string) error {
+	c.Lock()
+	defer c.Unlock()
+	c.declare(name, desiredSysctl{merge: merge})
+	return c.reconcileSysctl(name)
+}
+
+func (c *Controller) declare(name string, desired desiredSysctl) {
+	current, ok := c.sysctls[name]
+	if !ok {
+		c.order = append(c.order, name)
+	}
+	if !ok || current.merge != nil || desired.merge != nil || current.value != desired.value {
+		delete(c.applied, name)
+	}
+	c.sysctls[name] = desired
+}
+
+// Run applies the declared sysctls and reconciles them every syncPeriod until stopCh is closed
+func (c *Controller) Run(stopCh <-chan struct{}, syncPeriod time.Duration) {
+	if syncPeriod == 0 {
+		syncPeriod = defaultSysctlReconcileSyncInterval
+	}
+	if err := c.reconcile(); err != nil {
+		klog.Errorf("Sysctl manager: failed to reconcile (retry in %s): %v", syncPeriod.String(), err)
+	}
+	ticker := time.NewTicker(syncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := c.reconcile(); err != nil {
+				klog.Errorf("Sysctl manager: failed to reconcile (retry in %s): %v", syncPeriod.String(), err)
+			}
+		}
+	}
+}
+
+// reconcile sets every managed sysctl whose current value differs from the desired one, in the order they
+// were declared
+func (c *Controller) reconcile() error {
+	c.Lock()
+	defer c.Unlock()
+	var errs []error
+	for _, name := range c.order {
+		if err := c.reconcileSysctl(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	metrics.MetricNodeSysctlsOutOfSync.Set(float64(len(errs)))
+	return utilerrors.Join(errs...)
+}
+
+// reconcileSysctl sets the sysctl if its current value differs from the desired one, c must be locked
+func (c *Controller) reconcileSysctl(name string) error {
+	current, stderr, err := util.RunSysctl("-n", name)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v, stderr: %s", name, err, stderr)
+	}
+	desired := c.sysctls[name].from(current)
+	if current == desired {
+		c.applied[name] = true
+		return nil
+	}
+	if c.applied[name] {
+		klog.Warningf("Sysctl manager: %s drifted to %s, restoring %s", name, current, desired)
+		metrics.MetricNodeSysctlDrifts.WithLabelValues(name).Inc()
+	} else {
+		klog.Infof("Sysctl manager: setting %s to %s, current value is %s", name, desired, current)
+	}
+	stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("%s=%s", name, desired))
+	if err != nil || stdout != fmt.Sprintf("%s = %s", name, desired) {
+		return fmt.Errorf("could not set %s to %s: stdout: %s, stderr: %s, err: %v",
+			name, desired, stdout, stderr, err)
+	}
+	c.applied[name] = true
+	return nil
+}
@@ -0,0 +1,143 @@
+package sysctlmanager
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Sysctl Manager", func() {
+	var (
+		fexec *ovntest.FakeExec
+		c     *Controller
+	)
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+		c = NewController()
+		c.Add(map[string]string{
+			"net.ipv4.ip_forward":            "1",
+			"net.netfilter.nf_conntrack_max": "262144",
+		})
+	})
+
+	It("only rewrites the sysctls that differ from the desired values", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.ip_forward",
+			Output: "1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.netfilter.nf_conntrack_max",
+			Output: "131072",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.netfilter.nf_conntrack_max=262144",
+			Output: "net.netfilter.nf_conntrack_max = 262144",
+		})
+		Expect(c.reconcile()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(c.applied).To(HaveLen(2))
+	})
+
+	It("restores a sysctl that drifted after it was applied", func() {
+		c.applied["net.ipv4.ip_forward"] = true
+		c.applied["net.netfilter.nf_conntrack_max"] = true
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.ip_forward",
+			Output: "0",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.ipv4.ip_forward=1",
+			Output: "net.ipv4.ip_forward = 1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.netfilter.nf_conntrack_max",
+			Output: "262144",
+		})
+		Expect(c.reconcile()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("keeps reconciling the other sysctls when one of them fails", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "sysctl -n net.ipv4.ip_forward",
+			Err: fmt.Errorf("permission denied"),
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.netfilter.nf_conntrack_max",
+			Output: "262144",
+		})
+		Expect(c.reconcile()).To(MatchError(ContainSubstring("net.ipv4.ip_forward")))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(c.applied).To(HaveKey("net.netfilter.nf_conntrack_max"))
+		Expect(c.applied).NotTo(HaveKey("net.ipv4.ip_forward"))
+	})
+
+	It("applies a sysctl again when its desired value changes", func() {
+		c.applied["net.ipv4.ip_forward"] = true
+		c.applied["net.netfilter.nf_conntrack_max"] = true
+		c.Add(map[string]string{"net.netfilter.nf_conntrack_max": "524288"})
+		Expect(c.applied).To(Equal(map[string]bool{"net.ipv4.ip_forward": true}))
+	})
+
+	It("sets a sysctl now and restores it when it drifts", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.conf.breth0.forwarding",
+			Output: "0",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.ipv4.conf.breth0.forwarding=1",
+			Output: "net.ipv4.conf.breth0.forwarding = 1",
+		})
+		Expect(c.Set("net.ipv4.conf.breth0.forwarding", "1")).To(Succeed())
+		Expect(c.applied).To(HaveKey("net.ipv4.conf.breth0.forwarding"))
+		Expect(c.sysctls).To(HaveKey("net.ipv4.conf.breth0.forwarding"))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("merges a sysctl with its current value", func() {
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.ip_local_reserved_ports",
+			Output: "8080",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -w net.ipv4.ip_local_reserved_ports=8080,30000-32767",
+			Output: "net.ipv4.ip_local_reserved_ports = 8080,30000-32767",
+		})
+		Expect(c.Merge("net.ipv4.ip_local_reserved_ports", func(current string) string {
+			if current == "8080" {
+				return "8080,30000-32767"
+			}
+			return current
+		})).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(c.applied).To(HaveKey("net.ipv4.ip_local_reserved_ports"))
+	})
+
+	It("reconciles the sysctls in the order they were declared", func() {
+		c = NewController()
+		c.Add(map[string]string{"net.netfilter.nf_conntrack_buckets": "65536"})
+		c.Add(map[string]string{"net.netfilter.nf_conntrack_max": "262144"})
+		c.Add(map[string]string{"net.ipv4.ip_forward": "1"})
+		c.Add(map[string]string{"net.netfilter.nf_conntrack_buckets": "131072"})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.netfilter.nf_conntrack_buckets",
+			Output: "131072",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.netfilter.nf_conntrack_max",
+			Output: "262144",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "sysctl -n net.ipv4.ip_forward",
+			Output: "1",
+		})
+		Expect(c.reconcile()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
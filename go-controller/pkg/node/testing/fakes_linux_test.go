@@ -0,0 +1,85 @@
+package testing_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	nodetest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/testing"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestInstall(t *testing.T) {
+	fakeExec := ovntest.NewFakeExec()
+	netLinkOps := nodetest.NewNetLinkOps(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "breth0", Index: 5}})
+	restore, err := nodetest.Install(fakeExec, netLinkOps)
+	if err != nil {
+		t.Fatalf("failed to install the fakes: %v", err)
+	}
+	defer restore()
+
+	fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-vsctl --timeout=15 get Open_vSwitch . external_ids:ovn-encap-ip",
+		Output: "10.0.0.1",
+	})
+	fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd:    "ovs-ofctl dump-flows breth0",
+		Stderr: "no bridge",
+		Err:    fmt.Errorf("exit status 1"),
+	})
+	if out, _, err := util.RunOVSVsctl("get", "Open_vSwitch", ".", "external_ids:ovn-encap-ip"); err != nil || out != "10.0.0.1" {
+		t.Fatalf("expected the faked output, got %q, %v", out, err)
+	}
+	if _, stderr, err := util.RunOVSOfctl("dump-flows", "breth0"); err == nil || stderr != "no bridge" {
+		t.Fatalf("expected the faked error, got %q, %v", stderr, err)
+	}
+	if !fakeExec.CalledMatchesExpected() {
+		t.Fatalf("unexpected commands: %s", fakeExec.ErrorDesc())
+	}
+
+	netLinkOps.On("LinkSetUp", mock.Anything).Return(nil)
+	link, err := util.LinkSetUp("breth0")
+	if err != nil || link.Attrs().Index != 5 {
+		t.Fatalf("failed to set the link up: %v, %v", link, err)
+	}
+	if _, err := util.LinkSetUp("eth0"); err == nil {
+		t.Fatalf("expected an error setting up a missing link")
+	}
+	if _, err := util.GetNetLinkOps().LinkByIndex(6); !util.GetNetLinkOps().IsLinkNotFoundError(err) {
+		t.Fatalf("expected a link not found error, got %v", err)
+	}
+	netLinkOps.AssertNumberOfCalls(t, "LinkSetUp", 1)
+}
+
+func TestFakeKube(t *testing.T) {
+	const nodeName = "node1"
+	kube, err := nodetest.NewFakeKube(nodeName, &kapi.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+	if err != nil {
+		t.Fatalf("failed to create the fake kube: %v", err)
+	}
+	if err := kube.WatchFactory.Start(); err != nil {
+		t.Fatalf("failed to start the watch factory: %v", err)
+	}
+	defer kube.WatchFactory.Shutdown()
+
+	if _, err := kube.WatchFactory.GetNode(nodeName); err != nil {
+		t.Fatalf("failed to get the node from the watch factory: %v", err)
+	}
+	if _, err := kube.KubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{}); err != nil {
+		t.Fatalf("failed to get the node from the client: %v", err)
+	}
+
+	cnnci := node.NewCommonNodeNetworkControllerInfo(kube.Clientset.KubeClient, kube.Clientset.AdminPolicyRouteClient,
+		kube.Clientset.DPUNodePairingClient, kube.WatchFactory, kube.Recorder, nodeName, nil)
+	if cnnci == nil {
+		t.Fatalf("failed to create the node controller info")
+	}
+}
@@ -0,0 +1,56 @@
+// Package testing provides fakes of the host layers the node network controllers rely on: the OVS and system
+// commands, netlink and the kube API. It lets code embedding or integrating with DefaultNodeNetworkController
+// be unit tested without a real host, including outside of this repository. The commands are faked with the
+// FakeExec of pkg/testing and netlink with the NetLinkOps mock of pkg/util/mocks.
+package testing
+
+import (
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	adminpolicybasedroutefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
+	dpunodepairingfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned/fake"
+	egressipfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	egressservicefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/fake"
+	physicalnetworkfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/clientset/versioned/fake"
+	serviceannouncementfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// FakeKube holds the fake clients of the kube API used by the node network controllers and a node watch factory
+// on top of them. Its fields are the arguments of node.NewCommonNodeNetworkControllerInfo.
+type FakeKube struct {
+	Clientset    *util.OVNNodeClientset
+	KubeClient   *fake.Clientset
+	WatchFactory *factory.WatchFactory
+	Recorder     *record.FakeRecorder
+}
+
+// NewFakeKube returns the fake clients of the kube API holding the provided kubernetes objects, such as the node,
+// and a watch factory for the node. The watch factory must be started, and shut down once done with.
+func NewFakeKube(nodeName string, objects ...runtime.Object) (*FakeKube, error) {
+	kubeClient := fake.NewSimpleClientset(objects...)
+	clientset := &util.OVNNodeClientset{
+		KubeClient:                kubeClient,
+		EgressServiceClient:       egressservicefake.NewSimpleClientset(),
+		EgressIPClient:            egressipfake.NewSimpleClientset(),
+		AdminPolicyRouteClient:    adminpolicybasedroutefake.NewSimpleClientset(),
+		NetworkAttchDefClient:     nadfake.NewSimpleClientset(),
+		ServiceAnnouncementClient: serviceannouncementfake.NewSimpleClientset(),
+		DPUNodePairingClient:      dpunodepairingfake.NewSimpleClientset(),
+		PhysicalNetworkClient:     physicalnetworkfake.NewSimpleClientset(),
+	}
+	wf, err := factory.NewNodeWatchFactory(clientset, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	return &FakeKube{
+		Clientset:    clientset,
+		KubeClient:   kubeClient,
+		WatchFactory: wf,
+		Recorder:     record.NewFakeRecorder(100),
+	}, nil
+}
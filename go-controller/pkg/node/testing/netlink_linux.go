@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilmocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+// linkNotFoundError is returned when a link is not found, netlink.LinkNotFoundError can't be built outside of netlink
+type linkNotFoundError struct {
+	link string
+}
+
+func (e linkNotFoundError) Error() string {
+	return fmt.Sprintf("link %s not found", e.link)
+}
+
+// Install makes the OVS and system commands run by the util package use exec, and netlink use netLinkOps.
+// A nil netLinkOps keeps the real netlink. The returned function restores the real implementations.
+func Install(exec *ovntest.FakeExec, netLinkOps *utilmocks.NetLinkOps) (func(), error) {
+	if err := util.SetExec(exec); err != nil {
+		return nil, err
+	}
+	if netLinkOps != nil {
+		util.SetNetLinkOpMockInst(netLinkOps)
+	}
+	return func() {
+		util.ResetRunner()
+		util.ResetNetLinkOpMockInst()
+	}, nil
+}
+
+// NewNetLinkOps returns a NetLinkOps mock answering LinkByName and LinkByIndex with the provided links, and with
+// an error IsLinkNotFoundError recognizes for the other links. The other calls are expected on the mock as usual.
+func NewNetLinkOps(links ...netlink.Link) *utilmocks.NetLinkOps {
+	netLinkOps := &utilmocks.NetLinkOps{}
+	for _, link := range links {
+		netLinkOps.On("LinkByName", link.Attrs().Name).Return(link, nil).Maybe()
+		if link.Attrs().Index != 0 {
+			netLinkOps.On("LinkByIndex", link.Attrs().Index).Return(link, nil).Maybe()
+		}
+	}
+	netLinkOps.On("LinkByName", mock.Anything).Return(nil, func(name string) error {
+		return linkNotFoundError{link: name}
+	}).Maybe()
+	netLinkOps.On("LinkByIndex", mock.Anything).Return(nil, func(index int) error {
+		return linkNotFoundError{link: fmt.Sprintf("%d", index)}
+	}).Maybe()
+	netLinkOps.On("IsLinkNotFoundError", mock.Anything).Return(func(err error) bool {
+		_, ok := err.(linkNotFoundError)
+		return ok
+	}).Maybe()
+	return netLinkOps
+}
@@ -0,0 +1,264 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// transitSwitchHealthCheckInterval is how often the transit switch tunnels to the remote zones are checked
+const transitSwitchHealthCheckInterval = 30 * time.Second
+
+// transitSwitchHealthChecker periodically verifies, in multi-zone interconnect mode, that the transit
+// switch tunnel to every node of the remote zones is established: the transit switch port of the
+// remote node is bound to its chassis in the Southbound database and the tunnel to its encap IP is
+// up in OVS. The reachability of each remote zone is published in the node zone reachability
+// annotation and in metrics, the unreachable nodes of a zone are only logged.
+type transitSwitchHealthChecker struct {
+	nodeName      string
+	zone          string
+	watchFactory  factory.NodeWatchFactory
+	nodeAnnotator kube.Annotator
+	// zones are the remote zones reported in metrics, so that the metrics of removed zones are deleted
+	zones sets.Set[string]
+}
+
+// zoneHealth is the result of the check of the nodes of a remote zone
+type zoneHealth struct {
+	// unreachableNodes are the nodes of the zone that can't be reached, with the reason
+	unreachableNodes map[string]string
+}
+
+func (h zoneHealth) reachable() bool {
+	return len(h.unreachableNodes) == 0
+}
+
+func newTransitSwitchHealthChecker(nodeName, zone string, k kube.Interface, watchFactory factory.NodeWatchFactory) *transitSwitchHealthChecker {
+	return &transitSwitchHealthChecker{
+		nodeName:      nodeName,
+		zone:          zone,
+		watchFactory:  watchFactory,
+		nodeAnnotator: newNodeAnnotator(k, nodeName),
+		zones:         sets.New[string](),
+	}
+}
+
+func (c *transitSwitchHealthChecker) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	runPeriodicSync(stopChan, doneWg, transitSwitchHealthCheckInterval, nil, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to report the transit switch health of node %s: %v", c.nodeName, err)
+		}
+	})
+}
+
+// sync checks the remote zones and reports their reachability
+func (c *transitSwitchHealthChecker) sync() error {
+	nodes, err := c.watchFactory.GetNodes()
+	if err != nil {
+		return err
+	}
+	bindings, err := listTransitSwitchBindings()
+	if err != nil {
+		return err
+	}
+	tunnels, err := listTunnels()
+	if err != nil {
+		return err
+	}
+	health := c.health(nodes, bindings, tunnels)
+	c.updateMetrics(health)
+
+	reachability := make(map[string]util.ZoneReachability, len(health))
+	for zone, zoneHealth := range health {
+		reachability[zone] = util.ZoneReachability{Reachable: zoneHealth.reachable()}
+	}
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	current, err := util.ParseNodeZoneReachability(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid zone reachability of node %s: %v", c.nodeName, err)
+	}
+	for zone, zoneHealth := range health {
+		if !zoneHealth.reachable() && current[zone].Reachable {
+			klog.Warningf("Zone %s is not reachable anymore from node %s through the transit switch: %v",
+				zone, c.nodeName, zoneHealth.unreachableNodes)
+		}
+	}
+	if len(current) == 0 && len(reachability) == 0 || reflect.DeepEqual(current, reachability) {
+		return nil
+	}
+	if err := util.SetNodeZoneReachability(c.nodeAnnotator, reachability); err != nil {
+		return err
+	}
+	return c.nodeAnnotator.Run()
+}
+
+// health returns the health of the zones of the given remote nodes from the chassis their transit
+// switch ports are bound to and from the tunnels keyed by remote IP
+func (c *transitSwitchHealthChecker) health(nodes []*kapi.Node, bindings map[string]string,
+	tunnels map[string][]tunnel) map[string]zoneHealth {
+	health := map[string]zoneHealth{}
+	for _, node := range nodes {
+		zone := util.GetNodeZone(node)
+		if node.Name == c.nodeName || zone == c.zone || util.NoHostSubnet(node) {
+			continue
+		}
+		zoneHealth := health[zone]
+		if err := checkTransitSwitchTunnel(node, bindings, tunnels); err != nil {
+			healthLog.V(5).Infof("Node %s of zone %s is not reachable through the transit switch: %v", node.Name, zone, err)
+			if zoneHealth.unreachableNodes == nil {
+				zoneHealth.unreachableNodes = map[string]string{}
+			}
+			zoneHealth.unreachableNodes[node.Name] = err.Error()
+		}
+		health[zone] = zoneHealth
+	}
+	return health
+}
+
+func (c *transitSwitchHealthChecker) updateMetrics(health map[string]zoneHealth) {
+	for zone := range c.zones {
+		if _, ok := health[zone]; !ok {
+			metrics.MetricNodeInterconnectZoneReachable.DeleteLabelValues(zone)
+			metrics.MetricNodeInterconnectUnreachableNodes.DeleteLabelValues(zone)
+			c.zones.Delete(zone)
+		}
+	}
+	for zone, zoneHealth := range health {
+		reachable := 0.0
+		if zoneHealth.reachable() {
+			reachable = 1
+		}
+		metrics.MetricNodeInterconnectZoneReachable.WithLabelValues(zone).Set(reachable)
+		metrics.MetricNodeInterconnectUnreachableNodes.WithLabelValues(zone).Set(float64(len(zoneHealth.unreachableNodes)))
+		c.zones.Insert(zone)
+	}
+}
+
+// checkTransitSwitchTunnel returns an error when the transit switch port of the remote node is not
+// bound to a chassis or when the tunnel to the remote node encap IP is not up
+func checkTransitSwitchTunnel(node *kapi.Node, bindings map[string]string, tunnels map[string][]tunnel) error {
+	portName := types.TransitSwitchToRouterPrefix + node.Name
+	if bindings[portName] == "" {
+		return fmt.Errorf("transit switch port %s is not bound to a chassis", portName)
+	}
+
+	encapIPs, err := tunnelEncapIPs(node)
+	if err != nil {
+		return err
+	}
+	for _, encapIP := range encapIPs {
+		remoteTunnels := tunnels[encapIP.String()]
+		if len(remoteTunnels) == 0 {
+			return fmt.Errorf("no tunnel to encap IP %s", encapIP)
+		}
+		for _, t := range remoteTunnels {
+			if t.ofport == "-1" || t.linkState != "up" {
+				return fmt.Errorf("tunnel %s to encap IP %s is not up: ofport %s, link state %q",
+					t.name, encapIP, t.ofport, t.linkState)
+			}
+		}
+	}
+	return nil
+}
+
+// listTransitSwitchBindings returns the chassis the remote transit switch ports are bound to, by
+// logical port
+func listTransitSwitchBindings() (map[string]string, error) {
+	stdout, stderr, err := util.RunOVNSbctl("--no-heading", "--data=bare", "--format=csv",
+		"--columns=logical_port,chassis", "find", "Port_Binding", "type=remote")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the remote port bindings, stderr: %q, error: %v", stderr, err)
+	}
+	bindings := map[string]string{}
+	if stdout == "" {
+		return bindings, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected remote port binding %q", line)
+		}
+		bindings[fields[0]] = fields[1]
+	}
+	return bindings, nil
+}
+
+// tunnel is an OVS tunnel interface to a remote encap IP
+type tunnel struct {
+	name      string
+	ofport    string
+	linkState string
+}
+
+// listTunnels returns the OVS tunnel interfaces of the encapsulation type by remote IP
+func listTunnels() (map[string][]tunnel, error) {
+	stdout, stderr, err := util.RunOVSVsctl("--no-heading", "--data=bare", "--format=csv",
+		"--columns=name,ofport,link_state,options", "find", "Interface", "type="+config.Default.EncapType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tunnel interfaces, stderr: %q, error: %v", stderr, err)
+	}
+	tunnels := map[string][]tunnel{}
+	if stdout == "" {
+		return tunnels, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected tunnel interface %q", line)
+		}
+		remoteIP := util.GetExternalIDValByKey(fields[3], "remote_ip")
+		if remoteIP == "" {
+			continue
+		}
+		tunnels[remoteIP] = append(tunnels[remoteIP], tunnel{name: fields[0], ofport: fields[1], linkState: fields[2]})
+	}
+	return tunnels, nil
+}
+
+// tunnelEncapIPs returns the encap IPs of the remote node the local node has tunnels to: with a tunnel
+// endpoint per IP family, the remote endpoints of the families of the local endpoints
+func tunnelEncapIPs(node *kapi.Node) ([]net.IP, error) {
+	encapIPs, err := util.ParseNodeEncapIPs(node)
+	if err != nil {
+		return nil, err
+	}
+	if config.Default.EncapIP == "" {
+		return encapIPs, nil
+	}
+	localEncapIPs, err := util.ParseEncapIPs(config.Default.EncapIP)
+	if err != nil {
+		return nil, err
+	}
+	localFamilies := sets.New[utilnet.IPFamily]()
+	for _, localEncapIP := range localEncapIPs {
+		localFamilies.Insert(utilnet.IPFamilyOf(localEncapIP))
+	}
+	var tunnelIPs []net.IP
+	for _, encapIP := range encapIPs {
+		if localFamilies.Has(utilnet.IPFamilyOf(encapIP)) {
+			tunnelIPs = append(tunnelIPs, encapIP)
+		}
+	}
+	if len(tunnelIPs) == 0 {
+		return nil, fmt.Errorf("no encap IP of node %s matches the IP families of the local encap IP %s", node.Name, config.Default.EncapIP)
+	}
+	return tunnelIPs, nil
+}
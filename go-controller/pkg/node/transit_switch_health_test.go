@@ -0,0 +1,114 @@
+package node
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func newZoneNode(name, zone, encapIP string) *kapi.Node {
+	return &kapi.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				util.OvnNodeZoneName: zone,
+				util.OvnNodeEncapIp:  encapIP,
+			},
+		},
+	}
+}
+
+var _ = Describe("Transit switch health checker", func() {
+	var execMock *ovntest.FakeExec
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		execMock = ovntest.NewFakeExec()
+		Expect(util.SetExec(execMock)).To(Succeed())
+	})
+
+	It("reports the reachability of every remote zone", func() {
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovn-sbctl --timeout=15 --no-leader-only --no-heading --data=bare --format=csv " +
+				"--columns=logical_port,chassis find Port_Binding type=remote",
+			// the transit switch port of node3 is not bound yet
+			Output: "tstor-node2,7bc5b4f1-1b9d-4f0b-a1b7-4d7b5ba1c2a1\n" +
+				"tstor-node3,\n" +
+				"tstor-node4,0e7bd40d-8d62-4a4e-9a36-1b1f1a7b3c52",
+		})
+		execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 --no-heading --data=bare --format=csv " +
+				"--columns=name,ofport,link_state,options find Interface type=geneve",
+			Output: "ovn-7bc5b4-0,5,up,csum=true key=flow remote_ip=10.0.0.2\n" +
+				"ovn-0e7bd4-0,-1,down,csum=true key=flow remote_ip=fd00::4",
+		})
+		bindings, err := listTransitSwitchBindings()
+		Expect(err).NotTo(HaveOccurred())
+		tunnels, err := listTunnels()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+
+		c := &transitSwitchHealthChecker{nodeName: "node1", zone: "zone-a", zones: sets.New[string]()}
+		health := c.health([]*kapi.Node{
+			newZoneNode("node1", "zone-a", "10.0.0.1"),
+			// local zone node
+			newZoneNode("node5", "zone-a", "10.0.0.5"),
+			newZoneNode("node2", "zone-b", "10.0.0.2"),
+			newZoneNode("node3", "zone-c", "10.0.0.3"),
+			newZoneNode("node4", "zone-c", "fd00::4"),
+			// no tunnel to node6 yet
+			newZoneNode("node6", "zone-d", "10.0.0.6"),
+		}, bindings, tunnels)
+		Expect(health).To(Equal(map[string]zoneHealth{
+			"zone-b": {},
+			"zone-c": {
+				unreachableNodes: map[string]string{
+					"node3": "transit switch port tstor-node3 is not bound to a chassis",
+					"node4": `tunnel ovn-0e7bd4-0 to encap IP fd00::4 is not up: ofport -1, link state "down"`,
+				},
+			},
+			"zone-d": {
+				unreachableNodes: map[string]string{
+					"node6": "transit switch port tstor-node6 is not bound to a chassis",
+				},
+			},
+		}))
+
+		c.updateMetrics(health)
+		Expect(c.zones).To(Equal(sets.New("zone-b", "zone-c", "zone-d")))
+		c.updateMetrics(map[string]zoneHealth{"zone-b": {}})
+		Expect(c.zones).To(Equal(sets.New("zone-b")))
+	})
+
+	It("reports a node without a tunnel to its encap IP as unreachable", func() {
+		bindings := map[string]string{"tstor-node2": "7bc5b4f1-1b9d-4f0b-a1b7-4d7b5ba1c2a1"}
+		err := checkTransitSwitchTunnel(newZoneNode("node2", "zone-b", "10.0.0.2"), bindings, map[string][]tunnel{})
+		Expect(err).To(MatchError("no tunnel to encap IP 10.0.0.2"))
+	})
+
+	It("checks the tunnels to the remote encap IPs of the local encap IP families", func() {
+		remote := newZoneNode("node2", "zone-b", "10.0.0.2,fd00::2")
+		Expect(tunnelEncapIPs(remote)).To(Equal([]net.IP{ovntest.MustParseIP("10.0.0.2"), ovntest.MustParseIP("fd00::2")}))
+
+		config.Default.EncapIP = "fd00::1"
+		Expect(tunnelEncapIPs(remote)).To(Equal([]net.IP{ovntest.MustParseIP("fd00::2")}))
+
+		config.Default.EncapIP = "10.0.0.1,fd00::1"
+		Expect(tunnelEncapIPs(remote)).To(HaveLen(2))
+
+		_, err := tunnelEncapIPs(newZoneNode("node3", "zone-b", "10.0.0.3"))
+		Expect(err).NotTo(HaveOccurred())
+		config.Default.EncapIP = "fd00::1"
+		_, err = tunnelEncapIPs(newZoneNode("node3", "zone-b", "10.0.0.3"))
+		Expect(err).To(MatchError("no encap IP of node node3 matches the IP families of the local encap IP fd00::1"))
+	})
+})
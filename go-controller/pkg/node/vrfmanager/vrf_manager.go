@@ -48,6 +48,7 @@ func (vrfm *Controller) Run(stopCh <-chan struct{}, doneWg *sync.WaitGroup) erro
 			klog.Errorf("Failed during LinkSubscribe callback: %v", err)
 			// Note: Not calling sync() from here: it is redundant and unsafe when stopChan is closed.
 		},
+		Namespace: util.NodeNetNSHandle(),
 	}
 
 	subscribe := func() (bool, chan netlink.LinkUpdate, error) {
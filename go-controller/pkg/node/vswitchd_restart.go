@@ -0,0 +1,56 @@
+package node
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// vswitchdRestartCheckInterval is how often the gateway OpenFlow manager checks whether ovs-vswitchd restarted
+const vswitchdRestartCheckInterval = time.Second
+
+// vswitchdRestartDetector detects the restarts of ovs-vswitchd from the changes of its PID. A restarted
+// ovs-vswitchd starts with no flow on the gateway bridges, they are only reprogrammed by ovnkube-node.
+type vswitchdRestartDetector struct {
+	// getPID returns the PID of the running ovs-vswitchd
+	getPID func() (string, error)
+	// pid is the PID of ovs-vswitchd when it was last seen running
+	pid string
+}
+
+func newVswitchdRestartDetector() *vswitchdRestartDetector {
+	return &vswitchdRestartDetector{getPID: util.GetOvsVSwitchdPID}
+}
+
+// restarted returns whether ovs-vswitchd runs with another PID than when it was last seen running. While
+// ovs-vswitchd is down it is not reported restarted, it is once it runs again.
+func (d *vswitchdRestartDetector) restarted() bool {
+	pid, err := d.getPID()
+	if err != nil || pid == "" {
+		gatewayLog.V(5).Infof("ovs-vswitchd is not running: %v", err)
+		return false
+	}
+	previous := d.pid
+	d.pid = pid
+	return previous != "" && previous != pid
+}
+
+// reprogramAfterVswitchdRestart syncs the flows of the gateway bridges when ovs-vswitchd restarted or a sync
+// after a restart is pending. It returns whether the sync is still pending, e.g. ovs-vswitchd is not
+// accepting OpenFlow connections yet.
+func (c *openflowManager) reprogramAfterVswitchdRestart(pending bool) bool {
+	if c.vswitchd.restarted() {
+		klog.Warningf("ovs-vswitchd restarted, reprogramming the flows of the gateway bridges")
+		metrics.MetricNodeOVSVswitchdRestarts.Inc()
+		// the meters are gone with ovs-vswitchd
+		c.forgetInstalledMeters()
+		pending = true
+	}
+	if !pending {
+		return false
+	}
+	return !c.syncFlows()
+}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("ovs-vswitchd restarts", func() {
+	const replaceFlows = "ovs-ofctl -O OpenFlow13 --bundle replace-flows breth0 -"
+
+	var (
+		fakeExec *ovntest.FakeExec
+		pid      string
+		pidErr   error
+		ofm      *openflowManager
+	)
+
+	BeforeEach(func() {
+		fakeExec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fakeExec)).To(Succeed())
+		pid, pidErr = "100", nil
+		ofm = &openflowManager{
+			defaultBridge: &bridgeConfiguration{bridgeName: "breth0"},
+			flowCache:     map[string][]string{"NORMAL": {"table=0,priority=0,actions=NORMAL"}},
+			flowChan:      make(chan struct{}, 1),
+			vswitchd: &vswitchdRestartDetector{getPID: func() (string, error) {
+				return pid, pidErr
+			}},
+		}
+		Expect(ofm.vswitchd.restarted()).To(BeFalse())
+	})
+
+	AfterEach(func() {
+		Expect(fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeExec.ErrorDesc())
+	})
+
+	It("reports a restart once ovs-vswitchd runs with another PID", func() {
+		Expect(ofm.vswitchd.restarted()).To(BeFalse())
+
+		pid, pidErr = "", fmt.Errorf("no such file")
+		Expect(ofm.vswitchd.restarted()).To(BeFalse())
+
+		pid, pidErr = "200", nil
+		Expect(ofm.vswitchd.restarted()).To(BeTrue())
+		Expect(ofm.vswitchd.restarted()).To(BeFalse())
+	})
+
+	It("reprograms the gateway bridge flows after a restart", func() {
+		Expect(ofm.reprogramAfterVswitchdRestart(false)).To(BeFalse())
+
+		fakeExec.AddFakeCmdsNoOutputNoError([]string{replaceFlows})
+		pid = "200"
+		Expect(ofm.reprogramAfterVswitchdRestart(false)).To(BeFalse())
+	})
+
+	It("retries the reprogramming until ovs-vswitchd accepts the flows", func() {
+		fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    replaceFlows,
+			Stderr: "ovs-ofctl: breth0 is not a bridge or a socket",
+			Err:    fmt.Errorf("exit status 1"),
+		})
+		fakeExec.AddFakeCmdsNoOutputNoError([]string{replaceFlows})
+		pid = "200"
+		Expect(ofm.reprogramAfterVswitchdRestart(false)).To(BeTrue())
+		Expect(ofm.reprogramAfterVswitchdRestart(true)).To(BeFalse())
+	})
+})
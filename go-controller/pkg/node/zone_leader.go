@@ -0,0 +1,150 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+const (
+	// zoneLeaderLeaseDuration is how long the other nodes of the zone wait before taking over the zone tasks of
+	// a leader that stopped renewing the lease
+	zoneLeaderLeaseDuration = 60 * time.Second
+	// zoneLeaderRenewDeadline is how long the leader retries renewing the lease before it stops the zone tasks
+	zoneLeaderRenewDeadline = 30 * time.Second
+	// zoneLeaderRetryPeriod is how often the nodes of the zone try to acquire or renew the lease
+	zoneLeaderRetryPeriod = 10 * time.Second
+)
+
+// zoneTask is a task acting on the objects of the whole zone, run until stopChan is closed
+type zoneTask struct {
+	name string
+	run  func(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
+}
+
+// zoneLeaderElector runs the zone tasks on a single node of the zone with the zone-leader-election option: the
+// nodes of the zone elect the node running them with the ovnkube-node-zone-<zone> lease of the ovn config
+// namespace, another node taking them over when the leader stops renewing the lease. Every node of the zone runs
+// them otherwise.
+type zoneLeaderElector struct {
+	nodeName string
+	zone     string
+	client   kubernetes.Interface
+	recorder record.EventRecorder
+	tasks    []zoneTask
+}
+
+func newZoneLeaderElector(nodeName, zone string, client kubernetes.Interface, recorder record.EventRecorder) *zoneLeaderElector {
+	return &zoneLeaderElector{
+		nodeName: nodeName,
+		zone:     zone,
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+// addTask adds a zone task, to be called before Run
+func (e *zoneLeaderElector) addTask(name string, run func(stopChan <-chan struct{}, doneWg *sync.WaitGroup)) {
+	e.tasks = append(e.tasks, zoneTask{name: name, run: run})
+}
+
+// zoneLeaderLeaseName returns the name of the lease the nodes of the zone elect the node running the zone tasks with
+func zoneLeaderLeaseName(zone string) string {
+	return "ovnkube-node-zone-" + zone
+}
+
+func (e *zoneLeaderElector) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup) error {
+	if len(e.tasks) == 0 {
+		return nil
+	}
+	if !config.OvnKubeNode.ZoneLeaderElection {
+		e.runTasks(stopChan, doneWg)
+		return nil
+	}
+	electionConfig, err := e.electionConfig(doneWg)
+	if err != nil {
+		return err
+	}
+	// validate the configuration before the start
+	if _, err := leaderelection.NewLeaderElector(electionConfig); err != nil {
+		return fmt.Errorf("invalid leader election of zone %s: %w", e.zone, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		<-stopChan
+		cancel()
+	}()
+	doneWg.Add(1)
+	go func() {
+		defer doneWg.Done()
+		// a node losing the lease runs the election again until it stops
+		for ctx.Err() == nil {
+			elector, _ := leaderelection.NewLeaderElector(electionConfig)
+			elector.Run(ctx)
+		}
+	}()
+	return nil
+}
+
+func (e *zoneLeaderElector) electionConfig(doneWg *sync.WaitGroup) (leaderelection.LeaderElectionConfig, error) {
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		config.Kubernetes.OVNConfigNamespace,
+		zoneLeaderLeaseName(e.zone),
+		e.client.CoreV1(),
+		e.client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      e.nodeName,
+			EventRecorder: e.recorder,
+		},
+	)
+	if err != nil {
+		return leaderelection.LeaderElectionConfig{}, fmt.Errorf("failed to create the lock of zone %s: %w", e.zone, err)
+	}
+	return leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   zoneLeaderLeaseDuration,
+		RenewDeadline:   zoneLeaderRenewDeadline,
+		RetryPeriod:     zoneLeaderRetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            zoneLeaderLeaseName(e.zone),
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				doneWg.Add(1)
+				defer doneWg.Done()
+				klog.Infof("Node %s won the election of zone %s, running the zone tasks", e.nodeName, e.zone)
+				tasksWg := &sync.WaitGroup{}
+				e.runTasks(ctx.Done(), tasksWg)
+				<-ctx.Done()
+				tasksWg.Wait()
+			},
+			OnStoppedLeading: func() {
+				klog.Infof("Node %s does not lead zone %s anymore, the zone tasks are stopped", e.nodeName, e.zone)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != e.nodeName {
+					klog.Infof("Node %s runs the tasks of zone %s", identity, e.zone)
+				}
+			},
+		},
+	}, nil
+}
+
+func (e *zoneLeaderElector) runTasks(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+	for _, task := range e.tasks {
+		klog.V(5).Infof("Running task %s of zone %s", task.name, e.zone)
+		task.run(stopChan, doneWg)
+	}
+}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+var _ = Describe("Zone leader election", func() {
+	var (
+		client   *fake.Clientset
+		stopChan chan struct{}
+		wg       *sync.WaitGroup
+		// running counts the nodes running the zone task
+		running atomic.Int32
+	)
+
+	startNode := func(nodeName string) {
+		elector := newZoneLeaderElector(nodeName, "zone1", client, record.NewFakeRecorder(10))
+		elector.addTask("test", func(stopChan <-chan struct{}, doneWg *sync.WaitGroup) {
+			running.Add(1)
+			doneWg.Add(1)
+			go func() {
+				defer doneWg.Done()
+				<-stopChan
+				running.Add(-1)
+			}()
+		})
+		Expect(elector.Run(stopChan, wg)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		client = fake.NewSimpleClientset()
+		stopChan = make(chan struct{})
+		wg = &sync.WaitGroup{}
+		running.Store(0)
+	})
+
+	AfterEach(func() {
+		close(stopChan)
+		wg.Wait()
+	})
+
+	It("runs the zone tasks on every node without the election", func() {
+		startNode("node1")
+		startNode("node2")
+		Expect(running.Load()).To(Equal(int32(2)))
+	})
+
+	It("runs the zone tasks on the elected node only", func() {
+		config.OvnKubeNode.ZoneLeaderElection = true
+		startNode("node1")
+		startNode("node2")
+		Eventually(running.Load).Should(Equal(int32(1)))
+		Consistently(running.Load).Should(Equal(int32(1)))
+
+		lease, err := client.CoordinationV1().Leases(config.Kubernetes.OVNConfigNamespace).Get(context.Background(),
+			zoneLeaderLeaseName("zone1"), metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*lease.Spec.HolderIdentity).To(BeElementOf("node1", "node2"))
+	})
+})
@@ -0,0 +1,257 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// zoneMigrationSyncPeriod is how often a pending zone migration checks whether the node is drained
+	zoneMigrationSyncPeriod = 30 * time.Second
+	// zoneMigrationTimeout is how long ovn-controller has to connect to the Southbound database of a zone
+	zoneMigrationTimeout = 2 * time.Minute
+)
+
+// zoneMigrationController moves the node at runtime to the zone requested in the node zone migration
+// annotation. The migration waits for the node to be cordoned and drained, then connects ovn-controller
+// to the Southbound database of the target zone, updates the zone annotations of the node and resyncs
+// the gateway. A failure at any step rolls the node back to its previous zone.
+// The migration is not persisted in the ovnkube-node configuration: the zone configured for the node has
+// to be updated before ovnkube-node restarts, otherwise the node is migrated again once drained.
+type zoneMigrationController struct {
+	nodeName     string
+	watchFactory factory.NodeWatchFactory
+	kube         kube.Interface
+	stopChan     <-chan struct{}
+	trigger      chan struct{}
+	// failed is the last migration that failed, it is only retried once requested again
+	failed *util.ZoneMigration
+	// sbEndpoint is locked for the whole migration, the OVN databases discovery doesn't move the node meanwhile
+	sbEndpoint *southboundEndpoint
+
+	// the steps of the migration are functions so that unit tests can mock them
+	waitForZone   func(zone string) error
+	resyncGateway func() error
+}
+
+func newZoneMigrationController(nodeName string, k kube.Interface, watchFactory factory.NodeWatchFactory,
+	gw Gateway, sbEndpoint *southboundEndpoint, stopChan <-chan struct{}) *zoneMigrationController {
+	c := &zoneMigrationController{
+		nodeName:      nodeName,
+		watchFactory:  watchFactory,
+		kube:          k,
+		stopChan:      stopChan,
+		trigger:       make(chan struct{}, 1),
+		sbEndpoint:    sbEndpoint,
+		resyncGateway: gw.Reconcile,
+	}
+	c.waitForZone = c.waitForOVNControllerZone
+	return c
+}
+
+func (c *zoneMigrationController) Run(doneWg *sync.WaitGroup) error {
+	_, err := c.watchFactory.NodeInformer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldNode := old.(*kapi.Node)
+			newNode := new.(*kapi.Node)
+			if newNode.Name == c.nodeName && (util.NodeZoneMigrationAnnotationChanged(oldNode, newNode) ||
+				oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable) {
+				c.requestSync()
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("could not add node event handler for the zone migration: %w", err)
+	}
+
+	runPeriodicSync(c.stopChan, doneWg, zoneMigrationSyncPeriod, c.trigger, func() {
+		if err := c.sync(); err != nil {
+			klog.Errorf("Failed to sync the zone migration of node %s: %v", c.nodeName, err)
+		}
+	})
+	return nil
+}
+
+func (c *zoneMigrationController) requestSync() {
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// sync migrates the node when a migration to another zone is requested and the node is drained
+func (c *zoneMigrationController) sync() error {
+	node, err := c.watchFactory.GetNode(c.nodeName)
+	if err != nil {
+		return err
+	}
+	status, err := util.ParseNodeZoneMigrationStatus(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		klog.Warningf("Overwriting the invalid zone migration status of node %s: %v", c.nodeName, err)
+	}
+	migration, err := util.ParseNodeZoneMigration(node)
+	if err != nil {
+		if util.IsAnnotationNotSetError(err) {
+			// the migration request was removed
+			c.failed = nil
+			return c.setStatus(status, nil)
+		}
+		return c.setStatus(status, &util.ZoneMigrationStatus{Phase: util.ZoneMigrationFailed, Message: err.Error()})
+	}
+
+	if migration.Zone == util.GetNodeZone(node) {
+		return c.setStatus(status, &util.ZoneMigrationStatus{Zone: migration.Zone, Phase: util.ZoneMigrationCompleted})
+	}
+	if c.failed != nil && *c.failed == *migration {
+		return nil
+	}
+	if !strings.HasPrefix(migration.SBAddress, string(config.OvnSouth.Scheme)+":") {
+		return c.setStatus(status, &util.ZoneMigrationStatus{Zone: migration.Zone, Phase: util.ZoneMigrationFailed,
+			Message: fmt.Sprintf("sb-address %s must use the %s scheme of the node Southbound database configuration",
+				migration.SBAddress, config.OvnSouth.Scheme)})
+	}
+
+	pods, err := c.watchFactory.GetAllPods()
+	if err != nil {
+		return err
+	}
+	if reason := drainBlocker(node, pods); reason != "" {
+		return c.setStatus(status, &util.ZoneMigrationStatus{Zone: migration.Zone, Phase: util.ZoneMigrationPending, Message: reason})
+	}
+
+	if err := c.migrate(node, migration); err != nil {
+		klog.Errorf("Failed to migrate node %s to zone %s: %v", c.nodeName, migration.Zone, err)
+		c.failed = migration
+		return c.setStatus(status, &util.ZoneMigrationStatus{Zone: migration.Zone, Phase: util.ZoneMigrationFailed, Message: err.Error()})
+	}
+	return c.setStatus(status, &util.ZoneMigrationStatus{Zone: migration.Zone, Phase: util.ZoneMigrationCompleted})
+}
+
+// migrate moves the node to the zone of the migration, rolling back to the current zone on failure
+func (c *zoneMigrationController) migrate(node *kapi.Node, migration *util.ZoneMigration) error {
+	c.sbEndpoint.Lock()
+	defer c.sbEndpoint.Unlock()
+	fromZone := util.GetNodeZone(node)
+	fromAddress := config.OvnSouth.GetURL()
+	hasMigratedZone := util.HasNodeMigratedZone(node)
+	start := time.Now()
+	klog.Infof("Migrating node %s from zone %s to zone %s", c.nodeName, fromZone, migration.Zone)
+	err := c.switchZone(migration.SBAddress, migration.Zone, hasMigratedZone)
+	if err == nil {
+		c.sbEndpoint.migrated = migration.Zone != c.sbEndpoint.configuredZone
+		klog.Infof("Migrated node %s to zone %s, took %v", c.nodeName, migration.Zone, time.Since(start))
+		return nil
+	}
+	klog.Errorf("Failed to migrate node %s to zone %s, rolling back to zone %s: %v", c.nodeName, migration.Zone, fromZone, err)
+	if rollbackErr := c.switchZone(fromAddress, fromZone, hasMigratedZone); rollbackErr != nil {
+		return fmt.Errorf("%v, rollback to zone %s failed: %v", err, fromZone, rollbackErr)
+	}
+	return fmt.Errorf("%v, rolled back to zone %s", err, fromZone)
+}
+
+// switchZone connects ovn-controller to the Southbound database at address, waits for it to be
+// connected to the database of zone, then moves the node and its gateway to zone. c.sbEndpoint must
+// be locked.
+func (c *zoneMigrationController) switchZone(address, zone string, hasMigratedZone bool) error {
+	if err := c.sbEndpoint.connect(address); err != nil {
+		return fmt.Errorf("failed to connect to the Southbound database %s: %w", address, err)
+	}
+	if err := c.waitForZone(zone); err != nil {
+		return fmt.Errorf("ovn-controller is not connected to the Southbound database of zone %s: %w", zone, err)
+	}
+	config.SetZone(zone)
+
+	nodeAnnotator := newNodeAnnotator(c.kube, c.nodeName)
+	if err := util.SetNodeZone(nodeAnnotator, zone); err != nil {
+		return fmt.Errorf("failed to set node zone annotation for node %s: %w", c.nodeName, err)
+	}
+	// keep the zone of the legacy to interconnect upgrade annotation in sync
+	if hasMigratedZone {
+		if err := util.SetNodeZoneMigrated(nodeAnnotator, zone); err != nil {
+			return fmt.Errorf("failed to set node zone migrated annotation for node %s: %w", c.nodeName, err)
+		}
+	}
+	if err := nodeAnnotator.Run(); err != nil {
+		return fmt.Errorf("failed to set node %s annotations: %w", c.nodeName, err)
+	}
+
+	if err := c.resyncGateway(); err != nil {
+		return fmt.Errorf("failed to resync the gateway: %w", err)
+	}
+	return nil
+}
+
+func (c *zoneMigrationController) setStatus(current, status *util.ZoneMigrationStatus) error {
+	if reflect.DeepEqual(current, status) {
+		return nil
+	}
+	nodeAnnotator := newNodeAnnotator(c.kube, c.nodeName)
+	if err := util.SetNodeZoneMigrationStatus(nodeAnnotator, status); err != nil {
+		return err
+	}
+	return nodeAnnotator.Run()
+}
+
+// waitForOVNControllerZone waits for ovn-controller to be connected to the Southbound database of zone
+func (c *zoneMigrationController) waitForOVNControllerZone(zone string) error {
+	var lastErr error
+	err := wait.PollUntilContextTimeout(wait.ContextForChannel(c.stopChan), time.Second, zoneMigrationTimeout, true,
+		func(context.Context) (bool, error) {
+			ready, err := isOVNControllerReady()
+			if err != nil || !ready {
+				lastErr = fmt.Errorf("ovn-controller not ready: %v", err)
+				return false, nil
+			}
+			sbZone, err := getOVNSBZone()
+			if err != nil {
+				lastErr = err
+				return false, nil
+			}
+			if sbZone != zone {
+				lastErr = fmt.Errorf("connected to the Southbound database of zone %s", sbZone)
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		return fmt.Errorf("%v: %v", err, lastErr)
+	}
+	return nil
+}
+
+// drainBlocker returns why the node can't be migrated yet: it has to be cordoned and drained of the
+// pods attached to the cluster network, except for DaemonSet pods that are not evicted by a drain
+func drainBlocker(node *kapi.Node, pods []*kapi.Pod) string {
+	if !node.Spec.Unschedulable {
+		return "waiting for the node to be cordoned"
+	}
+	remaining := 0
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node.Name || util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) {
+			continue
+		}
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		remaining++
+	}
+	if remaining > 0 {
+		return fmt.Sprintf("waiting for %d pods to be drained", remaining)
+	}
+	return ""
+}
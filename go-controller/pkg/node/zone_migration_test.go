@@ -0,0 +1,164 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = Describe("Zone migration", func() {
+	const nodeName = "node1"
+	var (
+		kubeFakeClient *fake.Clientset
+		wf             *factory.WatchFactory
+		c              *zoneMigrationController
+		stop           chan struct{}
+		connected      []string
+		failingZone    string
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableInterconnect = true
+		config.Default.Zone = "zone-a"
+		config.OvnSouth.Scheme = config.OvnDBSchemeTCP
+		config.OvnSouth.Address = "tcp:10.0.0.1:6642"
+
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+				Annotations: map[string]string{
+					util.OvnNodeZoneName:      "zone-a",
+					util.OvnNodeZoneMigration: `{"zone": "zone-b", "sb-address": "tcp:10.0.0.2:6642"}`,
+				},
+			},
+		}
+		pod := v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+		}
+		kubeFakeClient = fake.NewSimpleClientset(&v1.NodeList{Items: []v1.Node{node}}, &v1.PodList{Items: []v1.Pod{pod}})
+		var err error
+		wf, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: kubeFakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+
+		stop = make(chan struct{})
+		connected = nil
+		failingZone = ""
+		c = &zoneMigrationController{
+			nodeName:     nodeName,
+			watchFactory: wf,
+			kube:         &kube.Kube{KClient: kubeFakeClient},
+			stopChan:     stop,
+			sbEndpoint: &southboundEndpoint{
+				configuredZone: "zone-a",
+				apply: func() error {
+					connected = append(connected, config.OvnSouth.GetURL())
+					return nil
+				},
+			},
+			waitForZone: func(zone string) error {
+				if zone == failingZone {
+					return fmt.Errorf("timed out")
+				}
+				return nil
+			},
+			resyncGateway: func() error { return nil },
+		}
+	})
+
+	AfterEach(func() {
+		close(stop)
+		wf.Shutdown()
+	})
+
+	getNode := func() *v1.Node {
+		node, err := kubeFakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return node
+	}
+
+	getStatus := func() *util.ZoneMigrationStatus {
+		status, err := util.ParseNodeZoneMigrationStatus(getNode())
+		Expect(err).NotTo(HaveOccurred())
+		return status
+	}
+
+	// drain cordons the node and deletes its pod, then waits for the informers to catch up
+	drain := func() {
+		node := getNode()
+		node.Spec.Unschedulable = true
+		_, err := kubeFakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kubeFakeClient.CoreV1().Pods("default").Delete(context.TODO(), "pod1", metav1.DeleteOptions{})).To(Succeed())
+		Eventually(func() bool {
+			node, err := wf.GetNode(nodeName)
+			pods, _ := wf.GetAllPods()
+			return err == nil && node.Spec.Unschedulable && len(pods) == 0
+		}).Should(BeTrue())
+	}
+
+	It("waits for the node to be drained and moves it to the requested zone", func() {
+		Expect(c.sync()).To(Succeed())
+		Expect(getStatus()).To(Equal(&util.ZoneMigrationStatus{
+			Zone: "zone-b", Phase: util.ZoneMigrationPending, Message: "waiting for the node to be cordoned"}))
+		Expect(connected).To(BeEmpty())
+
+		drain()
+		Expect(c.sync()).To(Succeed())
+		Expect(connected).To(Equal([]string{"tcp:10.0.0.2:6642"}))
+		Expect(getStatus()).To(Equal(&util.ZoneMigrationStatus{Zone: "zone-b", Phase: util.ZoneMigrationCompleted}))
+		Expect(util.GetNodeZone(getNode())).To(Equal("zone-b"))
+		Expect(config.Default.Zone).To(Equal("zone-b"))
+		Expect(config.OvnSouth.Address).To(Equal("tcp:10.0.0.2:6642"))
+
+		// the discovered endpoints of the Southbound database of zone-a don't revert the migration
+		Expect(c.sbEndpoint.follow("tcp:10.0.0.3:6642")).To(Succeed())
+		Expect(connected).To(Equal([]string{"tcp:10.0.0.2:6642"}))
+	})
+
+	It("rolls the node back to its zone when the migration fails", func() {
+		failingZone = "zone-b"
+		drain()
+		Expect(c.sync()).To(Succeed())
+		Expect(connected).To(Equal([]string{"tcp:10.0.0.2:6642", "tcp:10.0.0.1:6642"}))
+		status := getStatus()
+		Expect(status.Phase).To(Equal(util.ZoneMigrationFailed))
+		Expect(status.Message).To(ContainSubstring("rolled back to zone zone-a"))
+		Expect(util.GetNodeZone(getNode())).To(Equal("zone-a"))
+		Expect(config.Default.Zone).To(Equal("zone-a"))
+		Expect(config.OvnSouth.Address).To(Equal("tcp:10.0.0.1:6642"))
+		Expect(c.sbEndpoint.migrated).To(BeFalse())
+
+		// a failed migration is not retried until it is requested again
+		Expect(c.sync()).To(Succeed())
+		Expect(connected).To(HaveLen(2))
+	})
+
+	It("rejects a Southbound address with another scheme", func() {
+		node := getNode()
+		node.Annotations[util.OvnNodeZoneMigration] = `{"zone": "zone-b", "sb-address": "ssl:10.0.0.2:9642"}`
+		_, err := kubeFakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() string {
+			node, _ := wf.GetNode(nodeName)
+			return node.Annotations[util.OvnNodeZoneMigration]
+		}).Should(ContainSubstring("ssl"))
+
+		Expect(c.sync()).To(Succeed())
+		Expect(getStatus().Phase).To(Equal(util.ZoneMigrationFailed))
+		Expect(connected).To(BeEmpty())
+	})
+})
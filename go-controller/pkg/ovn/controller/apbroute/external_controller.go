@@ -822,3 +822,51 @@ func (m *externalPolicyManager) getStaticGatewayIPsForTargetNamespace(namespaceN
 	}
 	return policyGWIPs, nil
 }
+
+// isTargetNamespace returns true if the namespace is selected as target by any policy
+func (m *externalPolicyManager) isTargetNamespace(namespace *v1.Namespace) (bool, error) {
+	routePolicies, err := m.getAllRoutePolicies()
+	if err != nil {
+		return false, err
+	}
+	for _, routePolicy := range routePolicies {
+		targetNsSel, err := metav1.LabelSelectorAsSelector(&routePolicy.Spec.From.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("failed to get APB Policy %s target namespace selector: %w", routePolicy.Name, err)
+		}
+		if targetNsSel.Matches(labels.Set(namespace.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getBFDGatewayIPs returns the gateway IPs with BFD enabled of every policy, by policy name. The policies without
+// any are not returned.
+func (m *externalPolicyManager) getBFDGatewayIPs() (map[string]sets.Set[string], error) {
+	routePolicies, err := m.getAllRoutePolicies()
+	if err != nil {
+		return nil, err
+	}
+	policyGWIPs := map[string]sets.Set[string]{}
+	for _, routePolicy := range routePolicies {
+		staticGWInfo, err := m.processStaticHopsGatewayInformation(routePolicy.Spec.NextHops.StaticHops)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get APB Policy %s static gateway IPs: %w", routePolicy.Name, err)
+		}
+		dynamicGWInfo, _, _, err := m.processDynamicHopsGatewayInformation(routePolicy.Spec.NextHops.DynamicHops)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get APB Policy %s dynamic gateway IPs: %w", routePolicy.Name, err)
+		}
+		gwIPs := sets.New[string]()
+		for _, gwInfo := range append(staticGWInfo.Elems(), dynamicGWInfo.Elems()...) {
+			if gwInfo.BFDEnabled {
+				insertSet(gwIPs, gwInfo.Gateways)
+			}
+		}
+		if gwIPs.Len() > 0 {
+			policyGWIPs[routePolicy.Name] = gwIPs
+		}
+	}
+	return policyGWIPs, nil
+}
@@ -355,6 +355,33 @@ var _ = Describe("OVN External Gateway policy", func() {
 			eventuallyExpectConfig(policyName, expectedPolicy, expectedRefs)
 		})
 
+		It("returns the gateway IPs with bfd enabled of the policies", func() {
+			bfdPolicy := newPolicy("bfdPolicy",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New("10.10.10.2", "10.10.10.3"),
+				&v1.LabelSelector{MatchLabels: gatewayNamespaceMatch},
+				&v1.LabelSelector{MatchLabels: map[string]string{"key": "pod"}},
+				true,
+			)
+			initController([]runtime.Object{namespaceGW, namespaceTarget, targetPod1, namespaceTarget2, targetPod2, pod1},
+				[]runtime.Object{bfdPolicy, dynamicPolicy})
+
+			eventuallyExpectNumberOfPolicies(2)
+			Expect(externalController.mgr.getBFDGatewayIPs()).To(Equal(map[string]sets.Set[string]{
+				bfdPolicy.Name: sets.New("10.10.10.2", "10.10.10.3", "192.168.10.1"),
+			}))
+		})
+
+		It("reports the namespaces selected as target by the policies", func() {
+			initController([]runtime.Object{namespaceGW, namespaceTarget, targetPod1, namespaceTarget2, pod1},
+				[]runtime.Object{staticPolicy})
+
+			eventuallyExpectNumberOfPolicies(1)
+			Expect(externalController.mgr.isTargetNamespace(namespaceTarget)).To(BeTrue())
+			Expect(externalController.mgr.isTargetNamespace(namespaceTarget2)).To(BeFalse())
+			Expect(externalController.mgr.isTargetNamespace(namespaceGW)).To(BeFalse())
+		})
+
 		It("registers a second policy with no overlaping IPs", func() {
 			initController([]runtime.Object{namespaceGW, namespaceTarget, targetPod1, namespaceTarget2, targetPod2, pod1},
 				[]runtime.Object{staticPolicy, dynamicPolicy})
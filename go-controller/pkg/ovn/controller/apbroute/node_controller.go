@@ -3,6 +3,7 @@ package apbroute
 import (
 	"sync"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/klog/v2"
@@ -57,3 +58,13 @@ func (c *ExternalGatewayNodeController) GetAdminPolicyBasedExternalRouteIPsForTa
 
 	return gwIPs.Union(tmpIPs), nil
 }
+
+// IsTargetNamespace returns true if the namespace is selected as target by any policy
+func (c *ExternalGatewayNodeController) IsTargetNamespace(namespace *v1.Namespace) (bool, error) {
+	return c.mgr.isTargetNamespace(namespace)
+}
+
+// GetBFDGatewayIPs returns the gateway IPs with BFD enabled of every policy, by policy name
+func (c *ExternalGatewayNodeController) GetBFDGatewayIPs() (map[string]sets.Set[string], error) {
+	return c.mgr.getBFDGatewayIPs()
+}
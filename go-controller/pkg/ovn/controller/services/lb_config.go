@@ -7,6 +7,7 @@ import (
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	conf "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/unidling"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -37,6 +38,10 @@ type lbConfig struct {
 	// that means, skipSNAT, and remove any non-local endpoints.
 	// (see below)
 	externalTrafficLocal bool
+	// if true, then vips added on the router of a node with local endpoints of the
+	// vip family are in "local" mode, the other routers use the cluster endpoints.
+	// (see util.ServiceDirectServerReturn)
+	directServerReturn bool
 	// if true, then vips added on the switch are in "local" mode
 	// that means, remove any non-local endpoints.
 	internalTrafficLocal bool
@@ -75,6 +80,21 @@ func makeNodeSwitchTargetIPs(node string, c *lbConfig) (targetIPsV4, targetIPsV6
 	return
 }
 
+// routerTrafficLocal returns whether the vips of the family added on the router of the node are in "local" mode
+func (c *lbConfig) routerTrafficLocal(node string, isIPv6 bool) bool {
+	if c.externalTrafficLocal {
+		return true
+	}
+	if !c.directServerReturn {
+		return false
+	}
+	localEndpoints := c.nodeEndpoints[node]
+	if isIPv6 {
+		return len(localEndpoints.V6IPs) > 0
+	}
+	return len(localEndpoints.V4IPs) > 0
+}
+
 func makeNodeRouterTargetIPs(node *nodeInfo, c *lbConfig, hostMasqueradeIPV4, hostMasqueradeIPV6 string) (targetIPsV4, targetIPsV6 []string, v4Changed, v6Changed bool) {
 	targetIPsV4 = c.clusterEndpoints.V4IPs
 	targetIPsV6 = c.clusterEndpoints.V6IPs
@@ -90,6 +110,17 @@ func makeNodeRouterTargetIPs(node *nodeInfo, c *lbConfig, hostMasqueradeIPV4, ho
 		}
 		targetIPsV4 = localIPsV4
 		targetIPsV6 = localIPsV6
+	} else if c.directServerReturn {
+		// For direct server return, prefer the local endpoints and fall back to the cluster endpoints
+		// of the families without local endpoints
+		if localEndpoints, ok := c.nodeEndpoints[node.name]; ok {
+			if len(localEndpoints.V4IPs) > 0 {
+				targetIPsV4 = localEndpoints.V4IPs
+			}
+			if len(localEndpoints.V6IPs) > 0 {
+				targetIPsV6 = localEndpoints.V6IPs
+			}
+		}
 	}
 
 	// Any targets local to the node need to have a special
@@ -127,10 +158,11 @@ var protos = []v1.Protocol{
 // - services with host-network endpoints
 // - services with ExternalTrafficPolicy=Local
 // - services with InternalTrafficPolicy=Local
+// - services with direct server return
 //
 // Template LBs will be created for
-//   - services with NodePort set but *without* ExternalTrafficPolicy=Local or
-//     affinity timeout set.
+//   - services with NodePort set but *without* ExternalTrafficPolicy=Local,
+//     direct server return or affinity timeout set.
 func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.EndpointSlice, nodeInfos []nodeInfo, useLBGroup, useTemplates bool) (perNodeConfigs, templateConfigs, clusterConfigs []lbConfig) {
 	needsAffinityTimeout := hasSessionAffinityTimeOut(service)
 
@@ -150,6 +182,7 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 		// if ExternalTrafficPolicy or InternalTrafficPolicy is local, then we need to do things a bit differently
 		externalTrafficLocal := util.ServiceExternalTrafficPolicyLocal(service)
 		internalTrafficLocal := util.ServiceInternalTrafficPolicyLocal(service)
+		directServerReturn := util.ServiceDirectServerReturn(service)
 
 		// NodePort services get a per-node load balancer, but with the node's physical IP as the vip
 		// Thus, the vip "node" will be expanded later.
@@ -162,12 +195,13 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 				clusterEndpoints:     clusterEndpoints,
 				nodeEndpoints:        nodeEndpoints,
 				externalTrafficLocal: externalTrafficLocal,
+				directServerReturn:   directServerReturn,
 				internalTrafficLocal: false, // always false for non-ClusterIPs
 				hasNodePort:          true,
 			}
-			// Only "plain" NodePort services (no ETP, no affinity timeout)
+			// Only "plain" NodePort services (no ETP, no DSR, no affinity timeout)
 			// can use load balancer templates.
-			if !useLBGroup || !useTemplates || externalTrafficLocal || needsAffinityTimeout {
+			if !useLBGroup || !useTemplates || externalTrafficLocal || directServerReturn || needsAffinityTimeout {
 				perNodeConfigs = append(perNodeConfigs, nodePortLBConfig)
 			} else {
 				templateConfigs = append(templateConfigs, nodePortLBConfig)
@@ -178,17 +212,18 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 		vips := util.GetClusterIPs(service)
 		externalVips := util.GetExternalAndLBIPs(service)
 
-		// if ETP=Local, then treat ExternalIPs and LoadBalancer IPs specially
+		// if ETP=Local or DSR, then treat ExternalIPs and LoadBalancer IPs specially
 		// otherwise, they're just cluster IPs
 		// This is NEVER influenced by InternalTrafficPolicy
-		if externalTrafficLocal && len(externalVips) > 0 {
+		if (externalTrafficLocal || directServerReturn) && len(externalVips) > 0 {
 			externalIPConfig := lbConfig{
 				protocol:             svcPort.Protocol,
 				inport:               svcPort.Port,
 				vips:                 externalVips,
 				clusterEndpoints:     clusterEndpoints,
 				nodeEndpoints:        nodeEndpoints,
-				externalTrafficLocal: true,
+				externalTrafficLocal: externalTrafficLocal,
+				directServerReturn:   directServerReturn,
 				internalTrafficLocal: false, // always false for non-ClusterIPs
 				hasNodePort:          false,
 			}
@@ -579,6 +614,9 @@ func buildTemplateLBs(service *v1.Service, configs []lbConfig, nodes []nodeInfo,
 // - SkipSNAT enabled
 // - NodePort LB on the switch will have masqueradeIP as the vip to handle etp=local for LGW case.
 // This results in the creation of an additional load balancer on the GatewayRouters and NodeSwitches.
+//
+// For direct server return, the same applies to the "External" IPs of the routers of the nodes with
+// local endpoints of the IP family, the other routers keep the cluster endpoints and SNAT.
 func buildPerNodeLBs(service *v1.Service, configs []lbConfig, nodes []nodeInfo) []LB {
 	cbp := configsByProto(configs)
 	eids := util.ExternalIDsForObject(service)
@@ -676,10 +714,10 @@ func buildPerNodeLBs(service *v1.Service, configs []lbConfig, nodes []nodeInfo)
 						Targets: targets,
 					}
 
-					// in other words, is this ExternalTrafficPolicy=local?
+					// in other words, is this ExternalTrafficPolicy=local or DSR with local endpoints?
 					// if so, this gets a separate load balancer with SNAT disabled
 					// (but there's no need to do this if the list of targets is empty)
-					if config.externalTrafficLocal && len(targets) > 0 {
+					if config.routerTrafficLocal(node.name, isv6) && len(targets) > 0 {
 						noSNATRouterRules = append(noSNATRouterRules, rule)
 					} else {
 						routerRules = append(routerRules, rule)
@@ -802,10 +840,42 @@ func lbOpts(service *v1.Service) LBOpts {
 
 	if affinity {
 		lbOptions.AffinityTimeOut = getSessionAffinityTimeOut(service)
+	} else {
+		lbOptions.SelectionFields = getSelectionFields(service)
 	}
 	return lbOptions
 }
 
+// validSelectionFields are the OVN load balancer selection fields a service can request
+var validSelectionFields = sets.New[string](
+	nbdb.LoadBalancerSelectionFieldsEthSrc,
+	nbdb.LoadBalancerSelectionFieldsEthDst,
+	nbdb.LoadBalancerSelectionFieldsIPSrc,
+	nbdb.LoadBalancerSelectionFieldsIPDst,
+	nbdb.LoadBalancerSelectionFieldsTpSrc,
+	nbdb.LoadBalancerSelectionFieldsTpDst,
+)
+
+// getSelectionFields returns the sorted selection fields requested by the service annotation, nil if there
+// are none or if any of them is invalid.
+func getSelectionFields(service *v1.Service) []string {
+	annotation, ok := service.Annotations[util.ServiceLoadBalancerSelectionFieldsAnnotation]
+	if !ok || strings.TrimSpace(annotation) == "" {
+		return nil
+	}
+	fields := sets.New[string]()
+	for _, field := range strings.Split(annotation, ",") {
+		field = strings.TrimSpace(field)
+		if !validSelectionFields.Has(field) {
+			klog.Warningf("Ignoring the %s annotation of service %s/%s: invalid selection field %q, valid fields are %v",
+				util.ServiceLoadBalancerSelectionFieldsAnnotation, service.Namespace, service.Name, field, sets.List(validSelectionFields))
+			return nil
+		}
+		fields.Insert(field)
+	}
+	return sets.List(fields)
+}
+
 func lbTemplateOpts(service *v1.Service, addressFamily v1.IPFamily) LBOpts {
 	lbOptions := lbOpts(service)
 
@@ -880,14 +950,15 @@ func getServicePortKey(protocol v1.Protocol, name string) string {
 // GetEndpointsForService takes a service, all its slices and the list of nodes in the OVN zone
 // and returns two maps that hold all the endpoint addresses for the service:
 // one classified by port, one classified by port,node. This second map is only filled in
-// when the service needs local (per-node) endpoints, that is when ETP=local or ITP=local.
+// when the service needs local (per-node) endpoints, that is when ETP=local, ITP=local or DSR.
 // The node list helps to keep the resulting map small, since we're only interested in local endpoints.
 func getEndpointsForService(slices []*discovery.EndpointSlice, service *v1.Service, nodes sets.Set[string]) (map[string]lbEndpoints, map[string]map[string]lbEndpoints) {
 	// classify endpoints
 	ports := map[string]int32{}
 	portToEndpoints := map[string][]discovery.Endpoint{}
 	portToNodeToEndpoints := map[string]map[string][]discovery.Endpoint{}
-	requiresLocalEndpoints := util.ServiceExternalTrafficPolicyLocal(service) || util.ServiceInternalTrafficPolicyLocal(service)
+	requiresLocalEndpoints := util.ServiceExternalTrafficPolicyLocal(service) || util.ServiceInternalTrafficPolicyLocal(service) ||
+		util.ServiceDirectServerReturn(service)
 
 	for _, port := range service.Spec.Ports {
 		name := getServicePortKey(port.Protocol, port.Name)
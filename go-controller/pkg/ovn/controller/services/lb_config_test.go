@@ -9,6 +9,7 @@ import (
 	globalconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	kube_test "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/stretchr/testify/assert"
 
 	v1 "k8s.io/api/core/v1"
@@ -328,6 +329,83 @@ func Test_buildServiceLBConfigs(t *testing.T) {
 			}},
 			resultsSame: true,
 		},
+		{
+			name: "v4 type=LoadBalancer, direct server return, one port, endpoints, external ip",
+			args: args{
+				slices: makeSlices([]string{"10.128.0.2"}, nil, v1.ProtocolTCP),
+				service: &v1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        serviceName,
+						Namespace:   ns,
+						Annotations: map[string]string{util.ServiceDirectServerReturnAnnotation: "true"},
+					},
+					Spec: v1.ServiceSpec{
+						Type:        v1.ServiceTypeLoadBalancer,
+						ClusterIP:   "192.168.1.1",
+						ClusterIPs:  []string{"192.168.1.1"},
+						ExternalIPs: []string{"4.2.2.2"},
+						Ports: []v1.ServicePort{{
+							Name:       portName,
+							Port:       inport,
+							Protocol:   v1.ProtocolTCP,
+							TargetPort: outportstr,
+							NodePort:   5,
+						}},
+					},
+				},
+			},
+			resultSharedGatewayNode: []lbConfig{
+				{
+					vips:     []string{"node"},
+					protocol: v1.ProtocolTCP,
+					inport:   5,
+					clusterEndpoints: lbEndpoints{
+						V4IPs: []string{"10.128.0.2"},
+						Port:  outport,
+					},
+					nodeEndpoints: map[string]lbEndpoints{ // service is DSR, so nodeEndpoints is filled out
+						nodeA: {
+							V4IPs: []string{"10.128.0.2"},
+							Port:  outport,
+						},
+					},
+					directServerReturn: true,
+					hasNodePort:        true,
+				},
+				{
+					vips:     []string{"4.2.2.2"},
+					protocol: v1.ProtocolTCP,
+					inport:   inport,
+					clusterEndpoints: lbEndpoints{
+						V4IPs: []string{"10.128.0.2"},
+						Port:  outport,
+					},
+					nodeEndpoints: map[string]lbEndpoints{
+						nodeA: {
+							V4IPs: []string{"10.128.0.2"},
+							Port:  outport,
+						},
+					},
+					directServerReturn: true,
+				},
+			},
+			resultSharedGatewayCluster: []lbConfig{{
+				vips:     []string{"192.168.1.1"},
+				protocol: v1.ProtocolTCP,
+				inport:   inport,
+				clusterEndpoints: lbEndpoints{
+					V4IPs: []string{"10.128.0.2"},
+					Port:  outport,
+				},
+				nodeEndpoints: map[string]lbEndpoints{
+					nodeA: {
+						V4IPs: []string{"10.128.0.2"},
+						Port:  outport,
+					},
+				},
+			}},
+			resultsSame: true,
+		},
 		{
 			name: "v4 clusterip, two tcp ports, two endpoints",
 			args: args{
@@ -2846,6 +2924,80 @@ func Test_buildPerNodeLBs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "nodeport service, standard pod on one node, direct server return",
+			service: defaultService,
+			configs: []lbConfig{
+				{
+					vips:               []string{"node"},
+					protocol:           v1.ProtocolTCP,
+					inport:             5, // nodePort
+					hasNodePort:        true,
+					directServerReturn: true,
+					clusterEndpoints: lbEndpoints{
+						V4IPs: []string{"10.128.0.2", "10.128.1.2"},
+						Port:  8080,
+					},
+					nodeEndpoints: map[string]lbEndpoints{
+						nodeA: {
+							V4IPs: []string{"10.128.0.2"},
+							Port:  8080,
+						},
+					},
+				},
+			},
+			expectedShared: []LB{
+				{
+					Name:        "Service_testns/foo_TCP_node_local_router_node-a",
+					Protocol:    "TCP",
+					ExternalIDs: defaultExternalIDs,
+					Opts:        LBOpts{SkipSNAT: true, Reject: true},
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "10.0.0.1", Port: 5},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}}, // local endpoint
+						},
+						{
+							Source:  Addr{IP: "10.0.0.111", Port: 5},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}}, // local endpoint
+						},
+					},
+					Routers: []string{"gr-node-a"},
+				},
+				{
+					Name:        "Service_testns/foo_TCP_node_switch_node-a",
+					Protocol:    "TCP",
+					ExternalIDs: defaultExternalIDs,
+					Opts:        defaultOpts,
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "10.0.0.1", Port: 5},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+						{
+							Source:  Addr{IP: "10.0.0.111", Port: 5},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Switches: []string{"switch-node-a"},
+				},
+				{
+					// no local endpoint, falls back to the cluster endpoints with SNAT
+					Name:        "Service_testns/foo_TCP_node_router+switch_node-b",
+					Protocol:    "TCP",
+					ExternalIDs: defaultExternalIDs,
+					Opts:        defaultOpts,
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "10.0.0.2", Port: 5},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Routers:  []string{"gr-node-b"},
+					Switches: []string{"switch-node-b"},
+				},
+			},
+		},
 	}
 
 	// needs separate configuration variables for a V6 cluster
@@ -3201,6 +3353,51 @@ func Test_idledServices(t *testing.T) {
 	}
 }
 
+func Test_lbOptsSelectionFields(t *testing.T) {
+	tc := []struct {
+		name     string
+		service  *v1.Service
+		expected []string
+	}{
+		{
+			name:    "no annotation",
+			service: &v1.Service{},
+		},
+		{
+			name: "valid selection fields",
+			service: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					util.ServiceLoadBalancerSelectionFieldsAnnotation: "tp_src, ip_src,ip_dst,tp_dst,ip_src",
+				}},
+			},
+			expected: []string{"ip_dst", "ip_src", "tp_dst", "tp_src"},
+		},
+		{
+			name: "invalid selection field",
+			service: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					util.ServiceLoadBalancerSelectionFieldsAnnotation: "ip_src,proto",
+				}},
+			},
+		},
+		{
+			name: "session affinity takes precedence",
+			service: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					util.ServiceLoadBalancerSelectionFieldsAnnotation: "ip_src,ip_dst,tp_src,tp_dst",
+				}},
+				Spec: v1.ServiceSpec{SessionAffinity: v1.ServiceAffinityClientIP},
+			},
+		},
+	}
+
+	for i, tt := range tc {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			assert.Equal(t, tt.expected, lbOpts(tt.service).SelectionFields)
+		})
+	}
+}
+
 func Test_getEndpointsForService(t *testing.T) {
 	type args struct {
 		slices []*discovery.EndpointSlice
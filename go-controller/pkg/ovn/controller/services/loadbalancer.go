@@ -47,6 +47,9 @@ type LBOpts struct {
 	// If greater than 0, then enable per-client-IP affinity.
 	AffinityTimeOut int32
 
+	// If set and there is no per-client-IP affinity, the fields hashed to select the endpoint.
+	SelectionFields []string
+
 	// If true, then disable SNAT entirely
 	SkipSNAT bool
 
@@ -373,6 +376,10 @@ func buildLB(lb *LB) *templateLoadBalancer {
 				nbdb.LoadBalancerSelectionFieldsIPDst,
 			}
 		}
+	} else if len(lb.Opts.SelectionFields) > 0 {
+		// Consistent hashing
+		// Bucket flows by the requested fields, ovn-controller then selects the group bucket by hash
+		selectionFields = lb.Opts.SelectionFields
 	}
 
 	if lb.Opts.Template {
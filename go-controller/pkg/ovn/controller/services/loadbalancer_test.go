@@ -193,6 +193,48 @@ func TestEnsureLBs(t *testing.T) {
 				ExternalIDs: serviceExternalIDs(namespacedServiceName("foo", "testns")),
 			},
 		},
+		{
+			desc: "create service with consistent hashing",
+			service: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "testns"},
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeClusterIP,
+				},
+			},
+			LBs: []LB{
+				{
+					Name: "Service_foo/testns_TCP_cluster",
+					ExternalIDs: map[string]string{
+						types.LoadBalancerKindExternalID:  "Service",
+						types.LoadBalancerOwnerExternalID: fmt.Sprintf("%s/%s", "foo", "testns"),
+					},
+					Routers:  []string{"gr-node-a"},
+					Protocol: "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "192.168.1.1", Port: 80},
+							Targets: []Addr{{IP: "10.0.244.3", Port: 8080}},
+						},
+					},
+					UUID: "test-UUID",
+					Opts: LBOpts{
+						Reject:          true,
+						SelectionFields: []string{"ip_dst", "ip_src", "tp_dst", "tp_src"},
+					},
+				},
+			},
+			finalLB: &nbdb.LoadBalancer{
+				UUID:     loadBalancerClusterWideTCPServiceName("foo", "testns"),
+				Name:     loadBalancerClusterWideTCPServiceName("foo", "testns"),
+				Options:  servicesOptions(),
+				Protocol: &nbdb.LoadBalancerProtocolTCP,
+				Vips: map[string]string{
+					"192.168.1.1:80": "10.0.244.3:8080",
+				},
+				ExternalIDs:     serviceExternalIDs(namespacedServiceName("foo", "testns")),
+				SelectionFields: []string{"ip_dst", "ip_src", "tp_dst", "tp_src"}, // hash selection method
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
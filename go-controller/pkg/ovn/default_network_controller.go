@@ -960,6 +960,13 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 			if err := h.oc.addUpdateLocalNodeEvent(newNode, nodeSyncsParam); err != nil {
 				aggregatedErrors = append(aggregatedErrors, err)
 			}
+			// the node allocates the source port ranges of its pods, the SNATs of the pods added meanwhile are
+			// created with them
+			if config.Gateway.DisableSNATMultipleGWs && util.NodePodSNATPortRangesAnnotationChanged(oldNode, newNode) {
+				if err := h.oc.updatePodSNATPortRanges(newNode); err != nil {
+					aggregatedErrors = append(aggregatedErrors, err)
+				}
+			}
 		} else {
 			_, syncZoneIC := h.oc.syncZoneICFailed.Load(newNode.Name)
 
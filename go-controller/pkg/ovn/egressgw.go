@@ -592,28 +592,98 @@ func (oc *DefaultNetworkController) deletePodSNAT(nodeName string, extIPs, podIP
 	return nil
 }
 
-// buildPodSNAT builds per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
-func buildPodSNAT(extIPs, podIPNets []*net.IPNet) ([]*nbdb.NAT, error) {
+// buildPodSNAT builds per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides, with
+// the source port ranges allocated by the node to the pod IPs in portRanges
+func buildPodSNAT(extIPs, podIPNets []*net.IPNet, portRanges map[string]string) ([]*nbdb.NAT, error) {
 	nats := make([]*nbdb.NAT, 0, len(extIPs)*len(podIPNets))
 	for _, podIPNet := range podIPNets {
 		fullMaskPodNet := &net.IPNet{
 			IP:   podIPNet.IP,
 			Mask: util.GetIPFullMask(podIPNet.IP),
 		}
+		portRange := portRanges[podIPNet.IP.String()]
 		if len(extIPs) == 0 {
-			nats = append(nats, libovsdbops.BuildSNAT(nil, fullMaskPodNet, "", nil))
+			nat := libovsdbops.BuildSNAT(nil, fullMaskPodNet, "", nil)
+			nat.ExternalPortRange = portRange
+			nats = append(nats, nat)
 		} else {
 			for _, gwIPNet := range extIPs {
 				if utilnet.IsIPv6CIDR(gwIPNet) != utilnet.IsIPv6CIDR(podIPNet) {
 					continue
 				}
-				nats = append(nats, libovsdbops.BuildSNAT(&gwIPNet.IP, fullMaskPodNet, "", nil))
+				nat := libovsdbops.BuildSNAT(&gwIPNet.IP, fullMaskPodNet, "", nil)
+				nat.ExternalPortRange = portRange
+				nats = append(nats, nat)
 			}
 		}
 	}
 	return nats, nil
 }
 
+// getPodSNATPortRangesGR returns the source port ranges allocated by a node(GR) to its pod IPs from its pod SNAT
+// port ranges annotation, none when the node does not allocate them
+func getPodSNATPortRangesGR(watchFactory *factory.WatchFactory, nodeName string) (map[string]string, error) {
+	node, err := watchFactory.GetNode(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %v", nodeName, err)
+	}
+	portRanges, err := util.ParseNodePodSNATPortRanges(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		return nil, err
+	}
+	return portRanges, nil
+}
+
+// updatePodSNATPortRanges updates the source port ranges of the per pod SNAT rules of the GR of the node to the
+// ranges allocated by the node to its pod IPs
+// used when disableSNATMultipleGWs=true
+func (oc *DefaultNetworkController) updatePodSNATPortRanges(node *kapi.Node) error {
+	portRanges, err := util.ParseNodePodSNATPortRanges(node)
+	if err != nil && !util.IsAnnotationNotSetError(err) {
+		return err
+	}
+	l3GWConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return fmt.Errorf("unable to parse node L3 gw annotation: %v", err)
+	}
+	extIPs := sets.New[string]()
+	for _, ipNet := range l3GWConfig.IPAddresses {
+		extIPs.Insert(ipNet.IP.String())
+	}
+	router := &nbdb.LogicalRouter{Name: oc.GetNetworkScopedGWRouterName(node.Name)}
+	routerNATs, err := libovsdbops.GetRouterNATs(oc.nbClient, router)
+	if errors.Is(err, libovsdbclient.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get NAT entries for router %s: %w", router.Name, err)
+	}
+	var nats []*nbdb.NAT
+	for _, nat := range routerNATs {
+		// the per pod SNATs translate a pod IP to a node IP, unlike the egress IP SNATs of the pods that are
+		// bound to their logical port
+		if nat.Type != nbdb.NATTypeSNAT || nat.LogicalPort != nil || !extIPs.Has(nat.ExternalIP) ||
+			utilnet.ParseIPSloppy(nat.LogicalIP) == nil {
+			continue
+		}
+		if portRange := portRanges[nat.LogicalIP]; portRange != nat.ExternalPortRange {
+			nat.ExternalPortRange = portRange
+			nats = append(nats, nat)
+		}
+	}
+	if len(nats) == 0 {
+		return nil
+	}
+	ops, err := libovsdbops.UpdateNATsPortRangeOps(oc.nbClient, nil, nats...)
+	if err != nil {
+		return fmt.Errorf("failed to update the port range of the SNAT rules of router %s: %v", router.Name, err)
+	}
+	if _, err = libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to update the port range of the SNAT rules of router %s: %v", router.Name, err)
+	}
+	return nil
+}
+
 // getExternalIPsGR returns all the externalIPs for a node(GR) from its l3 gateway annotation
 func getExternalIPsGR(watchFactory *factory.WatchFactory, nodeName string) ([]*net.IPNet, error) {
 	var err error
@@ -631,7 +701,7 @@ func getExternalIPsGR(watchFactory *factory.WatchFactory, nodeName string) ([]*n
 // deletePodSNATOps creates ovsdb operation that removes per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
 // used when disableSNATMultipleGWs=true
 func deletePodSNATOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, gwRouterName string, extIPs, podIPNets []*net.IPNet) ([]ovsdb.Operation, error) {
-	nats, err := buildPodSNAT(extIPs, podIPNets)
+	nats, err := buildPodSNAT(extIPs, podIPNets, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -647,8 +717,8 @@ func deletePodSNATOps(nbClient libovsdbclient.Client, ops []ovsdb.Operation, gwR
 
 // addOrUpdatePodSNAT adds or updates per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
 // used when disableSNATMultipleGWs=true
-func addOrUpdatePodSNAT(nbClient libovsdbclient.Client, gwRouterName string, extIPs, podIfAddrs []*net.IPNet) error {
-	nats, err := buildPodSNAT(extIPs, podIfAddrs)
+func addOrUpdatePodSNAT(nbClient libovsdbclient.Client, gwRouterName string, extIPs, podIfAddrs []*net.IPNet, portRanges map[string]string) error {
+	nats, err := buildPodSNAT(extIPs, podIfAddrs, portRanges)
 	if err != nil {
 		return err
 	}
@@ -664,9 +734,9 @@ func addOrUpdatePodSNAT(nbClient libovsdbclient.Client, gwRouterName string, ext
 // addOrUpdatePodSNATOps returns the operation that adds or updates per pod SNAT rules towards the nodeIP that are
 // applied to the GR where the pod resides
 // used when disableSNATMultipleGWs=true
-func addOrUpdatePodSNATOps(nbClient libovsdbclient.Client, gwRouterName string, extIPs, podIfAddrs []*net.IPNet, ops []ovsdb.Operation) ([]ovsdb.Operation, error) {
+func addOrUpdatePodSNATOps(nbClient libovsdbclient.Client, gwRouterName string, extIPs, podIfAddrs []*net.IPNet, portRanges map[string]string, ops []ovsdb.Operation) ([]ovsdb.Operation, error) {
 	router := &nbdb.LogicalRouter{Name: gwRouterName}
-	nats, err := buildPodSNAT(extIPs, podIfAddrs)
+	nats, err := buildPodSNAT(extIPs, podIfAddrs, portRanges)
 	if err != nil {
 		return nil, err
 	}
@@ -3072,7 +3072,7 @@ var _ = ginkgo.Describe("OVN Egress Gateway Operations", func() {
 
 				_, fullMaskPodNet, _ := net.ParseCIDR("10.128.1.3/32")
 				gomega.Expect(
-					addOrUpdatePodSNAT(fakeOvn.controller.nbClient, util.GetGatewayRouterFromNode(pod[0].Spec.NodeName), extIPs, []*net.IPNet{fullMaskPodNet}),
+					addOrUpdatePodSNAT(fakeOvn.controller.nbClient, util.GetGatewayRouterFromNode(pod[0].Spec.NodeName), extIPs, []*net.IPNet{fullMaskPodNet}, nil),
 				).To(gomega.Succeed())
 				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(finalNB))
 				finalNB = []libovsdbtest.TestData{
@@ -3104,6 +3104,70 @@ var _ = ginkgo.Describe("OVN Egress Gateway Operations", func() {
 	})
 })
 
+var _ = ginkgo.Describe("Pod SNAT port range", func() {
+	const nodeName = "node1"
+
+	ginkgo.BeforeEach(func() {
+		gomega.Expect(config.PrepareTestConfig()).To(gomega.Succeed())
+		config.Gateway.DisableSNATMultipleGWs = true
+	})
+
+	ginkgo.It("sets the port ranges allocated by the node on the pod SNATs", func() {
+		_, extIP, _ := net.ParseCIDR("172.18.0.2/24")
+		_, podIP, _ := net.ParseCIDR("10.128.1.5/32")
+		_, otherPodIP, _ := net.ParseCIDR("10.128.1.6/32")
+		nats, err := buildPodSNAT([]*net.IPNet{extIP}, []*net.IPNet{podIP, otherPodIP},
+			map[string]string{"10.128.1.5": "32768-33023"})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nats).To(gomega.HaveLen(2))
+		gomega.Expect(nats[0].LogicalIP).To(gomega.Equal("10.128.1.5"))
+		gomega.Expect(nats[0].ExternalPortRange).To(gomega.Equal("32768-33023"))
+		gomega.Expect(nats[1].LogicalIP).To(gomega.Equal("10.128.1.6"))
+		gomega.Expect(nats[1].ExternalPortRange).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("updates the port ranges of the pod SNATs when the node changes them", func() {
+		gwRouterName := types.GWRouterPrefix + nodeName
+		podSNAT := &nbdb.NAT{UUID: "pod-snat-UUID", Type: nbdb.NATTypeSNAT, ExternalIP: "172.18.0.2",
+			LogicalIP: "10.128.1.5", Options: map[string]string{"stateless": "false"}}
+		stalePodSNAT := &nbdb.NAT{UUID: "stale-pod-snat-UUID", Type: nbdb.NATTypeSNAT, ExternalIP: "172.18.0.2",
+			LogicalIP: "10.128.1.6", ExternalPortRange: "33024-33279", Options: map[string]string{"stateless": "false"}}
+		eipPort := "k8s-" + nodeName
+		eipSNAT := &nbdb.NAT{UUID: "eip-snat-UUID", Type: nbdb.NATTypeSNAT, ExternalIP: "172.18.0.100",
+			LogicalIP: "10.128.1.7", LogicalPort: &eipPort, Options: map[string]string{"stateless": "false"}}
+		router := &nbdb.LogicalRouter{Name: gwRouterName, UUID: gwRouterName + "-UUID",
+			Nat: []string{podSNAT.UUID, stalePodSNAT.UUID, eipSNAT.UUID}}
+		nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{podSNAT, stalePodSNAT, eipSNAT, router},
+		}, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer cleanup.Cleanup()
+		controller := &DefaultNetworkController{BaseNetworkController: BaseNetworkController{
+			CommonNetworkControllerInfo: CommonNetworkControllerInfo{nbClient: nbClient},
+			NetInfo:                     &util.DefaultNetInfo{},
+		}}
+
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Annotations: map[string]string{
+				util.OvnNodeChassisID: "1a3dfc82-2749-4931-9190-c30e7c0ecea3",
+				util.OvnNodeL3GatewayConfig: `{"default":{"mode":"shared","mac-address":"0a:58:0a:00:00:0a",` +
+					`"ip-addresses":["172.18.0.2/24"],"next-hops":["172.18.0.1"]}}`,
+				util.OvnNodePodSNATPortRanges: `{"10.128.1.5":"32768-33023","10.128.1.7":"33280-33535"}`,
+			},
+		}}
+		gomega.Expect(controller.updatePodSNATPortRanges(node)).To(gomega.Succeed())
+
+		expectedPodSNAT := podSNAT.DeepCopy()
+		expectedPodSNAT.ExternalPortRange = "32768-33023"
+		expectedStalePodSNAT := stalePodSNAT.DeepCopy()
+		expectedStalePodSNAT.ExternalPortRange = ""
+		gomega.Expect(nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			expectedPodSNAT, expectedStalePodSNAT, eipSNAT, router,
+		}))
+	})
+})
+
 // injectNode adds a valid node to the nodeinformer so the get
 // to understand if there are two bridged won't fail
 func injectNode(fakeOvn *FakeOVN) {
@@ -1656,11 +1656,15 @@ func (e *egressIPZoneController) addExternalGWPodSNATOps(ops []ovsdb.Operation,
 			if err != nil {
 				return nil, err
 			}
+			portRanges, err := getPodSNATPortRangesGR(e.watchFactory, pod.Spec.NodeName)
+			if err != nil {
+				return nil, err
+			}
 			podIPs, err := util.GetPodCIDRsWithFullMask(pod, &util.DefaultNetInfo{})
 			if err != nil {
 				return nil, err
 			}
-			ops, err = addOrUpdatePodSNATOps(e.nbClient, e.GetNetworkScopedGWRouterName(pod.Spec.NodeName), extIPs, podIPs, ops)
+			ops, err = addOrUpdatePodSNATOps(e.nbClient, e.GetNetworkScopedGWRouterName(pod.Spec.NodeName), extIPs, podIPs, portRanges, ops)
 			if err != nil {
 				return nil, err
 			}
@@ -302,8 +302,12 @@ func (gw *GatewayManager) GatewayInit(
 
 	var options map[string]string
 	if enableGatewayMTU {
+		gatewayMTU := config.Default.MTU
+		if config.Default.GatewayPatchMTU != 0 {
+			gatewayMTU = config.Default.GatewayPatchMTU
+		}
 		options = map[string]string{
-			"gateway_mtu": strconv.Itoa(config.Default.MTU),
+			"gateway_mtu": strconv.Itoa(gatewayMTU),
 		}
 	}
 	logicalRouterPort := nbdb.LogicalRouterPort{
@@ -454,9 +458,11 @@ func (gw *GatewayManager) GatewayInit(
 				types.TopologyExternalID: gw.netInfo.TopologyType(),
 			}
 		}
+		// several next hops of an IP family are ECMP routes, the node removes the dead ones from its
+		// gateway config
 		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
 			return item.OutputPort != nil && *item.OutputPort == *lrsr.OutputPort && item.IPPrefix == lrsr.IPPrefix &&
-				libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
+				libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy) && item.Nexthop == lrsr.Nexthop
 		}
 		err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(gw.nbClient, gatewayRouter, &lrsr,
 			p, &lrsr.Nexthop)
@@ -464,6 +470,22 @@ func (gw *GatewayManager) GatewayInit(
 			return fmt.Errorf("error creating static route %+v in GR %s: %v", lrsr, gatewayRouter, err)
 		}
 	}
+	// Remove the default gateway routes of the next hops that are not in the gateway config anymore
+	staleNextHopRoute := func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if len(nextHops) == 0 || item.OutputPort == nil || *item.OutputPort != externalRouterPort || item.Policy != nil ||
+			(item.IPPrefix != "0.0.0.0/0" && item.IPPrefix != "::/0") {
+			return false
+		}
+		for _, nextHop := range nextHops {
+			if item.Nexthop == nextHop.String() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(gw.nbClient, gatewayRouter, staleNextHopRoute); err != nil {
+		return fmt.Errorf("error removing stale default gateway routes from GR %s: %v", gatewayRouter, err)
+	}
 
 	// We need to add a route to the Gateway router's IP, on the
 	// cluster router, to ensure that the return traffic goes back
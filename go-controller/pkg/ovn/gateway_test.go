@@ -449,6 +449,83 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 		})
 
+		ginkgo.It("creates a default route per next hop and removes the routes of the removed next hops", func() {
+			expectedOVNClusterRouter := &nbdb.LogicalRouter{
+				UUID: types.OVNClusterRouter + "-UUID",
+				Name: types.OVNClusterRouter,
+			}
+			expectedNodeSwitch := &nbdb.LogicalSwitch{
+				UUID: nodeName + "-UUID",
+				Name: nodeName,
+			}
+			fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					&nbdb.LogicalSwitch{
+						UUID: types.OVNJoinSwitch + "-UUID",
+						Name: types.OVNJoinSwitch,
+					},
+					expectedOVNClusterRouter,
+					expectedNodeSwitch,
+					&nbdb.LoadBalancerGroup{
+						UUID: types.ClusterLBGroupName + "-UUID",
+						Name: types.ClusterLBGroupName,
+					},
+					&nbdb.LoadBalancerGroup{
+						UUID: types.ClusterSwitchLBGroupName + "-UUID",
+						Name: types.ClusterSwitchLBGroupName,
+					},
+					&nbdb.LoadBalancerGroup{
+						UUID: types.ClusterRouterLBGroupName + "-UUID",
+						Name: types.ClusterRouterLBGroupName,
+					},
+				},
+			})
+
+			clusterIPSubnets := ovntest.MustParseIPNets("10.128.0.0/14")
+			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
+			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
+			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
+			l3GatewayConfig := &util.L3GatewayConfig{
+				Mode:           config.GatewayModeShared,
+				ChassisID:      "SYSTEM-ID",
+				BridgeID:       "BRIDGE-ID",
+				InterfaceID:    "INTERFACE-ID",
+				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses:    ovntest.MustParseIPNets("169.255.33.2/24"),
+				NextHops:       ovntest.MustParseIPs("169.255.33.1", "169.255.33.3", "169.255.33.4"),
+				NodePortEnable: true,
+			}
+
+			var err error
+			fakeOvn.controller.defaultCOPPUUID, err = EnsureDefaultCOPP(fakeOvn.nbClient)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gatewayInit := func() {
+				err := newGatewayManager(fakeOvn, nodeName).GatewayInit(
+					nodeName,
+					clusterIPSubnets,
+					hostSubnets,
+					l3GatewayConfig,
+					false,
+					joinLRPIPs,
+					defLRPIPs,
+					extractExternalIPs(l3GatewayConfig),
+					true,
+				)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				expectedOVNClusterRouter.StaticRoutes = []string{}
+				expectedDatabaseState := generateGatewayInitExpectedNB([]libovsdbtest.TestData{}, expectedOVNClusterRouter,
+					expectedNodeSwitch, nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, joinLRPIPs, defLRPIPs,
+					false, "", "1400")
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+			}
+			gatewayInit()
+
+			// a dead next hop removed from the gateway config by the node
+			l3GatewayConfig.NextHops = ovntest.MustParseIPs("169.255.33.1", "169.255.33.4")
+			gatewayInit()
+		})
+
 		ginkgo.It("removes stale MAC and route for old masquerade subnet using auto-detect", func() {
 			routeUUID := "route-UUID"
 			outputPort := types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix + nodeName
@@ -4,6 +4,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -24,12 +25,14 @@ const (
 // UnimplementedHealthServer must be embedded to have forward compatible implementations.
 type healthServer struct {
 	UnimplementedHealthServer
+	// notServing makes the egress ip service report not serving
+	notServing *atomic.Bool
 }
 
-func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
+func (hs healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
 	response := HealthCheckResponse{}
 
-	if req.GetService() == serviceEgressIPNode {
+	if req.GetService() == serviceEgressIPNode && !hs.notServing.Load() {
 		response.Status = HealthCheckResponse_SERVING
 	} else {
 		response.Status = HealthCheckResponse_NOT_SERVING
@@ -41,6 +44,9 @@ func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCh
 // the egress ip health check service.
 type EgressIPHealthServer interface {
 	Run(stopCh <-chan struct{})
+	// SetServing sets whether the egress ip service reports serving, so that the egress IPs are moved
+	// away from the node while it does not
+	SetServing(serving bool)
 }
 type egressIPHealthServer struct {
 	// Management port bound by server
@@ -48,6 +54,8 @@ type egressIPHealthServer struct {
 
 	// EgressIP Node reachability gRPC port (0 means it should use dial instead)
 	healthCheckPort int
+
+	notServing atomic.Bool
 }
 
 // NewEgressIPHealthServer allocates an Egress IP health server.
@@ -103,7 +111,7 @@ func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	go func() {
 		defer wg.Done()
 
-		RegisterHealthServer(grpcServer, &healthServer{})
+		RegisterHealthServer(grpcServer, &healthServer{notServing: &ehs.notServing})
 		klog.Infof("Starting Egress IP Health Server on %s:%d", ehs.nodeMgmtIP.String(), ehs.healthCheckPort)
 		if err := grpcServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			klog.Fatalf("Egress IP Health checking server failed: %v", err)
@@ -119,6 +127,11 @@ func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	klog.Info("Egress IP Health Server is shutdown")
 }
 
+// SetServing sets whether the egress ip service reports serving
+func (ehs *egressIPHealthServer) SetServing(serving bool) {
+	ehs.notServing.Store(!serving)
+}
+
 // EgressIPHealthClient interface offers the functions needed for connecting to
 // the egress ip health check service.
 type EgressIPHealthClient interface {
@@ -0,0 +1,21 @@
+package healthcheck
+
+import (
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// The messages of health.proto are the ones of the gRPC health checking protocol, registered by the
+// grpc_health_v1 package the OTLP trace exporter links: they can't be registered a second time from code
+// generated for this package, the protobuf registry panics on the name conflict.
+type (
+	HealthCheckRequest                = healthpb.HealthCheckRequest
+	HealthCheckResponse               = healthpb.HealthCheckResponse
+	HealthCheckResponse_ServingStatus = healthpb.HealthCheckResponse_ServingStatus
+)
+
+const (
+	HealthCheckResponse_UNKNOWN         = healthpb.HealthCheckResponse_UNKNOWN
+	HealthCheckResponse_SERVING         = healthpb.HealthCheckResponse_SERVING
+	HealthCheckResponse_NOT_SERVING     = healthpb.HealthCheckResponse_NOT_SERVING
+	HealthCheckResponse_SERVICE_UNKNOWN = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+)
@@ -243,7 +243,9 @@ func (oc *DefaultNetworkController) updateNamespace(old, newer *kapi.Namespace)
 				} else {
 					if extIPs, err := getExternalIPsGR(oc.watchFactory, pod.Spec.NodeName); err != nil {
 						errors = append(errors, err)
-					} else if err = addOrUpdatePodSNAT(oc.nbClient, oc.GetNetworkScopedGWRouterName(pod.Spec.NodeName), extIPs, podAnnotation.IPs); err != nil {
+					} else if portRanges, err := getPodSNATPortRangesGR(oc.watchFactory, pod.Spec.NodeName); err != nil {
+						errors = append(errors, err)
+					} else if err = addOrUpdatePodSNAT(oc.nbClient, oc.GetNetworkScopedGWRouterName(pod.Spec.NodeName), extIPs, podAnnotation.IPs, portRanges); err != nil {
 						errors = append(errors, err)
 					}
 				}
@@ -281,7 +281,7 @@ func (oc *SecondaryLocalnetNetworkController) Init() error {
 		Addresses: []string{"unknown"},
 		Type:      "localnet",
 		Options: map[string]string{
-			"network_name": oc.GetNetworkName(),
+			"network_name": oc.PhysicalNetworkName(),
 		},
 	}
 	intVlanID := int(oc.Vlan())
@@ -9,6 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -133,7 +134,7 @@ func (zch *ZoneChassisHandler) createOrUpdateNodeChassis(node *corev1.Node, isRe
 			node.Name, parsedErr)
 	}
 
-	encapIp, err := util.GetNodeEncapIp(node)
+	encapIPs, err := util.ParseNodeEncapIPs(node)
 	if err != nil {
 		return fmt.Errorf("failed to parse node %s encap IP %w", node.Name, err)
 	}
@@ -146,17 +147,29 @@ func (zch *ZoneChassisHandler) createOrUpdateNodeChassis(node *corev1.Node, isRe
 		},
 	}
 
-	encap := sbdb.Encap{
-		ChassisName: chassisID,
-		IP:          encapIp,
-		Type:        "geneve",
-		Options:     map[string]string{"csum": "true"},
-	}
+	// a node of a dual-stack cluster may have a tunnel endpoint per IP family, the tunnels to the node
+	// are only created with the endpoints of the IP families of the cluster
+	var encaps []*sbdb.Encap
+	for _, encapIP := range encapIPs {
+		if (utilnet.IsIPv6(encapIP) && !config.IPv6Mode) || (!utilnet.IsIPv6(encapIP) && !config.IPv4Mode) {
+			continue
+		}
+		encap := &sbdb.Encap{
+			ChassisName: chassisID,
+			IP:          encapIP.String(),
+			Type:        "geneve",
+			Options:     map[string]string{"csum": "true"},
+		}
 
-	// set the geneve port if using something else than default
-	if config.Default.EncapPort != config.DefaultEncapPort {
-		encap.Options["dst_port"] = strconv.FormatUint(uint64(config.Default.EncapPort), 10)
+		// set the geneve port if using something else than default
+		if config.Default.EncapPort != config.DefaultEncapPort {
+			encap.Options["dst_port"] = strconv.FormatUint(uint64(config.Default.EncapPort), 10)
+		}
+		encaps = append(encaps, encap)
+	}
+	if len(encaps) == 0 {
+		return fmt.Errorf("node %s has no encap IP of the cluster IP families: %v", node.Name, encapIPs)
 	}
 
-	return libovsdbops.CreateOrUpdateChassis(zch.sbClient, &chassis, &encap)
+	return libovsdbops.CreateOrUpdateChassis(zch.sbClient, &chassis, encaps...)
 }
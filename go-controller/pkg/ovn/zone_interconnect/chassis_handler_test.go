@@ -184,6 +184,60 @@ var _ = ginkgo.Describe("Zone Interconnect Chassis Operations", func() {
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	ginkgo.It("creates an encap per tunnel endpoint of the cluster IP families", func() {
+		app.Action = func(ctx *cli.Context) error {
+			dbSetup := libovsdbtest.TestSetup{
+				SBData: initialSBDB,
+			}
+
+			_, err := config.InitConfig(ctx, nil, nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			config.Kubernetes.HostNetworkNamespace = ""
+
+			var libovsdbOvnSBClient libovsdbclient.Client
+			_, libovsdbOvnSBClient, libovsdbCleanup, err = libovsdbtest.NewNBSBTestHarness(dbSetup)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			zoneChassisHandler := NewZoneChassisHandler(libovsdbOvnSBClient)
+			testNode3.Annotations["k8s.ovn.org/encap-ip"] = "10.0.0.12,fd00::12"
+
+			// the IPv6 endpoint is ignored in an IPv4 cluster
+			config.IPv4Mode, config.IPv6Mode = true, false
+			err = zoneChassisHandler.AddRemoteZoneNode(&testNode3)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			nodeCh, err := libovsdbops.GetChassis(libovsdbOvnSBClient, &node3Chassis)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(nodeCh.Encaps).To(gomega.HaveLen(1))
+
+			config.IPv6Mode = true
+			err = zoneChassisHandler.AddRemoteZoneNode(&testNode3)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			nodeCh, err = libovsdbops.GetChassis(libovsdbOvnSBClient, &node3Chassis)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(nodeCh.Encaps).To(gomega.HaveLen(2))
+			for _, ip := range []string{"10.0.0.12", "fd00::12"} {
+				encap := &sbdb.Encap{Type: "geneve", IP: ip}
+				err = libovsdbOvnSBClient.Get(context.Background(), encap)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(nodeCh.Encaps).To(gomega.ContainElement(encap.UUID))
+			}
+
+			config.IPv4Mode, config.IPv6Mode = false, false
+			err = zoneChassisHandler.AddRemoteZoneNode(&testNode3)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("node node3 has no encap IP of the cluster IP families")))
+			return nil
+		}
+
+		err := app.Run([]string{
+			app.Name,
+			"-cluster-subnets=" + clusterCIDR,
+			"-init-cluster-manager",
+			"-zone-join-switch-subnets=" + joinSubnetCIDR,
+			"-enable-interconnect",
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	ginkgo.It("Move chassis zone", func() {
 		app.Action = func(ctx *cli.Context) error {
 			dbSetup := libovsdbtest.TestSetup{
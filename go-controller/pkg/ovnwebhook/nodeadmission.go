@@ -3,7 +3,10 @@ package ovnwebhook
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/exp/maps"
@@ -20,8 +23,19 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
-// checkNodeAnnot defines additional checks for the allowed annotations
-type checkNodeAnnot func(v annotationChange, nodeName string) error
+// checkNodeAnnot defines additional checks for the allowed annotations, oldNode and newNode are the node before
+// and after the update
+type checkNodeAnnot func(v annotationChange, nodeName string, oldNode, newNode *corev1.Node) error
+
+// zoneMigrationAllowed returns whether a zone annotation change follows the zone migration requested for the node:
+// the zone can be set to the requested zone, and changed from it to another zone when the migration is rolled back
+func zoneMigrationAllowed(key string, v annotationChange, oldNode, newNode *corev1.Node) bool {
+	migration, err := util.ParseNodeZoneMigration(newNode)
+	if err != nil || v.action == removed {
+		return false
+	}
+	return v.value == migration.Zone || oldNode.Annotations[key] == migration.Zone
+}
 
 // commonNodeAnnotationChecks holds annotations allowed for ovnkube-node:<nodeName> users in non-IC and IC environments
 var commonNodeAnnotationChecks = map[string]checkNodeAnnot{
@@ -34,7 +48,7 @@ var commonNodeAnnotationChecks = map[string]checkNodeAnnot{
 	util.OvnNodeGatewayMtuSupport:          nil,
 	util.OvnNodeManagementPort:             nil,
 	util.OvnNodeEncapIp:                    nil,
-	util.OvnNodeChassisID: func(v annotationChange, nodeName string) error {
+	util.OvnNodeChassisID: func(v annotationChange, nodeName string, _, _ *corev1.Node) error {
 		if v.action == removed {
 			return fmt.Errorf("%s cannot be removed", util.OvnNodeChassisID)
 		}
@@ -43,27 +57,161 @@ var commonNodeAnnotationChecks = map[string]checkNodeAnnot{
 		}
 		return nil
 	},
-	util.OvnNodeZoneName: func(v annotationChange, nodeName string) error {
+	util.OvnNodeChassisInfo: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		info, err := util.ParseNodeChassisInfo(newNode)
+		if err != nil {
+			return err
+		}
+		// the chassis is the one of the node chassis ID
+		if chassisID, ok := newNode.Annotations[util.OvnNodeChassisID]; ok && info.ChassisID != chassisID {
+			return fmt.Errorf("chassis-id %q of %s does not match %s %q", info.ChassisID, util.OvnNodeChassisInfo,
+				util.OvnNodeChassisID, chassisID)
+		}
+		for _, encap := range info.Encaps {
+			if net.ParseIP(encap.IP) == nil {
+				return fmt.Errorf("invalid encap IP %q in %s", encap.IP, util.OvnNodeChassisInfo)
+			}
+		}
+		return nil
+	},
+	util.OvnNodeHwOffloadStatus: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		_, err := util.ParseNodeHwOffloadStatus(newNode)
+		return err
+	},
+	util.OvnNodeOVSUpgradeQuiesceStatus: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		status, err := util.ParseNodeOVSUpgradeQuiesceStatus(newNode)
+		if err != nil {
+			return err
+		}
+		switch status.Phase {
+		case util.OVSUpgradeQuiesced, util.OVSUpgradeTimedOut, util.OVSUpgradeFailed:
+			return nil
+		}
+		return fmt.Errorf("invalid phase %q in %s", status.Phase, util.OvnNodeOVSUpgradeQuiesceStatus)
+	},
+	util.OvnNodePodSNATPortRanges: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		portRanges, err := util.ParseNodePodSNATPortRanges(newNode)
+		if err != nil {
+			return err
+		}
+		return checkPodSNATPortRanges(portRanges)
+	},
+	util.DPUGatewayIntentAnnot: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		_, err := util.ParseNodeDPUGatewayIntent(newNode)
+		return err
+	},
+	util.DPUTopologyAnnot: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		_, err := util.ParseNodeDPUTopology(newNode)
+		return err
+	},
+	util.OvnNodeZoneMigrationStatus: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		_, err := util.ParseNodeZoneMigrationStatus(newNode)
+		return err
+	},
+	util.OvnNodeZoneName: func(v annotationChange, nodeName string, oldNode, newNode *corev1.Node) error {
 		// it is allowed for the annotation to be set to "global" or <nodeName> initially
 		if (v.action == added || v.action == changed) &&
 			(v.value == types.OvnDefaultZone || v.value == nodeName) {
 			return nil
 		}
+		// or to follow the zone migration requested for the node
+		if zoneMigrationAllowed(util.OvnNodeZoneName, v, oldNode, newNode) {
+			return nil
+		}
 
 		return fmt.Errorf("%s can only be set to %s or %s, it cannot be removed", util.OvnNodeZoneName, types.OvnDefaultZone, nodeName)
 	},
 }
 
+// checkPodSNATPortRanges checks that the pod SNAT port ranges are valid port ranges of pod IPs and that the ranges
+// of an IP family don't overlap, the pod IPs of a family share the same ranges once all of them are allocated
+func checkPodSNATPortRanges(portRanges map[string]string) error {
+	type portRange struct{ start, end int }
+	ranges := map[bool]sets.Set[portRange]{false: sets.New[portRange](), true: sets.New[portRange]()}
+	for ip, value := range portRanges {
+		podIP := net.ParseIP(ip)
+		if podIP == nil {
+			return fmt.Errorf("invalid pod IP %q in %s", ip, util.OvnNodePodSNATPortRanges)
+		}
+		startStr, endStr, _ := strings.Cut(value, "-")
+		start, startErr := strconv.Atoi(startStr)
+		end, endErr := strconv.Atoi(endStr)
+		if startErr != nil || endErr != nil || start < 1 || start > end || end > 65535 {
+			return fmt.Errorf("invalid port range %q of pod IP %s in %s", value, ip, util.OvnNodePodSNATPortRanges)
+		}
+		ranges[podIP.To4() == nil].Insert(portRange{start: start, end: end})
+	}
+	for _, familyRanges := range ranges {
+		sorted := familyRanges.UnsortedList()
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+		for i := 1; i < len(sorted); i++ {
+			if sorted[i].start <= sorted[i-1].end {
+				return fmt.Errorf("port ranges %d-%d and %d-%d overlap in %s", sorted[i-1].start, sorted[i-1].end,
+					sorted[i].start, sorted[i].end, util.OvnNodePodSNATPortRanges)
+			}
+		}
+	}
+	return nil
+}
+
+// obsoleteNodeAnnotationChecks holds annotations of former versions ovnkube-node:<nodeName> users can only remove
+var obsoleteNodeAnnotationChecks = func() map[string]checkNodeAnnot {
+	checks := map[string]checkNodeAnnot{}
+	for _, keys := range util.ObsoleteNodeAnnotations {
+		for _, key := range keys {
+			checks[key] = func(v annotationChange, _ string, _, _ *corev1.Node) error {
+				if v.action == removed {
+					return nil
+				}
+				return fmt.Errorf("%s is obsolete, it can only be removed", key)
+			}
+		}
+	}
+	return checks
+}()
+
 // interconnectNodeAnnotationChecks holds annotations allowed for ovnkube-node:<nodeName> users in IC environments
 var interconnectNodeAnnotationChecks = map[string]checkNodeAnnot{
-	util.OvnNodeMigratedZoneName: func(v annotationChange, nodeName string) error {
+	util.OvnNodeMigratedZoneName: func(v annotationChange, nodeName string, oldNode, newNode *corev1.Node) error {
 		// it is allowed for the annotation to be set to <nodeName>
 		if (v.action == added || v.action == changed) && v.value == nodeName {
 			return nil
 		}
+		// or to follow the zone migration of the node
+		if zoneMigrationAllowed(util.OvnNodeMigratedZoneName, v, oldNode, newNode) {
+			return nil
+		}
 
 		return fmt.Errorf("%s can only be set to %s, it cannot be removed", util.OvnNodeMigratedZoneName, nodeName)
 	},
+	util.OvnNodeZoneReachability: func(v annotationChange, _ string, _, newNode *corev1.Node) error {
+		if v.action == removed {
+			return nil
+		}
+		_, err := util.ParseNodeZoneReachability(newNode)
+		return err
+	},
 }
 
 // hybridOverlayNodeAnnotationChecks holds annotations allowed for ovnkube-node:<nodeName> users hybrid overlay environments
@@ -72,6 +220,17 @@ var hybridOverlayNodeAnnotationChecks = map[string]checkNodeAnnot{
 	hotypes.HybridOverlayDRIP:  nil,
 }
 
+// nodeLabelChecks holds labels allowed for ovnkube-node:<nodeName> users
+var nodeLabelChecks = map[string]checkNodeAnnot{
+	util.OvnNodeEgressReadyLabel: func(v annotationChange, _ string, _, _ *corev1.Node) error {
+		// ovnkube-node reflects whether it runs the egress controllers with "true" or "false"
+		if v.action == removed || v.value == "true" || v.value == "false" {
+			return nil
+		}
+		return fmt.Errorf("%s can only be set to true or false", util.OvnNodeEgressReadyLabel)
+	},
+}
+
 type NodeAdmission struct {
 	annotationChecks  map[string]checkNodeAnnot
 	annotationKeys    sets.Set[string]
@@ -81,6 +240,7 @@ type NodeAdmission struct {
 func NewNodeAdmissionWebhook(enableInterconnect, enableHybridOverlay bool, extraAllowedUsers ...string) *NodeAdmission {
 	checks := make(map[string]checkNodeAnnot)
 	maps.Copy(checks, commonNodeAnnotationChecks)
+	maps.Copy(checks, obsoleteNodeAnnotationChecks)
 	if enableInterconnect {
 		maps.Copy(checks, interconnectNodeAnnotationChecks)
 	}
@@ -139,7 +299,7 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 
 	for _, key := range changedKeys {
 		if check := p.annotationChecks[key]; check != nil {
-			if err := check(changes[key], nodeName); err != nil {
+			if err := check(changes[key], nodeName, oldNode, newNode); err != nil {
 				return nil, fmt.Errorf("user: %q is not allowed to set %s on node %q: %v", req.UserInfo.Username, key, newNode.Name, err)
 			}
 		}
@@ -162,13 +322,26 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 			sets.New[string](changedKeys...).Difference(p.annotationKeys).UnsortedList())
 	}
 
-	// Verify that nothing but the annotations changed.
+	labelChanges := mapDiff(oldNode.Labels, newNode.Labels)
+	for key, change := range labelChanges {
+		check, ok := nodeLabelChecks[key]
+		if !ok {
+			return nil, fmt.Errorf("ovnkube-node on node: %q is not allowed to modify anything other than annotations", nodeName)
+		}
+		if err := check(change, nodeName, oldNode, newNode); err != nil {
+			return nil, fmt.Errorf("user: %q is not allowed to set label %s on node %q: %v", req.UserInfo.Username, key, newNode.Name, err)
+		}
+	}
+
+	// Verify that nothing but the annotations and the allowed labels changed.
 	// Since ovnkube-node only has the node/status permissions, it is enough to check .Status and .ObjectMeta only.
 	// Ignore .ManagedFields fields which are modified on every update.
 	oldNodeShallowCopy := oldNode
 	newNodeShallowCopy := newNode
 	oldNodeShallowCopy.Annotations = nil
 	newNodeShallowCopy.Annotations = nil
+	oldNodeShallowCopy.Labels = nil
+	newNodeShallowCopy.Labels = nil
 	oldNodeShallowCopy.ManagedFields = nil
 	newNodeShallowCopy.ManagedFields = nil
 
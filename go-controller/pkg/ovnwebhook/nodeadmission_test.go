@@ -2,6 +2,7 @@ package ovnwebhook
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -9,6 +10,7 @@ import (
 
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/csrapprover"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"golang.org/x/exp/maps"
 	v1 "k8s.io/api/admission/v1"
@@ -64,6 +66,11 @@ var additionalUserName = fmt.Sprintf("%s:%s", additionalNamePrefix, nodeName)
 
 func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 	adm := NewNodeAdmissionWebhook(false, false)
+	// the annotations failing to be parsed are rejected with the error of their parser
+	invalidNode := func(key, value string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName, Annotations: map[string]string{key: value}}}
+	}
+	_, hwOffloadStatusErr := util.ParseNodeHwOffloadStatus(invalidNode(util.OvnNodeHwOffloadStatus, "enabled"))
 	tests := []struct {
 		name        string
 		ctx         context.Context
@@ -72,86 +79,454 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			name: "allow if user is not ovnkube-node and not changing ovnkube-node annotations",
+			name: "allow if user is not ovnkube-node and not changing ovnkube-node annotations",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: "system:nodes:node",
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{"key": "old"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{"key": "new"},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "error out if different user tries to set ovnkube-node annotations",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: "system:nodes:node",
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs: "old"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs: "new"},
+				},
+			},
+			expectedErr: fmt.Errorf("user %q is not allowed to set the following annotations on node: %q: %v", "system:nodes:node", nodeName, []string{util.OVNNodeHostCIDRs}),
+		},
+		{
+			name: "error out if the request is not in context",
+			ctx:  context.TODO(),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{"new": "value"},
+				},
+			},
+			expectedErr: errors.New("admission.Request not found in context"),
+		},
+		{
+			name: "ovnkube-node cannot modify annotations on different nodes",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName + "_rougeOne",
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs: "old"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs: "new"},
+				},
+			},
+			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to modify nodes %q annotations", nodeName+"_rougeOne", nodeName),
+		},
+		{
+			name: "ovnkube-node cannot modify annotations that do not belong to it",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs + "bad": "old"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OVNNodeHostCIDRs + "bad": "new"},
+				},
+			},
+			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to set the following annotations: %v", nodeName, []string{util.OVNNodeHostCIDRs + "bad"}),
+		},
+		{
+			name: "ovnkube-node can add util.OvnNodeChassisID",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"}},
+			},
+		},
+		{
+			name: "ovnkube-node cannot remove util.OvnNodeChassisID",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s cannot be removed", userName, util.OvnNodeChassisID, nodeName, util.OvnNodeChassisID),
+		},
+		{
+			name: "ovnkube-node cannot change util.OvnNodeChassisID once set",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisIDInvalid"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s cannot be changed once set", userName, util.OvnNodeChassisID, nodeName, util.OvnNodeChassisID),
+		},
+		{
+			name: "ovnkube-node can set util.OvnNodeChassisInfo",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID", util.OvnNodeChassisInfo: `{"chassis-id":"chassisID","encaps":[{"type":"geneve","ip":"192.168.122.156"}]}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set util.OvnNodeChassisInfo of another chassis",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID", util.OvnNodeChassisInfo: `{"chassis-id":"otherChassisID"}`},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: chassis-id %q of %s does not match %s %q", userName, util.OvnNodeChassisInfo, nodeName, "otherChassisID", util.OvnNodeChassisInfo, util.OvnNodeChassisID, "chassisID"),
+		},
+		{
+			name: "ovnkube-node cannot set util.OvnNodeChassisInfo with an invalid encap IP",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID", util.OvnNodeChassisInfo: `{"chassis-id":"chassisID","encaps":[{"type":"geneve","ip":"node1"}]}`},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: invalid encap IP %q in %s", userName, util.OvnNodeChassisInfo, nodeName, "node1", util.OvnNodeChassisInfo),
+		},
+		{
+			name: "ovnkube-node can set util.OvnNodeHwOffloadStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeHwOffloadStatus: `{"enabled":true,"functioning":true,"tc-policy":"none"}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set an invalid util.OvnNodeHwOffloadStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeHwOffloadStatus: "enabled"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %v", userName, util.OvnNodeHwOffloadStatus, nodeName, hwOffloadStatusErr),
+		},
+		{
+			name: "ovnkube-node can set util.OvnNodeOVSUpgradeQuiesceStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeOVSUpgradeQuiesceStatus: `{"id":"ovs-3.4.1","phase":"Quiesced","resume-at":"2024-05-02T10:10:00Z"}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node can remove util.OvnNodeOVSUpgradeQuiesceStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeOVSUpgradeQuiesceStatus: `{"id":"ovs-3.4.1","phase":"Quiesced"}`},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set util.OvnNodeOVSUpgradeQuiesceStatus with an invalid phase",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeOVSUpgradeQuiesceStatus: `{"id":"ovs-3.4.1","phase":"Upgraded"}`},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: invalid phase %q in %s", userName, util.OvnNodeOVSUpgradeQuiesceStatus, nodeName, "Upgraded", util.OvnNodeOVSUpgradeQuiesceStatus),
+		},
+		{
+			name: "ovnkube-node can set util.OvnNodePodSNATPortRanges",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodePodSNATPortRanges: `{"10.244.0.5":"32768-33023","10.244.0.6":"33024-33279","10.244.0.7":"32768-33023","fd00:10:244::5":"32768-33023"}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set overlapping util.OvnNodePodSNATPortRanges",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodePodSNATPortRanges: `{"10.244.0.5":"32768-33023","10.244.0.6":"33000-33255"}`},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: port ranges 32768-33023 and 33000-33255 overlap in %s", userName, util.OvnNodePodSNATPortRanges, nodeName, util.OvnNodePodSNATPortRanges),
+		},
+		{
+			name: "ovnkube-node cannot set an invalid util.OvnNodePodSNATPortRanges port range",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
-					Username: "system:nodes:node",
+					Username: userName,
 				}},
 			}),
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:   nodeName,
-					Labels: map[string]string{"key": "old"},
+					Name:        nodeName,
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:   nodeName,
-					Labels: map[string]string{"key": "new"},
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodePodSNATPortRanges: `{"10.244.0.5":"33023-32768"}`},
 				},
 			},
-			expectedErr: nil,
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: invalid port range %q of pod IP %s in %s", userName, util.OvnNodePodSNATPortRanges, nodeName, "33023-32768", "10.244.0.5", util.OvnNodePodSNATPortRanges),
 		},
 		{
-			name: "error out if different user tries to set ovnkube-node annotations",
+			name: "ovnkube-node can set util.DPUGatewayIntentAnnot",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
-					Username: "system:nodes:node",
+					Username: userName,
 				}},
 			}),
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs: "old"},
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs: "new"},
+					Annotations: map[string]string{util.DPUGatewayIntentAnnot: `{"vips":["172.18.0.100"]}`},
 				},
 			},
-			expectedErr: fmt.Errorf("user %q is not allowed to set the following annotations on node: %q: %v", "system:nodes:node", nodeName, []string{util.OVNNodeHostCIDRs}),
 		},
 		{
-			name: "error out if the request is not in context",
-			ctx:  context.TODO(),
+			name: "ovnkube-node cannot set an invalid util.DPUGatewayIntentAnnot",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: nodeName,
+					Name:        nodeName,
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{"new": "value"},
+					Annotations: map[string]string{util.DPUGatewayIntentAnnot: `{"vips":["vip"]}`},
 				},
 			},
-			expectedErr: errors.New("admission.Request not found in context"),
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: invalid VIP %q in %s annotation for node %q", userName, util.DPUGatewayIntentAnnot, nodeName, "vip", util.DPUGatewayIntentAnnot, nodeName),
 		},
 		{
-			name: "ovnkube-node cannot modify annotations on different nodes",
+			name: "ovnkube-node can set util.DPUTopologyAnnot",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
-					Username: userName + "_rougeOne",
+					Username: userName,
 				}},
 			}),
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs: "old"},
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs: "new"},
+					Annotations: map[string]string{util.DPUTopologyAnnot: `{"dpu-host":{}}`},
 				},
 			},
-			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to modify nodes %q annotations", nodeName+"_rougeOne", nodeName),
 		},
 		{
-			name: "ovnkube-node cannot modify annotations that do not belong to it",
+			name: "ovnkube-node cannot set an invalid util.DPUTopologyAnnot",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
 					Username: userName,
@@ -160,19 +535,19 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs + "bad": "old"},
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OVNNodeHostCIDRs + "bad": "new"},
+					Annotations: map[string]string{util.DPUTopologyAnnot: `{"full":{}}`},
 				},
 			},
-			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to set the following annotations: %v", nodeName, []string{util.OVNNodeHostCIDRs + "bad"}),
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: invalid mode %q in %s annotation for node %q", userName, util.DPUTopologyAnnot, nodeName, "full", util.DPUTopologyAnnot, nodeName),
 		},
 		{
-			name: "ovnkube-node can add util.OvnNodeChassisID",
+			name: "ovnkube-node can remove the obsolete types.OvnK8sTopoAnno",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
 					Username: userName,
@@ -180,17 +555,19 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 			}),
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: nodeName,
+					Name:        nodeName,
+					Annotations: map[string]string{types.OvnK8sTopoAnno: "7"},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"}},
+					Annotations: map[string]string{},
+				},
 			},
 		},
 		{
-			name: "ovnkube-node cannot remove util.OvnNodeChassisID",
+			name: "ovnkube-node cannot set the obsolete types.OvnK8sTopoAnno",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
 					Username: userName,
@@ -199,19 +576,19 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+					Annotations: map[string]string{},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{},
+					Annotations: map[string]string{types.OvnK8sTopoAnno: "7"},
 				},
 			},
-			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s cannot be removed", userName, util.OvnNodeChassisID, nodeName, util.OvnNodeChassisID),
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s is obsolete, it can only be removed", userName, types.OvnK8sTopoAnno, nodeName, types.OvnK8sTopoAnno),
 		},
 		{
-			name: "ovnkube-node cannot change util.OvnNodeChassisID once set",
+			name: "ovnkube-node can remove util.OvnNodeZoneMigrationStatus without interconnect",
 			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
 				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
 					Username: userName,
@@ -220,16 +597,15 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 			oldObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OvnNodeChassisID: "chassisID"},
+					Annotations: map[string]string{util.OvnNodeZoneMigrationStatus: `{"zone":"zone-b","phase":"Completed"}`},
 				},
 			},
 			newObj: &corev1.Node{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        nodeName,
-					Annotations: map[string]string{util.OvnNodeChassisID: "chassisIDInvalid"},
+					Annotations: map[string]string{},
 				},
 			},
-			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s cannot be changed once set", userName, util.OvnNodeChassisID, nodeName, util.OvnNodeChassisID),
 		},
 		{
 			name: "ovnkube-node can add util.OvnNodeZoneName with \"global\" value",
@@ -410,6 +786,46 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("ovnkube-node on node: %q is not allowed to modify anything other than annotations", nodeName),
 		},
+		{
+			name: "ovnkube-node can set util.OvnNodeEgressReadyLabel",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{"key": "value", util.OvnNodeEgressReadyLabel: "true"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{"key": "value", util.OvnNodeEgressReadyLabel: "false"},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set util.OvnNodeEgressReadyLabel to an invalid value",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{util.OvnNodeEgressReadyLabel: "maybe"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set label %s on node %q: %s can only be set to true or false", userName, util.OvnNodeEgressReadyLabel, nodeName, util.OvnNodeEgressReadyLabel),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -423,6 +839,9 @@ func TestNodeAdmission_ValidateUpdate(t *testing.T) {
 }
 func TestNodeAdmission_ValidateUpdateIC(t *testing.T) {
 	adm := NewNodeAdmissionWebhook(true, false)
+	zoneMigration := `{"zone":"zone-b","sb-address":"ssl:10.0.0.2:6642"}`
+	_, zoneReachabilityErr := util.ParseNodeZoneReachability(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName,
+		Annotations: map[string]string{util.OvnNodeZoneReachability: "zone-b"}}})
 	tests := []struct {
 		name        string
 		ctx         context.Context
@@ -450,6 +869,189 @@ func TestNodeAdmission_ValidateUpdateIC(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s can only be set to %s, it cannot be removed", userName, util.OvnNodeMigratedZoneName, nodeName, util.OvnNodeMigratedZoneName, nodeName),
 		},
+		{
+			name: "ovnkube-node can set util.OvnNodeZoneReachability",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneReachability: `{"zone-b":{"reachable":false}}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node can remove util.OvnNodeZoneReachability",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneReachability: `{"zone-b":{"reachable":true}}`},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set an invalid util.OvnNodeZoneReachability",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneReachability: "zone-b"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %v", userName, util.OvnNodeZoneReachability, nodeName, zoneReachabilityErr),
+		},
+		{
+			name: "ovnkube-node can change util.OvnNodeZoneName to the zone of its migration",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-a"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-b"},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node can roll back util.OvnNodeZoneName from the zone of its migration",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-b"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-a"},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot change util.OvnNodeZoneName to another zone than the zone of its migration",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-a"},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneName: "zone-c"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: %s can only be set to global or %s, it cannot be removed", userName, util.OvnNodeZoneName, nodeName, util.OvnNodeZoneName, nodeName),
+		},
+		{
+			name: "ovnkube-node can change util.OvnNodeMigratedZoneName to the zone of its migration",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeMigratedZoneName: nodeName},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeMigratedZoneName: "zone-b"},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node can set util.OvnNodeZoneMigrationStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneMigrationStatus: `{"zone":"zone-b","phase":"Completed"}`},
+				},
+			},
+		},
+		{
+			name: "ovnkube-node cannot set an invalid util.OvnNodeZoneMigrationStatus",
+			ctx: admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: v1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{
+					Username: userName,
+				}},
+			}),
+			oldObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration},
+				},
+			},
+			newObj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        nodeName,
+					Annotations: map[string]string{util.OvnNodeZoneMigration: zoneMigration, util.OvnNodeZoneMigrationStatus: "Completed"},
+				},
+			},
+			expectedErr: fmt.Errorf("user: %q is not allowed to set %s on node %q: failed to unmarshal %s annotation %q for node %q: %v", userName, util.OvnNodeZoneMigrationStatus, nodeName, util.OvnNodeZoneMigrationStatus, "Completed", nodeName, json.Unmarshal([]byte("Completed"), &util.ZoneMigrationStatus{})),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
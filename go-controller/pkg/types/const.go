@@ -162,6 +162,10 @@ const (
 	NodeModeDPU     = "dpu"
 	NodeModeDPUHost = "dpu-host"
 
+	// OVNKube-Node egress roles, an egress node runs the egress IP and egress service controllers
+	NodeEgressRoleEgress    = "egress"
+	NodeEgressRoleNonEgress = "non-egress"
+
 	// Geneve header length for IPv4 (https://github.com/openshift/cluster-network-operator/pull/720#issuecomment-664020823)
 	GeneveHeaderLengthIPv4 = 58
 	// Geneve header length for IPv6 (https://github.com/openshift/cluster-network-operator/pull/720#issuecomment-664020823)
@@ -12,6 +12,10 @@ const (
 	EgressQoSErrorMsg      = "EgressQoS Rules not correctly applied"
 )
 
+// apbRouteBFDStatusPrefix prefixes the BFD session status messages the nodes report in the APBRoute status,
+// they are not zone statuses
+const apbRouteBFDStatusPrefix = "bfd/"
+
 func GetZoneStatus(zoneID, message string) string {
 	return fmt.Sprintf("%s: %s", zoneID, message)
 }
@@ -19,3 +23,13 @@ func GetZoneStatus(zoneID, message string) string {
 func GetZoneFromStatus(status string) string {
 	return strings.Split(status, ":")[0]
 }
+
+// GetNodeBFDStatus returns the APBRoute status message of the BFD sessions of a node
+func GetNodeBFDStatus(nodeName, message string) string {
+	return fmt.Sprintf("%s%s: %s", apbRouteBFDStatusPrefix, nodeName, message)
+}
+
+// IsNodeBFDStatus returns true if the status message is the BFD session status of a node
+func IsNodeBFDStatus(status string) bool {
+	return strings.HasPrefix(status, apbRouteBFDStatusPrefix)
+}
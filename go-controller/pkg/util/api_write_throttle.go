@@ -0,0 +1,139 @@
+package util
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/clock"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+)
+
+// apiWriteThrottleBackoff is how the writes of a throttled node failing with a transient API error are retried:
+// exponentially, with a full jitter so that the nodes failing at once don't retry at once
+var apiWriteThrottleBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   1,
+	Steps:    6,
+	Cap:      time.Minute,
+}
+
+// APIWriteThrottle spreads and limits the writes of a node to the API server, so that the nodes of a cluster
+// restarted at once don't all write their annotations, events and leases at the same time. The writes of the
+// spread window following the start of the node are delayed by an offset within the window derived from the
+// node name: the nodes are spread evenly over the window without coordinating with each other. The writes are
+// then limited to qps per second.
+type APIWriteThrottle struct {
+	clock   clock.Clock
+	start   time.Time
+	offset  time.Duration
+	limiter flowcontrol.RateLimiter
+}
+
+var (
+	nodeAPIWriteThrottleOnce sync.Once
+	nodeAPIWriteThrottle     *APIWriteThrottle
+)
+
+// NodeAPIWriteThrottle returns the API write throttle of the node shared by its controllers, configured by the
+// api-write-spread and api-write-qps options, or nil when both are disabled. The spread window starts on the
+// first call.
+func NodeAPIWriteThrottle(nodeName string) *APIWriteThrottle {
+	nodeAPIWriteThrottleOnce.Do(func() {
+		if config.OvnKubeNode.APIWriteSpread == 0 && config.OvnKubeNode.APIWriteQPS == 0 {
+			return
+		}
+		nodeAPIWriteThrottle = NewAPIWriteThrottle(nodeName,
+			time.Duration(config.OvnKubeNode.APIWriteSpread)*time.Second, config.OvnKubeNode.APIWriteQPS)
+	})
+	return nodeAPIWriteThrottle
+}
+
+// NewAPIWriteThrottle returns a throttle delaying the writes of the node by its offset within the spread window
+// and limiting them to qps per second; 0 disables the spread or the limit
+func NewAPIWriteThrottle(nodeName string, spread time.Duration, qps int) *APIWriteThrottle {
+	return newAPIWriteThrottle(nodeName, spread, qps, clock.RealClock{})
+}
+
+func newAPIWriteThrottle(nodeName string, spread time.Duration, qps int, c clock.Clock) *APIWriteThrottle {
+	t := &APIWriteThrottle{
+		clock: c,
+		start: c.Now(),
+	}
+	if spread > 0 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(nodeName))
+		t.offset = time.Duration(h.Sum64() % uint64(spread))
+	}
+	if qps > 0 {
+		t.limiter = flowcontrol.NewTokenBucketRateLimiterWithClock(float32(qps), qps, c)
+	}
+	return t
+}
+
+// Offset returns the delay of the writes of the node within the spread window
+func (t *APIWriteThrottle) Offset() time.Duration {
+	return t.offset
+}
+
+// Wait blocks until the node may write to the API: until its offset within the spread window has passed, and
+// then until the rate limit admits the write
+func (t *APIWriteThrottle) Wait(ctx context.Context) error {
+	if delay := t.offset - t.clock.Since(t.start); delay > 0 {
+		select {
+		case <-t.clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return t.WaitRate(ctx)
+}
+
+// WaitRate blocks until the rate limit admits a write, without the spread delay, for the writes that can't wait
+// for the offset of the node like the renewals of the leases
+func (t *APIWriteThrottle) WaitRate(ctx context.Context) error {
+	if t.limiter == nil {
+		return nil
+	}
+	return t.limiter.Wait(ctx)
+}
+
+// Backoff returns how the writes of the node failing with a transient API error are retried
+func (t *APIWriteThrottle) Backoff() *wait.Backoff {
+	backoff := apiWriteThrottleBackoff
+	return &backoff
+}
+
+// throttledEventSink is an EventSink waiting for the API write throttle before writing the events
+type throttledEventSink struct {
+	record.EventSink
+	throttle *APIWriteThrottle
+}
+
+func (s *throttledEventSink) Create(event *kapi.Event) (*kapi.Event, error) {
+	if err := s.throttle.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+	return s.EventSink.Create(event)
+}
+
+func (s *throttledEventSink) Update(event *kapi.Event) (*kapi.Event, error) {
+	if err := s.throttle.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+	return s.EventSink.Update(event)
+}
+
+func (s *throttledEventSink) Patch(oldEvent *kapi.Event, data []byte) (*kapi.Event, error) {
+	if err := s.throttle.Wait(context.TODO()); err != nil {
+		return nil, err
+	}
+	return s.EventSink.Patch(oldEvent, data)
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestAPIWriteThrottleOffset(t *testing.T) {
+	spread := time.Minute
+	offsets := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		nodeName := fmt.Sprintf("node%d", i)
+		offset := NewAPIWriteThrottle(nodeName, spread, 0).Offset()
+		assert.GreaterOrEqual(t, offset, time.Duration(0))
+		assert.Less(t, offset, spread)
+		assert.Equal(t, offset, NewAPIWriteThrottle(nodeName, spread, 0).Offset(), "the offset of a node changed")
+		offsets[offset.Truncate(spread/4)] = true
+	}
+	assert.Len(t, offsets, 4, "the nodes are not spread over the whole window")
+	assert.Equal(t, time.Duration(0), NewAPIWriteThrottle("node1", 0, 0).Offset())
+}
+
+func TestAPIWriteThrottleWait(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	throttle := newAPIWriteThrottle("node1", time.Hour, 0, fakeClock)
+
+	done := make(chan error)
+	go func() {
+		done <- throttle.Wait(context.Background())
+	}()
+	// the rate limit doesn't wait for the offset
+	assert.NoError(t, throttle.WaitRate(context.Background()))
+	assert.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("the write was admitted before the offset of the node")
+	default:
+	}
+	fakeClock.Step(throttle.Offset())
+	assert.NoError(t, <-done)
+
+	// the writes after the offset are admitted right away
+	assert.NoError(t, throttle.Wait(context.Background()))
+}
+
+func TestAPIWriteThrottleWaitCanceled(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	throttle := newAPIWriteThrottle("node1", time.Hour, 0, fakeClock)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, throttle.Wait(ctx), context.Canceled)
+}
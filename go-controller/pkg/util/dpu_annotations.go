@@ -3,12 +3,15 @@ package util
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"reflect"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 
 	v1 "k8s.io/api/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/retry"
 )
 
 /*
@@ -42,11 +45,37 @@ Example:
 					"reason": ""
 				}
 			}
+
+Annotation: "k8s.ovn.org/dpu.gateway-intent"
+Applied on: Nodes
+Used for: convey the gateway configuration of the DPU host the DPU gateway has to handle, i.e. the
+additional addresses and the routes of the host gateway interface, as the DPU host cannot reach OVS
+Example:
+    annotations:
+        k8s.ovn.org/dpu.gateway-intent: |
+            {
+				"vips": ["192.168.1.10"],
+				"routes": [{"destination": "10.20.0.0/16", "next-hop": "192.168.1.254"}]
+			}
+
+Annotation: "k8s.ovn.org/dpu.topology"
+Applied on: Nodes
+Used for: convey the DPU topology of a node, each side of the DPU / DPU host pair publishes
+its own entry keyed by its ovnkube-node mode
+Example:
+    annotations:
+        k8s.ovn.org/dpu.topology: |
+            {
+				"dpu-host": {"serial": "MT2232X00001", "dpu-node-name": "dpu-node1", "pfs": ["ens1f0"]},
+				"dpu": {"serial": "MT2232X00001", "dpu-node-name": "dpu-node1", "pfs": ["p0"]}
+			}
 */
 
 const (
 	DPUConnectionDetailsAnnot = "k8s.ovn.org/dpu.connection-details"
 	DPUConnectionStatusAnnot  = "k8s.ovn.org/dpu.connection-status"
+	DPUGatewayIntentAnnot     = "k8s.ovn.org/dpu.gateway-intent"
+	DPUTopologyAnnot          = "k8s.ovn.org/dpu.topology"
 
 	DPUConnectionStatusReady = "Ready"
 	DPUConnectionStatusError = "Error"
@@ -64,6 +93,25 @@ type DPUConnectionStatus struct {
 	Reason string `json:"Reason,omitempty"`
 }
 
+// DPUGatewayIntentRoute is a route of the DPU host gateway interface
+type DPUGatewayIntentRoute struct {
+	Destination string `json:"destination"`
+	NextHop     string `json:"next-hop"`
+}
+
+// DPUGatewayIntent is the gateway configuration declared by the DPU host for the DPU gateway
+type DPUGatewayIntent struct {
+	VIPs   []string                `json:"vips,omitempty"`
+	Routes []DPUGatewayIntentRoute `json:"routes,omitempty"`
+}
+
+// DPUTopology is the DPU topology published by one side of a DPU / DPU host pair
+type DPUTopology struct {
+	Serial      string   `json:"serial,omitempty"`
+	DPUNodeName string   `json:"dpu-node-name,omitempty"`
+	PFs         []string `json:"pfs,omitempty"`
+}
+
 // UnmarshalPodDPUConnDetailsAllNetworks returns the DPUConnectionDetails map of all networks from the given Pod annotation
 func UnmarshalPodDPUConnDetailsAllNetworks(annotations map[string]string) (map[string]DPUConnectionDetails, error) {
 	podDcds := make(map[string]DPUConnectionDetails)
@@ -246,3 +294,151 @@ func UpdatePodDPUConnDetailsWithRetry(podLister listers.PodLister, kube kube.Int
 		updatePodAnnotationNoRollback,
 	)
 }
+
+// SetNodeDPUGatewayIntent sets the DPU gateway intent annotation, an empty intent removes it
+func SetNodeDPUGatewayIntent(nodeAnnotator kube.Annotator, intent *DPUGatewayIntent) error {
+	if len(intent.VIPs) == 0 && len(intent.Routes) == 0 {
+		nodeAnnotator.Delete(DPUGatewayIntentAnnot)
+		return nil
+	}
+	return nodeAnnotator.Set(DPUGatewayIntentAnnot, intent)
+}
+
+// ParseNodeDPUGatewayIntent returns the validated DPU gateway intent of the node
+func ParseNodeDPUGatewayIntent(node *v1.Node) (*DPUGatewayIntent, error) {
+	annotation, ok := node.Annotations[DPUGatewayIntentAnnot]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", DPUGatewayIntentAnnot, node.Name)
+	}
+	intent := &DPUGatewayIntent{}
+	if err := json.Unmarshal([]byte(annotation), intent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			DPUGatewayIntentAnnot, annotation, node.Name, err)
+	}
+	for _, vip := range intent.VIPs {
+		if net.ParseIP(vip) == nil {
+			return nil, fmt.Errorf("invalid VIP %q in %s annotation for node %q", vip, DPUGatewayIntentAnnot, node.Name)
+		}
+	}
+	for _, route := range intent.Routes {
+		if _, _, err := net.ParseCIDR(route.Destination); err != nil {
+			return nil, fmt.Errorf("invalid route destination %q in %s annotation for node %q: %v",
+				route.Destination, DPUGatewayIntentAnnot, node.Name, err)
+		}
+		if net.ParseIP(route.NextHop) == nil {
+			return nil, fmt.Errorf("invalid route next hop %q in %s annotation for node %q",
+				route.NextHop, DPUGatewayIntentAnnot, node.Name)
+		}
+	}
+	return intent, nil
+}
+
+// NodeDPUGatewayIntentAnnotationChanged returns true if the DPU gateway intent of the node changed
+func NodeDPUGatewayIntentAnnotationChanged(oldNode, newNode *v1.Node) bool {
+	return oldNode.Annotations[DPUGatewayIntentAnnot] != newNode.Annotations[DPUGatewayIntentAnnot]
+}
+
+// ParseNodeDPUTopology returns the DPU topology entries of the node keyed by ovnkube-node mode
+func ParseNodeDPUTopology(node *v1.Node) (map[string]DPUTopology, error) {
+	annotation, ok := node.Annotations[DPUTopologyAnnot]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", DPUTopologyAnnot, node.Name)
+	}
+	topologies := map[string]DPUTopology{}
+	if err := json.Unmarshal([]byte(annotation), &topologies); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			DPUTopologyAnnot, annotation, node.Name, err)
+	}
+	for mode := range topologies {
+		if mode != types.NodeModeDPU && mode != types.NodeModeDPUHost {
+			return nil, fmt.Errorf("invalid mode %q in %s annotation for node %q", mode, DPUTopologyAnnot, node.Name)
+		}
+	}
+	return topologies, nil
+}
+
+// UpdateNodeDPUTopologyWithRetry sets the DPU topology entry of the given ovnkube-node mode on the node,
+// retrying on conflict as both sides of the pair update the annotation
+func UpdateNodeDPUTopologyWithRetry(nodeName string, nodeLister listers.NodeLister, kubeInterface kube.Interface,
+	mode string, topology *DPUTopology) error {
+	resultErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		// Informer cache should not be mutated, so get a copy of the object
+		node, err := nodeLister.Get(nodeName)
+		if err != nil {
+			return err
+		}
+		topologies, err := ParseNodeDPUTopology(node)
+		if err != nil {
+			if !IsAnnotationNotSetError(err) {
+				return err
+			}
+			topologies = map[string]DPUTopology{}
+		}
+		if current, ok := topologies[mode]; ok && reflect.DeepEqual(current, *topology) {
+			return nil
+		}
+		topologies[mode] = *topology
+		bytes, err := json.Marshal(topologies)
+		if err != nil {
+			return fmt.Errorf("failed marshaling DPU topology %v: %v", topologies, err)
+		}
+
+		cnode := node.DeepCopy()
+		if cnode.Annotations == nil {
+			cnode.Annotations = map[string]string{}
+		}
+		cnode.Annotations[DPUTopologyAnnot] = string(bytes)
+		// It is possible to update the node annotations using status subresource
+		// because changes to metadata via status subresource are not restricted for nodes.
+		return kubeInterface.UpdateNodeStatus(cnode)
+	})
+	if resultErr != nil {
+		return fmt.Errorf("failed to update node %s annotation %s: %v", nodeName, DPUTopologyAnnot, resultErr)
+	}
+	return nil
+}
+
+// IsDPUHostNode returns true if a DPU host published its topology on the node
+func IsDPUHostNode(node *v1.Node) bool {
+	topologies, err := ParseNodeDPUTopology(node)
+	if err != nil {
+		return false
+	}
+	_, ok := topologies[types.NodeModeDPUHost]
+	return ok
+}
+
+// IsNodeDPUPaired returns true if both the DPU host and the DPU published their topology on the node
+// and the serial numbers they declare, when set, are the same
+func IsNodeDPUPaired(node *v1.Node) bool {
+	topologies, err := ParseNodeDPUTopology(node)
+	if err != nil {
+		return false
+	}
+	host, hostOk := topologies[types.NodeModeDPUHost]
+	dpu, dpuOk := topologies[types.NodeModeDPU]
+	if !hostOk || !dpuOk {
+		return false
+	}
+	return host.Serial == "" || dpu.Serial == "" || host.Serial == dpu.Serial
+}
+
+// GetNodePairedDPUNodeName returns the name of the node of the DPU paired with the node, as
+// published by the DPU or, if it did not set one, by the DPU host
+func GetNodePairedDPUNodeName(node *v1.Node) (string, error) {
+	topologies, err := ParseNodeDPUTopology(node)
+	if err != nil {
+		return "", err
+	}
+	for _, mode := range []string{types.NodeModeDPU, types.NodeModeDPUHost} {
+		if topology, ok := topologies[mode]; ok && topology.DPUNodeName != "" {
+			return topology.DPUNodeName, nil
+		}
+	}
+	return "", fmt.Errorf("no DPU node name in %s annotation for node %q", DPUTopologyAnnot, node.Name)
+}
+
+// NodeDPUTopologyAnnotationChanged returns true if the DPU topology of the node changed
+func NodeDPUTopologyAnnotationChanged(oldNode, newNode *v1.Node) bool {
+	return oldNode.Annotations[DPUTopologyAnnot] != newNode.Annotations[DPUTopologyAnnot]
+}
@@ -1,8 +1,18 @@
 package util
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 var _ = Describe("DPU Annotations test", func() {
@@ -149,4 +159,102 @@ var _ = Describe("DPU Annotations test", func() {
 			})
 		})
 	})
+
+	Describe("DPUGatewayIntent", func() {
+		newNode := func(annotation string) *v1.Node {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			if annotation != "" {
+				node.Annotations = map[string]string{DPUGatewayIntentAnnot: annotation}
+			}
+			return node
+		}
+
+		It("Parses a valid gateway intent", func() {
+			intent, err := ParseNodeDPUGatewayIntent(newNode(
+				`{"vips":["192.168.1.10","fd00::10"],"routes":[{"destination":"10.20.0.0/16","next-hop":"192.168.1.254"}]}`))
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(intent).To(gomega.Equal(&DPUGatewayIntent{
+				VIPs:   []string{"192.168.1.10", "fd00::10"},
+				Routes: []DPUGatewayIntentRoute{{Destination: "10.20.0.0/16", NextHop: "192.168.1.254"}},
+			}))
+		})
+
+		It("Fails on a missing annotation", func() {
+			_, err := ParseNodeDPUGatewayIntent(newNode(""))
+			gomega.Expect(IsAnnotationNotSetError(err)).To(gomega.BeTrue())
+		})
+
+		It("Fails on an invalid VIP or route", func() {
+			_, err := ParseNodeDPUGatewayIntent(newNode(`{"vips":["192.168.1"]}`))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			_, err = ParseNodeDPUGatewayIntent(newNode(`{"routes":[{"destination":"10.20.0.0","next-hop":"192.168.1.254"}]}`))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			_, err = ParseNodeDPUGatewayIntent(newNode(`{"routes":[{"destination":"10.20.0.0/16"}]}`))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+
+	Describe("DPUTopology", func() {
+		newNode := func(annotation string) *v1.Node {
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			if annotation != "" {
+				node.Annotations = map[string]string{DPUTopologyAnnot: annotation}
+			}
+			return node
+		}
+
+		It("Queries the pairing of the node", func() {
+			node := newNode(`{"dpu-host":{"serial":"MT2232X00001","pfs":["ens1f0"]}}`)
+			gomega.Expect(IsDPUHostNode(node)).To(gomega.BeTrue())
+			gomega.Expect(IsNodeDPUPaired(node)).To(gomega.BeFalse())
+			_, err := GetNodePairedDPUNodeName(node)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+
+			node = newNode(`{"dpu-host":{"serial":"MT2232X00001","pfs":["ens1f0"]},` +
+				`"dpu":{"serial":"MT2232X00001","dpu-node-name":"dpu-node1","pfs":["p0"]}}`)
+			gomega.Expect(IsNodeDPUPaired(node)).To(gomega.BeTrue())
+			name, err := GetNodePairedDPUNodeName(node)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(name).To(gomega.Equal("dpu-node1"))
+
+			// the serials declared by both sides do not match
+			node = newNode(`{"dpu-host":{"serial":"MT2232X00001"},"dpu":{"serial":"MT2232X00002"}}`)
+			gomega.Expect(IsNodeDPUPaired(node)).To(gomega.BeFalse())
+		})
+
+		It("Fails on a missing annotation or an unknown mode", func() {
+			_, err := ParseNodeDPUTopology(newNode(""))
+			gomega.Expect(IsAnnotationNotSetError(err)).To(gomega.BeTrue())
+			gomega.Expect(IsDPUHostNode(newNode(""))).To(gomega.BeFalse())
+			_, err = ParseNodeDPUTopology(newNode(`{"full":{"serial":"MT2232X00001"}}`))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		It("Updates the entry of each side of the pair", func() {
+			node := newNode("")
+			client := fake.NewSimpleClientset(node)
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			gomega.Expect(indexer.Add(node)).To(gomega.Succeed())
+			k := &kube.Kube{KClient: client}
+
+			host := &DPUTopology{Serial: "MT2232X00001", PFs: []string{"ens1f0"}}
+			gomega.Expect(UpdateNodeDPUTopologyWithRetry("node1", listers.NewNodeLister(indexer), k,
+				types.NodeModeDPUHost, host)).To(gomega.Succeed())
+			node, err := client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(indexer.Update(node)).To(gomega.Succeed())
+
+			dpu := &DPUTopology{Serial: "MT2232X00001", DPUNodeName: "dpu-node1", PFs: []string{"p0"}}
+			gomega.Expect(UpdateNodeDPUTopologyWithRetry("node1", listers.NewNodeLister(indexer), k,
+				types.NodeModeDPU, dpu)).To(gomega.Succeed())
+			node, err = client.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			topologies, err := ParseNodeDPUTopology(node)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(topologies).To(gomega.Equal(map[string]DPUTopology{
+				types.NodeModeDPUHost: *host,
+				types.NodeModeDPU:     *dpu,
+			}))
+		})
+	})
 })
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ref "k8s.io/client-go/tools/reference"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+// rateLimitedEventKey identifies the events of a reason about an object
+type rateLimitedEventKey struct {
+	reason    string
+	kind      string
+	namespace string
+	name      string
+}
+
+// rateLimitedEventState tracks the events of a key since the last one was recorded
+type rateLimitedEventState struct {
+	lastRecorded time.Time
+	suppressed   int
+}
+
+// RateLimitedEventRecorder is an EventRecorder that records at most one event of a given reason about
+// a given object per interval. The events of the interval that are not recorded are counted and the
+// count is appended to the message of the next recorded event, so that a flapping condition produces
+// one event per interval instead of one per occurrence.
+type RateLimitedEventRecorder struct {
+	record.EventRecorder
+	interval time.Duration
+	clock    clock.PassiveClock
+
+	lock   sync.Mutex
+	events map[rateLimitedEventKey]*rateLimitedEventState
+}
+
+// NewRateLimitedEventRecorder returns an EventRecorder recording the events through the given recorder at
+// most once per interval for each reason and object. It returns nil when the given recorder is nil, so that
+// the callers checking their recorder against nil keep not recording events.
+func NewRateLimitedEventRecorder(recorder record.EventRecorder, interval time.Duration) record.EventRecorder {
+	if recorder == nil {
+		return nil
+	}
+	return newRateLimitedEventRecorder(recorder, interval, clock.RealClock{})
+}
+
+func newRateLimitedEventRecorder(recorder record.EventRecorder, interval time.Duration, clock clock.PassiveClock) *RateLimitedEventRecorder {
+	return &RateLimitedEventRecorder{
+		EventRecorder: recorder,
+		interval:      interval,
+		clock:         clock,
+		events:        map[rateLimitedEventKey]*rateLimitedEventState{},
+	}
+}
+
+// admit returns whether an event of the reason about the object is to be recorded now, and the number of
+// events not recorded since the last one
+func (r *RateLimitedEventRecorder) admit(object runtime.Object, reason string) (bool, int) {
+	key := rateLimitedEventKey{reason: reason}
+	if objRef, err := ref.GetReference(scheme.Scheme, object); err == nil {
+		key.kind, key.namespace, key.name = objRef.Kind, objRef.Namespace, objRef.Name
+	} else {
+		klog.V(5).Infof("Could not get the reference of the object of event %s, rate limiting it by reason only: %v", reason, err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.clock.Now()
+	// forget the keys that were not seen for an interval, the next event of those is recorded anyway
+	for k, state := range r.events {
+		if k != key && state.suppressed == 0 && now.Sub(state.lastRecorded) >= r.interval {
+			delete(r.events, k)
+		}
+	}
+	state, ok := r.events[key]
+	if !ok {
+		r.events[key] = &rateLimitedEventState{lastRecorded: now}
+		return true, 0
+	}
+	if now.Sub(state.lastRecorded) < r.interval {
+		state.suppressed++
+		return false, 0
+	}
+	suppressed := state.suppressed
+	state.lastRecorded, state.suppressed = now, 0
+	return true, suppressed
+}
+
+func (r *RateLimitedEventRecorder) aggregate(message string, suppressed int) string {
+	if suppressed == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%d similar events not recorded since the previous one)", message, suppressed)
+}
+
+func (r *RateLimitedEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.EventRecorder == nil {
+		return
+	}
+	if ok, suppressed := r.admit(object, reason); ok {
+		r.EventRecorder.Event(object, eventtype, reason, r.aggregate(message, suppressed))
+	}
+}
+
+func (r *RateLimitedEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *RateLimitedEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if r.EventRecorder == nil {
+		return
+	}
+	if ok, suppressed := r.admit(object, reason); ok {
+		r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", r.aggregate(fmt.Sprintf(messageFmt, args...), suppressed))
+	}
+}
+
+var _ record.EventRecorder = &RateLimitedEventRecorder{}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestRateLimitedEventRecorder(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := newRateLimitedEventRecorder(fakeRecorder, time.Minute, fakeClock)
+	node1 := &kapi.ObjectReference{Kind: "Node", Name: "node1"}
+	node2 := &kapi.ObjectReference{Kind: "Node", Name: "node2"}
+
+	recorder.Eventf(node1, kapi.EventTypeWarning, "Flapping", "flap %d", 1)
+	// the same reason about the same object is not recorded within the interval
+	recorder.Eventf(node1, kapi.EventTypeWarning, "Flapping", "flap %d", 2)
+	recorder.Eventf(node1, kapi.EventTypeWarning, "Flapping", "flap %d", 3)
+	// other reasons and other objects are recorded
+	recorder.Eventf(node1, kapi.EventTypeWarning, "Other", "other")
+	recorder.Eventf(node2, kapi.EventTypeWarning, "Flapping", "flap %d", 1)
+
+	fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+	// the next event after the interval reports the events that were not recorded
+	recorder.Eventf(node1, kapi.EventTypeWarning, "Flapping", "flap %d", 4)
+	recorder.Eventf(node2, kapi.EventTypeWarning, "Flapping", "flap %d", 2)
+
+	close(fakeRecorder.Events)
+	var events []string
+	for event := range fakeRecorder.Events {
+		events = append(events, event)
+	}
+	assert.Equal(t, []string{
+		"Warning Flapping flap 1",
+		"Warning Other other",
+		"Warning Flapping flap 1",
+		"Warning Flapping flap 4 (2 similar events not recorded since the previous one)",
+		"Warning Flapping flap 2",
+	}, events)
+}
+
+func TestRateLimitedEventRecorderNilRecorder(t *testing.T) {
+	// a nil recorder is not wrapped, the callers guarding their recorder against nil keep working
+	assert.Nil(t, NewRateLimitedEventRecorder(nil, time.Minute))
+
+	// a recorder wrapping a nil recorder does not record anything
+	recorder := newRateLimitedEventRecorder(nil, time.Minute, clocktesting.NewFakePassiveClock(time.Now()))
+	node := &kapi.ObjectReference{Kind: "Node", Name: "node1"}
+	assert.NotPanics(t, func() {
+		recorder.Eventf(node, kapi.EventTypeWarning, "Flapping", "flap %d", 1)
+		recorder.AnnotatedEventf(node, nil, kapi.EventTypeWarning, "Flapping", "flap %d", 2)
+	})
+}
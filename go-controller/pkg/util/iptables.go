@@ -17,6 +17,8 @@ import (
 type IPTablesHelper interface {
 	// List rules in specified table/chain
 	List(table, chain string) ([]string, error)
+	// ListWithCounters lists the rules in specified table/chain with their packet and byte counters
+	ListWithCounters(table, chain string) ([]string, error)
 	// ListChains returns the names of all chains in the table
 	ListChains(string) ([]string, error)
 	// ClearChain removes all rules in the specified table/chain.
@@ -49,10 +51,11 @@ func SetIPTablesHelper(proto iptables.Protocol, ipt IPTablesHelper) {
 }
 
 // GetIPTablesHelper returns an IPTablesHelper. If SetIPTablesHelper has not yet been
-// called, it will create a new IPTablesHelper wrapping "live" go-iptables
+// called, it will create a new IPTablesHelper wrapping "live" go-iptables in the network
+// namespace of the node
 func GetIPTablesHelper(proto iptables.Protocol) (IPTablesHelper, error) {
 	if helpers[proto] == nil {
-		ipt, err := iptables.NewWithProtocol(proto)
+		ipt, err := newNodeNetNSIPTables(proto)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create IPTablesHelper for proto %v: %v",
 				proto, err)
@@ -162,6 +165,25 @@ func (f *FakeIPTables) List(tableName, chainName string) ([]string, error) {
 	return chain, nil
 }
 
+// ListWithCounters lists the rules in specified table/chain, the fake rules never match packets
+func (f *FakeIPTables) ListWithCounters(tableName, chainName string) ([]string, error) {
+	f.Lock()
+	defer f.Unlock()
+	table, err := f.getTable(tableName)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := table.getChain(chainName)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]string, 0, len(chain))
+	for _, rule := range chain {
+		rules = append(rules, fmt.Sprintf("-A %s %s -c 0 0", chainName, rule))
+	}
+	return rules, nil
+}
+
 // ListChains returns the names of all chains in the table
 func (f *FakeIPTables) ListChains(tableName string) ([]string, error) {
 	f.Lock()
@@ -44,10 +44,13 @@ import (
 	ocpnetworkclientset "github.com/openshift/client-go/network/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	dpunodepairingclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/dpunodepairing/v1/apis/clientset/versioned"
 	egressfirewallclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned"
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
+	physicalnetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/physicalnetwork/v1/apis/clientset/versioned"
+	serviceannouncementclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/serviceannouncement/v1/apis/clientset/versioned"
 	userdefinednetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/userdefinednetwork/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
@@ -55,19 +58,22 @@ import (
 
 // OVNClientset is a wrapper around all clientsets used by OVN-Kubernetes
 type OVNClientset struct {
-	KubeClient               kubernetes.Interface
-	ANPClient                anpclientset.Interface
-	EgressIPClient           egressipclientset.Interface
-	EgressFirewallClient     egressfirewallclientset.Interface
-	OCPNetworkClient         ocpnetworkclientset.Interface
-	CloudNetworkClient       ocpcloudnetworkclientset.Interface
-	EgressQoSClient          egressqosclientset.Interface
-	NetworkAttchDefClient    networkattchmentdefclientset.Interface
-	MultiNetworkPolicyClient multinetworkpolicyclientset.Interface
-	EgressServiceClient      egressserviceclientset.Interface
-	AdminPolicyRouteClient   adminpolicybasedrouteclientset.Interface
-	IPAMClaimsClient         ipamclaimssclientset.Interface
-	UserDefinedNetworkClient userdefinednetworkclientset.Interface
+	KubeClient                kubernetes.Interface
+	ANPClient                 anpclientset.Interface
+	EgressIPClient            egressipclientset.Interface
+	EgressFirewallClient      egressfirewallclientset.Interface
+	OCPNetworkClient          ocpnetworkclientset.Interface
+	CloudNetworkClient        ocpcloudnetworkclientset.Interface
+	EgressQoSClient           egressqosclientset.Interface
+	NetworkAttchDefClient     networkattchmentdefclientset.Interface
+	MultiNetworkPolicyClient  multinetworkpolicyclientset.Interface
+	EgressServiceClient       egressserviceclientset.Interface
+	AdminPolicyRouteClient    adminpolicybasedrouteclientset.Interface
+	IPAMClaimsClient          ipamclaimssclientset.Interface
+	UserDefinedNetworkClient  userdefinednetworkclientset.Interface
+	ServiceAnnouncementClient serviceannouncementclientset.Interface
+	DPUNodePairingClient      dpunodepairingclientset.Interface
+	PhysicalNetworkClient     physicalnetworkclientset.Interface
 }
 
 // OVNMasterClientset
@@ -85,6 +91,8 @@ type OVNMasterClientset struct {
 	IPAMClaimsClient         ipamclaimssclientset.Interface
 	NetworkAttchDefClient    networkattchmentdefclientset.Interface
 	UserDefinedNetworkClient userdefinednetworkclientset.Interface
+	DPUNodePairingClient     dpunodepairingclientset.Interface
+	PhysicalNetworkClient    physicalnetworkclientset.Interface
 }
 
 // OVNNetworkControllerManagerClientset
@@ -104,11 +112,14 @@ type OVNKubeControllerClientset struct {
 }
 
 type OVNNodeClientset struct {
-	KubeClient             kubernetes.Interface
-	EgressServiceClient    egressserviceclientset.Interface
-	EgressIPClient         egressipclientset.Interface
-	AdminPolicyRouteClient adminpolicybasedrouteclientset.Interface
-	NetworkAttchDefClient  networkattchmentdefclientset.Interface
+	KubeClient                kubernetes.Interface
+	EgressServiceClient       egressserviceclientset.Interface
+	EgressIPClient            egressipclientset.Interface
+	AdminPolicyRouteClient    adminpolicybasedrouteclientset.Interface
+	NetworkAttchDefClient     networkattchmentdefclientset.Interface
+	ServiceAnnouncementClient serviceannouncementclientset.Interface
+	DPUNodePairingClient      dpunodepairingclientset.Interface
+	PhysicalNetworkClient     physicalnetworkclientset.Interface
 }
 
 type OVNClusterManagerClientset struct {
@@ -151,6 +162,8 @@ func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 		IPAMClaimsClient:         cs.IPAMClaimsClient,
 		NetworkAttchDefClient:    cs.NetworkAttchDefClient,
 		UserDefinedNetworkClient: cs.UserDefinedNetworkClient,
+		DPUNodePairingClient:     cs.DPUNodePairingClient,
+		PhysicalNetworkClient:    cs.PhysicalNetworkClient,
 	}
 }
 
@@ -206,11 +219,14 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 
 func (cs *OVNClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient:             cs.KubeClient,
-		EgressServiceClient:    cs.EgressServiceClient,
-		EgressIPClient:         cs.EgressIPClient,
-		AdminPolicyRouteClient: cs.AdminPolicyRouteClient,
-		NetworkAttchDefClient:  cs.NetworkAttchDefClient,
+		KubeClient:                cs.KubeClient,
+		EgressServiceClient:       cs.EgressServiceClient,
+		EgressIPClient:            cs.EgressIPClient,
+		AdminPolicyRouteClient:    cs.AdminPolicyRouteClient,
+		NetworkAttchDefClient:     cs.NetworkAttchDefClient,
+		ServiceAnnouncementClient: cs.ServiceAnnouncementClient,
+		DPUNodePairingClient:      cs.DPUNodePairingClient,
+		PhysicalNetworkClient:     cs.PhysicalNetworkClient,
 	}
 }
 
@@ -220,6 +236,8 @@ func (cs *OVNMasterClientset) GetNodeClientset() *OVNNodeClientset {
 		EgressServiceClient:   cs.EgressServiceClient,
 		EgressIPClient:        cs.EgressIPClient,
 		NetworkAttchDefClient: cs.NetworkAttchDefClient,
+		DPUNodePairingClient:  cs.DPUNodePairingClient,
+		PhysicalNetworkClient: cs.PhysicalNetworkClient,
 	}
 }
 
@@ -492,20 +510,38 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		return nil, err
 	}
 
+	serviceAnnouncementClientset, err := serviceannouncementclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dpuNodePairingClientset, err := dpunodepairingclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	physicalNetworkClientset, err := physicalnetworkclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &OVNClientset{
-		KubeClient:               kclientset,
-		ANPClient:                anpClientset,
-		EgressIPClient:           egressIPClientset,
-		EgressFirewallClient:     egressFirewallClientset,
-		OCPNetworkClient:         networkClientset,
-		CloudNetworkClient:       cloudNetworkClientset,
-		EgressQoSClient:          egressqosClientset,
-		NetworkAttchDefClient:    networkAttchmntDefClientset,
-		MultiNetworkPolicyClient: multiNetworkPolicyClientset,
-		EgressServiceClient:      egressserviceClientset,
-		AdminPolicyRouteClient:   adminPolicyBasedRouteClientset,
-		IPAMClaimsClient:         ipamClaimsClientset,
-		UserDefinedNetworkClient: userDefinedNetworkClientSet,
+		KubeClient:                kclientset,
+		ANPClient:                 anpClientset,
+		EgressIPClient:            egressIPClientset,
+		EgressFirewallClient:      egressFirewallClientset,
+		OCPNetworkClient:          networkClientset,
+		CloudNetworkClient:        cloudNetworkClientset,
+		EgressQoSClient:           egressqosClientset,
+		NetworkAttchDefClient:     networkAttchmntDefClientset,
+		MultiNetworkPolicyClient:  multiNetworkPolicyClientset,
+		EgressServiceClient:       egressserviceClientset,
+		AdminPolicyRouteClient:    adminPolicyBasedRouteClientset,
+		IPAMClaimsClient:          ipamClaimsClientset,
+		UserDefinedNetworkClient:  userDefinedNetworkClientSet,
+		ServiceAnnouncementClient: serviceAnnouncementClientset,
+		DPUNodePairingClient:      dpuNodePairingClientset,
+		PhysicalNetworkClient:     physicalNetworkClientset,
 	}, nil
 }
 
@@ -597,6 +633,34 @@ func ServiceInternalTrafficPolicyLocal(service *kapi.Service) bool {
 	return service.Spec.InternalTrafficPolicy != nil && *service.Spec.InternalTrafficPolicy == kapi.ServiceInternalTrafficPolicyLocal
 }
 
+// ServiceDirectServerReturnAnnotation set to "true" on a service with ExternalTrafficPolicy=Cluster enables its
+// direct server return mode: the external traffic of the service received by a node with local endpoints is sent
+// to those endpoints without SNAT, preserving the client IP and returning through the same node, while the nodes
+// without local endpoints fall back to load balancing it to the cluster endpoints with SNAT.
+const ServiceDirectServerReturnAnnotation = "k8s.ovn.org/direct-server-return"
+
+// ServiceLoadBalancerSelectionFieldsAnnotation set on a service to a comma separated list of packet fields among
+// eth_src, eth_dst, ip_src, ip_dst, tp_src and tp_dst opts the service into consistent hashing: its OVN load
+// balancers select the endpoint of a connection by hashing those fields, and ovn-controller programs the openflow
+// groups of the load balancers with the matching hash selection method instead of dp_hash. ClientIP session
+// affinity takes precedence over it.
+const ServiceLoadBalancerSelectionFieldsAnnotation = "k8s.ovn.org/load-balancer-selection-fields"
+
+// ServiceDirectServerReturn returns whether the direct server return mode is enabled for the service. Services with
+// ExternalTrafficPolicy=Local always preserve the client IP and do not need it.
+func ServiceDirectServerReturn(service *kapi.Service) bool {
+	return !ServiceExternalTrafficPolicyLocal(service) && service.Annotations[ServiceDirectServerReturnAnnotation] == "true"
+}
+
+// PodLatencyCriticalAnnotation set to "true" on a pod steers its traffic to and from the physical network through
+// the latency critical fast path of the gateway bridge of its node, which bypasses the conntrack of the bridge.
+const PodLatencyCriticalAnnotation = "k8s.ovn.org/latency-critical"
+
+// PodLatencyCritical returns whether the pod is annotated as latency critical
+func PodLatencyCritical(pod *kapi.Pod) bool {
+	return pod.Annotations[PodLatencyCriticalAnnotation] == "true"
+}
+
 // GetClusterSubnets returns the v4&v6 cluster subnets in a cluster separately
 func GetClusterSubnets() ([]*net.IPNet, []*net.IPNet) {
 	var v4ClusterSubnets = []*net.IPNet{}
@@ -619,18 +683,30 @@ func GetAllClusterSubnets() []*net.IPNet {
 
 // GetNodePrimaryIP extracts the primary IP address from the node status in the  API
 func GetNodePrimaryIP(node *kapi.Node) (string, error) {
+	return GetNodePrimaryIPExcluding(node, nil)
+}
+
+// GetNodePrimaryIPExcluding extracts the primary IP address from the node status in the API like
+// GetNodePrimaryIP, skipping the addresses excluded returns true for
+func GetNodePrimaryIPExcluding(node *kapi.Node, excluded func(net.IP) bool) (string, error) {
 	if node == nil {
 		return "", fmt.Errorf("invalid node object")
 	}
-	for _, addr := range node.Status.Addresses {
-		if addr.Type == kapi.NodeInternalIP {
-			return utilnet.ParseIPSloppy(addr.Address).String(), nil
+	for _, addrType := range []kapi.NodeAddressType{kapi.NodeInternalIP, kapi.NodeExternalIP} {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type != addrType {
+				continue
+			}
+			ip := utilnet.ParseIPSloppy(addr.Address)
+			if excluded != nil && excluded(ip) {
+				continue
+			}
+			return ip.String(), nil
 		}
 	}
-	for _, addr := range node.Status.Addresses {
-		if addr.Type == kapi.NodeExternalIP {
-			return utilnet.ParseIPSloppy(addr.Address).String(), nil
-		}
+	if excluded != nil {
+		return "", fmt.Errorf("%s doesn't have a not excluded address with type %s or %s", node.GetName(),
+			kapi.NodeInternalIP, kapi.NodeExternalIP)
 	}
 	return "", fmt.Errorf("%s doesn't have an address with type %s or %s", node.GetName(),
 		kapi.NodeInternalIP, kapi.NodeExternalIP)
@@ -671,12 +747,21 @@ func PodTerminating(pod *kapi.Pod) bool {
 // EventRecorder returns an EventRecorder type that can be
 // used to post Events to different object's lifecycles.
 func EventRecorder(kubeClient kubernetes.Interface) record.EventRecorder {
+	return EventRecorderWithThrottle(kubeClient, nil)
+}
+
+// EventRecorderWithThrottle returns an EventRecorder like EventRecorder writing the events once the API write
+// throttle admits them, when it is not nil
+func EventRecorderWithThrottle(kubeClient kubernetes.Interface, throttle *APIWriteThrottle) record.EventRecorder {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(klog.Infof)
-	eventBroadcaster.StartRecordingToSink(
-		&typedcorev1.EventSinkImpl{
-			Interface: kubeClient.CoreV1().Events(""),
-		})
+	var sink record.EventSink = &typedcorev1.EventSinkImpl{
+		Interface: kubeClient.CoreV1().Events(""),
+	}
+	if throttle != nil {
+		sink = &throttledEventSink{EventSink: sink, throttle: throttle}
+	}
+	eventBroadcaster.StartRecordingToSink(sink)
 	recorder := eventBroadcaster.NewRecorder(
 		scheme.Scheme,
 		kapi.EventSource{Component: "controlplane"})
@@ -839,6 +924,47 @@ func GetLocalEligibleEndpointAddressesFromSlices(endpointSlices []*discovery.End
 	return sets.New(endpoints...)
 }
 
+// EndpointPortKey identifies a port of an endpoint slice by its name and protocol, the name being
+// the one of the service port whose target port it resolves
+type EndpointPortKey struct {
+	Name     string
+	Protocol kapi.Protocol
+}
+
+// NewEndpointPortKey returns the key of the given endpoint slice port
+func NewEndpointPortKey(port discovery.EndpointPort) EndpointPortKey {
+	key := EndpointPortKey{}
+	if port.Name != nil {
+		key.Name = *port.Name
+	}
+	if port.Protocol != nil {
+		key.Protocol = *port.Protocol
+	}
+	return key
+}
+
+// GetEligibleEndpointTargetPorts returns the target port number each port of the endpointslice resolves to,
+// for each eligible endpoint address. A named target port can resolve to a different number on each pod,
+// so the number an endpoint is reached on can change while the port numbers of the slice stay the same.
+func GetEligibleEndpointTargetPorts(endpointSlice *discovery.EndpointSlice, service *kapi.Service) map[string]map[EndpointPortKey]int32 {
+	targetPorts := map[string]map[EndpointPortKey]int32{}
+	endpoints := getEndpointsFromEndpointSlices([]*discovery.EndpointSlice{endpointSlice})
+	for _, ep := range getEligibleEndpoints(endpoints, service) {
+		for _, ip := range ep.Addresses {
+			ipStr := utilnet.ParseIPSloppy(ip).String()
+			if targetPorts[ipStr] == nil {
+				targetPorts[ipStr] = map[EndpointPortKey]int32{}
+			}
+			for _, port := range endpointSlice.Ports {
+				if port.Port != nil {
+					targetPorts[ipStr][NewEndpointPortKey(port)] = *port.Port
+				}
+			}
+		}
+	}
+	return targetPorts
+}
+
 // DoesEndpointSliceContainEndpoint returns true if the endpointslice
 // contains an endpoint with the given IP, port and Protocol and if this endpoint is considered eligible.
 func DoesEndpointSliceContainEligibleEndpoint(endpointSlice *discovery.EndpointSlice,
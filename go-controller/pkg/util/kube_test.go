@@ -341,6 +341,65 @@ func TestGetNodePrimaryIP(t *testing.T) {
 	}
 }
 
+func TestGetNodePrimaryIPExcluding(t *testing.T) {
+	node := &v1.Node{
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: "192.168.100.5"},
+				{Type: v1.NodeInternalIP, Address: "192.168.1.1"},
+				{Type: v1.NodeExternalIP, Address: "90.90.90.90"},
+			},
+		},
+	}
+	excludedNetwork := func(cidrs ...string) func(net.IP) bool {
+		return func(ip net.IP) bool {
+			for _, cidr := range cidrs {
+				if kube_test.MustParseIPNet(cidr).Contains(ip) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	tests := []struct {
+		desc     string
+		excluded func(net.IP) bool
+		expErr   bool
+		expOut   string
+	}{
+		{
+			desc:   "success: first internal IP returned without exclusions",
+			expOut: "192.168.100.5",
+		},
+		{
+			desc:     "success: excluded internal IP skipped",
+			excluded: excludedNetwork("192.168.100.0/24"),
+			expOut:   "192.168.1.1",
+		},
+		{
+			desc:     "success: external IP returned when all the internal IPs are excluded",
+			excluded: excludedNetwork("192.168.0.0/16"),
+			expOut:   "90.90.90.90",
+		},
+		{
+			desc:     "error: all the IPs excluded",
+			excluded: excludedNetwork("192.168.0.0/16", "90.90.90.0/24"),
+			expErr:   true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, e := GetNodePrimaryIPExcluding(node, tc.excluded)
+			if tc.expErr {
+				assert.Error(t, e)
+			} else {
+				assert.NoError(t, e)
+				assert.Equal(t, tc.expOut, res)
+			}
+		})
+	}
+}
+
 func Test_GetNodePrimaryIP(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -846,6 +905,49 @@ func TestGetEligibleEndpointAddresses(t *testing.T) {
 	}
 }
 
+func TestGetEligibleEndpointTargetPorts(t *testing.T) {
+	service := getSampleService(false)
+	httpsKey := EndpointPortKey{Name: httpsPortName, Protocol: tcpv1}
+	customKey := EndpointPortKey{Name: customPortName, Protocol: udpv1}
+	allPorts := map[EndpointPortKey]int32{httpsKey: httpsPortValue, customKey: customPortValue}
+	swappedSlice := setAllEndpointsToReady(getSampleEndpointSlice(service))
+	swappedSlice.Ports[0].Port, swappedSlice.Ports[1].Port = &customPortValue, &httpsPortValue
+	var tests = []struct {
+		name          string
+		endpointSlice *discovery.EndpointSlice
+		want          map[string]map[EndpointPortKey]int32
+	}{
+		{
+			"Tests an endpointslice with all ready endpoints",
+			setAllEndpointsToReady(getSampleEndpointSlice(service)),
+			map[string]map[EndpointPortKey]int32{ep1Address: allPorts, ep2Address: allPorts, ep3Address: allPorts},
+		},
+		{
+			"Tests an endpointslice with all non-ready, non-serving, terminating endpoints",
+			setAllEndpointsToTerminatingAndNotServing(getSampleEndpointSlice(service)),
+			map[string]map[EndpointPortKey]int32{},
+		},
+		{
+			"Tests an endpointslice whose named ports resolve to swapped port numbers",
+			swappedSlice,
+			map[string]map[EndpointPortKey]int32{
+				ep1Address: {httpsKey: customPortValue, customKey: httpsPortValue},
+				ep2Address: {httpsKey: customPortValue, customKey: httpsPortValue},
+				ep3Address: {httpsKey: customPortValue, customKey: httpsPortValue},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answer := GetEligibleEndpointTargetPorts(tt.endpointSlice, service)
+			if !reflect.DeepEqual(answer, tt.want) {
+				t.Errorf("got %v, want %v", answer, tt.want)
+			}
+		})
+	}
+}
+
 func TestDoesEndpointSliceContainEligibleEndpoint(t *testing.T) {
 	service := getSampleService(false)
 	var tests = []struct {
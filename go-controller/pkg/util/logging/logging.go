@@ -0,0 +1,228 @@
+// Package logging provides per-subsystem loggers whose verbosity can be adjusted at runtime independently of the
+// global klog verbosity, and the structured JSON output of the logs.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// FormatText is the klog text log format
+	FormatText = "text"
+	// FormatJSON is the structured JSON log format
+	FormatJSON = "json"
+
+	// subsystemKey is the key of the subsystem name in the structured logs
+	subsystemKey = "subsystem"
+)
+
+var (
+	loggersLock sync.Mutex
+	// loggers are the subsystem loggers, by subsystem name
+	loggers = map[string]*Logger{}
+
+	// structured is set when the logs are written as JSON
+	structured atomic.Bool
+
+	// verbosity is the global klog verbosity, as last set by SetVerbosity
+	verbosity atomic.Int32
+)
+
+// SetVerbosity sets the global klog verbosity
+func SetVerbosity(level klog.Level) error {
+	if level < 0 {
+		return fmt.Errorf("invalid negative verbosity %d", level)
+	}
+	var l klog.Level
+	if err := l.Set(strconv.Itoa(int(level))); err != nil {
+		return err
+	}
+	verbosity.Store(int32(level))
+	return nil
+}
+
+// Verbosity returns the global klog verbosity
+func Verbosity() klog.Level {
+	return klog.Level(verbosity.Load())
+}
+
+// Logger logs the messages of a subsystem
+type Logger struct {
+	name string
+	// level is the verbosity of the subsystem, the global klog verbosity applies when it is negative
+	level atomic.Int32
+}
+
+// Subsystem returns the logger of the subsystem, its verbosity is the global klog verbosity until it is set
+func Subsystem(name string) *Logger {
+	loggersLock.Lock()
+	defer loggersLock.Unlock()
+	l, ok := loggers[name]
+	if !ok {
+		l = &Logger{name: name}
+		l.level.Store(-1)
+		loggers[name] = l
+	}
+	return l
+}
+
+// SetLevel sets the verbosity of the subsystem
+func (l *Logger) SetLevel(level klog.Level) {
+	l.level.Store(int32(level))
+}
+
+// ResetLevel makes the subsystem follow the global klog verbosity again
+func (l *Logger) ResetLevel() {
+	l.level.Store(-1)
+}
+
+// Level returns the verbosity of the subsystem and whether it was set
+func (l *Logger) Level() (klog.Level, bool) {
+	level := l.level.Load()
+	return klog.Level(level), level >= 0
+}
+
+// V returns a Verbose logging messages at the level when the verbosity of the subsystem allows it. Without
+// a subsystem verbosity, the global klog verbosity and -vmodule of the file of the caller apply.
+func (l *Logger) V(level klog.Level) Verbose {
+	enabled := klog.VDepth(1, level).Enabled()
+	if subsystemLevel, ok := l.Level(); ok {
+		enabled = subsystemLevel >= level
+	}
+	return Verbose{enabled: enabled, name: l.name}
+}
+
+// Verbose logs the messages of a subsystem at a verbosity level
+type Verbose struct {
+	enabled bool
+	name    string
+}
+
+// Enabled returns whether the messages are logged
+func (v Verbose) Enabled() bool {
+	return v.enabled
+}
+
+// Info logs like klog.Info when enabled
+func (v Verbose) Info(args ...interface{}) {
+	if v.enabled {
+		v.log(fmt.Sprint(args...))
+	}
+}
+
+// Infof logs like klog.Infof when enabled
+func (v Verbose) Infof(format string, args ...interface{}) {
+	if v.enabled {
+		v.log(fmt.Sprintf(format, args...))
+	}
+}
+
+// InfoS logs like klog.InfoS when enabled
+func (v Verbose) InfoS(msg string, keysAndValues ...interface{}) {
+	if v.enabled {
+		klog.InfoSDepth(1, msg, append([]interface{}{subsystemKey, v.name}, keysAndValues...)...)
+	}
+}
+
+func (v Verbose) log(msg string) {
+	if structured.Load() {
+		klog.InfoSDepth(2, msg, subsystemKey, v.name)
+		return
+	}
+	klog.InfoDepth(2, msg)
+}
+
+// ParseLevels parses the verbosity of subsystems given as a comma separated list of <subsystem>=<level>
+func ParseLevels(s string) (map[string]klog.Level, error) {
+	levels := map[string]klog.Level{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid subsystem log level %q, expected <subsystem>=<level>", item)
+		}
+		level, err := strconv.ParseUint(strings.TrimSpace(value), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of subsystem %s: %v", name, err)
+		}
+		levels[name] = klog.Level(level)
+	}
+	return levels, nil
+}
+
+// SetLevels sets the verbosity of the subsystems, the other subsystems follow the global klog verbosity
+func SetLevels(levels map[string]klog.Level) {
+	loggersLock.Lock()
+	existing := make([]*Logger, 0, len(loggers))
+	for _, l := range loggers {
+		existing = append(existing, l)
+	}
+	loggersLock.Unlock()
+	for _, l := range existing {
+		if _, ok := levels[l.name]; !ok {
+			l.ResetLevel()
+		}
+	}
+	for name, level := range levels {
+		Subsystem(name).SetLevel(level)
+	}
+}
+
+// Levels returns the verbosity of the subsystems it was set for, by subsystem name
+func Levels() map[string]klog.Level {
+	loggersLock.Lock()
+	defer loggersLock.Unlock()
+	levels := map[string]klog.Level{}
+	for name, l := range loggers {
+		if level, ok := l.Level(); ok {
+			levels[name] = level
+		}
+	}
+	return levels
+}
+
+// FormatLevels returns the verbosity of the subsystems it was set for, in the format parsed by ParseLevels
+func FormatLevels() string {
+	var levels []string
+	for name, level := range Levels() {
+		levels = append(levels, fmt.Sprintf("%s=%d", name, level))
+	}
+	sort.Strings(levels)
+	return strings.Join(levels, ",")
+}
+
+// NewJSONLogger returns a logger writing one JSON object per line to w. It logs at any verbosity, the messages
+// are filtered by klog.
+func NewJSONLogger(w io.Writer) logr.Logger {
+	var lock sync.Mutex
+	return funcr.NewJSON(func(obj string) {
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintln(w, obj)
+	}, funcr.Options{
+		LogCaller:    funcr.All,
+		LogTimestamp: true,
+		Verbosity:    math.MaxInt32,
+	})
+}
+
+// EnableJSON writes the klog logs as JSON to w
+func EnableJSON(w io.Writer) {
+	structured.Store(true)
+	klog.SetLogger(NewJSONLogger(w))
+}
@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"reflect"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+func TestParseLevels(t *testing.T) {
+	tests := []struct {
+		name      string
+		levels    string
+		expected  map[string]klog.Level
+		expectErr bool
+	}{
+		{
+			name:     "empty",
+			levels:   "",
+			expected: map[string]klog.Level{},
+		},
+		{
+			name:     "several subsystems",
+			levels:   "node=5, gateway = 2,",
+			expected: map[string]klog.Level{"node": 5, "gateway": 2},
+		},
+		{
+			name:      "missing level",
+			levels:    "node",
+			expectErr: true,
+		},
+		{
+			name:      "negative level",
+			levels:    "node=-1",
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			levels, err := ParseLevels(tc.levels)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error parsing %q", tc.levels)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to parse %q: %v", tc.levels, err)
+			}
+			if !reflect.DeepEqual(levels, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, levels)
+			}
+		})
+	}
+}
+
+func TestSetLevels(t *testing.T) {
+	defer SetLevels(nil)
+	node, gateway := Subsystem("test-node"), Subsystem("test-gateway")
+
+	SetLevels(map[string]klog.Level{"test-node": 5, "test-gateway": 2})
+	if !node.V(5).Enabled() || !gateway.V(2).Enabled() || gateway.V(3).Enabled() {
+		t.Fatalf("expected the subsystem levels to apply")
+	}
+	if levels := FormatLevels(); levels != "test-gateway=2,test-node=5" {
+		t.Fatalf("unexpected levels %q", levels)
+	}
+
+	SetLevels(map[string]klog.Level{"test-gateway": 4})
+	if _, ok := node.Level(); ok {
+		t.Fatalf("expected the level of the subsystem not listed to be reset")
+	}
+	if node.V(5).Enabled() != klog.V(5).Enabled() {
+		t.Fatalf("expected the subsystem without a level to follow the klog level")
+	}
+	if levels := FormatLevels(); levels != "test-gateway=4" {
+		t.Fatalf("unexpected levels %q", levels)
+	}
+}
+
+func TestLoggerFollowsVModule(t *testing.T) {
+	flags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	defer func() {
+		_ = flags.Set("vmodule", "")
+	}()
+	if err := flags.Set("vmodule", "logging_test=4"); err != nil {
+		t.Fatal(err)
+	}
+	l := Subsystem("test-vmodule")
+	if !l.V(4).Enabled() || l.V(5).Enabled() {
+		t.Fatalf("expected the -vmodule verbosity of the caller file to apply")
+	}
+	l.SetLevel(2)
+	defer l.ResetLevel()
+	if l.V(4).Enabled() {
+		t.Fatalf("expected the subsystem verbosity to override -vmodule")
+	}
+}
+
+func TestNewJSONLogger(t *testing.T) {
+	var out bytes.Buffer
+	NewJSONLogger(&out).V(5).Info("starting", subsystemKey, "node")
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry %q: %v", out.String(), err)
+	}
+	if entry["msg"] != "starting" || entry[subsystemKey] != "node" || entry["level"] != float64(5) {
+		t.Fatalf("unexpected log entry %v", entry)
+	}
+}
@@ -109,6 +109,36 @@ func (_m *NetLinkOps) ConntrackDeleteFilter(table netlink.ConntrackTableType, fa
 	return r0, r1
 }
 
+// ConntrackTableList provides a mock function with given fields: table, family
+func (_m *NetLinkOps) ConntrackTableList(table netlink.ConntrackTableType, family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+	ret := _m.Called(table, family)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConntrackTableList")
+	}
+
+	var r0 []*netlink.ConntrackFlow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(netlink.ConntrackTableType, netlink.InetFamily) ([]*netlink.ConntrackFlow, error)); ok {
+		return rf(table, family)
+	}
+	if rf, ok := ret.Get(0).(func(netlink.ConntrackTableType, netlink.InetFamily) []*netlink.ConntrackFlow); ok {
+		r0 = rf(table, family)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*netlink.ConntrackFlow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(netlink.ConntrackTableType, netlink.InetFamily) error); ok {
+		r1 = rf(table, family)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsLinkNotFoundError provides a mock function with given fields: err
 func (_m *NetLinkOps) IsLinkNotFoundError(err error) bool {
 	ret := _m.Called(err)
@@ -613,6 +643,42 @@ func (_m *NetLinkOps) RouteReplace(route *netlink.Route) error {
 	return r0
 }
 
+// RuleAdd provides a mock function with given fields: rule
+func (_m *NetLinkOps) RuleAdd(rule *netlink.Rule) error {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleAdd")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*netlink.Rule) error); ok {
+		r0 = rf(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RuleDel provides a mock function with given fields: rule
+func (_m *NetLinkOps) RuleDel(rule *netlink.Rule) error {
+	ret := _m.Called(rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleDel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*netlink.Rule) error); ok {
+		r0 = rf(rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RuleListFiltered provides a mock function with given fields: family, filter, filterMask
 func (_m *NetLinkOps) RuleListFiltered(family int, filter *netlink.Rule, filterMask uint64) ([]netlink.Rule, error) {
 	ret := _m.Called(family, filter, filterMask)
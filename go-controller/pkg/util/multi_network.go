@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -41,6 +42,8 @@ type BasicNetInfo interface {
 	JoinSubnetV6() *net.IPNet
 	JoinSubnets() []*net.IPNet
 	Vlan() uint
+	AllowedVLANs() []uint
+	PhysicalNetworkName() string
 	AllowsPersistentIPs() bool
 
 	// utility methods
@@ -243,6 +246,16 @@ func (nInfo *DefaultNetInfo) Vlan() uint {
 	return config.Gateway.VLANID
 }
 
+// AllowedVLANs returns the defaultNetConfInfo's AllowedVLANs value
+func (nInfo *DefaultNetInfo) AllowedVLANs() []uint {
+	return nil
+}
+
+// PhysicalNetworkName returns the defaultNetConfInfo's PhysicalNetworkName value
+func (nInfo *DefaultNetInfo) PhysicalNetworkName() string {
+	return types.PhysicalNetworkName
+}
+
 // AllowsPersistentIPs returns the defaultNetConfInfo's AllowPersistentIPs value
 func (nInfo *DefaultNetInfo) AllowsPersistentIPs() bool {
 	return false
@@ -257,6 +270,8 @@ type secondaryNetInfo struct {
 	topology           string
 	mtu                int
 	vlan               uint
+	allowedVLANs       []uint
+	physNetName        string
 	allowPersistentIPs bool
 
 	ipv4mode, ipv6mode bool
@@ -393,6 +408,20 @@ func (nInfo *secondaryNetInfo) Vlan() uint {
 	return nInfo.vlan
 }
 
+// AllowedVLANs returns the VLAN IDs trunked to the pods of a localnet network
+func (nInfo *secondaryNetInfo) AllowedVLANs() []uint {
+	return nInfo.allowedVLANs
+}
+
+// PhysicalNetworkName returns the physical network a localnet network is mapped to,
+// the network name unless specified
+func (nInfo *secondaryNetInfo) PhysicalNetworkName() string {
+	if nInfo.physNetName != "" {
+		return nInfo.physNetName
+	}
+	return nInfo.netName
+}
+
 // AllowsPersistentIPs returns the defaultNetConfInfo's AllowPersistentIPs value
 func (nInfo *secondaryNetInfo) AllowsPersistentIPs() bool {
 	return nInfo.allowPersistentIPs
@@ -458,6 +487,12 @@ func (nInfo *secondaryNetInfo) Equals(other BasicNetInfo) bool {
 	if nInfo.vlan != other.Vlan() {
 		return false
 	}
+	if !cmp.Equal(nInfo.allowedVLANs, other.AllowedVLANs(), cmpopts.EquateEmpty()) {
+		return false
+	}
+	if nInfo.PhysicalNetworkName() != other.PhysicalNetworkName() {
+		return false
+	}
 	if nInfo.allowPersistentIPs != other.AllowsPersistentIPs() {
 		return false
 	}
@@ -488,6 +523,8 @@ func (nInfo *secondaryNetInfo) copy() *secondaryNetInfo {
 		topology:           nInfo.topology,
 		mtu:                nInfo.mtu,
 		vlan:               nInfo.vlan,
+		allowedVLANs:       nInfo.allowedVLANs,
+		physNetName:        nInfo.physNetName,
 		allowPersistentIPs: nInfo.allowPersistentIPs,
 		ipv4mode:           nInfo.ipv4mode,
 		ipv6mode:           nInfo.ipv6mode,
@@ -551,6 +588,10 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
 	}
+	allowedVLANs, err := parseVLANRanges(netconf.AllowedVLANs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
+	}
 
 	ni := &secondaryNetInfo{
 		netName:            netconf.Name,
@@ -559,6 +600,8 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
 		excludeSubnets:     excludes,
 		mtu:                netconf.MTU,
 		vlan:               uint(netconf.VLANID),
+		allowedVLANs:       allowedVLANs,
+		physNetName:        netconf.PhysicalNetworkName,
 		allowPersistentIPs: netconf.AllowPersistentIPs,
 		nadNames:           sets.Set[string]{},
 	}
@@ -566,6 +609,44 @@ func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
 	return ni, nil
 }
 
+// parseVLANRanges parses a comma-seperated list of VLAN IDs and VLAN ID ranges, eg. "100,200-210",
+// into the sorted list of the VLAN IDs
+func parseVLANRanges(vlanRanges string) ([]uint, error) {
+	if strings.TrimSpace(vlanRanges) == "" {
+		return nil, nil
+	}
+	vlans := sets.New[uint]()
+	for _, vlanRange := range strings.Split(vlanRanges, ",") {
+		vlanRange = strings.TrimSpace(vlanRange)
+		first, last, isRange := strings.Cut(vlanRange, "-")
+		start, err := parseVLANID(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed VLANs %q: %w", vlanRanges, err)
+		}
+		end := start
+		if isRange {
+			if end, err = parseVLANID(last); err != nil {
+				return nil, fmt.Errorf("invalid allowed VLANs %q: %w", vlanRanges, err)
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid allowed VLANs %q: range %s is reversed", vlanRanges, vlanRange)
+			}
+		}
+		for vlan := start; vlan <= end; vlan++ {
+			vlans.Insert(vlan)
+		}
+	}
+	return sets.List(vlans), nil
+}
+
+func parseVLANID(vlan string) (uint, error) {
+	id, err := strconv.ParseUint(strings.TrimSpace(vlan), 10, 16)
+	if err != nil || id < 1 || id > 4094 {
+		return 0, fmt.Errorf("VLAN ID %q must be a number between 1 and 4094", vlan)
+	}
+	return uint(id), nil
+}
+
 func parseSubnets(subnetsString, excludeSubnetsString, topology string) ([]config.CIDRNetworkEntry, []*net.IPNet, error) {
 	var parseSubnets func(clusterSubnetCmd string) ([]config.CIDRNetworkEntry, error)
 	switch topology {
@@ -757,6 +838,21 @@ func ValidateNetConf(nadName string, netconf *ovncnitypes.NetConf) error {
 		return fmt.Errorf("localnet topology does not allow specifying join-subnet as services are not supported")
 	}
 
+	if (netconf.AllowedVLANs != "" || netconf.PhysicalNetworkName != "") && netconf.Topology != types.LocalnetTopology {
+		return fmt.Errorf("%s topology does not allow specifying allowedVLANs or physicalNetworkName, "+
+			"they are only valid for localnet topology", netconf.Topology)
+	}
+
+	if netconf.AllowedVLANs != "" {
+		if netconf.VLANID != 0 {
+			return fmt.Errorf("localnet topology does not allow specifying both vlanID and allowedVLANs, " +
+				"the access port of vlanID can't trunk other VLANs")
+		}
+		if _, err := parseVLANRanges(netconf.AllowedVLANs); err != nil {
+			return err
+		}
+	}
+
 	if netconf.Role == types.NetworkRolePrimary && netconf.Subnets == "" && netconf.Topology == types.Layer2Topology {
 		return fmt.Errorf("the subnet attribute must be defined for layer2 primary user defined networks")
 	}
@@ -449,6 +449,69 @@ func TestParseNetconf(t *testing.T) {
 `,
 			expectedError: fmt.Errorf("localnet topology does not allow specifying join-subnet as services are not supported"),
 		},
+		{
+			desc: "valid attachment definition for a localnet topology trunking VLANs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "allowedVLANs": "10,20-22",
+            "physicalNetworkName": "physnet1",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedNetConf: &ovncnitypes.NetConf{
+				Topology:            "localnet",
+				NADName:             "ns1/nad1",
+				MTU:                 1400,
+				AllowedVLANs:        "10,20-22",
+				PhysicalNetworkName: "physnet1",
+				NetConf:             cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
+			},
+		},
+		{
+			desc: "a localnet topology can't both tag and trunk VLANs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "vlanID": 10,
+            "allowedVLANs": "20-22",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("localnet topology does not allow specifying both vlanID and allowedVLANs, " +
+				"the access port of vlanID can't trunk other VLANs"),
+		},
+		{
+			desc: "a localnet topology with an invalid VLAN range",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "allowedVLANs": "22-20",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("invalid allowed VLANs \"22-20\": range 22-20 is reversed"),
+		},
+		{
+			desc: "a layer2 topology can't trunk VLANs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "layer2",
+            "allowedVLANs": "20-22",
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedError: fmt.Errorf("layer2 topology does not allow specifying allowedVLANs or physicalNetworkName, " +
+				"they are only valid for localnet topology"),
+		},
 		{
 			desc: "A layer2 primary UDN requires a subnet",
 			inputNetAttachDefConfigSpec: `
@@ -485,6 +548,70 @@ func TestParseNetconf(t *testing.T) {
 	}
 }
 
+func TestParseVLANRanges(t *testing.T) {
+	tests := []struct {
+		desc          string
+		vlanRanges    string
+		expectedVLANs []uint
+		expectedError string
+	}{
+		{
+			desc: "no VLANs",
+		},
+		{
+			desc:          "VLAN IDs and overlapping ranges",
+			vlanRanges:    "300, 100-102,101 ,4094",
+			expectedVLANs: []uint{100, 101, 102, 300, 4094},
+		},
+		{
+			desc:          "VLAN ID out of range",
+			vlanRanges:    "100,4095",
+			expectedError: "invalid allowed VLANs \"100,4095\": VLAN ID \"4095\" must be a number between 1 and 4094",
+		},
+		{
+			desc:          "empty VLAN ID",
+			vlanRanges:    "100,",
+			expectedError: "invalid allowed VLANs \"100,\": VLAN ID \"\" must be a number between 1 and 4094",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			vlans, err := parseVLANRanges(tc.vlanRanges)
+			if tc.expectedError != "" {
+				g.Expect(err).To(gomega.MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(vlans).To(gomega.Equal(tc.expectedVLANs))
+		})
+	}
+}
+
+func TestLocalnetPhysicalNetwork(t *testing.T) {
+	g := gomega.NewWithT(t)
+	netInfo, err := NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:      cnitypes.NetConf{Name: "tenantred"},
+		Topology:     types.LocalnetTopology,
+		AllowedVLANs: "10-11",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(netInfo.PhysicalNetworkName()).To(gomega.Equal("tenantred"))
+	g.Expect(netInfo.AllowedVLANs()).To(gomega.Equal([]uint{10, 11}))
+
+	other, err := NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:             cnitypes.NetConf{Name: "tenantred"},
+		Topology:            types.LocalnetTopology,
+		AllowedVLANs:        "10-11",
+		PhysicalNetworkName: "physnet1",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(other.PhysicalNetworkName()).To(gomega.Equal("physnet1"))
+	g.Expect(netInfo.Equals(other)).To(gomega.BeFalse())
+	g.Expect(CopyNetInfo(other).Equals(other)).To(gomega.BeTrue())
+	g.Expect((&DefaultNetInfo{}).PhysicalNetworkName()).To(gomega.Equal(types.PhysicalNetworkName))
+}
+
 func TestJoinSubnets(t *testing.T) {
 	type testConfig struct {
 		desc            string
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
@@ -21,6 +22,8 @@ const (
 	ExternalGatewayPodIPsAnnotation = "k8s.ovn.org/external-gw-pod-ips"
 	// Annotation for enabling ACL logging to controller's log file
 	AclLoggingAnnotation = "k8s.ovn.org/acl-logging"
+	// Annotation limiting the egress rate of the pods of the namespace on each node
+	NamespaceEgressRateAnnotation = "k8s.ovn.org/egress-rate"
 )
 
 func UpdateExternalGatewayPodIPsAnnotation(k kube.Interface, namespace string, exgwIPs []string) error {
@@ -50,3 +53,21 @@ func ParseRoutingExternalGWAnnotation(annotation string) (sets.Set[string], erro
 	}
 	return ipTracker, nil
 }
+
+// ParseNamespaceEgressRate returns the egress rate of the pods of the namespace on each node in kilobits per
+// second, from the egress rate annotation holding a bandwidth quantity like the kubernetes.io/egress-bandwidth
+// pod annotation, e.g. 100M. It returns 0 when the namespace is not rate limited.
+func ParseNamespaceEgressRate(annotations map[string]string) (uint64, error) {
+	value, ok := annotations[NamespaceEgressRateAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	rate, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q: %w", NamespaceEgressRateAnnotation, value, err)
+	}
+	if rate.Value() < 1000 {
+		return 0, fmt.Errorf("invalid %s annotation %q: the rate must be at least 1k", NamespaceEgressRateAnnotation, value)
+	}
+	return uint64(rate.Value() / 1000), nil
+}
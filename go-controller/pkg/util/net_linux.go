@@ -10,10 +10,12 @@ import (
 	"net"
 	"net/netip"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ndp"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
@@ -52,6 +54,9 @@ type NetLinkOps interface {
 	NeighDel(neigh *netlink.Neigh) error
 	NeighList(linkIndex, family int) ([]netlink.Neigh, error)
 	ConntrackDeleteFilter(table netlink.ConntrackTableType, family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error)
+	ConntrackTableList(table netlink.ConntrackTableType, family netlink.InetFamily) ([]*netlink.ConntrackFlow, error)
+	RuleAdd(rule *netlink.Rule) error
+	RuleDel(rule *netlink.Rule) error
 	LinkSetVfHardwareAddr(pfLink netlink.Link, vfIndex int, hwaddr net.HardwareAddr) error
 }
 
@@ -76,59 +81,59 @@ func GetNetLinkOps() NetLinkOps {
 }
 
 func (defaultNetLinkOps) LinkList() ([]netlink.Link, error) {
-	return netlink.LinkList()
+	return nodeNetlink().LinkList()
 }
 
 func (defaultNetLinkOps) LinkByName(ifaceName string) (netlink.Link, error) {
-	return netlink.LinkByName(ifaceName)
+	return nodeNetlink().LinkByName(ifaceName)
 }
 
 func (defaultNetLinkOps) LinkByIndex(index int) (netlink.Link, error) {
-	return netlink.LinkByIndex(index)
+	return nodeNetlink().LinkByIndex(index)
 }
 
 func (defaultNetLinkOps) LinkSetDown(link netlink.Link) error {
-	return netlink.LinkSetDown(link)
+	return nodeNetlink().LinkSetDown(link)
 }
 
 func (defaultNetLinkOps) LinkAdd(link netlink.Link) error {
-	return netlink.LinkAdd(link)
+	return nodeNetlink().LinkAdd(link)
 }
 
 func (defaultNetLinkOps) LinkDelete(link netlink.Link) error {
-	return netlink.LinkDel(link)
+	return nodeNetlink().LinkDel(link)
 }
 
 func (defaultNetLinkOps) LinkSetUp(link netlink.Link) error {
-	return netlink.LinkSetUp(link)
+	return nodeNetlink().LinkSetUp(link)
 }
 
 func (defaultNetLinkOps) LinkSetName(link netlink.Link, newName string) error {
-	return netlink.LinkSetName(link, newName)
+	return nodeNetlink().LinkSetName(link, newName)
 }
 
 func (defaultNetLinkOps) LinkSetNsFd(link netlink.Link, fd int) error {
-	return netlink.LinkSetNsFd(link, fd)
+	return nodeNetlink().LinkSetNsFd(link, fd)
 }
 
 func (defaultNetLinkOps) LinkSetHardwareAddr(link netlink.Link, hwaddr net.HardwareAddr) error {
-	return netlink.LinkSetHardwareAddr(link, hwaddr)
+	return nodeNetlink().LinkSetHardwareAddr(link, hwaddr)
 }
 
 func (defaultNetLinkOps) LinkSetMaster(link netlink.Link, master netlink.Link) error {
-	return netlink.LinkSetMaster(link, master)
+	return nodeNetlink().LinkSetMaster(link, master)
 }
 
 func (defaultNetLinkOps) LinkSetNoMaster(link netlink.Link) error {
-	return netlink.LinkSetNoMaster(link)
+	return nodeNetlink().LinkSetNoMaster(link)
 }
 
 func (defaultNetLinkOps) LinkSetMTU(link netlink.Link, mtu int) error {
-	return netlink.LinkSetMTU(link, mtu)
+	return nodeNetlink().LinkSetMTU(link, mtu)
 }
 
 func (defaultNetLinkOps) LinkSetTxQLen(link netlink.Link, qlen int) error {
-	return netlink.LinkSetTxQLen(link, qlen)
+	return nodeNetlink().LinkSetTxQLen(link, qlen)
 }
 
 func (defaultNetLinkOps) IsLinkNotFoundError(err error) bool {
@@ -136,55 +141,67 @@ func (defaultNetLinkOps) IsLinkNotFoundError(err error) bool {
 }
 
 func (defaultNetLinkOps) AddrList(link netlink.Link, family int) ([]netlink.Addr, error) {
-	return netlink.AddrList(link, family)
+	return nodeNetlink().AddrList(link, family)
 }
 
 func (defaultNetLinkOps) AddrDel(link netlink.Link, addr *netlink.Addr) error {
-	return netlink.AddrDel(link, addr)
+	return nodeNetlink().AddrDel(link, addr)
 }
 
 func (defaultNetLinkOps) AddrAdd(link netlink.Link, addr *netlink.Addr) error {
-	return netlink.AddrAdd(link, addr)
+	return nodeNetlink().AddrAdd(link, addr)
 }
 
 func (defaultNetLinkOps) RouteList(link netlink.Link, family int) ([]netlink.Route, error) {
-	return netlink.RouteList(link, family)
+	return nodeNetlink().RouteList(link, family)
 }
 
 func (defaultNetLinkOps) RouteDel(route *netlink.Route) error {
-	return netlink.RouteDel(route)
+	return nodeNetlink().RouteDel(route)
 }
 
 func (defaultNetLinkOps) RouteAdd(route *netlink.Route) error {
-	return netlink.RouteAdd(route)
+	return nodeNetlink().RouteAdd(route)
 }
 
 func (defaultNetLinkOps) RouteReplace(route *netlink.Route) error {
-	return netlink.RouteReplace(route)
+	return nodeNetlink().RouteReplace(route)
 }
 
 func (defaultNetLinkOps) RouteListFiltered(family int, filter *netlink.Route, filterMask uint64) ([]netlink.Route, error) {
-	return netlink.RouteListFiltered(family, filter, filterMask)
+	return nodeNetlink().RouteListFiltered(family, filter, filterMask)
 }
 
 func (defaultNetLinkOps) RuleListFiltered(family int, filter *netlink.Rule, filterMask uint64) ([]netlink.Rule, error) {
-	return netlink.RuleListFiltered(family, filter, filterMask)
+	return nodeNetlink().RuleListFiltered(family, filter, filterMask)
 }
 
 func (defaultNetLinkOps) NeighAdd(neigh *netlink.Neigh) error {
-	return netlink.NeighAdd(neigh)
+	return nodeNetlink().NeighAdd(neigh)
 }
 
 func (defaultNetLinkOps) NeighDel(neigh *netlink.Neigh) error {
-	return netlink.NeighDel(neigh)
+	return nodeNetlink().NeighDel(neigh)
 }
 
 func (defaultNetLinkOps) NeighList(linkIndex, family int) ([]netlink.Neigh, error) {
-	return netlink.NeighList(linkIndex, family)
+	return nodeNetlink().NeighList(linkIndex, family)
 }
 
 func (defaultNetLinkOps) ConntrackDeleteFilter(table netlink.ConntrackTableType, family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error) {
-	return netlink.ConntrackDeleteFilter(table, family, filter)
+	return nodeNetlink().ConntrackDeleteFilter(table, family, filter)
+}
+
+func (defaultNetLinkOps) ConntrackTableList(table netlink.ConntrackTableType, family netlink.InetFamily) ([]*netlink.ConntrackFlow, error) {
+	return nodeNetlink().ConntrackTableList(table, family)
+}
+
+func (defaultNetLinkOps) RuleAdd(rule *netlink.Rule) error {
+	return nodeNetlink().RuleAdd(rule)
+}
+
+func (defaultNetLinkOps) RuleDel(rule *netlink.Rule) error {
+	return nodeNetlink().RuleDel(rule)
 }
 
 func getFamily(ip net.IP) int {
@@ -451,6 +468,55 @@ func LinkNeighAdd(link netlink.Link, neighIP net.IP, neighMAC net.HardwareAddr)
 	return nil
 }
 
+// AdvertiseIP sends a gratuitous ARP for an IPv4 address or an unsolicited neighbor
+// advertisement for an IPv6 address out of the interface
+func AdvertiseIP(ip net.IP, ifaceName string) error {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("failed finding interface %s: %w", ifaceName, err)
+	}
+	addr, err := netip.ParseAddr(ip.String())
+	if err != nil {
+		return fmt.Errorf("failed converting net.IP to netip.Addr: %w", err)
+	}
+
+	if addr.Is4() {
+		c, err := arp.Dial(iface)
+		if err != nil {
+			return fmt.Errorf("failed dialing interface %s: %w", ifaceName, err)
+		}
+		defer c.Close()
+		p, err := arp.NewPacket(arp.OperationRequest, iface.HardwareAddr, addr, net.HardwareAddr{0, 0, 0, 0, 0, 0}, addr)
+		if err != nil {
+			return fmt.Errorf("failed create GARP: %w", err)
+		}
+		if err = c.WriteTo(p, net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err != nil {
+			return fmt.Errorf("failed sending GARP: %w", err)
+		}
+		return nil
+	}
+
+	c, _, err := ndp.Listen(iface, ndp.LinkLocal)
+	if err != nil {
+		return fmt.Errorf("failed to dial NDP connection on interface %s: %w", ifaceName, err)
+	}
+	defer c.Close()
+	m := &ndp.NeighborAdvertisement{
+		Override:      true,
+		TargetAddress: addr,
+		Options: []ndp.Option{
+			&ndp.LinkLayerAddress{
+				Direction: ndp.Target,
+				Addr:      iface.HardwareAddr,
+			},
+		},
+	}
+	if err = c.WriteTo(m, nil, netip.IPv6LinkLocalAllNodes()); err != nil {
+		return fmt.Errorf("failed sending unsolicited neighbor advertisement: %w", err)
+	}
+	return nil
+}
+
 func GetMACAddressFromARP(neighIP net.IP) (net.HardwareAddr, error) {
 	selectedIface, err := findUsableInterfaceForNetwork(neighIP)
 	if err != nil {
@@ -574,7 +640,8 @@ func DeleteConntrackServicePort(ip string, port int32, protocol kapi.Protocol, i
 }
 
 // GetFilteredInterfaceV4V6IPs returns the IP addresses for the network interface 'iface' for ipv4 and ipv6.
-// Filter out addresses that are link local, reserved for internal use or added by keepalived.
+// Filter out addresses that are link local, reserved for internal use, added by keepalived or control plane VIPs.
+// The IPv6 addresses are ordered from the most to the least stable, see sortIPv6AddrsByStability.
 func GetFilteredInterfaceV4V6IPs(iface string) ([]*net.IPNet, error) {
 	link, err := netLinkOps.LinkByName(iface)
 	if err != nil {
@@ -584,6 +651,7 @@ func GetFilteredInterfaceV4V6IPs(iface string) ([]*net.IPNet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed get link %s addresses: %v", link.Attrs().Name, err)
 	}
+	sortIPv6AddrsByStability(netlinkAddrs)
 	ips := make([]*net.IPNet, 0, len(netlinkAddrs))
 	for _, netlinkAddr := range netlinkAddrs {
 		ips = append(ips, netlinkAddr.IPNet)
@@ -592,7 +660,7 @@ func GetFilteredInterfaceV4V6IPs(iface string) ([]*net.IPNet, error) {
 }
 
 // GetFilteredInterfaceAddrs returns addresses attached to a link and filters out link local addresses, OVN reserved IPs,
-// keepalived IPs and addresses marked as secondary or deprecated.
+// keepalived IPs, control plane VIPs and addresses marked as secondary, deprecated or temporary.
 func GetFilteredInterfaceAddrs(link netlink.Link, v4, v6 bool) ([]netlink.Addr, error) {
 	var ipFamily int // value of 0 means include both IP v4 and v6 addresses
 	if v4 && !v6 {
@@ -606,7 +674,8 @@ func GetFilteredInterfaceAddrs(link netlink.Link, v4, v6 bool) ([]netlink.Addr,
 	}
 	validAddrs := make([]netlink.Addr, 0)
 	for _, addr := range addrs {
-		if addr.IP.IsLinkLocalUnicast() || IsAddressReservedForInternalUse(addr.IP) || IsAddressAddedByKeepAlived(addr) {
+		if addr.IP.IsLinkLocalUnicast() || IsAddressReservedForInternalUse(addr.IP) || IsAddressAddedByKeepAlived(addr) ||
+			IsControlPlaneVIP(addr.IP) {
 			continue
 		}
 		// Ignore addresses marked as secondary or deprecated since they may
@@ -616,11 +685,56 @@ func GetFilteredInterfaceAddrs(link netlink.Link, v4, v6 bool) ([]netlink.Addr,
 		if (addr.Flags & (unix.IFA_F_SECONDARY | unix.IFA_F_DEPRECATED)) != 0 {
 			continue
 		}
+		// Ignore the temporary addresses of the IPv6 privacy extensions (RFC 8981),
+		// they are regenerated periodically.
+		if (addr.Flags & unix.IFA_F_TEMPORARY) != 0 {
+			continue
+		}
 		validAddrs = append(validAddrs, addr)
 	}
 	return validAddrs, nil
 }
 
+// ipv6AddrStability ranks how stable an IPv6 address is: the autoconfigured address with the interface
+// identifier of config.Gateway.IPv6Token first, then the statically configured addresses, then the
+// autoconfigured addresses with an EUI-64 interface identifier, then the others.
+func ipv6AddrStability(addr netlink.Addr) int {
+	ip := addr.IP.To16()
+	if token := net.ParseIP(config.Gateway.IPv6Token); token != nil && bytes.Equal(ip[8:], token.To16()[8:]) {
+		return 3
+	}
+	if addr.Flags&unix.IFA_F_PERMANENT != 0 {
+		return 2
+	}
+	if ip[11] == 0xff && ip[12] == 0xfe {
+		return 1
+	}
+	return 0
+}
+
+// sortIPv6AddrsByStability orders the IPv6 addresses in place by their ipv6AddrStability and then by
+// their preferred lifetime, the longest first, leaving the IPv4 addresses where they are, so that the
+// first IPv6 address is the one to select as the address of the interface.
+func sortIPv6AddrsByStability(addrs []netlink.Addr) {
+	var indexes []int
+	var v6Addrs []netlink.Addr
+	for i, addr := range addrs {
+		if utilnet.IsIPv6(addr.IP) {
+			indexes = append(indexes, i)
+			v6Addrs = append(v6Addrs, addr)
+		}
+	}
+	sort.SliceStable(v6Addrs, func(i, j int) bool {
+		if si, sj := ipv6AddrStability(v6Addrs[i]), ipv6AddrStability(v6Addrs[j]); si != sj {
+			return si > sj
+		}
+		return uint32(v6Addrs[i].PreferedLft) > uint32(v6Addrs[j].PreferedLft)
+	})
+	for i, index := range indexes {
+		addrs[index] = v6Addrs[i]
+	}
+}
+
 func IsAddressReservedForInternalUse(addr net.IP) bool {
 	var subnetStr string
 	if addr.To4() != nil {
@@ -646,6 +760,17 @@ func IsAddressAddedByKeepAlived(addr netlink.Addr) bool {
 	return strings.HasSuffix(addr.Label, "vip")
 }
 
+// IsControlPlaneVIP returns true if the address is within the control plane VIPs of the gateway configuration,
+// moved between the nodes by kube-vip or keepalived without the label IsAddressAddedByKeepAlived checks
+func IsControlPlaneVIP(ip net.IP) bool {
+	for _, vip := range config.Gateway.GetControlPlaneVIPs() {
+		if vip.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetIPv6OnSubnet when given an IPv6 address with a 128 prefix for an interface,
 // looks for possible broadest subnet on-link routes and returns the same address
 // with the found subnet prefix. Otherwise it returns the provided address unchanged.
@@ -733,7 +858,7 @@ func GetIPFamily(v6 bool) int {
 }
 
 func (defaultNetLinkOps) LinkSetVfHardwareAddr(pfLink netlink.Link, vfIndex int, hwaddr net.HardwareAddr) error {
-	return netlink.LinkSetVfHardwareAddr(pfLink, vfIndex, hwaddr)
+	return nodeNetlink().LinkSetVfHardwareAddr(pfLink, vfIndex, hwaddr)
 }
 
 func findUsableInterfaceForNetwork(ipAddr net.IP) (*net.Interface, error) {
@@ -13,6 +13,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 )
 
 func TestGetFamily(t *testing.T) {
@@ -1192,3 +1193,106 @@ func getAnyNetwork(v6 bool) *net.IPNet {
 	}
 	return ipNet
 }
+
+func TestSortIPv6AddrsByStability(t *testing.T) {
+	addr := func(cidr string, flags, preferredLft int) netlink.Addr {
+		return netlink.Addr{IPNet: ovntest.MustParseIPNet(cidr), Flags: flags, PreferedLft: preferredLft}
+	}
+	tests := []struct {
+		desc   string
+		token  string
+		input  []netlink.Addr
+		outExp []string
+	}{
+		{
+			desc: "prefers the static addresses, then the EUI-64 ones, then the longest preferred lifetime",
+			input: []netlink.Addr{
+				addr("fd00::1234:5678:9abc:def0/64", 0, 3600),
+				addr("172.18.0.2/16", unix.IFA_F_PERMANENT, 0),
+				addr("fd00::a8bb:ccff:fedd:eeff/64", 0, 1800),
+				addr("fd00::4321:8765:cba9:fed/64", 0, 7200),
+				addr("fd00::10/64", unix.IFA_F_PERMANENT, 0xffffffff),
+			},
+			outExp: []string{
+				"fd00::10/64",
+				"172.18.0.2/16",
+				"fd00::a8bb:ccff:fedd:eeff/64",
+				"fd00::4321:8765:cba9:fed/64",
+				"fd00::1234:5678:9abc:def0/64",
+			},
+		},
+		{
+			desc:  "prefers the address with the interface identifier of the token",
+			token: "::1:2:3:4",
+			input: []netlink.Addr{
+				addr("fd00::10/64", unix.IFA_F_PERMANENT, 0xffffffff),
+				addr("fd00::a8bb:ccff:fedd:eeff/64", 0, 1800),
+				addr("fd00::1:2:3:4/64", 0, 1800),
+			},
+			outExp: []string{
+				"fd00::1:2:3:4/64",
+				"fd00::10/64",
+				"fd00::a8bb:ccff:fedd:eeff/64",
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.Gateway.IPv6Token = tc.token
+			defer func() { config.Gateway.IPv6Token = "" }()
+			sortIPv6AddrsByStability(tc.input)
+			var res []string
+			for _, addr := range tc.input {
+				res = append(res, addr.IPNet.String())
+			}
+			assert.Equal(t, tc.outExp, res)
+		})
+	}
+}
+
+func TestGetFilteredInterfaceAddrsControlPlaneVIP(t *testing.T) {
+	mockNetLinkOps := new(mocks.NetLinkOps)
+	mockLink := new(netlink_mocks.Link)
+	// below is defined in net_linux.go
+	netLinkOps = mockNetLinkOps
+
+	nodeAddr := netlink.Addr{IPNet: ovntest.MustParseIPNet("10.1.1.10/24")}
+	vipAddr := netlink.Addr{IPNet: ovntest.MustParseIPNet("10.1.1.100/32")}
+	tests := []struct {
+		desc   string
+		vips   string
+		input  []netlink.Addr
+		outExp []netlink.Addr
+	}{
+		{
+			desc:   "the VIP failed over to the node is filtered out",
+			vips:   "10.1.1.100, fd00::100",
+			input:  []netlink.Addr{vipAddr, nodeAddr},
+			outExp: []netlink.Addr{nodeAddr},
+		},
+		{
+			desc:   "the VIP failed over away from the node leaves the same addresses",
+			vips:   "10.1.1.100, fd00::100",
+			input:  []netlink.Addr{nodeAddr},
+			outExp: []netlink.Addr{nodeAddr},
+		},
+		{
+			desc:   "the address is kept when it is not a control plane VIP",
+			input:  []netlink.Addr{vipAddr, nodeAddr},
+			outExp: []netlink.Addr{vipAddr, nodeAddr},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			config.Gateway.ControlPlaneVIPs = tc.vips
+			defer func() { config.Gateway.ControlPlaneVIPs = "" }()
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{tc.input, nil}},
+			})
+			res, err := GetFilteredInterfaceAddrs(mockLink, true, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.outExp, res)
+			mockNetLinkOps.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+//go:build linux
+// +build linux
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// nodeNetNS is the network namespace of the node when it is not the one ovnkube-node runs in
+	nodeNetNS ns.NetNS
+	// nodeNetNSHandle is the handle of nodeNetNS for the netlink subscriptions
+	nodeNetNSHandle *netns.NsHandle
+	// nodeNetlinkHandle is the netlink handle of nodeNetNS
+	nodeNetlinkHandle *netlink.Handle
+	// ovnkubeNetNS is the handle of the network namespace ovnkube-node runs in when nodeNetNS is set
+	ovnkubeNetNS netns.NsHandle
+	// currentNetlinkHandle is the netlink handle of the network namespace of the calling thread, as the netlink
+	// package functions
+	currentNetlinkHandle = &netlink.Handle{}
+)
+
+// SetNodeNetNS configures the links, addresses, routes, rules and iptables rules of the node in the network
+// namespace at path instead of the one ovnkube-node runs in: the netlink operations of GetNetLinkOps, the netlink
+// subscriptions of the callers of NodeNetNSHandle, the iptables helpers and the ip commands all target it. It must
+// be called before any of them is used.
+func SetNodeNetNS(path string) error {
+	netNS, err := ns.GetNS(path)
+	if err != nil {
+		return fmt.Errorf("failed to open the node network namespace %s: %w", path, err)
+	}
+	handle, err := netns.GetFromPath(path)
+	if err != nil {
+		netNS.Close()
+		return fmt.Errorf("failed to get the handle of the node network namespace %s: %w", path, err)
+	}
+	nlHandle, err := netlink.NewHandleAt(handle)
+	if err != nil {
+		netNS.Close()
+		handle.Close()
+		return fmt.Errorf("failed to create the netlink handle of the node network namespace %s: %w", path, err)
+	}
+	current, err := netns.Get()
+	if err != nil {
+		netNS.Close()
+		handle.Close()
+		nlHandle.Close()
+		return fmt.Errorf("failed to get the current network namespace: %w", err)
+	}
+	nodeNetNS = netNS
+	nodeNetNSHandle = &handle
+	nodeNetlinkHandle = nlHandle
+	ovnkubeNetNS = current
+	klog.Infof("Configuring the node networking in network namespace %s", path)
+	return nil
+}
+
+// nodeNetlink returns the netlink handle of the network namespace of the node for the netlink operations made from
+// the network namespace ovnkube-node runs in, and the one of the network namespace of the calling thread for the
+// others, e.g. the operations made in the network namespace of a pod.
+func nodeNetlink() *netlink.Handle {
+	if nodeNetlinkHandle == nil {
+		return currentNetlinkHandle
+	}
+	threadNetNS, err := netns.Get()
+	if err != nil {
+		return nodeNetlinkHandle
+	}
+	defer threadNetNS.Close()
+	if threadNetNS.Equal(ovnkubeNetNS) {
+		return nodeNetlinkHandle
+	}
+	return currentNetlinkHandle
+}
+
+// NodeNetNSHandle returns the handle of the network namespace of the node for the netlink subscriptions, nil
+// for the current network namespace
+func NodeNetNSHandle() *netns.NsHandle {
+	return nodeNetNSHandle
+}
+
+// GetNodeNetNS returns the network namespace of the node, it must be closed by the caller
+func GetNodeNetNS() (ns.NetNS, error) {
+	if nodeNetNS == nil {
+		return ns.GetCurrentNS()
+	}
+	return ns.GetNS(nodeNetNS.Path())
+}
+
+// DoInNodeNetNS runs f in the network namespace of the node. The commands f runs are run in it too.
+func DoInNodeNetNS(f func() error) error {
+	if nodeNetNS == nil {
+		return f()
+	}
+	return nodeNetNS.Do(func(ns.NetNS) error {
+		return f()
+	})
+}
+
+// nodeNetNSIPTables runs the iptables commands of an IPTablesHelper in the network namespace of the node
+type nodeNetNSIPTables struct {
+	ipt IPTablesHelper
+}
+
+func newNodeNetNSIPTables(proto iptables.Protocol) (IPTablesHelper, error) {
+	var ipt IPTablesHelper
+	err := DoInNodeNetNS(func() error {
+		var err error
+		ipt, err = iptables.NewWithProtocol(proto)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if nodeNetNS == nil {
+		return ipt, nil
+	}
+	return &nodeNetNSIPTables{ipt: ipt}, nil
+}
+
+func (n *nodeNetNSIPTables) List(table, chain string) (rules []string, err error) {
+	err = DoInNodeNetNS(func() error {
+		rules, err = n.ipt.List(table, chain)
+		return err
+	})
+	return rules, err
+}
+
+func (n *nodeNetNSIPTables) ListWithCounters(table, chain string) (rules []string, err error) {
+	err = DoInNodeNetNS(func() error {
+		rules, err = n.ipt.ListWithCounters(table, chain)
+		return err
+	})
+	return rules, err
+}
+
+func (n *nodeNetNSIPTables) ListChains(table string) (chains []string, err error) {
+	err = DoInNodeNetNS(func() error {
+		chains, err = n.ipt.ListChains(table)
+		return err
+	})
+	return chains, err
+}
+
+func (n *nodeNetNSIPTables) ClearChain(table, chain string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.ClearChain(table, chain)
+	})
+}
+
+func (n *nodeNetNSIPTables) DeleteChain(table, chain string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.DeleteChain(table, chain)
+	})
+}
+
+func (n *nodeNetNSIPTables) NewChain(table, chain string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.NewChain(table, chain)
+	})
+}
+
+func (n *nodeNetNSIPTables) Exists(table, chain string, rulespec ...string) (exists bool, err error) {
+	err = DoInNodeNetNS(func() error {
+		exists, err = n.ipt.Exists(table, chain, rulespec...)
+		return err
+	})
+	return exists, err
+}
+
+func (n *nodeNetNSIPTables) Insert(table, chain string, pos int, rulespec ...string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.Insert(table, chain, pos, rulespec...)
+	})
+}
+
+func (n *nodeNetNSIPTables) Append(table, chain string, rulespec ...string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.Append(table, chain, rulespec...)
+	})
+}
+
+func (n *nodeNetNSIPTables) Delete(table, chain string, rulespec ...string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.Delete(table, chain, rulespec...)
+	})
+}
+
+func (n *nodeNetNSIPTables) Restore(table string, rulesMap map[string][][]string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.Restore(table, rulesMap)
+	})
+}
+
+func (n *nodeNetNSIPTables) ChangePolicy(table, chain, target string) error {
+	return DoInNodeNetNS(func() error {
+		return n.ipt.ChangePolicy(table, chain, target)
+	})
+}
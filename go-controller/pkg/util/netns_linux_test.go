@@ -0,0 +1,68 @@
+package util
+
+import (
+	"runtime"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+)
+
+var _ = ginkgo.Describe("Node network namespace", func() {
+	var nodeNS, podNS ns.NetNS
+
+	ginkgo.BeforeEach(func() {
+		var err error
+		nodeNS, err = testutils.NewNS()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		podNS, err = testutils.NewNS()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.AfterEach(func() {
+		if nodeNetNS != nil {
+			nodeNetlinkHandle.Close()
+			nodeNetNSHandle.Close()
+			ovnkubeNetNS.Close()
+			gomega.Expect(nodeNetNS.Close()).To(gomega.Succeed())
+			nodeNetNS, nodeNetNSHandle, nodeNetlinkHandle = nil, nil, nil
+		}
+		for _, netNS := range []ns.NetNS{nodeNS, podNS} {
+			gomega.Expect(netNS.Close()).To(gomega.Succeed())
+			gomega.Expect(testutils.UnmountNS(netNS)).To(gomega.Succeed())
+		}
+	})
+
+	ovntest.OnSupportedPlatformsIt("configures the node links in the node network namespace but the pod links in the pod one", func() {
+		// the thread of the spec stays in the network namespace ovnkube-node runs in
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		gomega.Expect(SetNodeNetNS(nodeNS.Path())).To(gomega.Succeed())
+
+		gomega.Expect(GetNetLinkOps().LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "node0"}})).To(gomega.Succeed())
+		gomega.Expect(podNS.Do(func(ns.NetNS) error {
+			return GetNetLinkOps().LinkAdd(&netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: "pod0"}})
+		})).To(gomega.Succeed())
+
+		gomega.Expect(nodeNS.Do(func(ns.NetNS) error {
+			_, err := netlink.LinkByName("node0")
+			return err
+		})).To(gomega.Succeed())
+		gomega.Expect(podNS.Do(func(ns.NetNS) error {
+			_, err := netlink.LinkByName("pod0")
+			return err
+		})).To(gomega.Succeed())
+		_, err := netlink.LinkByName("node0")
+		gomega.Expect(err).To(gomega.HaveOccurred())
+
+		// the functions run by DoInNodeNetNS, and the commands they run, are in the node network namespace too
+		gomega.Expect(DoInNodeNetNS(func() error {
+			_, err := netlink.LinkByName("node0")
+			return err
+		})).To(gomega.Succeed())
+	})
+})
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package util
+
+// DoInNodeNetNS runs f, the network namespace of the node is only supported on linux platform
+func DoInNodeNetNS(f func() error) error {
+	return f()
+}
@@ -273,6 +273,71 @@ func NicToBridge(iface string) (string, error) {
 	return bridge, nil
 }
 
+// GetVLANSubInterfaceName returns the name of the VLAN sub-interface of 'iface' tagged with 'vlanID'
+func GetVLANSubInterfaceName(iface string, vlanID uint) string {
+	return fmt.Sprintf("%s.%d", iface, vlanID)
+}
+
+// EnsureVLANSubInterface creates the VLAN sub-interface of 'iface' tagged with 'vlanID' unless it exists
+// and moves the IP addresses and routes of 'iface' to it. The sub-interface does not survive a reboot,
+// in which case it is created again and the addresses and routes configured on 'iface' at boot are
+// moved to it again.
+func EnsureVLANSubInterface(iface string, vlanID uint) (string, error) {
+	name := GetVLANSubInterfaceName(iface, vlanID)
+	// the interface names are limited to IFNAMSIZ bytes, including the terminating null byte
+	if len(name) >= syscall.IFNAMSIZ {
+		return "", fmt.Errorf("VLAN sub-interface name %s is longer than %d characters", name, syscall.IFNAMSIZ-1)
+	}
+	ifaceLink, err := netLinkOps.LinkByName(iface)
+	if err != nil {
+		return "", err
+	}
+
+	vlanLink, err := netLinkOps.LinkByName(name)
+	if err != nil {
+		if !netLinkOps.IsLinkNotFoundError(err) {
+			return "", err
+		}
+		vlan := &netlink.Vlan{
+			LinkAttrs: netlink.LinkAttrs{Name: name, ParentIndex: ifaceLink.Attrs().Index},
+			VlanId:    int(vlanID),
+		}
+		if err = netLinkOps.LinkAdd(vlan); err != nil {
+			return "", fmt.Errorf("failed to create VLAN sub-interface %s: %w", name, err)
+		}
+		klog.Infof("Successfully created VLAN sub-interface %q", name)
+		if vlanLink, err = netLinkOps.LinkByName(name); err != nil {
+			return "", err
+		}
+	} else if vlan, ok := vlanLink.(*netlink.Vlan); !ok || vlan.VlanId != int(vlanID) ||
+		vlan.Attrs().ParentIndex != ifaceLink.Attrs().Index {
+		return "", fmt.Errorf("interface %s is not the VLAN %d sub-interface of %s", name, vlanID, iface)
+	}
+
+	// Get ip addresses and routes before any real operations.
+	family := syscall.AF_UNSPEC
+	addrs, err := netLinkOps.AddrList(ifaceLink, family)
+	if err != nil {
+		return "", err
+	}
+	routes, err := netLinkOps.RouteList(ifaceLink, family)
+	if err != nil {
+		return "", err
+	}
+
+	// save ip addresses to the sub-interface, which also brings it up.
+	if err = saveIPAddress(ifaceLink, vlanLink, addrs); err != nil {
+		return "", err
+	}
+
+	// save routes to the sub-interface.
+	if err = saveRoute(ifaceLink, vlanLink, routes); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
 // BridgeToNic moves the IP address and routes of internal port of the bridge to
 // underlying NIC interface and deletes the OVS bridge.
 func BridgeToNic(bridge string) error {
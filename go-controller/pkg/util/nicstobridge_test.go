@@ -572,6 +572,103 @@ func TestNicToBridge(t *testing.T) {
 	}
 }
 
+func TestEnsureVLANSubInterface(t *testing.T) {
+	mockNetLinkOps := new(mocks.NetLinkOps)
+	mockLink := new(netlink_mocks.Link)
+	// below is defined in net_linux.go
+	netLinkOps = mockNetLinkOps
+	vlanLink := &netlink.Vlan{LinkAttrs: netlink.LinkAttrs{Name: "eth0.100", Index: 3, ParentIndex: 2}, VlanId: 100}
+	tests := []struct {
+		desc                     string
+		outIface                 string
+		errExp                   bool
+		onRetArgsNetLinkLibOpers []ovntest.TestifyMockHelper
+		onRetArgsLinkIfaceOpers  []ovntest.TestifyMockHelper
+	}{
+		{
+			desc:   "invalid interface name fails to return a link",
+			errExp: true,
+			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			},
+		},
+		{
+			desc:   "existing interface with the sub-interface name and another VLAN ID fails",
+			errExp: true,
+			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Vlan{VlanId: 200}, nil}},
+			},
+		},
+		{
+			desc:   "creating the sub-interface fails",
+			errExp: true,
+			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+				{OnCallMethodName: "IsLinkNotFoundError", OnCallMethodArgType: []string{"*errors.errorString"}, RetArgList: []interface{}{true}},
+				{OnCallMethodName: "LinkAdd", OnCallMethodArgType: []string{"*netlink.Vlan"}, RetArgList: []interface{}{fmt.Errorf("mock error")}},
+			},
+			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "eth0", Index: 2}}},
+			},
+		},
+		{
+			desc:     "creating the sub-interface and moving the IP addresses and routes of the interface to it succeeds",
+			outIface: "eth0.100",
+			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+				{OnCallMethodName: "IsLinkNotFoundError", OnCallMethodArgType: []string{"*errors.errorString"}, RetArgList: []interface{}{true}},
+				{OnCallMethodName: "LinkAdd", OnCallMethodArgType: []string{"*netlink.Vlan"}, RetArgList: []interface{}{nil}},
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{vlanLink, nil}},
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 2}}, nil}},
+				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*netlink.Vlan"}, RetArgList: []interface{}{nil}},
+				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
+				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
+			},
+			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "eth0", Index: 2}}},
+			},
+		},
+		{
+			desc:     "an existing sub-interface is reused",
+			outIface: "eth0.100",
+			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{vlanLink, nil}},
+				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
+				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{}, nil}},
+				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*netlink.Vlan"}, RetArgList: []interface{}{nil}},
+			},
+			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "eth0", Index: 2}}},
+			},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
+			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
+
+			res, err := EnsureVLANSubInterface("eth0", 100)
+			t.Log(res, err)
+			if tc.errExp {
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.outIface, res)
+			mockNetLinkOps.AssertExpectations(t)
+			mockLink.AssertExpectations(t)
+		})
+	}
+	// the sub-interface name would be truncated by the kernel
+	_, err := EnsureVLANSubInterface("enp175s0f0np0", 1000)
+	assert.EqualError(t, err, "VLAN sub-interface name enp175s0f0np0.1000 is longer than 15 characters")
+}
+
 func TestBridgeToNic(t *testing.T) {
 	mockKexecIface := new(mock_k8s_io_utils_exec.Interface)
 	mockExecRunner := new(mocks.ExecRunner)
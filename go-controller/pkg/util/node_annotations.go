@@ -7,6 +7,8 @@ import (
 	"net"
 	"net/netip"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gaissmai/cidrtree"
 	corev1 "k8s.io/api/core/v1"
@@ -119,6 +121,84 @@ const (
 	// cluster.
 	OvnNodeEncapIp = "k8s.ovn.org/encap-ip"
 
+	// OvnNodeZoneReachability is the reachability of the remote zones from the node through the
+	// transit switch. It is set by ovnkube-node in multi-zone interconnect mode, e.g.
+	// k8s.ovn.org/zone-reachability: |
+	//   {
+	//     "zone-b": {"reachable": true},
+	//     "zone-c": {"reachable": false}
+	//   }
+	OvnNodeZoneReachability = "k8s.ovn.org/zone-reachability"
+
+	// OvnNodeZoneMigration requests the migration of the node to another zone at runtime. It is set by
+	// the administrator with the zone and the address of its Southbound database, e.g.
+	// k8s.ovn.org/zone-migration: '{"zone": "zone-b", "sb-address": "ssl:10.0.0.10:9642"}'
+	OvnNodeZoneMigration = "k8s.ovn.org/zone-migration"
+
+	// OvnNodeZoneMigrationStatus is the status of the zone migration of the node. It is set by ovnkube-node, e.g.
+	// k8s.ovn.org/zone-migration-status: '{"zone": "zone-b", "phase": "Pending", "message": "waiting for the node to be cordoned"}'
+	OvnNodeZoneMigrationStatus = "k8s.ovn.org/zone-migration-status"
+
+	// OvnNodeHwOffloadStatus is the OVS hardware offload status of the node. It is set by ovnkube-node on the
+	// nodes with OVS hardware offload enabled, the offload state of each representor port is only reported in
+	// metrics, e.g.
+	// k8s.ovn.org/hw-offload-status: '{"enabled": true, "functioning": true, "tc-policy": "none"}'
+	OvnNodeHwOffloadStatus = "k8s.ovn.org/hw-offload-status"
+
+	// OvnNodeProbeIntervals overrides the cluster probe intervals of ovn-controller on the node. It is set by
+	// the administrator, the intervals not set keep their cluster value, e.g.
+	// k8s.ovn.org/probe-intervals: '{"openflow-probe-interval": 300, "remote-probe-interval": 300000, "bundle-idle-timeout": 300}'
+	OvnNodeProbeIntervals = "k8s.ovn.org/probe-intervals"
+
+	// OvnNodeOVSUpgradeQuiesce asks ovnkube-node to quiesce while OVS or ovn-controller is upgraded on the
+	// node. It is set by the upgrade agent with an ID of the upgrade and, optionally, the number of seconds
+	// after which ovnkube-node resumes on its own, e.g.
+	// k8s.ovn.org/ovs-upgrade-quiesce: '{"id": "ovs-3.4.1", "timeout": 600}'
+	OvnNodeOVSUpgradeQuiesce = "k8s.ovn.org/ovs-upgrade-quiesce"
+
+	// OvnNodeOVSUpgradeQuiesceStatus is the status of the quiesce requested for an OVS upgrade. It is set by
+	// ovnkube-node, e.g.
+	// k8s.ovn.org/ovs-upgrade-quiesce-status: '{"id": "ovs-3.4.1", "phase": "Quiesced", "resume-at": "2024-05-02T10:10:00Z"}'
+	OvnNodeOVSUpgradeQuiesceStatus = "k8s.ovn.org/ovs-upgrade-quiesce-status"
+
+	// OvnNodeChassisInfo mirrors the Southbound database chassis of the node, so that the tooling and the
+	// other nodes can read it without access to the Southbound database. It is set by ovnkube-node, e.g.
+	// k8s.ovn.org/node-chassis-info: '{"chassis-id": "1a2b...", "hostname": "node1", "encaps": [{"type": "geneve", "ip": "10.0.0.1", "port": 6081}]}'
+	OvnNodeChassisInfo = "k8s.ovn.org/node-chassis-info"
+
+	// OvnNodePodSNATPortRanges holds the ranges of source ports of the node IP the gateway router SNATs the egress
+	// traffic of the local pods to, keyed by pod IP. It is set by ovnkube-node when the gateway pod SNAT port
+	// range size is configured, e.g.
+	// k8s.ovn.org/pod-snat-port-ranges: '{"10.244.1.5": "32768-33023", "10.244.1.6": "33024-33279"}'
+	OvnNodePodSNATPortRanges = "k8s.ovn.org/pod-snat-port-ranges"
+
+	// OvnNodePauseReconciliation pauses the reconciliation of the host networking by ovnkube-node, so that
+	// operators can debug the host state without ovnkube-node repairing it. It is set by the operators, e.g.
+	// k8s.ovn.org/pause-node-reconciliation: "true"
+	OvnNodePauseReconciliation = "k8s.ovn.org/pause-node-reconciliation"
+
+	// OvnNodeEgressRole is the egress role of the node set by the administrator, it overrides the egress role
+	// configured for ovnkube-node, e.g.
+	// k8s.ovn.org/egress-role: non-egress
+	OvnNodeEgressRole = "k8s.ovn.org/egress-role"
+
+	// OvnNodeIPExclude overrides the node-ip-exclude configuration of ovnkube-node on the node, the comma
+	// separated CIDRs and shell patterns of interface names never selected for the encap IP or the gateway
+	// interface when they are autodetected. It is set by the administrator and read when ovnkube-node starts, e.g.
+	// k8s.ovn.org/node-ip-exclude: "192.168.100.0/24,bmc*"
+	OvnNodeIPExclude = "k8s.ovn.org/node-ip-exclude"
+
+	// OvnNodeExternalSubnetClaims holds the subnets claimed on the node by the agents of other networks, e.g. the
+	// tunnels of a multi-cluster network, keyed by agent. ovnkube-node neither masquerades the traffic of the pods
+	// to the claimed subnets nor routes the claimed subnets via OVN, e.g.
+	// k8s.ovn.org/external-subnet-claims: '{"submariner": ["10.2.0.0/16", "100.66.0.0/16"]}'
+	OvnNodeExternalSubnetClaims = "k8s.ovn.org/external-subnet-claims"
+
+	// OvnNodeEgressReadyLabel is the node label set by ovnkube-node to "true" once the egress controllers run
+	// on an egress node, and to "false" on a non-egress node. The egress IPs and egress services are not
+	// assigned to a node labeled "false".
+	OvnNodeEgressReadyLabel = "k8s.ovn.org/egress-ready"
+
 	/** HACK BEGIN **/
 	// TODO(tssurya): Remove this annotation a few months from now (when one or two release jump
 	// upgrades are done). This has been added only to minimize disruption for upgrades when
@@ -1292,6 +1372,412 @@ func NodeZoneAnnotationChanged(oldNode, newNode *corev1.Node) bool {
 }
 
 
+// ZoneReachability is the reachability of a remote zone through the transit switch
+type ZoneReachability struct {
+	Reachable bool `json:"reachable"`
+}
+
+// SetNodeZoneReachability sets the reachability of the remote zones in the "OvnNodeZoneReachability"
+// node annotation, the annotation is removed when there are no remote zones
+func SetNodeZoneReachability(nodeAnnotator kube.Annotator, reachability map[string]ZoneReachability) error {
+	if len(reachability) == 0 {
+		nodeAnnotator.Delete(OvnNodeZoneReachability)
+		return nil
+	}
+	return nodeAnnotator.Set(OvnNodeZoneReachability, reachability)
+}
+
+// ParseNodeZoneReachability returns the reachability of the remote zones from the node
+func ParseNodeZoneReachability(node *kapi.Node) (map[string]ZoneReachability, error) {
+	annotation, ok := node.Annotations[OvnNodeZoneReachability]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeZoneReachability, node.Name)
+	}
+	reachability := map[string]ZoneReachability{}
+	if err := json.Unmarshal([]byte(annotation), &reachability); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeZoneReachability, annotation, node.Name, err)
+	}
+	return reachability, nil
+}
+
+// Zone migration phases reported in the "OvnNodeZoneMigrationStatus" node annotation
+const (
+	ZoneMigrationPending   = "Pending"
+	ZoneMigrationCompleted = "Completed"
+	ZoneMigrationFailed    = "Failed"
+)
+
+// ZoneMigration is a request to move the node to another zone
+type ZoneMigration struct {
+	Zone      string `json:"zone"`
+	SBAddress string `json:"sb-address"`
+}
+
+// ZoneMigrationStatus is the status of the zone migration of the node
+type ZoneMigrationStatus struct {
+	Zone    string `json:"zone"`
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+}
+
+// ParseNodeZoneMigration returns the validated zone migration requested for the node
+func ParseNodeZoneMigration(node *kapi.Node) (*ZoneMigration, error) {
+	annotation, ok := node.Annotations[OvnNodeZoneMigration]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeZoneMigration, node.Name)
+	}
+	migration := &ZoneMigration{}
+	if err := json.Unmarshal([]byte(annotation), migration); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeZoneMigration, annotation, node.Name, err)
+	}
+	if migration.Zone == "" || migration.SBAddress == "" {
+		return nil, fmt.Errorf("%s annotation %q for node %q must provide the zone and its sb-address",
+			OvnNodeZoneMigration, annotation, node.Name)
+	}
+	return migration, nil
+}
+
+// NodeZoneMigrationAnnotationChanged returns true if the OvnNodeZoneMigration annotation changed for the node
+func NodeZoneMigrationAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodeZoneMigration] != newNode.Annotations[OvnNodeZoneMigration]
+}
+
+// SetNodeZoneMigrationStatus sets the zone migration status in the "OvnNodeZoneMigrationStatus" node
+// annotation, the annotation is removed when status is nil
+func SetNodeZoneMigrationStatus(nodeAnnotator kube.Annotator, status *ZoneMigrationStatus) error {
+	if status == nil {
+		nodeAnnotator.Delete(OvnNodeZoneMigrationStatus)
+		return nil
+	}
+	return nodeAnnotator.Set(OvnNodeZoneMigrationStatus, status)
+}
+
+// ParseNodeZoneMigrationStatus returns the zone migration status of the node
+func ParseNodeZoneMigrationStatus(node *kapi.Node) (*ZoneMigrationStatus, error) {
+	annotation, ok := node.Annotations[OvnNodeZoneMigrationStatus]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeZoneMigrationStatus, node.Name)
+	}
+	status := &ZoneMigrationStatus{}
+	if err := json.Unmarshal([]byte(annotation), status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeZoneMigrationStatus, annotation, node.Name, err)
+	}
+	return status, nil
+}
+
+// HwOffloadStatus is the OVS hardware offload status of a node
+type HwOffloadStatus struct {
+	Enabled bool `json:"enabled"`
+	// Functioning is set when hardware offload is enabled and no representor has datapath flows
+	// without any of them offloaded
+	Functioning bool   `json:"functioning"`
+	TCPolicy    string `json:"tc-policy,omitempty"`
+}
+
+// SetNodeHwOffloadStatus sets the hardware offload status in the "OvnNodeHwOffloadStatus" node annotation
+func SetNodeHwOffloadStatus(nodeAnnotator kube.Annotator, status *HwOffloadStatus) error {
+	return nodeAnnotator.Set(OvnNodeHwOffloadStatus, status)
+}
+
+// ParseNodeHwOffloadStatus returns the hardware offload status of the node
+func ParseNodeHwOffloadStatus(node *kapi.Node) (*HwOffloadStatus, error) {
+	annotation, ok := node.Annotations[OvnNodeHwOffloadStatus]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeHwOffloadStatus, node.Name)
+	}
+	status := &HwOffloadStatus{}
+	if err := json.Unmarshal([]byte(annotation), status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeHwOffloadStatus, annotation, node.Name, err)
+	}
+	return status, nil
+}
+
+// OVS upgrade quiesce phases reported in the "OvnNodeOVSUpgradeQuiesceStatus" node annotation
+const (
+	OVSUpgradeQuiesced = "Quiesced"
+	OVSUpgradeTimedOut = "TimedOut"
+	OVSUpgradeFailed   = "Failed"
+)
+
+// OVSUpgradeQuiesce is a request to quiesce the node while OVS is upgraded
+type OVSUpgradeQuiesce struct {
+	ID string `json:"id"`
+	// Timeout is the number of seconds after which the node resumes on its own, the default timeout
+	// applies when not set
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// OVSUpgradeQuiesceStatus is the status of the quiesce of the node for an OVS upgrade
+type OVSUpgradeQuiesceStatus struct {
+	ID    string `json:"id"`
+	Phase string `json:"phase"`
+	// ResumeAt is when the quiesced node resumes on its own
+	ResumeAt *time.Time `json:"resume-at,omitempty"`
+	Message  string       `json:"message,omitempty"`
+}
+
+// ParseNodeOVSUpgradeQuiesce returns the validated quiesce requested for an OVS upgrade of the node
+func ParseNodeOVSUpgradeQuiesce(node *kapi.Node) (*OVSUpgradeQuiesce, error) {
+	annotation, ok := node.Annotations[OvnNodeOVSUpgradeQuiesce]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeOVSUpgradeQuiesce, node.Name)
+	}
+	quiesce := &OVSUpgradeQuiesce{}
+	if err := json.Unmarshal([]byte(annotation), quiesce); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeOVSUpgradeQuiesce, annotation, node.Name, err)
+	}
+	if quiesce.ID == "" {
+		return nil, fmt.Errorf("%s annotation %q for node %q must provide the id of the upgrade",
+			OvnNodeOVSUpgradeQuiesce, annotation, node.Name)
+	}
+	if quiesce.Timeout < 0 {
+		return nil, fmt.Errorf("%s annotation %q for node %q: timeout must not be negative",
+			OvnNodeOVSUpgradeQuiesce, annotation, node.Name)
+	}
+	return quiesce, nil
+}
+
+// NodeOVSUpgradeQuiesceAnnotationChanged returns true if the OvnNodeOVSUpgradeQuiesce annotation changed for the node
+func NodeOVSUpgradeQuiesceAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodeOVSUpgradeQuiesce] != newNode.Annotations[OvnNodeOVSUpgradeQuiesce]
+}
+
+// SetNodeOVSUpgradeQuiesceStatus sets the OVS upgrade quiesce status in the "OvnNodeOVSUpgradeQuiesceStatus"
+// node annotation, the annotation is removed when status is nil
+func SetNodeOVSUpgradeQuiesceStatus(nodeAnnotator kube.Annotator, status *OVSUpgradeQuiesceStatus) error {
+	if status == nil {
+		nodeAnnotator.Delete(OvnNodeOVSUpgradeQuiesceStatus)
+		return nil
+	}
+	return nodeAnnotator.Set(OvnNodeOVSUpgradeQuiesceStatus, status)
+}
+
+// ParseNodeOVSUpgradeQuiesceStatus returns the OVS upgrade quiesce status of the node
+func ParseNodeOVSUpgradeQuiesceStatus(node *kapi.Node) (*OVSUpgradeQuiesceStatus, error) {
+	annotation, ok := node.Annotations[OvnNodeOVSUpgradeQuiesceStatus]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeOVSUpgradeQuiesceStatus, node.Name)
+	}
+	status := &OVSUpgradeQuiesceStatus{}
+	if err := json.Unmarshal([]byte(annotation), status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeOVSUpgradeQuiesceStatus, annotation, node.Name, err)
+	}
+	return status, nil
+}
+
+// ChassisInfo is the Southbound database chassis of a node
+type ChassisInfo struct {
+	ChassisID string         `json:"chassis-id"`
+	Hostname  string         `json:"hostname,omitempty"`
+	Encaps    []ChassisEncap `json:"encaps,omitempty"`
+}
+
+// ChassisEncap is a tunnel encapsulation of a chassis
+type ChassisEncap struct {
+	Type string `json:"type"`
+	IP   string `json:"ip"`
+	// Port is the destination port of the tunnels, the default port of the encapsulation when not set
+	Port int `json:"port,omitempty"`
+}
+
+// SetNodeChassisInfo sets the chassis of the node in the "OvnNodeChassisInfo" node annotation
+func SetNodeChassisInfo(nodeAnnotator kube.Annotator, info *ChassisInfo) error {
+	return nodeAnnotator.Set(OvnNodeChassisInfo, info)
+}
+
+// ParseNodeChassisInfo returns the chassis of the node
+func ParseNodeChassisInfo(node *kapi.Node) (*ChassisInfo, error) {
+	annotation, ok := node.Annotations[OvnNodeChassisInfo]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeChassisInfo, node.Name)
+	}
+	info := &ChassisInfo{}
+	if err := json.Unmarshal([]byte(annotation), info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeChassisInfo, annotation, node.Name, err)
+	}
+	return info, nil
+}
+
+// NodeChassisInfoAnnotationChanged returns true if the chassis annotation changed
+func NodeChassisInfoAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodeChassisInfo] != newNode.Annotations[OvnNodeChassisInfo]
+}
+
+// SetNodePodSNATPortRanges sets the SNAT port ranges of the local pods in the "OvnNodePodSNATPortRanges" node
+// annotation
+func SetNodePodSNATPortRanges(nodeAnnotator kube.Annotator, portRanges map[string]string) error {
+	return nodeAnnotator.Set(OvnNodePodSNATPortRanges, portRanges)
+}
+
+// ParseNodePodSNATPortRanges returns the SNAT port ranges of the local pods of the node keyed by pod IP
+func ParseNodePodSNATPortRanges(node *kapi.Node) (map[string]string, error) {
+	annotation, ok := node.Annotations[OvnNodePodSNATPortRanges]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodePodSNATPortRanges, node.Name)
+	}
+	portRanges := map[string]string{}
+	if err := json.Unmarshal([]byte(annotation), &portRanges); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodePodSNATPortRanges, annotation, node.Name, err)
+	}
+	return portRanges, nil
+}
+
+// NodePodSNATPortRangesAnnotationChanged returns true if the pod SNAT port ranges annotation changed
+func NodePodSNATPortRangesAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodePodSNATPortRanges] != newNode.Annotations[OvnNodePodSNATPortRanges]
+}
+
+// ParseNodePauseReconciliation returns whether the "OvnNodePauseReconciliation" node annotation pauses the
+// reconciliation of the host networking of the node
+func ParseNodePauseReconciliation(node *corev1.Node) (bool, error) {
+	annotation, ok := node.Annotations[OvnNodePauseReconciliation]
+	if !ok {
+		return false, nil
+	}
+	paused, err := strconv.ParseBool(annotation)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s annotation %q for node %q: %v",
+			OvnNodePauseReconciliation, annotation, node.Name, err)
+	}
+	return paused, nil
+}
+
+// NodePauseReconciliationAnnotationChanged returns true if the "OvnNodePauseReconciliation" annotation changed
+func NodePauseReconciliationAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodePauseReconciliation] != newNode.Annotations[OvnNodePauseReconciliation]
+}
+
+// ProbeIntervals are the probe intervals of ovn-controller overridden on a node
+type ProbeIntervals struct {
+	// OpenFlowProbe is the ovn-openflow-probe-interval in seconds
+	OpenFlowProbe *int `json:"openflow-probe-interval,omitempty"`
+	// RemoteProbe is the ovn-remote-probe-interval in milliseconds
+	RemoteProbe *int `json:"remote-probe-interval,omitempty"`
+	// BundleIdleTimeout is the OVS bundle-idle-timeout in seconds
+	BundleIdleTimeout *int `json:"bundle-idle-timeout,omitempty"`
+}
+
+// ParseNodeProbeIntervals returns the validated probe intervals overridden on the node
+func ParseNodeProbeIntervals(node *kapi.Node) (*ProbeIntervals, error) {
+	annotation, ok := node.Annotations[OvnNodeProbeIntervals]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeProbeIntervals, node.Name)
+	}
+	intervals := &ProbeIntervals{}
+	if err := json.Unmarshal([]byte(annotation), intervals); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeProbeIntervals, annotation, node.Name, err)
+	}
+	for name, interval := range map[string]*int{
+		"openflow-probe-interval": intervals.OpenFlowProbe,
+		"remote-probe-interval":   intervals.RemoteProbe,
+		"bundle-idle-timeout":     intervals.BundleIdleTimeout,
+	} {
+		if interval != nil && *interval < 0 {
+			return nil, fmt.Errorf("%s annotation %q for node %q: %s must not be negative",
+				OvnNodeProbeIntervals, annotation, node.Name, name)
+		}
+	}
+	return intervals, nil
+}
+
+// ParseNodeEgressRole returns the egress role set on the node, egress or non-egress
+func ParseNodeEgressRole(node *kapi.Node) (string, error) {
+	role, ok := node.Annotations[OvnNodeEgressRole]
+	if !ok {
+		return "", newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeEgressRole, node.Name)
+	}
+	if role != types.NodeEgressRoleEgress && role != types.NodeEgressRoleNonEgress {
+		return "", fmt.Errorf("invalid %s annotation %q for node %q, must be %s or %s", OvnNodeEgressRole, role,
+			node.Name, types.NodeEgressRoleEgress, types.NodeEgressRoleNonEgress)
+	}
+	return role, nil
+}
+
+// ParseNodeIPExclude returns the networks and interfaces excluded from the encap IP and gateway interface
+// autodetection on the node
+func ParseNodeIPExclude(node *kapi.Node) (config.NodeIPExclusions, error) {
+	raw, ok := node.Annotations[OvnNodeIPExclude]
+	if !ok {
+		return config.NodeIPExclusions{}, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeIPExclude, node.Name)
+	}
+	exclusions, err := config.ParseNodeIPExclusions(raw)
+	if err != nil {
+		return config.NodeIPExclusions{}, fmt.Errorf("invalid %s annotation %q for node %q: %v", OvnNodeIPExclude, raw,
+			node.Name, err)
+	}
+	return exclusions, nil
+}
+
+// ObsoleteNodeAnnotations are the node annotations written by former versions of ovn-kubernetes, by the first
+// version none of the components reads them; they are kept on the nodes running an older version. An annotation
+// is only added here once the components of the versions the nodes may be upgraded from do not read it anymore.
+// ovnkube-node prunes them from its node, the node admission webhook allows it to remove them and nothing else.
+var ObsoleteNodeAnnotations = map[string][]string{
+	"1.0.0": {
+		types.OvnK8sTopoAnno,
+		"k8s.ovn.org/node-join-subnets",
+		"k8s.ovn.org/node-local-nat-ip",
+		"k8s.ovn.org/node-mgmt-port-mac-address",
+	},
+}
+
+// NodeRefusesEgress returns true when ovnkube-node labeled the node as not running the egress controllers
+func NodeRefusesEgress(node *kapi.Node) bool {
+	return node.Labels[OvnNodeEgressReadyLabel] == "false"
+}
+
+// ParseNodeExternalSubnetClaims returns the subnets claimed on the node by the agents of other networks,
+// keyed by agent
+func ParseNodeExternalSubnetClaims(node *kapi.Node) (map[string][]*net.IPNet, error) {
+	annotation, ok := node.Annotations[OvnNodeExternalSubnetClaims]
+	if !ok {
+		return nil, newAnnotationNotSetError("%s annotation not found for node %q", OvnNodeExternalSubnetClaims, node.Name)
+	}
+	rawClaims := map[string][]string{}
+	if err := json.Unmarshal([]byte(annotation), &rawClaims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation %q for node %q: %v",
+			OvnNodeExternalSubnetClaims, annotation, node.Name, err)
+	}
+	claims := make(map[string][]*net.IPNet, len(rawClaims))
+	for agent, rawSubnets := range rawClaims {
+		if agent == "" {
+			return nil, fmt.Errorf("%s annotation %q for node %q: empty agent name", OvnNodeExternalSubnetClaims,
+				annotation, node.Name)
+		}
+		subnets := make([]*net.IPNet, 0, len(rawSubnets))
+		for _, rawSubnet := range rawSubnets {
+			_, subnet, err := net.ParseCIDR(rawSubnet)
+			if err != nil {
+				return nil, fmt.Errorf("%s annotation %q for node %q: invalid subnet of agent %s: %v",
+					OvnNodeExternalSubnetClaims, annotation, node.Name, agent, err)
+			}
+			subnets = append(subnets, subnet)
+		}
+		claims[agent] = subnets
+	}
+	return claims, nil
+}
+
+// NodeExternalSubnetClaimsAnnotationChanged returns true if the OvnNodeExternalSubnetClaims annotation changed
+// for the node
+func NodeExternalSubnetClaimsAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodeExternalSubnetClaims] != newNode.Annotations[OvnNodeExternalSubnetClaims]
+}
+
+// NodeProbeIntervalsAnnotationChanged returns true if the OvnNodeProbeIntervals annotation changed for the node
+func NodeProbeIntervalsAnnotationChanged(oldNode, newNode *corev1.Node) bool {
+	return oldNode.Annotations[OvnNodeProbeIntervals] != newNode.Annotations[OvnNodeProbeIntervals]
+}
+
 // SetNodeEncapIp sets the node's encap-ip in the "OvnNodeEncapIp" node annotation.
 func SetNodeEncapIp(nodeAnnotator kube.Annotator, ip string) (err error) {
 	return nodeAnnotator.Set(OvnNodeEncapIp, ip)
@@ -1306,6 +1792,33 @@ func GetNodeEncapIp(node *kapi.Node) (string, error) {
 	return ip, nil
 }
 
+// ParseEncapIPs parses encap IPs given as a single IP or a comma separated list of IPs, e.g. one IPv4 and
+// one IPv6 tunnel endpoint in dual-stack clusters
+func ParseEncapIPs(encapIP string) ([]net.IP, error) {
+	var ips []net.IP
+	for _, ipStr := range strings.Split(encapIP, ",") {
+		ip := net.ParseIP(strings.TrimSpace(ipStr))
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q in encap IP %q", ipStr, encapIP)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// ParseNodeEncapIPs returns the encap IPs of the node stored in the "OvnNodeEncapIp" node annotation
+func ParseNodeEncapIPs(node *kapi.Node) ([]net.IP, error) {
+	encapIP, err := GetNodeEncapIp(node)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := ParseEncapIPs(encapIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the encap IP annotation of node %q: %w", node.Name, err)
+	}
+	return ips, nil
+}
+
 // NodeEncapIpAnnotationChanged returns true if the OvnNodeEncapIp in the corev1.Nodes doesn't match.
 func NodeEncapIpAnnotationChanged(oldNode, newNode *corev1.Node) bool {
 	return oldNode.Annotations[OvnNodeEncapIp] != newNode.Annotations[OvnNodeEncapIp]
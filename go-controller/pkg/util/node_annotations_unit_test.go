@@ -826,3 +826,44 @@ func TestGetNetworkID(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNodeEncapIPs(t *testing.T) {
+	tests := []struct {
+		desc          string
+		annotations   map[string]string
+		expectedIPs   []net.IP
+		expectedError string
+	}{
+		{
+			desc:          "without the encap IP annotation should return an error",
+			expectedError: "OVN Encap IP annotation not found",
+		},
+		{
+			desc:        "with a single encap IP should return it",
+			annotations: map[string]string{OvnNodeEncapIp: "10.0.0.1"},
+			expectedIPs: []net.IP{ovntest.MustParseIP("10.0.0.1")},
+		},
+		{
+			desc:        "with an encap IP per IP family should return both",
+			annotations: map[string]string{OvnNodeEncapIp: "10.0.0.1, fd00::1"},
+			expectedIPs: []net.IP{ovntest.MustParseIP("10.0.0.1"), ovntest.MustParseIP("fd00::1")},
+		},
+		{
+			desc:          "with an invalid encap IP should return an error",
+			annotations:   map[string]string{OvnNodeEncapIp: "10.0.0.1,fd00::zz"},
+			expectedError: `invalid IP address "fd00::zz" in encap IP "10.0.0.1,fd00::zz"`,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: tc.annotations}}
+			ips, err := ParseNodeEncapIPs(node)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedIPs, ips)
+		})
+	}
+}
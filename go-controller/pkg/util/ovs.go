@@ -38,6 +38,9 @@ const (
 	netshCommand       = "netsh"
 	routeCommand       = "route"
 	sysctlCommand      = "sysctl"
+	nftCommand         = "nft"
+	joolCommand        = "jool"
+	modprobeCommand    = "modprobe"
 	osRelease          = "/etc/os-release"
 	rhel               = "RHEL"
 	ubuntu             = "Ubuntu"
@@ -127,6 +130,9 @@ type execHelper struct {
 	netshPath       string
 	routePath       string
 	sysctlPath      string
+	nftPath         string
+	joolPath        string
+	modprobePath    string
 }
 
 var runner *execHelper
@@ -253,6 +259,12 @@ func SetExecWithoutOVS(exec kexec.Interface) error {
 		if err != nil {
 			return err
 		}
+		// nft is only needed by optional features, RunNFT fails when it is missing
+		runner.nftPath, _ = exec.LookPath(nftCommand)
+		// jool is only needed by the gateway NAT64, RunJool fails when it is missing
+		runner.joolPath, _ = exec.LookPath(joolCommand)
+		// modprobe is only needed to load the missing kernel modules, RunModprobe fails when it is missing
+		runner.modprobePath, _ = exec.LookPath(modprobeCommand)
 	}
 	return nil
 }
@@ -614,9 +626,24 @@ func GetOvsDBServerPID() (string, error) {
 	return strings.TrimSpace(string(pid)), nil
 }
 
-// RunIP runs a command via the iproute2 "ip" utility
+// GetOvnControllerPID retrieves the Process IDentifier for ovn-controller daemon.
+func GetOvnControllerPID() (string, error) {
+	pid, err := afero.ReadFile(AppFs, runner.ovnRunDir+"ovn-controller.pid")
+	if err != nil {
+		return "", fmt.Errorf("failed to get ovn-controller pid : %v", err)
+	}
+
+	return strings.TrimSpace(string(pid)), nil
+}
+
+// RunIP runs a command via the iproute2 "ip" utility in the network namespace of the node
 func RunIP(args ...string) (string, string, error) {
-	stdout, stderr, err := run(runner.ipPath, args...)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	err := DoInNodeNetNS(func() error {
+		var err error
+		stdout, stderr, err = run(runner.ipPath, args...)
+		return err
+	})
 	return strings.TrimSpace(stdout.String()), stderr.String(), err
 }
 
@@ -626,6 +653,33 @@ func RunSysctl(args ...string) (string, string, error) {
 	return strings.TrimSpace(stdout.String()), stderr.String(), err
 }
 
+// RunNFT runs a command via the nftables "nft" utility
+func RunNFT(args ...string) (string, string, error) {
+	if runner.nftPath == "" {
+		return "", "", fmt.Errorf("%s not found in the path", nftCommand)
+	}
+	stdout, stderr, err := run(runner.nftPath, args...)
+	return strings.TrimSpace(stdout.String()), stderr.String(), err
+}
+
+// RunJool runs a command via the "jool" NAT64 utility
+func RunJool(args ...string) (string, string, error) {
+	if runner.joolPath == "" {
+		return "", "", fmt.Errorf("%s not found in the path", joolCommand)
+	}
+	stdout, stderr, err := run(runner.joolPath, args...)
+	return strings.TrimSpace(stdout.String()), stderr.String(), err
+}
+
+// RunModprobe runs a command via the kmod "modprobe" utility
+func RunModprobe(args ...string) (string, string, error) {
+	if runner.modprobePath == "" {
+		return "", "", fmt.Errorf("%s not found in the path", modprobeCommand)
+	}
+	stdout, stderr, err := run(runner.modprobePath, args...)
+	return strings.TrimSpace(stdout.String()), stderr.String(), err
+}
+
 // RunPowershell runs a command via the Windows powershell utility
 func RunPowershell(args ...string) (string, string, error) {
 	stdout, stderr, err := run(runner.powershellPath, args...)
@@ -836,6 +890,23 @@ func IsOvsHwOffloadEnabled() (bool, error) {
 	return true, nil
 }
 
+// GetOVNBridgeMappings returns the physical network to bridge mappings of the ovn-bridge-mappings
+// of the Open_vSwitch external_ids
+func GetOVNBridgeMappings() (map[string]string, error) {
+	stdout, stderr, err := RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:ovn-bridge-mappings")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+	}
+	bridgeMappings := map[string]string{}
+	for _, bridgeMapping := range strings.Split(stdout, ",") {
+		if physNet, bridge, found := strings.Cut(bridgeMapping, ":"); found {
+			bridgeMappings[physNet] = bridge
+		}
+	}
+	return bridgeMappings, nil
+}
+
 type OvsDbProperties struct {
 	AppCtl        func(timeout int, args ...string) (string, string, error)
 	DbAlias       string
@@ -137,6 +137,9 @@ func GetFunctionRepresentorName(deviceID string) (string, error) {
 			return "", err
 		}
 		rep, err = GetSriovnetOps().GetVfRepresentor(uplink, index)
+		if err != nil {
+			rep, err = getVFLAGRepresentor(uplink, index, err)
+		}
 	} else if IsAuxDeviceName(deviceID) { // Auxiliary device
 		uplink, err = GetSriovnetOps().GetUplinkRepresentorFromAux(deviceID)
 		if err != nil {
@@ -156,6 +159,65 @@ func GetFunctionRepresentorName(deviceID string) (string, error) {
 	return rep, nil
 }
 
+// GetVFLAGBond returns the bond the uplink representor is enslaved to when the PFs of the NIC are bonded
+// (VF-LAG), along with the uplink representors of all the PFs of the bond. The bond name is empty when the
+// uplink representor is not bonded.
+func GetVFLAGBond(uplink string) (string, []string, error) {
+	link, err := GetNetLinkOps().LinkByName(uplink)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get uplink representor %s: %w", uplink, err)
+	}
+	if link.Attrs().MasterIndex == 0 {
+		return "", nil, nil
+	}
+	master, err := GetNetLinkOps().LinkByIndex(link.Attrs().MasterIndex)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get the master of uplink representor %s: %w", uplink, err)
+	}
+	// the uplink representor of a PF that is not bonded is enslaved to the OVS datapath
+	if master.Type() != "bond" {
+		return "", nil, nil
+	}
+	links, err := GetNetLinkOps().LinkList()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list the links: %w", err)
+	}
+	var uplinks []string
+	for _, l := range links {
+		if l.Attrs().MasterIndex == master.Attrs().Index {
+			uplinks = append(uplinks, l.Attrs().Name)
+		}
+	}
+	return master.Attrs().Name, uplinks, nil
+}
+
+// getVFLAGRepresentor looks up the representor of the VF with the given index through the other PFs of the
+// bond of the uplink representor: with VF-LAG the representors of the VFs of all the PFs are created on the
+// eswitch of the active PF. The error of the lookup through the uplink of the VF is returned when the uplink
+// is not bonded or the representor is not found through any PF of the bond.
+func getVFLAGRepresentor(uplink string, vfIndex int, repErr error) (string, error) {
+	bond, uplinks, err := GetVFLAGBond(uplink)
+	if err != nil {
+		klog.V(5).Infof("Failed to check if uplink representor %s is bonded: %v", uplink, err)
+		return "", repErr
+	}
+	if bond == "" {
+		return "", repErr
+	}
+	for _, bondUplink := range uplinks {
+		if bondUplink == uplink {
+			continue
+		}
+		rep, err := GetSriovnetOps().GetVfRepresentor(bondUplink, vfIndex)
+		if err == nil {
+			klog.Infof("Found representor %s of VF %d of uplink %s through uplink %s of bond %s",
+				rep, vfIndex, uplink, bondUplink, bond)
+			return rep, nil
+		}
+	}
+	return "", repErr
+}
+
 // GetNetdevNameFromDeviceId returns the netdevice name from the passed device ID.
 func GetNetdevNameFromDeviceId(deviceId string, deviceInfo nadapi.DeviceInfo) (string, error) {
 	var netdevices []string
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/vishvananda/netlink"
+
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 )
@@ -105,6 +107,9 @@ func TestIsAuxDeviceName(t *testing.T) {
 func TestGetFunctionRepresentorName(t *testing.T) {
 	mockSriovnetOps := mocks.NewSriovnetOps(t)
 	SetSriovnetOpsInst(mockSriovnetOps)
+	mockNetLinkOps := new(mocks.NetLinkOps)
+	SetNetLinkOpMockInst(mockNetLinkOps)
+	defer ResetNetLinkOpMockInst()
 
 	mockUplErr := fmt.Errorf("mock failed to get uplink representor")
 	mockIdxErr := fmt.Errorf("mock failed to get index")
@@ -115,6 +120,7 @@ func TestGetFunctionRepresentorName(t *testing.T) {
 		expVal         string
 		expErr         error
 		sriovOpsHelper []ovntest.TestifyMockHelper
+		netLinkHelper  []ovntest.TestifyMockHelper
 	}{
 		{
 			desc:     "PCI: success",
@@ -156,6 +162,30 @@ func TestGetFunctionRepresentorName(t *testing.T) {
 				{OnCallMethodName: "GetVfIndexByPciAddress", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{1, nil}},
 				{OnCallMethodName: "GetVfRepresentor", OnCallMethodArgType: []string{"string", "int"}, RetArgList: []interface{}{"", mockRepErr}},
 			},
+			netLinkHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "ens0"}}, nil}},
+			},
+		},
+		{
+			desc:     "PCI: VF-LAG representor found through the other PF of the bond",
+			deviceID: "0000:00:00.5",
+			expVal:   "eno2",
+			expErr:   nil,
+			sriovOpsHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "GetUplinkRepresentor", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{"ens1", nil}},
+				{OnCallMethodName: "GetVfIndexByPciAddress", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{1, nil}},
+				{OnCallMethodName: "GetVfRepresentor", OnCallMethodArgType: []string{"string", "int"}, RetArgList: []interface{}{"", mockRepErr}},
+				{OnCallMethodName: "GetVfRepresentor", OnCallMethodArgType: []string{"string", "int"}, RetArgList: []interface{}{"eno2", nil}},
+			},
+			netLinkHelper: []ovntest.TestifyMockHelper{
+				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "ens1", MasterIndex: 10}}, nil}},
+				{OnCallMethodName: "LinkByIndex", OnCallMethodArgType: []string{"int"}, RetArgList: []interface{}{&netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 10}}, nil}},
+				{OnCallMethodName: "LinkList", RetArgList: []interface{}{[]netlink.Link{
+					&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "ens0", MasterIndex: 10}},
+					&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "ens1", MasterIndex: 10}},
+					&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eno2"}},
+				}, nil}},
+			},
 		},
 		{
 			desc:     "Auxiliary: success",
@@ -203,6 +233,7 @@ func TestGetFunctionRepresentorName(t *testing.T) {
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockSriovnetOps.Mock, tc.sriovOpsHelper)
+			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.netLinkHelper)
 
 			ret, err := GetFunctionRepresentorName(tc.deviceID)
 			if tc.expVal != ret {
@@ -217,6 +248,7 @@ func TestGetFunctionRepresentorName(t *testing.T) {
 			}
 
 			mockSriovnetOps.AssertExpectations(t)
+			mockNetLinkOps.AssertExpectations(t)
 		})
 	}
 }
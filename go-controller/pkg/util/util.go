@@ -230,6 +230,12 @@ func GetNodeChassisID() (string, error) {
 	return chassisID, nil
 }
 
+// IsLocalOVNAvailable returns false when the node has no local ovn-controller connected to a
+// Southbound database, i.e. in DPU host mode or with the no local OVN profile
+func IsLocalOVNAvailable() bool {
+	return config.OvnKubeNode.Mode != types.NodeModeDPUHost && !config.OvnKubeNode.NoLocalOVN
+}
+
 // GetHybridOverlayPortName returns the name of the hybrid overlay switch port
 // for a given node
 func GetHybridOverlayPortName(nodeName string) string {